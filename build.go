@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi"
+)
+
+// buildCommand defines the subcommand used to generate self-contained provider binaries:
+// terraform-provider-openapi build --provider-name foo --spec ./foo.yaml
+const buildCommand = "build"
+
+var providerNameRegex = regexp.MustCompile("^[a-z0-9]+$")
+
+// runBuildCommand generates a terraform-provider-{name} binary with the OpenAPI document (or its URL) embedded, so the
+// provider can be distributed without relying on the binary name and the OTF_VAR_{name}_SWAGGER_URL/plugin configuration
+// file runtime discovery
+func runBuildCommand(args []string) error {
+	flags := flag.NewFlagSet(buildCommand, flag.ContinueOnError)
+	providerName := flags.String("provider-name", "", "[required] name of the provider, the binary will be named terraform-provider-{name}")
+	spec := flags.String("spec", "", "[required] path to the OpenAPI document to embed in the binary or URL where the OpenAPI document is exposed")
+	specFormat := flags.String("spec-format", "", "spec format of the OpenAPI document. If not specified, it will be detected")
+	providerVersion := flags.String("provider-version", "", "version of the provider, if specified the binary will be named terraform-provider-{name}_v{version}")
+	outputDir := flags.String("output-dir", ".", "directory where the provider binary will be generated")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	sourceBinary, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("error getting the OpenAPI Terraform provider binary path: %s", err)
+	}
+	outputPath, err := buildProvider(sourceBinary, *providerName, *spec, *specFormat, *providerVersion, *outputDir)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Provider binary successfully generated: %s", outputPath)
+	return nil
+}
+
+// buildProvider generates the provider binary copying the source binary and embedding the spec. If the spec is a URL, only
+// the URL is embedded and the OpenAPI document will be retrieved at runtime. Local OpenAPI documents are validated before
+// being embedded
+func buildProvider(sourceBinary, providerName, spec, specFormat, providerVersion, outputDir string) (string, error) {
+	if !providerNameRegex.MatchString(providerName) {
+		return "", fmt.Errorf("provider name '%s' not valid, the provider name must only contain lower case letters and numbers", providerName)
+	}
+	if spec == "" {
+		return "", errors.New("the spec must be specified, please provide the path to the OpenAPI document or the URL where it is exposed")
+	}
+	embeddedConfiguration := openapi.EmbeddedConfiguration{ProviderName: providerName, SpecFormat: specFormat, ProviderVersion: providerVersion}
+	if strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://") {
+		embeddedConfiguration.SwaggerURL = spec
+	} else {
+		if _, err := openapi.CreateSpecAnalyser(openapi.SpecAnalyserVersion(specFormat), spec); err != nil {
+			return "", fmt.Errorf("the OpenAPI document '%s' is not valid: %s", spec, err)
+		}
+		content, err := ioutil.ReadFile(spec) // #nosec G304
+		if err != nil {
+			return "", fmt.Errorf("failed to read the OpenAPI document '%s': %s", spec, err)
+		}
+		embeddedConfiguration.Spec = content
+	}
+	binaryName := fmt.Sprintf("terraform-provider-%s", providerName)
+	if providerVersion != "" {
+		binaryName = fmt.Sprintf("%s_v%s", binaryName, providerVersion)
+	}
+	if filepath.Ext(sourceBinary) == ".exe" {
+		binaryName += ".exe"
+	}
+	outputPath := filepath.Join(outputDir, binaryName)
+	if err := openapi.BuildProviderBinary(sourceBinary, outputPath, embeddedConfiguration); err != nil {
+		return "", err
+	}
+	return outputPath, nil
+}
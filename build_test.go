@@ -0,0 +1,100 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const buildTestSpec = `swagger: "2.0"
+paths:
+  /v1/cdns:`
+
+func TestBuildProvider(t *testing.T) {
+	Convey("Given a source binary, a valid provider name and a local OpenAPI document", t, func() {
+		dir, err := ioutil.TempDir("", "build")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		sourceBinary := filepath.Join(dir, "terraform-provider-openapi")
+		So(ioutil.WriteFile(sourceBinary, []byte("some binary content"), 0600), ShouldBeNil)
+		spec := filepath.Join(dir, "foo.yaml")
+		So(ioutil.WriteFile(spec, []byte(buildTestSpec), 0600), ShouldBeNil)
+		Convey("When buildProvider method is called", func() {
+			outputPath, err := buildProvider(sourceBinary, "foo", spec, "", "1.0.0", dir)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the binary should be named after the provider name and version", func() {
+				So(outputPath, ShouldEqual, filepath.Join(dir, "terraform-provider-foo_v1.0.0"))
+			})
+			Convey("And the binary should contain the embedded OpenAPI document", func() {
+				embeddedConfiguration, err := openapi.ReadEmbeddedConfiguration(outputPath)
+				So(err, ShouldBeNil)
+				So(embeddedConfiguration.ProviderName, ShouldEqual, "foo")
+				So(string(embeddedConfiguration.Spec), ShouldEqual, buildTestSpec)
+				So(embeddedConfiguration.SwaggerURL, ShouldBeEmpty)
+				So(embeddedConfiguration.ProviderVersion, ShouldEqual, "1.0.0")
+			})
+			Convey("And the provider should be initialised with the embedded configuration", func() {
+				embeddedConfiguration, err := openapi.ReadEmbeddedConfiguration(outputPath)
+				So(err, ShouldBeNil)
+				provider, err := initProvider("some-invalid-binary-name", embeddedConfiguration)
+				So(err, ShouldBeNil)
+				So(provider, ShouldNotBeNil)
+			})
+		})
+	})
+	Convey("Given a source binary and an OpenAPI document URL", t, func() {
+		dir, err := ioutil.TempDir("", "build")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		sourceBinary := filepath.Join(dir, "terraform-provider-openapi")
+		So(ioutil.WriteFile(sourceBinary, []byte("some binary content"), 0600), ShouldBeNil)
+		Convey("When buildProvider method is called", func() {
+			outputPath, err := buildProvider(sourceBinary, "foo", "https://api.foo.com/swagger.yaml", "", "", dir)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the binary should contain the embedded OpenAPI document URL", func() {
+				So(outputPath, ShouldEqual, filepath.Join(dir, "terraform-provider-foo"))
+				embeddedConfiguration, err := openapi.ReadEmbeddedConfiguration(outputPath)
+				So(err, ShouldBeNil)
+				So(embeddedConfiguration.SwaggerURL, ShouldEqual, "https://api.foo.com/swagger.yaml")
+				So(embeddedConfiguration.Spec, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given an invalid provider name", t, func() {
+		Convey("When buildProvider method is called", func() {
+			_, err := buildProvider("terraform-provider-openapi", "Foo_Bar", "./foo.yaml", "", "", ".")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "provider name 'Foo_Bar' not valid, the provider name must only contain lower case letters and numbers")
+			})
+		})
+	})
+	Convey("Given a missing spec", t, func() {
+		Convey("When buildProvider method is called", func() {
+			_, err := buildProvider("terraform-provider-openapi", "foo", "", "", "", ".")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "the spec must be specified, please provide the path to the OpenAPI document or the URL where it is exposed")
+			})
+		})
+	})
+	Convey("Given an invalid local OpenAPI document", t, func() {
+		dir, err := ioutil.TempDir("", "build")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		spec := filepath.Join(dir, "foo.yaml")
+		So(ioutil.WriteFile(spec, []byte("some non valid open api document"), 0600), ShouldBeNil)
+		Convey("When buildProvider method is called", func() {
+			_, err := buildProvider("terraform-provider-openapi", "foo", spec, "", "", dir)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldStartWith, "the OpenAPI document '"+spec+"' is not valid")
+			})
+		})
+	})
+}
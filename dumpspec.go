@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi"
+)
+
+// dumpSpecCommand defines the subcommand used to print the internal model the OpenAPI document is translated into, so
+// extension authors can check how the x-terraform extensions were interpreted (e,g: why a property is computed):
+// terraform-provider-openapi dump-spec --swagger-url ./swagger.yaml --resources cdns_v1
+const dumpSpecCommand = "dump-spec"
+
+// runDumpSpecCommand writes the internal model of the resources and data sources of the OpenAPI document in JSON format
+func runDumpSpecCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(dumpSpecCommand, flag.ContinueOnError)
+	swaggerURL := flags.String("swagger-url", "", "[required] URL or path to the OpenAPI document to dump")
+	resources := flags.String("resources", "", "comma separated list of the resources and data sources to dump (e,g: cdns_v1). If not specified, all of them are dumped")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *swaggerURL == "" {
+		return fmt.Errorf("the swagger-url flag is required")
+	}
+	var names []string
+	if *resources != "" {
+		names = strings.Split(*resources, ",")
+	}
+	specModel, err := openapi.ExportSpecModelJSON(*swaggerURL, names)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(specModel))
+	return err
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDumpSpecCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dumpspec")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	swaggerFile := filepath.Join(dir, "swagger.yaml")
+	swagger := `swagger: "2.0"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true`
+	require.NoError(t, ioutil.WriteFile(swaggerFile, []byte(swagger), 0600))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, runDumpSpecCommand([]string{"--swagger-url", swaggerFile, "--resources", "cdns_v1"}, out))
+	specModel := map[string][]map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &specModel))
+	require.Len(t, specModel["resources"], 1)
+	assert.Equal(t, "cdns_v1", specModel["resources"][0]["name"])
+}
+
+func TestRunDumpSpecCommandMissingSwaggerURL(t *testing.T) {
+	err := runDumpSpecCommand([]string{"--resources", "cdns_v1"}, &bytes.Buffer{})
+	assert.EqualError(t, err, "the swagger-url flag is required")
+}
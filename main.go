@@ -9,9 +9,11 @@ import (
 	"fmt"
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi"
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/version"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"os"
 	"regexp"
+	"strconv"
 )
 
 // Source addresses consist of three parts delimited by slashes (/), as follows: [<HOSTNAME>/]<NAMESPACE>/<TYPE>
@@ -21,12 +23,41 @@ import (
 
 var otfProviderSourceAddressVar = "OTF_PROVIDER_SOURCE_ADDRESS"
 
+// otfDevModeVar enables the dev mode where the provider watches the local OpenAPI document and rebuilds the provider schemas
+// when the document changes
+var otfDevModeVar = "OTF_DEV_MODE"
+
 func main() {
 
 	log.Printf("Running OpenAPI Terraform Provider v%s-%s; Released on: %s", version.Version, version.Commit, version.Date)
 
+	if len(os.Args) > 1 && os.Args[1] == buildCommand {
+		if err := runBuildCommand(os.Args[2:]); err != nil {
+			log.Fatalf("[ERROR] %s", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == smokeTestCommand {
+		if err := runSmokeTestCommand(os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("[ERROR] %s", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == dumpSpecCommand {
+		if err := runDumpSpecCommand(os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("[ERROR] %s", err)
+		}
+		return
+	}
+
 	var debugMode bool
+	var schemaJSON bool
+	var embeddedProviderName bool
 	flag.BoolVar(&debugMode, "debuggable", false, "set to true to run the provider with support for debuggers like delve")
+	flag.BoolVar(&schemaJSON, "schema-json", false, "set to true to print the provider schema in 'terraform providers schema -json' format and exit")
+	flag.BoolVar(&embeddedProviderName, "embedded-provider-name", false, "set to true to print the provider name of the embedded configuration (empty if the binary does not have one) and exit")
 	flag.Parse()
 
 	binaryName, err := os.Executable()
@@ -35,11 +66,40 @@ func main() {
 		log.Fatalf("[ERROR] There was an error when getting the provider binary name: %s", err)
 	}
 
-	provider, err := initProvider(binaryName)
+	embeddedConfiguration, err := openapi.ReadEmbeddedConfiguration(binaryName)
+	if err != nil {
+		log.Fatalf("[ERROR] There was an error when reading the provider binary embedded configuration: %s", err)
+	}
+
+	if embeddedProviderName {
+		fmt.Println(getEmbeddedProviderName(embeddedConfiguration))
+		return
+	}
+
+	if devMode, _ := strconv.ParseBool(os.Getenv(otfDevModeVar)); devMode && !schemaJSON {
+		if embeddedConfiguration != nil {
+			log.Fatalf("[ERROR] %s is not supported by provider binaries with an embedded configuration", otfDevModeVar)
+		}
+		if err := serveDevMode(binaryName, debugMode); err != nil {
+			log.Fatalf("[ERROR] %s", err)
+		}
+		return
+	}
+
+	provider, err := initProvider(binaryName, embeddedConfiguration)
 	if err != nil {
 		log.Fatalf("[ERROR] %s", err)
 	}
 
+	if schemaJSON {
+		b, err := exportProviderSchema(binaryName, provider)
+		if err != nil {
+			log.Fatalf("[ERROR] %s", err)
+		}
+		fmt.Println(string(b))
+		return
+	}
+
 	if debugMode {
 		// A provider's source address is its global identifier. It also specifies the primary location where Terraform can download it.
 		// The value of this variable must match the source value provided in the terraform's configuration. For instance,
@@ -79,7 +139,18 @@ func main() {
 	}
 }
 
-func initProvider(binaryName string) (*schema.Provider, error) {
+// initProvider creates the provider. If the binary was built with an embedded configuration (see build command), the provider
+// name and the OpenAPI document are taken from it; otherwise they are discovered at runtime based on the binary name
+func initProvider(binaryName string, embeddedConfiguration *openapi.EmbeddedConfiguration) (*schema.Provider, error) {
+	if embeddedConfiguration != nil {
+		log.Printf("[INFO] Initializing '%s' provider with embedded configuration", embeddedConfiguration.ProviderName)
+		p := openapi.ProviderOpenAPI{ProviderName: embeddedConfiguration.ProviderName}
+		provider, err := p.CreateSchemaProviderFromEmbeddedConfiguration(embeddedConfiguration)
+		if err != nil {
+			return nil, fmt.Errorf("error initialising the terraform provider: %s", err)
+		}
+		return provider, nil
+	}
 	providerName, err := getProviderName(binaryName)
 	if err != nil {
 		return nil, fmt.Errorf("error getting the provider's name from the binary '%s': %s", binaryName, err)
@@ -93,8 +164,69 @@ func initProvider(binaryName string) (*schema.Provider, error) {
 	return provider, nil
 }
 
+// serveDevMode serves the provider in dev mode, the provider schemas are rebuilt whenever the local OpenAPI document changes
+// so spec authors can iterate on the document without restarting the provider (e,g: when running with -debuggable)
+func serveDevMode(binaryName string, debugMode bool) error {
+	providerName, err := getProviderName(binaryName)
+	if err != nil {
+		return fmt.Errorf("error getting the provider's name from the binary '%s': %s", binaryName, err)
+	}
+	log.Printf("[INFO] Initializing '%s' provider in dev mode", providerName)
+	p := openapi.ProviderOpenAPI{ProviderName: providerName}
+	providerServer, err := p.CreateDevModeProviderServer(context.Background())
+	if err != nil {
+		return fmt.Errorf("error initialising the terraform provider in dev mode: %s", err)
+	}
+	serveOpts := &plugin.ServeOpts{
+		GRPCProviderFunc: func() tfprotov5.ProviderServer {
+			return providerServer
+		},
+	}
+	if debugMode {
+		providerSourceAddress := os.Getenv(otfProviderSourceAddressVar)
+		if providerSourceAddress == "" {
+			return fmt.Errorf("could not start the provider '%s' in debug mode due to missing required environment variable %s", binaryName, otfProviderSourceAddressVar)
+		}
+		return plugin.Debug(context.Background(), providerSourceAddress, serveOpts)
+	}
+	plugin.Serve(serveOpts)
+	return nil
+}
+
+// exportProviderSchema returns the provider schema in 'terraform providers schema -json' format. The schema is keyed by the
+// provider source address configured in the OTF_PROVIDER_SOURCE_ADDRESS environment variable or, if not set, by the address
+// Terraform would infer for the provider name (registry.terraform.io/hashicorp/{name})
+func exportProviderSchema(binaryName string, provider *schema.Provider) ([]byte, error) {
+	providerSourceAddress := os.Getenv(otfProviderSourceAddressVar)
+	if providerSourceAddress == "" {
+		providerName, err := getProviderName(binaryName)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the provider's name from the binary '%s': %s", binaryName, err)
+		}
+		providerSourceAddress = fmt.Sprintf("registry.terraform.io/hashicorp/%s", providerName)
+	}
+	b, err := openapi.ExportProviderSchemaJSON(provider, providerSourceAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error exporting the provider schema: %s", err)
+	}
+	return b, nil
+}
+
+// getEmbeddedProviderName returns the provider name of the embedded configuration or an empty string if the binary was not
+// built with an embedded configuration. This enables install tooling to verify the binary matches the provider name it is
+// being installed as
+func getEmbeddedProviderName(embeddedConfiguration *openapi.EmbeddedConfiguration) string {
+	if embeddedConfiguration == nil {
+		return ""
+	}
+	return embeddedConfiguration.ProviderName
+}
+
+// getProviderName returns the provider name from the binary name (terraform-provider-{name}), which may be a Unix or
+// Windows path to the binary. The naming convention prefix, version and the Windows executable suffix (.exe) are matched
+// case-insensitively since file names are case-insensitive on Windows
 func getProviderName(binaryName string) (string, error) {
-	r, err := regexp.Compile("\\bterraform-provider-([a-zA-Z0-9]+)(?:_v[\\d]+\\.[\\d]+\\.[\\d]+)?\\b$")
+	r, err := regexp.Compile("(?i)\\bterraform-provider-([a-z0-9]+)(?:_v[\\d]+\\.[\\d]+\\.[\\d]+)?(?:\\.exe)?$")
 	if err != nil {
 		return "", err
 	}
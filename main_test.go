@@ -6,7 +6,10 @@ import (
 	"os"
 	"testing"
 
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestInitProvider(t *testing.T) {
@@ -21,7 +24,7 @@ paths:
   /v1/cdns:`))
 		os.Setenv("OTF_VAR_openapi_SWAGGER_URL", file.Name())
 		Convey("When initProvider method is called", func() {
-			providerName, err := initProvider(binaryName)
+			providerName, err := initProvider(binaryName, nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -34,7 +37,7 @@ paths:
 	Convey("Given an invalid binary name", t, func() {
 		binaryName := "some-invalid-binary-name"
 		Convey("When initProvider method is called", func() {
-			providerName, err := initProvider(binaryName)
+			providerName, err := initProvider(binaryName, nil)
 			Convey("Then the error returned should be the expected one", func() {
 				So(err.Error(), ShouldEqual, "error getting the provider's name from the binary 'some-invalid-binary-name': provider binary name (some-invalid-binary-name) does not match terraform naming convention 'terraform-provider-{name}', please rename the provider binary")
 			})
@@ -52,7 +55,7 @@ paths:
 		file.Write([]byte(`some non valid open api document`))
 		os.Setenv("OTF_VAR_openapi_SWAGGER_URL", file.Name())
 		Convey("When initProvider method is called", func() {
-			providerName, err := initProvider(binaryName)
+			providerName, err := initProvider(binaryName, nil)
 			Convey("Then the error returned should be the expected one", func() {
 				So(err.Error(), ShouldContainSubstring, "error initialising the terraform provider: plugin OpenAPI spec analyser error: failed to retrieve the OpenAPI document")
 				So(err.Error(), ShouldContainSubstring, "error = analyzed: yaml: unmarshal errors:\n  line 1: cannot unmarshal !!str `some no...` into map[interface {}]interface {}")
@@ -64,6 +67,37 @@ paths:
 	})
 }
 
+func TestExportProviderSchema(t *testing.T) {
+	Convey("Given a provider and the binary name", t, func() {
+		binaryName := "terraform-provider-openapi"
+		provider := &schema.Provider{ResourcesMap: map[string]*schema.Resource{}}
+		Convey("When exportProviderSchema method is called", func() {
+			b, err := exportProviderSchema(binaryName, provider)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema returned should be keyed by the default provider source address", func() {
+				So(string(b), ShouldStartWith, `{"format_version":"1.0","provider_schemas":{"registry.terraform.io/hashicorp/openapi":`)
+			})
+		})
+	})
+	Convey("Given a provider and the OTF_PROVIDER_SOURCE_ADDRESS environment variable", t, func() {
+		binaryName := "terraform-provider-openapi"
+		provider := &schema.Provider{ResourcesMap: map[string]*schema.Resource{}}
+		os.Setenv(otfProviderSourceAddressVar, "terraform.example.com/examplecorp/openapi")
+		Convey("When exportProviderSchema method is called", func() {
+			b, err := exportProviderSchema(binaryName, provider)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema returned should be keyed by the configured provider source address", func() {
+				So(string(b), ShouldStartWith, `{"format_version":"1.0","provider_schemas":{"terraform.example.com/examplecorp/openapi":`)
+			})
+		})
+		os.Unsetenv(otfProviderSourceAddressVar)
+	})
+}
+
 func TestGetProviderName(t *testing.T) {
 	Convey("Given a valid pluginName with no version", t, func() {
 		binaryName := "terraform-provider-goa"
@@ -153,3 +187,30 @@ func TestGetProviderName(t *testing.T) {
 	})
 
 }
+
+func TestGetProviderNameWindows(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		binaryName           string
+		expectedProviderName string
+	}{
+		{name: "windows executable", binaryName: "terraform-provider-goa.exe", expectedProviderName: "goa"},
+		{name: "windows executable with version", binaryName: "terraform-provider-goa_v1.0.0.exe", expectedProviderName: "goa"},
+		{name: "windows executable with upper case suffix", binaryName: "terraform-provider-goa_v1.0.0.EXE", expectedProviderName: "goa"},
+		{name: "windows executable with mixed case prefix", binaryName: "Terraform-Provider-goa.Exe", expectedProviderName: "goa"},
+		{name: "windows path", binaryName: "C:\\Users\\username\\AppData\\Roaming\\terraform.d\\plugins\\terraform.example.com\\examplecorp\\goa\\1.0.0\\windows_amd64\\terraform-provider-goa_v1.0.0.exe", expectedProviderName: "goa"},
+	}
+	for _, tc := range testCases {
+		providerName, err := getProviderName(tc.binaryName)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedProviderName, providerName, tc.name)
+	}
+
+	_, err := getProviderName("terraform-provider-goa.exe.old")
+	assert.EqualError(t, err, "provider binary name (terraform-provider-goa.exe.old) does not match terraform naming convention 'terraform-provider-{name}', please rename the provider binary")
+}
+
+func TestGetEmbeddedProviderName(t *testing.T) {
+	assert.Equal(t, "", getEmbeddedProviderName(nil))
+	assert.Equal(t, "goa", getEmbeddedProviderName(&openapi.EmbeddedConfiguration{ProviderName: "goa"}))
+}
@@ -6,10 +6,11 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/openapierr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,18 +18,46 @@ import (
 
 func crudWithContext(crudFunc func(data *schema.ResourceData, i interface{}) error, timeoutFor string, resourceName string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
 	return func(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
-		errChan := make(chan error, 1)
-		go func() { errChan <- crudFunc(data, i) }()
-		select {
-		case <-ctx.Done():
-			return diag.Errorf("%s: '%s' %s timeout is %s", ctx.Err(), resourceName, timeoutFor, data.Timeout(timeoutFor))
-		case err := <-errChan:
-			if err != nil {
-				return diag.FromErr(err)
-			}
-		}
-		return nil
+		return runCRUDWithContext(ctx, crudFunc, data, i, timeoutFor, resourceName, data.Timeout(timeoutFor))
+	}
+}
+
+// runCRUDWithContext runs the CRUD function passed in returning as soon as the context is done, in which case the timeout
+// passed in is reported in the error
+func runCRUDWithContext(ctx context.Context, crudFunc func(data *schema.ResourceData, i interface{}) error, data *schema.ResourceData, i interface{}, timeoutFor string, resourceName string, timeout time.Duration) diag.Diagnostics {
+	diagsChan := make(chan diag.Diagnostics, 1)
+	go func() {
+		// the panics are recovered here since a panic in this goroutine would crash the whole provider plugin
+		defer recoverCRUDPanic(resourceName, timeoutFor, diagsChan)
+		diagsChan <- crudDiagnostics(crudFunc(data, i))
+	}()
+	select {
+	case <-ctx.Done():
+		return diag.Errorf("%s: '%s' %s timeout is %s", ctx.Err(), resourceName, timeoutFor, timeout)
+	case diags := <-diagsChan:
+		return diags
+	}
+}
+
+// operationWarnings is returned by the CRUD operations that succeeded but need to warn the user (e,g: read-after-write
+// verification mismatches), in which case crudWithContext reports a warning diagnostic instead of an error
+type operationWarnings struct {
+	summary string
+	details []string
+}
+
+func (w *operationWarnings) Error() string {
+	return fmt.Sprintf("%s: %s", w.summary, strings.Join(w.details, "; "))
+}
+
+// crudDiagnostics returns the diagnostics of the error returned by a CRUD operation; operationWarnings are reported as
+// warnings so the operation does not fail
+func crudDiagnostics(err error) diag.Diagnostics {
+	var warnings *operationWarnings
+	if errors.As(err, &warnings) {
+		return diag.Diagnostics{{Severity: diag.Warning, Summary: warnings.summary, Detail: strings.Join(warnings.details, "\n")}}
 	}
+	return diag.FromErr(err)
 }
 
 func checkHTTPStatusCode(openAPIResource SpecResource, res *http.Response, expectedHTTPStatusCodes []int) error {
@@ -48,9 +77,12 @@ func checkHTTPStatusCode(openAPIResource SpecResource, res *http.Response, expec
 			return fmt.Errorf("[resource='%s'] HTTP Response Status Code %d - Unauthorized: API access is denied due to invalid credentials (%s)", openAPIResource.GetResourceName(), res.StatusCode, resBody)
 		case http.StatusNotFound:
 			return &openapierr.NotFoundError{OriginalError: fmt.Errorf("HTTP Response Status Code %d - Not Found. Could not find resource instance: %s", res.StatusCode, resBody)}
-		default:
-			return fmt.Errorf("[resource='%s'] HTTP Response Status Code %d not matching expected one %v (%s)", openAPIResource.GetResourceName(), res.StatusCode, expectedHTTPStatusCodes, resBody)
+		case http.StatusConflict:
+			if conflicts := parseFieldManagerConflicts(resBody); len(conflicts) > 0 {
+				return newFieldManagerConflictError(openAPIResource, conflicts)
+			}
 		}
+		return fmt.Errorf("[resource='%s'] HTTP Response Status Code %d not matching expected one %v (%s)", openAPIResource.GetResourceName(), res.StatusCode, expectedHTTPStatusCodes, resBody)
 	}
 	return nil
 }
@@ -114,39 +146,90 @@ func dataSourceUpdateStateWithPayloadData(openAPIResource SpecResource, remoteDa
 
 // updateStateWithPayloadDataAndOptions is in charge of saving the given payload into the state file AND if the ignoreListOrder is enabled
 // it will go ahead and compare the items in the list (input vs remote) for properties of type list and the flag 'IgnoreItemsOrder' set to true
+// as well as keep the input values of network properties that are equivalent to the remote ones (refer to keepEquivalentNetworkValues).
 // The property names are converted into compliant terraform names if needed.
 func updateStateWithPayloadDataAndOptions(openAPIResource SpecResource, remoteData map[string]interface{}, resourceLocalData *schema.ResourceData, ignoreListOrderEnabled bool) error {
 	resourceSchema, err := openAPIResource.GetResourceSchema()
 	if err != nil {
 		return err
 	}
+	resourceSchema.resolveResourceIdentifierAliases(remoteData)
 	for propertyName, propertyRemoteValue := range remoteData {
 		property, err := resourceSchema.getProperty(propertyName)
 		if err != nil {
-			log.Printf("[WARN] The API returned a property that is not specified in the resource's schema definition in the OpenAPI document - error = %s", err)
+			resourceLog.Warn("The API returned a property that is not specified in the resource's schema definition in the OpenAPI document - error = %s", err)
 			continue
 		}
 		if property.isPropertyNamedID() {
 			continue
 		}
 
-		propValue := propertyRemoteValue
-		if ignoreListOrderEnabled && property.shouldIgnoreOrder() {
-			desiredValue := resourceLocalData.Get(property.GetTerraformCompliantPropertyName())
-			propValue = processIgnoreOrderIfEnabled(*property, desiredValue, propertyRemoteValue)
-		}
-
-		value, err := convertPayloadToLocalStateDataValue(property, propValue)
-		if err != nil {
+		if err := updateStatePropertyWithPayloadData(property, propertyRemoteValue, resourceLocalData, ignoreListOrderEnabled); err != nil {
 			return err
 		}
-		if value != nil {
-			if err := setResourceDataProperty(*property, value, resourceLocalData); err != nil {
-				return err
+	}
+	return nil
+}
+
+// updateStatePropertyWithPayloadData saves the given property value received from the API into the state. Panics raised
+// while processing the value (e,g: unexpected payload shapes) are annotated with the property and the value type
+func updateStatePropertyWithPayloadData(property *SpecSchemaDefinitionProperty, propertyRemoteValue interface{}, resourceLocalData *schema.ResourceData, ignoreListOrderEnabled bool) error {
+	defer annotatePropertyPanic(property.Name, propertyRemoteValue)
+
+	propValue := propertyRemoteValue
+	if ignoreListOrderEnabled && property.IgnoreServerItems {
+		desiredValue := resourceLocalData.Get(property.GetTerraformCompliantPropertyName())
+		propValue = excludeServerItems(*property, desiredValue, propValue)
+	}
+	if ignoreListOrderEnabled && property.shouldIgnoreOrder() {
+		desiredValue := resourceLocalData.Get(property.GetTerraformCompliantPropertyName())
+		propValue = processIgnoreOrderIfEnabled(*property, desiredValue, propValue)
+	} else if ignoreListOrderEnabled && property.isNetworkFormatProperty() {
+		desiredValue := resourceLocalData.Get(property.GetTerraformCompliantPropertyName())
+		propValue = keepEquivalentNetworkValues(*property, desiredValue, propValue)
+	}
+
+	value, err := convertPayloadToLocalStateDataValue(property, propValue)
+	if err != nil {
+		return err
+	}
+	if value != nil {
+		return setResourceDataProperty(*property, value, resourceLocalData)
+	}
+	return nil
+}
+
+// excludeServerItems returns the remote items (from the API) that match any of the input items (from the user) for lists
+// with IgnoreServerItems enabled, so the items appended by the server (e,g: system managed firewall rules) are not stored in
+// the state and do not show up as diffs. Each input item matches one remote item at most; items of lists of objects are
+// matched based on their user configurable properties only (refer to hashComplexObject). The remote order is kept.
+func excludeServerItems(property SpecSchemaDefinitionProperty, inputPropertyValue, remoteValue interface{}) interface{} {
+	inputValueArray, isInputList := inputPropertyValue.([]interface{})
+	remoteValueArray, isRemoteList := remoteValue.([]interface{})
+	if !isInputList || !isRemoteList { // unexpected payload shape, the remote value is kept as is
+		return remoteValue
+	}
+	matched := make([]bool, len(inputValueArray))
+	userItems := make([]interface{}, 0, len(inputValueArray))
+	for _, remoteItemValue := range remoteValueArray {
+		for idx, inputItemValue := range inputValueArray {
+			if matched[idx] {
+				continue
+			}
+			var equal bool
+			if property.isArrayOfObjectsProperty() {
+				equal = property.hashComplexObject(inputItemValue) == property.hashComplexObject(remoteItemValue)
+			} else {
+				equal = property.equalItems(property.ArrayItemsType, inputItemValue, remoteItemValue)
+			}
+			if equal {
+				matched[idx] = true
+				userItems = append(userItems, remoteItemValue)
+				break
 			}
 		}
 	}
-	return nil
+	return userItems
 }
 
 // processIgnoreOrderIfEnabled checks whether the property has enabled the `IgnoreItemsOrder` field and if so, goes ahead
@@ -157,14 +240,21 @@ func updateStateWithPayloadDataAndOptions(openAPIResource SpecResource, remoteDa
 // Use case 2: The desired state for an array property (input from user, inputPropertyValue) contains items in certain order BUT the remote state (remoteValue) comes back with the same items in different order PLUS new ones.
 // Use case 3: The desired state for an array property (input from user, inputPropertyValue) contains items in certain order BUT the remote state (remoteValue) comes back with a shorter list where the remaining elems match the inputs.
 // Use case 4: The desired state for an array property (input from user, inputPropertyValue) contains items in certain order BUT the remote state (remoteValue) some back with the list with the same size but some elems were updated
+// For lists of objects, the items are matched based on their user configurable properties only (refer to processIgnoreOrderObjectItems).
 func processIgnoreOrderIfEnabled(property SpecSchemaDefinitionProperty, inputPropertyValue, remoteValue interface{}) interface{} {
 	if inputPropertyValue == nil || remoteValue == nil { // treat remote as the final state if input value does not exists
 		return remoteValue
 	}
 	if property.shouldIgnoreOrder() {
 		newPropertyValue := []interface{}{}
-		inputValueArray := inputPropertyValue.([]interface{})
-		remoteValueArray := remoteValue.([]interface{})
+		inputValueArray, isInputList := inputPropertyValue.([]interface{})
+		remoteValueArray, isRemoteList := remoteValue.([]interface{})
+		if !isInputList || !isRemoteList { // unexpected payload shape, the remote value is kept as is
+			return remoteValue
+		}
+		if property.isArrayOfObjectsProperty() {
+			return processIgnoreOrderObjectItems(property, inputValueArray, remoteValueArray)
+		}
 		for _, inputItemValue := range inputValueArray {
 			for _, remoteItemValue := range remoteValueArray {
 				if property.equalItems(property.ArrayItemsType, inputItemValue, remoteItemValue) {
@@ -194,24 +284,93 @@ func processIgnoreOrderIfEnabled(property SpecSchemaDefinitionProperty, inputPro
 	return remoteValue
 }
 
+// processIgnoreOrderObjectItems reconciles the input items (from the user) with the remote items (from the API) for lists
+// of objects with IgnoreItemsOrder enabled. Items are matched based on the hash of their user configurable properties only
+// (refer to hashComplexObject) so differences in computed properties do not result into unstable diffs. The list returned
+// keeps the input order for the matched items (containing the computed properties returned by the API) followed by the
+// remote items that did not match any input item. Input items not returned by the API are dropped.
+func processIgnoreOrderObjectItems(property SpecSchemaDefinitionProperty, inputValueArray, remoteValueArray []interface{}) []interface{} {
+	newPropertyValue := make([]interface{}, 0, len(remoteValueArray))
+	// remote items indexed by hash so each input item is matched without having to scan (and re-hash) the remote items
+	remoteItemsByHash := make(map[int][]int, len(remoteValueArray))
+	for idx, remoteItemValue := range remoteValueArray {
+		hash := property.hashComplexObject(remoteItemValue)
+		remoteItemsByHash[hash] = append(remoteItemsByHash[hash], idx)
+	}
+	matched := make([]bool, len(remoteValueArray))
+	for _, inputItemValue := range inputValueArray {
+		inputHash := property.hashComplexObject(inputItemValue)
+		candidates := remoteItemsByHash[inputHash]
+		if len(candidates) == 0 {
+			continue
+		}
+		idx := candidates[0]
+		remoteItemsByHash[inputHash] = candidates[1:]
+		matched[idx] = true
+		newPropertyValue = append(newPropertyValue, mergeComputedProperties(property.SpecSchemaDefinition, inputItemValue, remoteValueArray[idx]))
+	}
+	for idx, remoteItemValue := range remoteValueArray {
+		if !matched[idx] {
+			newPropertyValue = append(newPropertyValue, remoteItemValue)
+		}
+	}
+	return newPropertyValue
+}
+
+// mergeComputedProperties returns the remote object (containing the computed properties returned by the API) where the
+// nested lists with IgnoreItemsOrder enabled keep the order of the input object. Nested objects are merged recursively.
+func mergeComputedProperties(schemaDefinition *SpecSchemaDefinition, inputValue, remoteValue interface{}) interface{} {
+	remoteObject, ok := remoteValue.(map[string]interface{})
+	inputObject := unwrapObjectValue(inputValue)
+	if !ok || inputObject == nil || schemaDefinition == nil {
+		return remoteValue
+	}
+	mergedObject := make(map[string]interface{}, len(remoteObject))
+	for propertyName, propertyValue := range remoteObject {
+		mergedObject[propertyName] = propertyValue
+	}
+	for _, property := range schemaDefinition.Properties {
+		if property.isComputed() {
+			continue
+		}
+		remotePropertyValue, exists := remoteObject[property.Name]
+		if !exists {
+			continue
+		}
+		inputPropertyValue := getObjectPropertyValue(inputObject, property)
+		switch {
+		case property.shouldIgnoreOrder():
+			if _, isList := inputPropertyValue.([]interface{}); isList {
+				mergedObject[property.Name] = processIgnoreOrderIfEnabled(*property, inputPropertyValue, remotePropertyValue)
+			}
+		case property.isObjectProperty():
+			mergedObject[property.Name] = mergeComputedProperties(property.SpecSchemaDefinition, inputPropertyValue, remotePropertyValue)
+		}
+	}
+	return mergedObject
+}
+
+// convertPayloadToLocalStateDataValue converts the given value received from the API into the value expected by the
+// Terraform schema for the property. Type switches are used instead of reflection for the common JSON types since this
+// function is called for every single value of the payload which can be considerably big (e,g: thousands of nested items).
 func convertPayloadToLocalStateDataValue(property *SpecSchemaDefinitionProperty, propertyValue interface{}) (interface{}, error) {
 	if propertyValue == nil {
 		return nil, nil
 	}
-	dataValueKind := reflect.TypeOf(propertyValue).Kind()
-	switch dataValueKind {
-	case reflect.Map:
-		objectInput := map[string]interface{}{}
-		mapValue := propertyValue.(map[string]interface{})
-		for propertyName, propertyValue := range mapValue {
+	switch value := propertyValue.(type) {
+	case map[string]interface{}:
+		if property.SpecSchemaDefinition == nil {
+			return nil, fmt.Errorf("property '%s' is supposed to be of type %s but the API returned an object", property.Name, property.Type)
+		}
+		objectInput := make(map[string]interface{}, len(value))
+		for propertyName, propertyValue := range value {
 			schemaDefinitionProperty, err := property.SpecSchemaDefinition.getProperty(propertyName)
 			if err != nil {
 				return nil, err
 			}
-			var propValue interface{}
 			// Here we are processing the items of the list which are objects. In this case we need to keep the original
 			// types as Terraform honors property types for resource schemas attached to TypeList properties
-			propValue, err = convertPayloadToLocalStateDataValue(schemaDefinitionProperty, propertyValue)
+			propValue, err := convertPayloadToLocalStateDataValue(schemaDefinitionProperty, propertyValue)
 			if err != nil {
 				return nil, err
 			}
@@ -222,49 +381,109 @@ func convertPayloadToLocalStateDataValue(property *SpecSchemaDefinitionProperty,
 		// blocks only for TypeList and TypeSet . In this case, we need to make sure that the json (which reflects to a map)
 		// gets translated to the expected array of one item that terraform expects.
 		if property.shouldUseLegacyTerraformSDKBlockApproachForComplexObjects() {
-			arrayInput := []interface{}{}
-			arrayInput = append(arrayInput, objectInput)
-			return arrayInput, nil
+			return []interface{}{objectInput}, nil
 		}
 		return objectInput, nil
-	case reflect.Slice, reflect.Array:
+	case []interface{}:
 		if isListOfPrimitives, _ := property.isTerraformListOfSimpleValues(); isListOfPrimitives {
+			if property.Coerce {
+				return coerceListOfPrimitivesValue(property, value)
+			}
 			return propertyValue, nil
 		}
 		if property.isArrayOfObjectsProperty() {
-			arrayInput := []interface{}{}
-			arrayValue := propertyValue.([]interface{})
-			for _, arrayItem := range arrayValue {
+			arrayInput := make([]interface{}, 0, len(value))
+			for _, arrayItem := range value {
 				objectValue, err := convertPayloadToLocalStateDataValue(property, arrayItem)
 				if err != nil {
-					return err, nil
+					return nil, err
 				}
 				arrayInput = append(arrayInput, objectValue)
 			}
 			return arrayInput, nil
 		}
 		return nil, fmt.Errorf("property '%s' is supposed to be an array objects", property.Name)
-	case reflect.String:
-		return propertyValue.(string), nil
-	case reflect.Int:
-		return propertyValue.(int), nil
-	case reflect.Float64:
+	case string:
+		if property.Coerce {
+			return coerceStringValue(property, property.Type, value)
+		}
+		return value, nil
+	case int:
+		return value, nil
+	case float64:
 		// In golang, a number in JSON message is always parsed into float64. Hence, checking here if the property value is
 		// an actual int or if not then casting to float64
 		if property.Type == TypeInt {
-			return int(propertyValue.(float64)), nil
+			return int(value), nil
 		}
-		return propertyValue.(float64), nil
-	case reflect.Bool:
-		return propertyValue.(bool), nil
+		return value, nil
+	case bool:
+		return value, nil
+	}
+	dataValueKind := reflect.TypeOf(propertyValue).Kind()
+	switch dataValueKind {
+	case reflect.Slice, reflect.Array:
+		if isListOfPrimitives, _ := property.isTerraformListOfSimpleValues(); isListOfPrimitives {
+			return propertyValue, nil
+		}
+		return nil, fmt.Errorf("property '%s' is supposed to be an array objects", property.Name)
 	default:
 		return nil, fmt.Errorf("'%s' type not supported", dataValueKind)
 	}
 }
 
+// coerceStringValue converts the string value received from the API into the given type (e,g: "true" into true for bool
+// properties). The value is returned as is if the type is string
+func coerceStringValue(property *SpecSchemaDefinitionProperty, propertyType schemaDefinitionPropertyType, value string) (interface{}, error) {
+	var coercedValue interface{}
+	var err error
+	switch propertyType {
+	case TypeBool:
+		coercedValue, err = strconv.ParseBool(value)
+	case TypeInt:
+		coercedValue, err = strconv.Atoi(value)
+	case TypeFloat:
+		coercedValue, err = strconv.ParseFloat(value, 64)
+	default:
+		return value, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("property '%s' value '%s' can not be coerced to %s", property.Name, value, propertyType)
+	}
+	return coercedValue, nil
+}
+
+// coerceListOfPrimitivesValue converts the string items of the list received from the API into the type of the items of
+// the array property
+func coerceListOfPrimitivesValue(property *SpecSchemaDefinitionProperty, value []interface{}) ([]interface{}, error) {
+	coercedValue := make([]interface{}, 0, len(value))
+	for _, item := range value {
+		if stringItem, ok := item.(string); ok {
+			coercedItem, err := coerceStringValue(property, property.ArrayItemsType, stringItem)
+			if err != nil {
+				return nil, err
+			}
+			item = coercedItem
+		}
+		coercedValue = append(coercedValue, item)
+	}
+	return coercedValue, nil
+}
+
 // setResourceDataProperty sets the expectedValue for the given schemaDefinitionPropertyName using the terraform compliant property name
 func setResourceDataProperty(schemaDefinitionProperty SpecSchemaDefinitionProperty, value interface{}, resourceLocalData *schema.ResourceData) error {
-	return resourceLocalData.Set(schemaDefinitionProperty.GetTerraformCompliantPropertyName(), value)
+	if err := resourceLocalData.Set(schemaDefinitionProperty.GetTerraformCompliantPropertyName(), value); err != nil {
+		return err
+	}
+	// the attribute aliases in use are kept in sync with the property so configurations using the aliases do not show diffs
+	for _, alias := range schemaDefinitionProperty.AttributeAliases {
+		if _, exists := resourceLocalData.GetOkExists(alias); exists {
+			if err := resourceLocalData.Set(alias, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // setStateID sets the local resource's data ID with the newly identifier created in the POST API request. Refer to
@@ -278,6 +497,7 @@ func setStateID(openAPIres SpecResource, resourceLocalData *schema.ResourceData,
 	if err != nil {
 		return err
 	}
+	resourceSchema.resolveResourceIdentifierAliases(payload)
 	if payload[identifierProperty] == nil {
 		return fmt.Errorf("response object returned from the API is missing mandatory identifier property '%s'", identifierProperty)
 	}
@@ -138,6 +138,24 @@ func TestCheckHTTPStatusCode(t *testing.T) {
 			inputStatusCodes: []int{http.StatusOK},
 			expectedError:    &openapierr.NotFoundError{OriginalError: errors.New("HTTP Response Status Code 404 - Not Found. Could not find resource instance: item not found")},
 		},
+		{
+			name: "response known with code 409 Conflict containing field manager conflicts",
+			inputResponse: &http.Response{
+				Body:       ioutil.NopCloser(strings.NewReader(`{"kind":"Status","reason":"Conflict","details":{"causes":[{"reason":"FieldManagerConflict","message":"conflict with \"kubectl\"","field":".spec.replicas"}]},"code":409}`)),
+				StatusCode: http.StatusConflict,
+			},
+			inputStatusCodes: []int{http.StatusOK},
+			expectedError:    errors.New("[resource='resourceName'] HTTP Response Status Code 409 - Conflict: the following fields are owned by other field managers: .spec.replicas (managed by 'kubectl')"),
+		},
+		{
+			name: "response known with code 409 Conflict without field manager conflicts",
+			inputResponse: &http.Response{
+				Body:       ioutil.NopCloser(strings.NewReader("resource already exists")),
+				StatusCode: http.StatusConflict,
+			},
+			inputStatusCodes: []int{http.StatusOK},
+			expectedError:    errors.New("[resource='resourceName'] HTTP Response Status Code 409 not matching expected one [200] (resource already exists)"),
+		},
 	}
 	Convey("Given a specStubResource", t, func() {
 		openAPIResource := &specStubResource{name: "resourceName"}
@@ -959,6 +977,24 @@ func TestSetStateID(t *testing.T) {
 	})
 }
 
+func TestSetStateIDWithIdentifierAliases(t *testing.T) {
+	uuidProperty := newStringSchemaDefinitionPropertyWithDefaults("uuid", "", false, true, nil)
+	uuidProperty.IsIdentifier = true
+	uuidProperty.IdentifierAliases = []string{"id"}
+	r, resourceData := testCreateResourceFactory(t, uuidProperty)
+
+	err := setStateID(r.openAPIResource, resourceData, map[string]interface{}{"id": "1234"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", resourceData.Id())
+
+	err = updateStateWithPayloadData(r.openAPIResource, map[string]interface{}{"id": "1234"}, resourceData)
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", resourceData.Get("uuid"))
+
+	err = setStateID(r.openAPIResource, resourceData, map[string]interface{}{"label": "label"})
+	assert.EqualError(t, err, "response object returned from the API is missing mandatory identifier property 'uuid'")
+}
+
 func TestProcessIgnoreOrderIfEnabled(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -1294,3 +1330,544 @@ func TestProcessIgnoreOrderIfEnabled(t *testing.T) {
 		assert.Equal(t, tc.expectedOutput, output, tc.name)
 	}
 }
+
+func TestProcessIgnoreOrderObjectItems(t *testing.T) {
+	membersProperty := func() *SpecSchemaDefinitionProperty {
+		return &SpecSchemaDefinitionProperty{
+			Name:             "members",
+			Type:             TypeList,
+			ArrayItemsType:   TypeObject,
+			IgnoreItemsOrder: true,
+			SpecSchemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					&SpecSchemaDefinitionProperty{Name: "email", Type: TypeString, Required: true},
+					&SpecSchemaDefinitionProperty{Name: "memberId", Type: TypeString, ReadOnly: true},
+				},
+			},
+		}
+	}
+	groupsProperty := SpecSchemaDefinitionProperty{
+		Name:             "groups",
+		Type:             TypeList,
+		ArrayItemsType:   TypeObject,
+		IgnoreItemsOrder: true,
+		SpecSchemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "groupName", Type: TypeString, Required: true},
+				&SpecSchemaDefinitionProperty{Name: "priority", Type: TypeInt},
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "status", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{
+					Name: "settings",
+					Type: TypeObject,
+					SpecSchemaDefinition: &SpecSchemaDefinition{
+						Properties: SpecSchemaDefinitionProperties{
+							&SpecSchemaDefinitionProperty{Name: "enabled", Type: TypeBool},
+							&SpecSchemaDefinitionProperty{Name: "members", Type: TypeList, ArrayItemsType: TypeObject, IgnoreItemsOrder: true, SpecSchemaDefinition: membersProperty().SpecSchemaDefinition},
+							&SpecSchemaDefinitionProperty{Name: "lastUpdated", Type: TypeString, ReadOnly: true},
+						},
+					},
+				},
+				membersProperty(),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		inputValue     []interface{}
+		remoteValue    []interface{}
+		expectedOutput []interface{}
+	}{
+		{
+			name: "items match ignoring the computed properties returned by the API and the input order is kept",
+			inputValue: []interface{}{
+				map[string]interface{}{"group_name": "b", "priority": 2, "id": "", "status": ""},
+				map[string]interface{}{"group_name": "a", "priority": 1, "id": "", "status": ""},
+			},
+			remoteValue: []interface{}{
+				map[string]interface{}{"groupName": "a", "priority": 1.0, "id": "id-a", "status": "active"},
+				map[string]interface{}{"groupName": "b", "priority": 2.0, "id": "id-b", "status": "pending"},
+			},
+			expectedOutput: []interface{}{
+				map[string]interface{}{"groupName": "b", "priority": 2.0, "id": "id-b", "status": "pending"},
+				map[string]interface{}{"groupName": "a", "priority": 1.0, "id": "id-a", "status": "active"},
+			},
+		},
+		{
+			name: "optional properties not configured by the user (zero values in the state) match properties not returned by the API",
+			inputValue: []interface{}{
+				map[string]interface{}{"group_name": "a", "priority": 0, "settings": []interface{}{}, "members": []interface{}{}},
+			},
+			remoteValue: []interface{}{
+				map[string]interface{}{"groupName": "a", "id": "id-a"},
+			},
+			expectedOutput: []interface{}{
+				map[string]interface{}{"groupName": "a", "id": "id-a"},
+			},
+		},
+		{
+			name: "items with different configurable properties do not match; input items not returned by the API are dropped and unexpected remote items are appended",
+			inputValue: []interface{}{
+				map[string]interface{}{"group_name": "a", "priority": 1},
+				map[string]interface{}{"group_name": "b", "priority": 1},
+			},
+			remoteValue: []interface{}{
+				map[string]interface{}{"groupName": "a", "priority": 5.0, "id": "id-a"},
+				map[string]interface{}{"groupName": "b", "priority": 1.0, "id": "id-b"},
+			},
+			expectedOutput: []interface{}{
+				map[string]interface{}{"groupName": "b", "priority": 1.0, "id": "id-b"},
+				map[string]interface{}{"groupName": "a", "priority": 5.0, "id": "id-a"},
+			},
+		},
+		{
+			name: "duplicate input items are matched with different remote items",
+			inputValue: []interface{}{
+				map[string]interface{}{"group_name": "a"},
+				map[string]interface{}{"group_name": "a"},
+			},
+			remoteValue: []interface{}{
+				map[string]interface{}{"groupName": "a", "id": "id-1"},
+				map[string]interface{}{"groupName": "a", "id": "id-2"},
+			},
+			expectedOutput: []interface{}{
+				map[string]interface{}{"groupName": "a", "id": "id-1"},
+				map[string]interface{}{"groupName": "a", "id": "id-2"},
+			},
+		},
+		{
+			name: "set in set: nested items are matched ignoring computed properties and keep the input order",
+			inputValue: []interface{}{
+				map[string]interface{}{
+					"group_name": "a",
+					"members": []interface{}{
+						map[string]interface{}{"email": "y@mail.com", "member_id": ""},
+						map[string]interface{}{"email": "x@mail.com", "member_id": ""},
+					},
+				},
+			},
+			remoteValue: []interface{}{
+				map[string]interface{}{
+					"groupName": "a",
+					"members": []interface{}{
+						map[string]interface{}{"email": "x@mail.com", "memberId": "m-x"},
+						map[string]interface{}{"email": "y@mail.com", "memberId": "m-y"},
+					},
+				},
+			},
+			expectedOutput: []interface{}{
+				map[string]interface{}{
+					"groupName": "a",
+					"members": []interface{}{
+						map[string]interface{}{"email": "y@mail.com", "memberId": "m-y"},
+						map[string]interface{}{"email": "x@mail.com", "memberId": "m-x"},
+					},
+				},
+			},
+		},
+		{
+			name: "set in object in set: nested object (stored in the state as a list of one elem) is merged and its nested set keeps the input order",
+			inputValue: []interface{}{
+				map[string]interface{}{
+					"group_name": "b",
+					"settings": []interface{}{
+						map[string]interface{}{
+							"enabled":      true,
+							"last_updated": "",
+							"members": []interface{}{
+								map[string]interface{}{"email": "y@mail.com"},
+								map[string]interface{}{"email": "x@mail.com"},
+							},
+						},
+					},
+				},
+				map[string]interface{}{
+					"group_name": "a",
+					"settings": []interface{}{
+						map[string]interface{}{
+							"enabled": false,
+							"members": []interface{}{
+								map[string]interface{}{"email": "z@mail.com"},
+							},
+						},
+					},
+				},
+			},
+			remoteValue: []interface{}{
+				map[string]interface{}{
+					"groupName": "a",
+					"id":        "id-a",
+					"settings": map[string]interface{}{
+						"lastUpdated": "yesterday",
+						"members": []interface{}{
+							map[string]interface{}{"email": "z@mail.com", "memberId": "m-z"},
+						},
+					},
+				},
+				map[string]interface{}{
+					"groupName": "b",
+					"id":        "id-b",
+					"settings": map[string]interface{}{
+						"enabled":     true,
+						"lastUpdated": "today",
+						"members": []interface{}{
+							map[string]interface{}{"email": "x@mail.com", "memberId": "m-x"},
+							map[string]interface{}{"email": "y@mail.com", "memberId": "m-y"},
+						},
+					},
+				},
+			},
+			expectedOutput: []interface{}{
+				map[string]interface{}{
+					"groupName": "b",
+					"id":        "id-b",
+					"settings": map[string]interface{}{
+						"enabled":     true,
+						"lastUpdated": "today",
+						"members": []interface{}{
+							map[string]interface{}{"email": "y@mail.com", "memberId": "m-y"},
+							map[string]interface{}{"email": "x@mail.com", "memberId": "m-x"},
+						},
+					},
+				},
+				map[string]interface{}{
+					"groupName": "a",
+					"id":        "id-a",
+					"settings": map[string]interface{}{
+						"lastUpdated": "yesterday",
+						"members": []interface{}{
+							map[string]interface{}{"email": "z@mail.com", "memberId": "m-z"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "set in object in set: items whose nested set differs in configurable properties do not match",
+			inputValue: []interface{}{
+				map[string]interface{}{
+					"group_name": "a",
+					"settings": []interface{}{
+						map[string]interface{}{
+							"members": []interface{}{map[string]interface{}{"email": "x@mail.com"}},
+						},
+					},
+				},
+			},
+			remoteValue: []interface{}{
+				map[string]interface{}{
+					"groupName": "a",
+					"settings": map[string]interface{}{
+						"members": []interface{}{map[string]interface{}{"email": "other@mail.com", "memberId": "m-o"}},
+					},
+				},
+			},
+			expectedOutput: []interface{}{
+				map[string]interface{}{
+					"groupName": "a",
+					"settings": map[string]interface{}{
+						"members": []interface{}{map[string]interface{}{"email": "other@mail.com", "memberId": "m-o"}},
+					},
+				},
+			},
+		},
+		{
+			name:           "empty input",
+			inputValue:     []interface{}{},
+			remoteValue:    []interface{}{map[string]interface{}{"groupName": "a", "id": "id-a"}},
+			expectedOutput: []interface{}{map[string]interface{}{"groupName": "a", "id": "id-a"}},
+		},
+		{
+			name:           "empty remote",
+			inputValue:     []interface{}{map[string]interface{}{"group_name": "a"}},
+			remoteValue:    []interface{}{},
+			expectedOutput: []interface{}{},
+		},
+	}
+	for _, tc := range testCases {
+		output := processIgnoreOrderIfEnabled(groupsProperty, tc.inputValue, tc.remoteValue)
+		assert.Equal(t, tc.expectedOutput, output, tc.name)
+	}
+}
+
+func TestMergeComputedProperties(t *testing.T) {
+	schemaDefinition := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString},
+			&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+		},
+	}
+	testCases := []struct {
+		name             string
+		schemaDefinition *SpecSchemaDefinition
+		inputValue       interface{}
+		remoteValue      interface{}
+		expectedOutput   interface{}
+	}{
+		{
+			name:             "remote object is returned containing the computed properties",
+			schemaDefinition: schemaDefinition,
+			inputValue:       map[string]interface{}{"name": "a", "id": ""},
+			remoteValue:      map[string]interface{}{"name": "a", "id": "some-id"},
+			expectedOutput:   map[string]interface{}{"name": "a", "id": "some-id"},
+		},
+		{
+			name:             "input value is not an object",
+			schemaDefinition: schemaDefinition,
+			inputValue:       "not an object",
+			remoteValue:      map[string]interface{}{"name": "a", "id": "some-id"},
+			expectedOutput:   map[string]interface{}{"name": "a", "id": "some-id"},
+		},
+		{
+			name:             "remote value is not an object",
+			schemaDefinition: schemaDefinition,
+			inputValue:       map[string]interface{}{"name": "a"},
+			remoteValue:      "not an object",
+			expectedOutput:   "not an object",
+		},
+		{
+			name:             "nil schema definition",
+			schemaDefinition: nil,
+			inputValue:       map[string]interface{}{"name": "a"},
+			remoteValue:      map[string]interface{}{"name": "a", "id": "some-id"},
+			expectedOutput:   map[string]interface{}{"name": "a", "id": "some-id"},
+		},
+	}
+	for _, tc := range testCases {
+		output := mergeComputedProperties(tc.schemaDefinition, tc.inputValue, tc.remoteValue)
+		assert.Equal(t, tc.expectedOutput, output, tc.name)
+	}
+}
+
+// newBenchmarkClusterProperty returns a property describing a cluster with a list of nodes (ignoring order) where each
+// node in turn contains nested objects and lists, representative of the big payloads some APIs return
+func newBenchmarkClusterProperty() *SpecSchemaDefinitionProperty {
+	return &SpecSchemaDefinitionProperty{
+		Name:             "nodes",
+		Type:             TypeList,
+		ArrayItemsType:   TypeObject,
+		IgnoreItemsOrder: true,
+		SpecSchemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "nodeName", Type: TypeString, Required: true},
+				&SpecSchemaDefinitionProperty{Name: "rack", Type: TypeString},
+				&SpecSchemaDefinitionProperty{Name: "sizeGb", Type: TypeInt},
+				&SpecSchemaDefinitionProperty{Name: "load", Type: TypeFloat, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "seed", Type: TypeBool},
+				&SpecSchemaDefinitionProperty{Name: "status", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "tags", Type: TypeList, ArrayItemsType: TypeString, IgnoreItemsOrder: true},
+				&SpecSchemaDefinitionProperty{
+					Name: "network",
+					Type: TypeObject,
+					SpecSchemaDefinition: &SpecSchemaDefinition{
+						Properties: SpecSchemaDefinitionProperties{
+							&SpecSchemaDefinitionProperty{Name: "privateAddress", Type: TypeString, ReadOnly: true},
+							&SpecSchemaDefinitionProperty{Name: "publicAddress", Type: TypeString, ReadOnly: true},
+							&SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt},
+						},
+					},
+				},
+				&SpecSchemaDefinitionProperty{
+					Name:             "disks",
+					Type:             TypeList,
+					ArrayItemsType:   TypeObject,
+					IgnoreItemsOrder: true,
+					SpecSchemaDefinition: &SpecSchemaDefinition{
+						Properties: SpecSchemaDefinitionProperties{
+							&SpecSchemaDefinitionProperty{Name: "device", Type: TypeString, Required: true},
+							&SpecSchemaDefinitionProperty{Name: "sizeGb", Type: TypeInt},
+							&SpecSchemaDefinitionProperty{Name: "usedGb", Type: TypeFloat, ReadOnly: true},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// newBenchmarkClusterPayloads returns the remote payload (as returned by the API) and the input (as stored in the
+// Terraform state) for the given number of nodes. The input items are in reverse order compared to the remote ones.
+func newBenchmarkClusterPayloads(numNodes int) (remote []interface{}, input []interface{}) {
+	for i := 0; i < numNodes; i++ {
+		disks := []interface{}{}
+		inputDisks := []interface{}{}
+		for d := 0; d < 4; d++ {
+			device := fmt.Sprintf("/dev/sd%d", d)
+			disks = append(disks, map[string]interface{}{"device": device, "sizeGb": 100.0, "usedGb": 12.5})
+			inputDisks = append([]interface{}{map[string]interface{}{"device": device, "size_gb": 100, "used_gb": 0.0}}, inputDisks...)
+		}
+		nodeName := fmt.Sprintf("node-%d", i)
+		remote = append(remote, map[string]interface{}{
+			"id":       fmt.Sprintf("id-%d", i),
+			"nodeName": nodeName,
+			"rack":     fmt.Sprintf("rack-%d", i%3),
+			"sizeGb":   float64(i),
+			"load":     0.5,
+			"seed":     i%10 == 0,
+			"status":   "RUNNING",
+			"tags":     []interface{}{"tag1", "tag2", "tag3"},
+			"network":  map[string]interface{}{"privateAddress": "10.0.0.1", "publicAddress": "1.1.1.1", "port": 9042.0},
+			"disks":    disks,
+		})
+		input = append([]interface{}{map[string]interface{}{
+			"id":        "",
+			"node_name": nodeName,
+			"rack":      fmt.Sprintf("rack-%d", i%3),
+			"size_gb":   i,
+			"load":      0.0,
+			"seed":      i%10 == 0,
+			"status":    "",
+			"tags":      []interface{}{"tag3", "tag2", "tag1"},
+			"network":   []interface{}{map[string]interface{}{"private_address": "", "public_address": "", "port": 9042}},
+			"disks":     inputDisks,
+		}}, input...)
+	}
+	return remote, input
+}
+
+func BenchmarkConvertPayloadToLocalStateDataValue(b *testing.B) {
+	property := newBenchmarkClusterProperty()
+	remote, _ := newBenchmarkClusterPayloads(2000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := convertPayloadToLocalStateDataValue(property, remote); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkProcessIgnoreOrderIfEnabled(b *testing.B) {
+	property := newBenchmarkClusterProperty()
+	remote, input := newBenchmarkClusterPayloads(2000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		processIgnoreOrderIfEnabled(*property, input, remote)
+	}
+}
+
+func TestConvertPayloadToLocalStateDataValueCoerce(t *testing.T) {
+	testCases := []struct {
+		name          string
+		property      *SpecSchemaDefinitionProperty
+		value         interface{}
+		expectedValue interface{}
+		expectedError string
+	}{
+		{name: "bool property with string value", property: &SpecSchemaDefinitionProperty{Name: "enabled", Type: TypeBool, Coerce: true}, value: "true", expectedValue: true},
+		{name: "int property with string value", property: &SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt, Coerce: true}, value: "123", expectedValue: 123},
+		{name: "float property with string value", property: &SpecSchemaDefinitionProperty{Name: "ratio", Type: TypeFloat, Coerce: true}, value: "0.75", expectedValue: 0.75},
+		{name: "string property with string value", property: &SpecSchemaDefinitionProperty{Name: "label", Type: TypeString, Coerce: true}, value: "123", expectedValue: "123"},
+		{name: "int property with number value", property: &SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt, Coerce: true}, value: float64(123), expectedValue: 123},
+		{name: "int property with string value and coerce disabled", property: &SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt}, value: "123", expectedValue: "123"},
+		{name: "list of ints with string items", property: &SpecSchemaDefinitionProperty{Name: "ports", Type: TypeList, ArrayItemsType: TypeInt, Coerce: true}, value: []interface{}{"80", float64(443)}, expectedValue: []interface{}{80, float64(443)}},
+		{name: "bool property with value that can not be coerced", property: &SpecSchemaDefinitionProperty{Name: "enabled", Type: TypeBool, Coerce: true}, value: "yes", expectedError: "property 'enabled' value 'yes' can not be coerced to boolean"},
+		{name: "list of bools with item that can not be coerced", property: &SpecSchemaDefinitionProperty{Name: "flags", Type: TypeList, ArrayItemsType: TypeBool, Coerce: true}, value: []interface{}{"true", "maybe"}, expectedError: "property 'flags' value 'maybe' can not be coerced to boolean"},
+	}
+	for _, tc := range testCases {
+		value, err := convertPayloadToLocalStateDataValue(tc.property, tc.value)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedValue, value, tc.name)
+	}
+}
+
+func TestExcludeServerItems(t *testing.T) {
+	rulesProperty := SpecSchemaDefinitionProperty{
+		Name:              "rules",
+		Type:              TypeList,
+		ArrayItemsType:    TypeObject,
+		IgnoreServerItems: true,
+		SpecSchemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "cidr", Type: TypeString, Required: true},
+				&SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt},
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+			},
+		},
+	}
+	tagsProperty := SpecSchemaDefinitionProperty{Name: "tags", Type: TypeList, ArrayItemsType: TypeString, IgnoreServerItems: true}
+	testCases := []struct {
+		name           string
+		property       SpecSchemaDefinitionProperty
+		inputValue     interface{}
+		remoteValue    interface{}
+		expectedOutput interface{}
+	}{
+		{
+			name:           "server appended primitive items are excluded",
+			property:       tagsProperty,
+			inputValue:     []interface{}{"env:prod", "team:a"},
+			remoteValue:    []interface{}{"system:managed", "team:a", "env:prod"},
+			expectedOutput: []interface{}{"team:a", "env:prod"},
+		},
+		{
+			name:           "duplicated input items match one remote item each",
+			property:       tagsProperty,
+			inputValue:     []interface{}{"team:a"},
+			remoteValue:    []interface{}{"team:a", "team:a"},
+			expectedOutput: []interface{}{"team:a"},
+		},
+		{
+			name:           "input items not returned by the API are dropped",
+			property:       tagsProperty,
+			inputValue:     []interface{}{"env:prod", "team:a"},
+			remoteValue:    []interface{}{"env:prod"},
+			expectedOutput: []interface{}{"env:prod"},
+		},
+		{
+			name:     "server appended object items are excluded matching on the user configurable properties",
+			property: rulesProperty,
+			inputValue: []interface{}{
+				map[string]interface{}{"cidr": "10.0.0.0/16", "port": 443, "id": ""},
+			},
+			remoteValue: []interface{}{
+				map[string]interface{}{"cidr": "0.0.0.0/0", "port": 22.0, "id": "system-rule"},
+				map[string]interface{}{"cidr": "10.0.0.0/16", "port": 443.0, "id": "user-rule"},
+			},
+			expectedOutput: []interface{}{
+				map[string]interface{}{"cidr": "10.0.0.0/16", "port": 443.0, "id": "user-rule"},
+			},
+		},
+		{
+			name:           "no input items",
+			property:       tagsProperty,
+			inputValue:     []interface{}{},
+			remoteValue:    []interface{}{"system:managed"},
+			expectedOutput: []interface{}{},
+		},
+		{
+			name:           "unexpected remote value shape is kept as is",
+			property:       tagsProperty,
+			inputValue:     []interface{}{"team:a"},
+			remoteValue:    "team:a",
+			expectedOutput: "team:a",
+		},
+	}
+	for _, tc := range testCases {
+		output := excludeServerItems(tc.property, tc.inputValue, tc.remoteValue)
+		assert.Equal(t, tc.expectedOutput, output, tc.name)
+	}
+}
+
+func TestUpdateStateWithPayloadDataIgnoreServerItems(t *testing.T) {
+	tagsProperty := newListSchemaDefinitionPropertyWithDefaults("tags", "", false, false, false, []interface{}{"env:prod", "team:a"}, TypeString, nil)
+	tagsProperty.IgnoreServerItems = true
+	tagsProperty.IgnoreItemsOrder = true
+	r, resourceData := testCreateResourceFactory(t, tagsProperty)
+
+	err := updateStateWithPayloadData(r.openAPIResource, map[string]interface{}{"tags": []interface{}{"system:managed", "team:a", "env:prod"}}, resourceData)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"env:prod", "team:a"}, resourceData.Get("tags"))
+
+	// data sources store all the items returned by the API
+	err = dataSourceUpdateStateWithPayloadData(r.openAPIResource, map[string]interface{}{"tags": []interface{}{"system:managed", "team:a", "env:prod"}}, resourceData)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"system:managed", "team:a", "env:prod"}, resourceData.Get("tags"))
+}
@@ -0,0 +1,172 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultCountField defines the response payload field containing the number of matching items if the root path GET
+// operation does not have the x-terraform-count-field extension
+const defaultCountField = "count"
+
+// count is a reserved Terraform data source argument name, hence the number of matches is exposed as match_count instead
+const dataSourceCountMatchCountProperty = "match_count"
+const dataSourceCountExistsProperty = "exists"
+
+// dataSourceCountFactory creates the count data source of the data sources. The data source accepts the same filters as
+// the data source it derives from and returns only the number of matching items and whether any item matches, so
+// configurations can gate behaviour on the presence of remote objects without failing when none (or several) match.
+// The matching items are counted by the API when the root path GET operation has the x-terraform-count-path extension;
+// otherwise they are counted from the collection
+type dataSourceCountFactory struct {
+	dataSource dataSourceFactory
+}
+
+func newDataSourceCountFactory(dataSource dataSourceFactory) dataSourceCountFactory {
+	return dataSourceCountFactory{
+		dataSource: dataSource,
+	}
+}
+
+// dataSourceCountName returns the name of the count data source of the data source with the given name
+func dataSourceCountName(dataSourceName string) string {
+	return fmt.Sprintf("%s_count", dataSourceName)
+}
+
+// getCount returns the server side count request defined in the root path GET operation; nil if the operation does not
+// have the x-terraform-count-path extension
+func (d dataSourceCountFactory) getCount() *specCount {
+	operation := d.dataSource.openAPIResource.getResourceOperations().List
+	if operation == nil {
+		return nil
+	}
+	return operation.count
+}
+
+func (d dataSourceCountFactory) createTerraformCountDataSource() (*schema.Resource, error) {
+	s, err := d.createTerraformCountDataSourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	timeouts, err := createDataSourceTimeouts(d.dataSource.openAPIResource)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.Resource{
+		Schema:             s,
+		ReadWithoutTimeout: dataSourceReadWithContext(d.read, d.dataSource.openAPIResource.GetResourceName(), *timeouts.Read),
+		Timeouts:           timeouts,
+	}, nil
+}
+
+// createTerraformCountDataSourceSchema returns the schema of the count data source, which contains the parent properties
+// (if the data source is a subresource), the filters and the computed match_count and exists attributes
+func (d dataSourceCountFactory) createTerraformCountDataSourceSchema() (map[string]*schema.Schema, error) {
+	specSchema, err := d.dataSource.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	s := map[string]*schema.Schema{
+		dataSourceFilterPropertyName: d.dataSource.dataSourceFiltersSchema(),
+		dataSourceCountMatchCountProperty: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of items matching the filters",
+		},
+		dataSourceCountExistsProperty: {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether at least one item matches the filters",
+		},
+	}
+	for _, property := range specSchema.Properties {
+		if !property.IsParentProperty {
+			continue
+		}
+		propertyName := property.GetTerraformCompliantPropertyName()
+		if _, exists := s[propertyName]; exists {
+			return nil, fmt.Errorf("resource '%s' count data source can not be created: parent property '%s' collides with the '%s' data source attribute", d.dataSource.openAPIResource.GetResourceName(), propertyName, propertyName)
+		}
+		tfSchema, err := property.terraformSchema()
+		if err != nil {
+			return nil, err
+		}
+		s[propertyName] = tfSchema
+	}
+	return s, nil
+}
+
+func (d dataSourceCountFactory) read(data *schema.ResourceData, i interface{}) error {
+	openAPIClient := i.(ClientOpenAPI)
+
+	if d.dataSource.openAPIResource == nil {
+		return fmt.Errorf("missing openAPI resource configuration")
+	}
+	dataSourceName := dataSourceCountName(d.dataSource.openAPIResource.GetResourceName())
+
+	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, dataSourceName)
+
+	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.dataSource.openAPIResource, data)
+	if err != nil {
+		return err
+	}
+
+	filters, err := d.dataSource.validateInput(data)
+	if err != nil {
+		return err
+	}
+
+	var matchCount int
+	if count := d.getCount(); count != nil {
+		matchCount, err = d.serverSideCount(openAPIClient, count, filters, parentIDs)
+	} else {
+		var matches []map[string]interface{}
+		matches, err = d.dataSource.listMatches(openAPIClient, filters, parentIDs, resourcePath)
+		matchCount = len(matches)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := data.Set(dataSourceCountMatchCountProperty, matchCount); err != nil {
+		return err
+	}
+	if err := data.Set(dataSourceCountExistsProperty, matchCount > 0); err != nil {
+		return err
+	}
+	// the ID is derived from the inputs so it remains stable across runs regardless of the number of matches
+	data.SetId(appendOptionsQueryParameters(resourcePath, filters.toQueryParameters()))
+	return nil
+}
+
+// serverSideCount returns the number of items matching the filters as returned by the count endpoint (x-terraform-count-path)
+func (d dataSourceCountFactory) serverSideCount(openAPIClient ClientOpenAPI, count *specCount, filters filters, parentIDs []string) (int, error) {
+	dataSourceName := dataSourceCountName(d.dataSource.openAPIResource.GetResourceName())
+	responsePayload := map[string]interface{}{}
+	resp, err := openAPIClient.Count(d.dataSource.openAPIResource, count, filters.toQueryParameters(), &responsePayload, parentIDs...)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkHTTPStatusCode(d.dataSource.openAPIResource, resp, []int{http.StatusOK}); err != nil {
+		return 0, fmt.Errorf("[data source='%s'] GET %s failed: %s", dataSourceName, count.path, err)
+	}
+	value, _ := getPayloadValue(responsePayload, count.field)
+	switch v := value.(type) {
+	case float64:
+		return int(v), nil
+	case int:
+		return v, nil
+	}
+	return 0, fmt.Errorf("[data source='%s'] GET %s response field '%s' is not a number: %v", dataSourceName, count.path, count.field, value)
+}
+
+// toQueryParameters returns the filters as query parameters keyed by the filter property name
+func (f filters) toQueryParameters() map[string]string {
+	queryParameters := map[string]string{}
+	for _, filter := range f {
+		queryParameters[filter.name] = filter.value
+	}
+	return queryParameters
+}
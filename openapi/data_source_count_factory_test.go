@@ -0,0 +1,131 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceCountName(t *testing.T) {
+	assert.Equal(t, "cdns_v1_count", dataSourceCountName("cdns_v1"))
+}
+
+func TestCreateTerraformCountDataSource(t *testing.T) {
+	parentProperty := newStringSchemaDefinitionPropertyWithDefaults("cdns_v1_id", "", true, false, nil)
+	parentProperty.IsParentProperty = true
+	d := newDataSourceCountFactory(newDataSourceFactory(&specStubResource{
+		name: "firewall",
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+				parentProperty,
+			},
+		},
+	}))
+	dataSource, err := d.createTerraformCountDataSource()
+	require.NoError(t, err)
+	assert.NotNil(t, dataSource.ReadWithoutTimeout)
+	assert.Equal(t, defaultDataSourceReadTimeout, *dataSource.Timeouts.Read)
+	assert.Len(t, dataSource.Schema, 4)
+	assert.Contains(t, dataSource.Schema, dataSourceFilterPropertyName)
+	assert.True(t, dataSource.Schema[dataSourceCountMatchCountProperty].Computed)
+	assert.Equal(t, schema.TypeInt, dataSource.Schema[dataSourceCountMatchCountProperty].Type)
+	assert.True(t, dataSource.Schema[dataSourceCountExistsProperty].Computed)
+	assert.Equal(t, schema.TypeBool, dataSource.Schema[dataSourceCountExistsProperty].Type)
+	assert.True(t, dataSource.Schema["cdns_v1_id"].Required)
+	assert.NotContains(t, dataSource.Schema, "label", "only the parent properties of the resource are part of the count data source schema")
+	assert.NoError(t, dataSource.InternalValidate(nil, false))
+}
+
+func TestCreateTerraformCountDataSource_ParentPropertyCollision(t *testing.T) {
+	parentProperty := newStringSchemaDefinitionPropertyWithDefaults("exists", "", true, false, nil)
+	parentProperty.IsParentProperty = true
+	d := newDataSourceCountFactory(newDataSourceFactory(&specStubResource{
+		name:             "firewall",
+		schemaDefinition: &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{parentProperty}},
+	}))
+	_, err := d.createTerraformCountDataSource()
+	assert.EqualError(t, err, "resource 'firewall' count data source can not be created: parent property 'exists' collides with the 'exists' data source attribute")
+}
+
+func TestDataSourceCountRead(t *testing.T) {
+	testCases := []struct {
+		name                          string
+		listOperation                 *specResourceOperation
+		filtersInput                  []interface{}
+		responseListPayload           []map[string]interface{}
+		responsePayload               map[string]interface{}
+		expectedCount                 int
+		expectedExists                bool
+		expectedCountRequestsReceived []string
+		expectedError                 string
+	}{
+		{
+			name:         "items matching the filters are counted from the collection",
+			filtersInput: []interface{}{newFilter("label", []interface{}{"my_label"})},
+			responseListPayload: []map[string]interface{}{
+				{"id": "someID", "label": "my_label"},
+				{"id": "someOtherID", "label": "my_label"},
+				{"id": "anotherID", "label": "other_label"},
+			},
+			expectedCount:  2,
+			expectedExists: true,
+		},
+		{
+			name:                "no items match the filters",
+			filtersInput:        []interface{}{newFilter("label", []interface{}{"my_label"})},
+			responseListPayload: []map[string]interface{}{{"id": "someID", "label": "other_label"}},
+			expectedCount:       0,
+			expectedExists:      false,
+		},
+		{
+			name:                          "items matching the filters are counted by the API",
+			listOperation:                 &specResourceOperation{count: &specCount{path: "/v1/cdns/count", field: "meta.total"}},
+			filtersInput:                  []interface{}{newFilter("label", []interface{}{"my_label"})},
+			responsePayload:               map[string]interface{}{"meta": map[string]interface{}{"total": float64(3)}},
+			expectedCount:                 3,
+			expectedExists:                true,
+			expectedCountRequestsReceived: []string{"/v1/cdns/count?label=my_label"},
+		},
+		{
+			name:                          "count endpoint response does not contain the count field",
+			listOperation:                 &specResourceOperation{count: &specCount{path: "/v1/cdns/count", field: "count"}},
+			responsePayload:               map[string]interface{}{"total": float64(3)},
+			expectedCountRequestsReceived: []string{"/v1/cdns/count"},
+			expectedError:                 "[data source='cdns_v1_count'] GET /v1/cdns/count response field 'count' is not a number: <nil>",
+		},
+	}
+	for _, tc := range testCases {
+		d := newDataSourceCountFactory(newDataSourceFactory(&specStubResource{
+			name:                  "cdns_v1",
+			path:                  "/v1/cdns",
+			resourceListOperation: tc.listOperation,
+			schemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+					newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+				},
+			},
+		}))
+		dataSourceSchema, err := d.createTerraformCountDataSourceSchema()
+		require.NoError(t, err, tc.name)
+		resourceData := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{dataSourceFilterPropertyName: tc.filtersInput})
+		client := &clientOpenAPIStub{
+			responseListPayload: tc.responseListPayload,
+			responsePayload:     tc.responsePayload,
+		}
+		err = d.read(resourceData, client)
+		assert.Equal(t, tc.expectedCountRequestsReceived, client.countRequestsReceived, tc.name)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedCount, resourceData.Get(dataSourceCountMatchCountProperty), tc.name)
+		assert.Equal(t, tc.expectedExists, resourceData.Get(dataSourceCountExistsProperty), tc.name)
+		assert.Equal(t, "/v1/cdns?label=my_label", resourceData.Id(), tc.name)
+	}
+}
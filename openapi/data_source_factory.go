@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -15,6 +17,11 @@ const dataSourceFilterSchemaValuesPropertyName = "values"
 
 type dataSourceFactory struct {
 	openAPIResource SpecResource
+	// responseCache is used to serve the reads of the same data source with the responses already received
+	responseCache *dataSourceResponseCache
+	// stateEncrypter is used to encrypt the properties with the x-terraform-encrypt-in-state extension; nil if the state
+	// encryption is not configured
+	stateEncrypter *stateEncrypter
 }
 
 type filters []filter
@@ -26,6 +33,7 @@ type filter struct {
 func newDataSourceFactory(openAPIResource SpecResource) dataSourceFactory {
 	return dataSourceFactory{
 		openAPIResource: openAPIResource,
+		responseCache:   newDataSourceResponseCache(),
 	}
 }
 
@@ -34,9 +42,19 @@ func (d dataSourceFactory) createTerraformDataSource() (*schema.Resource, error)
 	if err != nil {
 		return nil, err
 	}
+	timeouts, err := createDataSourceTimeouts(d.openAPIResource)
+	if err != nil {
+		return nil, err
+	}
+	specSchema, err := d.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
 	return &schema.Resource{
-		Schema:      s,
-		ReadContext: crudWithContext(d.read, schema.TimeoutRead, d.openAPIResource.GetResourceName()),
+		Description:        specSchema.Description,
+		Schema:             s,
+		ReadWithoutTimeout: dataSourceReadWithContext(d.read, d.openAPIResource.GetResourceName(), *timeouts.Read),
+		Timeouts:           timeouts,
 	}, nil
 }
 
@@ -49,25 +67,37 @@ func (d dataSourceFactory) createTerraformDataSourceSchema() (map[string]*schema
 	if err != nil {
 		return nil, err
 	}
+	if err := validateProtectedStateProperties(d.openAPIResource, specSchema, d.stateEncrypter); err != nil {
+		return nil, err
+	}
 	dataSourceSchema[dataSourceFilterPropertyName] = d.dataSourceFiltersSchema()
+	for propertyName, propertySchema := range paginationSchema(d.getPagination()) {
+		if _, exists := dataSourceSchema[propertyName]; exists {
+			return nil, fmt.Errorf("resource '%s' data source can not be created: property '%s' collides with the '%s' pagination attribute", d.openAPIResource.GetResourceName(), propertyName, propertyName)
+		}
+		dataSourceSchema[propertyName] = propertySchema
+	}
 	return dataSourceSchema, nil
 }
 
 func (d dataSourceFactory) dataSourceFiltersSchema() *schema.Schema {
 	return &schema.Schema{
-		Type:     schema.TypeSet,
-		Optional: true,
-		ForceNew: true,
+		Type:        schema.TypeSet,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Filters narrowing down the items returned by the API to the one the data source is populated with",
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				dataSourceFilterSchemaNamePropertyName: {
-					Type:     schema.TypeString,
-					Required: true,
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Name of the property the items are filtered by",
 				},
 				dataSourceFilterSchemaValuesPropertyName: {
-					Type:     schema.TypeList,
-					Required: true,
-					Elem:     &schema.Schema{Type: schema.TypeString},
+					Type:        schema.TypeList,
+					Required:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Values the property is matched against",
 				},
 			},
 		},
@@ -94,38 +124,170 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 		return err
 	}
 
-	responsePayload := []map[string]interface{}{}
-	resp, err := openAPIClient.List(d.openAPIResource, &responsePayload, parentIDs...)
+	items, header, err := d.list(openAPIClient, parentIDs, resourcePath)
+	if err != nil {
+		return err
+	}
+	page := newDataSourcePage(d.getPagination(), header, len(items))
+	filteredResults := d.filterResults(filters, items)
+
+	if len(filteredResults) == 0 {
+		return fmt.Errorf("your query returned no results. Please change your search criteria and try again%s", page.truncatedHint())
+	}
+
+	if len(filteredResults) > 1 {
+		return fmt.Errorf("your query returned contains more than one result (%s). Please change your search criteria to make it more specific", strings.Join(d.getSortedIdentifiers(filteredResults), ", "))
+	}
+
+	result := filteredResults[0]
+	err = setStateID(d.openAPIResource, data, result)
 	if err != nil {
 		return err
 	}
 
-	if err := checkHTTPStatusCode(d.openAPIResource, resp, []int{http.StatusOK}); err != nil {
-		return fmt.Errorf("[data source='%s'] GET %s failed: %s", resourceName, resourcePath, err)
+	if d.openAPIResource.getResourceOperations().List.isDataSourceDetailFetchEnabled() {
+		result, err = d.fetchDetail(openAPIClient, data.Id(), parentIDs, resourcePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	remoteData, err := protectStateProperties(d.openAPIResource, d.stateEncrypter, result, data)
+	if err != nil {
+		return err
+	}
+	if err := dataSourceUpdateStateWithPayloadData(d.openAPIResource, remoteData, data); err != nil {
+		return err
+	}
+	return page.updateState(data)
+}
+
+// listMatches returns the items of the collection that match the filters passed in
+func (d dataSourceFactory) listMatches(openAPIClient ClientOpenAPI, filters filters, parentIDs []string, resourcePath string) ([]map[string]interface{}, error) {
+	items, _, err := d.list(openAPIClient, parentIDs, resourcePath)
+	if err != nil {
+		return nil, err
+	}
+	return d.filterResults(filters, items), nil
+}
+
+// list returns the items of the collection along with the headers of the collection response
+func (d dataSourceFactory) list(openAPIClient ClientOpenAPI, parentIDs []string, resourcePath string) ([]map[string]interface{}, http.Header, error) {
+	resourceName := d.openAPIResource.GetResourceName()
+	responsePayload := []map[string]interface{}{}
+	header, err := d.responseCache.getWithHeader(openAPIClient, resourcePath, &responsePayload, func(responsePayload interface{}) (http.Header, error) {
+		resp, err := openAPIClient.List(d.openAPIResource, responsePayload, parentIDs...)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkHTTPStatusCode(d.openAPIResource, resp, []int{http.StatusOK}); err != nil {
+			return nil, fmt.Errorf("[data source='%s'] GET %s failed: %s", resourceName, resourcePath, err)
+		}
+		return resp.Header, nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
+	return responsePayload, header, nil
+}
 
+// filterResults returns the items that match the filters passed in
+func (d dataSourceFactory) filterResults(filters filters, items []map[string]interface{}) []map[string]interface{} {
 	var filteredResults []map[string]interface{}
-	for _, payloadItem := range responsePayload {
+	for _, payloadItem := range items {
 		match := d.filterMatch(filters, payloadItem)
 		if match {
 			filteredResults = append(filteredResults, payloadItem)
 		}
 	}
+	return filteredResults
+}
 
-	if len(filteredResults) == 0 {
-		return fmt.Errorf("your query returned no results. Please change your search criteria and try again")
+// getPagination returns the pagination metadata declared in the root path GET operation; nil if the operation does not
+// declare any pagination headers
+func (d dataSourceFactory) getPagination() *specPagination {
+	operation := d.openAPIResource.getResourceOperations().List
+	if operation == nil {
+		return nil
 	}
+	return operation.pagination
+}
 
-	if len(filteredResults) > 1 {
-		return fmt.Errorf("your query returned contains more than one result. Please change your search criteria to make it more specific")
+// fetchDetail returns the detailed representation of the data source match with the given id, fetched via the instance GET
+// operation. This is used when the collection returns a summary representation of the items that does not contain all
+// the properties of the resource (x-terraform-data-source-detail-fetch)
+func (d dataSourceFactory) fetchDetail(openAPIClient ClientOpenAPI, id string, parentIDs []string, resourcePath string) (map[string]interface{}, error) {
+	resourceName := d.openAPIResource.GetResourceName()
+	responsePayload := map[string]interface{}{}
+	err := d.responseCache.get(openAPIClient, fmt.Sprintf("%s/%s", resourcePath, id), &responsePayload, func(responsePayload interface{}) error {
+		resp, err := openAPIClient.Get(d.openAPIResource, id, responsePayload, parentIDs...)
+		if err != nil {
+			return err
+		}
+		if err := checkHTTPStatusCode(d.openAPIResource, resp, []int{http.StatusOK}); err != nil {
+			return fmt.Errorf("[data source='%s'] GET %s/%s failed: %s", resourceName, resourcePath, id, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return responsePayload, nil
+}
 
-	err = setStateID(d.openAPIResource, data, filteredResults[0])
-	if err != nil {
-		return err
+// getSortedIdentifiers returns the identifiers of the payload items passed in sorted deterministically (see lessIdentifier),
+// so the results reported are stable across runs regardless of the order the API returns them
+func (d dataSourceFactory) getSortedIdentifiers(payloadItems []map[string]interface{}) []string {
+	specSchemaDefinition, _ := d.openAPIResource.GetResourceSchema() // ignoring error because will be caught beforehand when data source is constructed via createTerraformDataSourceSchema
+	identifierProperty, _ := specSchemaDefinition.getResourceIdentifier()
+	identifiers := make([]string, 0, len(payloadItems))
+	for _, payloadItem := range payloadItems {
+		identifiers = append(identifiers, getPayloadIdentifier(payloadItem[identifierProperty]))
 	}
+	sort.SliceStable(identifiers, func(i, j int) bool {
+		return lessIdentifier(identifiers[i], identifiers[j])
+	})
+	return identifiers
+}
 
-	return dataSourceUpdateStateWithPayloadData(d.openAPIResource, filteredResults[0], data)
+// getPayloadIdentifier returns the string representation of the identifier value passed in, formatted the same way as the
+// data source ID
+func getPayloadIdentifier(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.Itoa(int(v))
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// lessIdentifier reports whether the identifier a sorts before b. The numeric identifiers sort before the rest and are
+// compared numerically (e,g: 9 < 10), whereas the rest are compared lexically; this is a total order so the result does
+// not depend on the order the identifiers are sorted from (e,g: 2 < 10 < 1a). Only finite numbers are considered numeric
+// since NaN is not ordered, so identifiers such as NaN or Inf are compared lexically
+func lessIdentifier(a, b string) bool {
+	numberA, isNumberA := parseFiniteNumber(a)
+	numberB, isNumberB := parseFiniteNumber(b)
+	if isNumberA != isNumberB {
+		return isNumberA
+	}
+	if isNumberA && numberA != numberB {
+		return numberA < numberB
+	}
+	return a < b
+}
+
+// parseFiniteNumber returns the number the value passed in represents and true if the value is a finite number
+func parseFiniteNumber(value string) (float64, bool) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, false
+	}
+	return number, true
 }
 
 func (d dataSourceFactory) filterMatch(filters filters, payloadItem map[string]interface{}) bool {
@@ -2,6 +2,9 @@ package openapi
 
 import (
 	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -46,7 +49,9 @@ func TestCreateTerraformDataSource(t *testing.T) {
 		if tc.expectedError == nil {
 			assert.Nil(t, err, tc.name)
 			assert.NotNil(t, dataSource, tc.name)
-			assert.NotNil(t, dataSource.ReadContext, tc.name)
+			assert.NotNil(t, dataSource.ReadWithoutTimeout, tc.name)
+			assert.Nil(t, dataSource.ReadContext, tc.name)
+			assert.Equal(t, defaultDataSourceReadTimeout, *dataSource.Timeouts.Read, tc.name)
 			assert.Nil(t, dataSource.Read, tc.name)
 			assert.Nil(t, dataSource.Delete, tc.name)
 			assert.Nil(t, dataSource.Create, tc.name)
@@ -187,15 +192,15 @@ func TestDataSourceRead(t *testing.T) {
 			},
 			responsePayload: []map[string]interface{}{
 				{
-					"id":    "someID",
+					"id":    "someOtherID",
 					"label": "my_label",
 				},
 				{
-					"id":    "someOtherID",
+					"id":    "someID",
 					"label": "my_label",
 				},
 			},
-			expectedError: errors.New("your query returned contains more than one result. Please change your search criteria to make it more specific"),
+			expectedError: errors.New("your query returned contains more than one result (someID, someOtherID). Please change your search criteria to make it more specific"),
 		},
 		{
 			name: "validate input fails",
@@ -303,6 +308,99 @@ func TestDataSourceRead_Subresource(t *testing.T) {
 	assert.Equal(t, TelemetryResourceOperationRead, telemetryHandlerTFOperationReceived)
 }
 
+func TestDataSourceRead_DetailFetch(t *testing.T) {
+	testCases := []struct {
+		name                string
+		detailFetchEnabled  bool
+		expectedGetCalls    int
+		expectedDescription string
+	}{
+		{
+			name:                "detail fetch enabled populates the data source with the instance GET response",
+			detailFetchEnabled:  true,
+			expectedGetCalls:    1,
+			expectedDescription: "some detailed description",
+		},
+		{
+			name:                "detail fetch disabled populates the data source with the collection item",
+			detailFetchEnabled:  false,
+			expectedGetCalls:    0,
+			expectedDescription: "",
+		},
+	}
+	for _, tc := range testCases {
+		dataSourceFactory := dataSourceFactory{
+			openAPIResource: &specStubResource{
+				name: "resourceName",
+				path: "/v1/cdns",
+				schemaDefinition: &SpecSchemaDefinition{
+					Properties: SpecSchemaDefinitionProperties{
+						newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+						newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+						newStringSchemaDefinitionPropertyWithDefaults("description", "", false, true, nil),
+					},
+				},
+				resourceListOperation: &specResourceOperation{isDetailFetchEnabled: tc.detailFetchEnabled},
+			},
+		}
+		resourceSchema, err := dataSourceFactory.createTerraformDataSourceSchema()
+		require.NoError(t, err, tc.name)
+		filtersInput := map[string]interface{}{
+			dataSourceFilterPropertyName: []interface{}{
+				newFilter("label", []interface{}{"my_label"}),
+			},
+		}
+		resourceData := schema.TestResourceDataRaw(t, resourceSchema, filtersInput)
+		client := &clientOpenAPIStub{
+			responseListPayload: []map[string]interface{}{
+				{"id": "someID", "label": "my_label"},
+				{"id": "someOtherID", "label": "other_label"},
+			},
+			responsePayload: map[string]interface{}{
+				"id":          "someID",
+				"label":       "my_label",
+				"description": "some detailed description",
+			},
+		}
+		err = dataSourceFactory.read(resourceData, client)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedGetCalls, client.getCalls, tc.name)
+		if tc.detailFetchEnabled {
+			assert.Equal(t, "someID", client.idReceived, tc.name)
+		}
+		assert.Equal(t, "someID", resourceData.Id(), tc.name)
+		assert.Equal(t, "my_label", resourceData.Get("label"), tc.name)
+		assert.Equal(t, tc.expectedDescription, resourceData.Get("description"), tc.name)
+	}
+}
+
+func TestDataSourceRead_DetailFetch_Fails_Because_Bad_Status_Code(t *testing.T) {
+	dataSourceFactory := dataSourceFactory{
+		openAPIResource: &specStubResource{
+			name: "resourceName",
+			path: "/v1/cdns",
+			schemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+					newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+				},
+			},
+			resourceListOperation: &specResourceOperation{isDetailFetchEnabled: true},
+		},
+	}
+	resourceSchema, err := dataSourceFactory.createTerraformDataSourceSchema()
+	require.NoError(t, err)
+	resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	client := &clientOpenAPIStub{
+		responseListPayload: []map[string]interface{}{{"id": "someID", "label": "my_label"}},
+		funcGet: func() (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+		},
+	}
+	err = dataSourceFactory.read(resourceData, client)
+	assert.EqualError(t, err, "[data source='resourceName'] GET /v1/cdns/someID failed: HTTP Response Status Code 404 - Not Found. Could not find resource instance: ")
+}
+
 func TestDataSourceRead_ForNestedObjects(t *testing.T) {
 	// Given ...
 	// ... a schema describing a nested object which is used to ...
@@ -718,3 +816,59 @@ func newFilter(name string, values []interface{}) map[string]interface{} {
 		dataSourceFilterSchemaValuesPropertyName: values,
 	}
 }
+
+func TestGetSortedIdentifiers(t *testing.T) {
+	testCases := []struct {
+		name                string
+		payloadItems        []map[string]interface{}
+		expectedIdentifiers []string
+	}{
+		{
+			name:                "string identifiers are sorted alphabetically",
+			payloadItems:        []map[string]interface{}{{"id": "c"}, {"id": "a"}, {"id": "b"}},
+			expectedIdentifiers: []string{"a", "b", "c"},
+		},
+		{
+			name:                "numeric identifiers are sorted numerically",
+			payloadItems:        []map[string]interface{}{{"id": float64(10)}, {"id": float64(9)}, {"id": 100}},
+			expectedIdentifiers: []string{"9", "10", "100"},
+		},
+		{
+			name:                "mixed identifiers sort the numeric identifiers first",
+			payloadItems:        []map[string]interface{}{{"id": "b"}, {"id": float64(2)}, {"id": "a"}},
+			expectedIdentifiers: []string{"2", "a", "b"},
+		},
+		{
+			name:                "mixed identifiers sort the numeric identifiers numerically and the rest alphabetically",
+			payloadItems:        []map[string]interface{}{{"id": "1a"}, {"id": "10"}, {"id": "2"}},
+			expectedIdentifiers: []string{"2", "10", "1a"},
+		},
+		{
+			name:                "mixed identifiers are sorted the same way regardless of the order they are returned",
+			payloadItems:        []map[string]interface{}{{"id": "2"}, {"id": "1a"}, {"id": "10"}},
+			expectedIdentifiers: []string{"2", "10", "1a"},
+		},
+		{
+			name:                "NaN and infinite identifiers are sorted alphabetically along with the non numeric identifiers",
+			payloadItems:        []map[string]interface{}{{"id": "NaN"}, {"id": "10"}, {"id": "Inf"}, {"id": "b"}, {"id": "-Inf"}, {"id": "2"}},
+			expectedIdentifiers: []string{"2", "10", "-Inf", "Inf", "NaN", "b"},
+		},
+		{
+			name:                "NaN and infinite identifiers are sorted the same way regardless of the order they are returned",
+			payloadItems:        []map[string]interface{}{{"id": "2"}, {"id": "-Inf"}, {"id": "b"}, {"id": "Inf"}, {"id": "10"}, {"id": "NaN"}},
+			expectedIdentifiers: []string{"2", "10", "-Inf", "Inf", "NaN", "b"},
+		},
+	}
+	for _, tc := range testCases {
+		dataSourceFactory := dataSourceFactory{
+			openAPIResource: &specStubResource{
+				schemaDefinition: &SpecSchemaDefinition{
+					Properties: SpecSchemaDefinitionProperties{
+						newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+					},
+				},
+			},
+		}
+		assert.Equal(t, tc.expectedIdentifiers, dataSourceFactory.getSortedIdentifiers(tc.payloadItems), tc.name)
+	}
+}
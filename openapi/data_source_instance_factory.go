@@ -11,16 +11,27 @@ const dataSourceInstanceIDProperty = "id"
 
 type dataSourceInstanceFactory struct {
 	openAPIResource SpecResource
+	// responseCache is used to serve the reads of the same data source instance with the responses already received
+	responseCache *dataSourceResponseCache
+	// stateEncrypter is used to encrypt the properties with the x-terraform-encrypt-in-state extension; nil if the state
+	// encryption is not configured
+	stateEncrypter *stateEncrypter
 }
 
 func newDataSourceInstanceFactory(openAPIResource SpecResource) dataSourceInstanceFactory {
 	return dataSourceInstanceFactory{
 		openAPIResource: openAPIResource,
+		responseCache:   newDataSourceResponseCache(),
 	}
 }
 
 func (d dataSourceInstanceFactory) getDataSourceInstanceName() string {
-	return fmt.Sprintf("%s_instance", d.openAPIResource.GetResourceName())
+	return dataSourceInstanceName(d.openAPIResource.GetResourceName())
+}
+
+// dataSourceInstanceName returns the name of the data source instance of the resource with the given name
+func dataSourceInstanceName(resourceName string) string {
+	return fmt.Sprintf("%s_instance", resourceName)
 }
 
 func (d dataSourceInstanceFactory) createTerraformInstanceDataSource() (*schema.Resource, error) {
@@ -28,9 +39,19 @@ func (d dataSourceInstanceFactory) createTerraformInstanceDataSource() (*schema.
 	if err != nil {
 		return nil, err
 	}
+	timeouts, err := createDataSourceTimeouts(d.openAPIResource)
+	if err != nil {
+		return nil, err
+	}
+	specSchema, err := d.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
 	return &schema.Resource{
-		Schema:      s,
-		ReadContext: crudWithContext(d.read, schema.TimeoutRead, d.openAPIResource.GetResourceName()),
+		Description:        specSchema.Description,
+		Schema:             s,
+		ReadWithoutTimeout: dataSourceReadWithContext(d.read, d.openAPIResource.GetResourceName(), *timeouts.Read),
+		Timeouts:           timeouts,
 	}, nil
 }
 
@@ -43,14 +64,18 @@ func (d dataSourceInstanceFactory) createTerraformDataSourceInstanceSchema() (ma
 	if err != nil {
 		return nil, err
 	}
+	if err := validateProtectedStateProperties(d.openAPIResource, specSchema, d.stateEncrypter); err != nil {
+		return nil, err
+	}
 	dataSourceSchema[dataSourceInstanceIDProperty] = d.dataSourceInstanceSchema()
 	return dataSourceSchema, nil
 }
 
 func (d dataSourceInstanceFactory) dataSourceInstanceSchema() *schema.Schema {
 	return &schema.Schema{
-		Type:     schema.TypeString,
-		Required: true,
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "ID of the instance the data source is populated with",
 	}
 }
 
@@ -73,16 +98,26 @@ func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}
 		return fmt.Errorf("data source 'id' property value must be populated")
 	}
 	responsePayload := map[string]interface{}{}
-	resp, err := openAPIClient.Get(d.openAPIResource, id.(string), &responsePayload, parentIDs...)
+	err = d.responseCache.get(openAPIClient, fmt.Sprintf("%s/%s", resourcePath, id), &responsePayload, func(responsePayload interface{}) error {
+		resp, err := openAPIClient.Get(d.openAPIResource, id.(string), responsePayload, parentIDs...)
+		if err != nil {
+			return err
+		}
+		if err := checkHTTPStatusCode(d.openAPIResource, resp, []int{http.StatusOK}); err != nil {
+			return fmt.Errorf("[data source instance='%s'] GET %s failed: %s", resourceName, resourcePath, err)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	if err := checkHTTPStatusCode(d.openAPIResource, resp, []int{http.StatusOK}); err != nil {
-		return fmt.Errorf("[data source instance='%s'] GET %s failed: %s", resourceName, resourcePath, err)
-	}
 	err = setStateID(d.openAPIResource, data, responsePayload)
 	if err != nil {
 		return err
 	}
-	return dataSourceUpdateStateWithPayloadData(d.openAPIResource, responsePayload, data)
+	remoteData, err := protectStateProperties(d.openAPIResource, d.stateEncrypter, responsePayload, data)
+	if err != nil {
+		return err
+	}
+	return dataSourceUpdateStateWithPayloadData(d.openAPIResource, remoteData, data)
 }
@@ -58,7 +58,9 @@ func TestCreateTerraformInstanceDataSource(t *testing.T) {
 		if tc.expectedError == nil {
 			assert.Nil(t, err, tc.name)
 			assert.NotNil(t, dataSource, tc.name)
-			assert.NotNil(t, dataSource.ReadContext, tc.name)
+			assert.NotNil(t, dataSource.ReadWithoutTimeout, tc.name)
+			assert.Nil(t, dataSource.ReadContext, tc.name)
+			assert.Equal(t, defaultDataSourceReadTimeout, *dataSource.Timeouts.Read, tc.name)
 			assert.Nil(t, dataSource.Read, tc.name)
 			assert.Nil(t, dataSource.Delete, tc.name)
 			assert.Nil(t, dataSource.Create, tc.name)
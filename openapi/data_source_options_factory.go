@@ -0,0 +1,213 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const dataSourceOptionsPathParametersProperty = "path_parameters"
+const dataSourceOptionsQueryParametersProperty = "query_parameters"
+
+// dataSourceOptionsFactory creates the options data source of the resources with the x-terraform-options-path extension.
+// The data source exposes the values the API currently accepts for the resource properties (e,g: the node sizes available
+// in a region), so users can validate and iterate over values that are not static enums in the OpenAPI document. The
+// options endpoint is expected to return an object containing, for each property, the list of values accepted (e,g:
+// {"node_size": ["small", "medium"], "region": ["us-east-1"]})
+type dataSourceOptionsFactory struct {
+	openAPIResource SpecResource
+}
+
+func newDataSourceOptionsFactory(openAPIResource SpecResource) dataSourceOptionsFactory {
+	return dataSourceOptionsFactory{
+		openAPIResource: openAPIResource,
+	}
+}
+
+// dataSourceOptionsName returns the name of the options data source of the resource with the given name
+func dataSourceOptionsName(resourceName string) string {
+	return fmt.Sprintf("%s_options", resourceName)
+}
+
+// getOptionsPath returns the path of the options endpoint defined in the resource root POST operation; empty if the
+// resource does not have the x-terraform-options-path extension
+func (d dataSourceOptionsFactory) getOptionsPath() string {
+	operation := d.openAPIResource.getResourceOperations().Post
+	if operation == nil {
+		return ""
+	}
+	return operation.optionsPath
+}
+
+func (d dataSourceOptionsFactory) createTerraformOptionsDataSource() (*schema.Resource, error) {
+	s, err := d.createTerraformOptionsDataSourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	return &schema.Resource{
+		Schema:      s,
+		ReadContext: crudWithContext(d.read, schema.TimeoutRead, d.openAPIResource.GetResourceName()),
+	}, nil
+}
+
+// createTerraformOptionsDataSourceSchema returns the schema of the options data source, which contains a computed list of
+// the values accepted for each of the user configurable primitive (or list of primitives) properties of the resource
+func (d dataSourceOptionsFactory) createTerraformOptionsDataSourceSchema() (map[string]*schema.Schema, error) {
+	specSchema, err := d.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	s := map[string]*schema.Schema{
+		dataSourceOptionsPathParametersProperty: {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Values of the path parameters of the options endpoint (e,g: {project_id})",
+		},
+		dataSourceOptionsQueryParametersProperty: {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Query parameters sent to the options endpoint to narrow down the values returned (e,g: region)",
+		},
+	}
+	for _, property := range d.getOptionsProperties(specSchema) {
+		propertyName := property.GetTerraformCompliantPropertyName()
+		if _, exists := s[propertyName]; exists {
+			return nil, fmt.Errorf("resource '%s' options data source can not be created: property '%s' collides with the '%s' data source argument", d.openAPIResource.GetResourceName(), propertyName, propertyName)
+		}
+		s[propertyName] = &schema.Schema{
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: fmt.Sprintf("Values currently accepted by the API for the '%s' property", propertyName),
+		}
+	}
+	return s, nil
+}
+
+// getOptionsProperties returns the resource properties the options data source exposes the accepted values for
+func (d dataSourceOptionsFactory) getOptionsProperties(specSchema *SpecSchemaDefinition) []*SpecSchemaDefinitionProperty {
+	var properties []*SpecSchemaDefinitionProperty
+	for _, property := range specSchema.Properties {
+		if property.isComputed() || property.IsParentProperty || property.isPropertyNamedID() {
+			continue
+		}
+		if property.isPrimitiveProperty() || (property.isArrayProperty() && !property.isArrayOfObjectsProperty()) {
+			properties = append(properties, property)
+		}
+	}
+	return properties
+}
+
+func (d dataSourceOptionsFactory) read(data *schema.ResourceData, i interface{}) error {
+	openAPIClient := i.(ClientOpenAPI)
+
+	if d.openAPIResource == nil {
+		return fmt.Errorf("missing openAPI resource configuration")
+	}
+	dataSourceName := dataSourceOptionsName(d.openAPIResource.GetResourceName())
+
+	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, dataSourceName)
+
+	path, err := renderOptionsPath(d.getOptionsPath(), toStringMap(data.Get(dataSourceOptionsPathParametersProperty)))
+	if err != nil {
+		return fmt.Errorf("[data source='%s'] %s", dataSourceName, err)
+	}
+	queryParameters := toStringMap(data.Get(dataSourceOptionsQueryParametersProperty))
+	responsePayload := map[string]interface{}{}
+	resp, err := openAPIClient.GetOptions(d.openAPIResource, path, queryParameters, &responsePayload)
+	if err != nil {
+		return err
+	}
+	if err := checkHTTPStatusCode(d.openAPIResource, resp, []int{http.StatusOK}); err != nil {
+		return fmt.Errorf("[data source='%s'] GET %s failed: %s", dataSourceName, path, err)
+	}
+
+	specSchema, err := d.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	for _, property := range d.getOptionsProperties(specSchema) {
+		values, err := getOptionValues(responsePayload[property.Name])
+		if err != nil {
+			return fmt.Errorf("[data source='%s'] property '%s' options not valid: %s", dataSourceName, property.Name, err)
+		}
+		if err := data.Set(property.GetTerraformCompliantPropertyName(), values); err != nil {
+			return err
+		}
+	}
+	data.SetId(appendOptionsQueryParameters(path, queryParameters))
+	return nil
+}
+
+// renderOptionsPath returns the options path with the path parameters (e,g: {project_id}) resolved with the values passed in
+func renderOptionsPath(optionsPath string, pathParameters map[string]string) (string, error) {
+	path := optionsPath
+	for _, pathParameter := range pathParameterPlaceholderRegex.FindAllString(optionsPath, -1) {
+		value, exists := pathParameters[strings.Trim(pathParameter, "{}")]
+		if !exists || value == "" {
+			return "", fmt.Errorf("missing value for the path parameter %s of the options path '%s'", pathParameter, optionsPath)
+		}
+		path = strings.Replace(path, pathParameter, url.PathEscape(value), 1)
+	}
+	return path, nil
+}
+
+// appendOptionsQueryParameters appends the query parameters passed in to the path, sorted by name (e,g: /v1/clusters/options?region=us-east-1)
+func appendOptionsQueryParameters(path string, queryParameters map[string]string) string {
+	if len(queryParameters) == 0 {
+		return path
+	}
+	values := url.Values{}
+	for name, value := range queryParameters {
+		values.Set(name, value)
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + values.Encode()
+}
+
+// getOptionValues returns the values of the list passed in as strings; objects are JSON encoded. An empty list is returned
+// if the options endpoint does not return the values of the property
+func getOptionValues(value interface{}) ([]string, error) {
+	if value == nil {
+		return []string{}, nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a list of values but received %v", value)
+	}
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		switch v := item.(type) {
+		case string:
+			values = append(values, v)
+		case map[string]interface{}, []interface{}:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, string(b))
+		default:
+			values = append(values, fmt.Sprintf("%v", v))
+		}
+	}
+	return values, nil
+}
+
+// toStringMap converts the value of a schema.TypeMap of strings into a map of strings
+func toStringMap(value interface{}) map[string]string {
+	m := map[string]string{}
+	values, _ := value.(map[string]interface{})
+	for k, v := range values {
+		m[k] = fmt.Sprintf("%v", v)
+	}
+	return m
+}
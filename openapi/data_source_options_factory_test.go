@@ -0,0 +1,99 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOptionsResource(schemaDefinitionProperties ...*SpecSchemaDefinitionProperty) *specStubResource {
+	schemaDefinition := &SpecSchemaDefinition{Properties: schemaDefinitionProperties}
+	return newSpecStubResourceWithOperations("cluster", "/v1/projects/{project_id}/clusters", false, schemaDefinition, &specResourceOperation{optionsPath: "/v1/projects/{project_id}/clusters/options"}, nil, nil, nil)
+}
+
+func TestCreateTerraformOptionsDataSource(t *testing.T) {
+	parentProperty := newStringSchemaDefinitionPropertyWithDefaults("project_id", "", true, false, nil)
+	parentProperty.IsParentProperty = true
+	d := newDataSourceOptionsFactory(newTestOptionsResource(
+		idProperty,
+		parentProperty,
+		newStringSchemaDefinitionPropertyWithDefaults("nodeSize", "", true, false, nil),
+		newIntSchemaDefinitionPropertyWithDefaults("node_count", "", false, false, nil),
+		newListSchemaDefinitionPropertyWithDefaults("zones", "", false, false, false, nil, TypeString, nil),
+		newStringSchemaDefinitionPropertyWithDefaults("status", "", false, true, nil),
+		newObjectSchemaDefinitionPropertyWithDefaults("config", "", false, false, false, nil, &SpecSchemaDefinition{}),
+	))
+	assert.Equal(t, "/v1/projects/{project_id}/clusters/options", d.getOptionsPath())
+	dataSource, err := d.createTerraformOptionsDataSource()
+	assert.NoError(t, err)
+	assert.NotNil(t, dataSource.ReadContext)
+	assert.Nil(t, dataSource.CreateContext)
+	var attributes []string
+	for name, attribute := range dataSource.Schema {
+		attributes = append(attributes, name)
+		if name == dataSourceOptionsPathParametersProperty || name == dataSourceOptionsQueryParametersProperty {
+			assert.Equal(t, schema.TypeMap, attribute.Type, name)
+			assert.True(t, attribute.Optional, name)
+			continue
+		}
+		assert.Equal(t, schema.TypeList, attribute.Type, name)
+		assert.True(t, attribute.Computed, name)
+	}
+	assert.ElementsMatch(t, []string{dataSourceOptionsPathParametersProperty, dataSourceOptionsQueryParametersProperty, "node_size", "node_count", "zones"}, attributes)
+
+	d = newDataSourceOptionsFactory(newTestOptionsResource(newStringSchemaDefinitionPropertyWithDefaults("query_parameters", "", true, false, nil)))
+	_, err = d.createTerraformOptionsDataSource()
+	assert.EqualError(t, err, "resource 'cluster' options data source can not be created: property 'query_parameters' collides with the 'query_parameters' data source argument")
+}
+
+func TestDataSourceOptionsRead(t *testing.T) {
+	d := newDataSourceOptionsFactory(newTestOptionsResource(
+		newStringSchemaDefinitionPropertyWithDefaults("nodeSize", "", true, false, nil),
+		newIntSchemaDefinitionPropertyWithDefaults("node_count", "", false, false, nil),
+		newListSchemaDefinitionPropertyWithDefaults("zones", "", false, false, false, nil, TypeString, nil),
+	))
+	dataSource, err := d.createTerraformOptionsDataSource()
+	assert.NoError(t, err)
+	data := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		dataSourceOptionsPathParametersProperty:  map[string]interface{}{"project_id": "p1"},
+		dataSourceOptionsQueryParametersProperty: map[string]interface{}{"region": "us-east-1"},
+	})
+	client := &clientOpenAPIStub{responsePayload: map[string]interface{}{"nodeSize": []interface{}{"small", "medium"}, "node_count": []interface{}{float64(3), float64(5)}}}
+	assert.NoError(t, d.read(data, client))
+	assert.Equal(t, []string{"/v1/projects/p1/clusters/options?region=us-east-1"}, client.optionsRequestsReceived)
+	assert.Equal(t, "/v1/projects/p1/clusters/options?region=us-east-1", data.Id())
+	assert.Equal(t, []interface{}{"small", "medium"}, data.Get("node_size"))
+	assert.Equal(t, []interface{}{"3", "5"}, data.Get("node_count"))
+	assert.Equal(t, []interface{}{}, data.Get("zones"))
+
+	// missing path parameters
+	data = schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{})
+	err = d.read(data, &clientOpenAPIStub{})
+	assert.EqualError(t, err, "[data source='cluster_options'] missing value for the path parameter {project_id} of the options path '/v1/projects/{project_id}/clusters/options'")
+
+	// non 200 response
+	data = schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{dataSourceOptionsPathParametersProperty: map[string]interface{}{"project_id": "p1"}})
+	err = d.read(data, &clientOpenAPIStub{returnHTTPCode: http.StatusInternalServerError})
+	assert.EqualError(t, err, "[data source='cluster_options'] GET /v1/projects/p1/clusters/options failed: [resource='cluster'] HTTP Response Status Code 500 not matching expected one [200] ()")
+
+	// client error
+	err = d.read(data, &clientOpenAPIStub{error: errors.New("some error")})
+	assert.EqualError(t, err, "some error")
+
+	// options not returned as a list
+	err = d.read(data, &clientOpenAPIStub{responsePayload: map[string]interface{}{"zones": "us-east-1a"}})
+	assert.EqualError(t, err, "[data source='cluster_options'] property 'zones' options not valid: expected a list of values but received us-east-1a")
+}
+
+func TestGetOptionValues(t *testing.T) {
+	values, err := getOptionValues(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, values)
+
+	values, err = getOptionValues([]interface{}{"small", float64(2), true, map[string]interface{}{"name": "large"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"small", "2", "true", `{"name":"large"}`}, values)
+}
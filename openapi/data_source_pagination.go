@@ -0,0 +1,153 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const dataSourcePaginationTotalProperty = "pagination_total"
+const dataSourcePaginationNextCursorProperty = "pagination_next_cursor"
+const dataSourcePaginationTruncatedProperty = "pagination_truncated"
+
+// linkHeader defines the standard header (RFC 8288) containing the links to the other pages of the collection
+const linkHeader = "Link"
+
+// dataSourcePage contains the pagination metadata of the collection response a data source was read from
+type dataSourcePage struct {
+	pagination *specPagination
+	// itemsReturned contains the number of items returned in the collection response
+	itemsReturned int
+	// total contains the total number of items of the collection; nil if the API did not return it
+	total *int
+	// nextCursor contains the cursor of the next page; empty if there are no more pages
+	nextCursor string
+}
+
+// newDataSourcePage returns the pagination metadata contained in the headers of the collection response; nil if the
+// collection does not declare any pagination headers
+func newDataSourcePage(pagination *specPagination, header http.Header, itemsReturned int) *dataSourcePage {
+	if pagination == nil {
+		return nil
+	}
+	page := &dataSourcePage{
+		pagination:    pagination,
+		itemsReturned: itemsReturned,
+	}
+	if pagination.totalHeader != "" {
+		if value := header.Get(pagination.totalHeader); value != "" {
+			total, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				clientLog.Warn("ignoring pagination total header %s, value '%s' is not a number", pagination.totalHeader, value)
+			} else {
+				page.total = &total
+			}
+		}
+	}
+	if pagination.nextCursorHeader != "" {
+		if strings.EqualFold(pagination.nextCursorHeader, linkHeader) {
+			page.nextCursor = getNextLink(header.Values(linkHeader))
+		} else {
+			page.nextCursor = header.Get(pagination.nextCursorHeader)
+		}
+	}
+	return page
+}
+
+// isTruncated returns true if the collection response does not contain all the items of the collection, that is when
+// there is a next page or the total number of items is greater than the items returned
+func (p *dataSourcePage) isTruncated() bool {
+	if p == nil {
+		return false
+	}
+	return p.nextCursor != "" || (p.total != nil && *p.total > p.itemsReturned)
+}
+
+// truncatedHint returns the hint appended to the errors of the data source reads when the collection response is truncated;
+// empty otherwise
+func (p *dataSourcePage) truncatedHint() string {
+	if !p.isTruncated() {
+		return ""
+	}
+	if p.total != nil {
+		return fmt.Sprintf(" (note the collection response is paginated and only %d out of %d items were returned)", p.itemsReturned, *p.total)
+	}
+	return fmt.Sprintf(" (note the collection response is paginated and only the first %d items were returned)", p.itemsReturned)
+}
+
+// updateState sets the pagination attributes of the data source; nothing is set if the collection does not declare any
+// pagination headers
+func (p *dataSourcePage) updateState(data *schema.ResourceData) error {
+	if p == nil {
+		return nil
+	}
+	if p.pagination.totalHeader != "" && p.total != nil {
+		if err := data.Set(dataSourcePaginationTotalProperty, *p.total); err != nil {
+			return err
+		}
+	}
+	if p.pagination.nextCursorHeader != "" {
+		if err := data.Set(dataSourcePaginationNextCursorProperty, p.nextCursor); err != nil {
+			return err
+		}
+	}
+	return data.Set(dataSourcePaginationTruncatedProperty, p.isTruncated())
+}
+
+// paginationSchema returns the computed attributes exposing the pagination metadata declared in the collection; empty if
+// the collection does not declare any pagination headers
+func paginationSchema(pagination *specPagination) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{}
+	if pagination == nil {
+		return s
+	}
+	if pagination.totalHeader != "" {
+		s[dataSourcePaginationTotalProperty] = &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Total number of items of the collection as returned by the API",
+		}
+	}
+	if pagination.nextCursorHeader != "" {
+		s[dataSourcePaginationNextCursorProperty] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cursor of the next page of the collection; empty if there are no more pages",
+		}
+	}
+	s[dataSourcePaginationTruncatedProperty] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the collection returned by the API is truncated, in which case the filters may need to be more specific",
+	}
+	return s
+}
+
+// getNextLink returns the URL of the link with relation type next contained in the Link header values passed in (e,g:
+// <https://api.example.com/v1/cdns?page=2>; rel="next"); empty if there is no next link
+func getNextLink(values []string) string {
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			url := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(url, "<") || !strings.HasSuffix(url, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				nameValue := strings.SplitN(strings.TrimSpace(param), "=", 2)
+				if len(nameValue) != 2 || !strings.EqualFold(strings.TrimSpace(nameValue[0]), "rel") {
+					continue
+				}
+				for _, relation := range strings.Fields(strings.Trim(strings.TrimSpace(nameValue[1]), `"`)) {
+					if strings.EqualFold(relation, "next") {
+						return strings.TrimSuffix(strings.TrimPrefix(url, "<"), ">")
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDataSourcePage(t *testing.T) {
+	total := func(value int) *int { return &value }
+	testCases := []struct {
+		name               string
+		pagination         *specPagination
+		header             http.Header
+		itemsReturned      int
+		expectedTotal      *int
+		expectedNextCursor string
+		expectedTruncated  bool
+		expectedHint       string
+	}{
+		{
+			name:          "collection without pagination headers",
+			itemsReturned: 2,
+		},
+		{
+			name:          "total header with all the items returned",
+			pagination:    &specPagination{totalHeader: "X-Total-Count"},
+			header:        http.Header{"X-Total-Count": []string{"2"}},
+			itemsReturned: 2,
+			expectedTotal: total(2),
+		},
+		{
+			name:              "total header with some of the items returned",
+			pagination:        &specPagination{totalHeader: "X-Total-Count"},
+			header:            http.Header{"X-Total-Count": []string{"150"}},
+			itemsReturned:     100,
+			expectedTotal:     total(150),
+			expectedTruncated: true,
+			expectedHint:      " (note the collection response is paginated and only 100 out of 150 items were returned)",
+		},
+		{
+			name:          "total header that is not a number is ignored",
+			pagination:    &specPagination{totalHeader: "X-Total-Count"},
+			header:        http.Header{"X-Total-Count": []string{"many"}},
+			itemsReturned: 100,
+		},
+		{
+			name:               "next cursor header",
+			pagination:         &specPagination{nextCursorHeader: "X-Next-Cursor"},
+			header:             http.Header{"X-Next-Cursor": []string{"abc"}},
+			itemsReturned:      100,
+			expectedNextCursor: "abc",
+			expectedTruncated:  true,
+			expectedHint:       " (note the collection response is paginated and only the first 100 items were returned)",
+		},
+		{
+			name:          "next cursor header without more pages",
+			pagination:    &specPagination{nextCursorHeader: "X-Next-Cursor"},
+			header:        http.Header{},
+			itemsReturned: 100,
+		},
+		{
+			name:               "link header with next link",
+			pagination:         &specPagination{nextCursorHeader: "link"},
+			header:             http.Header{"Link": []string{`<https://api.example.com/v1/cdns?page=1>; rel="prev", <https://api.example.com/v1/cdns?page=3>; rel="next"`}},
+			itemsReturned:      100,
+			expectedNextCursor: "https://api.example.com/v1/cdns?page=3",
+			expectedTruncated:  true,
+			expectedHint:       " (note the collection response is paginated and only the first 100 items were returned)",
+		},
+		{
+			name:          "link header without next link",
+			pagination:    &specPagination{nextCursorHeader: "Link"},
+			header:        http.Header{"Link": []string{`<https://api.example.com/v1/cdns?page=1>; rel="prev first"`}},
+			itemsReturned: 100,
+		},
+	}
+	for _, tc := range testCases {
+		page := newDataSourcePage(tc.pagination, tc.header, tc.itemsReturned)
+		if tc.pagination == nil {
+			assert.Nil(t, page, tc.name)
+		} else {
+			assert.Equal(t, tc.expectedTotal, page.total, tc.name)
+			assert.Equal(t, tc.expectedNextCursor, page.nextCursor, tc.name)
+		}
+		assert.Equal(t, tc.expectedTruncated, page.isTruncated(), tc.name)
+		assert.Equal(t, tc.expectedHint, page.truncatedHint(), tc.name)
+	}
+}
+
+func TestPaginationSchema(t *testing.T) {
+	assert.Empty(t, paginationSchema(nil))
+	s := paginationSchema(&specPagination{totalHeader: "X-Total-Count"})
+	assert.Len(t, s, 2)
+	assert.Equal(t, schema.TypeInt, s[dataSourcePaginationTotalProperty].Type)
+	assert.Equal(t, schema.TypeBool, s[dataSourcePaginationTruncatedProperty].Type)
+	s = paginationSchema(&specPagination{totalHeader: "X-Total-Count", nextCursorHeader: "X-Next-Cursor"})
+	assert.Len(t, s, 3)
+	assert.Equal(t, schema.TypeString, s[dataSourcePaginationNextCursorProperty].Type)
+	for _, propertySchema := range s {
+		assert.True(t, propertySchema.Computed)
+	}
+}
+
+func TestDataSourceRead_Pagination(t *testing.T) {
+	dataSourceFactory := newDataSourceFactory(&specStubResource{
+		name:                  "cdns_v1",
+		path:                  "/v1/cdns",
+		resourceListOperation: &specResourceOperation{pagination: &specPagination{totalHeader: "X-Total-Count", nextCursorHeader: "X-Next-Cursor"}},
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+			},
+		},
+	})
+	dataSourceSchema, err := dataSourceFactory.createTerraformDataSourceSchema()
+	require.NoError(t, err)
+	client := &clientOpenAPIStub{
+		responseListPayload: []map[string]interface{}{{"id": "someID", "label": "my_label"}},
+		responseListHeader:  http.Header{"X-Total-Count": []string{"250"}, "X-Next-Cursor": []string{"abc"}},
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{
+		dataSourceFilterPropertyName: []interface{}{newFilter("label", []interface{}{"my_label"})},
+	})
+	require.NoError(t, dataSourceFactory.read(resourceData, client))
+	assert.Equal(t, "someID", resourceData.Id())
+	assert.Equal(t, 250, resourceData.Get(dataSourcePaginationTotalProperty))
+	assert.Equal(t, "abc", resourceData.Get(dataSourcePaginationNextCursorProperty))
+	assert.Equal(t, true, resourceData.Get(dataSourcePaginationTruncatedProperty))
+
+	// the pagination headers are served from the response cache too
+	resourceData = schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{
+		dataSourceFilterPropertyName: []interface{}{newFilter("label", []interface{}{"other_label"})},
+	})
+	err = dataSourceFactory.read(resourceData, client)
+	assert.EqualError(t, err, "your query returned no results. Please change your search criteria and try again (note the collection response is paginated and only 1 out of 250 items were returned)")
+	assert.Equal(t, 1, client.listCalls)
+}
+
+func TestCreateTerraformDataSourceSchema_PaginationCollision(t *testing.T) {
+	dataSourceFactory := newDataSourceFactory(&specStubResource{
+		name:                  "cdns_v1",
+		resourceListOperation: &specResourceOperation{pagination: &specPagination{totalHeader: "X-Total-Count"}},
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newIntSchemaDefinitionPropertyWithDefaults("pagination_total", "", false, true, nil),
+			},
+		},
+	})
+	_, err := dataSourceFactory.createTerraformDataSourceSchema()
+	assert.EqualError(t, err, "resource 'cdns_v1' data source can not be created: property 'pagination_total' collides with the 'pagination_total' pagination attribute")
+}
@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const dataSourceProviderInfoName = "provider_info"
+
+const dataSourceProviderInfoSpecVersionProperty = "spec_version"
+const dataSourceProviderInfoSpecChecksumProperty = "spec_checksum"
+const dataSourceProviderInfoProviderVersionProperty = "provider_version"
+const dataSourceProviderInfoOpenAPIProviderVersionProperty = "openapi_provider_version"
+const dataSourceProviderInfoResourcesProperty = "resources"
+const dataSourceProviderInfoHostProperty = "host"
+const dataSourceProviderInfoRegionProperty = "region"
+const dataSourceProviderInfoResourceNamingVersionProperty = "resource_naming_version"
+
+// dataSourceProviderInfoFactory creates the built-in data source that describes the provider itself: the OpenAPI document
+// loaded (version and checksum), the version of the OpenAPI Terraform provider binary, the resources registered and the
+// effective host/region the API calls are made against. This enables users to assert on this information (e,g: in CI)
+// and detect unintended drift in the OpenAPI document served by the API.
+type dataSourceProviderInfoFactory struct {
+	specInfo      SpecInfo
+	resourceNames []string
+	// providerVersion contains the version of custom provider builds. If empty, the OpenAPI Terraform provider version is reported
+	providerVersion string
+	// resourceNamingVersion contains the version of the naming algorithm the resource names are built with; zero means the
+	// current version
+	resourceNamingVersion int
+}
+
+func newDataSourceProviderInfoFactory(specInfo SpecInfo, resourceNames []string, providerVersion string) dataSourceProviderInfoFactory {
+	sortedResourceNames := make([]string, len(resourceNames))
+	copy(sortedResourceNames, resourceNames)
+	sort.Strings(sortedResourceNames)
+	return dataSourceProviderInfoFactory{
+		specInfo:        specInfo,
+		resourceNames:   sortedResourceNames,
+		providerVersion: providerVersion,
+	}
+}
+
+func (d dataSourceProviderInfoFactory) createTerraformProviderInfoDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema:      d.createTerraformProviderInfoDataSourceSchema(),
+		ReadContext: crudWithContext(d.read, schema.TimeoutRead, dataSourceProviderInfoName),
+	}
+}
+
+func (d dataSourceProviderInfoFactory) createTerraformProviderInfoDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		dataSourceProviderInfoSpecVersionProperty: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Version of the API as documented in the OpenAPI document loaded by the provider",
+		},
+		dataSourceProviderInfoSpecChecksumProperty: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "SHA256 checksum of the OpenAPI document loaded by the provider",
+		},
+		dataSourceProviderInfoProviderVersionProperty: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Version of the provider binary (for custom provider builds the version of the custom provider, otherwise the OpenAPI Terraform provider version)",
+		},
+		dataSourceProviderInfoOpenAPIProviderVersionProperty: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Version of the OpenAPI Terraform provider library the provider is built with",
+		},
+		dataSourceProviderInfoResourcesProperty: {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Sorted list of the resources registered in the provider",
+		},
+		dataSourceProviderInfoHostProperty: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Host the API calls are made against (not including resource specific host or endpoint overrides)",
+		},
+		dataSourceProviderInfoRegionProperty: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Region the API calls are made against (only populated for multi-region providers)",
+		},
+		dataSourceProviderInfoResourceNamingVersionProperty: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Version of the naming algorithm the resource and data source names are built with",
+		},
+	}
+}
+
+func (d dataSourceProviderInfoFactory) read(data *schema.ResourceData, i interface{}) error {
+	openAPIClient := i.(ClientOpenAPI)
+
+	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, dataSourceProviderInfoName)
+
+	host, region, err := openAPIClient.GetProviderHost()
+	if err != nil {
+		return fmt.Errorf("[data source='%s'] failed to resolve the provider host: %s", dataSourceProviderInfoName, err)
+	}
+
+	providerVersion := d.providerVersion
+	if providerVersion == "" {
+		providerVersion = version.Version
+	}
+
+	resourceNamingVersion := d.resourceNamingVersion
+	if resourceNamingVersion == 0 {
+		resourceNamingVersion = CurrentResourceNamingVersion
+	}
+
+	values := map[string]interface{}{
+		dataSourceProviderInfoSpecVersionProperty:            d.specInfo.Version,
+		dataSourceProviderInfoSpecChecksumProperty:           d.specInfo.Checksum,
+		dataSourceProviderInfoProviderVersionProperty:        providerVersion,
+		dataSourceProviderInfoOpenAPIProviderVersionProperty: version.Version,
+		dataSourceProviderInfoResourcesProperty:              d.resourceNames,
+		dataSourceProviderInfoHostProperty:                   host,
+		dataSourceProviderInfoRegionProperty:                 region,
+		dataSourceProviderInfoResourceNamingVersionProperty:  resourceNamingVersion,
+	}
+	for propertyName, value := range values {
+		if err := data.Set(propertyName, value); err != nil {
+			return err
+		}
+	}
+	// The checksum uniquely identifies the OpenAPI document loaded; falling back to the data source name if the checksum
+	// is not available so the data source is not considered removed (empty ID)
+	id := d.specInfo.Checksum
+	if id == "" {
+		id = dataSourceProviderInfoName
+	}
+	data.SetId(id)
+	return nil
+}
@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDataSourceProviderInfoFactory(t *testing.T) {
+	specInfo := SpecInfo{Version: "1.0.0", Checksum: "checksum"}
+	resourceNames := []string{"cdn_v1", "bucket", "lb_v1"}
+	d := newDataSourceProviderInfoFactory(specInfo, resourceNames, "2.0.0")
+	assert.Equal(t, specInfo, d.specInfo)
+	assert.Equal(t, []string{"bucket", "cdn_v1", "lb_v1"}, d.resourceNames)
+	assert.Equal(t, []string{"cdn_v1", "bucket", "lb_v1"}, resourceNames, "the input resource names should not be mutated")
+	assert.Equal(t, "2.0.0", d.providerVersion)
+}
+
+func TestCreateTerraformProviderInfoDataSource(t *testing.T) {
+	d := newDataSourceProviderInfoFactory(SpecInfo{}, nil, "")
+	dataSource := d.createTerraformProviderInfoDataSource()
+	assert.NotNil(t, dataSource.ReadContext)
+	assert.Nil(t, dataSource.CreateContext)
+	assert.Nil(t, dataSource.UpdateContext)
+	assert.Nil(t, dataSource.DeleteContext)
+	expectedProperties := []string{
+		dataSourceProviderInfoSpecVersionProperty,
+		dataSourceProviderInfoSpecChecksumProperty,
+		dataSourceProviderInfoProviderVersionProperty,
+		dataSourceProviderInfoOpenAPIProviderVersionProperty,
+		dataSourceProviderInfoResourcesProperty,
+		dataSourceProviderInfoHostProperty,
+		dataSourceProviderInfoRegionProperty,
+		dataSourceProviderInfoResourceNamingVersionProperty,
+	}
+	assert.Len(t, dataSource.Schema, len(expectedProperties))
+	for _, propertyName := range expectedProperties {
+		assert.Contains(t, dataSource.Schema, propertyName)
+		assert.True(t, dataSource.Schema[propertyName].Computed, propertyName)
+	}
+	assert.Equal(t, schema.TypeList, dataSource.Schema[dataSourceProviderInfoResourcesProperty].Type)
+}
+
+func TestDataSourceProviderInfoRead(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		specInfo                SpecInfo
+		providerVersion         string
+		client                  *clientOpenAPIStub
+		expectedID              string
+		expectedProviderVersion string
+		expectedHost            string
+		expectedRegion          string
+		expectedSpecResult      SpecInfo
+		expectedError           error
+	}{
+		{
+			name:                    "happy path - single region provider",
+			specInfo:                SpecInfo{Version: "1.0.0", Checksum: "some_checksum"},
+			client:                  &clientOpenAPIStub{host: "api.server.com"},
+			expectedID:              "some_checksum",
+			expectedProviderVersion: version.Version,
+			expectedHost:            "api.server.com",
+			expectedRegion:          "",
+		},
+		{
+			name:                    "happy path - custom provider build reports its own version",
+			specInfo:                SpecInfo{Version: "1.0.0", Checksum: "some_checksum"},
+			providerVersion:         "2.0.0",
+			client:                  &clientOpenAPIStub{host: "api.server.com"},
+			expectedID:              "some_checksum",
+			expectedProviderVersion: "2.0.0",
+			expectedHost:            "api.server.com",
+		},
+		{
+			name:                    "happy path - multi-region provider",
+			specInfo:                SpecInfo{Version: "1.0.0", Checksum: "some_checksum"},
+			client:                  &clientOpenAPIStub{host: "api.rst1.server.com", region: "rst1"},
+			expectedID:              "some_checksum",
+			expectedProviderVersion: version.Version,
+			expectedHost:            "api.rst1.server.com",
+			expectedRegion:          "rst1",
+		},
+		{
+			name:                    "happy path - spec info without checksum falls back to the data source name as ID",
+			specInfo:                SpecInfo{},
+			client:                  &clientOpenAPIStub{host: "api.server.com"},
+			expectedID:              dataSourceProviderInfoName,
+			expectedProviderVersion: version.Version,
+			expectedHost:            "api.server.com",
+		},
+		{
+			name:          "crappy path - client fails to resolve the provider host",
+			specInfo:      SpecInfo{Version: "1.0.0", Checksum: "some_checksum"},
+			client:        &clientOpenAPIStub{error: errors.New("some error")},
+			expectedError: errors.New("[data source='provider_info'] failed to resolve the provider host: some error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		d := newDataSourceProviderInfoFactory(tc.specInfo, []string{"cdn_v1", "bucket"}, tc.providerVersion)
+		resourceData := schema.TestResourceDataRaw(t, d.createTerraformProviderInfoDataSourceSchema(), map[string]interface{}{})
+		err := d.read(resourceData, tc.client)
+		if tc.expectedError != nil {
+			assert.EqualError(t, err, tc.expectedError.Error(), tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedID, resourceData.Id(), tc.name)
+		assert.Equal(t, tc.specInfo.Version, resourceData.Get(dataSourceProviderInfoSpecVersionProperty), tc.name)
+		assert.Equal(t, tc.specInfo.Checksum, resourceData.Get(dataSourceProviderInfoSpecChecksumProperty), tc.name)
+		assert.Equal(t, tc.expectedProviderVersion, resourceData.Get(dataSourceProviderInfoProviderVersionProperty), tc.name)
+		assert.Equal(t, version.Version, resourceData.Get(dataSourceProviderInfoOpenAPIProviderVersionProperty), tc.name)
+		assert.Equal(t, []interface{}{"bucket", "cdn_v1"}, resourceData.Get(dataSourceProviderInfoResourcesProperty), tc.name)
+		assert.Equal(t, tc.expectedHost, resourceData.Get(dataSourceProviderInfoHostProperty), tc.name)
+		assert.Equal(t, tc.expectedRegion, resourceData.Get(dataSourceProviderInfoRegionProperty), tc.name)
+		assert.Equal(t, CurrentResourceNamingVersion, resourceData.Get(dataSourceProviderInfoResourceNamingVersionProperty), tc.name)
+	}
+}
+
+func TestDataSourceProviderInfoReadResourceNamingVersion(t *testing.T) {
+	d := newDataSourceProviderInfoFactory(SpecInfo{}, []string{}, "")
+	d.resourceNamingVersion = resourceNamingV1
+	resourceData := schema.TestResourceDataRaw(t, d.createTerraformProviderInfoDataSourceSchema(), map[string]interface{}{})
+	assert.NoError(t, d.read(resourceData, &clientOpenAPIStub{}))
+	assert.Equal(t, resourceNamingV1, resourceData.Get(dataSourceProviderInfoResourceNamingVersionProperty))
+}
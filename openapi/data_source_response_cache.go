@@ -0,0 +1,92 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// dataSourceResponseCache holds the successful GET responses received when reading data sources. Terraform runs one provider
+// process per plan/apply invocation, so the responses are cached for the whole invocation allowing configurations that
+// read the same data source many times (e,g: a module instantiated 50 times reading the same lookup data source) to perform
+// one single GET call instead of one per read. The responses are cached per provider client and URL path, and only
+// successful responses are cached so failed reads are retried.
+type dataSourceResponseCache struct {
+	mutex   sync.Mutex
+	entries map[dataSourceResponseCacheKey]*dataSourceResponseCacheEntry
+}
+
+type dataSourceResponseCacheKey struct {
+	client ClientOpenAPI
+	path   string
+}
+
+// dataSourceResponseCacheEntry contains the JSON encoded response payload along with the response headers. The entry has its
+// own mutex so concurrent reads waiting on the same URL result in one single GET call.
+type dataSourceResponseCacheEntry struct {
+	mutex   sync.Mutex
+	payload []byte
+	header  http.Header
+}
+
+// dataSourceResponseFetchFunc performs the GET call populating the response payload passed in. An error is returned if the
+// call failed or the response was not successful
+type dataSourceResponseFetchFunc func(responsePayload interface{}) error
+
+// dataSourceResponseHeaderFetchFunc performs the GET call populating the response payload passed in and returns the headers
+// of the response. An error is returned if the call failed or the response was not successful
+type dataSourceResponseHeaderFetchFunc func(responsePayload interface{}) (http.Header, error)
+
+func newDataSourceResponseCache() *dataSourceResponseCache {
+	return &dataSourceResponseCache{
+		entries: map[dataSourceResponseCacheKey]*dataSourceResponseCacheEntry{},
+	}
+}
+
+// get populates the response payload passed in with the cached response for the given client and URL path. If the response
+// is not cached yet, the fetch function is called to retrieve it. Each call gets its own copy of the payload so callers
+// can not alter the cached response. If the cache is nil, the fetch function is always called.
+func (c *dataSourceResponseCache) get(client ClientOpenAPI, path string, responsePayload interface{}, fetch dataSourceResponseFetchFunc) error {
+	_, err := c.getWithHeader(client, path, responsePayload, func(responsePayload interface{}) (http.Header, error) {
+		return nil, fetch(responsePayload)
+	})
+	return err
+}
+
+// getWithHeader behaves as get and also returns the headers of the response (e,g: pagination headers), which are cached
+// along with the response payload
+func (c *dataSourceResponseCache) getWithHeader(client ClientOpenAPI, path string, responsePayload interface{}, fetch dataSourceResponseHeaderFetchFunc) (http.Header, error) {
+	if c == nil {
+		return fetch(responsePayload)
+	}
+	entry := c.getEntry(client, path)
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	if entry.payload != nil {
+		clientLog.Debug("serving data source read for GET %s from the response cache", path)
+		return entry.header.Clone(), json.Unmarshal(entry.payload, responsePayload)
+	}
+	header, err := fetch(responsePayload)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(responsePayload)
+	if err != nil {
+		return nil, err
+	}
+	entry.payload = payload
+	entry.header = header.Clone()
+	return header, nil
+}
+
+func (c *dataSourceResponseCache) getEntry(client ClientOpenAPI, path string) *dataSourceResponseCacheEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	key := dataSourceResponseCacheKey{client: clientWithoutContext(client), path: path}
+	entry, exists := c.entries[key]
+	if !exists {
+		entry = &dataSourceResponseCacheEntry{}
+		c.entries[key] = entry
+	}
+	return entry
+}
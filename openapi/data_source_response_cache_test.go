@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceResponseCacheGet(t *testing.T) {
+	client := &clientOpenAPIStub{}
+	c := newDataSourceResponseCache()
+
+	fetchCalls := 0
+	fetch := func(responsePayload interface{}) error {
+		fetchCalls++
+		*responsePayload.(*map[string]interface{}) = map[string]interface{}{"id": "1", "label": "some label"}
+		return nil
+	}
+
+	responsePayload := map[string]interface{}{}
+	assert.NoError(t, c.get(client, "/v1/cdns/1", &responsePayload, fetch))
+	assert.Equal(t, map[string]interface{}{"id": "1", "label": "some label"}, responsePayload)
+
+	responsePayload["label"] = "modified by the caller"
+	cachedResponsePayload := map[string]interface{}{}
+	assert.NoError(t, c.get(client, "/v1/cdns/1", &cachedResponsePayload, fetch))
+	assert.Equal(t, map[string]interface{}{"id": "1", "label": "some label"}, cachedResponsePayload, "callers should not be able to alter the cached response")
+	assert.Equal(t, 1, fetchCalls, "response should be fetched only once")
+
+	assert.NoError(t, c.get(client, "/v1/cdns/2", &map[string]interface{}{}, fetch))
+	assert.Equal(t, 2, fetchCalls, "responses for different URLs should be cached separately")
+
+	assert.NoError(t, c.get(&clientOpenAPIStub{}, "/v1/cdns/1", &map[string]interface{}{}, fetch))
+	assert.Equal(t, 3, fetchCalls, "responses from different clients should be cached separately")
+
+	failingFetch := func(responsePayload interface{}) error {
+		fetchCalls++
+		return errors.New("some error")
+	}
+	assert.EqualError(t, c.get(client, "/v1/cdns/3", &map[string]interface{}{}, failingFetch), "some error")
+	assert.EqualError(t, c.get(client, "/v1/cdns/3", &map[string]interface{}{}, failingFetch), "some error")
+	assert.Equal(t, 5, fetchCalls, "failed responses should not be cached")
+
+	var nilCache *dataSourceResponseCache
+	assert.NoError(t, nilCache.get(client, "/v1/cdns/1", &map[string]interface{}{}, fetch))
+	assert.NoError(t, nilCache.get(client, "/v1/cdns/1", &map[string]interface{}{}, fetch))
+	assert.Equal(t, 7, fetchCalls, "nil cache should always fetch the response")
+}
+
+func TestDataSourceResponseCacheGet_ContextBoundClients(t *testing.T) {
+	fetchCalls := 0
+	fetch := func(responsePayload interface{}) error {
+		fetchCalls++
+		*responsePayload.(*map[string]interface{}) = map[string]interface{}{"id": "1"}
+		return nil
+	}
+	c := newDataSourceResponseCache()
+	providerClient := &ProviderClient{}
+	auditClient := &auditLogClient{ClientOpenAPI: providerClient}
+	for _, client := range []ClientOpenAPI{providerClient, auditClient} {
+		assert.NoError(t, c.get(client, "/v1/cdns/1", &map[string]interface{}{}, fetch))
+		assert.NoError(t, c.get(clientWithContext(context.Background(), client).(ClientOpenAPI), "/v1/cdns/1", &map[string]interface{}{}, fetch))
+		boundTwice := clientWithContext(context.Background(), clientWithContext(context.Background(), client))
+		assert.NoError(t, c.get(boundTwice.(ClientOpenAPI), "/v1/cdns/1", &map[string]interface{}{}, fetch))
+	}
+	assert.Equal(t, 2, fetchCalls, "responses should be served from the cache for the clients bound to a context")
+}
+
+func TestDataSourceReadResponseCache(t *testing.T) {
+	openAPIResource := &specStubResource{
+		name: "resourceName",
+		path: "/v1/resource",
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+			},
+		},
+	}
+	client := &clientOpenAPIStub{
+		responsePayload: map[string]interface{}{"id": "someID", "label": "someLabel"},
+		responseListPayload: []map[string]interface{}{
+			{"id": "someID", "label": "someLabel"},
+			{"id": "someOtherID", "label": "someOtherLabel"},
+		},
+	}
+
+	d := newDataSourceFactory(openAPIResource)
+	dataSourceSchema, err := d.createTerraformDataSourceSchema()
+	require.NoError(t, err)
+	for _, label := range []string{"someLabel", "someOtherLabel", "someLabel"} {
+		resourceData := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{
+			dataSourceFilterPropertyName: []interface{}{newFilter("label", []interface{}{label})},
+		})
+		require.NoError(t, d.read(resourceData, client))
+		assert.Equal(t, label, resourceData.Get("label"))
+	}
+	assert.Equal(t, 1, client.listCalls, "the data source reads should be served with one single GET call")
+
+	i := newDataSourceInstanceFactory(openAPIResource)
+	dataSourceInstanceSchema, err := i.createTerraformDataSourceInstanceSchema()
+	require.NoError(t, err)
+	for n := 0; n < 3; n++ {
+		resourceData := schema.TestResourceDataRaw(t, dataSourceInstanceSchema, map[string]interface{}{dataSourceInstanceIDProperty: "someID"})
+		require.NoError(t, i.read(resourceData, client))
+		assert.Equal(t, "someLabel", resourceData.Get("label"))
+	}
+	assert.Equal(t, 1, client.getCalls, "the data source instance reads should be served with one single GET call")
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultDataSourceReadTimeout defines the read timeout of the data sources when neither the timeouts block nor the
+// x-terraform-resource-timeout extension of the resource GET operation are configured. It matches the Terraform SDK default
+const defaultDataSourceReadTimeout = 20 * time.Minute
+
+// contextClient defines the clients that can be bound to a context so the API calls performed are cancelled as soon as
+// the context is done (e,g: the data source read timeout is reached)
+type contextClient interface {
+	withContext(ctx context.Context) ClientOpenAPI
+	withoutContext() ClientOpenAPI
+}
+
+// clientWithContext returns the client passed in bound to the given context; the client is returned as is if it does not
+// support contexts
+func clientWithContext(ctx context.Context, i interface{}) interface{} {
+	if client, ok := i.(contextClient); ok {
+		return client.withContext(ctx)
+	}
+	return i
+}
+
+// clientWithoutContext returns the client the client passed in was bound to a context from, so clients bound to different
+// contexts (e,g: one per data source read) can still be identified as the same provider client
+func clientWithoutContext(client ClientOpenAPI) ClientOpenAPI {
+	if c, ok := client.(contextClient); ok {
+		return c.withoutContext()
+	}
+	return client
+}
+
+// createDataSourceTimeouts returns the timeouts of the data sources created for the resource passed in. The read timeout
+// defaults to the x-terraform-resource-timeout of the resource GET operation if present and can be overridden by the users
+// in the data source timeouts block
+func createDataSourceTimeouts(openAPIResource SpecResource) (*schema.ResourceTimeout, error) {
+	timeouts, err := openAPIResource.getTimeouts()
+	if err != nil {
+		return nil, err
+	}
+	readTimeout := defaultDataSourceReadTimeout
+	if timeouts != nil && timeouts.Get != nil {
+		readTimeout = *timeouts.Get
+	}
+	return &schema.ResourceTimeout{
+		Read: &readTimeout,
+	}, nil
+}
+
+// dataSourceReadWithContext returns the read function of the data sources. The Terraform SDK does not load the timeouts
+// configured in the data source timeouts block, so the read timeout is resolved here from the raw configuration instead
+// and the read (including the API calls in flight) is cancelled once the timeout is reached
+func dataSourceReadWithContext(readFunc func(data *schema.ResourceData, i interface{}) error, resourceName string, defaultTimeout time.Duration) schema.ReadContextFunc {
+	return func(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
+		timeout, err := getDataSourceReadTimeout(data, defaultTimeout)
+		if err != nil {
+			return diag.Errorf("'%s' %s", resourceName, err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return runCRUDWithContext(ctx, readFunc, data, clientWithContext(ctx, i), schema.TimeoutRead, resourceName, timeout)
+	}
+}
+
+// getDataSourceReadTimeout returns the read timeout configured in the data source timeouts block (e,g: read = "30m"); the
+// default timeout passed in is returned if the read timeout is not configured
+func getDataSourceReadTimeout(data *schema.ResourceData, defaultTimeout time.Duration) (time.Duration, error) {
+	rawConfig := data.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.Type().IsObjectType() || !rawConfig.Type().HasAttribute(schema.TimeoutsConfigKey) {
+		return defaultTimeout, nil
+	}
+	timeouts := rawConfig.GetAttr(schema.TimeoutsConfigKey)
+	if timeouts.IsNull() || !timeouts.IsKnown() || !timeouts.Type().IsObjectType() || !timeouts.Type().HasAttribute(schema.TimeoutRead) {
+		return defaultTimeout, nil
+	}
+	read := timeouts.GetAttr(schema.TimeoutRead)
+	if read.IsNull() || !read.IsKnown() || read.Type() != cty.String {
+		return defaultTimeout, nil
+	}
+	timeout, err := time.ParseDuration(read.AsString())
+	if err != nil {
+		return 0, fmt.Errorf("%s timeout '%s' is not valid: %s", schema.TimeoutRead, read.AsString(), err)
+	}
+	return timeout, nil
+}
@@ -0,0 +1,141 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDataSourceTimeouts(t *testing.T) {
+	getTimeout := 45 * time.Minute
+	testCases := []struct {
+		name                string
+		openAPIResource     SpecResource
+		expectedReadTimeout time.Duration
+		expectedError       string
+	}{
+		{
+			name:                "resource without timeouts uses the default data source read timeout",
+			openAPIResource:     &specStubResource{},
+			expectedReadTimeout: defaultDataSourceReadTimeout,
+		},
+		{
+			name:                "resource with GET timeout uses it as the data source read timeout",
+			openAPIResource:     &specStubResource{timeouts: &specTimeouts{Get: &getTimeout}},
+			expectedReadTimeout: getTimeout,
+		},
+		{
+			name: "resource with timeouts that are not valid",
+			openAPIResource: &specStubResource{funcGetTimeouts: func() (*specTimeouts, error) {
+				return nil, errors.New("invalid timeout value")
+			}},
+			expectedError: "invalid timeout value",
+		},
+	}
+	for _, tc := range testCases {
+		timeouts, err := createDataSourceTimeouts(tc.openAPIResource)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedReadTimeout, *timeouts.Read, tc.name)
+		assert.Nil(t, timeouts.Create, tc.name)
+		assert.Nil(t, timeouts.Update, tc.name)
+		assert.Nil(t, timeouts.Delete, tc.name)
+	}
+}
+
+func TestGetDataSourceReadTimeout(t *testing.T) {
+	testCases := []struct {
+		name            string
+		rawConfig       cty.Value
+		expectedTimeout time.Duration
+		expectedError   string
+	}{
+		{
+			name:            "no raw config",
+			rawConfig:       cty.NullVal(cty.EmptyObject),
+			expectedTimeout: defaultDataSourceReadTimeout,
+		},
+		{
+			name:            "timeouts block not configured",
+			rawConfig:       cty.ObjectVal(map[string]cty.Value{schema.TimeoutsConfigKey: cty.NullVal(cty.Object(map[string]cty.Type{schema.TimeoutRead: cty.String}))}),
+			expectedTimeout: defaultDataSourceReadTimeout,
+		},
+		{
+			name:            "timeouts block without read timeout",
+			rawConfig:       dataSourceTimeoutsRawConfig(cty.NullVal(cty.String)),
+			expectedTimeout: defaultDataSourceReadTimeout,
+		},
+		{
+			name:            "timeouts block with read timeout",
+			rawConfig:       dataSourceTimeoutsRawConfig(cty.StringVal("45m")),
+			expectedTimeout: 45 * time.Minute,
+		},
+		{
+			name:          "timeouts block with read timeout that is not valid",
+			rawConfig:     dataSourceTimeoutsRawConfig(cty.StringVal("forever")),
+			expectedError: "read timeout 'forever' is not valid: time: invalid duration \"forever\"",
+		},
+	}
+	for _, tc := range testCases {
+		data := newDataSourceResourceDataWithRawConfig(tc.rawConfig)
+		timeout, err := getDataSourceReadTimeout(data, defaultDataSourceReadTimeout)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedTimeout, timeout, tc.name)
+	}
+}
+
+func TestDataSourceReadWithContext(t *testing.T) {
+	t.Run("read completes before the timeout", func(t *testing.T) {
+		var clientReceived interface{}
+		read := dataSourceReadWithContext(func(data *schema.ResourceData, i interface{}) error {
+			clientReceived = i
+			return nil
+		}, "cdn", time.Minute)
+		providerClient := &ProviderClient{}
+		diags := read(context.Background(), newDataSourceResourceDataWithRawConfig(cty.NullVal(cty.EmptyObject)), providerClient)
+		assert.False(t, diags.HasError())
+		assert.NotNil(t, clientReceived.(*ProviderClient).ctx, "the client should be bound to the read context")
+		assert.Nil(t, providerClient.ctx)
+	})
+	t.Run("read exceeds the timeout configured in the timeouts block", func(t *testing.T) {
+		read := dataSourceReadWithContext(func(data *schema.ResourceData, i interface{}) error {
+			time.Sleep(time.Second)
+			return nil
+		}, "cdn", time.Minute)
+		diags := read(context.Background(), newDataSourceResourceDataWithRawConfig(dataSourceTimeoutsRawConfig(cty.StringVal("10ms"))), &clientOpenAPIStub{})
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "context deadline exceeded: 'cdn' read timeout is 10ms", diags[0].Summary)
+	})
+	t.Run("read timeout configured is not valid", func(t *testing.T) {
+		read := dataSourceReadWithContext(func(data *schema.ResourceData, i interface{}) error {
+			return nil
+		}, "cdn", time.Minute)
+		diags := read(context.Background(), newDataSourceResourceDataWithRawConfig(dataSourceTimeoutsRawConfig(cty.StringVal("forever"))), &clientOpenAPIStub{})
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "'cdn' read timeout 'forever' is not valid: time: invalid duration \"forever\"", diags[0].Summary)
+	})
+}
+
+func dataSourceTimeoutsRawConfig(read cty.Value) cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		schema.TimeoutsConfigKey: cty.ObjectVal(map[string]cty.Value{schema.TimeoutRead: read}),
+	})
+}
+
+func newDataSourceResourceDataWithRawConfig(rawConfig cty.Value) *schema.ResourceData {
+	resource := &schema.Resource{Schema: map[string]*schema.Schema{}}
+	return resource.Data(&terraform.InstanceState{RawConfig: rawConfig})
+}
@@ -0,0 +1,149 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// defaultDialerFallbackDelay defines how long the IPv6 connection attempt is given before the IPv4 one is started when
+// IPv6 is preferred, if the dialer configuration does not configure the fallback delay. Same as the Go default (RFC 6555)
+const defaultDialerFallbackDelay = 300 * time.Millisecond
+
+// dialContextFunc dials the network address passed in (e,g: net.Dialer.DialContext)
+type dialContextFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// newResolver returns the DNS resolver the API hosts are resolved with. If the dialer configuration does not configure a
+// resolver address, the Go default resolver is returned
+func (d *DialerConfig) newResolver() *net.Resolver {
+	if d == nil || d.Resolver == "" {
+		return net.DefaultResolver
+	}
+	resolverAddress := d.Resolver
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			// the queries are sent to the resolver configured instead of the name servers in the system configuration
+			return (&net.Dialer{}).DialContext(ctx, network, resolverAddress)
+		},
+	}
+}
+
+// newDialContext returns the function the connections to the API are dialed with based on the dialer configuration. nil
+// is returned if the dialer configuration is nil, in which case the Go defaults apply
+func (d *DialerConfig) newDialContext() dialContextFunc {
+	if d == nil {
+		return nil
+	}
+	fallbackDelay := defaultDialerFallbackDelay
+	if d.FallbackDelay != "" {
+		fallbackDelay, _ = time.ParseDuration(d.FallbackDelay)
+	}
+	dialer := &net.Dialer{
+		Timeout:       30 * time.Second,
+		KeepAlive:     30 * time.Second,
+		FallbackDelay: fallbackDelay,
+		Resolver:      d.newResolver(),
+	}
+	switch {
+	case d.DisableIPv4:
+		return func(ctx context.Context, network, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, ipv6Network(network), address)
+		}
+	case d.PreferIPv6:
+		return func(ctx context.Context, network, address string) (net.Conn, error) {
+			return dialPreferIPv6(ctx, dialer, network, address)
+		}
+	}
+	return dialer.DialContext
+}
+
+// dialPreferIPv6 dials the IPv6 addresses of the host first. If the IPv6 connection is not established within the dialer
+// fallback delay the IPv4 connection attempt is started too (happy eyeballs), and the first connection established is
+// returned. A negative fallback delay disables the racing, only falling back to IPv4 if the IPv6 attempt fails
+func dialPreferIPv6(ctx context.Context, dialer *net.Dialer, network, address string) (net.Conn, error) {
+	if network != "tcp" {
+		return dialer.DialContext(ctx, network, address)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan dialAttemptResult, 2)
+	dial := func(network string) {
+		conn, err := dialer.DialContext(ctx, network, address)
+		results <- dialAttemptResult{conn: conn, err: err}
+	}
+	go dial("tcp6")
+
+	var fallback <-chan time.Time
+	if dialer.FallbackDelay >= 0 {
+		timer := time.NewTimer(dialer.FallbackDelay)
+		defer timer.Stop()
+		fallback = timer.C
+	}
+	attempts, failures := 1, 0
+	var firstErr error
+	for {
+		select {
+		case <-fallback:
+			fallback = nil
+			if attempts == 1 {
+				attempts++
+				go dial("tcp4")
+			}
+		case result := <-results:
+			if result.err == nil {
+				if failures+1 < attempts {
+					go closePendingDialAttempt(results)
+				}
+				return result.conn, nil
+			}
+			failures++
+			if firstErr == nil {
+				firstErr = result.err
+			}
+			if attempts == 1 {
+				attempts++
+				fallback = nil
+				go dial("tcp4")
+				continue
+			}
+			if failures == attempts {
+				return nil, firstErr
+			}
+		}
+	}
+}
+
+type dialAttemptResult struct {
+	conn net.Conn
+	err  error
+}
+
+// closePendingDialAttempt waits for the dial attempt still in progress (which is cancelled once a connection is established
+// by the other attempt) and closes its connection in case it was established anyway
+func closePendingDialAttempt(results <-chan dialAttemptResult) {
+	if result := <-results; result.conn != nil {
+		result.conn.Close()
+	}
+}
+
+// ipv6Network returns the IPv6 only network of the network passed in (e,g: tcp6 for tcp)
+func ipv6Network(network string) string {
+	switch network {
+	case "tcp":
+		return "tcp6"
+	case "udp":
+		return "udp6"
+	}
+	return network
+}
+
+// validateResolverAddress makes sure the resolver address is in host:port form
+func validateResolverAddress(address string) error {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return fmt.Errorf("resolver '%s' not valid, the address must be in host:port form: %s", address, err)
+	}
+	return nil
+}
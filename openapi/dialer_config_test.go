@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialerConfigNewDialContext(t *testing.T) {
+	var dialerConfig *DialerConfig
+	assert.Nil(t, dialerConfig.newDialContext())
+	assert.Equal(t, net.DefaultResolver, dialerConfig.newResolver())
+
+	dialerConfig = &DialerConfig{Resolver: "10.0.0.2:53"}
+	assert.NotNil(t, dialerConfig.newDialContext())
+	resolver := dialerConfig.newResolver()
+	assert.NotEqual(t, net.DefaultResolver, resolver)
+	assert.True(t, resolver.PreferGo)
+}
+
+func TestDialerConfigNewDialContextDisableIPv4(t *testing.T) {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	dialContext := (&DialerConfig{DisableIPv4: true}).newDialContext()
+	_, err = dialContext(context.Background(), "tcp", listener.Addr().String())
+	assert.Error(t, err, "IPv4 addresses must not be dialed when IPv4 is disabled")
+}
+
+func TestDialPreferIPv6(t *testing.T) {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// the IPv6 attempt fails (the address is IPv4 only) so the connection falls back to IPv4 even with racing disabled
+	dialer := &net.Dialer{Timeout: 5 * time.Second, FallbackDelay: -1}
+	conn, err := dialPreferIPv6(context.Background(), dialer, "tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, listener.Addr().String(), conn.RemoteAddr().String())
+
+	_, err = dialPreferIPv6(context.Background(), dialer, "tcp", "127.0.0.1:1")
+	assert.Error(t, err)
+}
+
+func TestIPv6Network(t *testing.T) {
+	assert.Equal(t, "tcp6", ipv6Network("tcp"))
+	assert.Equal(t, "udp6", ipv6Network("udp"))
+	assert.Equal(t, "tcp6", ipv6Network("tcp6"))
+	assert.Equal(t, "unix", ipv6Network("unix"))
+}
@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// fieldManagerConflictReason is the reason of the causes of the conflicts returned by APIs supporting Kubernetes-style
+// server-side apply when the fields applied are owned by other field managers
+const fieldManagerConflictReason = "FieldManagerConflict"
+
+// fieldManagerConflictManagerRegex extracts the name of the field manager owning the field from the conflict cause message
+// (e,g: conflict with "kubectl" using apps/v1)
+var fieldManagerConflictManagerRegex = regexp.MustCompile(`conflict with "([^"]*)"`)
+
+// fieldManagerConflict describes a field the API refused to apply because it is owned by another field manager
+type fieldManagerConflict struct {
+	field   string
+	manager string
+}
+
+// fieldManagerConflictStatus represents the structured conflict details returned by APIs supporting Kubernetes-style
+// server-side apply along with the 409 Conflict responses, e,g:
+// {"kind":"Status","reason":"Conflict","details":{"causes":[{"reason":"FieldManagerConflict","message":"conflict with \"kubectl\"","field":".spec.replicas"}]}}
+type fieldManagerConflictStatus struct {
+	Details struct {
+		Causes []struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+			Field   string `json:"field"`
+		} `json:"causes"`
+	} `json:"details"`
+}
+
+// parseFieldManagerConflicts returns the field manager conflicts contained in the given response body; nil is returned if
+// the body does not contain structured conflict details
+func parseFieldManagerConflicts(body string) []fieldManagerConflict {
+	var status fieldManagerConflictStatus
+	if err := json.Unmarshal([]byte(body), &status); err != nil {
+		return nil
+	}
+	var conflicts []fieldManagerConflict
+	for _, cause := range status.Details.Causes {
+		if cause.Reason != fieldManagerConflictReason {
+			continue
+		}
+		conflict := fieldManagerConflict{field: cause.Field, manager: "unknown"}
+		if match := fieldManagerConflictManagerRegex.FindStringSubmatch(cause.Message); match != nil {
+			conflict.manager = match[1]
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+// newFieldManagerConflictError returns an error listing the fields that conflict and the field managers owning them. If the
+// resource supports force applying the changes, the error suggests enabling the force_apply provider property
+func newFieldManagerConflictError(openAPIResource SpecResource, conflicts []fieldManagerConflict) error {
+	fields := make([]string, len(conflicts))
+	for i, conflict := range conflicts {
+		fields[i] = fmt.Sprintf("%s (managed by '%s')", conflict.field, conflict.manager)
+	}
+	err := fmt.Sprintf("[resource='%s'] HTTP Response Status Code 409 - Conflict: the following fields are owned by other field managers: %s", openAPIResource.GetResourceName(), strings.Join(fields, ", "))
+	if isForceApplySupported(openAPIResource) {
+		err += fmt.Sprintf(". Set '%s = true' in the provider configuration to force the changes and take ownership of the fields", providerPropertyForceApply)
+	}
+	return errors.New(err)
+}
+
+// isForceApplySupported returns true if the resource POST or PUT operations support force applying the changes
+// (x-terraform-force-apply-parameter)
+func isForceApplySupported(openAPIResource SpecResource) bool {
+	operations := openAPIResource.getResourceOperations()
+	return operations.Post.getForceApplyParameter() != "" || operations.Put.getForceApplyParameter() != ""
+}
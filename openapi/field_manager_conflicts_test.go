@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFieldManagerConflicts(t *testing.T) {
+	testCases := []struct {
+		name              string
+		body              string
+		expectedConflicts []fieldManagerConflict
+	}{
+		{
+			name: "body containing field manager conflicts",
+			body: `{"kind":"Status","status":"Failure","message":"Apply failed with 2 conflicts","reason":"Conflict","details":{"causes":[{"reason":"FieldManagerConflict","message":"conflict with \"kubectl\" using apps/v1","field":".spec.replicas"},{"reason":"FieldManagerConflict","message":"conflict with \"autoscaler\"","field":".spec.template.spec.containers[name=\"app\"].resources"}]},"code":409}`,
+			expectedConflicts: []fieldManagerConflict{
+				{field: ".spec.replicas", manager: "kubectl"},
+				{field: `.spec.template.spec.containers[name="app"].resources`, manager: "autoscaler"},
+			},
+		},
+		{
+			name:              "conflict cause without manager in the message",
+			body:              `{"details":{"causes":[{"reason":"FieldManagerConflict","message":"conflict","field":".spec.replicas"}]}}`,
+			expectedConflicts: []fieldManagerConflict{{field: ".spec.replicas", manager: "unknown"}},
+		},
+		{
+			name: "causes other than field manager conflicts are ignored",
+			body: `{"details":{"causes":[{"reason":"FieldValueInvalid","message":"invalid value","field":".spec.replicas"}]}}`,
+		},
+		{
+			name: "body not containing structured conflict details",
+			body: "resource already exists",
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedConflicts, parseFieldManagerConflicts(tc.body), tc.name)
+	}
+}
+
+func TestNewFieldManagerConflictError(t *testing.T) {
+	conflicts := []fieldManagerConflict{{field: ".spec.replicas", manager: "kubectl"}, {field: ".spec.paused", manager: "helm"}}
+
+	err := newFieldManagerConflictError(&specStubResource{name: "deployment"}, conflicts)
+	assert.EqualError(t, err, "[resource='deployment'] HTTP Response Status Code 409 - Conflict: the following fields are owned by other field managers: .spec.replicas (managed by 'kubectl'), .spec.paused (managed by 'helm')")
+
+	resource := &specStubResource{name: "deployment", resourcePutOperation: &specResourceOperation{forceApplyParameter: "force"}}
+	err = newFieldManagerConflictError(resource, conflicts)
+	assert.EqualError(t, err, "[resource='deployment'] HTTP Response Status Code 409 - Conflict: the following fields are owned by other field managers: .spec.replicas (managed by 'kubectl'), .spec.paused (managed by 'helm'). Set 'force_apply = true' in the provider configuration to force the changes and take ownership of the fields")
+}
+
+func TestIsForceApplySupported(t *testing.T) {
+	assert.False(t, isForceApplySupported(&specStubResource{}))
+	assert.True(t, isForceApplySupported(&specStubResource{resourcePostOperation: &specResourceOperation{forceApplyParameter: "force"}}))
+	assert.True(t, isForceApplySupported(&specStubResource{resourcePutOperation: &specResourceOperation{forceApplyParameter: "force"}}))
+}
@@ -0,0 +1,103 @@
+//go:build go1.18
+// +build go1.18
+
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// The fuzz targets below make sure the conversion of the payloads returned by the API into the terraform state (and of
+// the state into the request payloads) does not panic no matter how malformed the payloads are. The seed corpus and the
+// crashers discovered are kept in testdata/fuzz/<FuzzTarget> as regression corpus. To run a fuzz target:
+// go test ./openapi -run '^$' -fuzz FuzzConvertPayloadToLocalStateDataValue -fuzztime 60s
+
+// newFuzzSchemaDefinition returns a schema definition covering the different property types supported: primitives,
+// lists of primitives, objects with nested objects and lists of objects, with and without the ignore order behaviour
+func newFuzzSchemaDefinition() *SpecSchemaDefinition {
+	origin := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{
+		{Name: "host", Type: TypeString},
+		{Name: "port", Type: TypeInt},
+	}}
+	settings := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{
+		{Name: "protocol", Type: TypeString},
+		{Name: "origin", Type: TypeObject, SpecSchemaDefinition: origin},
+	}}
+	rule := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{
+		{Name: "path", Type: TypeString},
+		{Name: "priority", Type: TypeInt},
+		{Name: "cacheable", Type: TypeBool},
+	}}
+	s := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{
+		{Name: "name", Type: TypeString, Required: true},
+		{Name: "port", Type: TypeInt},
+		{Name: "ratio", Type: TypeFloat},
+		{Name: "enabled", Type: TypeBool, Coerce: true},
+		{Name: "tags", Type: TypeList, ArrayItemsType: TypeString, IgnoreItemsOrder: true},
+		{Name: "ports", Type: TypeList, ArrayItemsType: TypeInt, Coerce: true},
+		{Name: "settings", Type: TypeObject, SpecSchemaDefinition: settings},
+		{Name: "rules", Type: TypeList, ArrayItemsType: TypeObject, SpecSchemaDefinition: rule, IgnoreItemsOrder: true},
+	}}
+	for _, schemaDefinition := range []*SpecSchemaDefinition{origin, settings, rule, s} {
+		schemaDefinition.buildPropertyIndex()
+	}
+	return s
+}
+
+func FuzzConvertPayloadToLocalStateDataValue(f *testing.F) {
+	f.Add([]byte(`{"name":"cdn","port":8080,"ratio":0.5,"enabled":"true","tags":["a","b"],"ports":["80",443]}`))
+	f.Add([]byte(`{"settings":{"protocol":"https","origin":{"host":"origin.example.com","port":443}}}`))
+	f.Add([]byte(`{"rules":[{"path":"/static","priority":1,"cacheable":true},{"path":"/api"}]}`))
+	schemaDefinition := newFuzzSchemaDefinition()
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		var remoteData map[string]interface{}
+		if err := json.Unmarshal(payload, &remoteData); err != nil {
+			return
+		}
+		for propertyName, propertyValue := range remoteData {
+			property, err := schemaDefinition.getProperty(propertyName)
+			if err != nil {
+				continue
+			}
+			convertPayloadToLocalStateDataValue(property, propertyValue) // #nosec G104
+		}
+	})
+}
+
+func FuzzProcessIgnoreOrderIfEnabled(f *testing.F) {
+	f.Add([]byte(`["c","a","b"]`), []byte(`["a","b","c","d"]`))
+	f.Add([]byte(`[{"path":"/api","priority":2},{"path":"/static","priority":1}]`), []byte(`[{"path":"/static","priority":1},{"path":"/api","priority":2}]`))
+	schemaDefinition := newFuzzSchemaDefinition()
+	tags, _ := schemaDefinition.getProperty("tags")
+	rules, _ := schemaDefinition.getProperty("rules")
+	f.Fuzz(func(t *testing.T, input, remote []byte) {
+		var inputValue, remoteValue interface{}
+		if json.Unmarshal(input, &inputValue) != nil || json.Unmarshal(remote, &remoteValue) != nil {
+			return
+		}
+		processIgnoreOrderIfEnabled(*tags, inputValue, remoteValue)
+		processIgnoreOrderIfEnabled(*rules, inputValue, remoteValue)
+	})
+}
+
+func FuzzPopulatePayload(f *testing.F) {
+	f.Add([]byte(`{"name":"cdn","port":8080,"enabled":true,"tags":["a","b"]}`))
+	f.Add([]byte(`{"settings":[{"protocol":"https","origin":[{"host":"origin.example.com","port":443}]}]}`))
+	f.Add([]byte(`{"rules":[{"path":"/static","priority":1}],"settings":[]}`))
+	schemaDefinition := newFuzzSchemaDefinition()
+	r := resourceFactory{}
+	f.Fuzz(func(t *testing.T, state []byte) {
+		var localData map[string]interface{}
+		if err := json.Unmarshal(state, &localData); err != nil {
+			return
+		}
+		for propertyName, dataValue := range localData {
+			property, err := schemaDefinition.getPropertyBasedOnTerraformName(propertyName)
+			if err != nil {
+				continue
+			}
+			r.populatePayload(map[string]interface{}{}, property, dataValue) // #nosec G104
+		}
+	})
+}
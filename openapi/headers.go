@@ -2,7 +2,10 @@ package openapi
 
 // HTTP headers
 const (
-	authorizationHeader = "Authorization"
-	userAgentHeader     = "User-Agent"
-	contentType         = "Content-Type"
+	authorizationHeader  = "Authorization"
+	userAgentHeader      = "User-Agent"
+	contentType          = "Content-Type"
+	eTagHeader           = "ETag"
+	ifNoneMatchHeader    = "If-None-Match"
+	acceptLanguageHeader = "Accept-Language"
 )
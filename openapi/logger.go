@@ -0,0 +1,153 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// logLevel defines the severity of the log messages. The levels are ordered by severity, from the most verbose (trace)
+// to the least verbose (error)
+type logLevel int
+
+const (
+	logLevelTrace logLevel = iota
+	logLevelDebug
+	logLevelInfo
+	logLevelWarn
+	logLevelError
+	logLevelOff
+)
+
+const tfLogEnvVar = "TF_LOG"
+const tfLogProviderEnvVar = "TF_LOG_PROVIDER"
+
+var logLevelNames = map[logLevel]string{
+	logLevelTrace: "TRACE",
+	logLevelDebug: "DEBUG",
+	logLevelInfo:  "INFO",
+	logLevelWarn:  "WARN",
+	logLevelError: "ERROR",
+}
+
+// Subsystem loggers used across the provider. The subsystem is included in every log message so it's easy to filter the
+// logs for a specific area of the provider (e,g: grep '\[client\]')
+var (
+	analyserLog  = newLogger("analyser")
+	clientLog    = newLogger("client")
+	pollerLog    = newLogger("poller")
+	resourceLog  = newLogger("resource")
+	providerLog  = newLogger("provider")
+	configLog    = newLogger("config")
+	telemetryLog = newLogger("telemetry")
+)
+
+// logField represents a key/value pair appended to the log message
+type logField struct {
+	key   string
+	value interface{}
+}
+
+// logger is a leveled and structured logger that writes log messages using the standard log package (which is wired by the
+// Terraform plugin SDK to the Terraform logging). Messages are written with the following format:
+//
+//	[LEVEL] [subsystem] message key1=value1 key2=value2
+//
+// Messages with severity lower than the one configured via TF_LOG_PROVIDER (or TF_LOG if the former is not set) are discarded.
+type logger struct {
+	subsystem string
+	fields    []logField
+}
+
+func newLogger(subsystem string) logger {
+	return logger{subsystem: subsystem}
+}
+
+// With returns a copy of the logger that appends the given key/value pair to every log message
+func (l logger) With(key string, value interface{}) logger {
+	fields := make([]logField, len(l.fields), len(l.fields)+1)
+	copy(fields, l.fields)
+	return logger{
+		subsystem: l.subsystem,
+		fields:    append(fields, logField{key: key, value: value}),
+	}
+}
+
+// Trace logs the message with trace level
+func (l logger) Trace(format string, args ...interface{}) {
+	l.log(logLevelTrace, format, args...)
+}
+
+// Debug logs the message with debug level
+func (l logger) Debug(format string, args ...interface{}) {
+	l.log(logLevelDebug, format, args...)
+}
+
+// Info logs the message with info level
+func (l logger) Info(format string, args ...interface{}) {
+	l.log(logLevelInfo, format, args...)
+}
+
+// Warn logs the message with warn level
+func (l logger) Warn(format string, args ...interface{}) {
+	l.log(logLevelWarn, format, args...)
+}
+
+// Error logs the message with error level
+func (l logger) Error(format string, args ...interface{}) {
+	l.log(logLevelError, format, args...)
+}
+
+func (l logger) log(level logLevel, format string, args ...interface{}) {
+	if level < getLogLevel() {
+		return
+	}
+	log.Print(l.format(level, format, args...))
+}
+
+func (l logger) format(level logLevel, format string, args ...interface{}) string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	sb.WriteString(logLevelNames[level])
+	sb.WriteString("] ")
+	if l.subsystem != "" {
+		sb.WriteString("[")
+		sb.WriteString(l.subsystem)
+		sb.WriteString("] ")
+	}
+	if len(args) > 0 {
+		sb.WriteString(fmt.Sprintf(format, args...))
+	} else {
+		sb.WriteString(format)
+	}
+	for _, field := range l.fields {
+		sb.WriteString(fmt.Sprintf(" %s=%v", field.key, field.value))
+	}
+	return sb.String()
+}
+
+// getLogLevel returns the log level configured via TF_LOG_PROVIDER, falling back to TF_LOG if the former is not set. If
+// none of them are set (or the value is not a known level) no messages are discarded, leaving Terraform to decide
+// what to do with the logs.
+func getLogLevel() logLevel {
+	value := os.Getenv(tfLogProviderEnvVar)
+	if value == "" {
+		value = os.Getenv(tfLogEnvVar)
+	}
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "DEBUG":
+		return logLevelDebug
+	case "INFO":
+		return logLevelInfo
+	case "WARN":
+		return logLevelWarn
+	case "ERROR":
+		return logLevelError
+	case "OFF":
+		return logLevelOff
+	default:
+		// TRACE, JSON (Terraform emits trace logs in JSON format) and any other value
+		return logLevelTrace
+	}
+}
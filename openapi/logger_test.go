@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLogLevel(t *testing.T) {
+	testCases := []struct {
+		name             string
+		tfLog            string
+		tfLogProvider    string
+		expectedLogLevel logLevel
+	}{
+		{name: "no env variables set", expectedLogLevel: logLevelTrace},
+		{name: "TF_LOG set to TRACE", tfLog: "TRACE", expectedLogLevel: logLevelTrace},
+		{name: "TF_LOG set to JSON", tfLog: "JSON", expectedLogLevel: logLevelTrace},
+		{name: "TF_LOG set to DEBUG", tfLog: "DEBUG", expectedLogLevel: logLevelDebug},
+		{name: "TF_LOG set to lower case info", tfLog: "info", expectedLogLevel: logLevelInfo},
+		{name: "TF_LOG set to WARN", tfLog: "WARN", expectedLogLevel: logLevelWarn},
+		{name: "TF_LOG set to ERROR", tfLog: "ERROR", expectedLogLevel: logLevelError},
+		{name: "TF_LOG set to OFF", tfLog: "OFF", expectedLogLevel: logLevelOff},
+		{name: "TF_LOG set to unknown value", tfLog: "unknown", expectedLogLevel: logLevelTrace},
+		{name: "TF_LOG_PROVIDER takes preference over TF_LOG", tfLog: "TRACE", tfLogProvider: "ERROR", expectedLogLevel: logLevelError},
+		{name: "TF_LOG_PROVIDER set and TF_LOG not set", tfLogProvider: "WARN", expectedLogLevel: logLevelWarn},
+	}
+	for _, tc := range testCases {
+		os.Setenv(tfLogEnvVar, tc.tfLog)
+		os.Setenv(tfLogProviderEnvVar, tc.tfLogProvider)
+		assert.Equal(t, tc.expectedLogLevel, getLogLevel(), tc.name)
+	}
+	os.Unsetenv(tfLogEnvVar)
+	os.Unsetenv(tfLogProviderEnvVar)
+}
+
+func TestLoggerFormat(t *testing.T) {
+	testCases := []struct {
+		name            string
+		logger          logger
+		level           logLevel
+		format          string
+		args            []interface{}
+		expectedMessage string
+	}{
+		{
+			name:            "message with arguments",
+			logger:          newLogger("client"),
+			level:           logLevelDebug,
+			format:          "Performing %s %s",
+			args:            []interface{}{"GET", "https://www.host.com/v1/cdns"},
+			expectedMessage: "[DEBUG] [client] Performing GET https://www.host.com/v1/cdns",
+		},
+		{
+			name:            "message without arguments is not formatted",
+			logger:          newLogger("poller"),
+			level:           logLevelWarn,
+			format:          "100% done",
+			expectedMessage: "[WARN] [poller] 100% done",
+		},
+		{
+			name:            "message with fields",
+			logger:          newLogger("resource").With("resource", "cdn_v1").With("id", 1234),
+			level:           logLevelError,
+			format:          "something went wrong",
+			expectedMessage: "[ERROR] [resource] something went wrong resource=cdn_v1 id=1234",
+		},
+		{
+			name:            "logger without subsystem",
+			logger:          logger{},
+			level:           logLevelInfo,
+			format:          "some message",
+			expectedMessage: "[INFO] some message",
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedMessage, tc.logger.format(tc.level, tc.format, tc.args...), tc.name)
+	}
+}
+
+func TestLoggerWithDoesNotMutateParent(t *testing.T) {
+	parent := newLogger("resource").With("resource", "cdn_v1")
+	child1 := parent.With("id", "1")
+	child2 := parent.With("id", "2")
+	assert.Len(t, parent.fields, 1)
+	assert.Equal(t, "[INFO] [resource] msg resource=cdn_v1 id=1", child1.format(logLevelInfo, "msg"))
+	assert.Equal(t, "[INFO] [resource] msg resource=cdn_v1 id=2", child2.format(logLevelInfo, "msg"))
+}
+
+func TestLoggerLevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	defer os.Unsetenv(tfLogProviderEnvVar)
+
+	os.Setenv(tfLogProviderEnvVar, "WARN")
+	l := newLogger("analyser")
+	l.Debug("debug message")
+	l.Info("info message")
+	l.Warn("warn message")
+	l.Error("error message")
+
+	assert.NotContains(t, buf.String(), "debug message")
+	assert.NotContains(t, buf.String(), "info message")
+	assert.Contains(t, buf.String(), "[WARN] [analyser] warn message")
+	assert.Contains(t, buf.String(), "[ERROR] [analyser] error message")
+
+	buf.Reset()
+	os.Setenv(tfLogProviderEnvVar, "TRACE")
+	l.Trace("trace message")
+	assert.Contains(t, buf.String(), "[TRACE] [analyser] trace message")
+}
@@ -1,11 +1,16 @@
 package openapi
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
-	"log"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/version"
 
@@ -19,6 +24,7 @@ const (
 	httpPost   httpMethodSupported = "POST"
 	httpPut    httpMethodSupported = "PUT"
 	httpDelete httpMethodSupported = "DELETE"
+	httpPatch  httpMethodSupported = "PATCH"
 )
 
 // ClientOpenAPI defines the behaviour expected to be implemented for the OpenAPI Client used in the Terraform OpenAPI Provider
@@ -26,9 +32,24 @@ type ClientOpenAPI interface {
 	Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
 	Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
 	Get(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	GetIfNoneMatch(resource SpecResource, id string, eTag string, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	GetFields(resource SpecResource, id string, fields []string, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
 	Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error)
 	List(resource SpecResource, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	PostSubResource(resource SpecResource, id string, subResourcePath string, requestPayload interface{}, parentIDs ...string) (*http.Response, error)
+	DeleteSubResource(resource SpecResource, id string, subResourcePath string, parentIDs ...string) (*http.Response, error)
+	Poll(resource SpecResource, id string, pollRequest *specPollRequest, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	PollLocation(resource SpecResource, location string, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	Precheck(resource SpecResource, precheck *specPrecheck, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	AcquireLock(resource SpecResource, lock *specLock, ids ...string) (*http.Response, error)
+	ReleaseLock(resource SpecResource, lock *specLock, ids ...string) (*http.Response, error)
+	Lookup(resource SpecResource, lookupPath string, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	RequestUpload(resource SpecResource, uploadPath string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	Upload(uploadURL string, content io.Reader, size int64) (*http.Response, error)
+	GetOptions(resource SpecResource, optionsPath string, queryParameters map[string]string, responsePayload interface{}) (*http.Response, error)
+	Count(resource SpecResource, count *specCount, queryParameters map[string]string, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
 	GetTelemetryHandler() TelemetryHandler
+	GetProviderHost() (host string, region string, err error)
 }
 
 // ProviderClient defines a client that is configured based on the OpenAPI server side documentation
@@ -40,79 +61,500 @@ type ProviderClient struct {
 	providerConfiguration       providerConfiguration
 	apiAuthenticator            specAuthenticator
 	telemetryHandler            TelemetryHandler
+	requestCompression          *RequestCompressionConfig
+	// maxResponseBodySize defines the max size in bytes of the response bodies; zero means no limit
+	maxResponseBodySize int64
+	// middlewares defines the middleware chain the API calls go through; nil if no middlewares are configured
+	middlewares *middlewareChain
+	// userAgent contains the template of the User-Agent header configured for the service; empty if the default
+	// User-Agent is sent
+	userAgent string
+	// userAgentTemplateData contains the values the User-Agent templates are rendered with
+	userAgentTemplateData userAgentTemplateData
+	// identificationHeaders contains the static headers sent in all the API calls to identify the client
+	identificationHeaders map[string]string
+	// tlsConfig contains the TLS configuration of the client transport; nil if the Go defaults apply. The REST API calls
+	// use the default http transport which is configured at provider initialisation, this one is used by the gRPC backend
+	tlsConfig *tls.Config
+	// dialContext dials the connections to the API based on the dialer configuration; nil if the Go defaults apply. As
+	// with the tlsConfig, the REST API calls use the default http transport, this one is used by the gRPC backend
+	dialContext dialContextFunc
+	// hostFailover fails over the API calls to the fallback hosts when the provider host is not available; nil if no
+	// fallback hosts are configured
+	hostFailover *hostFailover
+	// srvHostResolver resolves the provider host from the DNS SRV record configured for the service; nil if service
+	// discovery is not configured
+	srvHostResolver *srvHostResolver
+	// ctx is the context the API calls are bound to (e,g: the data source read timeout); nil if the calls are not bound to
+	// any context
+	ctx context.Context
+	// unbound is the client the copy was bound to the context from; nil if the client is not bound to any context
+	unbound *ProviderClient
+	// session authenticates the API calls with the session token returned by the login endpoint; nil if the API does not
+	// use session login (x-terraform-provider-session-login)
+	session *apiSession
+	// consistencyTokens keeps the latest consistency token returned per resource, which is sent on the following reads of
+	// the resource (x-terraform-consistency-token); nil if the tokens are not propagated
+	consistencyTokens *consistencyTokens
+	// rateLimitRetry defines the budget of the retries of the API calls responding 429 Too Many Requests; nil if the API
+	// calls are not retried
+	rateLimitRetry *rateLimitRetry
+}
+
+// withContext returns a copy of the client whose API calls are cancelled as soon as the context passed in is done
+func (o *ProviderClient) withContext(ctx context.Context) ClientOpenAPI {
+	client := *o
+	client.ctx = ctx
+	client.unbound = o.withoutContext().(*ProviderClient)
+	return &client
+}
+
+// withoutContext returns the client the copy was bound to the context from; the client itself if it is not bound
+func (o *ProviderClient) withoutContext() ClientOpenAPI {
+	if o.unbound != nil {
+		return o.unbound
+	}
+	return o
 }
 
 // Post performs a POST request to the server API based on the resource configuration and the payload passed in
 func (o *ProviderClient) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Post
+	if operation.isGraphQL() {
+		return o.performGraphQLRequest(httpPost, resource, operation, parentIDs, "", requestPayload, responsePayload)
+	}
 	resourceURL, err := o.getResourceURL(resource, parentIDs)
 	if err != nil {
 		return nil, err
 	}
-	operation := resource.getResourceOperations().Post
-	return o.performRequest(httpPost, resourceURL, operation, requestPayload, responsePayload)
+	resourceURL = o.appendForceApplyQueryParameter(resourceURL, operation)
+	resp, err := o.performRequest(httpPost, resourceURL, operation, requestPayload, responsePayload)
+	if err == nil {
+		o.consistencyTokens.record(resource, operation, resp, responsePayload)
+	}
+	return resp, err
 }
 
-// Put performs a PUT request to the server API based on the resource configuration and the payload passed in
+// Put performs a PUT request to the server API based on the resource configuration and the payload passed in. If the
+// operation update strategy is merge-patch or json-patch, a PATCH request is performed instead with the payload passed in
+// sent as a JSON merge patch or JSON Patch document respectively
 func (o *ProviderClient) Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Put
+	if operation.isGraphQL() {
+		return o.performGraphQLRequest(httpPut, resource, operation, parentIDs, id, requestPayload, responsePayload)
+	}
 	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
 	if err != nil {
 		return nil, err
 	}
-	operation := resource.getResourceOperations().Put
-	return o.performRequest(httpPut, resourceURL, operation, requestPayload, responsePayload)
+	resourceURL = o.appendForceApplyQueryParameter(resourceURL, operation)
+	var headers map[string]string
+	if updateContentType := operation.getUpdateContentType(); updateContentType != "" {
+		headers = map[string]string{contentType: updateContentType}
+	}
+	resp, err := o.performRequestWithHeaders(operation.getUpdateMethod(), resourceURL, operation, headers, requestPayload, responsePayload)
+	if err == nil {
+		o.consistencyTokens.record(resource, operation, resp, responsePayload)
+	}
+	return resp, err
 }
 
-// Get performs a GET request to the server API based on the resource configuration and the resource instance id passed in
+// Get performs a GET request to the server API based on the resource configuration and the resource instance id passed in.
+// If the operation requests a subset of the resource fields (x-terraform-fields), only those are requested
 func (o *ProviderClient) Get(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
-	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
+	operation := resource.getResourceOperations().Get
+	if operation.isGraphQL() {
+		return o.performGraphQLRequest(httpGet, resource, operation, parentIDs, id, nil, responsePayload)
+	}
+	resourceURL, err := o.getResourceReadURL(resource, parentIDs, id)
+	if err != nil {
+		return nil, err
+	}
+	resourceURL = appendFieldsQueryParameter(resourceURL, operation.getFieldsParameter(), operation.getFields(resource))
+	resourceURL, headers := o.withConsistencyToken(resource, resourceURL, nil)
+	return o.performRequestWithHeaders(httpGet, resourceURL, operation, headers, nil, responsePayload)
+}
+
+// GetIfNoneMatch performs a conditional GET request to the server API sending the eTag provided in the If-None-Match header
+// (if not empty). If the API responds with 304 Not Modified the response payload is not populated; otherwise, the response
+// payload is populated only if the API responds with 200 OK.
+func (o *ProviderClient) GetIfNoneMatch(resource SpecResource, id string, eTag string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Get
+	if operation.isGraphQL() {
+		return o.performGraphQLRequest(httpGet, resource, operation, parentIDs, id, nil, responsePayload)
+	}
+	resourceURL, err := o.getResourceReadURL(resource, parentIDs, id)
 	if err != nil {
 		return nil, err
 	}
+	resourceURL = appendFieldsQueryParameter(resourceURL, operation.getFieldsParameter(), operation.getFields(resource))
+	headers := map[string]string{}
+	if eTag != "" {
+		headers[ifNoneMatchHeader] = eTag
+	}
+	resourceURL, headers = o.withConsistencyToken(resource, resourceURL, headers)
+	return o.performRequestWithHeaders(httpGet, resourceURL, operation, headers, nil, responsePayload)
+}
+
+// GetFields performs a GET request to the server API requesting only the fields passed in (sparse fieldset) via the query
+// parameter configured in the instance GET operation (e,g: GET /v1/groups/1234?fields=id,revision)
+func (o *ProviderClient) GetFields(resource SpecResource, id string, fields []string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
 	operation := resource.getResourceOperations().Get
-	return o.performRequest(httpGet, resourceURL, operation, nil, responsePayload)
+	if operation.isGraphQL() {
+		return o.performGraphQLRequest(httpGet, resource, operation, parentIDs, id, nil, responsePayload)
+	}
+	resourceURL, err := o.getResourceReadURL(resource, parentIDs, id)
+	if err != nil {
+		return nil, err
+	}
+	resourceURL, headers := o.withConsistencyToken(resource, appendFieldsQueryParameter(resourceURL, operation.getFieldsParameter(), fields), nil)
+	return o.performRequestWithHeaders(httpGet, resourceURL, operation, headers, nil, responsePayload)
+}
+
+// appendFieldsQueryParameter appends the fields query parameter to the URL passed in (e,g: fields=id,revision). The field
+// names are escaped individually so the comma separator is kept as is
+func appendFieldsQueryParameter(resourceURL string, fieldsParameter string, fields []string) string {
+	if len(fields) == 0 {
+		return resourceURL
+	}
+	escapedFields := make([]string, len(fields))
+	for i, field := range fields {
+		escapedFields[i] = url.QueryEscape(field)
+	}
+	separator := "?"
+	if strings.Contains(resourceURL, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%s%s=%s", resourceURL, separator, url.QueryEscape(fieldsParameter), strings.Join(escapedFields, ","))
+}
+
+// appendForceApplyQueryParameter appends the force apply query parameter (x-terraform-force-apply-parameter) with value true
+// to the given URL if the user enabled force applying the changes and the operation supports it
+func (o *ProviderClient) appendForceApplyQueryParameter(resourceURL string, operation *specResourceOperation) string {
+	forceApplyParameter := operation.getForceApplyParameter()
+	if forceApplyParameter == "" || !o.providerConfiguration.isForceApplyEnabled() {
+		return resourceURL
+	}
+	separator := "?"
+	if strings.Contains(resourceURL, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%s%s=true", resourceURL, separator, url.QueryEscape(forceApplyParameter))
 }
 
 // List performs a GET request to the root level endpoint of the resource (e,g: GET /v1/groups)
 func (o *ProviderClient) List(resource SpecResource, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().List
+	if operation.isGraphQL() {
+		return o.performGraphQLRequest(httpGet, resource, operation, parentIDs, "", nil, responsePayload)
+	}
 	resourceURL, err := o.getResourceURL(resource, parentIDs)
 	if err != nil {
 		return nil, err
 	}
-	operation := resource.getResourceOperations().List
-	return o.performRequest(httpGet, resourceURL, operation, nil, responsePayload)
+	resourceURL = appendFieldsQueryParameter(resourceURL, operation.getFieldsParameter(), operation.getFields(resource))
+	resourceURL, headers := o.withConsistencyToken(resource, resourceURL, nil)
+	return o.performRequestWithHeaders(httpGet, resourceURL, operation, headers, nil, responsePayload)
 }
 
 // Delete performs a DELETE request to the server API based on the resource configuration and the resource instance id passed in
 func (o *ProviderClient) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Delete
+	if operation.isGraphQL() {
+		return o.performGraphQLRequest(httpDelete, resource, operation, parentIDs, id, nil, nil)
+	}
 	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
 	if err != nil {
 		return nil, err
 	}
-	operation := resource.getResourceOperations().Delete
 	return o.performRequest(httpDelete, resourceURL, operation, nil, nil)
 }
 
+// PostSubResource performs a POST request to the sub resource path of the resource instance (e,g: POST /v1/groups/1234/tags).
+// The request is configured based on the resource PUT operation as sub resources are only modified when updating the resource
+func (o *ProviderClient) PostSubResource(resource SpecResource, id string, subResourcePath string, requestPayload interface{}, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Put
+	resourceURL, err := o.getSubResourceURL(resource, operation, parentIDs, id, subResourcePath)
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(httpPost, resourceURL, operation, requestPayload, nil)
+}
+
+// DeleteSubResource performs a DELETE request to the sub resource path of the resource instance (e,g: DELETE /v1/groups/1234/tags/env).
+// The request is configured based on the resource PUT operation as sub resources are only modified when updating the resource
+func (o *ProviderClient) DeleteSubResource(resource SpecResource, id string, subResourcePath string, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Put
+	resourceURL, err := o.getSubResourceURL(resource, operation, parentIDs, id, subResourcePath)
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(httpDelete, resourceURL, operation, nil, nil)
+}
+
+// Poll performs the custom poll request (x-terraform-resource-poll-request) to check the status of the resource instance
+// (e,g: POST /v1/rpc with a JSON-RPC body). The request is performed against the resource instance path unless the poll
+// request defines the path of the status endpoint, and it is configured based on the resource GET operation
+func (o *ProviderClient) Poll(resource SpecResource, id string, pollRequest *specPollRequest, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Get
+	if operation.isGraphQL() {
+		return nil, fmt.Errorf("resource '%s' poll requests are not supported by GraphQL operations", resource.GetResourceName())
+	}
+	var resourceURL string
+	var err error
+	if pollRequest.path == "" {
+		resourceURL, err = o.getResourceReadURL(resource, parentIDs, id)
+	} else {
+		var path string
+		if path, err = pollRequest.renderPath(id, parentIDs); err == nil {
+			resourceURL, err = o.buildResourceURL(resource, path)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	requestPayload, err := pollRequest.renderBody(id, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	resourceURL, headers := o.withConsistencyToken(resource, resourceURL, nil)
+	return o.performRequestWithHeaders(pollRequest.method, resourceURL, operation, headers, requestPayload, responsePayload)
+}
+
+// PollLocation performs a GET request against the location passed in (e,g: the Location header returned by the API when
+// the resource is created asynchronously), which is resolved relative to the resource URL and must be served by the API
+// host. The request is configured based on the resource GET operation
+func (o *ProviderClient) PollLocation(resource SpecResource, location string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Get
+	if operation.isGraphQL() {
+		return nil, fmt.Errorf("resource '%s' location poll requests are not supported by GraphQL operations", resource.GetResourceName())
+	}
+	resourceURL, err := o.getResourceURL(resource, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	locationURL, err := resolveLocationURL(resourceURL, location)
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(httpGet, locationURL, operation, nil, responsePayload)
+}
+
+// resolveLocationURL returns the absolute URL of the location passed in, resolved relative to the resource URL. An error is
+// returned if the location is not served by the same host as the resource, so the API credentials are not sent elsewhere
+func resolveLocationURL(resourceURL, location string) (string, error) {
+	base, err := url.Parse(resourceURL)
+	if err != nil {
+		return "", err
+	}
+	reference, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("location '%s' not valid: %s", location, err)
+	}
+	locationURL := base.ResolveReference(reference)
+	if !strings.EqualFold(locationURL.Host, base.Host) {
+		return "", fmt.Errorf("location '%s' not valid: the location must be served by the API host '%s'", location, base.Host)
+	}
+	return locationURL.String(), nil
+}
+
+// Precheck performs the GET request against the precheck endpoint (x-terraform-precheck-path) to check whether the resource
+// can be created. The request is configured based on the resource POST operation
+func (o *ProviderClient) Precheck(resource SpecResource, precheck *specPrecheck, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Post
+	if operation.isGraphQL() {
+		return nil, fmt.Errorf("resource '%s' precheck requests are not supported by GraphQL operations", resource.GetResourceName())
+	}
+	path, err := precheck.renderPath(parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	resourceURL, err := o.buildResourceURL(resource, path)
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(httpGet, resourceURL, operation, nil, responsePayload)
+}
+
+// AcquireLock performs the POST request against the lock endpoint (x-terraform-lock-path) to acquire the lock. The path
+// parameters are resolved in order with the ids passed in (the parent IDs followed by the resource ID) and the request is
+// configured based on the resource operation the lock is defined in
+func (o *ProviderClient) AcquireLock(resource SpecResource, lock *specLock, ids ...string) (*http.Response, error) {
+	return o.performLockRequest(httpPost, resource, lock, ids)
+}
+
+// ReleaseLock performs the DELETE request against the lock endpoint (x-terraform-lock-path) to release the lock acquired
+func (o *ProviderClient) ReleaseLock(resource SpecResource, lock *specLock, ids ...string) (*http.Response, error) {
+	return o.performLockRequest(httpDelete, resource, lock, ids)
+}
+
+func (o *ProviderClient) performLockRequest(method httpMethodSupported, resource SpecResource, lock *specLock, ids []string) (*http.Response, error) {
+	operation := getLockOperation(resource, lock)
+	if operation.isGraphQL() {
+		return nil, fmt.Errorf("resource '%s' lock requests are not supported by GraphQL operations", resource.GetResourceName())
+	}
+	path, err := resolvePathParameters(lock.path, ids)
+	if err != nil {
+		return nil, err
+	}
+	resourceURL, err := o.buildResourceURL(resource, path)
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(method, resourceURL, operation, nil, nil)
+}
+
+// getLockOperation returns the resource operation (PUT or DELETE) the lock passed in is defined in
+func getLockOperation(resource SpecResource, lock *specLock) *specResourceOperation {
+	operations := resource.getResourceOperations()
+	if operations.Delete != nil && operations.Delete.lock == lock {
+		return operations.Delete
+	}
+	return operations.Put
+}
+
+// Lookup performs the GET request against the collection endpoint the resources referenced by the resource properties are
+// looked up from (x-terraform-resolve-by-name). The request is configured based on the resource POST operation
+func (o *ProviderClient) Lookup(resource SpecResource, lookupPath string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Post
+	if operation.isGraphQL() {
+		return nil, fmt.Errorf("resource '%s' lookup requests are not supported by GraphQL operations", resource.GetResourceName())
+	}
+	path, err := resolvePathParameters(lookupPath, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	resourceURL, err := o.buildResourceURL(resource, path)
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(httpGet, resourceURL, operation, nil, responsePayload)
+}
+
+// RequestUpload performs the POST request against the endpoint the signed upload URLs of the upload properties are
+// requested from (x-terraform-upload-property). The request is configured based on the resource POST operation
+func (o *ProviderClient) RequestUpload(resource SpecResource, uploadPath string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().Post
+	if operation.isGraphQL() {
+		return nil, fmt.Errorf("resource '%s' upload requests are not supported by GraphQL operations", resource.GetResourceName())
+	}
+	path, err := resolvePathParameters(uploadPath, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	resourceURL, err := o.buildResourceURL(resource, path)
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(httpPost, resourceURL, operation, requestPayload, responsePayload)
+}
+
+// Upload performs the PUT request uploading the content passed in to the signed upload URL returned by the API. The
+// signed URL already grants access to the object storage so the request does not include any of the API headers (e,g:
+// authentication) which could otherwise be rejected by the object storage
+func (o *ProviderClient) Upload(uploadURL string, content io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPut, uploadURL, content)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	req.Header.Set(contentType, "application/octet-stream")
+	client := http.DefaultClient
+	if httpClient, ok := o.httpClient.(*http_goclient.HttpClient); ok && httpClient.HttpClient != nil {
+		client = httpClient.HttpClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	return resp, err
+}
+
+// GetOptions performs the GET request against the options endpoint (x-terraform-options-path) including the query parameters
+// passed in. The request is configured based on the resource POST operation
+func (o *ProviderClient) GetOptions(resource SpecResource, optionsPath string, queryParameters map[string]string, responsePayload interface{}) (*http.Response, error) {
+	operation := resource.getResourceOperations().Post
+	if operation.isGraphQL() {
+		return nil, fmt.Errorf("resource '%s' options requests are not supported by GraphQL operations", resource.GetResourceName())
+	}
+	resourceURL, err := o.buildResourceURL(resource, appendOptionsQueryParameters(optionsPath, queryParameters))
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(httpGet, resourceURL, operation, nil, responsePayload)
+}
+
+// Count performs the GET request against the count endpoint (x-terraform-count-path) including the query parameters passed
+// in. The request is configured based on the resource root path GET operation
+func (o *ProviderClient) Count(resource SpecResource, count *specCount, queryParameters map[string]string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	operation := resource.getResourceOperations().List
+	if operation.isGraphQL() {
+		return nil, fmt.Errorf("resource '%s' count requests are not supported by GraphQL operations", resource.GetResourceName())
+	}
+	path, err := resolvePathParameters(count.path, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	resourceURL, err := o.buildResourceURL(resource, appendOptionsQueryParameters(path, queryParameters))
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(httpGet, resourceURL, operation, nil, responsePayload)
+}
+
+func (o ProviderClient) getSubResourceURL(resource SpecResource, operation *specResourceOperation, parentIDs []string, id string, subResourcePath string) (string, error) {
+	if operation == nil {
+		return "", fmt.Errorf("resource '%s' does not support %s operations", resource.GetResourceName(), httpPut)
+	}
+	if operation.isGraphQL() {
+		return "", fmt.Errorf("resource '%s' sub resource requests are not supported by GraphQL operations", resource.GetResourceName())
+	}
+	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", resourceURL, subResourcePath), nil
+}
+
 // GetTelemetryHandler returns the configured telemetry handler
 func (o *ProviderClient) GetTelemetryHandler() TelemetryHandler {
 	return o.telemetryHandler
 }
 
 func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
-	reqContext, err := o.apiAuthenticator.prepareAuth(resourceURL, operation.SecuritySchemes, o.providerConfiguration)
-	if err != nil {
-		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
-	}
+	return o.performRequestWithHeaders(method, resourceURL, operation, nil, requestPayload, responsePayload)
+}
 
-	err = o.appendOperationHeaders(operation.HeaderParameters, reqContext.headers)
+// performRequestWithHeaders performs the request including the headers passed in along with the ones required by the operation
+func (o *ProviderClient) performRequestWithHeaders(method httpMethodSupported, resourceURL string, operation *specResourceOperation, headers map[string]string, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	requestedAt := time.Now()
+	reqContext, err := o.prepareRequest(method, resourceURL, operation)
 	if err != nil {
-		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
+		return nil, err
+	}
+	for name, value := range headers {
+		reqContext.headers[name] = value
 	}
-	log.Printf("[DEBUG] Performing %s %s", method, reqContext.url)
-
-	userAgentHeader := version.BuildUserAgent(runtime.GOOS, runtime.GOARCH)
-	o.appendUserAgentHeader(reqContext.headers, userAgentHeader)
 
-	o.logHeadersSafely(reqContext.headers)
+	if httpClient, ok := o.httpClient.(*http_goclient.HttpClient); ok && httpClient.HttpClient != nil {
+		client := withRequestTimeout(o.middlewares.getHTTPClient(o.hostFailover.getHTTPClient(httpClient.HttpClient), operation), operation)
+		resp, err := o.doRequestWithRateLimitRetry(client, method, reqContext, requestPayload, responsePayload)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized || o.session == nil {
+			return resp, err
+		}
+		// the API rejected the session before its expiry (or its expiry is unknown), logging in again and retrying the request once
+		clientLog.Info("%s %s responded with status code '%d', renewing the API session", method, resourceURL, resp.StatusCode)
+		o.session.invalidate(requestedAt)
+		if reqContext, err = o.prepareRequest(method, resourceURL, operation); err != nil {
+			return nil, err
+		}
+		for name, value := range headers {
+			reqContext.headers[name] = value
+		}
+		return o.doRequestWithRateLimitRetry(client, method, reqContext, requestPayload, responsePayload)
+	}
 
 	switch method {
 	case httpPost:
@@ -127,19 +569,172 @@ func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL
 	return nil, fmt.Errorf("method '%s' not supported", method)
 }
 
+// withRequestTimeout returns an http client with the request timeout of the operation (x-terraform-request-timeout), so
+// slow operations (e,g: synchronous creates) can take longer than the rest of the API calls. The http client passed in is
+// returned as is if the operation does not define a request timeout
+func withRequestTimeout(httpClient *http.Client, operation *specResourceOperation) *http.Client {
+	if operation == nil || operation.requestTimeout == nil {
+		return httpClient
+	}
+	return &http.Client{
+		Transport:     httpClient.Transport,
+		CheckRedirect: httpClient.CheckRedirect,
+		Jar:           httpClient.Jar,
+		Timeout:       *operation.requestTimeout,
+	}
+}
+
+// doRequest performs the request using the http client passed in. The request payload is encoded as it is sent to the API
+// (see newRequestBody) and successful (2xx) responses are decoded directly from the response stream into the response
+// payload, avoiding holding the whole encoded payloads in memory. The rest of the response bodies are kept so they can be
+// read afterwards (e,g: when checking the response status code). The response bodies are limited to the max response body
+// size configured (if any)
+func (o *ProviderClient) doRequest(httpClient *http.Client, method httpMethodSupported, reqContext *authContext, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	var body *requestBody
+	var bodyReader io.Reader
+	if requestPayload != nil {
+		var err error
+		body, err = newRequestBody(requestPayload, o.getGzipThreshold())
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the request body for %s %s: %s", method, reqContext.url, err)
+		}
+		bodyReader = body.reader
+	}
+	ctx := o.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, string(method), reqContext.url, bodyReader)
+	if err != nil {
+		if closer, ok := bodyReader.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+	for key, value := range reqContext.headers {
+		req.Header.Set(key, value)
+	}
+	if body != nil {
+		req.ContentLength = body.contentLength
+		if req.Header.Get(contentType) == "" {
+			req.Header.Set(contentType, "application/json")
+		}
+		if body.gzipped {
+			req.Header.Set(contentEncodingHeader, "gzip")
+			clientLog.Debug("Request body for %s %s sent gzip compressed", method, reqContext.url)
+		}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s %s %s failed. Response Error: '%s'", req.Method, req.URL, req.Proto, err.Error())
+	}
+	resp.Body = newResponseBodyReader(resp.Body, o.maxResponseBodySize)
+	if responsePayload != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		err = decodeResponseBody(resp, responsePayload)
+	} else {
+		err = bufferResponseBody(resp)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s for request = '%s %s'. Response = '%s'", err, method, reqContext.url, resp.Status)
+	}
+	return resp, nil
+}
+
+// getGzipThreshold returns the size in bytes above which request bodies are gzip compressed; -1 is returned if request
+// compression is not configured
+func (o *ProviderClient) getGzipThreshold() int {
+	if o.requestCompression == nil {
+		return -1
+	}
+	return o.requestCompression.GzipThreshold
+}
+
+// prepareRequest returns the request context (url and headers) containing the authentication, operation and user agent headers
+func (o *ProviderClient) prepareRequest(method httpMethodSupported, resourceURL string, operation *specResourceOperation) (*authContext, error) {
+	reqContext, err := o.apiAuthenticator.prepareAuth(resourceURL, operation.SecuritySchemes, o.providerConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
+	}
+
+	if o.session != nil {
+		if err := o.session.prepareAuth(reqContext); err != nil {
+			return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
+		}
+	}
+
+	err = o.appendOperationHeaders(operation.HeaderParameters, reqContext.headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
+	}
+	clientLog.Debug("Performing %s %s", method, reqContext.url)
+
+	o.appendIdentificationHeaders(reqContext.headers)
+	o.appendAcceptLanguageHeader(reqContext.headers)
+	o.appendUserAgentHeader(reqContext.headers, o.getUserAgent(operation))
+
+	o.logHeadersSafely(reqContext.headers)
+	return reqContext, nil
+}
+
 func (o *ProviderClient) appendUserAgentHeader(headers map[string]string, value string) {
 	headers[userAgentHeader] = value
 }
 
+// getUserAgent returns the User-Agent sent when performing the operation. The operation User-Agent template
+// (x-terraform-user-agent) takes precedence over the one configured for the service; if none is configured or the
+// template fails to render the default OpenAPI Terraform provider User-Agent is returned
+func (o *ProviderClient) getUserAgent(operation *specResourceOperation) string {
+	userAgent := o.userAgent
+	if operation != nil && operation.userAgent != "" {
+		userAgent = operation.userAgent
+	}
+	if userAgent == "" {
+		return version.BuildUserAgent(runtime.GOOS, runtime.GOARCH)
+	}
+	renderedUserAgent, err := renderUserAgent(userAgent, o.userAgentTemplateData)
+	if err != nil {
+		clientLog.Warn("failed to render the User-Agent template '%s', sending the default User-Agent: %s", userAgent, err)
+		return version.BuildUserAgent(runtime.GOOS, runtime.GOARCH)
+	}
+	return renderedUserAgent
+}
+
+// appendIdentificationHeaders adds the identification headers configured for the service. The headers already present
+// (e,g: authentication or operation headers) are not overridden
+func (o *ProviderClient) appendIdentificationHeaders(headers map[string]string) {
+	for name, value := range o.identificationHeaders {
+		if _, exists := headers[name]; exists {
+			clientLog.Warn("identification header '%s' is already set by the operation, ignoring it", name)
+			continue
+		}
+		headers[name] = value
+	}
+}
+
+// appendAcceptLanguageHeader adds the Accept-Language header with the languages configured in the provider configuration
+// so the API localizes its messages (e,g: error messages). The header is not overridden if already set by the operation
+func (o *ProviderClient) appendAcceptLanguageHeader(headers map[string]string) {
+	acceptLanguage := o.providerConfiguration.getAcceptLanguage()
+	if acceptLanguage == "" {
+		return
+	}
+	for name := range headers {
+		if strings.EqualFold(name, acceptLanguageHeader) {
+			return
+		}
+	}
+	headers[acceptLanguageHeader] = acceptLanguage
+}
+
 // logHeadersSafely logs the header names sent to the APIs but the values are redacted for security reasons in case
 // values contain secrets. However, the logging will display whether the values contained data or not so it's easier
 // to debug whether the headers sent had data.
 func (o *ProviderClient) logHeadersSafely(headers map[string]string) {
 	for headerName, headerValue := range headers {
 		if headerValue == "" {
-			log.Printf("[DEBUG] Request Header '%s' sent with empty value :(", headerName)
+			clientLog.Debug("Request Header '%s' sent with empty value :(", headerName)
 		}
-		log.Printf("[DEBUG] Request Header '%s' sent", headerName)
+		clientLog.Debug("Request Header '%s' sent", headerName)
 	}
 }
 
@@ -159,13 +754,12 @@ func (o ProviderClient) appendOperationHeaders(operationHeaders []SpecHeaderPara
 	return nil
 }
 
-func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string) (string, error) {
-	var host string
-	var err error
-
+// GetProviderHost returns the host the API calls are made against by default (before any resource host or endpoint
+// override is applied) and the region selected if the provider is multi-region; otherwise the region returned is empty
+func (o *ProviderClient) GetProviderHost() (string, string, error) {
 	isMultiRegion, _, regions, err := o.openAPIBackendConfiguration.IsMultiRegion()
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	if isMultiRegion {
 		// get region value provided by user in the terraform configuration file
@@ -174,63 +768,125 @@ func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string
 		if region == "" {
 			region, err = o.openAPIBackendConfiguration.GetDefaultRegion(regions)
 			if err != nil {
-				return "", err
+				return "", "", err
 			}
 		}
-		host, err = o.openAPIBackendConfiguration.getHostByRegion(region)
+		host, err := o.openAPIBackendConfiguration.getHostByRegion(region)
 		if err != nil {
-			return "", err
+			return "", "", err
 		}
-	} else {
-		host, err = o.openAPIBackendConfiguration.getHost()
+		return host, region, nil
+	}
+	if host := o.providerConfiguration.getHost(); host != "" {
+		return host, "", nil
+	}
+	if o.srvHostResolver != nil {
+		host, err := o.srvHostResolver.getHost()
 		if err != nil {
-			return "", err
+			return "", "", err
 		}
+		return host, "", nil
 	}
+	host, err := o.openAPIBackendConfiguration.getHost()
+	if err != nil {
+		return "", "", err
+	}
+	return host, "", nil
+}
 
-	basePath := o.openAPIBackendConfiguration.getBasePath()
+func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string) (string, error) {
 	resourceRelativePath, err := resource.getResourcePath(parentIDs)
 	if err != nil {
 		return "", err
 	}
+	return o.buildResourceURL(resource, resourceRelativePath)
+}
 
-	// Fall back to override the host if value is not empty; otherwise global host will be used as usual
-	hostOverride, err := resource.getHost()
+// buildResourceURL returns the URL of the resource relative path passed in, using the host, base path and scheme the resource
+// API calls are made against
+func (o ProviderClient) buildResourceURL(resource SpecResource, resourceRelativePath string) (string, error) {
+	host, err := o.getResourceHost(resource)
 	if err != nil {
 		return "", err
 	}
-	if hostOverride != "" {
-		log.Printf("[INFO] resource '%s' is configured with host override, API calls will be made against '%s' instead of '%s'", resourceRelativePath, hostOverride, host)
-		host = hostOverride
-	}
 
-	if endPointHost := o.providerConfiguration.getEndPoint(resource.GetResourceName()); endPointHost != "" {
-		log.Printf("[INFO] resource '%s' is configured with endpoint override, API calls will be made against '%s' instead of '%s'", resourceRelativePath, endPointHost, host)
-		host = endPointHost
-	}
+	backendConfiguration := o.getResourceBackendConfiguration(resource)
+	basePath := backendConfiguration.getBasePath()
 
 	if host == "" || resourceRelativePath == "" {
 		return "", fmt.Errorf("host and path are mandatory attributes to get the resource URL - host['%s'], path['%s']", host, resourceRelativePath)
 	}
 
 	// TODO: use resource operation schemes if specified
-	defaultScheme, err := o.openAPIBackendConfiguration.getHTTPScheme()
+	defaultScheme, err := backendConfiguration.getHTTPScheme()
 	if err != nil {
 		return "", err
 	}
 
-	path := resourceRelativePath
-	if strings.Index(resourceRelativePath, "/") != 0 {
-		path = fmt.Sprintf("/%s", resourceRelativePath)
+	return buildURL(defaultScheme, host, basePath, resourceRelativePath), nil
+}
+
+// buildURL returns the URL of the path passed in, relative to the base path of the host
+func buildURL(scheme, host, basePath, relativePath string) string {
+	path := relativePath
+	if strings.Index(relativePath, "/") != 0 {
+		path = fmt.Sprintf("/%s", relativePath)
 	}
 
 	if basePath != "" && basePath != "/" {
 		if strings.Index(basePath, "/") == 0 {
-			return fmt.Sprintf("%s://%s%s%s", defaultScheme, host, basePath, path), nil
+			return fmt.Sprintf("%s://%s%s%s", scheme, host, basePath, path)
 		}
-		return fmt.Sprintf("%s://%s/%s%s", defaultScheme, host, basePath, path), nil
+		return fmt.Sprintf("%s://%s/%s%s", scheme, host, basePath, path)
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, host, path)
+}
+
+// getResourceBackendConfiguration returns the backend configuration of the OpenAPI document the resource is defined in. For
+// providers aggregating several OpenAPI documents, the resources from the additional documents are served by their own backend
+func (o ProviderClient) getResourceBackendConfiguration(resource SpecResource) SpecBackendConfiguration {
+	if aggregatedResource, ok := resource.(*specAggregatedResource); ok {
+		return aggregatedResource.backendConfiguration
+	}
+	return o.openAPIBackendConfiguration
+}
+
+// getResourceHost returns the host the resource API calls are made against. The provider host is overridden by the resource
+// host (x-terraform-resource-host) and the endpoint configured for the resource in the provider configuration (if any)
+func (o ProviderClient) getResourceHost(resource SpecResource) (string, error) {
+	host, _, err := o.GetProviderHost()
+	if err != nil {
+		return "", err
+	}
+
+	// Fall back to override the host if value is not empty; otherwise global host will be used as usual
+	hostOverride, err := resource.getHost()
+	if err != nil {
+		return "", err
+	}
+	if hostOverride != "" {
+		clientLog.Info("resource '%s' is configured with host override, API calls will be made against '%s' instead of '%s'", resource.GetResourceName(), hostOverride, host)
+		host = hostOverride
+	}
+
+	if endPointHost := o.providerConfiguration.getEndPoint(resource.GetResourceName()); endPointHost != "" {
+		clientLog.Info("resource '%s' is configured with endpoint override, API calls will be made against '%s' instead of '%s'", resource.GetResourceName(), endPointHost, host)
+		host = endPointHost
+	}
+	return host, nil
+}
+
+// getResourceReadURL returns the URL the resource instance is read from: the read path (x-terraform-read-path) URL if the
+// resource is configured with one; otherwise the resource instance URL
+func (o ProviderClient) getResourceReadURL(resource SpecResource, parentIDs []string, id string) (string, error) {
+	readPath, err := resource.getReadPath(parentIDs, id)
+	if err != nil {
+		return "", err
+	}
+	if readPath == "" {
+		return o.getResourceIDURL(resource, parentIDs, id)
 	}
-	return fmt.Sprintf("%s://%s%s", defaultScheme, host, path), nil
+	return o.buildResourceURL(resource, readPath)
 }
 
 func (o ProviderClient) getResourceIDURL(resource SpecResource, parentIDs []string, id string) (string, error) {
@@ -0,0 +1,220 @@
+package openapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+	"time"
+)
+
+// auditLogEntry defines the audit log line recorded per mutating API call
+type auditLogEntry struct {
+	Timestamp string `json:"timestamp"`
+	Method    string `json:"method"`
+	Resource  string `json:"resource"`
+	Path      string `json:"path"`
+	// PayloadHash contains the SHA-256 hash of the JSON encoded request payload; empty if the request has no payload.
+	// The payload itself is not recorded as it may contain sensitive information
+	PayloadHash string `json:"payload_hash,omitempty"`
+	// Status contains the response status code; zero if the API call failed before receiving a response
+	Status   int    `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Identity string `json:"identity"`
+}
+
+// auditLog appends the audit log entries to the file configured. The file is opened in append mode for every entry so
+// several provider processes (e,g: parallel terraform runs) can share the same audit log file
+type auditLog struct {
+	mutex    sync.Mutex
+	path     string
+	identity string
+}
+
+func newAuditLog(auditLogConfig *AuditLogConfig) *auditLog {
+	identity := auditLogConfig.Identity
+	if identity == "" {
+		identity = getOSUserName()
+	}
+	return &auditLog{path: auditLogConfig.Path, identity: identity}
+}
+
+func getOSUserName() string {
+	if currentUser, err := user.Current(); err == nil {
+		return currentUser.Username
+	}
+	return os.Getenv("USER")
+}
+
+// record appends the audit log entry of the API call performed. Failures writing the entry are logged but do not fail the
+// API call since the change was already applied
+func (a *auditLog) record(method httpMethodSupported, resource SpecResource, id string, parentIDs []string, requestPayload interface{}, resp *http.Response, err error) {
+	var path string
+	if resourcePath, pathErr := resource.getResourcePath(parentIDs); pathErr == nil {
+		path = resourcePath
+		if id != "" {
+			path = resourcePath + "/" + id
+		}
+	}
+	a.recordPath(method, resource.GetResourceName(), path, requestPayload, resp, err)
+}
+
+// recordPath appends the audit log entry of the API call performed against the path passed in, for the API calls made
+// against endpoints other than the resource ones (e,g: lock endpoints)
+func (a *auditLog) recordPath(method httpMethodSupported, resourceName, path string, requestPayload interface{}, resp *http.Response, err error) {
+	entry := auditLogEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Method:    string(method),
+		Resource:  resourceName,
+		Path:      path,
+		Identity:  a.identity,
+	}
+	if requestPayload != nil {
+		if payload, marshalErr := json.Marshal(requestPayload); marshalErr == nil {
+			hash := sha256.Sum256(payload)
+			entry.PayloadHash = "sha256:" + hex.EncodeToString(hash[:])
+		}
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if writeErr := a.write(entry); writeErr != nil {
+		clientLog.Error("failed to write the audit log entry for %s %s to '%s': %s", entry.Method, entry.Path, a.path, writeErr)
+	}
+}
+
+func (a *auditLog) write(entry auditLogEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// auditLogClient is a ClientOpenAPI that records in the audit log the mutating API calls (POST, PUT and DELETE) performed
+// by the client it wraps, including the lock requests (x-terraform-lock-path) and the uploads (x-terraform-upload-property), regardless of the backend (REST, GraphQL or gRPC) the calls are performed against
+type auditLogClient struct {
+	ClientOpenAPI
+	auditLog *auditLog
+	// unbound is the client the copy was bound to the context from; nil if the client is not bound to any context
+	unbound *auditLogClient
+}
+
+// withContext returns the audit log client wrapping the underlying client bound to the context passed in
+func (c *auditLogClient) withContext(ctx context.Context) ClientOpenAPI {
+	return &auditLogClient{
+		ClientOpenAPI: clientWithContext(ctx, c.ClientOpenAPI).(ClientOpenAPI),
+		auditLog:      c.auditLog,
+		unbound:       c.withoutContext().(*auditLogClient),
+	}
+}
+
+// withoutContext returns the client the copy was bound to the context from; the client itself if it is not bound
+func (c *auditLogClient) withoutContext() ClientOpenAPI {
+	if c.unbound != nil {
+		return c.unbound
+	}
+	return c
+}
+
+// Post performs the POST call and records it in the audit log
+func (c *auditLogClient) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	resp, err := c.ClientOpenAPI.Post(resource, requestPayload, responsePayload, parentIDs...)
+	c.auditLog.record(httpPost, resource, "", parentIDs, requestPayload, resp, err)
+	return resp, err
+}
+
+// Put performs the update call (PUT, or PATCH if the update is sent as a JSON merge patch) and records it in the audit log
+func (c *auditLogClient) Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	resp, err := c.ClientOpenAPI.Put(resource, id, requestPayload, responsePayload, parentIDs...)
+	c.auditLog.record(resource.getResourceOperations().Put.getUpdateMethod(), resource, id, parentIDs, requestPayload, resp, err)
+	return resp, err
+}
+
+// Delete performs the DELETE call and records it in the audit log
+func (c *auditLogClient) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	resp, err := c.ClientOpenAPI.Delete(resource, id, parentIDs...)
+	c.auditLog.record(httpDelete, resource, id, parentIDs, nil, resp, err)
+	return resp, err
+}
+
+// PostSubResource performs the sub resource POST call and records it in the audit log
+func (c *auditLogClient) PostSubResource(resource SpecResource, id string, subResourcePath string, requestPayload interface{}, parentIDs ...string) (*http.Response, error) {
+	resp, err := c.ClientOpenAPI.PostSubResource(resource, id, subResourcePath, requestPayload, parentIDs...)
+	c.auditLog.record(httpPost, resource, id+"/"+subResourcePath, parentIDs, requestPayload, resp, err)
+	return resp, err
+}
+
+// DeleteSubResource performs the sub resource DELETE call and records it in the audit log
+func (c *auditLogClient) DeleteSubResource(resource SpecResource, id string, subResourcePath string, parentIDs ...string) (*http.Response, error) {
+	resp, err := c.ClientOpenAPI.DeleteSubResource(resource, id, subResourcePath, parentIDs...)
+	c.auditLog.record(httpDelete, resource, id+"/"+subResourcePath, parentIDs, nil, resp, err)
+	return resp, err
+}
+
+// AcquireLock performs the POST call against the lock endpoint and records it in the audit log
+func (c *auditLogClient) AcquireLock(resource SpecResource, lock *specLock, ids ...string) (*http.Response, error) {
+	resp, err := c.ClientOpenAPI.AcquireLock(resource, lock, ids...)
+	c.recordLock(httpPost, resource, lock, ids, resp, err)
+	return resp, err
+}
+
+// ReleaseLock performs the DELETE call against the lock endpoint and records it in the audit log
+func (c *auditLogClient) ReleaseLock(resource SpecResource, lock *specLock, ids ...string) (*http.Response, error) {
+	resp, err := c.ClientOpenAPI.ReleaseLock(resource, lock, ids...)
+	c.recordLock(httpDelete, resource, lock, ids, resp, err)
+	return resp, err
+}
+
+func (c *auditLogClient) recordLock(method httpMethodSupported, resource SpecResource, lock *specLock, ids []string, resp *http.Response, err error) {
+	path, _ := resolvePathParameters(lock.path, ids)
+	c.auditLog.recordPath(method, resource.GetResourceName(), path, nil, resp, err)
+}
+
+// RequestUpload performs the POST call requesting the signed upload URL and records it in the audit log
+func (c *auditLogClient) RequestUpload(resource SpecResource, uploadPath string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	resp, err := c.ClientOpenAPI.RequestUpload(resource, uploadPath, requestPayload, responsePayload, parentIDs...)
+	path, _ := resolvePathParameters(uploadPath, parentIDs)
+	c.auditLog.recordPath(httpPost, resource.GetResourceName(), path, requestPayload, resp, err)
+	return resp, err
+}
+
+// Upload performs the PUT call uploading the content to the signed upload URL and records it in the audit log. The query
+// string of the signed URL is not recorded (not even in the error) since it contains the credentials granting access to
+// the object storage, and neither is the hash of the content since it is streamed to the object storage
+func (c *auditLogClient) Upload(uploadURL string, content io.Reader, size int64) (*http.Response, error) {
+	resp, err := c.ClientOpenAPI.Upload(uploadURL, content, size)
+	var path string
+	if parsedURL, parseErr := url.Parse(uploadURL); parseErr == nil {
+		parsedURL.RawQuery = ""
+		parsedURL.Fragment = ""
+		path = parsedURL.String()
+	}
+	auditErr := err
+	if err != nil {
+		auditErr = errors.New(strings.ReplaceAll(err.Error(), uploadURL, path))
+	}
+	c.auditLog.recordPath(httpPut, "", path, nil, resp, auditErr)
+	return resp, err
+}
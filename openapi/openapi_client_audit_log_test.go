@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAuditLogEntries(t *testing.T, path string) []auditLogEntry {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var entries []auditLogEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := auditLogEntry{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestNewAuditLog(t *testing.T) {
+	a := newAuditLog(&AuditLogConfig{Path: "/tmp/audit.log", Identity: "ci-pipeline"})
+	assert.Equal(t, "/tmp/audit.log", a.path)
+	assert.Equal(t, "ci-pipeline", a.identity)
+
+	a = newAuditLog(&AuditLogConfig{Path: "/tmp/audit.log"})
+	assert.Equal(t, getOSUserName(), a.identity, "the OS user should be recorded if the identity is not configured")
+}
+
+func TestAuditLogClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	client := &clientOpenAPIStub{responsePayload: map[string]interface{}{"id": "1234"}}
+	auditClient := &auditLogClient{ClientOpenAPI: client, auditLog: &auditLog{path: path, identity: "ci-pipeline"}}
+	resource := &specStubResource{name: "cdns_v1", path: "/v1/cdns"}
+	requestPayload := map[string]interface{}{"label": "some label"}
+
+	_, err := auditClient.Post(resource, requestPayload, &map[string]interface{}{})
+	require.NoError(t, err)
+	_, err = auditClient.Put(resource, "1234", requestPayload, &map[string]interface{}{})
+	require.NoError(t, err)
+	_, err = auditClient.Get(resource, "1234", &map[string]interface{}{})
+	require.NoError(t, err)
+	_, err = auditClient.Delete(resource, "1234")
+	require.NoError(t, err)
+	client.error = errors.New("connection refused")
+	_, err = auditClient.Delete(resource, "5678")
+	assert.EqualError(t, err, "connection refused")
+
+	payload, _ := json.Marshal(requestPayload)
+	hash := sha256.Sum256(payload)
+	expectedPayloadHash := "sha256:" + hex.EncodeToString(hash[:])
+
+	entries := readAuditLogEntries(t, path)
+	require.Len(t, entries, 4, "only the mutating API calls should be recorded")
+	for _, entry := range entries {
+		_, err := time.Parse(time.RFC3339Nano, entry.Timestamp)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, auditLogEntry{Method: "POST", Resource: "cdns_v1", Path: "/v1/cdns", PayloadHash: expectedPayloadHash, Status: 201, Identity: "ci-pipeline"}, withoutTimestamp(entries[0]))
+	assert.Equal(t, auditLogEntry{Method: "PUT", Resource: "cdns_v1", Path: "/v1/cdns/1234", PayloadHash: expectedPayloadHash, Status: 200, Identity: "ci-pipeline"}, withoutTimestamp(entries[1]))
+	assert.Equal(t, auditLogEntry{Method: "DELETE", Resource: "cdns_v1", Path: "/v1/cdns/1234", Status: 204, Identity: "ci-pipeline"}, withoutTimestamp(entries[2]))
+	assert.Equal(t, auditLogEntry{Method: "DELETE", Resource: "cdns_v1", Path: "/v1/cdns/5678", Error: "connection refused", Identity: "ci-pipeline"}, withoutTimestamp(entries[3]))
+}
+
+func TestAuditLogClientLocks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	client := &clientOpenAPIStub{}
+	auditClient := &auditLogClient{ClientOpenAPI: client, auditLog: &auditLog{path: path, identity: "ci-pipeline"}}
+	resource := &specStubResource{name: "cdns_v1", path: "/v1/projects/{project_id}/cdns"}
+	lock := &specLock{path: "/v1/projects/{project_id}/locks/cdns"}
+
+	_, err := auditClient.AcquireLock(resource, lock, "project-1", "1234")
+	require.NoError(t, err)
+	_, err = auditClient.ReleaseLock(resource, lock, "project-1", "1234")
+	require.NoError(t, err)
+	client.funcAcquireLock = func() (*http.Response, error) { return nil, errors.New("connection refused") }
+	_, err = auditClient.AcquireLock(resource, lock, "project-1", "1234")
+	assert.EqualError(t, err, "connection refused")
+
+	entries := readAuditLogEntries(t, path)
+	require.Len(t, entries, 3)
+	assert.Equal(t, auditLogEntry{Method: "POST", Resource: "cdns_v1", Path: "/v1/projects/project-1/locks/cdns", Status: 201, Identity: "ci-pipeline"}, withoutTimestamp(entries[0]))
+	assert.Equal(t, auditLogEntry{Method: "DELETE", Resource: "cdns_v1", Path: "/v1/projects/project-1/locks/cdns", Status: 204, Identity: "ci-pipeline"}, withoutTimestamp(entries[1]))
+	assert.Equal(t, auditLogEntry{Method: "POST", Resource: "cdns_v1", Path: "/v1/projects/project-1/locks/cdns", Error: "connection refused", Identity: "ci-pipeline"}, withoutTimestamp(entries[2]))
+}
+
+func TestAuditLogClientUploads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	client := &clientOpenAPIStub{uploadResponsePayload: map[string]interface{}{"upload_url": "https://storage.example.com/bucket/object"}}
+	auditClient := &auditLogClient{ClientOpenAPI: client, auditLog: &auditLog{path: path, identity: "ci-pipeline"}}
+	resource := &specStubResource{name: "functions_v1", path: "/v1/projects/{project_id}/functions"}
+	requestPayload := map[string]interface{}{"file_name": "function.zip"}
+
+	_, err := auditClient.RequestUpload(resource, "/v1/projects/{project_id}/uploads", requestPayload, &map[string]interface{}{}, "project-1")
+	require.NoError(t, err)
+	_, err = auditClient.Upload("https://storage.example.com/bucket/object?X-Amz-Signature=secret#fragment", strings.NewReader("content"), 7)
+	require.NoError(t, err)
+	client.error = errors.New(`Put "https://storage.example.com/bucket/object?X-Amz-Signature=secret": connection refused`)
+	_, err = auditClient.Upload("https://storage.example.com/bucket/object?X-Amz-Signature=secret", strings.NewReader("content"), 7)
+	assert.Error(t, err)
+
+	payload, _ := json.Marshal(requestPayload)
+	hash := sha256.Sum256(payload)
+	expectedPayloadHash := "sha256:" + hex.EncodeToString(hash[:])
+
+	entries := readAuditLogEntries(t, path)
+	require.Len(t, entries, 3)
+	assert.Equal(t, auditLogEntry{Method: "POST", Resource: "functions_v1", Path: "/v1/projects/project-1/uploads", PayloadHash: expectedPayloadHash, Status: 200, Identity: "ci-pipeline"}, withoutTimestamp(entries[0]))
+	assert.Equal(t, auditLogEntry{Method: "PUT", Path: "https://storage.example.com/bucket/object", Status: 200, Identity: "ci-pipeline"}, withoutTimestamp(entries[1]), "the signed URL credentials should not be recorded")
+	assert.Equal(t, auditLogEntry{Method: "PUT", Path: "https://storage.example.com/bucket/object", Error: `Put "https://storage.example.com/bucket/object": connection refused`, Identity: "ci-pipeline"}, withoutTimestamp(entries[2]))
+}
+
+func TestAuditLogClientWriteFailure(t *testing.T) {
+	client := &clientOpenAPIStub{}
+	auditClient := &auditLogClient{ClientOpenAPI: client, auditLog: &auditLog{path: filepath.Join(t.TempDir(), "missing", "audit.log")}}
+	_, err := auditClient.Delete(&specStubResource{name: "cdns_v1", path: "/v1/cdns"}, "1234")
+	assert.NoError(t, err, "failures writing the audit log should not fail the API call")
+}
+
+func withoutTimestamp(entry auditLogEntry) auditLogEntry {
+	entry.Timestamp = ""
+	return entry
+}
@@ -0,0 +1,85 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// consistencyToken defines the latest consistency token returned when a resource was created or updated, along with how the
+// token is sent on the reads
+type consistencyToken struct {
+	value  string
+	config *specConsistencyToken
+}
+
+// consistencyTokens keeps the latest consistency token (x-terraform-consistency-token) returned per resource, so the reads
+// of the resource following a write are served by a replica that already reflects the write. The tokens are kept in memory
+// for the lifetime of the provider process (e,g: a terraform apply)
+type consistencyTokens struct {
+	mutex  sync.Mutex
+	tokens map[string]consistencyToken
+}
+
+func newConsistencyTokens() *consistencyTokens {
+	return &consistencyTokens{tokens: map[string]consistencyToken{}}
+}
+
+// record keeps the consistency token returned in the response of the write operation passed in (POST or PUT); nothing is
+// recorded if the operation does not define a consistency token or the response does not contain it
+func (c *consistencyTokens) record(resource SpecResource, operation *specResourceOperation, resp *http.Response, responsePayload interface{}) {
+	if c == nil || operation == nil || operation.consistencyToken == nil || resp == nil {
+		return
+	}
+	config := operation.consistencyToken
+	var value string
+	if config.responseHeader != "" {
+		value = resp.Header.Get(config.responseHeader)
+	} else if payload, ok := responsePayload.(*map[string]interface{}); ok && payload != nil {
+		if fieldValue, ok := getPayloadValue(*payload, config.responseField); ok && fieldValue != nil {
+			value = fmt.Sprintf("%v", fieldValue)
+		}
+	}
+	if value == "" {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.tokens[resource.GetResourceName()] = consistencyToken{value: value, config: config}
+	clientLog.Debug("consistency token '%s' recorded for the '%s' reads", value, resource.GetResourceName())
+}
+
+// get returns the latest consistency token recorded for the resource passed in; false if no token has been recorded
+func (c *consistencyTokens) get(resource SpecResource) (consistencyToken, bool) {
+	if c == nil {
+		return consistencyToken{}, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	token, ok := c.tokens[resource.GetResourceName()]
+	return token, ok
+}
+
+// withConsistencyToken returns the URL and headers of the read request passed in including the latest consistency token
+// recorded for the resource (as a header or query parameter, as defined in x-terraform-consistency-token). The URL and
+// headers are returned as is if no token has been recorded
+func (o *ProviderClient) withConsistencyToken(resource SpecResource, resourceURL string, headers map[string]string) (string, map[string]string) {
+	token, ok := o.consistencyTokens.get(resource)
+	if !ok {
+		return resourceURL, headers
+	}
+	if token.config.requestHeader != "" {
+		if headers == nil {
+			headers = map[string]string{}
+		}
+		headers[token.config.requestHeader] = token.value
+		return resourceURL, headers
+	}
+	separator := "?"
+	if strings.Contains(resourceURL, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%s%s=%s", resourceURL, separator, url.QueryEscape(token.config.requestParameter), url.QueryEscape(token.value)), headers
+}
@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderClientConsistencyToken(t *testing.T) {
+	var tokenHeaderReceived, tokenParameterReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenHeaderReceived = r.Header.Get("X-Min-Consistency-Token")
+		tokenParameterReceived = r.URL.Query().Get("min_token")
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("X-Consistency-Token", "lsn-100")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"1234"}`))
+		case http.MethodPut:
+			w.Write([]byte(`{"id":"1234","meta":{"consistency_token":"lsn-200"}}`))
+		default:
+			if r.URL.Path == "/v1/cdns" {
+				w.Write([]byte(`[{"id":"1234"}]`))
+				return
+			}
+			w.Write([]byte(`{"id":"1234"}`))
+		}
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newAPIAuthenticator(nil),
+		consistencyTokens:           newConsistencyTokens(),
+	}
+	resource := &specStubResource{
+		name:                  "cdns_v1",
+		path:                  "/v1/cdns",
+		resourcePostOperation: &specResourceOperation{consistencyToken: &specConsistencyToken{responseHeader: "X-Consistency-Token", requestHeader: "X-Min-Consistency-Token"}},
+		resourcePutOperation:  &specResourceOperation{consistencyToken: &specConsistencyToken{responseField: "meta.consistency_token", requestParameter: "min_token"}},
+		resourceGetOperation:  &specResourceOperation{},
+		resourceListOperation: &specResourceOperation{},
+	}
+	responsePayload := map[string]interface{}{}
+
+	_, err := providerClient.Get(resource, "1234", &responsePayload)
+	require.NoError(t, err)
+	assert.Empty(t, tokenHeaderReceived, "no token should be sent if no write returned one")
+	assert.Empty(t, tokenParameterReceived, "no token should be sent if no write returned one")
+
+	_, err = providerClient.Post(resource, map[string]interface{}{"label": "some label"}, &responsePayload)
+	require.NoError(t, err)
+	_, err = providerClient.Get(resource, "1234", &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, "lsn-100", tokenHeaderReceived)
+	_, err = providerClient.GetIfNoneMatch(resource, "1234", `"etag"`, &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, "lsn-100", tokenHeaderReceived)
+
+	_, err = providerClient.Put(resource, "1234", map[string]interface{}{"label": "some other label"}, &responsePayload)
+	require.NoError(t, err)
+	listPayload := []map[string]interface{}{}
+	_, err = providerClient.List(resource, &listPayload)
+	require.NoError(t, err)
+	assert.Empty(t, tokenHeaderReceived)
+	assert.Equal(t, "lsn-200", tokenParameterReceived, "the latest token should be sent as defined by the write that returned it")
+
+	otherResource := &specStubResource{name: "groups_v1", path: "/v1/groups", resourceGetOperation: &specResourceOperation{}}
+	_, err = providerClient.Get(otherResource, "1234", &responsePayload)
+	require.NoError(t, err)
+	assert.Empty(t, tokenHeaderReceived, "the tokens should only be sent on the reads of the resource they were returned for")
+	assert.Empty(t, tokenParameterReceived, "the tokens should only be sent on the reads of the resource they were returned for")
+}
+
+func TestConsistencyTokensRecord(t *testing.T) {
+	resource := &specStubResource{name: "cdns_v1"}
+	headerToken := &specConsistencyToken{responseHeader: "X-Consistency-Token", requestHeader: "X-Min-Consistency-Token"}
+	fieldToken := &specConsistencyToken{responseField: "meta.version", requestParameter: "min_version"}
+	testCases := []struct {
+		name            string
+		operation       *specResourceOperation
+		header          http.Header
+		responsePayload interface{}
+		expectedToken   *consistencyToken
+	}{
+		{name: "operation without consistency token", operation: &specResourceOperation{}, header: http.Header{"X-Consistency-Token": []string{"lsn-100"}}},
+		{name: "token returned in the header", operation: &specResourceOperation{consistencyToken: headerToken}, header: http.Header{"X-Consistency-Token": []string{"lsn-100"}}, expectedToken: &consistencyToken{value: "lsn-100", config: headerToken}},
+		{name: "header missing", operation: &specResourceOperation{consistencyToken: headerToken}, header: http.Header{}},
+		{name: "token returned in the payload", operation: &specResourceOperation{consistencyToken: fieldToken}, responsePayload: &map[string]interface{}{"meta": map[string]interface{}{"version": float64(42)}}, expectedToken: &consistencyToken{value: "42", config: fieldToken}},
+		{name: "field missing", operation: &specResourceOperation{consistencyToken: fieldToken}, responsePayload: &map[string]interface{}{"id": "1234"}},
+		{name: "no response payload", operation: &specResourceOperation{consistencyToken: fieldToken}},
+	}
+	for _, tc := range testCases {
+		tokens := newConsistencyTokens()
+		tokens.record(resource, tc.operation, &http.Response{StatusCode: http.StatusOK, Header: tc.header}, tc.responsePayload)
+		token, ok := tokens.get(resource)
+		if tc.expectedToken == nil {
+			assert.False(t, ok, tc.name)
+			continue
+		}
+		assert.True(t, ok, tc.name)
+		assert.Equal(t, *tc.expectedToken, token, tc.name)
+	}
+
+	var nilTokens *consistencyTokens
+	nilTokens.record(resource, &specResourceOperation{consistencyToken: headerToken}, &http.Response{Header: http.Header{"X-Consistency-Token": []string{"lsn-100"}}}, nil)
+	_, ok := nilTokens.get(resource)
+	assert.False(t, ok, "the tokens should not be propagated if the client does not keep them")
+}
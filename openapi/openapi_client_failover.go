@@ -0,0 +1,174 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultFailoverUnhealthyCooldown defines how long a host that failed is skipped if the failover configuration does not
+// configure unhealthy_cooldown
+const defaultFailoverUnhealthyCooldown = 30 * time.Second
+
+// hostFailover fails over the API calls made against the provider host to the fallback hosts when the host fails with
+// connection errors or 5xx responses. Non-idempotent requests (e,g: POST, PATCH) are only failed over when the connection
+// to the host could not be established, since otherwise the host may have already processed them. The hosts that fail are marked as unhealthy and skipped until the cooldown expires,
+// so subsequent API calls go straight to a healthy host. The failover is created once per provider so the health of the
+// hosts is shared across all the API calls
+type hostFailover struct {
+	// hosts contains the provider host followed by the fallback hosts in the order they are tried
+	hosts    []string
+	cooldown time.Duration
+
+	mutex          sync.Mutex
+	unhealthyUntil map[string]time.Time
+}
+
+// newHostFailover returns the failover for the provider host passed in. The fallback hosts configured in the service
+// configuration take preference over the ones defined in the OpenAPI document. Nil is returned if there are no fallback hosts
+func newHostFailover(host string, failoverConfig *FailoverConfig, backendConfiguration SpecBackendConfiguration) *hostFailover {
+	var fallbackHosts []string
+	cooldown := defaultFailoverUnhealthyCooldown
+	if failoverConfig != nil {
+		fallbackHosts = failoverConfig.Hosts
+		if failoverConfig.UnhealthyCooldown != "" {
+			cooldown, _ = time.ParseDuration(failoverConfig.UnhealthyCooldown)
+		}
+	}
+	if len(fallbackHosts) == 0 {
+		fallbackHosts = backendConfiguration.getFallbackHosts()
+	}
+	if host == "" || len(fallbackHosts) == 0 {
+		return nil
+	}
+	hosts := []string{host}
+	for _, fallbackHost := range fallbackHosts {
+		if fallbackHost != host {
+			hosts = append(hosts, fallbackHost)
+		}
+	}
+	return &hostFailover{
+		hosts:          hosts,
+		cooldown:       cooldown,
+		unhealthyUntil: map[string]time.Time{},
+	}
+}
+
+// getHTTPClient returns an http client that fails over the API calls to the fallback hosts. The http client passed in is
+// returned as is if the failover is not configured
+func (f *hostFailover) getHTTPClient(httpClient *http.Client) *http.Client {
+	if f == nil {
+		return httpClient
+	}
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &http.Client{
+		Transport:     f.roundTripper(transport),
+		CheckRedirect: httpClient.CheckRedirect,
+		Jar:           httpClient.Jar,
+		Timeout:       httpClient.Timeout,
+	}
+}
+
+func (f *hostFailover) roundTripper(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if !f.isFailoverHost(req.URL.Host) {
+			return next.RoundTrip(req)
+		}
+		hosts := f.getHostsByHealth()
+		for i, host := range hosts {
+			attempt := req.Clone(req.Context())
+			attempt.URL.Host = host
+			attempt.Host = ""
+			if i > 0 && req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attempt.Body = body
+			}
+			resp, err := next.RoundTrip(attempt)
+			if err == nil && resp.StatusCode < http.StatusInternalServerError {
+				f.markHealthy(host)
+				return resp, nil
+			}
+			f.markUnhealthy(host)
+			if i == len(hosts)-1 || req.Context().Err() != nil {
+				return resp, err
+			}
+			if !isIdempotentMethod(req.Method) && !isDialError(err) {
+				clientLog.Warn("%s %s can not fail over to '%s' since the request may have already been processed", req.Method, req.URL, hosts[i+1])
+				return resp, err
+			}
+			if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+				clientLog.Warn("%s %s can not fail over to '%s' since the request body can not be replayed", req.Method, req.URL, hosts[i+1])
+				return resp, err
+			}
+			if err != nil {
+				clientLog.Warn("%s %s failed (%s), failing over to '%s'", attempt.Method, attempt.URL, err, hosts[i+1])
+			} else {
+				clientLog.Warn("%s %s returned %d, failing over to '%s'", attempt.Method, attempt.URL, resp.StatusCode, hosts[i+1])
+				io.Copy(ioutil.Discard, resp.Body) // #nosec G104
+				resp.Body.Close()
+			}
+		}
+		return nil, fmt.Errorf("%s %s failed: no hosts available", req.Method, req.URL)
+	})
+}
+
+// isDialError returns true if the error passed in was returned because the connection to the host could not be established,
+// in which case the request was never sent
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isFailoverHost returns true if the host passed in is any of the failover hosts. API calls made against other hosts
+// (e,g: resources with x-terraform-resource-host or endpoint overrides) are not failed over
+func (f *hostFailover) isFailoverHost(host string) bool {
+	for _, h := range f.hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
+
+// getHostsByHealth returns the healthy hosts in the order they are configured followed by the unhealthy ones, which are
+// still tried as a last resort
+func (f *hostFailover) getHostsByHealth() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	now := time.Now()
+	var healthy, unhealthy []string
+	for _, host := range f.hosts {
+		if until, exists := f.unhealthyUntil[host]; exists && now.Before(until) {
+			unhealthy = append(unhealthy, host)
+			continue
+		}
+		healthy = append(healthy, host)
+	}
+	return append(healthy, unhealthy...)
+}
+
+func (f *hostFailover) markUnhealthy(host string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.unhealthyUntil[host] = time.Now().Add(f.cooldown)
+}
+
+func (f *hostFailover) markHealthy(host string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if _, exists := f.unhealthyUntil[host]; exists {
+		clientLog.Info("host '%s' is healthy again", host)
+		delete(f.unhealthyUntil, host)
+	}
+}
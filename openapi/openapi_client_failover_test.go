@@ -0,0 +1,157 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHostFailover(t *testing.T) {
+	backendConfiguration := newStubBackendConfiguration("api.example.com", "", "https")
+	backendConfiguration.fallbackHosts = []string{"api-spec-passive.example.com"}
+
+	testCases := []struct {
+		name             string
+		host             string
+		failoverConfig   *FailoverConfig
+		expectedHosts    []string
+		expectedCooldown time.Duration
+	}{
+		{name: "fallback hosts from the OpenAPI document", host: "api.example.com", expectedHosts: []string{"api.example.com", "api-spec-passive.example.com"}, expectedCooldown: defaultFailoverUnhealthyCooldown},
+		{name: "fallback hosts from the service configuration take preference", host: "api.example.com", failoverConfig: &FailoverConfig{Hosts: []string{"api-passive.example.com"}, UnhealthyCooldown: "1m"}, expectedHosts: []string{"api.example.com", "api-passive.example.com"}, expectedCooldown: time.Minute},
+		{name: "fallback host matching the provider host is ignored", host: "api-spec-passive.example.com", failoverConfig: &FailoverConfig{Hosts: []string{"api-spec-passive.example.com", "api-passive.example.com"}}, expectedHosts: []string{"api-spec-passive.example.com", "api-passive.example.com"}, expectedCooldown: defaultFailoverUnhealthyCooldown},
+	}
+	for _, tc := range testCases {
+		failover := newHostFailover(tc.host, tc.failoverConfig, backendConfiguration)
+		require.NotNil(t, failover, tc.name)
+		assert.Equal(t, tc.expectedHosts, failover.hosts, tc.name)
+		assert.Equal(t, tc.expectedCooldown, failover.cooldown, tc.name)
+	}
+
+	assert.Nil(t, newHostFailover("api.example.com", nil, newStubBackendConfiguration("api.example.com", "", "https")))
+	assert.Nil(t, newHostFailover("", nil, backendConfiguration))
+}
+
+func TestHostFailover(t *testing.T) {
+	var primaryCalls, fallbackCalls int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&primaryCalls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fallbackCalls, 1)
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer fallback.Close()
+	primaryHost := strings.TrimPrefix(primary.URL, "http://")
+	fallbackHost := strings.TrimPrefix(fallback.URL, "http://")
+
+	failover := newHostFailover(primaryHost, &FailoverConfig{Hosts: []string{fallbackHost}}, newStubBackendConfiguration(primaryHost, "", "http"))
+	client := failover.getHTTPClient(&http.Client{})
+
+	// the POST request may have been processed by the primary host so it is not resent to the fallback host
+	resp, err := client.Post(primary.URL+"/v1/resource", "application/json", strings.NewReader(`{"label":"some label"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), primaryCalls)
+	assert.Equal(t, int32(0), fallbackCalls)
+
+	// the primary host is unhealthy so subsequent calls go straight to the fallback host until the cooldown expires
+	resp, err = client.Get(primary.URL + "/v1/resource")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(1), primaryCalls)
+	assert.Equal(t, int32(1), fallbackCalls)
+
+	failover.unhealthyUntil[primaryHost] = time.Now().Add(-time.Second)
+	req, err := http.NewRequest(http.MethodPut, primary.URL+"/v1/resource/1234", strings.NewReader(`{"label":"some label"}`))
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"label":"some label"}`, string(body))
+	assert.Equal(t, int32(2), primaryCalls)
+	assert.Equal(t, int32(2), fallbackCalls)
+}
+
+func TestHostFailoverConnectionError(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	primaryURL := primary.URL
+	primary.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1234"}`))
+	}))
+	defer fallback.Close()
+	primaryHost := strings.TrimPrefix(primaryURL, "http://")
+	fallbackHost := strings.TrimPrefix(fallback.URL, "http://")
+
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(primaryHost, "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		hostFailover:                newHostFailover(primaryHost, &FailoverConfig{Hosts: []string{fallbackHost}}, newStubBackendConfiguration(primaryHost, "", "http")),
+	}
+	responsePayload := map[string]interface{}{}
+	resp, err := providerClient.performRequest(httpGet, primaryURL+"/v1/resource", &specResourceOperation{}, nil, &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "1234", responsePayload["id"])
+
+	// non-idempotent requests are failed over too since the request never reached the primary host
+	providerClient.hostFailover.unhealthyUntil = map[string]time.Time{}
+	responsePayload = map[string]interface{}{}
+	resp, err = providerClient.performRequest(httpPost, primaryURL+"/v1/resource", &specResourceOperation{}, map[string]interface{}{"label": "some label"}, &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "1234", responsePayload["id"])
+}
+
+func TestHostFailoverAllHostsFailing(t *testing.T) {
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer api.Close()
+	apiHost := strings.TrimPrefix(api.URL, "http://")
+	// both hosts point to the same server, using the loopback IP for the fallback one so they are different hosts
+	fallbackHost := strings.Replace(apiHost, "127.0.0.1", "localhost", 1)
+
+	failover := newHostFailover(apiHost, &FailoverConfig{Hosts: []string{fallbackHost}}, newStubBackendConfiguration(apiHost, "", "http"))
+	resp, err := failover.getHTTPClient(&http.Client{}).Get(api.URL + "/v1/resource")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, int32(2), calls)
+}
+
+func TestHostFailoverOtherHostsNotFailedOver(t *testing.T) {
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer api.Close()
+
+	failover := newHostFailover("api.example.com", &FailoverConfig{Hosts: []string{"api-passive.example.com"}}, newStubBackendConfiguration("api.example.com", "", "http"))
+	resp, err := failover.getHTTPClient(&http.Client{}).Get(api.URL + "/v1/resource")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), calls)
+	assert.Empty(t, failover.unhealthyUntil)
+}
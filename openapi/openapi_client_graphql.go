@@ -0,0 +1,157 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// defaultGraphQLEndpoint defines the path of the GraphQL endpoint used if the x-terraform-graphql extension does not specify one
+const defaultGraphQLEndpoint = "/graphql"
+
+// graphQLRequest defines the payload of the GraphQL requests
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse defines the payload of the GraphQL responses
+type graphQLResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []graphQLError         `json:"errors,omitempty"`
+}
+
+type graphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// performGraphQLRequest performs the operation via the GraphQL query or mutation defined in the operation x-terraform-graphql
+// extension. The document is sent to the GraphQL endpoint along with the following variables:
+//   - id: the resource instance id (only for instance operations)
+//   - parentIds: the parent resource ids (only for sub-resources)
+//   - input: the request payload (only for POST and PUT operations)
+//
+// The response returned contains the resource (the response data field configured in the extension or the only field in
+// the data otherwise) in the body and 200 OK status code. If the resource is null, 404 Not Found is returned instead. If the
+// GraphQL response contains errors the body contains the errors and the status code is derived from the first error code
+// (extensions.code). Non 200 OK GraphQL responses are returned as is.
+func (o *ProviderClient) performGraphQLRequest(method httpMethodSupported, resource SpecResource, operation *specResourceOperation, parentIDs []string, id string, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	if operation.graphQL.document == "" {
+		return nil, fmt.Errorf("resource '%s' %s operation is missing the GraphQL document in the '%s' extension", resource.GetResourceName(), method, extTfGraphQL)
+	}
+	graphQLURL, err := o.getGraphQLEndpointURL(resource, operation.graphQL.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	variables := map[string]interface{}{}
+	if id != "" {
+		variables["id"] = id
+	}
+	if len(parentIDs) > 0 {
+		variables["parentIds"] = parentIDs
+	}
+	if requestPayload != nil {
+		variables["input"] = requestPayload
+	}
+	clientLog.Debug("Performing %s operation of resource '%s' via GraphQL", method, resource.GetResourceName())
+	graphQLResp := &graphQLResponse{}
+	resp, err := o.performRequest(httpPost, graphQLURL, operation, graphQLRequest{Query: operation.graphQL.document, Variables: variables}, graphQLResp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+	if len(graphQLResp.Errors) > 0 {
+		body, err := json.Marshal(map[string]interface{}{"errors": graphQLResp.Errors})
+		if err != nil {
+			return nil, err
+		}
+		return newJSONResponse(getHTTPStatusFromGraphQLErrors(graphQLResp.Errors), body), nil
+	}
+	result, err := graphQLResp.getResult(operation.graphQL.resultPath)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected GraphQL response for resource '%s' %s operation: %s", resource.GetResourceName(), method, err)
+	}
+	if result == nil && responsePayload != nil {
+		return newJSONResponse(http.StatusNotFound, []byte("null")), nil
+	}
+	body, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	if responsePayload != nil {
+		if err := json.Unmarshal(body, responsePayload); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal response body ['%s'] for GraphQL %s operation of resource '%s'", err, method, resource.GetResourceName())
+		}
+	}
+	return newJSONResponse(http.StatusOK, body), nil
+}
+
+// getGraphQLEndpointURL returns the URL of the GraphQL endpoint. Endpoint paths are resolved against the resource host
+func (o *ProviderClient) getGraphQLEndpointURL(resource SpecResource, endpoint string) (string, error) {
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		return endpoint, nil
+	}
+	host, err := o.getResourceHost(resource)
+	if err != nil {
+		return "", err
+	}
+	if host == "" {
+		return "", fmt.Errorf("host is a mandatory attribute to get the GraphQL endpoint URL")
+	}
+	scheme, err := o.openAPIBackendConfiguration.getHTTPScheme()
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = fmt.Sprintf("/%s", endpoint)
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, host, endpoint), nil
+}
+
+// getResult returns the value of the response data field located in the result path (dot separated). If the result path is
+// empty, the data must contain one single field whose value is returned
+func (r *graphQLResponse) getResult(resultPath string) (interface{}, error) {
+	if resultPath == "" {
+		if len(r.Data) != 1 {
+			return nil, fmt.Errorf("the response data contains %d fields, the result_path must be specified in the '%s' extension to select the field containing the resource", len(r.Data), extTfGraphQL)
+		}
+		for _, result := range r.Data {
+			return result, nil
+		}
+	}
+	var result interface{} = r.Data
+	for _, field := range strings.Split(resultPath, ".") {
+		if result == nil {
+			return nil, nil
+		}
+		fields, ok := result.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("the response data field '%s' in the result path '%s' is not an object", field, resultPath)
+		}
+		if result, ok = fields[field]; !ok {
+			return nil, fmt.Errorf("the response data does not contain the field '%s' in the result path '%s'", field, resultPath)
+		}
+	}
+	return result, nil
+}
+
+// getHTTPStatusFromGraphQLErrors returns the HTTP status code corresponding to the first error code (extensions.code)
+func getHTTPStatusFromGraphQLErrors(errors []graphQLError) int {
+	code, _ := errors[0].Extensions["code"].(string)
+	switch code {
+	case "BAD_USER_INPUT", "GRAPHQL_PARSE_FAILED", "GRAPHQL_VALIDATION_FAILED":
+		return http.StatusBadRequest
+	case "UNAUTHENTICATED":
+		return http.StatusUnauthorized
+	case "FORBIDDEN":
+		return http.StatusForbidden
+	case "NOT_FOUND":
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
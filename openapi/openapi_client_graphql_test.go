@@ -0,0 +1,214 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderClientGraphQLOperations(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		graphQL                 *specGraphQLOperation
+		call                    func(client *ProviderClient, resource SpecResource, responsePayload *map[string]interface{}) (*http.Response, error)
+		apiResponseStatusCode   int
+		apiResponse             string
+		expectedRequest         string
+		expectedStatusCode      int
+		expectedResponsePayload map[string]interface{}
+		expectedBody            string
+		expectedError           string
+	}{
+		{
+			name:    "POST operation performed via GraphQL mutation",
+			graphQL: &specGraphQLOperation{document: "mutation($input: CDNInput!) { createCDN(input: $input) { id label } }", endpoint: "/graphql"},
+			call: func(client *ProviderClient, resource SpecResource, responsePayload *map[string]interface{}) (*http.Response, error) {
+				return client.Post(resource, map[string]interface{}{"label": "some label"}, responsePayload)
+			},
+			apiResponse:             `{"data":{"createCDN":{"id":"some-id","label":"some label"}}}`,
+			expectedRequest:         `{"query":"mutation($input: CDNInput!) { createCDN(input: $input) { id label } }","variables":{"input":{"label":"some label"}}}`,
+			expectedStatusCode:      http.StatusOK,
+			expectedResponsePayload: map[string]interface{}{"id": "some-id", "label": "some label"},
+			expectedBody:            `{"id":"some-id","label":"some label"}`,
+		},
+		{
+			name:    "PUT operation performed via GraphQL mutation with the resource id and parent ids",
+			graphQL: &specGraphQLOperation{document: "mutation($id: ID!, $input: CDNInput!) { updateCDN(id: $id, input: $input) { id label } }", endpoint: "/graphql"},
+			call: func(client *ProviderClient, resource SpecResource, responsePayload *map[string]interface{}) (*http.Response, error) {
+				return client.Put(resource, "some-id", map[string]interface{}{"label": "some label"}, responsePayload, "parent-id")
+			},
+			apiResponse:             `{"data":{"updateCDN":{"id":"some-id","label":"some label"}}}`,
+			expectedRequest:         `{"query":"mutation($id: ID!, $input: CDNInput!) { updateCDN(id: $id, input: $input) { id label } }","variables":{"id":"some-id","input":{"label":"some label"},"parentIds":["parent-id"]}}`,
+			expectedStatusCode:      http.StatusOK,
+			expectedResponsePayload: map[string]interface{}{"id": "some-id", "label": "some label"},
+			expectedBody:            `{"id":"some-id","label":"some label"}`,
+		},
+		{
+			name:    "GET operation performed via GraphQL query with result path",
+			graphQL: &specGraphQLOperation{document: "query($id: ID!) { viewer { cdn(id: $id) { id label } } }", endpoint: "/graphql", resultPath: "viewer.cdn"},
+			call: func(client *ProviderClient, resource SpecResource, responsePayload *map[string]interface{}) (*http.Response, error) {
+				return client.Get(resource, "some-id", responsePayload)
+			},
+			apiResponse:             `{"data":{"viewer":{"cdn":{"id":"some-id","label":"some label"}}}}`,
+			expectedRequest:         `{"query":"query($id: ID!) { viewer { cdn(id: $id) { id label } } }","variables":{"id":"some-id"}}`,
+			expectedStatusCode:      http.StatusOK,
+			expectedResponsePayload: map[string]interface{}{"id": "some-id", "label": "some label"},
+			expectedBody:            `{"id":"some-id","label":"some label"}`,
+		},
+		{
+			name:    "GET operation performed via GraphQL query returning null",
+			graphQL: &specGraphQLOperation{document: "query($id: ID!) { cdn(id: $id) { id label } }", endpoint: "/graphql"},
+			call: func(client *ProviderClient, resource SpecResource, responsePayload *map[string]interface{}) (*http.Response, error) {
+				return client.Get(resource, "some-id", responsePayload)
+			},
+			apiResponse:             `{"data":{"cdn":null}}`,
+			expectedRequest:         `{"query":"query($id: ID!) { cdn(id: $id) { id label } }","variables":{"id":"some-id"}}`,
+			expectedStatusCode:      http.StatusNotFound,
+			expectedResponsePayload: map[string]interface{}{},
+			expectedBody:            `null`,
+		},
+		{
+			name:    "DELETE operation performed via GraphQL mutation",
+			graphQL: &specGraphQLOperation{document: "mutation($id: ID!) { deleteCDN(id: $id) }", endpoint: "/graphql"},
+			call: func(client *ProviderClient, resource SpecResource, responsePayload *map[string]interface{}) (*http.Response, error) {
+				return client.Delete(resource, "some-id")
+			},
+			apiResponse:             `{"data":{"deleteCDN":null}}`,
+			expectedRequest:         `{"query":"mutation($id: ID!) { deleteCDN(id: $id) }","variables":{"id":"some-id"}}`,
+			expectedStatusCode:      http.StatusOK,
+			expectedResponsePayload: map[string]interface{}{},
+			expectedBody:            `null`,
+		},
+		{
+			name:    "GraphQL response containing errors",
+			graphQL: &specGraphQLOperation{document: "query($id: ID!) { cdn(id: $id) { id label } }", endpoint: "/graphql"},
+			call: func(client *ProviderClient, resource SpecResource, responsePayload *map[string]interface{}) (*http.Response, error) {
+				return client.Get(resource, "some-id", responsePayload)
+			},
+			apiResponse:             `{"data":null,"errors":[{"message":"not allowed","extensions":{"code":"FORBIDDEN"}}]}`,
+			expectedRequest:         `{"query":"query($id: ID!) { cdn(id: $id) { id label } }","variables":{"id":"some-id"}}`,
+			expectedStatusCode:      http.StatusForbidden,
+			expectedResponsePayload: map[string]interface{}{},
+			expectedBody:            `{"errors":[{"message":"not allowed","extensions":{"code":"FORBIDDEN"}}]}`,
+		},
+		{
+			name:    "GraphQL endpoint responding with a non 200 OK status code",
+			graphQL: &specGraphQLOperation{document: "query($id: ID!) { cdn(id: $id) { id label } }", endpoint: "/graphql"},
+			call: func(client *ProviderClient, resource SpecResource, responsePayload *map[string]interface{}) (*http.Response, error) {
+				return client.Get(resource, "some-id", responsePayload)
+			},
+			apiResponseStatusCode:   http.StatusUnauthorized,
+			apiResponse:             `{"message":"unauthorized"}`,
+			expectedRequest:         `{"query":"query($id: ID!) { cdn(id: $id) { id label } }","variables":{"id":"some-id"}}`,
+			expectedStatusCode:      http.StatusUnauthorized,
+			expectedResponsePayload: map[string]interface{}{},
+			expectedBody:            `{"message":"unauthorized"}`,
+		},
+		{
+			name:    "GraphQL response data containing multiple fields without result path",
+			graphQL: &specGraphQLOperation{document: "query($id: ID!) { cdn(id: $id) { id } firewall(id: $id) { id } }", endpoint: "/graphql"},
+			call: func(client *ProviderClient, resource SpecResource, responsePayload *map[string]interface{}) (*http.Response, error) {
+				return client.Get(resource, "some-id", responsePayload)
+			},
+			apiResponse:     `{"data":{"cdn":{"id":"some-id"},"firewall":{"id":"some-id"}}}`,
+			expectedRequest: `{"query":"query($id: ID!) { cdn(id: $id) { id } firewall(id: $id) { id } }","variables":{"id":"some-id"}}`,
+			expectedError:   "unexpected GraphQL response for resource 'cdns_v1' GET operation: the response data contains 2 fields, the result_path must be specified in the 'x-terraform-graphql' extension to select the field containing the resource",
+		},
+		{
+			name:    "GraphQL operation missing the document",
+			graphQL: &specGraphQLOperation{endpoint: "/graphql"},
+			call: func(client *ProviderClient, resource SpecResource, responsePayload *map[string]interface{}) (*http.Response, error) {
+				return client.Get(resource, "some-id", responsePayload)
+			},
+			expectedError: "resource 'cdns_v1' GET operation is missing the GraphQL document in the 'x-terraform-graphql' extension",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var request, requestPath string
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				request = string(b)
+				requestPath = r.Method + " " + r.URL.Path
+				if tc.apiResponseStatusCode != 0 {
+					w.WriteHeader(tc.apiResponseStatusCode)
+				}
+				w.Write([]byte(tc.apiResponse))
+			}))
+			defer api.Close()
+			providerClient := &ProviderClient{
+				openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "/api", "http"),
+				httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+				apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+			}
+			operation := &specResourceOperation{graphQL: tc.graphQL}
+			resource := &specStubResource{
+				name:                    "cdns_v1",
+				path:                    "/v1/cdns",
+				resourcePostOperation:   operation,
+				resourcePutOperation:    operation,
+				resourceGetOperation:    operation,
+				resourceDeleteOperation: operation,
+			}
+			responsePayload := map[string]interface{}{}
+			resp, err := tc.call(providerClient, resource, &responsePayload)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "POST /graphql", requestPath)
+			assert.JSONEq(t, tc.expectedRequest, request)
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, tc.expectedResponsePayload, responsePayload)
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedBody, string(body))
+		})
+	}
+}
+
+func TestGetGraphQLEndpointURL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		endpoint    string
+		expectedURL string
+	}{
+		{name: "endpoint path", endpoint: "/graphql", expectedURL: "https://www.some-backend.com/graphql"},
+		{name: "endpoint path without leading slash", endpoint: "graphql", expectedURL: "https://www.some-backend.com/graphql"},
+		{name: "endpoint URL", endpoint: "https://graphql.some-backend.com/query", expectedURL: "https://graphql.some-backend.com/query"},
+	}
+	for _, tc := range testCases {
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration("www.some-backend.com", "/api", "https"),
+		}
+		url, err := providerClient.getGraphQLEndpointURL(&specStubResource{name: "cdns_v1"}, tc.endpoint)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedURL, url, tc.name)
+	}
+}
+
+func TestGetHTTPStatusFromGraphQLErrors(t *testing.T) {
+	testCases := []struct {
+		errors             string
+		expectedStatusCode int
+	}{
+		{errors: `[{"message":"some error","extensions":{"code":"BAD_USER_INPUT"}}]`, expectedStatusCode: http.StatusBadRequest},
+		{errors: `[{"message":"some error","extensions":{"code":"UNAUTHENTICATED"}}]`, expectedStatusCode: http.StatusUnauthorized},
+		{errors: `[{"message":"some error","extensions":{"code":"FORBIDDEN"}}]`, expectedStatusCode: http.StatusForbidden},
+		{errors: `[{"message":"some error","extensions":{"code":"NOT_FOUND"}},{"message":"some other error"}]`, expectedStatusCode: http.StatusNotFound},
+		{errors: `[{"message":"some error"}]`, expectedStatusCode: http.StatusInternalServerError},
+	}
+	for _, tc := range testCases {
+		var errors []graphQLError
+		require.NoError(t, json.Unmarshal([]byte(tc.errors), &errors))
+		assert.Equal(t, tc.expectedStatusCode, getHTTPStatusFromGraphQLErrors(errors), tc.errors)
+	}
+}
@@ -0,0 +1,452 @@
+package openapi
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// grpcClient is a ClientOpenAPI that performs the API calls against the gRPC server instead of the REST API. This is meant
+// for APIs published via grpc-gateway, where the OpenAPI document (e,g: generated by protoc-gen-openapiv2) describes the
+// HTTP mapping (google.api.http annotations) of the gRPC methods. Each resource operation is transcoded back to its gRPC
+// method: the request message is built from the request payload plus the path parameters (parent IDs and resource ID) and
+// the response message is returned in its JSON representation, as grpc-gateway would do. The gRPC method descriptors are
+// retrieved from the server via the gRPC server reflection service.
+//
+// The authentication, operation and user agent headers are sent as gRPC metadata. The host, base path and scheme resolution
+// as well as the telemetry handler are inherited from the ProviderClient.
+type grpcClient struct {
+	*ProviderClient
+	conn     grpc.ClientConnInterface
+	resolver *grpcMethodResolver
+	// unbound is the client the copy was bound to the context from; nil if the client is not bound to any context
+	unbound *grpcClient
+}
+
+// newGRPCClient returns a grpcClient that connects to the gRPC server address configured in the backend configuration. The
+// connection is established lazily when the first call is performed.
+func newGRPCClient(providerClient *ProviderClient, backendConfig *BackendConfig) (*grpcClient, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if providerClient.tlsConfig != nil {
+		tlsConfig = providerClient.tlsConfig.Clone()
+	}
+	transportCredentials := credentials.NewTLS(tlsConfig)
+	if backendConfig.Insecure {
+		transportCredentials = insecure.NewCredentials()
+	}
+	dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(transportCredentials)}
+	if providerClient.maxResponseBodySize > 0 {
+		dialOptions = append(dialOptions, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(providerClient.maxResponseBodySize))))
+	}
+	if providerClient.dialContext != nil {
+		dialOptions = append(dialOptions, grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return providerClient.dialContext(ctx, "tcp", address)
+		}))
+	}
+	conn, err := grpc.Dial(backendConfig.Address, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the gRPC server '%s': %s", backendConfig.Address, err)
+	}
+	clientLog.Info("API calls will be performed against the gRPC server '%s'", backendConfig.Address)
+	return &grpcClient{
+		ProviderClient: providerClient,
+		conn:           conn,
+		resolver:       newGRPCMethodResolver(conn),
+	}, nil
+}
+
+// withContext returns a copy of the client whose gRPC calls are cancelled as soon as the context passed in is done. The
+// ProviderClient.withContext inherited otherwise would return the REST client, performing the calls against the REST API
+func (o *grpcClient) withContext(ctx context.Context) ClientOpenAPI {
+	return &grpcClient{
+		ProviderClient: o.ProviderClient.withContext(ctx).(*ProviderClient),
+		conn:           o.conn,
+		resolver:       o.resolver,
+		unbound:        o.withoutContext().(*grpcClient),
+	}
+}
+
+// withoutContext returns the client the copy was bound to the context from; the client itself if it is not bound
+func (o *grpcClient) withoutContext() ClientOpenAPI {
+	if o.unbound != nil {
+		return o.unbound
+	}
+	return o
+}
+
+// Post invokes the gRPC method of the resource POST operation with the payload passed in
+func (o *grpcClient) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return o.invoke(httpPost, resource, resource.getResourceOperations().Post, parentIDs, "", requestPayload, responsePayload)
+}
+
+// Put invokes the gRPC method of the resource PUT operation with the payload and resource instance id passed in
+func (o *grpcClient) Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return o.invoke(httpPut, resource, resource.getResourceOperations().Put, parentIDs, id, requestPayload, responsePayload)
+}
+
+// Get invokes the gRPC method of the resource instance GET operation with the resource instance id passed in
+func (o *grpcClient) Get(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return o.invoke(httpGet, resource, resource.getResourceOperations().Get, parentIDs, id, nil, responsePayload)
+}
+
+// GetIfNoneMatch invokes the gRPC method of the resource instance GET operation. Conditional requests are not supported by
+// gRPC so the eTag is ignored and the response payload is always populated.
+func (o *grpcClient) GetIfNoneMatch(resource SpecResource, id string, eTag string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return o.Get(resource, id, responsePayload, parentIDs...)
+}
+
+// GetFields invokes the gRPC method of the resource instance GET operation. Field selection is not supported by gRPC so the
+// fields are ignored and the response payload is always populated with the whole resource.
+func (o *grpcClient) GetFields(resource SpecResource, id string, fields []string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return o.Get(resource, id, responsePayload, parentIDs...)
+}
+
+// List invokes the gRPC method of the resource root level GET operation
+func (o *grpcClient) List(resource SpecResource, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return o.invoke(httpGet, resource, resource.getResourceOperations().List, parentIDs, "", nil, responsePayload)
+}
+
+// Delete invokes the gRPC method of the resource DELETE operation with the resource instance id passed in
+func (o *grpcClient) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	return o.invoke(httpDelete, resource, resource.getResourceOperations().Delete, parentIDs, id, nil, nil)
+}
+
+// PostSubResource is not supported by the gRPC backend as sub resources do not map to any gRPC method
+func (o *grpcClient) PostSubResource(resource SpecResource, id string, subResourcePath string, requestPayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return nil, fmt.Errorf("resource '%s' sub resource requests are not supported by the gRPC backend", resource.GetResourceName())
+}
+
+// DeleteSubResource is not supported by the gRPC backend as sub resources do not map to any gRPC method
+func (o *grpcClient) DeleteSubResource(resource SpecResource, id string, subResourcePath string, parentIDs ...string) (*http.Response, error) {
+	return nil, fmt.Errorf("resource '%s' sub resource requests are not supported by the gRPC backend", resource.GetResourceName())
+}
+
+// Poll is not supported by the gRPC backend as the poll requests do not map to any gRPC method
+func (o *grpcClient) Poll(resource SpecResource, id string, pollRequest *specPollRequest, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return nil, fmt.Errorf("resource '%s' poll requests are not supported by the gRPC backend", resource.GetResourceName())
+}
+
+// Precheck is not supported by the gRPC backend as the precheck requests do not map to any gRPC method
+func (o *grpcClient) Precheck(resource SpecResource, precheck *specPrecheck, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return nil, fmt.Errorf("resource '%s' precheck requests are not supported by the gRPC backend", resource.GetResourceName())
+}
+
+// AcquireLock is not supported by the gRPC backend as the lock requests do not map to any gRPC method
+func (o *grpcClient) AcquireLock(resource SpecResource, lock *specLock, ids ...string) (*http.Response, error) {
+	return nil, fmt.Errorf("resource '%s' lock requests are not supported by the gRPC backend", resource.GetResourceName())
+}
+
+// ReleaseLock is not supported by the gRPC backend as the lock requests do not map to any gRPC method
+func (o *grpcClient) ReleaseLock(resource SpecResource, lock *specLock, ids ...string) (*http.Response, error) {
+	return nil, fmt.Errorf("resource '%s' lock requests are not supported by the gRPC backend", resource.GetResourceName())
+}
+
+// PollLocation is not supported by the gRPC backend as the gRPC methods do not return locations to poll
+func (o *grpcClient) PollLocation(resource SpecResource, location string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return nil, fmt.Errorf("resource '%s' location poll requests are not supported by the gRPC backend", resource.GetResourceName())
+}
+
+// Lookup is not supported by the gRPC backend as the lookup requests do not map to any gRPC method
+func (o *grpcClient) Lookup(resource SpecResource, lookupPath string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return nil, fmt.Errorf("resource '%s' lookup requests are not supported by the gRPC backend", resource.GetResourceName())
+}
+
+// RequestUpload is not supported by the gRPC backend as the upload requests do not map to any gRPC method
+func (o *grpcClient) RequestUpload(resource SpecResource, uploadPath string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return nil, fmt.Errorf("resource '%s' upload requests are not supported by the gRPC backend", resource.GetResourceName())
+}
+
+// GetOptions is not supported by the gRPC backend as the options requests do not map to any gRPC method
+func (o *grpcClient) GetOptions(resource SpecResource, optionsPath string, queryParameters map[string]string, responsePayload interface{}) (*http.Response, error) {
+	return nil, fmt.Errorf("resource '%s' options requests are not supported by the gRPC backend", resource.GetResourceName())
+}
+
+// Count is not supported by the gRPC backend as the count requests do not map to any gRPC method
+func (o *grpcClient) Count(resource SpecResource, count *specCount, queryParameters map[string]string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return nil, fmt.Errorf("resource '%s' count requests are not supported by the gRPC backend", resource.GetResourceName())
+}
+
+// invoke transcodes the operation into its gRPC method call. The response returned contains the HTTP status code mapped
+// from the gRPC status (following the grpc-gateway mapping) and the body contains the JSON representation of the response
+// message, or the gRPC status if the call failed.
+func (o *grpcClient) invoke(method httpMethodSupported, resource SpecResource, operation *specResourceOperation, parentIDs []string, id string, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	if operation == nil {
+		return nil, fmt.Errorf("resource '%s' does not support %s operations", resource.GetResourceName(), method)
+	}
+	if operation.grpcMethod == "" {
+		return nil, fmt.Errorf("resource '%s' %s operation is missing the gRPC method: the operation id must follow the Service_Method convention or the operation must have the '%s' extension", resource.GetResourceName(), method, extTfGRPCMethod)
+	}
+	resourceURL, err := o.getResourceURL(resource, parentIDs)
+	if id != "" {
+		resourceURL, err = o.getResourceIDURL(resource, parentIDs, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	reqContext, err := o.prepareRequest(method, resourceURL, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := o.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	fullMethod, methodDescriptor, err := o.resolver.resolve(ctx, operation.grpcMethod)
+	if err != nil {
+		return nil, err
+	}
+	pathParameterValues := parentIDs
+	if id != "" {
+		pathParameterValues = append(append([]string{}, parentIDs...), id)
+	}
+	request, err := newGRPCRequestMessage(methodDescriptor.Input(), requestPayload, operation.grpcPathParameters, pathParameterValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the gRPC request message for %s: %s", fullMethod, err)
+	}
+	response := dynamicpb.NewMessage(methodDescriptor.Output())
+
+	clientLog.Debug("Invoking gRPC method %s (%s %s)", fullMethod, method, resourceURL)
+	ctx = metadata.NewOutgoingContext(ctx, newGRPCMetadata(reqContext.headers))
+	if err := o.conn.Invoke(ctx, fullMethod, request, response); err != nil {
+		grpcStatus, ok := status.FromError(err)
+		if !ok {
+			return nil, fmt.Errorf("gRPC call %s failed: %s", fullMethod, err)
+		}
+		return newGRPCStatusResponse(grpcStatus)
+	}
+	body, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the gRPC response message for %s: %s", fullMethod, err)
+	}
+	if responsePayload != nil {
+		if err := json.Unmarshal(body, responsePayload); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal response body ['%s'] for gRPC call %s", err, fullMethod)
+		}
+	}
+	return newJSONResponse(http.StatusOK, body), nil
+}
+
+// newGRPCRequestMessage returns the request message populated with the request payload fields and the path parameters. The
+// path parameter names may refer to nested fields (e,g: cdn.id) as per the google.api.http path templates
+func newGRPCRequestMessage(messageDescriptor protoreflect.MessageDescriptor, requestPayload interface{}, pathParameterNames []string, pathParameterValues []string) (*dynamicpb.Message, error) {
+	if len(pathParameterNames) != len(pathParameterValues) {
+		return nil, fmt.Errorf("the operation declares %d path parameters %v but %d values were provided", len(pathParameterNames), pathParameterNames, len(pathParameterValues))
+	}
+	fields := map[string]interface{}{}
+	if requestPayload != nil {
+		payload, err := json.Marshal(requestPayload)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &fields); err != nil {
+			return nil, err
+		}
+	}
+	for i, name := range pathParameterNames {
+		setGRPCRequestField(fields, strings.Split(name, "."), pathParameterValues[i])
+	}
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	message := dynamicpb.NewMessage(messageDescriptor)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(payload, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func setGRPCRequestField(fields map[string]interface{}, fieldPath []string, value string) {
+	if len(fieldPath) == 1 {
+		fields[fieldPath[0]] = value
+		return
+	}
+	nestedFields, ok := fields[fieldPath[0]].(map[string]interface{})
+	if !ok {
+		nestedFields = map[string]interface{}{}
+		fields[fieldPath[0]] = nestedFields
+	}
+	setGRPCRequestField(nestedFields, fieldPath[1:], value)
+}
+
+// newGRPCMetadata returns the headers passed in as gRPC metadata (metadata keys are lower case)
+func newGRPCMetadata(headers map[string]string) metadata.MD {
+	md := metadata.MD{}
+	for name, value := range headers {
+		md.Set(strings.ToLower(name), value)
+	}
+	return md
+}
+
+// newGRPCStatusResponse returns the response for a failed gRPC call. The body contains the gRPC status in JSON format, as
+// grpc-gateway would return it
+func newGRPCStatusResponse(grpcStatus *status.Status) (*http.Response, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"code":    grpcStatus.Code(),
+		"message": grpcStatus.Message(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newJSONResponse(getHTTPStatusFromGRPCCode(grpcStatus.Code()), body), nil
+}
+
+// getHTTPStatusFromGRPCCode returns the HTTP status code corresponding to the gRPC code following the grpc-gateway mapping
+func getHTTPStatusFromGRPCCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return http.StatusRequestTimeout
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// grpcMethodResolver resolves the gRPC method descriptors using the gRPC server reflection service. The descriptors are
+// cached so the server is only queried once per method.
+type grpcMethodResolver struct {
+	conn    grpc.ClientConnInterface
+	mutex   sync.Mutex
+	methods map[string]protoreflect.MethodDescriptor
+}
+
+func newGRPCMethodResolver(conn grpc.ClientConnInterface) *grpcMethodResolver {
+	return &grpcMethodResolver{
+		conn:    conn,
+		methods: map[string]protoreflect.MethodDescriptor{},
+	}
+}
+
+// resolve returns the full method name (e,g: /example.v1.CDNService/GetCDN) and the method descriptor of the gRPC method
+// passed in. The service can be either fully qualified (example.v1.CDNService/GetCDN) or just the service name
+// (CDNService/GetCDN), in which case the service is looked up in the services exposed by the server
+func (r *grpcMethodResolver) resolve(ctx context.Context, grpcMethod string) (string, protoreflect.MethodDescriptor, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if methodDescriptor, exists := r.methods[grpcMethod]; exists {
+		return getGRPCFullMethodName(methodDescriptor), methodDescriptor, nil
+	}
+	parts := strings.Split(strings.TrimPrefix(grpcMethod, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", nil, fmt.Errorf("gRPC method '%s' not valid, expected format is 'Service/Method'", grpcMethod)
+	}
+	stream, err := reflectionpb.NewServerReflectionClient(r.conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve the gRPC method '%s' via server reflection: %s", grpcMethod, err)
+	}
+	defer stream.CloseSend() // nolint: errcheck
+
+	serviceName := parts[0]
+	if !strings.Contains(serviceName, ".") {
+		serviceName, err = r.findService(stream, serviceName)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to resolve the gRPC method '%s' via server reflection: %s", grpcMethod, err)
+		}
+	}
+	files, err := r.getFiles(stream, serviceName)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve the gRPC method '%s' via server reflection: %s", grpcMethod, err)
+	}
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve the gRPC method '%s': %s", grpcMethod, err)
+	}
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return "", nil, fmt.Errorf("failed to resolve the gRPC method '%s': '%s' is not a service", grpcMethod, serviceName)
+	}
+	methodDescriptor := serviceDescriptor.Methods().ByName(protoreflect.Name(parts[1]))
+	if methodDescriptor == nil {
+		return "", nil, fmt.Errorf("failed to resolve the gRPC method '%s': service '%s' does not have the method '%s'", grpcMethod, serviceName, parts[1])
+	}
+	r.methods[grpcMethod] = methodDescriptor
+	return getGRPCFullMethodName(methodDescriptor), methodDescriptor, nil
+}
+
+// findService returns the fully qualified name of the service exposed by the server matching the service name passed in
+func (r *grpcMethodResolver) findService(stream reflectionpb.ServerReflection_ServerReflectionInfoClient, serviceName string) (string, error) {
+	response, err := r.send(stream, &reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}})
+	if err != nil {
+		return "", err
+	}
+	for _, service := range response.GetListServicesResponse().GetService() {
+		if service.GetName() == serviceName || strings.HasSuffix(service.GetName(), "."+serviceName) {
+			return service.GetName(), nil
+		}
+	}
+	return "", fmt.Errorf("service '%s' is not exposed by the gRPC server", serviceName)
+}
+
+// getFiles returns the file containing the symbol passed in along with its dependencies
+func (r *grpcMethodResolver) getFiles(stream reflectionpb.ServerReflection_ServerReflectionInfoClient, symbol string) (*protoregistry.Files, error) {
+	response, err := r.send(stream, &reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol}})
+	if err != nil {
+		return nil, err
+	}
+	fileDescriptorSet := &descriptorpb.FileDescriptorSet{}
+	for _, file := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		fileDescriptor := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(file, fileDescriptor); err != nil {
+			return nil, err
+		}
+		fileDescriptorSet.File = append(fileDescriptorSet.File, fileDescriptor)
+	}
+	return protodesc.NewFiles(fileDescriptorSet)
+}
+
+func (r *grpcMethodResolver) send(stream reflectionpb.ServerReflection_ServerReflectionInfoClient, request *reflectionpb.ServerReflectionRequest) (*reflectionpb.ServerReflectionResponse, error) {
+	if err := stream.Send(request); err != nil {
+		return nil, err
+	}
+	response, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if errorResponse := response.GetErrorResponse(); errorResponse != nil {
+		return nil, status.Error(codes.Code(errorResponse.GetErrorCode()), errorResponse.GetErrorMessage())
+	}
+	return response, nil
+}
+
+func getGRPCFullMethodName(methodDescriptor protoreflect.MethodDescriptor) string {
+	return fmt.Sprintf("/%s/%s", methodDescriptor.Parent().FullName(), methodDescriptor.Name())
+}
@@ -0,0 +1,332 @@
+package openapi
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// grpcTestServer is a gRPC server exposing the example.v1.CDNService (built dynamically) and the server reflection service
+type grpcTestServer struct {
+	address  string
+	mutex    sync.Mutex
+	cdns     map[string]string
+	metadata metadata.MD
+}
+
+func newGRPCTestServer(t *testing.T) *grpcTestServer {
+	fileDescriptor, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("example/v1/cdn.proto"),
+		Package: proto.String("example.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("CDN"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("id"), JsonName: proto.String("id"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+					{Name: proto.String("label"), JsonName: proto.String("label"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+				},
+			},
+			{
+				Name: proto.String("GetCDNRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("id"), JsonName: proto.String("id"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("CDNService"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{Name: proto.String("CreateCDN"), InputType: proto.String(".example.v1.CDN"), OutputType: proto.String(".example.v1.CDN")},
+					{Name: proto.String("GetCDN"), InputType: proto.String(".example.v1.GetCDNRequest"), OutputType: proto.String(".example.v1.CDN")},
+				},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+	files := &protoregistry.Files{}
+	require.NoError(t, files.RegisterFile(fileDescriptor))
+
+	testServer := &grpcTestServer{cdns: map[string]string{}}
+	serviceDescriptor := fileDescriptor.Services().Get(0)
+	grpcServiceDesc := grpc.ServiceDesc{
+		ServiceName: string(serviceDescriptor.FullName()),
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{MethodName: "CreateCDN", Handler: testServer.handler(serviceDescriptor.Methods().ByName("CreateCDN"), testServer.createCDN)},
+			{MethodName: "GetCDN", Handler: testServer.handler(serviceDescriptor.Methods().ByName("GetCDN"), testServer.getCDN)},
+		},
+	}
+	server := grpc.NewServer()
+	server.RegisterService(&grpcServiceDesc, testServer)
+	reflectionpb.RegisterServerReflectionServer(server, reflection.NewServer(reflection.ServerOptions{Services: server, DescriptorResolver: files}))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(listener) // nolint: errcheck
+	t.Cleanup(server.Stop)
+	testServer.address = listener.Addr().String()
+	return testServer
+}
+
+func (s *grpcTestServer) handler(methodDescriptor protoreflect.MethodDescriptor, call func(request, response *dynamicpb.Message) error) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(_ interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+		request := dynamicpb.NewMessage(methodDescriptor.Input())
+		if err := dec(request); err != nil {
+			return nil, err
+		}
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.metadata, _ = metadata.FromIncomingContext(ctx)
+		response := dynamicpb.NewMessage(methodDescriptor.Output())
+		if err := call(request, response); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+}
+
+func (s *grpcTestServer) createCDN(request, response *dynamicpb.Message) error {
+	label := request.Get(request.Descriptor().Fields().ByName("label")).String()
+	s.cdns["some-id"] = label
+	response.Set(response.Descriptor().Fields().ByName("id"), protoreflect.ValueOfString("some-id"))
+	response.Set(response.Descriptor().Fields().ByName("label"), protoreflect.ValueOfString(label))
+	return nil
+}
+
+func (s *grpcTestServer) getCDN(request, response *dynamicpb.Message) error {
+	id := request.Get(request.Descriptor().Fields().ByName("id")).String()
+	label, exists := s.cdns[id]
+	if !exists {
+		return status.Errorf(codes.NotFound, "cdn '%s' not found", id)
+	}
+	response.Set(response.Descriptor().Fields().ByName("id"), protoreflect.ValueOfString(id))
+	response.Set(response.Descriptor().Fields().ByName("label"), protoreflect.ValueOfString(label))
+	return nil
+}
+
+func newGRPCTestClient(t *testing.T, address string) *grpcClient {
+	client, err := newGRPCClient(&ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration("www.some-backend.com", "", "https"),
+		apiAuthenticator:            newStubAuthenticator("Authorization", "Bearer some-token", nil),
+	}, &BackendConfig{Type: backendTypeGRPC, Address: address, Insecure: true})
+	require.NoError(t, err)
+	return client
+}
+
+func TestGRPCClient(t *testing.T) {
+	server := newGRPCTestServer(t)
+	client := newGRPCTestClient(t, server.address)
+	resource := &specStubResource{
+		name:                  "cdns_v1",
+		path:                  "/v1/cdns",
+		resourcePostOperation: &specResourceOperation{grpcMethod: "CDNService/CreateCDN"},
+		resourceGetOperation:  &specResourceOperation{grpcMethod: "example.v1.CDNService/GetCDN", grpcPathParameters: []string{"id"}},
+	}
+
+	responsePayload := map[string]interface{}{}
+	resp, err := client.Post(resource, map[string]interface{}{"label": "some label", "unknown_property": "some value"}, &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, map[string]interface{}{"id": "some-id", "label": "some label"}, responsePayload)
+	assert.Equal(t, []string{"Bearer some-token"}, server.metadata.Get("authorization"))
+
+	responsePayload = map[string]interface{}{}
+	resp, err = client.Get(resource, "some-id", &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, map[string]interface{}{"id": "some-id", "label": "some label"}, responsePayload)
+
+	resp, err = client.Get(resource, "non-existing-id", &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"code":5,"message":"cdn 'non-existing-id' not found"}`, string(body))
+}
+
+func TestGRPCClient_DataSourceRead(t *testing.T) {
+	server := newGRPCTestServer(t)
+	server.cdns["some-id"] = "some label"
+	client := newGRPCTestClient(t, server.address)
+	resource := &specStubResource{
+		name:                 "cdns_v1",
+		path:                 "/v1/cdns",
+		resourceGetOperation: &specResourceOperation{grpcMethod: "example.v1.CDNService/GetCDN", grpcPathParameters: []string{"id"}},
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+			},
+		},
+	}
+	dataSource, err := newDataSourceInstanceFactory(resource).createTerraformInstanceDataSource()
+	require.NoError(t, err)
+	data := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{dataSourceInstanceIDProperty: "some-id"})
+
+	diags := dataSource.ReadWithoutTimeout(context.Background(), data, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "some-id", data.Id())
+	assert.Equal(t, "some label", data.Get("label"))
+	assert.Equal(t, []string{"Bearer some-token"}, server.metadata.Get("authorization"), "the data source should be read via the gRPC backend")
+
+	boundClient := client.withContext(context.Background())
+	require.IsType(t, &grpcClient{}, boundClient)
+	assert.Equal(t, client, boundClient.(*grpcClient).withoutContext())
+}
+
+func TestGRPCClient_WithContext(t *testing.T) {
+	server := newGRPCTestServer(t)
+	server.cdns["some-id"] = "some label"
+	client := newGRPCTestClient(t, server.address)
+	resource := &specStubResource{
+		name:                 "cdns_v1",
+		path:                 "/v1/cdns",
+		resourceGetOperation: &specResourceOperation{grpcMethod: "example.v1.CDNService/GetCDN", grpcPathParameters: []string{"id"}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	boundClient := client.withContext(ctx)
+
+	responsePayload := map[string]interface{}{}
+	_, err := boundClient.Get(resource, "some-id", &responsePayload)
+	assert.Error(t, err, "the gRPC method should not be resolved once the context is done")
+
+	// the method resolved by the client not bound to the context is cached, so the gRPC call itself is cancelled
+	resp, err := client.Get(resource, "some-id", &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, err = boundClient.Get(resource, "some-id", &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusRequestTimeout, resp.StatusCode, "the gRPC call should be cancelled once the context is done")
+}
+
+func TestGRPCClient_Errors(t *testing.T) {
+	server := newGRPCTestServer(t)
+	testCases := []struct {
+		name          string
+		operation     *specResourceOperation
+		expectedError string
+	}{
+		{
+			name:          "operation not supported",
+			operation:     nil,
+			expectedError: "resource 'cdns_v1' does not support GET operations",
+		},
+		{
+			name:          "operation missing the gRPC method",
+			operation:     &specResourceOperation{},
+			expectedError: "resource 'cdns_v1' GET operation is missing the gRPC method: the operation id must follow the Service_Method convention or the operation must have the 'x-terraform-grpc-method' extension",
+		},
+		{
+			name:          "gRPC method not valid",
+			operation:     &specResourceOperation{grpcMethod: "GetCDN"},
+			expectedError: "gRPC method 'GetCDN' not valid, expected format is 'Service/Method'",
+		},
+		{
+			name:          "service not exposed by the server",
+			operation:     &specResourceOperation{grpcMethod: "FirewallService/GetFirewall"},
+			expectedError: "failed to resolve the gRPC method 'FirewallService/GetFirewall' via server reflection: service 'FirewallService' is not exposed by the gRPC server",
+		},
+		{
+			name:          "method not exposed by the service",
+			operation:     &specResourceOperation{grpcMethod: "CDNService/UpdateCDN"},
+			expectedError: "failed to resolve the gRPC method 'CDNService/UpdateCDN': service 'example.v1.CDNService' does not have the method 'UpdateCDN'",
+		},
+		{
+			name:          "path parameters not matching the values",
+			operation:     &specResourceOperation{grpcMethod: "CDNService/GetCDN"},
+			expectedError: "failed to build the gRPC request message for /example.v1.CDNService/GetCDN: the operation declares 0 path parameters [] but 1 values were provided",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newGRPCTestClient(t, server.address)
+			resource := &specStubResource{name: "cdns_v1", path: "/v1/cdns", resourceGetOperation: tc.operation}
+			_, err := client.Get(resource, "some-id", &map[string]interface{}{})
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestNewGRPCRequestMessage(t *testing.T) {
+	messageDescriptor := (&descriptorpb.FileDescriptorProto{}).ProtoReflect().Descriptor()
+	testCases := []struct {
+		name                string
+		requestPayload      interface{}
+		pathParameterNames  []string
+		pathParameterValues []string
+		expectedJSON        string
+	}{
+		{
+			name:           "request payload fields are mapped to the message fields",
+			requestPayload: map[string]interface{}{"name": "cdn.proto", "package": "example.v1"},
+			expectedJSON:   `{"name":"cdn.proto","package":"example.v1"}`,
+		},
+		{
+			name:                "path parameters are mapped to the message fields",
+			requestPayload:      map[string]interface{}{"package": "example.v1"},
+			pathParameterNames:  []string{"name"},
+			pathParameterValues: []string{"cdn.proto"},
+			expectedJSON:        `{"name":"cdn.proto","package":"example.v1"}`,
+		},
+		{
+			name:                "path parameters are mapped to the nested message fields",
+			pathParameterNames:  []string{"options.go_package"},
+			pathParameterValues: []string{"example/v1"},
+			expectedJSON:        `{"options":{"goPackage":"example/v1"}}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			message, err := newGRPCRequestMessage(messageDescriptor, tc.requestPayload, tc.pathParameterNames, tc.pathParameterValues)
+			require.NoError(t, err)
+			b, err := protojson.Marshal(message)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedJSON, string(b))
+		})
+	}
+}
+
+func TestGetHTTPStatusFromGRPCCode(t *testing.T) {
+	testCases := []struct {
+		code               codes.Code
+		expectedStatusCode int
+	}{
+		{code: codes.OK, expectedStatusCode: http.StatusOK},
+		{code: codes.InvalidArgument, expectedStatusCode: http.StatusBadRequest},
+		{code: codes.NotFound, expectedStatusCode: http.StatusNotFound},
+		{code: codes.AlreadyExists, expectedStatusCode: http.StatusConflict},
+		{code: codes.PermissionDenied, expectedStatusCode: http.StatusForbidden},
+		{code: codes.Unauthenticated, expectedStatusCode: http.StatusUnauthorized},
+		{code: codes.ResourceExhausted, expectedStatusCode: http.StatusTooManyRequests},
+		{code: codes.Unavailable, expectedStatusCode: http.StatusServiceUnavailable},
+		{code: codes.DataLoss, expectedStatusCode: http.StatusInternalServerError},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.code.String(), func(t *testing.T) {
+			assert.Equal(t, tc.expectedStatusCode, getHTTPStatusFromGRPCCode(tc.code))
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// healthCheckDefaultStatusProperty defines the property of the health endpoint response containing the API status
+	// if the health check does not configure one
+	healthCheckDefaultStatusProperty = "status"
+	// healthCheckDefaultCacheTTL defines how long the API status is cached for if the health check does not configure it
+	healthCheckDefaultCacheTTL = 30 * time.Second
+	// healthCheckTimeout defines the max time the health endpoint is allowed to take to respond
+	healthCheckTimeout = 10 * time.Second
+	// healthCheckMaxResponseBodySize defines the max size of the health endpoint response read
+	healthCheckMaxResponseBodySize = 1 << 20
+)
+
+// healthCheckDefaultBlockingStatuses defines the API statuses the mutating API calls are refused on if the health check
+// does not configure them
+var healthCheckDefaultBlockingStatuses = []string{"degraded", "maintenance"}
+
+// healthCheck checks the API health endpoint before the mutating API calls are performed, refusing them while the API
+// reports a blocking status (e,g: degraded or maintenance). The status is cached so the endpoint is not called per API call
+type healthCheck struct {
+	config     *HealthCheckConfig
+	healthURL  func() (string, error)
+	httpClient *http.Client
+	cacheTTL   time.Duration
+	now        func() time.Time
+
+	mutex     sync.Mutex
+	checkedAt time.Time
+	err       error
+}
+
+func newHealthCheck(config *HealthCheckConfig, healthURL func() (string, error), transport http.RoundTripper) *healthCheck {
+	cacheTTL := healthCheckDefaultCacheTTL
+	if config.CacheTTL != "" {
+		if configuredCacheTTL, err := time.ParseDuration(config.CacheTTL); err == nil {
+			cacheTTL = configuredCacheTTL
+		}
+	}
+	return &healthCheck{
+		config:     config,
+		healthURL:  healthURL,
+		httpClient: &http.Client{Transport: transport, Timeout: healthCheckTimeout},
+		cacheTTL:   cacheTTL,
+		now:        time.Now,
+	}
+}
+
+// check returns an error if the API reports a blocking status; nil if the API accepts changes. Failures calling the health
+// endpoint (e,g: connection errors or unexpected responses) are logged but do not block the mutating API calls, since the
+// API calls themselves will surface the problem if the API is not available
+func (h *healthCheck) check() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if !h.checkedAt.IsZero() && h.now().Sub(h.checkedAt) < h.cacheTTL {
+		return h.err
+	}
+	h.err = h.getStatus()
+	h.checkedAt = h.now()
+	return h.err
+}
+
+func (h *healthCheck) getStatus() error {
+	healthURL, err := h.healthURL()
+	if err != nil {
+		clientLog.Warn("failed to resolve the health endpoint '%s', skipping the health check: %s", h.config.Path, err)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
+	if err != nil {
+		clientLog.Warn("failed to build the health check request GET %s, skipping the health check: %s", healthURL, err)
+		return nil
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		clientLog.Warn("failed to check the API health GET %s, skipping the health check: %s", healthURL, err)
+		return nil
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, healthCheckMaxResponseBodySize))
+	if err != nil {
+		clientLog.Warn("failed to read the health check response GET %s, skipping the health check: %s", healthURL, err)
+		return nil
+	}
+	payload := map[string]interface{}{}
+	json.Unmarshal(body, &payload) // #nosec G104
+	status := h.getPayloadString(payload, h.getStatusProperty())
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		if status == "" {
+			status = "unavailable"
+		}
+		return h.newBlockingStatusError(healthURL, status, payload)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		clientLog.Warn("health check response status code '%d' GET %s not matching expected response status code [2XX], skipping the health check", resp.StatusCode, healthURL)
+		return nil
+	}
+	for _, blockingStatus := range h.getBlockingStatuses() {
+		if strings.EqualFold(status, blockingStatus) {
+			return h.newBlockingStatusError(healthURL, status, payload)
+		}
+	}
+	clientLog.Debug("health check GET %s reports status '%s', the API accepts changes", healthURL, status)
+	return nil
+}
+
+func (h *healthCheck) newBlockingStatusError(healthURL, status string, payload map[string]interface{}) error {
+	message := ""
+	if h.config.MessageProperty != "" {
+		if value := h.getPayloadString(payload, h.config.MessageProperty); value != "" {
+			message = fmt.Sprintf(": %s", value)
+		}
+	}
+	return fmt.Errorf("the API is not accepting changes: health endpoint GET %s reports status '%s'%s; reads are still allowed, please try again later", healthURL, status, message)
+}
+
+func (h *healthCheck) getPayloadString(payload map[string]interface{}, property string) string {
+	value, ok := getPayloadValue(payload, property)
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func (h *healthCheck) getStatusProperty() string {
+	if h.config.StatusProperty != "" {
+		return h.config.StatusProperty
+	}
+	return healthCheckDefaultStatusProperty
+}
+
+func (h *healthCheck) getBlockingStatuses() []string {
+	if len(h.config.BlockingStatuses) > 0 {
+		return h.config.BlockingStatuses
+	}
+	return healthCheckDefaultBlockingStatuses
+}
+
+// healthGatedClient is a ClientOpenAPI that checks the API health before performing the mutating API calls of the client
+// it wraps, failing fast while the API reports a blocking status (e,g: degraded or maintenance). The reads are performed
+// regardless of the API health so plans and refreshes keep working
+type healthGatedClient struct {
+	ClientOpenAPI
+	healthCheck *healthCheck
+	// unbound is the client the copy was bound to the context from; nil if the client is not bound to any context
+	unbound *healthGatedClient
+}
+
+// withContext returns the health gated client wrapping the underlying client bound to the context passed in
+func (c *healthGatedClient) withContext(ctx context.Context) ClientOpenAPI {
+	return &healthGatedClient{
+		ClientOpenAPI: clientWithContext(ctx, c.ClientOpenAPI).(ClientOpenAPI),
+		healthCheck:   c.healthCheck,
+		unbound:       c.withoutContext().(*healthGatedClient),
+	}
+}
+
+// withoutContext returns the client the copy was bound to the context from; the client itself if it is not bound
+func (c *healthGatedClient) withoutContext() ClientOpenAPI {
+	if c.unbound != nil {
+		return c.unbound
+	}
+	return c
+}
+
+// Post performs the POST call if the API accepts changes
+func (c *healthGatedClient) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if err := c.healthCheck.check(); err != nil {
+		return nil, err
+	}
+	return c.ClientOpenAPI.Post(resource, requestPayload, responsePayload, parentIDs...)
+}
+
+// Put performs the PUT call if the API accepts changes
+func (c *healthGatedClient) Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if err := c.healthCheck.check(); err != nil {
+		return nil, err
+	}
+	return c.ClientOpenAPI.Put(resource, id, requestPayload, responsePayload, parentIDs...)
+}
+
+// Delete performs the DELETE call if the API accepts changes
+func (c *healthGatedClient) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	if err := c.healthCheck.check(); err != nil {
+		return nil, err
+	}
+	return c.ClientOpenAPI.Delete(resource, id, parentIDs...)
+}
+
+// PostSubResource performs the sub-resource POST call if the API accepts changes
+func (c *healthGatedClient) PostSubResource(resource SpecResource, id string, subResourcePath string, requestPayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if err := c.healthCheck.check(); err != nil {
+		return nil, err
+	}
+	return c.ClientOpenAPI.PostSubResource(resource, id, subResourcePath, requestPayload, parentIDs...)
+}
+
+// DeleteSubResource performs the sub-resource DELETE call if the API accepts changes
+func (c *healthGatedClient) DeleteSubResource(resource SpecResource, id string, subResourcePath string, parentIDs ...string) (*http.Response, error) {
+	if err := c.healthCheck.check(); err != nil {
+		return nil, err
+	}
+	return c.ClientOpenAPI.DeleteSubResource(resource, id, subResourcePath, parentIDs...)
+}
+
+// AcquireLock acquires the API lock if the API accepts changes, so locks are not held while the changes are refused.
+// The locks are always released
+func (c *healthGatedClient) AcquireLock(resource SpecResource, lock *specLock, ids ...string) (*http.Response, error) {
+	if err := c.healthCheck.check(); err != nil {
+		return nil, err
+	}
+	return c.ClientOpenAPI.AcquireLock(resource, lock, ids...)
+}
+
+// RequestUpload requests the upload URL if the API accepts changes
+func (c *healthGatedClient) RequestUpload(resource SpecResource, uploadPath string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if err := c.healthCheck.check(); err != nil {
+		return nil, err
+	}
+	return c.ClientOpenAPI.RequestUpload(resource, uploadPath, requestPayload, responsePayload, parentIDs...)
+}
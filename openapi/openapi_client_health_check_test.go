@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHealthServerStub(t *testing.T, statusCode int, body string) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestHealthCheckCheck(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        HealthCheckConfig
+		statusCode    int
+		body          string
+		expectedError string
+	}{
+		{name: "api healthy", config: HealthCheckConfig{}, statusCode: http.StatusOK, body: `{"status":"ok"}`},
+		{name: "api degraded", config: HealthCheckConfig{}, statusCode: http.StatusOK, body: `{"status":"Degraded"}`, expectedError: "the API is not accepting changes: health endpoint GET {url} reports status 'Degraded'; reads are still allowed, please try again later"},
+		{name: "api under maintenance with message", config: HealthCheckConfig{MessageProperty: "message"}, statusCode: http.StatusOK, body: `{"status":"maintenance","message":"scheduled maintenance until 10:00 UTC"}`, expectedError: "the API is not accepting changes: health endpoint GET {url} reports status 'maintenance': scheduled maintenance until 10:00 UTC; reads are still allowed, please try again later"},
+		{name: "blocking statuses and nested status property configured", config: HealthCheckConfig{StatusProperty: "status.indicator", BlockingStatuses: []string{"major"}}, statusCode: http.StatusOK, body: `{"status":{"indicator":"major"}}`, expectedError: "the API is not accepting changes: health endpoint GET {url} reports status 'major'; reads are still allowed, please try again later"},
+		{name: "status not blocking as per the statuses configured", config: HealthCheckConfig{BlockingStatuses: []string{"major"}}, statusCode: http.StatusOK, body: `{"status":"degraded"}`},
+		{name: "api unavailable", config: HealthCheckConfig{}, statusCode: http.StatusServiceUnavailable, body: ``, expectedError: "the API is not accepting changes: health endpoint GET {url} reports status 'unavailable'; reads are still allowed, please try again later"},
+		{name: "unexpected response status code", config: HealthCheckConfig{}, statusCode: http.StatusNotFound, body: `{"status":"maintenance"}`},
+		{name: "response not json", config: HealthCheckConfig{}, statusCode: http.StatusOK, body: `OK`},
+	}
+	for _, tc := range testCases {
+		server, _ := newHealthServerStub(t, tc.statusCode, tc.body)
+		config := tc.config
+		config.Path = server.URL + "/health"
+		err := newHealthCheck(&config, func() (string, error) { return config.Path, nil }, nil).check()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, strings.Replace(tc.expectedError, "{url}", config.Path, 1), tc.name)
+		}
+	}
+}
+
+func TestHealthCheckCheckFailsOpen(t *testing.T) {
+	healthCheck := newHealthCheck(&HealthCheckConfig{Path: "/health"}, func() (string, error) { return "", errors.New("host not resolved") }, nil)
+	assert.NoError(t, healthCheck.check(), "the mutating API calls should not be blocked if the health endpoint url can not be resolved")
+	healthCheck = newHealthCheck(&HealthCheckConfig{Path: "/health"}, func() (string, error) { return "http://127.0.0.1:0/health", nil }, nil)
+	assert.NoError(t, healthCheck.check(), "the mutating API calls should not be blocked if the health endpoint is not reachable")
+}
+
+func TestHealthCheckCheckCache(t *testing.T) {
+	server, requests := newHealthServerStub(t, http.StatusOK, `{"status":"maintenance"}`)
+	healthCheck := newHealthCheck(&HealthCheckConfig{Path: server.URL, CacheTTL: "1m"}, func() (string, error) { return server.URL, nil }, nil)
+	now := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	healthCheck.now = func() time.Time { return now }
+
+	assert.Error(t, healthCheck.check())
+	assert.Error(t, healthCheck.check())
+	assert.Equal(t, 1, *requests, "the status should be cached for the cache ttl")
+	now = now.Add(time.Minute)
+	assert.Error(t, healthCheck.check())
+	assert.Equal(t, 2, *requests, "the status should be checked again once the cache ttl expires")
+}
+
+func TestHealthGatedClient(t *testing.T) {
+	server, _ := newHealthServerStub(t, http.StatusOK, `{"status":"maintenance"}`)
+	client := &clientOpenAPIStub{responsePayload: map[string]interface{}{"id": "1234"}}
+	healthGatedClient := &healthGatedClient{ClientOpenAPI: client, healthCheck: newHealthCheck(&HealthCheckConfig{Path: server.URL}, func() (string, error) { return server.URL, nil }, nil)}
+	resource := &specStubResource{name: "cdns_v1", path: "/v1/cdns"}
+	expectedError := "the API is not accepting changes: health endpoint GET " + server.URL + " reports status 'maintenance'; reads are still allowed, please try again later"
+
+	_, err := healthGatedClient.Get(resource, "1234", &map[string]interface{}{})
+	assert.NoError(t, err, "the reads should be allowed regardless of the API health")
+	_, err = healthGatedClient.List(resource, &[]map[string]interface{}{})
+	assert.NoError(t, err, "the reads should be allowed regardless of the API health")
+
+	_, err = healthGatedClient.Post(resource, map[string]interface{}{"label": "some label"}, &map[string]interface{}{})
+	assert.EqualError(t, err, expectedError)
+	_, err = healthGatedClient.Put(resource, "1234", map[string]interface{}{"label": "some label"}, &map[string]interface{}{})
+	assert.EqualError(t, err, expectedError)
+	_, err = healthGatedClient.Delete(resource, "1234")
+	assert.EqualError(t, err, expectedError)
+	_, err = healthGatedClient.PostSubResource(resource, "1234", "/v1/cdns/{id}/start", nil)
+	assert.EqualError(t, err, expectedError)
+	_, err = healthGatedClient.DeleteSubResource(resource, "1234", "/v1/cdns/{id}/start")
+	assert.EqualError(t, err, expectedError)
+	_, err = healthGatedClient.AcquireLock(resource, &specLock{path: "/v1/cdns/lock"})
+	assert.EqualError(t, err, expectedError)
+	_, err = healthGatedClient.RequestUpload(resource, "/v1/cdns/uploads", nil, &map[string]interface{}{})
+	assert.EqualError(t, err, expectedError)
+	assert.Empty(t, client.lockRequestsReceived, "the lock should not be acquired while the API is not accepting changes")
+
+	healthyServer, _ := newHealthServerStub(t, http.StatusOK, `{"status":"ok"}`)
+	healthGatedClient.healthCheck = newHealthCheck(&HealthCheckConfig{Path: healthyServer.URL}, func() (string, error) { return healthyServer.URL, nil }, nil)
+	resp, err := healthGatedClient.Post(resource, map[string]interface{}{"label": "some label"}, &map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
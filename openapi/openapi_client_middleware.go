@@ -0,0 +1,453 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// defaultMiddlewareRetryWait defines the wait before the first retry if the retry middleware does not configure retry_wait
+const defaultMiddlewareRetryWait = time.Second
+
+// defaultMiddlewareMaxRetryWait defines the max wait in between retries if the retry middleware does not configure
+// max_retry_wait
+const defaultMiddlewareMaxRetryWait = 30 * time.Second
+
+// defaultMiddlewareRetryStatusCodes defines the response status codes retried if the retry middleware does not configure
+// retry_status_codes
+var defaultMiddlewareRetryStatusCodes = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// defaultMiddlewareCSRFTokenHeader defines the header the CSRF token is echoed in if the csrf middleware does not configure
+// token_header
+const defaultMiddlewareCSRFTokenHeader = "X-CSRF-Token"
+
+// csrfTokenFetchValue is the value of the token header sent when fetching the CSRF token, which some APIs require to
+// return the token in the response header
+const csrfTokenFetchValue = "Fetch"
+
+// clientMiddleware wraps the round tripper passed in with the middleware behaviour
+type clientMiddleware func(next http.RoundTripper) http.RoundTripper
+
+// roundTripperFunc allows regular functions to be used as http.RoundTrippers
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type namedClientMiddleware struct {
+	name       string
+	config     MiddlewareConfig
+	middleware clientMiddleware
+}
+
+// middlewareChain contains the middlewares configured for the service (see MiddlewareConfig) that the API calls go through.
+// The middlewares are created once per provider so their state (e,g: the rate limiter) is shared across all the API calls
+type middlewareChain struct {
+	middlewares []namedClientMiddleware
+}
+
+// newMiddlewareChain returns the chain of the middlewares configured. The middlewares enabled by a feature flag are only
+// part of the chain if the feature flag is enabled in the featureFlags passed in
+func newMiddlewareChain(middlewareConfigs []MiddlewareConfig, featureFlags map[string]interface{}) (*middlewareChain, error) {
+	chain := &middlewareChain{}
+	for _, middlewareConfig := range middlewareConfigs {
+		if err := middlewareConfig.Validate(); err != nil {
+			return nil, fmt.Errorf("middleware '%s' not valid: %s", middlewareConfig.GetName(), err)
+		}
+		if middlewareConfig.FeatureFlag != "" {
+			if _, defined := featureFlags[middlewareConfig.FeatureFlag]; !defined {
+				return nil, fmt.Errorf("middleware '%s' not valid: feature flag '%s' is not defined in the OpenAPI document", middlewareConfig.GetName(), middlewareConfig.FeatureFlag)
+			}
+			if !isFeatureFlagEnabled(featureFlags, middlewareConfig.FeatureFlag) {
+				clientLog.Debug("middleware '%s' skipped since the feature flag '%s' is not enabled", middlewareConfig.GetName(), middlewareConfig.FeatureFlag)
+				continue
+			}
+		}
+		var middleware clientMiddleware
+		switch middlewareConfig.Type {
+		case middlewareTypeRetry:
+			middleware = newRetryMiddleware(middlewareConfig)
+		case middlewareTypeRateLimit:
+			middleware = newRateLimitMiddleware(middlewareConfig)
+		case middlewareTypeHeaders:
+			middleware = newHeadersMiddleware(middlewareConfig)
+		case middlewareTypeLogging:
+			middleware = newLoggingMiddleware()
+		case middlewareTypeCSRF:
+			middleware = newCSRFMiddleware(middlewareConfig)
+		}
+		chain.middlewares = append(chain.middlewares, namedClientMiddleware{name: middlewareConfig.GetName(), config: middlewareConfig, middleware: middleware})
+	}
+	return chain, nil
+}
+
+// getHTTPClient returns an http client that performs the operation API calls through the middlewares. If the operation
+// selects the middlewares via the x-terraform-middleware extension only those are applied (keeping the order in which they
+// are configured); otherwise all the middlewares configured are applied. If the operation defines its own retry settings
+// (x-terraform-retry) they override the ones of the retry middleware. The http client passed in is returned as is if
+// there are no middlewares to apply
+func (c *middlewareChain) getHTTPClient(httpClient *http.Client, operation *specResourceOperation) *http.Client {
+	var middlewares []namedClientMiddleware
+	if c != nil {
+		middlewares = c.middlewares
+		if operation != nil && operation.middlewares != nil {
+			middlewares = c.selectMiddlewares(operation.middlewares)
+		}
+	}
+	if operation != nil && operation.retry != nil {
+		middlewares = withOperationRetry(middlewares, operation.retry)
+	}
+	if len(middlewares) == 0 {
+		return httpClient
+	}
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i].middleware(transport)
+	}
+	return &http.Client{
+		Transport:     transport,
+		CheckRedirect: httpClient.CheckRedirect,
+		Jar:           httpClient.Jar,
+		Timeout:       httpClient.Timeout,
+	}
+}
+
+func (c *middlewareChain) selectMiddlewares(names []string) []namedClientMiddleware {
+	selected := map[string]bool{}
+	for _, name := range names {
+		selected[name] = true
+	}
+	var middlewares []namedClientMiddleware
+	for _, middleware := range c.middlewares {
+		if selected[middleware.name] {
+			middlewares = append(middlewares, middleware)
+			delete(selected, middleware.name)
+		}
+	}
+	for name := range selected {
+		clientLog.Warn("middleware '%s' selected by the operation is not configured, ignoring it", name)
+	}
+	return middlewares
+}
+
+// withOperationRetry returns the middlewares passed in with the retry middlewares configured with the retry settings of
+// the operation. If there is no retry middleware, one configured with the operation retry settings is prepended so the
+// operation API calls are retried even if the service does not configure the retry middleware
+func withOperationRetry(middlewares []namedClientMiddleware, retry *specRetry) []namedClientMiddleware {
+	operationMiddlewares := make([]namedClientMiddleware, 0, len(middlewares)+1)
+	retryConfigured := false
+	for _, middleware := range middlewares {
+		if middleware.config.Type == middlewareTypeRetry {
+			retryConfigured = true
+			middleware.config = retry.apply(middleware.config)
+			middleware.middleware = newRetryMiddleware(middleware.config)
+		}
+		operationMiddlewares = append(operationMiddlewares, middleware)
+	}
+	if !retryConfigured {
+		config := retry.apply(MiddlewareConfig{Type: middlewareTypeRetry})
+		operationMiddlewares = append([]namedClientMiddleware{{name: config.GetName(), config: config, middleware: newRetryMiddleware(config)}}, operationMiddlewares...)
+	}
+	return operationMiddlewares
+}
+
+// newRetryMiddleware returns a middleware that retries the API calls failing with connection errors or any of the retry
+// status codes configured, waiting an exponential backoff with jitter in between. Only idempotent requests are retried with the
+// exception of 429 Too Many Requests responses which are retried for all methods since the request was not processed.
+// Requests whose body can not be replayed are not retried
+func newRetryMiddleware(middlewareConfig MiddlewareConfig) clientMiddleware {
+	retryWait := defaultMiddlewareRetryWait
+	if middlewareConfig.RetryWait != "" {
+		retryWait, _ = time.ParseDuration(middlewareConfig.RetryWait)
+	}
+	maxRetryWait := defaultMiddlewareMaxRetryWait
+	if middlewareConfig.MaxRetryWait != "" {
+		maxRetryWait, _ = time.ParseDuration(middlewareConfig.MaxRetryWait)
+	}
+	retryStatusCodes := middlewareConfig.RetryStatusCodes
+	if len(retryStatusCodes) == 0 {
+		retryStatusCodes = defaultMiddlewareRetryStatusCodes
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			for attempt := 0; ; attempt++ {
+				resp, err := next.RoundTrip(req)
+				if attempt >= middlewareConfig.MaxRetries || !shouldRetryRequest(req, resp, err, retryStatusCodes) {
+					return resp, err
+				}
+				if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+					return resp, err
+				}
+				wait := getRetryWait(retryWait, maxRetryWait, attempt)
+				if err != nil {
+					clientLog.Debug("%s %s failed (%s), retrying in %s (retry %d/%d)", req.Method, req.URL, err, wait, attempt+1, middlewareConfig.MaxRetries)
+				} else {
+					clientLog.Debug("%s %s returned %d, retrying in %s (retry %d/%d)", req.Method, req.URL, resp.StatusCode, wait, attempt+1, middlewareConfig.MaxRetries)
+					io.Copy(ioutil.Discard, resp.Body) // #nosec G104
+					resp.Body.Close()
+				}
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(wait):
+				}
+				if req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					req = req.Clone(req.Context())
+					req.Body = body
+				}
+			}
+		})
+	}
+}
+
+// getRetryWait returns the wait before the retry passed in (zero based): the retry wait doubled on each retry, up to the
+// max retry wait, with a random jitter of up to half of it so the retries of concurrent API calls are spread out
+func getRetryWait(retryWait, maxRetryWait time.Duration, retry int) time.Duration {
+	wait := retryWait
+	for i := 0; i < retry && wait < maxRetryWait; i++ {
+		wait *= 2
+	}
+	if wait > maxRetryWait {
+		wait = maxRetryWait
+	}
+	if jitter := int64(wait / 2); jitter > 0 {
+		wait -= time.Duration(rand.Int63n(jitter + 1)) // #nosec G404
+	}
+	return wait
+}
+
+// isIdempotentMethod returns true if sending the request more than once has the same effect as sending it once
+func isIdempotentMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodPut || method == http.MethodDelete || method == http.MethodOptions
+}
+
+func shouldRetryRequest(req *http.Request, resp *http.Response, err error, retryStatusCodes []int) bool {
+	idempotent := isIdempotentMethod(req.Method)
+	if err != nil {
+		return idempotent
+	}
+	for _, statusCode := range retryStatusCodes {
+		if resp.StatusCode == statusCode {
+			return idempotent || statusCode == http.StatusTooManyRequests
+		}
+	}
+	return false
+}
+
+// newRateLimitMiddleware returns a middleware that limits the rate of the API calls using a token bucket that is refilled
+// at the requests per second configured and holds up to burst tokens
+func newRateLimitMiddleware(middlewareConfig MiddlewareConfig) clientMiddleware {
+	burst := middlewareConfig.Burst
+	if burst == 0 {
+		burst = 1
+	}
+	limiter := &rateLimiter{rate: middlewareConfig.RequestsPerSecond, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if wait := limiter.reserve(); wait > 0 {
+				clientLog.Debug("%s %s rate limited, waiting %s", req.Method, req.URL, wait)
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(wait):
+				}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// rateLimiter implements a token bucket rate limiter
+type rateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes a token from the bucket and returns how long the caller must wait before the token can be used
+func (l *rateLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// newHeadersMiddleware returns a middleware that injects the headers configured in the API calls
+func newHeadersMiddleware(middlewareConfig MiddlewareConfig) clientMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for name, value := range middlewareConfig.Headers {
+				req.Header.Set(name, value)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// newLoggingMiddleware returns a middleware that logs the API calls along with the response status code and latency. The
+// request and response bodies are not logged as they may contain sensitive information
+func newLoggingMiddleware() clientMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				clientLog.Info("%s %s failed after %s: %s", req.Method, req.URL, time.Since(start), err)
+				return resp, err
+			}
+			clientLog.Info("%s %s returned %d (time:%s)", req.Method, req.URL, resp.StatusCode, time.Since(start))
+			return resp, err
+		})
+	}
+}
+
+// newCSRFMiddleware returns a middleware that performs the CSRF token handshake required by some APIs: the token is fetched
+// via a GET request to the token path configured and echoed in the token header of the mutating API calls (POST, PUT, PATCH
+// and DELETE), along with the cookies set by the token response. The token is fetched on the first mutating API call made
+// against each host and shared across the API calls; if the API rejects a call with 403 Forbidden the token is fetched
+// again and the call retried once (as long as the request body can be replayed)
+func newCSRFMiddleware(middlewareConfig MiddlewareConfig) clientMiddleware {
+	tokenHeader := middlewareConfig.TokenHeader
+	if tokenHeader == "" {
+		tokenHeader = defaultMiddlewareCSRFTokenHeader
+	}
+	tokenPath, _ := url.Parse(middlewareConfig.TokenPath)
+	tokens := &csrfTokens{tokens: map[string]*csrfToken{}}
+	return func(next http.RoundTripper) http.RoundTripper {
+		fetchToken := func(req *http.Request) (*csrfToken, error) {
+			tokenURL := req.URL.ResolveReference(tokenPath)
+			tokenReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, tokenURL.String(), nil)
+			if err != nil {
+				return nil, err
+			}
+			// the token request is authenticated the same way as the API call
+			for name, values := range req.Header {
+				if name != contentType && name != contentEncodingHeader {
+					tokenReq.Header[name] = values
+				}
+			}
+			tokenReq.Header.Set(tokenHeader, csrfTokenFetchValue)
+			resp, err := next.RoundTrip(tokenReq)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch the CSRF token: %s", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("failed to fetch the CSRF token: GET %s responded with status code '%d'", tokenURL, resp.StatusCode)
+			}
+			token := &csrfToken{value: resp.Header.Get(tokenHeader), cookies: resp.Cookies()}
+			if middlewareConfig.TokenField != "" {
+				responseBody := map[string]interface{}{}
+				if err := json.NewDecoder(resp.Body).Decode(&responseBody); err != nil {
+					return nil, fmt.Errorf("failed to fetch the CSRF token: token response not valid: %s", err)
+				}
+				tokenValue, _ := getPayloadValue(responseBody, middlewareConfig.TokenField)
+				token.value, _ = tokenValue.(string)
+			}
+			if token.value == "" || token.value == csrfTokenFetchValue {
+				return nil, fmt.Errorf("failed to fetch the CSRF token: GET %s response is missing the token", tokenURL)
+			}
+			clientLog.Debug("CSRF token fetched from %s", tokenURL)
+			return token, nil
+		}
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodPost && req.Method != http.MethodPut && req.Method != http.MethodPatch && req.Method != http.MethodDelete {
+				return next.RoundTrip(req)
+			}
+			for attempt := 0; ; attempt++ {
+				token, err := tokens.get(req.URL.Host, func() (*csrfToken, error) { return fetchToken(req) })
+				if err != nil {
+					return nil, err
+				}
+				csrfReq := req.Clone(req.Context())
+				csrfReq.Header.Set(tokenHeader, token.value)
+				for _, cookie := range token.cookies {
+					csrfReq.AddCookie(cookie)
+				}
+				resp, err := next.RoundTrip(csrfReq)
+				if err != nil || resp.StatusCode != http.StatusForbidden || attempt > 0 {
+					return resp, err
+				}
+				if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+					return resp, err
+				}
+				clientLog.Debug("%s %s returned %d, fetching the CSRF token again", req.Method, req.URL, resp.StatusCode)
+				io.Copy(ioutil.Discard, resp.Body) // #nosec G104
+				resp.Body.Close()
+				tokens.invalidate(req.URL.Host, token)
+				if req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					req = req.Clone(req.Context())
+					req.Body = body
+				}
+			}
+		})
+	}
+}
+
+// csrfToken contains the CSRF token along with the cookies set by the token response
+type csrfToken struct {
+	value   string
+	cookies []*http.Cookie
+}
+
+// csrfTokens contains the CSRF tokens fetched keyed by the host they were fetched from
+type csrfTokens struct {
+	mutex  sync.Mutex
+	tokens map[string]*csrfToken
+}
+
+// get returns the token of the host passed in, fetching it if there's no token yet
+func (t *csrfTokens) get(host string, fetch func() (*csrfToken, error)) (*csrfToken, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if token, exists := t.tokens[host]; exists {
+		return token, nil
+	}
+	token, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	t.tokens[host] = token
+	return token, nil
+}
+
+// invalidate drops the token of the host passed in so it's fetched again, unless the token was already fetched again (e,g:
+// by a concurrent API call)
+func (t *csrfTokens) invalidate(host string, token *csrfToken) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.tokens[host] == token {
+		delete(t.tokens, host)
+	}
+}
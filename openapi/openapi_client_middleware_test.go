@@ -0,0 +1,369 @@
+package openapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMiddlewareChain(t *testing.T) {
+	chain, err := newMiddlewareChain([]MiddlewareConfig{{Type: "retry", MaxRetries: 2}, {Name: "audit", Type: "logging"}}, nil)
+	require.NoError(t, err)
+	require.Len(t, chain.middlewares, 2)
+	assert.Equal(t, "retry", chain.middlewares[0].name)
+	assert.Equal(t, "audit", chain.middlewares[1].name)
+
+	_, err = newMiddlewareChain([]MiddlewareConfig{{Type: "retry"}}, nil)
+	assert.EqualError(t, err, "middleware 'retry' not valid: max_retries must be greater than zero for the retry middleware")
+}
+
+func TestNewMiddlewareChainFeatureFlags(t *testing.T) {
+	middlewareConfigs := []MiddlewareConfig{{Type: "logging"}, {Type: "headers", Headers: map[string]string{"X-Billing": "v2"}, FeatureFlag: "new_billing"}}
+	chain, err := newMiddlewareChain(middlewareConfigs, map[string]interface{}{"new_billing": true})
+	require.NoError(t, err)
+	assert.Len(t, chain.middlewares, 2)
+
+	chain, err = newMiddlewareChain(middlewareConfigs, map[string]interface{}{"new_billing": false})
+	require.NoError(t, err)
+	require.Len(t, chain.middlewares, 1)
+	assert.Equal(t, "logging", chain.middlewares[0].name)
+
+	_, err = newMiddlewareChain(middlewareConfigs, nil)
+	assert.EqualError(t, err, "middleware 'headers' not valid: feature flag 'new_billing' is not defined in the OpenAPI document")
+}
+
+func TestMiddlewareChainGetHTTPClient(t *testing.T) {
+	var calls []string
+	recordingMiddleware := func(name string) namedClientMiddleware {
+		return namedClientMiddleware{name: name, middleware: func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.RoundTrip(req)
+			})
+		}}
+	}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer api.Close()
+	chain := &middlewareChain{middlewares: []namedClientMiddleware{recordingMiddleware("first"), recordingMiddleware("second")}}
+	httpClient := &http.Client{Timeout: time.Minute}
+
+	testCases := []struct {
+		name          string
+		chain         *middlewareChain
+		operation     *specResourceOperation
+		expectedCalls []string
+	}{
+		{name: "no middlewares configured", chain: nil, operation: &specResourceOperation{}, expectedCalls: nil},
+		{name: "operation without middleware selection", chain: chain, operation: &specResourceOperation{}, expectedCalls: []string{"first", "second"}},
+		{name: "operation selecting a middleware", chain: chain, operation: &specResourceOperation{middlewares: []string{"second"}}, expectedCalls: []string{"second"}},
+		{name: "operation selecting the middlewares in a different order", chain: chain, operation: &specResourceOperation{middlewares: []string{"second", "first"}}, expectedCalls: []string{"first", "second"}},
+		{name: "operation opting out of the middlewares", chain: chain, operation: &specResourceOperation{middlewares: []string{}}, expectedCalls: nil},
+		{name: "operation selecting a middleware not configured", chain: chain, operation: &specResourceOperation{middlewares: []string{"unknown"}}, expectedCalls: nil},
+	}
+	for _, tc := range testCases {
+		calls = nil
+		client := tc.chain.getHTTPClient(httpClient, tc.operation)
+		assert.Equal(t, time.Minute, client.Timeout, tc.name)
+		resp, err := client.Get(api.URL)
+		require.NoError(t, err, tc.name)
+		resp.Body.Close()
+		assert.Equal(t, tc.expectedCalls, calls, tc.name)
+	}
+}
+
+func TestRetryMiddleware(t *testing.T) {
+	testCases := []struct {
+		name             string
+		method           string
+		body             string
+		statusCodes      []int
+		retryStatusCodes []int
+		expectedStatus   int
+		expectedCalls    int32
+	}{
+		{name: "GET request retried until it succeeds", method: http.MethodGet, statusCodes: []int{503, 502, 200}, expectedStatus: 200, expectedCalls: 3},
+		{name: "GET request retried until the max retries are reached", method: http.MethodGet, statusCodes: []int{503, 503, 503, 503}, expectedStatus: 503, expectedCalls: 3},
+		{name: "GET request not retried on status codes not configured", method: http.MethodGet, statusCodes: []int{500, 200}, expectedStatus: 500, expectedCalls: 1},
+		{name: "GET request retried on custom status codes", method: http.MethodGet, statusCodes: []int{500, 200}, retryStatusCodes: []int{500}, expectedStatus: 200, expectedCalls: 2},
+		{name: "PUT request retried replaying the body", method: http.MethodPut, body: `{"label":"some label"}`, statusCodes: []int{503, 200}, expectedStatus: 200, expectedCalls: 2},
+		{name: "POST request not retried on 503", method: http.MethodPost, body: `{"label":"some label"}`, statusCodes: []int{503, 201}, expectedStatus: 503, expectedCalls: 1},
+		{name: "POST request retried on 429", method: http.MethodPost, body: `{"label":"some label"}`, statusCodes: []int{429, 201}, expectedStatus: 201, expectedCalls: 2},
+	}
+	for _, tc := range testCases {
+		var calls int32
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			call := atomic.AddInt32(&calls, 1)
+			body := make([]byte, len(tc.body))
+			r.Body.Read(body)
+			assert.Equal(t, tc.body, string(body), tc.name)
+			w.WriteHeader(tc.statusCodes[call-1])
+		}))
+		retryMiddleware := newRetryMiddleware(MiddlewareConfig{Type: "retry", MaxRetries: 2, RetryWait: "1ms", RetryStatusCodes: tc.retryStatusCodes})
+		req, err := http.NewRequest(tc.method, api.URL, strings.NewReader(tc.body))
+		require.NoError(t, err, tc.name)
+		resp, err := retryMiddleware(http.DefaultTransport).RoundTrip(req)
+		api.Close()
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedStatus, resp.StatusCode, tc.name)
+		assert.Equal(t, tc.expectedCalls, calls, tc.name)
+	}
+}
+
+func TestGetRetryWait(t *testing.T) {
+	testCases := []struct {
+		retry       int
+		expectedMax time.Duration
+	}{
+		{retry: 0, expectedMax: time.Second},
+		{retry: 1, expectedMax: 2 * time.Second},
+		{retry: 2, expectedMax: 4 * time.Second},
+		{retry: 3, expectedMax: 5 * time.Second},
+		{retry: 100, expectedMax: 5 * time.Second},
+	}
+	for _, tc := range testCases {
+		for i := 0; i < 10; i++ {
+			wait := getRetryWait(time.Second, 5*time.Second, tc.retry)
+			assert.True(t, wait >= tc.expectedMax/2 && wait <= tc.expectedMax, "retry %d: wait %s not within the expected backoff", tc.retry, wait)
+		}
+	}
+}
+
+func TestProviderClientOperationRetry(t *testing.T) {
+	maxRetries := 3
+	testCases := []struct {
+		name          string
+		middlewares   []MiddlewareConfig
+		retry         *specRetry
+		statusCodes   []int
+		expectedCalls int32
+	}{
+		{
+			name:          "retry middleware settings",
+			middlewares:   []MiddlewareConfig{{Type: "retry", MaxRetries: 1, RetryWait: "1ms"}},
+			statusCodes:   []int{503, 503, 200},
+			expectedCalls: 2,
+		},
+		{
+			name:          "operation overrides the retry middleware settings",
+			middlewares:   []MiddlewareConfig{{Type: "retry", MaxRetries: 1, RetryWait: "1ms"}},
+			retry:         &specRetry{maxRetries: &maxRetries, retryStatusCodes: []int{409}},
+			statusCodes:   []int{409, 409, 200},
+			expectedCalls: 3,
+		},
+		{
+			name:          "operation retried without the retry middleware configured",
+			middlewares:   []MiddlewareConfig{{Type: "logging"}},
+			retry:         &specRetry{maxRetries: &maxRetries, retryWait: "1ms"},
+			statusCodes:   []int{503, 502, 200},
+			expectedCalls: 3,
+		},
+	}
+	for _, tc := range testCases {
+		var calls int32
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.statusCodes[atomic.AddInt32(&calls, 1)-1])
+		}))
+		middlewares, err := newMiddlewareChain(tc.middlewares, nil)
+		require.NoError(t, err, tc.name)
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newAPIAuthenticator(nil),
+			middlewares:                 middlewares,
+		}
+		_, err = providerClient.performRequest(httpGet, api.URL+"/v1/resource", &specResourceOperation{retry: tc.retry}, nil, nil)
+		api.Close()
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedCalls, calls, tc.name)
+	}
+}
+
+func TestWithOperationRetry(t *testing.T) {
+	maxRetries := 0
+	middlewares := []namedClientMiddleware{
+		{name: "logging", config: MiddlewareConfig{Type: "logging"}},
+		{name: "retry_reads", config: MiddlewareConfig{Name: "retry_reads", Type: "retry", MaxRetries: 3, RetryWait: "1s"}},
+	}
+	operationMiddlewares := withOperationRetry(middlewares, &specRetry{maxRetries: &maxRetries})
+	require.Len(t, operationMiddlewares, 2)
+	assert.Equal(t, MiddlewareConfig{Name: "retry_reads", Type: "retry", MaxRetries: 0, RetryWait: "1s"}, operationMiddlewares[1].config)
+	assert.Equal(t, 3, middlewares[1].config.MaxRetries, "the middlewares of the chain should not be modified")
+
+	operationMiddlewares = withOperationRetry(middlewares[:1], &specRetry{retryWait: "1s"})
+	require.Len(t, operationMiddlewares, 2)
+	assert.Equal(t, "retry", operationMiddlewares[0].name, "the retry middleware should be prepended if not configured")
+	assert.Equal(t, MiddlewareConfig{Type: "retry", RetryWait: "1s"}, operationMiddlewares[0].config)
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer api.Close()
+	rateLimitMiddleware := newRateLimitMiddleware(MiddlewareConfig{Type: "rate_limit", RequestsPerSecond: 20, Burst: 2})(http.DefaultTransport)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		req, err := http.NewRequest(http.MethodGet, api.URL, nil)
+		require.NoError(t, err)
+		resp, err := rateLimitMiddleware.RoundTrip(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	// the first two requests are served by the burst and the next two wait 50ms each
+	assert.True(t, time.Since(start) >= 90*time.Millisecond, "requests were not rate limited")
+}
+
+func TestRateLimiterReserve(t *testing.T) {
+	limiter := &rateLimiter{rate: 1, burst: 1, tokens: 1, last: time.Now()}
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	wait := limiter.reserve()
+	assert.True(t, wait > 900*time.Millisecond && wait <= time.Second, "unexpected wait %s", wait)
+}
+
+func TestHeadersMiddleware(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "platform", r.Header.Get("X-Team"))
+		assert.Equal(t, "Bearer secret!", r.Header.Get("Authentication"))
+	}))
+	defer api.Close()
+	headersMiddleware := newHeadersMiddleware(MiddlewareConfig{Type: "headers", Headers: map[string]string{"X-Team": "platform"}})
+	req, err := http.NewRequest(http.MethodGet, api.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authentication", "Bearer secret!")
+	resp, err := headersMiddleware(http.DefaultTransport).RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, req.Header.Get("X-Team"), "the original request must not be modified")
+}
+
+func TestProviderClientMiddlewares(t *testing.T) {
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "platform", r.Header.Get("X-Team"))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":"1234"}`))
+	}))
+	defer api.Close()
+	middlewares, err := newMiddlewareChain([]MiddlewareConfig{
+		{Type: "retry", MaxRetries: 1, RetryWait: "1ms"},
+		{Type: "headers", Headers: map[string]string{"X-Team": "platform"}},
+	}, nil)
+	require.NoError(t, err)
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		middlewares:                 middlewares,
+	}
+	responsePayload := map[string]interface{}{}
+	resp, err := providerClient.performRequest(httpGet, api.URL+"/v1/resource", &specResourceOperation{}, nil, &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "1234", responsePayload["id"])
+	assert.Equal(t, int32(2), calls)
+}
+
+func TestCSRFMiddleware(t *testing.T) {
+	newCSRFAPI := func(t *testing.T, requests *[]string) *httptest.Server {
+		var tokens int32
+		var validToken string
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*requests = append(*requests, r.Method+" "+r.URL.Path)
+			assert.Equal(t, "Bearer secret!", r.Header.Get("Authentication"), "the token request should be authenticated as the API call")
+			switch {
+			case r.URL.Path == "/api/csrf":
+				assert.Equal(t, csrfTokenFetchValue, r.Header.Get(defaultMiddlewareCSRFTokenHeader))
+				validToken = fmt.Sprintf("token-%d", atomic.AddInt32(&tokens, 1))
+				http.SetCookie(w, &http.Cookie{Name: "csrf_session", Value: validToken})
+				w.Header().Set(defaultMiddlewareCSRFTokenHeader, validToken)
+			case r.Method == http.MethodGet:
+				assert.Empty(t, r.Header.Get(defaultMiddlewareCSRFTokenHeader), "the CSRF token should only be sent in mutating API calls")
+			default:
+				cookie, err := r.Cookie("csrf_session")
+				if err != nil || cookie.Value != validToken || r.Header.Get(defaultMiddlewareCSRFTokenHeader) != validToken {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				body, _ := ioutil.ReadAll(r.Body)
+				assert.Equal(t, `{"name":"cdn"}`, string(body))
+				w.WriteHeader(http.StatusCreated)
+			}
+		}))
+	}
+	post := func(transport http.RoundTripper, url string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"name":"cdn"}`))
+		require.NoError(t, err)
+		req.Header.Set("Authentication", "Bearer secret!")
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	t.Run("token fetched once and echoed in the mutating API calls", func(t *testing.T) {
+		var requests []string
+		api := newCSRFAPI(t, &requests)
+		defer api.Close()
+		csrfMiddleware := newCSRFMiddleware(MiddlewareConfig{Type: "csrf", TokenPath: "/api/csrf"})(http.DefaultTransport)
+		req, err := http.NewRequest(http.MethodGet, api.URL+"/api/v1/cdns/1234", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authentication", "Bearer secret!")
+		resp, err := csrfMiddleware.RoundTrip(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, post(csrfMiddleware, api.URL+"/api/v1/cdns").StatusCode)
+		assert.Equal(t, http.StatusCreated, post(csrfMiddleware, api.URL+"/api/v1/cdns").StatusCode)
+		assert.Equal(t, []string{"GET /api/v1/cdns/1234", "GET /api/csrf", "POST /api/v1/cdns", "POST /api/v1/cdns"}, requests)
+	})
+
+	t.Run("token fetched again and call retried once the API rejects the token", func(t *testing.T) {
+		var requests []string
+		api := newCSRFAPI(t, &requests)
+		defer api.Close()
+		csrfMiddleware := newCSRFMiddleware(MiddlewareConfig{Type: "csrf", TokenPath: "/api/csrf"})(http.DefaultTransport)
+		assert.Equal(t, http.StatusCreated, post(csrfMiddleware, api.URL+"/api/v1/cdns").StatusCode)
+		// another client fetching a token invalidates the one held by the middleware
+		req, err := http.NewRequest(http.MethodGet, api.URL+"/api/csrf", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authentication", "Bearer secret!")
+		req.Header.Set(defaultMiddlewareCSRFTokenHeader, csrfTokenFetchValue)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, post(csrfMiddleware, api.URL+"/api/v1/cdns").StatusCode)
+		assert.Equal(t, []string{"GET /api/csrf", "POST /api/v1/cdns", "GET /api/csrf", "POST /api/v1/cdns", "GET /api/csrf", "POST /api/v1/cdns"}, requests)
+	})
+
+	t.Run("token read from the response body", func(t *testing.T) {
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/csrf" {
+				w.Write([]byte(`{"csrf":{"token":"bodyToken"}}`))
+				return
+			}
+			assert.Equal(t, "bodyToken", r.Header.Get("X-XSRF-Token"))
+		}))
+		defer api.Close()
+		csrfMiddleware := newCSRFMiddleware(MiddlewareConfig{Type: "csrf", TokenPath: "/csrf", TokenHeader: "X-XSRF-Token", TokenField: "csrf.token"})(http.DefaultTransport)
+		assert.Equal(t, http.StatusOK, post(csrfMiddleware, api.URL+"/api/v1/cdns").StatusCode)
+	})
+
+	t.Run("token fetch fails", func(t *testing.T) {
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer api.Close()
+		csrfMiddleware := newCSRFMiddleware(MiddlewareConfig{Type: "csrf", TokenPath: "/api/csrf"})(http.DefaultTransport)
+		req, err := http.NewRequest(http.MethodDelete, api.URL+"/api/v1/cdns/1234", nil)
+		require.NoError(t, err)
+		_, err = csrfMiddleware.RoundTrip(req)
+		assert.EqualError(t, err, fmt.Sprintf("failed to fetch the CSRF token: GET %s/api/csrf response is missing the token", api.URL))
+	})
+}
@@ -0,0 +1,180 @@
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+)
+
+// maxBufferedRequestBodySize defines the size in bytes up to which request bodies are buffered in memory and sent along with
+// the Content-Length header. Bigger request bodies are streamed to the API as they are encoded (chunked transfer encoding)
+// to avoid memory spikes when sending large payloads
+const maxBufferedRequestBodySize = 1024 * 1024
+
+const contentEncodingHeader = "Content-Encoding"
+
+var errRequestBodyLimitExceeded = errors.New("request body limit exceeded")
+
+// requestBody contains the encoded request body. If the body was buffered, contentLength contains the size of the body;
+// otherwise it's -1 and the body is encoded as it is read
+type requestBody struct {
+	reader        io.Reader
+	contentLength int64
+	gzipped       bool
+}
+
+// newRequestBody returns the JSON request body for the payload passed in. Payloads up to maxBufferedRequestBodySize are
+// buffered; bigger payloads are streamed. If gzipThreshold is not negative, the body is gzip compressed when its size is
+// greater than the threshold
+func newRequestBody(payload interface{}, gzipThreshold int) (*requestBody, error) {
+	buffer := &limitedBuffer{limit: maxBufferedRequestBodySize}
+	err := writeJSON(buffer, payload)
+	if err == nil {
+		if gzipThreshold < 0 || buffer.Len() <= gzipThreshold {
+			return &requestBody{reader: bytes.NewReader(buffer.Bytes()), contentLength: int64(buffer.Len())}, nil
+		}
+		compressed := &bytes.Buffer{}
+		gzipWriter := gzip.NewWriter(compressed)
+		if _, err := gzipWriter.Write(buffer.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := gzipWriter.Close(); err != nil {
+			return nil, err
+		}
+		return &requestBody{reader: bytes.NewReader(compressed.Bytes()), contentLength: int64(compressed.Len()), gzipped: true}, nil
+	}
+	if err != errRequestBodyLimitExceeded {
+		return nil, err
+	}
+	gzipped := false
+	if gzipThreshold >= 0 {
+		gzipped = gzipThreshold <= maxBufferedRequestBodySize
+		if !gzipped {
+			// the threshold is bigger than the buffered size so the payload size is calculated without keeping it in memory
+			counter := &countingWriter{}
+			if err := writeJSON(counter, payload); err != nil {
+				return nil, err
+			}
+			gzipped = counter.n > int64(gzipThreshold)
+		}
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeRequestBody(writer, payload, gzipped))
+	}()
+	return &requestBody{reader: reader, contentLength: -1, gzipped: gzipped}, nil
+}
+
+func writeRequestBody(w io.Writer, payload interface{}, gzipped bool) error {
+	var gzipWriter *gzip.Writer
+	if gzipped {
+		gzipWriter = gzip.NewWriter(w)
+		w = gzipWriter
+	}
+	bufferedWriter := bufio.NewWriter(w)
+	if err := writeJSON(bufferedWriter, payload); err != nil {
+		return err
+	}
+	if err := bufferedWriter.Flush(); err != nil {
+		return err
+	}
+	if gzipWriter != nil {
+		return gzipWriter.Close()
+	}
+	return nil
+}
+
+// writeJSON writes the JSON encoding of v into w. Objects and arrays are encoded as they are traversed so the whole encoding
+// is never held in memory; the rest of the values are encoded using json.Marshal. The output matches the json.Marshal
+// output (e,g: object keys are sorted)
+func writeJSON(w io.Writer, v interface{}) error {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if value == nil {
+			return writeJSONValue(w, nil)
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		if _, err := io.WriteString(w, "{"); err != nil {
+			return err
+		}
+		for i, key := range keys {
+			if i > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			if err := writeJSONValue(w, key); err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, ":"); err != nil {
+				return err
+			}
+			if err := writeJSON(w, value[key]); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, "}")
+		return err
+	case []interface{}:
+		if value == nil {
+			return writeJSONValue(w, nil)
+		}
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		for i, item := range value {
+			if i > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			if err := writeJSON(w, item); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, "]")
+		return err
+	default:
+		return writeJSONValue(w, v)
+	}
+}
+
+func writeJSONValue(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// limitedBuffer is a bytes.Buffer that fails with errRequestBodyLimitExceeded if the data written exceeds the limit
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errRequestBodyLimitExceeded
+	}
+	return b.Buffer.Write(p)
+}
+
+// countingWriter discards the data written keeping track of its size
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
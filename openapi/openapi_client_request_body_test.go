@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJSON(t *testing.T) {
+	testCases := []struct {
+		name    string
+		payload interface{}
+	}{
+		{name: "nil payload", payload: nil},
+		{name: "nil map", payload: map[string]interface{}(nil)},
+		{name: "nil list", payload: []interface{}(nil)},
+		{name: "empty map", payload: map[string]interface{}{}},
+		{name: "empty list", payload: []interface{}{}},
+		{
+			name: "map with primitive values",
+			payload: map[string]interface{}{
+				"string_prop": "some value with <html> & \"quotes\"",
+				"int_prop":    12,
+				"float_prop":  12.5,
+				"bool_prop":   true,
+				"nil_prop":    nil,
+			},
+		},
+		{
+			name: "nested objects and lists",
+			payload: map[string]interface{}{
+				"object_prop": map[string]interface{}{
+					"list_prop": []interface{}{
+						map[string]interface{}{"b": 1, "a": 2},
+						"value",
+						[]interface{}{1, 2},
+					},
+				},
+				"typed_list_prop": []string{"a", "b"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		var buffer bytes.Buffer
+		err := writeJSON(&buffer, tc.payload)
+		assert.NoError(t, err, tc.name)
+		expected, _ := json.Marshal(tc.payload)
+		assert.Equal(t, string(expected), buffer.String(), tc.name)
+	}
+}
+
+func TestWriteJSONError(t *testing.T) {
+	var buffer bytes.Buffer
+	err := writeJSON(&buffer, map[string]interface{}{"prop": make(chan int)})
+	assert.EqualError(t, err, "json: unsupported type: chan int")
+}
+
+func TestNewRequestBody(t *testing.T) {
+	smallPayload := map[string]interface{}{"name": "some name"}
+	largePayload := map[string]interface{}{"description": strings.Repeat("a", 2*maxBufferedRequestBodySize)}
+	testCases := []struct {
+		name             string
+		payload          interface{}
+		gzipThreshold    int
+		expectedBuffered bool
+		expectedGzipped  bool
+	}{
+		{name: "small payload without compression", payload: smallPayload, gzipThreshold: -1, expectedBuffered: true, expectedGzipped: false},
+		{name: "small payload smaller than the gzip threshold", payload: smallPayload, gzipThreshold: 1024, expectedBuffered: true, expectedGzipped: false},
+		{name: "small payload bigger than the gzip threshold", payload: smallPayload, gzipThreshold: 10, expectedBuffered: true, expectedGzipped: true},
+		{name: "small payload with zero gzip threshold", payload: smallPayload, gzipThreshold: 0, expectedBuffered: true, expectedGzipped: true},
+		{name: "large payload without compression", payload: largePayload, gzipThreshold: -1, expectedBuffered: false, expectedGzipped: false},
+		{name: "large payload bigger than the gzip threshold", payload: largePayload, gzipThreshold: 1024, expectedBuffered: false, expectedGzipped: true},
+		{name: "large payload bigger than a gzip threshold bigger than the buffer size", payload: largePayload, gzipThreshold: maxBufferedRequestBodySize + 1, expectedBuffered: false, expectedGzipped: true},
+		{name: "large payload smaller than the gzip threshold", payload: largePayload, gzipThreshold: 4 * maxBufferedRequestBodySize, expectedBuffered: false, expectedGzipped: false},
+	}
+	for _, tc := range testCases {
+		body, err := newRequestBody(tc.payload, tc.gzipThreshold)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedGzipped, body.gzipped, tc.name)
+		content, err := ioutil.ReadAll(body.reader)
+		require.NoError(t, err, tc.name)
+		if tc.expectedBuffered {
+			assert.Equal(t, int64(len(content)), body.contentLength, tc.name)
+		} else {
+			assert.Equal(t, int64(-1), body.contentLength, tc.name)
+		}
+		if tc.expectedGzipped {
+			gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+			require.NoError(t, err, tc.name)
+			content, err = ioutil.ReadAll(gzipReader)
+			require.NoError(t, err, tc.name)
+		}
+		expected, _ := json.Marshal(tc.payload)
+		assert.Equal(t, string(expected), string(content), tc.name)
+	}
+}
+
+func TestNewRequestBodyError(t *testing.T) {
+	_, err := newRequestBody(map[string]interface{}{"prop": make(chan int)}, -1)
+	assert.EqualError(t, err, "json: unsupported type: chan int")
+
+	largeInvalidPayload := map[string]interface{}{
+		"description": strings.Repeat("a", 2*maxBufferedRequestBodySize),
+		"prop":        make(chan int),
+	}
+	body, err := newRequestBody(largeInvalidPayload, -1)
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(body.reader)
+	assert.EqualError(t, err, "json: unsupported type: chan int")
+}
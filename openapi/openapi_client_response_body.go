@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+var errResponseBodyTooLarge = errors.New("response body exceeds the max response body size configured")
+
+// responseBodyReader wraps the response body failing with errResponseBodyTooLarge if the body read is bigger than maxSize
+type responseBodyReader struct {
+	body    io.ReadCloser
+	reader  io.Reader
+	read    int64
+	maxSize int64
+}
+
+// newResponseBodyReader returns a reader that fails if the body read is bigger than maxSize; the body is returned as is if
+// maxSize is zero (no limit)
+func newResponseBodyReader(body io.ReadCloser, maxSize int64) io.ReadCloser {
+	if maxSize <= 0 {
+		return body
+	}
+	return &responseBodyReader{body: body, reader: io.LimitReader(body, maxSize+1), maxSize: maxSize}
+}
+
+func (r *responseBodyReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	if r.read > r.maxSize {
+		return n, errResponseBodyTooLarge
+	}
+	return n, err
+}
+
+func (r *responseBodyReader) Close() error {
+	return r.body.Close()
+}
+
+// decodeResponseBody decodes the response body stream into the response payload and closes the body
+func decodeResponseBody(resp *http.Response, responsePayload interface{}) error {
+	defer resp.Body.Close()
+	err := json.NewDecoder(resp.Body).Decode(responsePayload)
+	resp.Body = http.NoBody
+	switch {
+	case err == nil:
+		return nil
+	case err == io.EOF:
+		return errors.New("expected a response body but response body received was empty")
+	case err == errResponseBodyTooLarge:
+		return err
+	default:
+		return fmt.Errorf("unable to unmarshal response body ['%s']", err)
+	}
+}
+
+// bufferResponseBody reads the whole response body (e,g: error messages) so the connection can be reused, replacing the
+// response body with a reader containing the bytes read. The body of the error responses is converted to UTF-8 so the
+// localized error messages (see the accept_language provider property) are passed through as is in the diagnostics
+func bufferResponseBody(resp *http.Response) error {
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		body = decodeResponseBodyCharset(resp, body)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return nil
+}
+
+// decodeResponseBodyCharset returns the body passed in converted to UTF-8 from the charset of the response Content-Type
+// header (e,g: text/plain; charset=ISO-8859-1), updating the header accordingly. The body is returned as is if the charset
+// is not specified, is already UTF-8 or is not supported
+func decodeResponseBodyCharset(resp *http.Response, body []byte) []byte {
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get(contentType))
+	if err != nil || params["charset"] == "" {
+		return body
+	}
+	encoding, err := htmlindex.Get(params["charset"])
+	if err != nil {
+		clientLog.Warn("response body charset '%s' not supported, the response body is passed through as is", params["charset"])
+		return body
+	}
+	if name, _ := htmlindex.Name(encoding); name == "utf-8" {
+		return body
+	}
+	decodedBody, err := encoding.NewDecoder().Bytes(body)
+	if err != nil {
+		clientLog.Warn("failed to decode the response body from the '%s' charset, the response body is passed through as is: %s", params["charset"], err)
+		return body
+	}
+	params["charset"] = "utf-8"
+	resp.Header.Set(contentType, mime.FormatMediaType(mediaType, params))
+	return decodedBody
+}
+
+// newJSONResponse returns a response with the status code and JSON body passed in. This is used by the clients that do not
+// perform the REST API calls (e,g: gRPC backend) so the responses can be handled as any other API response
+func newJSONResponse(statusCode int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Header:        http.Header{contentType: []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+}
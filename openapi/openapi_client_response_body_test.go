@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewResponseBodyReader(t *testing.T) {
+	testCases := []struct {
+		name          string
+		body          string
+		maxSize       int64
+		expectedError error
+	}{
+		{name: "no limit configured", body: "some body", maxSize: 0},
+		{name: "body smaller than the limit", body: "some body", maxSize: 100},
+		{name: "body same size as the limit", body: "some body", maxSize: 9},
+		{name: "body bigger than the limit", body: "some body", maxSize: 8, expectedError: errResponseBodyTooLarge},
+	}
+	for _, tc := range testCases {
+		reader := newResponseBodyReader(ioutil.NopCloser(strings.NewReader(tc.body)), tc.maxSize)
+		body, err := ioutil.ReadAll(reader)
+		if tc.expectedError != nil {
+			assert.Equal(t, tc.expectedError, err, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.body, string(body), tc.name)
+		assert.NoError(t, reader.Close(), tc.name)
+	}
+}
+
+func TestDecodeResponseBody(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		body                    string
+		maxSize                 int64
+		expectedResponsePayload map[string]interface{}
+		expectedError           string
+	}{
+		{
+			name:                    "valid JSON body",
+			body:                    `{"id":"1234","size":1}`,
+			expectedResponsePayload: map[string]interface{}{"id": "1234", "size": float64(1)},
+		},
+		{
+			name:          "empty body",
+			body:          "",
+			expectedError: "expected a response body but response body received was empty",
+		},
+		{
+			name:          "body that is not valid JSON",
+			body:          "not json",
+			expectedError: "unable to unmarshal response body ['invalid character 'o' in literal null (expecting 'u')']",
+		},
+		{
+			name:          "body bigger than the max response body size",
+			body:          `{"id":"1234","size":1}`,
+			maxSize:       10,
+			expectedError: "response body exceeds the max response body size configured",
+		},
+	}
+	for _, tc := range testCases {
+		resp := &http.Response{Body: newResponseBodyReader(ioutil.NopCloser(strings.NewReader(tc.body)), tc.maxSize)}
+		responsePayload := map[string]interface{}{}
+		err := decodeResponseBody(resp, &responsePayload)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedResponsePayload, responsePayload, tc.name)
+		assert.Equal(t, http.NoBody, resp.Body, tc.name)
+	}
+}
+
+func TestBufferResponseBody(t *testing.T) {
+	resp := &http.Response{Body: newResponseBodyReader(ioutil.NopCloser(strings.NewReader("some error")), 100)}
+	assert.NoError(t, bufferResponseBody(resp))
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "some error", string(body))
+
+	resp = &http.Response{Body: newResponseBodyReader(ioutil.NopCloser(strings.NewReader("some error")), 5)}
+	assert.EqualError(t, bufferResponseBody(resp), "response body exceeds the max response body size configured")
+}
+
+func TestBufferResponseBodyCharset(t *testing.T) {
+	testCases := []struct {
+		name                string
+		statusCode          int
+		contentType         string
+		body                string
+		expectedBody        string
+		expectedContentType string
+	}{
+		{name: "error response in ISO-8859-1", statusCode: http.StatusBadRequest, contentType: "text/plain; charset=ISO-8859-1", body: "Ung\xfcltige Gr\xf6\xdfe", expectedBody: "Ungültige Größe", expectedContentType: "text/plain; charset=utf-8"},
+		{name: "error response in windows-1252", statusCode: http.StatusConflict, contentType: "application/json; charset=windows-1252", body: "{\"message\":\"D\xe9j\xe0 existant\"}", expectedBody: `{"message":"Déjà existant"}`, expectedContentType: "application/json; charset=utf-8"},
+		{name: "error response in UTF-8", statusCode: http.StatusBadRequest, contentType: "text/plain; charset=UTF-8", body: "Ungültige Größe", expectedBody: "Ungültige Größe", expectedContentType: "text/plain; charset=UTF-8"},
+		{name: "error response without charset", statusCode: http.StatusBadRequest, contentType: "application/json", body: `{"message":"Ungültige Größe"}`, expectedBody: `{"message":"Ungültige Größe"}`, expectedContentType: "application/json"},
+		{name: "error response with a charset not supported", statusCode: http.StatusBadRequest, contentType: "text/plain; charset=klingon", body: "Ung\xfcltig", expectedBody: "Ung\xfcltig", expectedContentType: "text/plain; charset=klingon"},
+		{name: "successful response is not decoded", statusCode: http.StatusOK, contentType: "text/plain; charset=ISO-8859-1", body: "Gr\xf6\xdfe", expectedBody: "Gr\xf6\xdfe", expectedContentType: "text/plain; charset=ISO-8859-1"},
+	}
+	for _, tc := range testCases {
+		resp := &http.Response{StatusCode: tc.statusCode, Header: http.Header{contentType: []string{tc.contentType}}, Body: ioutil.NopCloser(strings.NewReader(tc.body))}
+		assert.NoError(t, bufferResponseBody(resp), tc.name)
+		body, err := ioutil.ReadAll(resp.Body)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedBody, string(body), tc.name)
+		assert.Equal(t, tc.expectedContentType, resp.Header.Get(contentType), tc.name)
+	}
+}
@@ -0,0 +1,104 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// retryAfterHeader is the response header the APIs specify how long to wait before retrying the request with
+	retryAfterHeader = "Retry-After"
+	// defaultRateLimitMaxRetries defines the max number of retries of the rate limited API calls if not configured
+	defaultRateLimitMaxRetries = 3
+	// defaultRateLimitMaxWait defines the max total wait of the rate limited API calls if not configured
+	defaultRateLimitMaxWait = time.Minute
+)
+
+// rateLimitRetryWait defines the wait before retrying the rate limited API calls whose response does not contain a valid
+// Retry-After header
+var rateLimitRetryWait = time.Second
+
+// rateLimitRetry defines the budget of the retries of the API calls responding 429 Too Many Requests
+type rateLimitRetry struct {
+	maxRetries int
+	maxWait    time.Duration
+}
+
+// newRateLimitRetry returns the rate limit retry budget configured, using the defaults for the values not configured
+func newRateLimitRetry(config *RateLimitRetryConfig) *rateLimitRetry {
+	retry := &rateLimitRetry{maxRetries: defaultRateLimitMaxRetries, maxWait: defaultRateLimitMaxWait}
+	if config == nil {
+		return retry
+	}
+	if config.MaxRetries != nil {
+		retry.maxRetries = *config.MaxRetries
+	}
+	if config.MaxWait != "" {
+		if maxWait, err := time.ParseDuration(config.MaxWait); err == nil {
+			retry.maxWait = maxWait
+		}
+	}
+	return retry
+}
+
+// doRequestWithRateLimitRetry performs the request (see doRequest) retrying it while the API responds 429 Too Many Requests,
+// waiting what the API asks for in the Retry-After header, as long as the retries and the total wait are within the rate
+// limit retry budget. The 429 response is returned as is once the budget is exhausted
+func (o *ProviderClient) doRequestWithRateLimitRetry(httpClient *http.Client, method httpMethodSupported, reqContext *authContext, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	ctx := o.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var waited time.Duration
+	for retry := 0; ; retry++ {
+		resp, err := o.doRequest(httpClient, method, reqContext, requestPayload, responsePayload)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || o.rateLimitRetry == nil {
+			return resp, err
+		}
+		if retry >= o.rateLimitRetry.maxRetries {
+			clientLog.Warn("%s %s is still rate limited after %d retries, giving up", method, reqContext.url, retry)
+			return resp, err
+		}
+		wait, ok := getRetryAfter(resp.Header.Get(retryAfterHeader), time.Now())
+		if !ok {
+			wait = rateLimitRetryWait
+		}
+		if waited+wait > o.rateLimitRetry.maxWait {
+			clientLog.Warn("%s %s is rate limited and the API asks to wait %s, which exceeds the remaining wait budget (%s), giving up", method, reqContext.url, wait, o.rateLimitRetry.maxWait-waited)
+			return resp, err
+		}
+		clientLog.Info("%s %s is rate limited, retrying in %s (retry %d/%d)", method, reqContext.url, wait, retry+1, o.rateLimitRetry.maxRetries)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		waited += wait
+	}
+}
+
+// getRetryAfter returns the wait specified in the Retry-After header value passed in, which is either a number of seconds
+// or an HTTP date (in which case the wait is relative to the now passed in). False is returned if the value is not valid
+func getRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRetryAfter(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name         string
+		value        string
+		expectedWait time.Duration
+		expectedOK   bool
+	}{
+		{name: "seconds", value: "120", expectedWait: 2 * time.Minute, expectedOK: true},
+		{name: "zero seconds", value: "0", expectedWait: 0, expectedOK: true},
+		{name: "http date", value: "Wed, 01 Jun 2022 10:00:30 GMT", expectedWait: 30 * time.Second, expectedOK: true},
+		{name: "http date in the past", value: "Wed, 01 Jun 2022 09:59:00 GMT", expectedWait: 0, expectedOK: true},
+		{name: "missing", value: ""},
+		{name: "negative seconds", value: "-1"},
+		{name: "not valid", value: "soon"},
+	}
+	for _, tc := range testCases {
+		wait, ok := getRetryAfter(tc.value, now)
+		assert.Equal(t, tc.expectedOK, ok, tc.name)
+		assert.Equal(t, tc.expectedWait, wait, tc.name)
+	}
+}
+
+func TestNewRateLimitRetry(t *testing.T) {
+	assert.Equal(t, &rateLimitRetry{maxRetries: 3, maxWait: time.Minute}, newRateLimitRetry(nil))
+	maxRetries := 0
+	assert.Equal(t, &rateLimitRetry{maxRetries: 0, maxWait: time.Minute}, newRateLimitRetry(&RateLimitRetryConfig{MaxRetries: &maxRetries}))
+	assert.Equal(t, &rateLimitRetry{maxRetries: 3, maxWait: 5 * time.Minute}, newRateLimitRetry(&RateLimitRetryConfig{MaxWait: "5m"}))
+}
+
+func TestProviderClientRateLimitRetry(t *testing.T) {
+	defer func(wait time.Duration) { rateLimitRetryWait = wait }(rateLimitRetryWait)
+	rateLimitRetryWait = time.Millisecond
+
+	testCases := []struct {
+		name               string
+		rateLimitRetry     *rateLimitRetry
+		retryAfter         []string
+		expectedStatusCode int
+		expectedCalls      int
+	}{
+		{name: "retried after the wait the API asks for", rateLimitRetry: &rateLimitRetry{maxRetries: 3, maxWait: time.Minute}, retryAfter: []string{"0", "0"}, expectedStatusCode: http.StatusCreated, expectedCalls: 3},
+		{name: "retried if the API does not specify the wait", rateLimitRetry: &rateLimitRetry{maxRetries: 3, maxWait: time.Minute}, retryAfter: []string{""}, expectedStatusCode: http.StatusCreated, expectedCalls: 2},
+		{name: "max retries exhausted", rateLimitRetry: &rateLimitRetry{maxRetries: 2, maxWait: time.Minute}, retryAfter: []string{"0", "0", "0"}, expectedStatusCode: http.StatusTooManyRequests, expectedCalls: 3},
+		{name: "wait asked exceeding the wait budget", rateLimitRetry: &rateLimitRetry{maxRetries: 3, maxWait: time.Minute}, retryAfter: []string{"120"}, expectedStatusCode: http.StatusTooManyRequests, expectedCalls: 1},
+		{name: "retries disabled", rateLimitRetry: nil, retryAfter: []string{"0"}, expectedStatusCode: http.StatusTooManyRequests, expectedCalls: 1},
+	}
+	for _, tc := range testCases {
+		calls := 0
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls <= len(tc.retryAfter) {
+				if tc.retryAfter[calls-1] != "" {
+					w.Header().Set(retryAfterHeader, tc.retryAfter[calls-1])
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"1234"}`))
+		}))
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newAPIAuthenticator(nil),
+			rateLimitRetry:              tc.rateLimitRetry,
+		}
+		resource := &specStubResource{name: "cdns_v1", path: "/v1/cdns", resourcePostOperation: &specResourceOperation{}}
+		responsePayload := map[string]interface{}{}
+		resp, err := providerClient.Post(resource, map[string]interface{}{"label": "some label"}, &responsePayload)
+		api.Close()
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedStatusCode, resp.StatusCode, tc.name)
+		assert.Equal(t, tc.expectedCalls, calls, tc.name)
+		if tc.expectedStatusCode == http.StatusCreated {
+			assert.Equal(t, map[string]interface{}{"id": "1234"}, responsePayload, tc.name)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultServiceDiscoveryTTL defines how long the host resolved is used if the service discovery configuration does not
+// configure the ttl
+const defaultServiceDiscoveryTTL = 60 * time.Second
+
+// srvHostResolver resolves the API host from a DNS SRV record. The host resolved is cached for the TTL configured so the
+// SRV record is not resolved on every API call; once the TTL expires the record is resolved again, which allows the API
+// calls to follow the changes in the service discovery records during long running applies
+type srvHostResolver struct {
+	name string
+	ttl  time.Duration
+	// lookupSRV resolves the SRV record; the resolver passed in when the srvHostResolver is created is used by default
+	lookupSRV func(service, proto, name string) (string, []*net.SRV, error)
+
+	mutex     sync.Mutex
+	host      string
+	expiresAt time.Time
+}
+
+func newSRVHostResolver(serviceDiscoveryConfig *ServiceDiscoveryConfig, resolver *net.Resolver) *srvHostResolver {
+	ttl := defaultServiceDiscoveryTTL
+	if serviceDiscoveryConfig.TTL != "" {
+		ttl, _ = time.ParseDuration(serviceDiscoveryConfig.TTL)
+	}
+	return &srvHostResolver{
+		name: serviceDiscoveryConfig.SRV,
+		ttl:  ttl,
+		lookupSRV: func(service, proto, name string) (string, []*net.SRV, error) {
+			return resolver.LookupSRV(context.Background(), service, proto, name)
+		},
+	}
+}
+
+// getHost returns the host (including the port) resolved from the SRV record. The record with the lowest priority is
+// selected, records with the same priority are selected randomly by weight (RFC 2782). If the record can not be resolved
+// again once the TTL expires, the host previously resolved is kept
+func (r *srvHostResolver) getHost() (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.host != "" && time.Now().Before(r.expiresAt) {
+		return r.host, nil
+	}
+	host, err := r.resolve()
+	if err != nil {
+		if r.host != "" {
+			clientLog.Warn("failed to resolve the SRV record '%s' again, API calls will keep being made against '%s': %s", r.name, r.host, err)
+			r.expiresAt = time.Now().Add(r.ttl)
+			return r.host, nil
+		}
+		return "", err
+	}
+	if host != r.host {
+		clientLog.Info("SRV record '%s' resolved to '%s'", r.name, host)
+	}
+	r.host = host
+	r.expiresAt = time.Now().Add(r.ttl)
+	return r.host, nil
+}
+
+func (r *srvHostResolver) resolve() (string, error) {
+	_, records, err := r.lookupSRV("", "", r.name)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the SRV record '%s': %s", r.name, err)
+	}
+	if len(records) == 0 {
+		return "", fmt.Errorf("failed to resolve the SRV record '%s': no records found", r.name)
+	}
+	// the resolver returns the records sorted by priority and randomized by weight within a priority
+	target := strings.TrimSuffix(records[0].Target, ".")
+	return net.JoinHostPort(target, fmt.Sprintf("%d", records[0].Port)), nil
+}
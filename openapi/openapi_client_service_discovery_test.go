@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSRVHostResolver(t *testing.T) {
+	resolver := newSRVHostResolver(&ServiceDiscoveryConfig{SRV: "_api._tcp.example.internal"}, net.DefaultResolver)
+	assert.Equal(t, "_api._tcp.example.internal", resolver.name)
+	assert.Equal(t, defaultServiceDiscoveryTTL, resolver.ttl)
+
+	resolver = newSRVHostResolver(&ServiceDiscoveryConfig{SRV: "_api._tcp.example.internal", TTL: "5m"}, net.DefaultResolver)
+	assert.Equal(t, 5*time.Minute, resolver.ttl)
+}
+
+func TestSRVHostResolverGetHost(t *testing.T) {
+	var lookups int
+	var lookupErr error
+	target := "api-1.example.internal."
+	resolver := newSRVHostResolver(&ServiceDiscoveryConfig{SRV: "_api._tcp.example.internal", TTL: "1m"}, net.DefaultResolver)
+	resolver.lookupSRV = func(service, proto, name string) (string, []*net.SRV, error) {
+		lookups++
+		assert.Equal(t, "_api._tcp.example.internal", name)
+		if lookupErr != nil {
+			return "", nil, lookupErr
+		}
+		return "", []*net.SRV{{Target: target, Port: 8443, Priority: 10, Weight: 5}, {Target: "api-2.example.internal.", Port: 8443, Priority: 20}}, nil
+	}
+
+	host, err := resolver.getHost()
+	require.NoError(t, err)
+	assert.Equal(t, "api-1.example.internal:8443", host)
+
+	// the host resolved is cached until the TTL expires
+	target = "api-3.example.internal."
+	host, err = resolver.getHost()
+	require.NoError(t, err)
+	assert.Equal(t, "api-1.example.internal:8443", host)
+	assert.Equal(t, 1, lookups)
+
+	resolver.expiresAt = time.Now().Add(-time.Second)
+	host, err = resolver.getHost()
+	require.NoError(t, err)
+	assert.Equal(t, "api-3.example.internal:8443", host)
+	assert.Equal(t, 2, lookups)
+
+	// the host previously resolved is kept if the record can not be resolved again
+	resolver.expiresAt = time.Now().Add(-time.Second)
+	lookupErr = errors.New("no such host")
+	host, err = resolver.getHost()
+	require.NoError(t, err)
+	assert.Equal(t, "api-3.example.internal:8443", host)
+	assert.True(t, resolver.expiresAt.After(time.Now()))
+}
+
+func TestSRVHostResolverGetHostErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		records       []*net.SRV
+		lookupErr     error
+		expectedError string
+	}{
+		{name: "lookup fails", lookupErr: errors.New("no such host"), expectedError: "failed to resolve the SRV record '_api._tcp.example.internal': no such host"},
+		{name: "no records found", records: []*net.SRV{}, expectedError: "failed to resolve the SRV record '_api._tcp.example.internal': no records found"},
+	}
+	for _, tc := range testCases {
+		resolver := newSRVHostResolver(&ServiceDiscoveryConfig{SRV: "_api._tcp.example.internal"}, net.DefaultResolver)
+		resolver.lookupSRV = func(service, proto, name string) (string, []*net.SRV, error) {
+			return "", tc.records, tc.lookupErr
+		}
+		_, err := resolver.getHost()
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
+
+func TestGetProviderHostServiceDiscovery(t *testing.T) {
+	resolver := newSRVHostResolver(&ServiceDiscoveryConfig{SRV: "_api._tcp.example.internal"}, net.DefaultResolver)
+	resolver.lookupSRV = func(service, proto, name string) (string, []*net.SRV, error) {
+		return "", []*net.SRV{{Target: "api-1.example.internal.", Port: 443}}, nil
+	}
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration("api.example.com", "", "https"),
+		srvHostResolver:             resolver,
+	}
+	host, region, err := providerClient.GetProviderHost()
+	require.NoError(t, err)
+	assert.Equal(t, "api-1.example.internal:443", host)
+	assert.Empty(t, region)
+
+	// the host configured in the provider (e,g: selected profile host) takes preference
+	providerClient.providerConfiguration = providerConfiguration{Host: "api.staging.example.com"}
+	host, _, err = providerClient.GetProviderHost()
+	require.NoError(t, err)
+	assert.Equal(t, "api.staging.example.com", host)
+}
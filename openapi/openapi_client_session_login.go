@@ -0,0 +1,186 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const cookieHeader = "Cookie"
+
+// sessionLoginRequestTimeout defines the max time the requests to the login endpoint are allowed to take
+const sessionLoginRequestTimeout = 30 * time.Second
+
+// sessionExpiryMargin defines how long before their expiry the sessions are renewed, so the session tokens sent to the API
+// do not expire in flight
+const sessionExpiryMargin = 30 * time.Second
+
+// apiSession authenticates the API calls with the session token returned by the login endpoint the credentials are posted
+// to (x-terraform-provider-session-login). The login is performed on the first API call and the session is renewed
+// (logging in again) shortly before it expires, if the expiry is known, or as soon as the API rejects the session token.
+// The session is shared by all the API calls made by the provider
+type apiSession struct {
+	sessionLogin *specSessionLogin
+	// loginURL returns the URL of the login endpoint; the URL is resolved on login so the provider host selected at
+	// the time (e,g: via service discovery) is used
+	loginURL func() (string, error)
+	// credentials contains the values of the login request body fields
+	credentials map[string]string
+	httpClient  *http.Client
+	now         func() time.Time
+
+	mutex     sync.Mutex
+	token     string
+	expiresAt time.Time
+	// loggedInAt is the (wall clock) time the session was established at
+	loggedInAt time.Time
+}
+
+func newAPISession(sessionLogin *specSessionLogin, loginURL func() (string, error), credentials map[string]string) *apiSession {
+	return &apiSession{
+		sessionLogin: sessionLogin,
+		loginURL:     loginURL,
+		credentials:  credentials,
+		httpClient:   &http.Client{Timeout: sessionLoginRequestTimeout},
+		now:          time.Now,
+	}
+}
+
+// prepareAuth adds the session token to the request headers, logging in if there is no session yet or the session is
+// about to expire. Cookie sessions are sent in the Cookie header along with any other cookie already in the request
+func (s *apiSession) prepareAuth(authContext *authContext) error {
+	token, err := s.getToken()
+	if err != nil {
+		return err
+	}
+	if authContext.headers == nil {
+		authContext.headers = map[string]string{}
+	}
+	if s.sessionLogin.tokenCookie != "" {
+		cookie := (&http.Cookie{Name: s.sessionLogin.tokenCookie, Value: token}).String()
+		if cookies := authContext.headers[cookieHeader]; cookies != "" {
+			cookie = fmt.Sprintf("%s; %s", cookies, cookie)
+		}
+		authContext.headers[cookieHeader] = cookie
+		return nil
+	}
+	authContext.headers[s.sessionLogin.tokenHeader] = fmt.Sprintf(s.sessionLogin.tokenHeaderTemplate, token)
+	return nil
+}
+
+// getToken returns the session token, logging in if there is no session yet or the session is about to expire
+func (s *apiSession) getToken() (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.token != "" && (s.expiresAt.IsZero() || s.now().Before(s.expiresAt.Add(-sessionExpiryMargin))) {
+		return s.token, nil
+	}
+	token, expiresAt, err := s.login()
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	s.expiresAt = expiresAt
+	s.loggedInAt = time.Now()
+	return s.token, nil
+}
+
+// invalidate drops the session if it was established before the time passed in (the time the request rejected by the
+// API was prepared at), so the next API call logs in again. Sessions established afterwards (e,g: renewed by a concurrent
+// API call) are kept
+func (s *apiSession) invalidate(requestedAt time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.token != "" && !s.loggedInAt.After(requestedAt) {
+		s.token = ""
+	}
+}
+
+// login posts the credentials to the login endpoint and returns the session token along with its expiry time (zero if
+// unknown)
+func (s *apiSession) login() (string, time.Time, error) {
+	loginURL, err := s.loginURL()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to log in to the API: %s", err)
+	}
+	body, err := json.Marshal(s.credentials)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req, err := http.NewRequest(http.MethodPost, loginURL, bytes.NewReader(body))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set(contentType, "application/json")
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to log in to the API: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", time.Time{}, fmt.Errorf("failed to log in to the API: POST %s responded with status code '%d'", loginURL, resp.StatusCode)
+	}
+	clientLog.Debug("logged in to the API (POST %s)", loginURL)
+	if s.sessionLogin.tokenCookie != "" {
+		return s.getSessionCookie(resp)
+	}
+	responseBody := map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&responseBody); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to log in to the API: login response not valid: %s", err)
+	}
+	tokenValue, _ := getPayloadValue(responseBody, s.sessionLogin.tokenPath)
+	token, _ := tokenValue.(string)
+	if token == "" {
+		return "", time.Time{}, fmt.Errorf("failed to log in to the API: login response is missing the session token field '%s'", s.sessionLogin.tokenPath)
+	}
+	expiresAt := getJWTExpiry(token)
+	if s.sessionLogin.expiresInPath != "" {
+		expiresInValue, _ := getPayloadValue(responseBody, s.sessionLogin.expiresInPath)
+		expiresIn, err := strconv.ParseFloat(fmt.Sprintf("%v", expiresInValue), 64)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to log in to the API: login response session expiry field '%s' is not a number", s.sessionLogin.expiresInPath)
+		}
+		expiresAt = s.now().Add(time.Duration(expiresIn * float64(time.Second)))
+	}
+	return token, expiresAt, nil
+}
+
+// getSessionCookie returns the value of the session cookie set by the login response along with its expiry time (zero if
+// the cookie does not expire)
+func (s *apiSession) getSessionCookie(resp *http.Response) (string, time.Time, error) {
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name != s.sessionLogin.tokenCookie || cookie.Value == "" {
+			continue
+		}
+		var expiresAt time.Time
+		if cookie.MaxAge > 0 {
+			expiresAt = s.now().Add(time.Duration(cookie.MaxAge) * time.Second)
+		} else if !cookie.Expires.IsZero() {
+			expiresAt = cookie.Expires
+		}
+		return cookie.Value, expiresAt, nil
+	}
+	return "", time.Time{}, fmt.Errorf("failed to log in to the API: login response is missing the session cookie '%s'", s.sessionLogin.tokenCookie)
+}
+
+// getEndpointURL returns the URL of the endpoint path passed in (e,g: the session login or health endpoints), resolved
+// against the provider host and base path. Absolute URLs (e,g: endpoints served by a different host) are returned as is
+func (o *ProviderClient) getEndpointURL(path string) (string, error) {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path, nil
+	}
+	host, _, err := o.GetProviderHost()
+	if err != nil {
+		return "", err
+	}
+	scheme, err := o.openAPIBackendConfiguration.getHTTPScheme()
+	if err != nil {
+		return "", err
+	}
+	return buildURL(scheme, host, o.openAPIBackendConfiguration.getBasePath(), path), nil
+}
@@ -0,0 +1,199 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sessionAPIStub fakes an API authenticating the API calls with the session tokens issued by its login endpoint
+type sessionAPIStub struct {
+	*httptest.Server
+	requests []string
+	// sessions contains the session tokens issued which are still valid
+	sessions      map[string]bool
+	logins        int
+	loginResponse func(w http.ResponseWriter, token string)
+}
+
+func newSessionAPIStub(t *testing.T) *sessionAPIStub {
+	a := &sessionAPIStub{sessions: map[string]bool{}}
+	a.loginResponse = func(w http.ResponseWriter, token string) {
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"session": map[string]interface{}{"token": token, "expires_in": 3600}}))
+	}
+	a.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.requests = append(a.requests, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/api/login":
+			credentials := map[string]string{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&credentials))
+			if credentials["user"] != "admin" || credentials["password"] != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			a.logins++
+			token := fmt.Sprintf("session-%d", a.logins)
+			a.sessions[token] = true
+			a.loginResponse(w, token)
+		case "/api/v1/resource/1234":
+			token := strings.TrimPrefix(r.Header.Get(authorizationHeader), "Bearer ")
+			if cookie, err := r.Cookie("SESSIONID"); err == nil {
+				token = cookie.Value
+			}
+			if !a.sessions[token] {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"id": "1234", "session": token}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return a
+}
+
+func newSessionTestProviderClient(api *sessionAPIStub, sessionLogin *specSessionLogin, credentials map[string]string) *ProviderClient {
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "/api", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("X-Request-Source", "terraform", nil),
+	}
+	providerClient.session = newAPISession(sessionLogin, func() (string, error) { return providerClient.getEndpointURL(sessionLogin.path) }, credentials)
+	return providerClient
+}
+
+func TestProviderClientSessionLogin(t *testing.T) {
+	sessionLogin := &specSessionLogin{
+		path:                "/login",
+		credentials:         map[string]string{"user": "username", "password": "password"},
+		tokenPath:           "session.token",
+		tokenHeader:         authorizationHeader,
+		tokenHeaderTemplate: "Bearer %s",
+		expiresInPath:       "session.expires_in",
+	}
+	resource := &specStubResource{path: "/v1/resource", resourceGetOperation: &specResourceOperation{}}
+
+	t.Run("session token read from the response body and reused by the following API calls", func(t *testing.T) {
+		api := newSessionAPIStub(t)
+		defer api.Close()
+		providerClient := newSessionTestProviderClient(api, sessionLogin, map[string]string{"user": "admin", "password": "secret"})
+		for i := 0; i < 2; i++ {
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.Get(resource, "1234", &responsePayload)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "session-1", responsePayload["session"])
+		}
+		assert.Equal(t, []string{"POST /api/login", "GET /api/v1/resource/1234", "GET /api/v1/resource/1234"}, api.requests)
+	})
+
+	t.Run("session renewed before it expires", func(t *testing.T) {
+		api := newSessionAPIStub(t)
+		defer api.Close()
+		providerClient := newSessionTestProviderClient(api, sessionLogin, map[string]string{"user": "admin", "password": "secret"})
+		now := time.Now()
+		providerClient.session.now = func() time.Time { return now }
+		_, err := providerClient.Get(resource, "1234", &map[string]interface{}{})
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(time.Hour), providerClient.session.expiresAt)
+
+		now = now.Add(time.Hour - sessionExpiryMargin)
+		responsePayload := map[string]interface{}{}
+		_, err = providerClient.Get(resource, "1234", &responsePayload)
+		require.NoError(t, err)
+		assert.Equal(t, "session-2", responsePayload["session"])
+		assert.Equal(t, []string{"POST /api/login", "GET /api/v1/resource/1234", "POST /api/login", "GET /api/v1/resource/1234"}, api.requests)
+	})
+
+	t.Run("session renewed and request retried once the API rejects the session", func(t *testing.T) {
+		api := newSessionAPIStub(t)
+		defer api.Close()
+		providerClient := newSessionTestProviderClient(api, sessionLogin, map[string]string{"user": "admin", "password": "secret"})
+		_, err := providerClient.Get(resource, "1234", &map[string]interface{}{})
+		require.NoError(t, err)
+		delete(api.sessions, "session-1")
+
+		responsePayload := map[string]interface{}{}
+		resp, err := providerClient.Get(resource, "1234", &responsePayload)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "session-2", responsePayload["session"])
+		assert.Equal(t, []string{"POST /api/login", "GET /api/v1/resource/1234", "GET /api/v1/resource/1234", "POST /api/login", "GET /api/v1/resource/1234"}, api.requests)
+	})
+
+	t.Run("session cookie", func(t *testing.T) {
+		api := newSessionAPIStub(t)
+		defer api.Close()
+		api.loginResponse = func(w http.ResponseWriter, token string) {
+			http.SetCookie(w, &http.Cookie{Name: "SESSIONID", Value: token, MaxAge: 600})
+		}
+		cookieSessionLogin := &specSessionLogin{path: "/login", credentials: map[string]string{"user": "username", "password": "password"}, tokenCookie: "SESSIONID", tokenHeaderTemplate: "%s"}
+		providerClient := newSessionTestProviderClient(api, cookieSessionLogin, map[string]string{"user": "admin", "password": "secret"})
+		now := time.Now()
+		providerClient.session.now = func() time.Time { return now }
+		responsePayload := map[string]interface{}{}
+		_, err := providerClient.Get(resource, "1234", &responsePayload)
+		require.NoError(t, err)
+		assert.Equal(t, "session-1", responsePayload["session"])
+		assert.Equal(t, now.Add(10*time.Minute), providerClient.session.expiresAt)
+	})
+
+	t.Run("login fails", func(t *testing.T) {
+		api := newSessionAPIStub(t)
+		defer api.Close()
+		providerClient := newSessionTestProviderClient(api, sessionLogin, map[string]string{"user": "admin", "password": "wrong"})
+		_, err := providerClient.Get(resource, "1234", &map[string]interface{}{})
+		assert.EqualError(t, err, fmt.Sprintf("failed to configure the API request for GET %[1]s/api/v1/resource/1234: failed to log in to the API: POST %[1]s/api/login responded with status code '401'", api.URL))
+	})
+
+	t.Run("login response missing the session token", func(t *testing.T) {
+		api := newSessionAPIStub(t)
+		defer api.Close()
+		api.loginResponse = func(w http.ResponseWriter, token string) { w.Write([]byte(`{"session":{}}`)) }
+		providerClient := newSessionTestProviderClient(api, sessionLogin, map[string]string{"user": "admin", "password": "secret"})
+		_, err := providerClient.Get(resource, "1234", &map[string]interface{}{})
+		assert.EqualError(t, err, fmt.Sprintf("failed to configure the API request for GET %s/api/v1/resource/1234: failed to log in to the API: login response is missing the session token field 'session.token'", api.URL))
+	})
+}
+
+func TestAPISessionPrepareAuth(t *testing.T) {
+	session := newAPISession(&specSessionLogin{tokenCookie: "SESSIONID"}, nil, nil)
+	session.token = "session-1"
+	ctx := &authContext{headers: map[string]string{cookieHeader: "apikey=secret"}}
+	assert.NoError(t, session.prepareAuth(ctx))
+	assert.Equal(t, "apikey=secret; SESSIONID=session-1", ctx.headers[cookieHeader], "the session cookie should be appended to the cookies already in the request")
+
+	session = newAPISession(&specSessionLogin{tokenPath: "token", tokenHeader: "X-Session-Token", tokenHeaderTemplate: "%s"}, nil, nil)
+	session.token = "session-1"
+	ctx = &authContext{}
+	assert.NoError(t, session.prepareAuth(ctx))
+	assert.Equal(t, map[string]string{"X-Session-Token": "session-1"}, ctx.headers)
+}
+
+func TestAPISessionInvalidate(t *testing.T) {
+	session := newAPISession(&specSessionLogin{}, nil, nil)
+	session.token = "session-1"
+	session.loggedInAt = time.Now()
+	session.invalidate(session.loggedInAt.Add(-time.Second))
+	assert.Equal(t, "session-1", session.token, "sessions established after the rejected request was prepared should be kept")
+	session.invalidate(session.loggedInAt)
+	assert.Empty(t, session.token)
+}
+
+func TestGetEndpointURL(t *testing.T) {
+	providerClient := &ProviderClient{openAPIBackendConfiguration: newStubBackendConfiguration("api.example.com", "/v1", "https")}
+	loginURL, err := providerClient.getEndpointURL("login")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.example.com/v1/login", loginURL)
+	loginURL, err = providerClient.getEndpointURL("https://auth.example.com/login")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://auth.example.com/login", loginURL)
+}
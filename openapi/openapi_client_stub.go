@@ -1,6 +1,8 @@
 package openapi
 
 import (
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -14,9 +16,70 @@ type clientOpenAPIStub struct {
 	returnHTTPCode      int
 	idReceived          string
 	parentIDsReceived   []string
+	getCalls            int
+	listCalls           int
+	getFieldsCalls      int
+	fieldsReceived      []string
+	eTagReceived        string
+	responseETag        string
 	telemetryHandler    TelemetryHandler
+	host                string
+	region              string
+	// subResourceRequests contains the sub resource requests received (e,g: POST tags, DELETE tags/env) in order
+	subResourceRequests []string
+	// subResourcePayloads contains the payloads of the sub resource POST requests received in order
+	subResourcePayloads []interface{}
+	// pollRequestsReceived contains the poll requests received in order
+	pollRequestsReceived []*specPollRequest
+	// pollResponsePayloads contains the response payloads returned by the poll requests in order; the last one is returned
+	// once all have been returned
+	pollResponsePayloads []map[string]interface{}
+	// prechecksReceived contains the precheck requests received in order
+	prechecksReceived []*specPrecheck
+	// precheckResponsePayload contains the response payload returned by the precheck requests
+	precheckResponsePayload map[string]interface{}
+	// lockRequestsReceived contains the lock requests received (e,g: POST /v1/projects/{project_id}/lock) in order
+	lockRequestsReceived []string
+	// lockIDsReceived contains the ids the lock paths are resolved with, as received by the last lock request
+	lockIDsReceived []string
+	// funcAcquireLock overrides the response of the acquire lock requests
+	funcAcquireLock func() (*http.Response, error)
+	// optionsRequestsReceived contains the paths (including the query parameters) of the options requests received in order
+	optionsRequestsReceived []string
+	// lookupPathsReceived contains the paths of the lookup requests received in order
+	lookupPathsReceived []string
+	// lookupResponsePayload contains the response payload returned by the lookup requests
+	lookupResponsePayload interface{}
+	// uploadRequestsReceived contains the paths of the upload requests received in order
+	uploadRequestsReceived []string
+	// uploadRequestPayloads contains the payloads of the upload requests received in order
+	uploadRequestPayloads []interface{}
+	// uploadResponsePayload contains the response payload returned by the upload requests
+	uploadResponsePayload map[string]interface{}
+	// uploadsReceived contains the URLs the content was uploaded to along with the content uploaded (e,g: PUT
+	// https://storage/object content) in order
+	uploadsReceived []string
+	// responseListHeader contains the headers returned by the list requests (e,g: pagination headers)
+	responseListHeader http.Header
+	// countRequestsReceived contains the paths (including the query parameters) of the count requests received in order
+	countRequestsReceived []string
+	// getResponsePayloads contains the response payloads returned by the get requests in order, overriding the responsePayload;
+	// the last one is returned once all have been returned
+	getResponsePayloads []map[string]interface{}
+	// putPayloadReceived contains the request payload received by the last PUT request
+	putPayloadReceived interface{}
+	// postResponseHeader contains the headers returned by the POST requests (e,g: Location header)
+	postResponseHeader http.Header
+	// locationsReceived contains the locations polled in order
+	locationsReceived []string
+	// locationResponseCodes contains the status codes returned by the location poll requests in order; the last one is
+	// returned once all have been returned
+	locationResponseCodes []int
+	// locationResponsePayload contains the response payload returned by the location poll requests
+	locationResponsePayload map[string]interface{}
 
 	funcPut func() (*http.Response, error)
+	funcGet func() (*http.Response, error)
 }
 
 func (c *clientOpenAPIStub) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
@@ -30,10 +93,13 @@ func (c *clientOpenAPIStub) Post(resource SpecResource, requestPayload interface
 	default:
 		panic("unexpected type")
 	}
-	return c.generateStubResponse(http.StatusCreated), nil
+	resp := c.generateStubResponse(http.StatusCreated)
+	resp.Header = c.postResponseHeader
+	return resp, nil
 }
 
 func (c *clientOpenAPIStub) Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	c.putPayloadReceived = requestPayload
 	if c.funcPut != nil {
 		return c.funcPut()
 	}
@@ -52,6 +118,10 @@ func (c *clientOpenAPIStub) Put(resource SpecResource, id string, requestPayload
 }
 
 func (c *clientOpenAPIStub) Get(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	c.getCalls++
+	if c.funcGet != nil {
+		return c.funcGet()
+	}
 	if c.error != nil {
 		return nil, c.error
 	}
@@ -60,6 +130,12 @@ func (c *clientOpenAPIStub) Get(resource SpecResource, id string, responsePayloa
 	switch p := responsePayload.(type) {
 	case *map[string]interface{}:
 		*p = c.responsePayload
+		if len(c.getResponsePayloads) > 0 {
+			*p = c.getResponsePayloads[0]
+			if len(c.getResponsePayloads) > 1 {
+				c.getResponsePayloads = c.getResponsePayloads[1:]
+			}
+		}
 	default:
 		panic("unexpected type")
 	}
@@ -67,7 +143,55 @@ func (c *clientOpenAPIStub) Get(resource SpecResource, id string, responsePayloa
 	return c.generateStubResponse(http.StatusOK), nil
 }
 
+func (c *clientOpenAPIStub) GetIfNoneMatch(resource SpecResource, id string, eTag string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	c.getCalls++
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.idReceived = id
+	c.eTagReceived = eTag
+	c.parentIDsReceived = parentIDs
+	resp := c.generateStubResponse(http.StatusOK)
+	if c.responseETag != "" {
+		resp.Header = http.Header{}
+		resp.Header.Set(eTagHeader, c.responseETag)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+	switch p := responsePayload.(type) {
+	case *map[string]interface{}:
+		*p = c.responsePayload
+	default:
+		panic("unexpected type")
+	}
+	return resp, nil
+}
+
+func (c *clientOpenAPIStub) GetFields(resource SpecResource, id string, fields []string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	c.getFieldsCalls++
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.idReceived = id
+	c.fieldsReceived = fields
+	c.parentIDsReceived = parentIDs
+	switch p := responsePayload.(type) {
+	case *map[string]interface{}:
+		*p = map[string]interface{}{}
+		for _, field := range fields {
+			if value, exists := c.responsePayload[field]; exists {
+				(*p)[field] = value
+			}
+		}
+	default:
+		panic("unexpected type")
+	}
+	return c.generateStubResponse(http.StatusOK), nil
+}
+
 func (c *clientOpenAPIStub) List(resource SpecResource, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	c.listCalls++
 	if c.error != nil {
 		return nil, c.error
 	}
@@ -79,7 +203,9 @@ func (c *clientOpenAPIStub) List(resource SpecResource, responsePayload interfac
 		panic("unexpected type")
 	}
 
-	return c.generateStubResponse(http.StatusOK), nil
+	resp := c.generateStubResponse(http.StatusOK)
+	resp.Header = c.responseListHeader
+	return resp, nil
 }
 
 func (c *clientOpenAPIStub) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
@@ -92,10 +218,179 @@ func (c *clientOpenAPIStub) Delete(resource SpecResource, id string, parentIDs .
 	return c.generateStubResponse(http.StatusNoContent), nil
 }
 
+func (c *clientOpenAPIStub) PostSubResource(resource SpecResource, id string, subResourcePath string, requestPayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.subResourceRequests = append(c.subResourceRequests, "POST "+subResourcePath)
+	c.subResourcePayloads = append(c.subResourcePayloads, requestPayload)
+	return c.generateStubResponse(http.StatusCreated), nil
+}
+
+func (c *clientOpenAPIStub) DeleteSubResource(resource SpecResource, id string, subResourcePath string, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.subResourceRequests = append(c.subResourceRequests, "DELETE "+subResourcePath)
+	return c.generateStubResponse(http.StatusNoContent), nil
+}
+
+func (c *clientOpenAPIStub) Poll(resource SpecResource, id string, pollRequest *specPollRequest, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.idReceived = id
+	c.parentIDsReceived = parentIDs
+	c.pollRequestsReceived = append(c.pollRequestsReceived, pollRequest)
+	switch p := responsePayload.(type) {
+	case *map[string]interface{}:
+		*p = c.pollResponsePayloads[len(c.pollResponsePayloads)-1]
+		if len(c.pollRequestsReceived) <= len(c.pollResponsePayloads) {
+			*p = c.pollResponsePayloads[len(c.pollRequestsReceived)-1]
+		}
+	default:
+		panic("unexpected type")
+	}
+	return c.generateStubResponse(http.StatusOK), nil
+}
+
+func (c *clientOpenAPIStub) Precheck(resource SpecResource, precheck *specPrecheck, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.parentIDsReceived = parentIDs
+	c.prechecksReceived = append(c.prechecksReceived, precheck)
+	switch p := responsePayload.(type) {
+	case *map[string]interface{}:
+		*p = c.precheckResponsePayload
+	default:
+		panic("unexpected type")
+	}
+	return c.generateStubResponse(http.StatusOK), nil
+}
+
+func (c *clientOpenAPIStub) AcquireLock(resource SpecResource, lock *specLock, ids ...string) (*http.Response, error) {
+	c.lockRequestsReceived = append(c.lockRequestsReceived, "POST "+lock.path)
+	c.lockIDsReceived = ids
+	if c.funcAcquireLock != nil {
+		return c.funcAcquireLock()
+	}
+	if c.error != nil {
+		return nil, c.error
+	}
+	return c.generateStubResponse(http.StatusCreated), nil
+}
+
+func (c *clientOpenAPIStub) ReleaseLock(resource SpecResource, lock *specLock, ids ...string) (*http.Response, error) {
+	c.lockRequestsReceived = append(c.lockRequestsReceived, "DELETE "+lock.path)
+	c.lockIDsReceived = ids
+	return c.generateStubResponse(http.StatusNoContent), nil
+}
+
+func (c *clientOpenAPIStub) Lookup(resource SpecResource, lookupPath string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.parentIDsReceived = parentIDs
+	c.lookupPathsReceived = append(c.lookupPathsReceived, lookupPath)
+	switch p := responsePayload.(type) {
+	case *interface{}:
+		*p = c.lookupResponsePayload
+	default:
+		panic("unexpected type")
+	}
+	return c.generateStubResponse(http.StatusOK), nil
+}
+
+func (c *clientOpenAPIStub) RequestUpload(resource SpecResource, uploadPath string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.parentIDsReceived = parentIDs
+	c.uploadRequestsReceived = append(c.uploadRequestsReceived, uploadPath)
+	c.uploadRequestPayloads = append(c.uploadRequestPayloads, requestPayload)
+	switch p := responsePayload.(type) {
+	case *map[string]interface{}:
+		*p = c.uploadResponsePayload
+	default:
+		panic("unexpected type")
+	}
+	return c.generateStubResponse(http.StatusOK), nil
+}
+
+func (c *clientOpenAPIStub) Upload(uploadURL string, content io.Reader, size int64) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	body, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	c.uploadsReceived = append(c.uploadsReceived, fmt.Sprintf("PUT %s %s", uploadURL, body))
+	return c.generateStubResponse(http.StatusOK), nil
+}
+
+func (c *clientOpenAPIStub) GetOptions(resource SpecResource, optionsPath string, queryParameters map[string]string, responsePayload interface{}) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.optionsRequestsReceived = append(c.optionsRequestsReceived, appendOptionsQueryParameters(optionsPath, queryParameters))
+	switch p := responsePayload.(type) {
+	case *map[string]interface{}:
+		*p = c.responsePayload
+	default:
+		panic("unexpected type")
+	}
+	return c.generateStubResponse(http.StatusOK), nil
+}
+
+func (c *clientOpenAPIStub) Count(resource SpecResource, count *specCount, queryParameters map[string]string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.parentIDsReceived = parentIDs
+	c.countRequestsReceived = append(c.countRequestsReceived, appendOptionsQueryParameters(count.path, queryParameters))
+	switch p := responsePayload.(type) {
+	case *map[string]interface{}:
+		*p = c.responsePayload
+	default:
+		panic("unexpected type")
+	}
+	return c.generateStubResponse(http.StatusOK), nil
+}
+
+func (c *clientOpenAPIStub) PollLocation(resource SpecResource, location string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.locationsReceived = append(c.locationsReceived, location)
+	statusCode := http.StatusOK
+	if len(c.locationResponseCodes) > 0 {
+		statusCode = c.locationResponseCodes[0]
+		if len(c.locationResponseCodes) > 1 {
+			c.locationResponseCodes = c.locationResponseCodes[1:]
+		}
+	}
+	switch p := responsePayload.(type) {
+	case *map[string]interface{}:
+		*p = c.locationResponsePayload
+	default:
+		panic("unexpected type")
+	}
+	return &http.Response{StatusCode: statusCode, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
 func (c *clientOpenAPIStub) GetTelemetryHandler() TelemetryHandler {
 	return c.telemetryHandler
 }
 
+func (c *clientOpenAPIStub) GetProviderHost() (string, string, error) {
+	if c.error != nil {
+		return "", "", c.error
+	}
+	return c.host, c.region, nil
+}
+
 func (c *clientOpenAPIStub) generateStubResponse(defaultHTTPCode int) *http.Response {
 	return &http.Response{
 		StatusCode: c.returnCode(defaultHTTPCode),
@@ -1,17 +1,25 @@
 package openapi
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-openapi/spec"
 
 	"github.com/dikhan/http_goclient"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProviderClient(t *testing.T) {
@@ -249,6 +257,64 @@ func TestGetResourceIDURL(t *testing.T) {
 	})
 }
 
+func TestGetProviderHost(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		backendConfiguration *specStubBackendConfiguration
+		userRegion           string
+		profileHost          string
+		expectedHost         string
+		expectedRegion       string
+		expectedError        string
+	}{
+		{
+			name:                 "single region provider returns the backend host and empty region",
+			backendConfiguration: newStubBackendConfiguration("www.host.com", "/api", "http"),
+			expectedHost:         "www.host.com",
+			expectedRegion:       "",
+		},
+		{
+			name:                 "multi-region provider with no region provided by the user falls back to the default region",
+			backendConfiguration: &specStubBackendConfiguration{host: "www.%s.host.com", regions: []string{"rst1", "dub1"}},
+			expectedHost:         "www.rst1.host.com",
+			expectedRegion:       "rst1",
+		},
+		{
+			name:                 "multi-region provider with region provided by the user",
+			backendConfiguration: &specStubBackendConfiguration{host: "www.%s.host.com", regions: []string{"rst1", "dub1"}},
+			userRegion:           "dub1",
+			expectedHost:         "www.dub1.host.com",
+			expectedRegion:       "dub1",
+		},
+		{
+			name:                 "backend configuration returns an error",
+			backendConfiguration: &specStubBackendConfiguration{err: errors.New("some error")},
+			expectedError:        "some error",
+		},
+		{
+			name:                 "single region provider with the service profile host configured returns the profile host",
+			backendConfiguration: newStubBackendConfiguration("www.host.com", "/api", "http"),
+			profileHost:          "www.staging.host.com",
+			expectedHost:         "www.staging.host.com",
+			expectedRegion:       "",
+		},
+	}
+	for _, tc := range testCases {
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: tc.backendConfiguration,
+			providerConfiguration:       providerConfiguration{Region: tc.userRegion, Host: tc.profileHost},
+		}
+		host, region, err := providerClient.GetProviderHost()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedHost, host, tc.name)
+		assert.Equal(t, tc.expectedRegion, region, tc.name)
+	}
+}
+
 func TestGetResourceURL_edge_cases(t *testing.T) {
 	testCases := []struct {
 		name                string
@@ -352,6 +418,18 @@ func TestGetResourceURL(t *testing.T) {
 			})
 		})
 
+		Convey("When getResourceURL is called with a resource aggregated from an additional OpenAPI document", func() {
+			aggregatedResource := &specAggregatedResource{
+				SpecResource:         &specStubResource{path: "/v1/firewalls"},
+				backendConfiguration: newStubBackendConfiguration("firewall.host.com", "/firewall-api", "https"),
+			}
+			resourceURL, err := providerClient.getResourceURL(aggregatedResource, []string{})
+			Convey("Then the result returned should use the host, base path and scheme of the additional OpenAPI document", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "https://firewall.host.com/firewall-api/v1/firewalls")
+			})
+		})
+
 		Convey("When getResourceURL is called with a resource which blows up on getResourcePath", func() {
 			specStubResource := &specStubResource{
 				funcGetResourcePath: func(parentIDs []string) (string, error) { return "", errors.New("getResourcePath blew up") },
@@ -1482,6 +1560,451 @@ func TestProviderClientDelete(t *testing.T) {
 	})
 }
 
+func TestProviderClientGetIfNoneMatch(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		eTag                    string
+		responseStatusCode      int
+		responseBody            string
+		responseETag            string
+		expectedIfNoneMatch     string
+		expectedStatusCode      int
+		expectedResponsePayload map[string]interface{}
+		expectedError           string
+	}{
+		{
+			name:                    "no eTag provided and the API responds with 200 and the ETag",
+			responseStatusCode:      http.StatusOK,
+			responseBody:            `{"property1":"value1"}`,
+			responseETag:            `"v1"`,
+			expectedStatusCode:      http.StatusOK,
+			expectedResponsePayload: map[string]interface{}{"property1": "value1"},
+		},
+		{
+			name:                    "eTag provided and the API responds with 304 Not Modified",
+			eTag:                    `"v1"`,
+			responseStatusCode:      http.StatusNotModified,
+			expectedIfNoneMatch:     `"v1"`,
+			expectedStatusCode:      http.StatusNotModified,
+			expectedResponsePayload: map[string]interface{}{},
+		},
+		{
+			name:                    "eTag provided and the API responds with 200 since the resource changed",
+			eTag:                    `"v1"`,
+			responseStatusCode:      http.StatusOK,
+			responseBody:            `{"property1":"value2"}`,
+			responseETag:            `"v2"`,
+			expectedIfNoneMatch:     `"v1"`,
+			expectedStatusCode:      http.StatusOK,
+			expectedResponsePayload: map[string]interface{}{"property1": "value2"},
+		},
+		{
+			name:                    "API responds with non expected status code",
+			responseStatusCode:      http.StatusNotFound,
+			responseBody:            `{"error":"not found"}`,
+			expectedStatusCode:      http.StatusNotFound,
+			expectedResponsePayload: map[string]interface{}{},
+		},
+		{
+			name:               "API responds with 200 and empty body",
+			responseStatusCode: http.StatusOK,
+			expectedError:      "expected a response body but response body received was empty for request = 'GET %s/v1/resource/1234'. Response = '200 OK'",
+		},
+		{
+			name:               "API responds with 200 and a body that is not valid JSON",
+			responseStatusCode: http.StatusOK,
+			responseBody:       `not json`,
+			expectedError:      "unable to unmarshal response body ['invalid character 'o' in literal null (expecting 'u')'] for request = 'GET %s/v1/resource/1234'. Response = '200 OK'",
+		},
+	}
+	for _, tc := range testCases {
+		var ifNoneMatchReceived string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ifNoneMatchReceived = r.Header.Get(ifNoneMatchHeader)
+			if tc.responseETag != "" {
+				w.Header().Set(eTagHeader, tc.responseETag)
+			}
+			w.WriteHeader(tc.responseStatusCode)
+			w.Write([]byte(tc.responseBody))
+		}))
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		}
+		resource := &specStubResource{
+			path:                 "/v1/resource",
+			resourceGetOperation: &specResourceOperation{},
+		}
+		responsePayload := map[string]interface{}{}
+		resp, err := providerClient.GetIfNoneMatch(resource, "1234", tc.eTag, &responsePayload)
+		api.Close()
+		assert.Equal(t, tc.expectedIfNoneMatch, ifNoneMatchReceived, tc.name)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, fmt.Sprintf(tc.expectedError, api.URL), tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedStatusCode, resp.StatusCode, tc.name)
+		assert.Equal(t, tc.responseETag, resp.Header.Get(eTagHeader), tc.name)
+		assert.Equal(t, tc.expectedResponsePayload, responsePayload, tc.name)
+	}
+}
+
+func TestProviderClientPerformRequestWithBody(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		method                  httpMethodSupported
+		requestCompression      *RequestCompressionConfig
+		responseStatusCode      int
+		responseBody            string
+		expectedContentEncoding string
+		expectedResponsePayload map[string]interface{}
+		expectedError           string
+	}{
+		{
+			name:                    "POST request without compression configured",
+			method:                  httpPost,
+			responseStatusCode:      http.StatusCreated,
+			responseBody:            `{"id":"1234"}`,
+			expectedResponsePayload: map[string]interface{}{"id": "1234"},
+		},
+		{
+			name:                    "PUT request with request body bigger than the gzip threshold",
+			method:                  httpPut,
+			requestCompression:      &RequestCompressionConfig{GzipThreshold: 10},
+			responseStatusCode:      http.StatusOK,
+			responseBody:            `{"id":"1234"}`,
+			expectedContentEncoding: "gzip",
+			expectedResponsePayload: map[string]interface{}{"id": "1234"},
+		},
+		{
+			name:                    "POST request with request body smaller than the gzip threshold",
+			method:                  httpPost,
+			requestCompression:      &RequestCompressionConfig{GzipThreshold: 1024},
+			responseStatusCode:      http.StatusCreated,
+			responseBody:            `{"id":"1234"}`,
+			expectedResponsePayload: map[string]interface{}{"id": "1234"},
+		},
+		{
+			name:               "POST request with empty response body",
+			method:             httpPost,
+			responseStatusCode: http.StatusCreated,
+			expectedError:      "expected a response body but response body received was empty for request = 'POST %s/v1/resource'. Response = '201 Created'",
+		},
+	}
+	requestPayload := map[string]interface{}{"name": "some name", "tags": []interface{}{"tag1", "tag2"}}
+	for _, tc := range testCases {
+		var methodReceived, contentTypeReceived, contentEncodingReceived string
+		var requestPayloadReceived map[string]interface{}
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			methodReceived = r.Method
+			contentTypeReceived = r.Header.Get(contentType)
+			contentEncodingReceived = r.Header.Get(contentEncodingHeader)
+			var body io.Reader = r.Body
+			if contentEncodingReceived == "gzip" {
+				body, _ = gzip.NewReader(r.Body)
+			}
+			json.NewDecoder(body).Decode(&requestPayloadReceived)
+			w.WriteHeader(tc.responseStatusCode)
+			w.Write([]byte(tc.responseBody))
+		}))
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+			requestCompression:          tc.requestCompression,
+		}
+		responsePayload := map[string]interface{}{}
+		resp, err := providerClient.performRequest(tc.method, api.URL+"/v1/resource", &specResourceOperation{}, requestPayload, &responsePayload)
+		api.Close()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, fmt.Sprintf(tc.expectedError, api.URL), tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.responseStatusCode, resp.StatusCode, tc.name)
+		assert.Equal(t, string(tc.method), methodReceived, tc.name)
+		assert.Equal(t, "application/json", contentTypeReceived, tc.name)
+		assert.Equal(t, tc.expectedContentEncoding, contentEncodingReceived, tc.name)
+		assert.Equal(t, map[string]interface{}{"name": "some name", "tags": []interface{}{"tag1", "tag2"}}, requestPayloadReceived, tc.name)
+		assert.Equal(t, tc.expectedResponsePayload, responsePayload, tc.name)
+	}
+}
+
+func TestProviderClientMaxResponseBodySize(t *testing.T) {
+	testCases := []struct {
+		name                string
+		maxResponseBodySize int64
+		responseStatusCode  int
+		responseBody        string
+		expectedBody        string
+		expectedError       string
+	}{
+		{
+			name:                "successful response smaller than the max response body size",
+			maxResponseBodySize: 100,
+			responseStatusCode:  http.StatusOK,
+			responseBody:        `{"id":"1234"}`,
+		},
+		{
+			name:                "successful response bigger than the max response body size",
+			maxResponseBodySize: 5,
+			responseStatusCode:  http.StatusOK,
+			responseBody:        `{"id":"1234"}`,
+			expectedError:       "response body exceeds the max response body size configured for request = 'GET %s/v1/resource'. Response = '200 OK'",
+		},
+		{
+			name:                "error response is kept so it can be read afterwards",
+			maxResponseBodySize: 100,
+			responseStatusCode:  http.StatusBadRequest,
+			responseBody:        `{"error":"bad request"}`,
+			expectedBody:        `{"error":"bad request"}`,
+		},
+		{
+			name:                "error response bigger than the max response body size",
+			maxResponseBodySize: 5,
+			responseStatusCode:  http.StatusBadRequest,
+			responseBody:        `{"error":"bad request"}`,
+			expectedError:       "response body exceeds the max response body size configured for request = 'GET %s/v1/resource'. Response = '400 Bad Request'",
+		},
+	}
+	for _, tc := range testCases {
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.responseStatusCode)
+			w.Write([]byte(tc.responseBody))
+		}))
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+			maxResponseBodySize:         tc.maxResponseBodySize,
+		}
+		responsePayload := map[string]interface{}{}
+		resp, err := providerClient.performRequest(httpGet, api.URL+"/v1/resource", &specResourceOperation{}, nil, &responsePayload)
+		api.Close()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, fmt.Sprintf(tc.expectedError, api.URL), tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		body, err := ioutil.ReadAll(resp.Body)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedBody, string(body), tc.name)
+	}
+}
+
+func TestAppendFieldsQueryParameter(t *testing.T) {
+	testCases := []struct {
+		name            string
+		resourceURL     string
+		fieldsParameter string
+		fields          []string
+		expectedURL     string
+	}{
+		{name: "no fields", resourceURL: "http://host.com/v1/resource/id", fieldsParameter: "fields", expectedURL: "http://host.com/v1/resource/id"},
+		{name: "fields", resourceURL: "http://host.com/v1/resource/id", fieldsParameter: "fields", fields: []string{"id", "revision"}, expectedURL: "http://host.com/v1/resource/id?fields=id,revision"},
+		{name: "custom fields parameter", resourceURL: "http://host.com/v1/resource/id", fieldsParameter: "select", fields: []string{"revision"}, expectedURL: "http://host.com/v1/resource/id?select=revision"},
+		{name: "URL already containing query parameters", resourceURL: "http://host.com/v1/resource/id?expand=true", fieldsParameter: "fields", fields: []string{"revision"}, expectedURL: "http://host.com/v1/resource/id?expand=true&fields=revision"},
+		{name: "fields escaped", resourceURL: "http://host.com/v1/resource/id", fieldsParameter: "fields", fields: []string{"meta data"}, expectedURL: "http://host.com/v1/resource/id?fields=meta+data"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedURL, appendFieldsQueryParameter(tc.resourceURL, tc.fieldsParameter, tc.fields), tc.name)
+	}
+}
+
+func TestProviderClientGetFields(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/resource/1234", r.URL.Path)
+		assert.Equal(t, "revision", r.URL.Query().Get("fields"))
+		w.Write([]byte(`{"revision":3}`))
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+	}
+	resource := &specStubResource{
+		path:                 "/v1/resource",
+		resourceGetOperation: &specResourceOperation{fieldsParameter: "fields"},
+	}
+	responsePayload := map[string]interface{}{}
+	resp, err := providerClient.GetFields(resource, "1234", []string{"revision"}, &responsePayload)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, map[string]interface{}{"revision": float64(3)}, responsePayload)
+}
+
+func TestProviderClientForceApply(t *testing.T) {
+	var queryReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queryReceived = r.URL.RawQuery
+		w.Write([]byte(`{"id":"1234"}`))
+	}))
+	defer api.Close()
+	testCases := []struct {
+		name          string
+		forceApply    bool
+		operation     *specResourceOperation
+		expectedQuery string
+	}{
+		{name: "force apply enabled and supported by the operation", forceApply: true, operation: &specResourceOperation{forceApplyParameter: "force"}, expectedQuery: "force=true"},
+		{name: "force apply disabled", forceApply: false, operation: &specResourceOperation{forceApplyParameter: "force"}, expectedQuery: ""},
+		{name: "force apply enabled but not supported by the operation", forceApply: true, operation: &specResourceOperation{}, expectedQuery: ""},
+	}
+	for _, tc := range testCases {
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+			providerConfiguration:       providerConfiguration{ForceApply: tc.forceApply},
+		}
+		resource := &specStubResource{
+			path:                  "/v1/resource",
+			resourcePostOperation: tc.operation,
+			resourcePutOperation:  tc.operation,
+		}
+		_, err := providerClient.Post(resource, map[string]interface{}{}, &map[string]interface{}{})
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedQuery, queryReceived, tc.name)
+		_, err = providerClient.Put(resource, "1234", map[string]interface{}{}, &map[string]interface{}{})
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedQuery, queryReceived, tc.name)
+	}
+}
+
+func TestProviderClientSubResource(t *testing.T) {
+	var requestReceived string
+	var bodyReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestReceived = r.Method + " " + r.URL.Path
+		b, _ := ioutil.ReadAll(r.Body)
+		bodyReceived = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+	}
+	resource := &specStubResource{
+		path:                 "/v1/resource",
+		resourcePutOperation: &specResourceOperation{},
+	}
+
+	res, err := providerClient.PostSubResource(resource, "1234", "tags", "env")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.Equal(t, "POST /v1/resource/1234/tags", requestReceived)
+	assert.Equal(t, `"env"`, strings.TrimSpace(bodyReceived))
+
+	providerClient.apiAuthenticator = newStubAuthenticator("Authentication", "Bearer secret!", nil)
+	res, err = providerClient.DeleteSubResource(resource, "1234", "tags/env")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.Equal(t, "DELETE /v1/resource/1234/tags/env", requestReceived)
+
+	_, err = providerClient.DeleteSubResource(&specStubResource{name: "resourceName", path: "/v1/resource"}, "1234", "tags/env")
+	assert.EqualError(t, err, "resource 'resourceName' does not support PUT operations")
+}
+
+func TestAppendForceApplyQueryParameter(t *testing.T) {
+	providerClient := &ProviderClient{providerConfiguration: providerConfiguration{ForceApply: true}}
+	operation := &specResourceOperation{forceApplyParameter: "force"}
+	assert.Equal(t, "http://host.com/v1/resource?force=true", providerClient.appendForceApplyQueryParameter("http://host.com/v1/resource", operation))
+	assert.Equal(t, "http://host.com/v1/resource?dryRun=All&force=true", providerClient.appendForceApplyQueryParameter("http://host.com/v1/resource?dryRun=All", operation))
+	assert.Equal(t, "http://host.com/v1/resource", providerClient.appendForceApplyQueryParameter("http://host.com/v1/resource", nil))
+}
+
+func TestProviderClientGetWithReadPath(t *testing.T) {
+	var pathReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pathReceived = r.URL.Path
+		w.Write([]byte(`{"id":"1234"}`))
+	}))
+	defer api.Close()
+	newProviderClient := func() *ProviderClient {
+		return &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "/api", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		}
+	}
+	resource := &specStubResource{
+		path:                    "/v1/deployments",
+		readPath:                "/v1/clusters",
+		resourceGetOperation:    &specResourceOperation{},
+		resourceDeleteOperation: &specResourceOperation{},
+	}
+
+	_, err := newProviderClient().Get(resource, "1234", &map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v1/clusters/1234", pathReceived)
+
+	_, err = newProviderClient().GetIfNoneMatch(resource, "1234", "", &map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v1/clusters/1234", pathReceived)
+
+	_, err = newProviderClient().GetFields(resource, "1234", []string{"id"}, &map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v1/clusters/1234", pathReceived)
+
+	// the rest of the instance operations are performed against the resource instance path
+	_, err = newProviderClient().Delete(resource, "1234")
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v1/deployments/1234", pathReceived)
+}
+
+func TestProviderClientReadsWithFields(t *testing.T) {
+	var queryReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queryReceived = r.URL.RawQuery
+		if r.URL.Path == "/v1/resource" {
+			w.Write([]byte(`[{"id":"1234"}]`))
+			return
+		}
+		w.Write([]byte(`{"id":"1234"}`))
+	}))
+	defer api.Close()
+	newProviderClient := func() *ProviderClient {
+		return &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		}
+	}
+	resource := &specStubResource{
+		path: "/v1/resource",
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil),
+			},
+		},
+		resourceGetOperation:  &specResourceOperation{fields: []string{"id", "label"}},
+		resourceListOperation: &specResourceOperation{fieldsParameter: "include", fieldsFromSchema: true},
+	}
+
+	_, err := newProviderClient().Get(resource, "1234", &map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "fields=id,label", queryReceived)
+
+	_, err = newProviderClient().GetIfNoneMatch(resource, "1234", "", &map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "fields=id,label", queryReceived)
+
+	_, err = newProviderClient().List(resource, &[]map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "include=id,label", queryReceived)
+
+	resource.resourceGetOperation = &specResourceOperation{}
+	_, err = newProviderClient().Get(resource, "1234", &map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", queryReceived)
+}
+
 func TestProviderClientGetTelemetryHandler(t *testing.T) {
 	Convey("Given a providerClient set up with a telemetry handler", t, func() {
 		telemetryHandler := &telemetryHandlerTimeoutSupport{}
@@ -1496,3 +2019,375 @@ func TestProviderClientGetTelemetryHandler(t *testing.T) {
 		})
 	})
 }
+
+func TestWithRequestTimeout(t *testing.T) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	assert.Equal(t, httpClient, withRequestTimeout(httpClient, nil))
+	assert.Equal(t, httpClient, withRequestTimeout(httpClient, &specResourceOperation{}))
+
+	requestTimeout := 120 * time.Second
+	operationHTTPClient := withRequestTimeout(httpClient, &specResourceOperation{requestTimeout: &requestTimeout})
+	assert.Equal(t, requestTimeout, operationHTTPClient.Timeout)
+	assert.Equal(t, 30*time.Second, httpClient.Timeout, "the http client passed in should not be modified")
+}
+
+func TestProviderClientRequestTimeout(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"id":"1234"}`))
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{Timeout: 10 * time.Millisecond}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+	}
+	_, err := providerClient.performRequest(httpPost, api.URL+"/v1/resource", &specResourceOperation{}, nil, &map[string]interface{}{})
+	assert.Error(t, err)
+
+	requestTimeout := 5 * time.Second
+	responsePayload := map[string]interface{}{}
+	resp, err := providerClient.performRequest(httpPost, api.URL+"/v1/resource", &specResourceOperation{requestTimeout: &requestTimeout}, nil, &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "1234", responsePayload["id"])
+}
+
+func TestProviderClientPoll(t *testing.T) {
+	var methodReceived, pathReceived, bodyReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methodReceived = r.Method
+		pathReceived = r.URL.Path
+		body, _ := ioutil.ReadAll(r.Body)
+		bodyReceived = string(body)
+		w.Write([]byte(`{"result":{"status":"running"}}`))
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+	}
+	resource := &specStubResource{
+		path:                 "/v1/resource",
+		resourceGetOperation: &specResourceOperation{},
+	}
+	testCases := []struct {
+		name           string
+		pollRequest    *specPollRequest
+		expectedMethod string
+		expectedPath   string
+		expectedBody   string
+	}{
+		{
+			name:           "poll request against the resource instance path",
+			pollRequest:    &specPollRequest{method: httpGet},
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/v1/resource/1234",
+		},
+		{
+			name:           "poll request with path and body",
+			pollRequest:    &specPollRequest{method: httpPost, path: "/v1/rpc", body: `{"method":"resource.status","params":{"id":"{{.ID}}"}}`},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/v1/rpc",
+			expectedBody:   `{"method":"resource.status","params":{"id":"1234"}}`,
+		},
+		{
+			name:           "poll request with path template",
+			pollRequest:    &specPollRequest{method: httpPut, path: "/v1/resource/{{.ID}}/status"},
+			expectedMethod: http.MethodPut,
+			expectedPath:   "/v1/resource/1234/status",
+		},
+	}
+	for _, tc := range testCases {
+		bodyReceived = ""
+		providerClient.apiAuthenticator = newStubAuthenticator("Authentication", "Bearer secret!", nil)
+		responsePayload := map[string]interface{}{}
+		resp, err := providerClient.Poll(resource, "1234", tc.pollRequest, &responsePayload)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, tc.name)
+		assert.Equal(t, tc.expectedMethod, methodReceived, tc.name)
+		assert.Equal(t, tc.expectedPath, pathReceived, tc.name)
+		assert.Equal(t, tc.expectedBody, bodyReceived, tc.name)
+		assert.Equal(t, map[string]interface{}{"result": map[string]interface{}{"status": "running"}}, responsePayload, tc.name)
+	}
+
+	subResource := &specStubResource{
+		resourceGetOperation: &specResourceOperation{},
+		funcGetResourcePath: func(parentIDs []string) (string, error) {
+			return resolvePathParameters("/v1/parents/{parent_id}/resource", parentIDs)
+		},
+	}
+	providerClient.apiAuthenticator = newStubAuthenticator("Authentication", "Bearer secret!", nil)
+	_, err := providerClient.Poll(subResource, "1234", &specPollRequest{method: httpGet}, &map[string]interface{}{}, "parent-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/parents/parent-1/resource/1234", pathReceived)
+	providerClient.apiAuthenticator = newStubAuthenticator("Authentication", "Bearer secret!", nil)
+	_, err = providerClient.Poll(subResource, "1234", &specPollRequest{method: httpPost, path: "/v1/parents/{parent_id}/rpc", body: `{"parent":"{{index .ParentIDs 0}}","id":"{{.ID}}"}`}, &map[string]interface{}{}, "parent-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/parents/parent-1/rpc", pathReceived)
+	assert.Equal(t, `{"id":"1234","parent":"parent-1"}`, bodyReceived)
+
+	_, err = providerClient.Poll(resource, "1234", &specPollRequest{method: httpPost, body: `{"id": {{.ID}}`}, &map[string]interface{}{})
+	assert.EqualError(t, err, "poll request body is not valid JSON: unexpected end of JSON input")
+}
+
+func TestProviderClientPrecheck(t *testing.T) {
+	var methodReceived, pathReceived, authHeaderReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methodReceived = r.Method
+		pathReceived = r.URL.Path
+		authHeaderReceived = r.Header.Get("Authentication")
+		w.Write([]byte(`{"allowed":true}`))
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+	}
+	resource := &specStubResource{
+		path:                  "/v1/projects/{project_id}/clusters",
+		resourcePostOperation: &specResourceOperation{},
+	}
+	responsePayload := map[string]interface{}{}
+	resp, err := providerClient.Precheck(resource, &specPrecheck{path: "/v1/projects/{project_id}/quota"}, &responsePayload, "p1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, http.MethodGet, methodReceived)
+	assert.Equal(t, "/v1/projects/p1/quota", pathReceived)
+	assert.Equal(t, "Bearer secret!", authHeaderReceived)
+	assert.Equal(t, map[string]interface{}{"allowed": true}, responsePayload)
+
+	_, err = providerClient.Precheck(resource, &specPrecheck{path: "/v1/projects/{project_id}/quota"}, &responsePayload)
+	assert.EqualError(t, err, "could not resolve the path '/v1/projects/{project_id}/quota' with the given ids - missing ids to resolve the path params properly: []")
+}
+
+func TestProviderClientLock(t *testing.T) {
+	var requestsReceived []string
+	var authHeaderReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestsReceived = append(requestsReceived, r.Method+" "+r.URL.Path)
+		authHeaderReceived = r.Header.Get("Authentication")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+	}
+	lock := &specLock{path: "/v1/projects/{project_id}/locks/{id}"}
+	resource := &specStubResource{
+		path:                    "/v1/projects/{project_id}/clusters",
+		resourcePutOperation:    &specResourceOperation{},
+		resourceDeleteOperation: &specResourceOperation{lock: lock},
+	}
+	resp, err := providerClient.AcquireLock(resource, lock, "p1", "1234")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp, err = providerClient.ReleaseLock(resource, lock, "p1", "1234")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, []string{"POST /v1/projects/p1/locks/1234", "DELETE /v1/projects/p1/locks/1234"}, requestsReceived)
+	assert.Equal(t, "Bearer secret!", authHeaderReceived)
+	assert.Equal(t, resource.resourceDeleteOperation, getLockOperation(resource, lock))
+	assert.Equal(t, resource.resourcePutOperation, getLockOperation(resource, &specLock{path: "/v1/projects/{project_id}/lock"}))
+
+	_, err = providerClient.AcquireLock(resource, lock, "p1")
+	assert.EqualError(t, err, "could not resolve the path '/v1/projects/{project_id}/locks/{id}' with the given ids - missing ids to resolve the path params properly: [p1]")
+}
+
+func TestProviderClientGetOptions(t *testing.T) {
+	var requestURIReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURIReceived = r.URL.RequestURI()
+		w.Write([]byte(`{"node_size":["small","medium"]}`))
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+	}
+	resource := &specStubResource{
+		path:                  "/v1/clusters",
+		resourcePostOperation: &specResourceOperation{},
+	}
+	responsePayload := map[string]interface{}{}
+	resp, err := providerClient.GetOptions(resource, "/v1/clusters/options", map[string]string{"region": "us east"}, &responsePayload)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "/v1/clusters/options?region=us+east", requestURIReceived)
+	assert.Equal(t, map[string]interface{}{"node_size": []interface{}{"small", "medium"}}, responsePayload)
+}
+
+func TestProviderClientLookup(t *testing.T) {
+	var pathReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pathReceived = r.URL.Path
+		w.Write([]byte(`[{"id":"vpc-1","name":"prod"}]`))
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+	}
+	resource := &specStubResource{
+		path:                  "/v1/projects/{project_id}/clusters",
+		resourcePostOperation: &specResourceOperation{},
+	}
+	var responsePayload interface{}
+	resp, err := providerClient.Lookup(resource, "/v1/projects/{project_id}/vpcs", &responsePayload, "p1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "/v1/projects/p1/vpcs", pathReceived)
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": "vpc-1", "name": "prod"}}, responsePayload)
+}
+
+func TestProviderClientCount(t *testing.T) {
+	var requestURIReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURIReceived = r.URL.RequestURI()
+		w.Write([]byte(`{"count":2}`))
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+	}
+	resource := &specStubResource{
+		path:                  "/v1/cdns/{cdn_id}/firewalls",
+		resourceListOperation: &specResourceOperation{},
+	}
+	responsePayload := map[string]interface{}{}
+	resp, err := providerClient.Count(resource, &specCount{path: "/v1/cdns/{cdn_id}/firewalls/count"}, map[string]string{"label": "my label"}, &responsePayload, "cdn1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "/v1/cdns/cdn1/firewalls/count?label=my+label", requestURIReceived)
+	assert.Equal(t, map[string]interface{}{"count": float64(2)}, responsePayload)
+
+	_, err = providerClient.Count(resource, &specCount{path: "/v1/cdns/{cdn_id}/firewalls/count"}, nil, &responsePayload)
+	assert.EqualError(t, err, "could not resolve the path '/v1/cdns/{cdn_id}/firewalls/count' with the given ids - missing ids to resolve the path params properly: []")
+}
+
+func TestProviderClientWithContext(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+	}
+	resource := &specStubResource{
+		path:                  "/v1/clusters",
+		resourcePostOperation: &specResourceOperation{},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := providerClient.withContext(ctx)
+	responsePayload := map[string]interface{}{}
+	_, err := client.GetOptions(resource, "/v1/clusters/options", nil, &responsePayload)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), context.Canceled.Error())
+
+	// the original client is not bound to the context
+	assert.Nil(t, providerClient.ctx)
+	resp, err := providerClient.GetOptions(resource, "/v1/clusters/options", nil, &responsePayload)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestProviderClientPutMergePatch(t *testing.T) {
+	var methodReceived, contentTypeReceived, bodyReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methodReceived = r.Method
+		contentTypeReceived = r.Header.Get(contentType)
+		body, _ := ioutil.ReadAll(r.Body)
+		bodyReceived = string(body)
+		w.Header().Set(contentType, "application/json")
+		w.Write([]byte(`{"id":"1234","label":"some other label"}`))
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newAPIAuthenticator(nil),
+	}
+	resource := &specStubResource{name: "cdns_v1", path: "/v1/cdns", resourcePutOperation: &specResourceOperation{}}
+	responsePayload := map[string]interface{}{}
+
+	_, err := providerClient.Put(resource, "1234", map[string]interface{}{"label": "some other label"}, &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPut, methodReceived)
+	assert.Equal(t, "application/json", contentTypeReceived)
+
+	resource.resourcePutOperation.updateStrategy = operationUpdateStrategyMergePatch
+	_, err = providerClient.Put(resource, "1234", map[string]interface{}{"label": "some other label", "description": nil}, &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPatch, methodReceived)
+	assert.Equal(t, "application/merge-patch+json", contentTypeReceived)
+	assert.JSONEq(t, `{"label":"some other label","description":null}`, bodyReceived)
+	assert.Equal(t, map[string]interface{}{"id": "1234", "label": "some other label"}, responsePayload)
+
+	resource.resourcePutOperation.updateStrategy = operationUpdateStrategyJSONPatch
+	_, err = providerClient.Put(resource, "1234", []jsonPatchOperation{{Op: jsonPatchReplace, Path: "/label", Value: "some other label"}, {Op: jsonPatchRemove, Path: "/description"}}, &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPatch, methodReceived)
+	assert.Equal(t, "application/json-patch+json", contentTypeReceived)
+	assert.JSONEq(t, `[{"op":"replace","path":"/label","value":"some other label"},{"op":"remove","path":"/description"}]`, bodyReceived)
+}
+
+func TestProviderClientPollLocation(t *testing.T) {
+	var pathReceived string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pathReceived = r.URL.RequestURI()
+		w.Header().Set(contentType, "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status":"provisioning"}`))
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newAPIAuthenticator(nil),
+	}
+	resource := &specStubResource{name: "cdns_v1", path: "/v1/cdns", resourceGetOperation: &specResourceOperation{}}
+	responsePayload := map[string]interface{}{}
+
+	resp, err := providerClient.PollLocation(resource, "/v1/operations/1234?wait=true", &responsePayload)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "/v1/operations/1234?wait=true", pathReceived)
+	assert.Equal(t, map[string]interface{}{"status": "provisioning"}, responsePayload)
+
+	_, err = providerClient.PollLocation(resource, "https://other-host.com/v1/operations/1234", &responsePayload)
+	assert.EqualError(t, err, fmt.Sprintf("location 'https://other-host.com/v1/operations/1234' not valid: the location must be served by the API host '%s'", strings.TrimPrefix(api.URL, "http://")))
+}
+
+func TestResolveLocationURL(t *testing.T) {
+	testCases := []struct {
+		name          string
+		location      string
+		expectedURL   string
+		expectedError string
+	}{
+		{name: "absolute path", location: "/v1/operations/1234", expectedURL: "https://api.example.com/v1/operations/1234"},
+		{name: "relative path", location: "cdns/1234", expectedURL: "https://api.example.com/v1/cdns/1234"},
+		{name: "absolute URL", location: "https://api.example.com/v1/cdns/1234", expectedURL: "https://api.example.com/v1/cdns/1234"},
+		{name: "other host", location: "https://evil.com/v1/cdns/1234", expectedError: "location 'https://evil.com/v1/cdns/1234' not valid: the location must be served by the API host 'api.example.com'"},
+	}
+	for _, tc := range testCases {
+		locationURL, err := resolveLocationURL("https://api.example.com/v1/cdns", tc.location)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedURL, locationURL, tc.name)
+	}
+}
@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"bytes"
+	"runtime"
+	"text/template"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/version"
+)
+
+// userAgentTemplateData contains the values available to the User-Agent templates (see ServiceConfigV1.UserAgent and
+// the x-terraform-user-agent extension)
+type userAgentTemplateData struct {
+	// ProviderName contains the name of the provider (e,g: openapi)
+	ProviderName string
+	// ProviderVersion contains the version of the provider. If the provider build does not specify a version, the
+	// OpenAPI Terraform provider version is used
+	ProviderVersion string
+	// TerraformVersion contains the version of Terraform running the provider; empty if Terraform does not report it
+	TerraformVersion string
+	// OpenAPIProviderVersion contains the version of the OpenAPI Terraform provider
+	OpenAPIProviderVersion string
+	// OS contains the operating system the provider is running on
+	OS string
+	// Arch contains the architecture the provider is running on
+	Arch string
+	// Features contains the values of the provider feature flags keyed by their terraform compliant names (e,g:
+	// {{ .Features.new_billing }}); empty if the OpenAPI document does not define feature flags
+	Features map[string]interface{}
+}
+
+func newUserAgentTemplateData(providerName, providerVersion, terraformVersion string) userAgentTemplateData {
+	if providerVersion == "" {
+		providerVersion = version.Version
+	}
+	return userAgentTemplateData{
+		ProviderName:           providerName,
+		ProviderVersion:        providerVersion,
+		TerraformVersion:       terraformVersion,
+		OpenAPIProviderVersion: version.Version,
+		OS:                     runtime.GOOS,
+		Arch:                   runtime.GOARCH,
+	}
+}
+
+func newUserAgentTemplate(userAgent string) (*template.Template, error) {
+	return template.New("user_agent").Option("missingkey=error").Parse(userAgent)
+}
+
+// renderUserAgent returns the User-Agent resulting of executing the template passed in with the given data
+func renderUserAgent(userAgent string, data userAgentTemplateData) (string, error) {
+	tmpl, err := newUserAgentTemplate(userAgent)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
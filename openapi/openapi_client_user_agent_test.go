@@ -0,0 +1,137 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserAgentTemplateData(t *testing.T) {
+	data := newUserAgentTemplateData("openapi", "1.2.3", "1.3.0")
+	assert.Equal(t, userAgentTemplateData{
+		ProviderName:           "openapi",
+		ProviderVersion:        "1.2.3",
+		TerraformVersion:       "1.3.0",
+		OpenAPIProviderVersion: version.Version,
+		OS:                     runtime.GOOS,
+		Arch:                   runtime.GOARCH,
+	}, data)
+
+	data = newUserAgentTemplateData("openapi", "", "1.3.0")
+	assert.Equal(t, version.Version, data.ProviderVersion, "the OpenAPI Terraform provider version should be used if the provider version is not specified")
+}
+
+func TestRenderUserAgent(t *testing.T) {
+	data := userAgentTemplateData{ProviderName: "openapi", ProviderVersion: "1.2.3", TerraformVersion: "1.3.0", OS: "linux", Arch: "amd64", Features: map[string]interface{}{"new_billing": true}}
+	testCases := []struct {
+		name              string
+		userAgent         string
+		expectedUserAgent string
+		expectedError     string
+	}{
+		{name: "static user agent", userAgent: "my-agent/1.0", expectedUserAgent: "my-agent/1.0"},
+		{name: "user agent template", userAgent: "terraform-provider-{{.ProviderName}}/{{.ProviderVersion}} terraform/{{.TerraformVersion}} ({{.OS}}/{{.Arch}})", expectedUserAgent: "terraform-provider-openapi/1.2.3 terraform/1.3.0 (linux/amd64)"},
+		{name: "user agent template with feature flags", userAgent: "openapi/1.0{{if .Features.new_billing}} billing/v2{{end}}", expectedUserAgent: "openapi/1.0 billing/v2"},
+		{name: "user agent template not valid", userAgent: "{{.ProviderName", expectedError: "template: user_agent:1: unclosed action"},
+		{name: "user agent template with unknown field", userAgent: "{{.Unknown}}", expectedError: "template: user_agent:1:2: executing \"user_agent\" at <.Unknown>: can't evaluate field Unknown in type openapi.userAgentTemplateData"},
+	}
+	for _, tc := range testCases {
+		userAgent, err := renderUserAgent(tc.userAgent, data)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedUserAgent, userAgent, tc.name)
+	}
+}
+
+func TestProviderClientGetUserAgent(t *testing.T) {
+	defaultUserAgent := version.BuildUserAgent(runtime.GOOS, runtime.GOARCH)
+	data := userAgentTemplateData{ProviderName: "openapi", ProviderVersion: "1.2.3"}
+	testCases := []struct {
+		name              string
+		userAgent         string
+		operation         *specResourceOperation
+		expectedUserAgent string
+	}{
+		{name: "user agent not configured", operation: &specResourceOperation{}, expectedUserAgent: defaultUserAgent},
+		{name: "service user agent configured", userAgent: "{{.ProviderName}}/{{.ProviderVersion}}", operation: &specResourceOperation{}, expectedUserAgent: "openapi/1.2.3"},
+		{name: "operation user agent configured", userAgent: "{{.ProviderName}}/{{.ProviderVersion}}", operation: &specResourceOperation{userAgent: "{{.ProviderName}}-importer"}, expectedUserAgent: "openapi-importer"},
+		{name: "operation user agent configured without service user agent", operation: &specResourceOperation{userAgent: "{{.ProviderName}}-importer"}, expectedUserAgent: "openapi-importer"},
+		{name: "user agent failing to render", userAgent: "{{.Unknown}}", operation: &specResourceOperation{}, expectedUserAgent: defaultUserAgent},
+		{name: "nil operation", userAgent: "{{.ProviderName}}", operation: nil, expectedUserAgent: "openapi"},
+	}
+	for _, tc := range testCases {
+		providerClient := &ProviderClient{userAgent: tc.userAgent, userAgentTemplateData: data}
+		assert.Equal(t, tc.expectedUserAgent, providerClient.getUserAgent(tc.operation), tc.name)
+	}
+}
+
+func TestProviderClientUserAgentAndIdentificationHeaders(t *testing.T) {
+	var headersReceived http.Header
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headersReceived = r.Header
+		w.Write([]byte(`{"id":"1234"}`))
+	}))
+	defer api.Close()
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		userAgent:                   "terraform-provider-{{.ProviderName}}/{{.ProviderVersion}} terraform/{{.TerraformVersion}}",
+		userAgentTemplateData:       userAgentTemplateData{ProviderName: "openapi", ProviderVersion: "1.2.3", TerraformVersion: "1.3.0"},
+		identificationHeaders:       map[string]string{"X-Client-Id": "platform-team", "Authentication": "overridden"},
+	}
+	resource := &specStubResource{path: "/v1/resource", resourceGetOperation: &specResourceOperation{}}
+	_, err := providerClient.Get(resource, "1234", &map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "terraform-provider-openapi/1.2.3 terraform/1.3.0", headersReceived.Get(userAgentHeader))
+	assert.Equal(t, "platform-team", headersReceived.Get("X-Client-Id"))
+	assert.Equal(t, "Bearer secret!", headersReceived.Get("Authentication"), "identification headers should not override the headers already set")
+}
+
+func TestProviderClientAcceptLanguageHeader(t *testing.T) {
+	var headersReceived http.Header
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headersReceived = r.Header
+		w.Write([]byte(`{"id":"1234"}`))
+	}))
+	defer api.Close()
+	testCases := []struct {
+		name                   string
+		acceptLanguage         string
+		operationHeaders       SpecHeaderParameters
+		headers                map[string]string
+		expectedAcceptLanguage string
+	}{
+		{name: "accept language not configured", acceptLanguage: "", expectedAcceptLanguage: ""},
+		{name: "accept language configured", acceptLanguage: "de-DE, de;q=0.9", expectedAcceptLanguage: "de-DE, de;q=0.9"},
+		{
+			name:                   "accept language header set by the operation",
+			acceptLanguage:         "de-DE",
+			operationHeaders:       SpecHeaderParameters{SpecHeaderParam{Name: "accept-language", TerraformName: "language"}},
+			headers:                map[string]string{"language": "fr-FR"},
+			expectedAcceptLanguage: "fr-FR",
+		},
+	}
+	for _, tc := range testCases {
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newStubAuthenticator(authorizationHeader, "Bearer secret!", nil),
+			providerConfiguration:       providerConfiguration{AcceptLanguage: tc.acceptLanguage, Headers: tc.headers},
+		}
+		resource := &specStubResource{path: "/v1/resource", resourceGetOperation: &specResourceOperation{HeaderParameters: tc.operationHeaders}}
+		_, err := providerClient.Get(resource, "1234", &map[string]interface{}{})
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedAcceptLanguage, headersReceived.Get(acceptLanguageHeader), tc.name)
+	}
+}
@@ -0,0 +1,188 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// webhookDefaultTimeout defines the max time the webhook notifications are allowed to take if the webhook does not
+// configure a timeout
+const webhookDefaultTimeout = 10 * time.Second
+
+// webhookNotification defines the notification POSTed to the webhooks per resource successfully created, updated or deleted
+type webhookNotification struct {
+	Timestamp string `json:"timestamp"`
+	// ResourceType contains the terraform resource type (e,g: cdn_v1), as referred to in the terraform configuration
+	ResourceType string `json:"resource_type"`
+	// ID contains the identifier of the resource; empty if the API did not return it in the create response
+	ID     string `json:"id"`
+	Action string `json:"action"`
+	// Status contains the response status code of the API call that performed the change
+	Status int `json:"status"`
+}
+
+// webhookNotifier POSTs the notifications of the changes performed by the provider to the webhooks configured
+type webhookNotifier struct {
+	webhooks      []WebhookConfig
+	providerName  string
+	namingVersion int
+	httpClient    *http.Client
+}
+
+func newWebhookNotifier(webhooks []WebhookConfig, providerName string, namingVersion int) *webhookNotifier {
+	return &webhookNotifier{
+		webhooks:      webhooks,
+		providerName:  providerName,
+		namingVersion: namingVersion,
+		httpClient:    &http.Client{},
+	}
+}
+
+// notify sends the notification of the action performed on the resource to the webhooks notified of the action. Nothing
+// is sent if the API call failed. Failures notifying the webhooks are logged but do not fail the API call since the
+// change was already applied
+func (w *webhookNotifier) notify(action string, resource SpecResource, id string, resp *http.Response, err error) {
+	if err != nil || resp == nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return
+	}
+	notification := webhookNotification{
+		Timestamp:    time.Now().UTC().Format(time.RFC3339Nano),
+		ResourceType: fmt.Sprintf("%s_%s", w.providerName, GetResourceNameForNamingVersion(resource, w.namingVersion)),
+		ID:           id,
+		Action:       action,
+		Status:       resp.StatusCode,
+	}
+	body, marshalErr := json.Marshal(notification)
+	if marshalErr != nil {
+		clientLog.Error("failed to encode the %s notification of %s '%s': %s", action, notification.ResourceType, id, marshalErr)
+		return
+	}
+	for _, webhook := range w.webhooks {
+		if !webhook.isNotifiedOf(action) {
+			continue
+		}
+		if sendErr := w.send(webhook, body); sendErr != nil {
+			clientLog.Error("failed to notify the webhook '%s' of the %s of %s '%s': %s", webhook.URL, action, notification.ResourceType, id, sendErr)
+		}
+	}
+}
+
+func (w *webhookNotifier) send(webhook WebhookConfig, body []byte) error {
+	timeout := webhookDefaultTimeout
+	if webhook.Timeout != "" {
+		if configuredTimeout, err := time.ParseDuration(webhook.Timeout); err == nil {
+			timeout = configuredTimeout
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, value := range webhook.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set(contentType, "application/json")
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body) // #nosec G104
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook response status code '%d' not matching expected response status code [2XX]", resp.StatusCode)
+	}
+	return nil
+}
+
+// getWebhookResourceID returns the identifier of the resource contained in the create response payload passed in; empty
+// if the payload does not contain it
+func getWebhookResourceID(resource SpecResource, responsePayload interface{}) string {
+	payload, ok := responsePayload.(*map[string]interface{})
+	if !ok || payload == nil {
+		return ""
+	}
+	resourceSchema, err := resource.GetResourceSchema()
+	if err != nil {
+		return ""
+	}
+	identifierProperty, err := resourceSchema.getResourceIdentifier()
+	if err != nil {
+		return ""
+	}
+	value := (*payload)[identifierProperty]
+	if value == nil {
+		if property, err := resourceSchema.getProperty(identifierProperty); err == nil {
+			for _, alias := range property.IdentifierAliases {
+				if value = (*payload)[alias]; value != nil {
+					break
+				}
+			}
+		}
+	}
+	switch id := value.(type) {
+	case int:
+		return strconv.Itoa(id)
+	case float64:
+		return strconv.Itoa(int(id))
+	case string:
+		return id
+	}
+	return ""
+}
+
+// webhookClient is a ClientOpenAPI that notifies the webhooks configured of the resources successfully created (POST),
+// updated (PUT) or deleted (DELETE) by the client it wraps, so external systems (e,g: CMDB or ITSM) can track the changes
+// without parsing the state files
+type webhookClient struct {
+	ClientOpenAPI
+	notifier *webhookNotifier
+	// unbound is the client the copy was bound to the context from; nil if the client is not bound to any context
+	unbound *webhookClient
+}
+
+// withContext returns the webhook client wrapping the underlying client bound to the context passed in
+func (c *webhookClient) withContext(ctx context.Context) ClientOpenAPI {
+	return &webhookClient{
+		ClientOpenAPI: clientWithContext(ctx, c.ClientOpenAPI).(ClientOpenAPI),
+		notifier:      c.notifier,
+		unbound:       c.withoutContext().(*webhookClient),
+	}
+}
+
+// withoutContext returns the client the copy was bound to the context from; the client itself if it is not bound
+func (c *webhookClient) withoutContext() ClientOpenAPI {
+	if c.unbound != nil {
+		return c.unbound
+	}
+	return c
+}
+
+// Post performs the POST call and notifies the webhooks of the resource created
+func (c *webhookClient) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	resp, err := c.ClientOpenAPI.Post(resource, requestPayload, responsePayload, parentIDs...)
+	c.notifier.notify(webhookEventCreate, resource, getWebhookResourceID(resource, responsePayload), resp, err)
+	return resp, err
+}
+
+// Put performs the PUT call and notifies the webhooks of the resource updated
+func (c *webhookClient) Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	resp, err := c.ClientOpenAPI.Put(resource, id, requestPayload, responsePayload, parentIDs...)
+	c.notifier.notify(webhookEventUpdate, resource, id, resp, err)
+	return resp, err
+}
+
+// Delete performs the DELETE call and notifies the webhooks of the resource deleted
+func (c *webhookClient) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	resp, err := c.ClientOpenAPI.Delete(resource, id, parentIDs...)
+	c.notifier.notify(webhookEventDelete, resource, id, resp, err)
+	return resp, err
+}
@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookServerStub struct {
+	mutex         sync.Mutex
+	notifications []webhookNotification
+	headers       []http.Header
+}
+
+func newWebhookServerStub(t *testing.T, statusCode int) (*webhookServerStub, *httptest.Server) {
+	stub := &webhookServerStub{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		notification := webhookNotification{}
+		require.NoError(t, json.Unmarshal(body, &notification))
+		stub.mutex.Lock()
+		stub.notifications = append(stub.notifications, notification)
+		stub.headers = append(stub.headers, r.Header)
+		stub.mutex.Unlock()
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+	return stub, server
+}
+
+func TestWebhookClient(t *testing.T) {
+	allEvents, allEventsServer := newWebhookServerStub(t, http.StatusOK)
+	deleteOnly, deleteOnlyServer := newWebhookServerStub(t, http.StatusNoContent)
+	webhooks := []WebhookConfig{
+		{URL: allEventsServer.URL, Headers: map[string]string{"Authorization": "Bearer cmdb-token"}},
+		{URL: deleteOnlyServer.URL, Events: []string{webhookEventDelete}},
+	}
+	client := &clientOpenAPIStub{responsePayload: map[string]interface{}{"id": "1234", "label": "some label"}}
+	webhookClient := &webhookClient{ClientOpenAPI: client, notifier: newWebhookNotifier(webhooks, "openapi", CurrentResourceNamingVersion)}
+	resource := &specStubResource{
+		name: "cdns_v1",
+		path: "/v1/cdns",
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "label", Type: TypeString},
+			},
+		},
+	}
+	requestPayload := map[string]interface{}{"label": "some label"}
+
+	_, err := webhookClient.Post(resource, requestPayload, &map[string]interface{}{})
+	require.NoError(t, err)
+	_, err = webhookClient.Put(resource, "1234", requestPayload, &map[string]interface{}{})
+	require.NoError(t, err)
+	_, err = webhookClient.Get(resource, "1234", &map[string]interface{}{})
+	require.NoError(t, err)
+	_, err = webhookClient.Delete(resource, "1234")
+	require.NoError(t, err)
+	client.error = errors.New("connection refused")
+	_, err = webhookClient.Delete(resource, "5678")
+	assert.EqualError(t, err, "connection refused")
+
+	require.Len(t, allEvents.notifications, 3, "only the successful mutating API calls should be notified")
+	for i, notification := range allEvents.notifications {
+		_, err := time.Parse(time.RFC3339Nano, notification.Timestamp)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer cmdb-token", allEvents.headers[i].Get("Authorization"))
+		assert.Equal(t, "application/json", allEvents.headers[i].Get(contentType))
+	}
+	assert.Equal(t, webhookNotification{ResourceType: "openapi_cdns_v1", ID: "1234", Action: "create", Status: 201}, withoutNotificationTimestamp(allEvents.notifications[0]))
+	assert.Equal(t, webhookNotification{ResourceType: "openapi_cdns_v1", ID: "1234", Action: "update", Status: 200}, withoutNotificationTimestamp(allEvents.notifications[1]))
+	assert.Equal(t, webhookNotification{ResourceType: "openapi_cdns_v1", ID: "1234", Action: "delete", Status: 204}, withoutNotificationTimestamp(allEvents.notifications[2]))
+
+	require.Len(t, deleteOnly.notifications, 1, "the webhook should only be notified of the events configured")
+	assert.Equal(t, webhookNotification{ResourceType: "openapi_cdns_v1", ID: "1234", Action: "delete", Status: 204}, withoutNotificationTimestamp(deleteOnly.notifications[0]))
+}
+
+func TestWebhookClientNotificationFailure(t *testing.T) {
+	_, server := newWebhookServerStub(t, http.StatusInternalServerError)
+	client := &clientOpenAPIStub{}
+	webhookClient := &webhookClient{ClientOpenAPI: client, notifier: newWebhookNotifier([]WebhookConfig{{URL: server.URL}, {URL: "http://127.0.0.1:0"}}, "openapi", CurrentResourceNamingVersion)}
+	_, err := webhookClient.Delete(&specStubResource{name: "cdns_v1", path: "/v1/cdns"}, "1234")
+	assert.NoError(t, err, "failures notifying the webhooks should not fail the API call")
+}
+
+func TestGetWebhookResourceID(t *testing.T) {
+	resource := &specStubResource{
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeInt, ReadOnly: true, IdentifierAliases: []string{"uuid"}},
+			},
+		},
+	}
+	testCases := []struct {
+		name            string
+		responsePayload interface{}
+		expectedID      string
+	}{
+		{name: "string identifier", responsePayload: &map[string]interface{}{"id": "1234"}, expectedID: "1234"},
+		{name: "numeric identifier", responsePayload: &map[string]interface{}{"id": float64(1234)}, expectedID: "1234"},
+		{name: "identifier alias", responsePayload: &map[string]interface{}{"uuid": "1234"}, expectedID: "1234"},
+		{name: "missing identifier", responsePayload: &map[string]interface{}{"label": "some label"}, expectedID: ""},
+		{name: "unexpected payload", responsePayload: &[]map[string]interface{}{}, expectedID: ""},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedID, getWebhookResourceID(resource, tc.responsePayload), tc.name)
+	}
+}
+
+func withoutNotificationTimestamp(notification webhookNotification) webhookNotification {
+	notification.Timestamp = ""
+	return notification
+}
@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"fmt"
+	"strings"
 )
 
 // SpecAnalyser analyses the swagger doc and provides helper methods to retrieve all the end points that can
@@ -31,6 +32,17 @@ type SpecAnalyser interface {
 	// (e,g: host, protocols, etc) which is then used in the ProviderClient to communicate with the API as specified in
 	// the configuration.
 	GetAPIBackendConfiguration() (SpecBackendConfiguration, error)
+	// GetSpecInfo returns metadata about the loaded OpenAPI document such as the API version and the checksum of the
+	// document content. This information is useful to detect when the document served by the API has changed.
+	GetSpecInfo() SpecInfo
+}
+
+// SpecInfo contains metadata about the OpenAPI document loaded by the SpecAnalyser
+type SpecInfo struct {
+	// Version contains the version of the API as documented in the OpenAPI document (info.version)
+	Version string
+	// Checksum contains the SHA256 checksum (hex encoded) of the raw OpenAPI document
+	Checksum string
 }
 
 // SpecAnalyserVersion defines the type for versions supported in the SpecAnalyser
@@ -39,21 +51,43 @@ type SpecAnalyserVersion string
 const (
 	// specAnalyserV2 version that supports OpenAPI v2 (swagger)
 	specAnalyserV2 SpecAnalyserVersion = "v2"
+	// specAnalyserV3 version that supports OpenAPI v3.0
+	specAnalyserV3 SpecAnalyserVersion = "v3"
 )
 
 // CreateSpecAnalyser is a factory method that returns the appropriate implementation of SpecAnalyser
-// depending upon the openApiSpecAnalyserVersion passed in.
-// Currently only OpenAPI v2 version is supported but this constructor is ready to handle new implementations such as v3
-// when the time comes
+// depending upon the specAnalyserVersion (spec format) passed in. The OpenAPI v2 and v3 versions are supported out of the box
+// and other implementations can be plugged in via RegisterSpecAnalyser. If the specAnalyserVersion is empty, the
+// implementation is selected based on the content of the document (see SpecFormatDetector)
 func CreateSpecAnalyser(specAnalyserVersion SpecAnalyserVersion, openAPIDocumentURL string) (SpecAnalyser, error) {
-	var err error
-	var specAnalyser SpecAnalyser
-	switch specAnalyserVersion {
-	case specAnalyserV2:
-		specAnalyser, err = newSpecAnalyserV2(openAPIDocumentURL)
-	default:
-		return nil, fmt.Errorf("open api spec analyser version '%s' not supported, please choose a valid SpecAnalyser implementation [%s]", specAnalyserVersion, specAnalyserV2)
+	return createSpecAnalyserWithLoader(specAnalyserVersion, openAPIDocumentURL, loadDocument)
+}
+
+// createSpecAnalyserWithLoader creates the SpecAnalyser (see CreateSpecAnalyser) retrieving the document with the loader
+// passed in. The SpecAnalyser implementations registered via RegisterSpecAnalyser retrieve the document themselves
+func createSpecAnalyserWithLoader(specAnalyserVersion SpecAnalyserVersion, openAPIDocumentURL string, load documentLoader) (SpecAnalyser, error) {
+	if specAnalyserVersion == "" {
+		registration, document, err := detectSpecFormat(openAPIDocumentURL, load)
+		if err != nil {
+			return nil, err
+		}
+		if registration.documentFactory != nil {
+			return registration.documentFactory(openAPIDocumentURL, document)
+		}
+		specAnalyserVersion = registration.specFormat
+	}
+	registration, registered := getSpecAnalyserRegistration(specAnalyserVersion)
+	if !registered {
+		return nil, fmt.Errorf("open api spec analyser version '%s' not supported, please choose a valid SpecAnalyser implementation [%s]", specAnalyserVersion, strings.Join(getRegisteredSpecFormats(), ", "))
+	}
+	if registration.documentFactory != nil && openAPIDocumentURL != "" {
+		document, err := load(openAPIDocumentURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, err)
+		}
+		return registration.documentFactory(openAPIDocumentURL, document)
 	}
+	specAnalyser, err := registration.factory(openAPIDocumentURL)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,225 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+)
+
+// specAggregatedDocument defines an OpenAPI document that is part of an aggregated provider
+type specAggregatedDocument struct {
+	url      string
+	analyser SpecAnalyser
+}
+
+// specAggregatedAnalyser implements the SpecAnalyser interface merging the resources and data sources from several OpenAPI
+// documents into a single provider namespace. This is useful when an organization splits its API into several OpenAPI
+// documents (e,g: one per microservice). The first document is considered the primary one and it defines the provider
+// level configuration (e,g: host, base path, multi-region and global security schemes). The resources from the additional
+// documents are served by the host and base path of the document they are defined in and, unless the operations define
+// their own security schemes, use the global security schemes from their document.
+type specAggregatedAnalyser struct {
+	documents []specAggregatedDocument
+}
+
+func newSpecAggregatedAnalyser(documents []specAggregatedDocument) (*specAggregatedAnalyser, error) {
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("at least one OpenAPI document must be provided")
+	}
+	return &specAggregatedAnalyser{documents: documents}, nil
+}
+
+// GetTerraformCompliantResources returns the resources from all the documents. An error is returned if several documents
+// define resources with the same name
+func (a *specAggregatedAnalyser) GetTerraformCompliantResources() ([]SpecResource, error) {
+	var resources []SpecResource
+	resourceDocuments := map[string]string{}
+	for i, document := range a.documents {
+		documentResources, err := document.analyser.GetTerraformCompliantResources()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the resources from the OpenAPI document '%s': %s", document.url, err)
+		}
+		for _, resource := range documentResources {
+			resourceName := resource.GetResourceName()
+			if url, exists := resourceDocuments[resourceName]; exists {
+				return nil, fmt.Errorf("resource name collision: resource '%s' is defined in both '%s' and '%s' OpenAPI documents", resourceName, url, document.url)
+			}
+			resourceDocuments[resourceName] = document.url
+			if i > 0 {
+				resource, err = newSpecAggregatedResource(resource, document.analyser)
+				if err != nil {
+					return nil, fmt.Errorf("failed to aggregate the resource '%s' from the OpenAPI document '%s': %s", resourceName, document.url, err)
+				}
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// GetTerraformCompliantDataSources returns the data sources from all the documents. If several documents define data sources
+// with the same name, only the first one is registered
+func (a *specAggregatedAnalyser) GetTerraformCompliantDataSources() []SpecResource {
+	var dataSources []SpecResource
+	dataSourceDocuments := map[string]string{}
+	for i, document := range a.documents {
+		for _, dataSource := range document.analyser.GetTerraformCompliantDataSources() {
+			dataSourceName := dataSource.GetResourceName()
+			if url, exists := dataSourceDocuments[dataSourceName]; exists {
+				analyserLog.Warn("data source name collision: data source '%s' is defined in both '%s' and '%s' OpenAPI documents, skipping the latter", dataSourceName, url, document.url)
+				continue
+			}
+			dataSourceDocuments[dataSourceName] = document.url
+			if i > 0 {
+				aggregatedDataSource, err := newSpecAggregatedResource(dataSource, document.analyser)
+				if err != nil {
+					analyserLog.Warn("failed to aggregate the data source '%s' from the OpenAPI document '%s', skipping it: %s", dataSourceName, document.url, err)
+					continue
+				}
+				dataSource = aggregatedDataSource
+			}
+			dataSources = append(dataSources, dataSource)
+		}
+	}
+	return dataSources
+}
+
+// GetSecurity returns the security definitions from all the documents and the global security schemes from the primary one
+func (a *specAggregatedAnalyser) GetSecurity() SpecSecurity {
+	return specAggregatedSecurity{documents: a.documents}
+}
+
+// GetAllHeaderParameters returns the header parameters from all the documents
+func (a *specAggregatedAnalyser) GetAllHeaderParameters() SpecHeaderParameters {
+	headers := SpecHeaderParameters{}
+	for _, document := range a.documents {
+		for _, header := range document.analyser.GetAllHeaderParameters() {
+			if headers.specHeaderExists(header) {
+				continue
+			}
+			headers = append(headers, header)
+		}
+	}
+	return headers
+}
+
+// GetAPIBackendConfiguration returns the backend configuration from the primary document
+func (a *specAggregatedAnalyser) GetAPIBackendConfiguration() (SpecBackendConfiguration, error) {
+	return a.documents[0].analyser.GetAPIBackendConfiguration()
+}
+
+// GetSpecInfo returns the API version from the primary document and a checksum computed from the checksums of all the documents
+func (a *specAggregatedAnalyser) GetSpecInfo() SpecInfo {
+	checksum := sha256.New()
+	for _, document := range a.documents {
+		checksum.Write([]byte(document.analyser.GetSpecInfo().Checksum))
+	}
+	return SpecInfo{
+		Version:  a.documents[0].analyser.GetSpecInfo().Version,
+		Checksum: hex.EncodeToString(checksum.Sum(nil)),
+	}
+}
+
+// specAggregatedSecurity implements the SpecSecurity interface for aggregated providers
+type specAggregatedSecurity struct {
+	documents []specAggregatedDocument
+}
+
+// GetAPIKeySecurityDefinitions returns the security definitions from all the documents. Security definitions with the same
+// name are shared across the documents (and therefore configured once in the provider) as long as they are equivalent
+func (s specAggregatedSecurity) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinitions, error) {
+	securityDefinitions := SpecSecurityDefinitions{}
+	securityDefinitionDocuments := map[string]string{}
+	for _, document := range s.documents {
+		documentSecurityDefinitions, err := document.analyser.GetSecurity().GetAPIKeySecurityDefinitions()
+		if err != nil {
+			return nil, err
+		}
+		for _, securityDefinition := range *documentSecurityDefinitions {
+			existing := securityDefinitions.findSecurityDefinitionFor(securityDefinition.getName())
+			if existing == nil {
+				securityDefinitionDocuments[securityDefinition.getName()] = document.url
+				securityDefinitions = append(securityDefinitions, securityDefinition)
+				continue
+			}
+			if existing.getType() != securityDefinition.getType() || !reflect.DeepEqual(existing.getAPIKey(), securityDefinition.getAPIKey()) {
+				return nil, fmt.Errorf("security definition name collision: security definition '%s' is defined differently in '%s' and '%s' OpenAPI documents", securityDefinition.getName(), securityDefinitionDocuments[securityDefinition.getName()], document.url)
+			}
+		}
+	}
+	return &securityDefinitions, nil
+}
+
+// GetGlobalSecuritySchemes returns the global security schemes from the primary document
+func (s specAggregatedSecurity) GetGlobalSecuritySchemes() (SpecSecuritySchemes, error) {
+	return s.documents[0].analyser.GetSecurity().GetGlobalSecuritySchemes()
+}
+
+// GetSecurityDefinitionDescription returns the description of the security definition with the given name from the first
+// document documenting it
+func (s specAggregatedSecurity) GetSecurityDefinitionDescription(securityDefinitionName string) string {
+	for _, document := range s.documents {
+		if description := document.analyser.GetSecurity().GetSecurityDefinitionDescription(securityDefinitionName); description != "" {
+			return description
+		}
+	}
+	return ""
+}
+
+// specAggregatedResource decorates the resources from the additional documents of an aggregated provider so the API calls
+// are made against the host and base path of the document the resource is defined in, using the global security schemes
+// of the document unless the operations define their own security schemes
+type specAggregatedResource struct {
+	SpecResource
+	backendConfiguration  SpecBackendConfiguration
+	globalSecuritySchemes SpecSecuritySchemes
+}
+
+func newSpecAggregatedResource(resource SpecResource, analyser SpecAnalyser) (*specAggregatedResource, error) {
+	backendConfiguration, err := analyser.GetAPIBackendConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	globalSecuritySchemes, err := analyser.GetSecurity().GetGlobalSecuritySchemes()
+	if err != nil {
+		return nil, err
+	}
+	return &specAggregatedResource{
+		SpecResource:          resource,
+		backendConfiguration:  backendConfiguration,
+		globalSecuritySchemes: globalSecuritySchemes,
+	}, nil
+}
+
+// getHost returns the resource host override (x-terraform-resource-host) if configured; otherwise the host of the document
+// the resource is defined in
+func (r *specAggregatedResource) getHost() (string, error) {
+	host, err := r.SpecResource.getHost()
+	if err != nil || host != "" {
+		return host, err
+	}
+	return r.backendConfiguration.getHost()
+}
+
+func (r *specAggregatedResource) getResourceOperations() specResourceOperations {
+	operations := r.SpecResource.getResourceOperations()
+	return specResourceOperations{
+		List:   r.withGlobalSecuritySchemes(operations.List),
+		Post:   r.withGlobalSecuritySchemes(operations.Post),
+		Get:    r.withGlobalSecuritySchemes(operations.Get),
+		Put:    r.withGlobalSecuritySchemes(operations.Put),
+		Delete: r.withGlobalSecuritySchemes(operations.Delete),
+	}
+}
+
+// withGlobalSecuritySchemes returns a copy of the operation using the global security schemes of the document the resource
+// is defined in if the operation does not define its own security schemes
+func (r *specAggregatedResource) withGlobalSecuritySchemes(operation *specResourceOperation) *specResourceOperation {
+	if operation == nil || len(operation.SecuritySchemes) > 0 || len(r.globalSecuritySchemes) == 0 {
+		return operation
+	}
+	aggregatedOperation := *operation
+	aggregatedOperation.SecuritySchemes = r.globalSecuritySchemes
+	return &aggregatedOperation
+}
@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecAggregatedAnalyserGetTerraformCompliantResources(t *testing.T) {
+	cdnResource := &specStubResource{name: "cdn_v1", resourceGetOperation: &specResourceOperation{}}
+	firewallResource := &specStubResource{
+		name:                 "firewall_v1",
+		resourceGetOperation: &specResourceOperation{},
+		resourcePostOperation: &specResourceOperation{
+			SecuritySchemes: SpecSecuritySchemes{{Name: "operation_apikey"}},
+		},
+	}
+	primary := &specAnalyserStub{resources: []SpecResource{cdnResource}}
+	additional := &specAnalyserStub{
+		resources:            []SpecResource{firewallResource},
+		backendConfiguration: newStubBackendConfiguration("firewall.api.com", "/api", "https"),
+		security:             &specSecurityStub{globalSecuritySchemes: SpecSecuritySchemes{{Name: "firewall_apikey"}}},
+	}
+	analyser, err := newSpecAggregatedAnalyser([]specAggregatedDocument{{url: "cdn.yaml", analyser: primary}, {url: "firewall.yaml", analyser: additional}})
+	require.NoError(t, err)
+
+	resources, err := analyser.GetTerraformCompliantResources()
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Equal(t, cdnResource, resources[0], "resources from the primary document should not be decorated")
+
+	aggregatedResource, ok := resources[1].(*specAggregatedResource)
+	require.True(t, ok)
+	assert.Equal(t, "firewall_v1", aggregatedResource.GetResourceName())
+	host, err := aggregatedResource.getHost()
+	require.NoError(t, err)
+	assert.Equal(t, "firewall.api.com", host)
+	operations := aggregatedResource.getResourceOperations()
+	assert.Equal(t, SpecSecuritySchemes{{Name: "firewall_apikey"}}, operations.Get.SecuritySchemes, "operations without security schemes should use the document global security schemes")
+	assert.Equal(t, SpecSecuritySchemes{{Name: "operation_apikey"}}, operations.Post.SecuritySchemes, "operations with security schemes should keep them")
+	assert.Nil(t, operations.Put)
+	assert.Empty(t, firewallResource.resourceGetOperation.SecuritySchemes, "the original operation should not be mutated")
+}
+
+func TestSpecAggregatedAnalyserGetTerraformCompliantResourcesErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		additional    *specAnalyserStub
+		expectedError string
+	}{
+		{
+			name:          "resource name collision",
+			additional:    &specAnalyserStub{resources: []SpecResource{&specStubResource{name: "cdn_v1"}}},
+			expectedError: "resource name collision: resource 'cdn_v1' is defined in both 'cdn.yaml' and 'other.yaml' OpenAPI documents",
+		},
+		{
+			name:          "additional document returns an error",
+			additional:    &specAnalyserStub{error: errors.New("some error")},
+			expectedError: "failed to get the resources from the OpenAPI document 'other.yaml': some error",
+		},
+	}
+	for _, tc := range testCases {
+		primary := &specAnalyserStub{resources: []SpecResource{&specStubResource{name: "cdn_v1"}}}
+		analyser, err := newSpecAggregatedAnalyser([]specAggregatedDocument{{url: "cdn.yaml", analyser: primary}, {url: "other.yaml", analyser: tc.additional}})
+		require.NoError(t, err)
+		_, err = analyser.GetTerraformCompliantResources()
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
+
+func TestSpecAggregatedAnalyserGetTerraformCompliantDataSources(t *testing.T) {
+	primary := &specAnalyserStub{dataSources: []SpecResource{&specStubResource{name: "cdn_v1"}}}
+	additional := &specAnalyserStub{
+		dataSources:          []SpecResource{&specStubResource{name: "cdn_v1"}, &specStubResource{name: "firewall_v1"}},
+		backendConfiguration: newStubBackendConfiguration("firewall.api.com", "", "https"),
+		security:             &specSecurityStub{},
+	}
+	analyser, err := newSpecAggregatedAnalyser([]specAggregatedDocument{{url: "cdn.yaml", analyser: primary}, {url: "firewall.yaml", analyser: additional}})
+	require.NoError(t, err)
+	dataSources := analyser.GetTerraformCompliantDataSources()
+	require.Len(t, dataSources, 2)
+	assert.Equal(t, primary.dataSources[0], dataSources[0])
+	assert.Equal(t, "firewall_v1", dataSources[1].GetResourceName())
+}
+
+func TestSpecAggregatedAnalyserGetSecurity(t *testing.T) {
+	apiKeyHeader := newAPIKeyHeaderSecurityDefinition("apikey", authorizationHeader)
+	primary := &specAnalyserStub{
+		security: &specSecurityStub{
+			securityDefinitions:   &SpecSecurityDefinitions{apiKeyHeader},
+			globalSecuritySchemes: SpecSecuritySchemes{{Name: "apikey"}},
+		},
+	}
+	testCases := []struct {
+		name                        string
+		additionalSecurity          *specSecurityStub
+		expectedSecurityDefinitions *SpecSecurityDefinitions
+		expectedError               string
+	}{
+		{
+			name:                        "security definitions are merged and equivalent ones shared",
+			additionalSecurity:          &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{newAPIKeyHeaderSecurityDefinition("apikey", authorizationHeader), newAPIKeyQuerySecurityDefinition("firewall_token", "token")}},
+			expectedSecurityDefinitions: &SpecSecurityDefinitions{apiKeyHeader, newAPIKeyQuerySecurityDefinition("firewall_token", "token")},
+		},
+		{
+			name:               "security definition name collision",
+			additionalSecurity: &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{newAPIKeyQuerySecurityDefinition("apikey", "token")}},
+			expectedError:      "security definition name collision: security definition 'apikey' is defined differently in 'cdn.yaml' and 'firewall.yaml' OpenAPI documents",
+		},
+	}
+	for _, tc := range testCases {
+		analyser, err := newSpecAggregatedAnalyser([]specAggregatedDocument{{url: "cdn.yaml", analyser: primary}, {url: "firewall.yaml", analyser: &specAnalyserStub{security: tc.additionalSecurity}}})
+		require.NoError(t, err)
+		securityDefinitions, err := analyser.GetSecurity().GetAPIKeySecurityDefinitions()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedSecurityDefinitions, securityDefinitions, tc.name)
+		globalSecuritySchemes, err := analyser.GetSecurity().GetGlobalSecuritySchemes()
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, SpecSecuritySchemes{{Name: "apikey"}}, globalSecuritySchemes, tc.name)
+	}
+}
+
+func TestSpecAggregatedAnalyserGetAllHeaderParameters(t *testing.T) {
+	primary := &specAnalyserStub{headers: SpecHeaderParameters{{Name: "X-Request-ID"}}}
+	additional := &specAnalyserStub{headers: SpecHeaderParameters{{Name: "X-Request-ID"}, {Name: "X-Firewall-Zone"}}}
+	analyser, err := newSpecAggregatedAnalyser([]specAggregatedDocument{{url: "cdn.yaml", analyser: primary}, {url: "firewall.yaml", analyser: additional}})
+	require.NoError(t, err)
+	assert.Equal(t, SpecHeaderParameters{{Name: "X-Request-ID"}, {Name: "X-Firewall-Zone"}}, analyser.GetAllHeaderParameters())
+}
+
+func TestSpecAggregatedAnalyserGetSpecInfo(t *testing.T) {
+	primary := &specAnalyserStub{specInfo: SpecInfo{Version: "1.0.0", Checksum: "checksum1"}}
+	additional := &specAnalyserStub{specInfo: SpecInfo{Version: "2.0.0", Checksum: "checksum2"}}
+	analyser, err := newSpecAggregatedAnalyser([]specAggregatedDocument{{url: "cdn.yaml", analyser: primary}, {url: "firewall.yaml", analyser: additional}})
+	require.NoError(t, err)
+	specInfo := analyser.GetSpecInfo()
+	assert.Equal(t, "1.0.0", specInfo.Version)
+	assert.Len(t, specInfo.Checksum, 64)
+	assert.NotEqual(t, specInfo.Checksum, (&specAggregatedAnalyser{documents: []specAggregatedDocument{{analyser: primary}}}).GetSpecInfo().Checksum)
+}
+
+func TestCreateSchemaProviderFromServiceConfigurationAggregatingSpecs(t *testing.T) {
+	specTemplate := `swagger: "2.0"
+host: %[1]s
+paths:
+  /v1/%[2]s:
+    post:
+      parameters:
+      - in: body
+        name: body
+        schema:
+          $ref: "#/definitions/Resource"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/Resource"
+  /v1/%[2]s/{id}:
+    get:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        type: string
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Resource"
+definitions:
+  Resource:
+    type: object
+    properties:
+      id:
+        type: string
+        readOnly: true`
+	writeSpec := func(host, resource string) string {
+		file, err := ioutil.TempFile("", "aggregated_spec.yaml")
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(file.Name(), []byte(fmt.Sprintf(specTemplate, host, resource)), 0600))
+		return file.Name()
+	}
+	cdnSpec := writeSpec("cdn.api.com", "cdns")
+	defer os.Remove(cdnSpec)
+	firewallSpec := writeSpec("firewall.api.com", "firewalls")
+	defer os.Remove(firewallSpec)
+	otherCDNSpec := writeSpec("other.api.com", "cdns")
+	defer os.Remove(otherCDNSpec)
+
+	p := ProviderOpenAPI{ProviderName: "foo"}
+	provider, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: cdnSpec, AdditionalSwaggerURLs: []string{firewallSpec}})
+	require.NoError(t, err)
+	assert.Contains(t, provider.ResourcesMap, "foo_cdns_v1")
+	assert.Contains(t, provider.ResourcesMap, "foo_firewalls_v1")
+
+	p = ProviderOpenAPI{ProviderName: "foo"}
+	_, err = p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: cdnSpec, AdditionalSwaggerURLs: []string{otherCDNSpec}})
+	assert.EqualError(t, err, fmt.Sprintf("plugin terraform-provider-foo init error while creating schema provider: resource name collision: resource 'cdns_v1' is defined in both '%s' and '%s' OpenAPI documents", cdnSpec, otherCDNSpec))
+}
@@ -0,0 +1,216 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/loads"
+	"gopkg.in/yaml.v2"
+)
+
+// SpecAnalyserFactory creates a SpecAnalyser for the document located in the URL (or path to a file stored on disk) passed in.
+// Custom spec dialects can be supported by converting the document on the fly into an OpenAPI v2 document and returning
+// the SpecAnalyser created via CreateSpecAnalyser("v2", convertedOpenAPIDocumentPath)
+type SpecAnalyserFactory func(openAPIDocumentURL string) (SpecAnalyser, error)
+
+// SpecFormatDetector returns true if the document content passed in is in the format supported by the SpecAnalyser. This is
+// used to select the SpecAnalyser when the spec format is not configured
+type SpecFormatDetector func(document []byte) bool
+
+type specAnalyserRegistration struct {
+	specFormat SpecAnalyserVersion
+	factory    SpecAnalyserFactory
+	// documentFactory creates the SpecAnalyser from the document content already loaded to detect the spec format, so
+	// the document is not retrieved twice; nil if the factory retrieves the document itself (e,g: registered spec dialects)
+	documentFactory func(openAPIDocumentURL string, document []byte) (SpecAnalyser, error)
+	detector        SpecFormatDetector
+}
+
+// specAnalyserRegistry contains the SpecAnalyser implementations available in the registration order
+var specAnalyserRegistry = struct {
+	sync.RWMutex
+	registrations []specAnalyserRegistration
+}{
+	registrations: []specAnalyserRegistration{
+		{
+			specFormat: specAnalyserV2,
+			factory: func(openAPIDocumentURL string) (SpecAnalyser, error) {
+				return newSpecAnalyserV2(openAPIDocumentURL)
+			},
+			documentFactory: func(openAPIDocumentURL string, document []byte) (SpecAnalyser, error) {
+				return newSpecAnalyserV2FromDocument(openAPIDocumentURL, document)
+			},
+			detector: isOpenAPIV2Document,
+		},
+		{
+			specFormat: specAnalyserV3,
+			factory: func(openAPIDocumentURL string) (SpecAnalyser, error) {
+				return newSpecAnalyserV3(openAPIDocumentURL)
+			},
+			documentFactory: func(openAPIDocumentURL string, document []byte) (SpecAnalyser, error) {
+				return newSpecAnalyserV3FromDocument(openAPIDocumentURL, document)
+			},
+			detector: isOpenAPIV3Document,
+		},
+	},
+}
+
+// RegisterSpecAnalyser registers a SpecAnalyser implementation for the given spec format, enabling third parties to plug
+// custom spec dialects without forking the provider. The SpecAnalyser is used when the service configuration specifies the
+// spec format (spec_format) or, if not specified, when the detector (optional) recognises the document content. This function
+// is meant to be called before the provider is created (e,g: in the main function of the provider binary)
+func RegisterSpecAnalyser(specFormat SpecAnalyserVersion, factory SpecAnalyserFactory, detector SpecFormatDetector) error {
+	if specFormat == "" {
+		return errors.New("spec analyser registration failed: spec format must not be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("spec analyser registration failed: factory for spec format '%s' must not be nil", specFormat)
+	}
+	specAnalyserRegistry.Lock()
+	defer specAnalyserRegistry.Unlock()
+	for _, registration := range specAnalyserRegistry.registrations {
+		if registration.specFormat == specFormat {
+			return fmt.Errorf("spec analyser registration failed: spec format '%s' is already registered", specFormat)
+		}
+	}
+	specAnalyserRegistry.registrations = append(specAnalyserRegistry.registrations, specAnalyserRegistration{specFormat: specFormat, factory: factory, detector: detector})
+	analyserLog.Info("spec analyser registered for spec format '%s'", specFormat)
+	return nil
+}
+
+func getSpecAnalyserRegistration(specFormat SpecAnalyserVersion) (specAnalyserRegistration, bool) {
+	specAnalyserRegistry.RLock()
+	defer specAnalyserRegistry.RUnlock()
+	for _, registration := range specAnalyserRegistry.registrations {
+		if registration.specFormat == specFormat {
+			return registration, true
+		}
+	}
+	return specAnalyserRegistration{}, false
+}
+
+func getSpecAnalyserRegistrations() []specAnalyserRegistration {
+	specAnalyserRegistry.RLock()
+	defer specAnalyserRegistry.RUnlock()
+	registrations := make([]specAnalyserRegistration, len(specAnalyserRegistry.registrations))
+	copy(registrations, specAnalyserRegistry.registrations)
+	return registrations
+}
+
+func getRegisteredSpecFormats() []string {
+	var specFormats []string
+	for _, registration := range getSpecAnalyserRegistrations() {
+		specFormats = append(specFormats, string(registration.specFormat))
+	}
+	return specFormats
+}
+
+// detectSpecFormat returns the registration of the first registered SpecAnalyser whose detector recognises the document
+// content, along with the document content retrieved so it can be reused to create the SpecAnalyser
+func detectSpecFormat(openAPIDocumentURL string, load documentLoader) (specAnalyserRegistration, []byte, error) {
+	registrations := getSpecAnalyserRegistrations()
+	document, err := load(openAPIDocumentURL)
+	if err != nil {
+		return specAnalyserRegistration{}, nil, fmt.Errorf("failed to retrieve the document from '%s' to detect its spec format - error = %s", openAPIDocumentURL, err)
+	}
+	for _, registration := range registrations {
+		if registration.detector != nil && registration.detector(document) {
+			analyserLog.Debug("document '%s' detected as spec format '%s'", openAPIDocumentURL, registration.specFormat)
+			return registration, document, nil
+		}
+	}
+	return specAnalyserRegistration{}, nil, fmt.Errorf("the spec format of the document '%s' could not be detected, please configure the spec format explicitly [%s]", openAPIDocumentURL, strings.Join(getRegisteredSpecFormats(), ", "))
+}
+
+// documentLoader returns the content of the document located in the URL or path to a file stored on disk passed in
+type documentLoader func(documentURL string) ([]byte, error)
+
+// documentLoadTimeout defines the timeout of the requests retrieving the remote documents, which matches the one go-openapi
+// retrieves the remote documents with
+const documentLoadTimeout = 30 * time.Second
+
+// loadDocument returns the content of the document located in the URL or path to a file stored on disk passed in. The
+// remote documents are retrieved with the loader of go-openapi, which relies on the default transport
+func loadDocument(documentURL string) ([]byte, error) {
+	if document, ok := getInMemoryDocument(documentURL); ok {
+		return document, nil
+	}
+	if !isRemoteDocument(documentURL) {
+		return ioutil.ReadFile(documentURL)
+	}
+	return loads.JSONDoc(documentURL)
+}
+
+// newDocumentLoader returns the loader retrieving the remote documents with the transport passed in (e,g: the provider
+// transport configured with the plugin TLS and dialer settings) instead of the default transport
+func newDocumentLoader(transport http.RoundTripper) documentLoader {
+	httpClient := &http.Client{Transport: transport, Timeout: documentLoadTimeout}
+	return func(documentURL string) ([]byte, error) {
+		if document, ok := getInMemoryDocument(documentURL); ok {
+			return document, nil
+		}
+		if !isRemoteDocument(documentURL) {
+			return ioutil.ReadFile(documentURL)
+		}
+		resp, err := httpClient.Get(documentURL)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("could not access document at %q [%s]", documentURL, resp.Status)
+		}
+		return ioutil.ReadAll(resp.Body)
+	}
+}
+
+func isRemoteDocument(documentURL string) bool {
+	return strings.HasPrefix(documentURL, "http://") || strings.HasPrefix(documentURL, "https://")
+}
+
+// inMemoryDocumentScheme defines the scheme of the URLs identifying the OpenAPI documents held in memory (e,g: embedded
+// specs or specs provided via environment variable) so they can be loaded without reading from or writing to disk
+const inMemoryDocumentScheme = "memory:"
+
+// inMemoryDocuments contains the content of the OpenAPI documents held in memory keyed by their in memory document URL
+var inMemoryDocuments sync.Map
+
+// registerInMemoryDocument stores the document content passed in and returns the URL that identifies it, which can be used
+// as the swagger URL of the service configuration. The URL is based on the content checksum so registering the same
+// document more than once returns the same URL
+func registerInMemoryDocument(name string, document []byte) string {
+	checksum := sha256.Sum256(document)
+	documentURL := fmt.Sprintf("%s%s-%x", inMemoryDocumentScheme, strings.ToLower(name), checksum[:8])
+	inMemoryDocuments.Store(documentURL, document)
+	return documentURL
+}
+
+// getInMemoryDocument returns the content of the in memory document identified by the URL passed in and whether the
+// document was found
+func getInMemoryDocument(documentURL string) ([]byte, bool) {
+	if !strings.HasPrefix(documentURL, inMemoryDocumentScheme) {
+		return nil, false
+	}
+	document, ok := inMemoryDocuments.Load(documentURL)
+	if !ok {
+		return nil, false
+	}
+	return document.([]byte), true
+}
+
+// isOpenAPIV2Document returns true if the document passed in (JSON or YAML) is an OpenAPI v2 (swagger) document
+func isOpenAPIV2Document(document []byte) bool {
+	doc := struct {
+		Swagger string `yaml:"swagger"`
+	}{}
+	if err := yaml.Unmarshal(document, &doc); err != nil {
+		return false
+	}
+	return doc.Swagger == "2.0"
+}
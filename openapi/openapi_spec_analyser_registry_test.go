@@ -0,0 +1,214 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreSpecAnalyserRegistry restores the spec analyser registry to the state it was before the test was executed
+func restoreSpecAnalyserRegistry(t *testing.T) {
+	registrations := getSpecAnalyserRegistrations()
+	t.Cleanup(func() {
+		specAnalyserRegistry.Lock()
+		defer specAnalyserRegistry.Unlock()
+		specAnalyserRegistry.registrations = registrations
+	})
+}
+
+func TestRegisterSpecAnalyser(t *testing.T) {
+	restoreSpecAnalyserRegistry(t)
+	factory := func(openAPIDocumentURL string) (SpecAnalyser, error) { return &specAnalyserStub{}, nil }
+	testCases := []struct {
+		name          string
+		specFormat    SpecAnalyserVersion
+		factory       SpecAnalyserFactory
+		expectedError string
+	}{
+		{name: "spec analyser registered", specFormat: "custom", factory: factory},
+		{name: "empty spec format", specFormat: "", factory: factory, expectedError: "spec analyser registration failed: spec format must not be empty"},
+		{name: "nil factory", specFormat: "other", factory: nil, expectedError: "spec analyser registration failed: factory for spec format 'other' must not be nil"},
+		{name: "spec format already registered", specFormat: "custom", factory: factory, expectedError: "spec analyser registration failed: spec format 'custom' is already registered"},
+		{name: "built-in spec format", specFormat: specAnalyserV2, factory: factory, expectedError: "spec analyser registration failed: spec format 'v2' is already registered"},
+	}
+	for _, tc := range testCases {
+		err := RegisterSpecAnalyser(tc.specFormat, tc.factory, nil)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+	}
+	assert.Equal(t, []string{"v2", "v3", "custom"}, getRegisteredSpecFormats())
+}
+
+func TestCreateSpecAnalyserWithRegisteredSpecAnalyser(t *testing.T) {
+	restoreSpecAnalyserRegistry(t)
+	customSpecAnalyser := &specAnalyserStub{}
+	var documentURLReceived string
+	err := RegisterSpecAnalyser("custom", func(openAPIDocumentURL string) (SpecAnalyser, error) {
+		documentURLReceived = openAPIDocumentURL
+		if strings.Contains(openAPIDocumentURL, "broken") {
+			return nil, errors.New("some error")
+		}
+		return customSpecAnalyser, nil
+	}, func(document []byte) bool {
+		return strings.HasPrefix(string(document), "custom-idl")
+	})
+	assert.NoError(t, err)
+
+	customFile := initAPISpecFile("custom-idl: some service")
+	defer os.Remove(customFile.Name())
+	swaggerFile := initAPISpecFile(`{"swagger": "2.0"}`)
+	defer os.Remove(swaggerFile.Name())
+	unknownFile := initAPISpecFile(`asyncapi: "2.0.0"`)
+	defer os.Remove(unknownFile.Name())
+
+	testCases := []struct {
+		name                 string
+		specFormat           SpecAnalyserVersion
+		documentURL          string
+		expectedSpecAnalyser SpecAnalyser
+		expectedV2Analyser   bool
+		expectedError        string
+	}{
+		{
+			name:                 "spec format configured",
+			specFormat:           "custom",
+			documentURL:          "http://some-api.com/service.idl",
+			expectedSpecAnalyser: customSpecAnalyser,
+		},
+		{
+			name:          "spec format configured and the registered factory fails",
+			specFormat:    "custom",
+			documentURL:   "http://some-api.com/broken.idl",
+			expectedError: "some error",
+		},
+		{
+			name:                 "spec format not configured and document detected as custom format",
+			specFormat:           "",
+			documentURL:          customFile.Name(),
+			expectedSpecAnalyser: customSpecAnalyser,
+		},
+		{
+			name:               "spec format not configured and document detected as OpenAPI v2",
+			specFormat:         "",
+			documentURL:        swaggerFile.Name(),
+			expectedV2Analyser: true,
+		},
+		{
+			name:          "spec format not configured and document format not detected",
+			specFormat:    "",
+			documentURL:   unknownFile.Name(),
+			expectedError: "the spec format of the document '" + unknownFile.Name() + "' could not be detected, please configure the spec format explicitly [v2, v3, custom]",
+		},
+		{
+			name:          "spec format not configured and document can not be loaded",
+			specFormat:    "",
+			documentURL:   "some non existing file",
+			expectedError: "failed to retrieve the document from 'some non existing file' to detect its spec format - error = open some non existing file: no such file or directory",
+		},
+		{
+			name:          "spec format not registered",
+			specFormat:    "other",
+			documentURL:   customFile.Name(),
+			expectedError: "open api spec analyser version 'other' not supported, please choose a valid SpecAnalyser implementation [v2, v3, custom]",
+		},
+	}
+	for _, tc := range testCases {
+		documentURLReceived = ""
+		specAnalyser, err := CreateSpecAnalyser(tc.specFormat, tc.documentURL)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		if tc.expectedV2Analyser {
+			assert.IsType(t, &specV2Analyser{}, specAnalyser, tc.name)
+			continue
+		}
+		assert.Equal(t, tc.expectedSpecAnalyser, specAnalyser, tc.name)
+		assert.Equal(t, tc.documentURL, documentURLReceived, tc.name)
+	}
+}
+
+func TestCreateSpecAnalyserWithoutSpecFormatDefaultsToOpenAPIV2(t *testing.T) {
+	file := initAPISpecFile(`swagger: "2.0"`)
+	defer os.Remove(file.Name())
+	specAnalyser, err := CreateSpecAnalyser("", file.Name())
+	assert.NoError(t, err)
+	assert.IsType(t, &specV2Analyser{}, specAnalyser)
+}
+
+func TestCreateSpecAnalyserWithoutSpecFormatRetrievesDocumentOnce(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		document             string
+		expectedSpecAnalyser SpecAnalyser
+	}{
+		{name: "OpenAPI v2 document", document: `{"swagger":"2.0","info":{"title":"some api","version":"1.0.0"},"paths":{}}`, expectedSpecAnalyser: &specV2Analyser{}},
+		{name: "OpenAPI v3 document", document: `{"openapi":"3.0.0","info":{"title":"some api","version":"1.0.0"},"paths":{}}`, expectedSpecAnalyser: &specV3Analyser{}},
+	}
+	for _, tc := range testCases {
+		requests := 0
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Write([]byte(tc.document))
+		}))
+		specAnalyser, err := CreateSpecAnalyser("", api.URL+"/openapi.json")
+		api.Close()
+		require.NoError(t, err, tc.name)
+		assert.IsType(t, tc.expectedSpecAnalyser, specAnalyser, tc.name)
+		assert.Equal(t, 1, requests, "%s: the document retrieved to detect the spec format should be reused", tc.name)
+		backendConfiguration, err := specAnalyser.GetAPIBackendConfiguration()
+		require.NoError(t, err, tc.name)
+		host, err := backendConfiguration.getHost()
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, strings.TrimPrefix(api.URL, "http://"), host, "%s: the host should fall back to where the document is served from", tc.name)
+	}
+}
+
+func TestIsOpenAPIV2Document(t *testing.T) {
+	testCases := []struct {
+		name     string
+		document string
+		expected bool
+	}{
+		{name: "OpenAPI v2 YAML document", document: "swagger: \"2.0\"\ninfo:\n  title: some api", expected: true},
+		{name: "OpenAPI v2 JSON document", document: `{"swagger":"2.0","info":{"title":"some api"}}`, expected: true},
+		{name: "OpenAPI v3 document", document: `openapi: "3.0.0"`, expected: false},
+		{name: "document that is not JSON nor YAML", document: "{not valid", expected: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, isOpenAPIV2Document([]byte(tc.document)), tc.name)
+	}
+}
+
+func TestInMemoryDocuments(t *testing.T) {
+	document := []byte("swagger: \"2.0\"\nhost: localhost:8443\npaths: {}")
+	documentURL := registerInMemoryDocument("Foo", document)
+	assert.Regexp(t, `^memory:foo-[0-9a-f]{16}$`, documentURL)
+	assert.Equal(t, documentURL, registerInMemoryDocument("Foo", document))
+	assert.NotEqual(t, documentURL, registerInMemoryDocument("Foo", []byte(`swagger: "2.0"`)))
+
+	loadedDocument, err := loadDocument(documentURL)
+	assert.NoError(t, err)
+	assert.Equal(t, document, loadedDocument)
+
+	_, ok := getInMemoryDocument("memory:bar-0123456789abcdef")
+	assert.False(t, ok)
+
+	specAnalyser, err := CreateSpecAnalyser("", documentURL)
+	assert.NoError(t, err)
+	backendConfiguration, err := specAnalyser.GetAPIBackendConfiguration()
+	assert.NoError(t, err)
+	host, err := backendConfiguration.getHost()
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:8443", host)
+}
@@ -7,6 +7,7 @@ type specAnalyserStub struct {
 	security             *specSecurityStub
 	headers              SpecHeaderParameters
 	backendConfiguration SpecBackendConfiguration
+	specInfo             SpecInfo
 	error                error
 }
 
@@ -35,3 +36,7 @@ func (s *specAnalyserStub) GetAPIBackendConfiguration() (SpecBackendConfiguratio
 	}
 	return s.backendConfiguration, nil
 }
+
+func (s *specAnalyserStub) GetSpecInfo() SpecInfo {
+	return s.specInfo
+}
@@ -2,7 +2,6 @@ package openapi
 
 import (
 	"fmt"
-	"log"
 )
 
 // apiAuth is an implementation of specAuthenticator encapsulating the general settings to be applied in case
@@ -24,12 +23,12 @@ func newAPIAuthenticator(globalSecuritySchemes *SpecSecuritySchemes) specAuthent
 func (oa apiAuth) authRequired(url string, operationSecuritySchemes SpecSecuritySchemes) (bool, SpecSecuritySchemes) {
 	// TODO: check in the OpenAPI spec whether operation overrides global schemes or can complement global configuration?
 	if len(operationSecuritySchemes) != 0 {
-		log.Printf("operation security policies found for '%s' (overriding global security config if applicable). Selected the following based on order of appearance in the list %+v", url, operationSecuritySchemes)
+		clientLog.Debug("operation security policies found for '%s' (overriding global security config if applicable). Selected the following based on order of appearance in the list %+v", url, operationSecuritySchemes)
 		return true, operationSecuritySchemes
 	}
-	log.Printf("operation security schemes missing, falling back to global security schemes (if there's any)")
+	clientLog.Debug("operation security schemes missing, falling back to global security schemes (if there's any)")
 	if oa.globalSecuritySchemes != nil && len(*oa.globalSecuritySchemes) != 0 {
-		log.Printf("the global configuration contains security schemes, selected the following based on order of appearance in the list %+v", oa.globalSecuritySchemes)
+		clientLog.Debug("the global configuration contains security schemes, selected the following based on order of appearance in the list %+v", oa.globalSecuritySchemes)
 		return true, *oa.globalSecuritySchemes
 	}
 	return false, nil
@@ -42,7 +41,7 @@ func (oa apiAuth) fetchRequiredAuthenticators(operationSecuritySchemes SpecSecur
 	for _, operationSecurityScheme := range operationSecuritySchemes {
 		authenticator := providerConfig.getAuthenticatorFor(operationSecurityScheme)
 		if authenticator == nil {
-			return nil, fmt.Errorf("operation's security policy '%s' is not defined, please make sure the swagger file contains a security definition named '%s' under the securityDefinitions section", operationSecurityScheme, operationSecurityScheme)
+			return nil, fmt.Errorf("operation's security policy '%s' is not defined, please make sure the swagger file contains a security definition named '%s' under the securityDefinitions section", operationSecurityScheme.Name, operationSecurityScheme.Name)
 		}
 		authenticators = append(authenticators, authenticator)
 	}
@@ -59,12 +58,18 @@ func (oa apiAuth) prepareAuth(url string, operationSecuritySchemes SpecSecurityS
 		if err != nil {
 			return authContext, err
 		}
-		for _, authenticator := range authenticators {
+		for i, authenticator := range authenticators {
 			err := authenticator.validate()
 			if err != nil {
 				return authContext, err
 			}
-			if err := authenticator.prepareAuth(authContext); err != nil {
+			scopes := requiredSecuritySchemes[i].Scopes
+			if scopedAuthenticator, ok := authenticator.(specScopedAuthenticator); ok && len(scopes) > 0 {
+				err = scopedAuthenticator.prepareScopedAuth(authContext, scopes)
+			} else {
+				err = authenticator.prepareAuth(authContext)
+			}
+			if err != nil {
 				return authContext, err
 			}
 		}
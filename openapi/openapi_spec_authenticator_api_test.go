@@ -5,6 +5,8 @@ import (
 	"fmt"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -272,7 +274,7 @@ func TestPrepareAuth(t *testing.T) {
 			},
 			expectedHeaders: map[string]string{},
 			expectedURL:     "https://www.host.com/v1/resource",
-			expectedError:   errors.New("operation's security policy '{not_defined_scheme}' is not defined, please make sure the swagger file contains a security definition named '{not_defined_scheme}' under the securityDefinitions section"),
+			expectedError:   errors.New("operation's security policy 'not_defined_scheme' is not defined, please make sure the swagger file contains a security definition named 'not_defined_scheme' under the securityDefinitions section"),
 		},
 		{
 			name:                          "apiAuthenticator set up with no global security schemes and the operation having specific security scheme that are not defined in the provider configuration ",
@@ -291,7 +293,7 @@ func TestPrepareAuth(t *testing.T) {
 			},
 			expectedHeaders: map[string]string{},
 			expectedURL:     "https://www.host.com/v1/resource",
-			expectedError:   errors.New("operation's security policy '{not_defined_scheme}' is not defined, please make sure the swagger file contains a security definition named '{not_defined_scheme}' under the securityDefinitions section"),
+			expectedError:   errors.New("operation's security policy 'not_defined_scheme' is not defined, please make sure the swagger file contains a security definition named 'not_defined_scheme' under the securityDefinitions section"),
 		},
 		{
 			name:                          "apiAuthenticator set up with global security schemes 'api_key' that match security definitions defined in the provider configuration but it's missing the value",
@@ -343,3 +345,32 @@ func TestPrepareAuth(t *testing.T) {
 	}
 
 }
+
+func TestPrepareAuth_Scopes(t *testing.T) {
+	var scopesReceived []string
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scopesReceived = append(scopesReceived, r.URL.Query().Get("scope"))
+		w.Header().Set(authorizationHeader, "Bearer token for "+r.URL.Query().Get("scope"))
+	}))
+	defer tokenServer.Close()
+	providerConfig := providerConfiguration{
+		SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+			"refresh_token": newAPIRefreshTokenAuthenticator(authorizationHeader, "Bearer refreshToken", tokenServer.URL, "refresh_token"),
+		},
+	}
+	globalSecuritySchemes := SpecSecuritySchemes{SpecSecurityScheme{Name: "refresh_token", Scopes: []string{"admin"}}}
+	apiAuthenticator := newAPIAuthenticator(&globalSecuritySchemes)
+
+	authContext, err := apiAuthenticator.prepareAuth("https://www.host.com/v1/cdns", SpecSecuritySchemes{SpecSecurityScheme{Name: "refresh_token", Scopes: []string{"cdns:write", "cdns:read"}}}, providerConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token for cdns:read cdns:write", authContext.headers[authorizationHeader], "the token should be requested for the operation scopes")
+
+	authContext, err = apiAuthenticator.prepareAuth("https://www.host.com/v1/cdns", nil, providerConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token for admin", authContext.headers[authorizationHeader], "the token should be requested for the global scopes if the operation does not have security schemes")
+
+	authContext, err = apiAuthenticator.prepareAuth("https://www.host.com/v1/cdns", SpecSecuritySchemes{SpecSecurityScheme{Name: "refresh_token"}}, providerConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token for", authContext.headers[authorizationHeader], "the token should be requested without scopes if the operation does not require any")
+	assert.Equal(t, []string{"cdns:read cdns:write", "admin", ""}, scopesReceived)
+}
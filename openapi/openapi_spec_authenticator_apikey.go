@@ -8,12 +8,22 @@ type specAPIKeyAuthenticator interface {
 	validate() error
 }
 
+// specScopedAuthenticator defines the behaviour for the authenticators requesting access tokens, which can request the
+// tokens for the scopes required by the operation (least privilege) instead of the scopes granted by default
+type specScopedAuthenticator interface {
+	prepareScopedAuth(authContext *authContext, scopes []string) error
+}
+
 func createAPIKeyAuthenticator(secDef SpecSecurityDefinition, value string) specAPIKeyAuthenticator {
 	switch secDef.getAPIKey().In {
 	case inHeader:
 		if secDef.getType() == securityDefinitionAPIKeyRefreshToken {
 			return newAPIRefreshTokenAuthenticator(secDef.getAPIKey().Name, secDef.buildValue(value), secDef.getAPIKey().Metadata[refreshTokenURLKey].(string), secDef.GetTerraformConfigurationName())
 		}
+		if secDef.getType() == securityDefinitionAPIKeyCloudIdentity {
+			metadata := secDef.getAPIKey().Metadata
+			return newAPICloudIdentityAuthenticator(secDef.getAPIKey().Name, secDef.buildValue(value), metadata[cloudIdentityProviderKey].(string), metadata[cloudIdentityAudienceKey].(string), secDef.GetTerraformConfigurationName())
+		}
 		return newAPIKeyHeaderAuthenticator(secDef.getAPIKey().Name, secDef.buildValue(value), secDef.GetTerraformConfigurationName())
 	case inQuery:
 		return newAPIKeyQueryAuthenticator(secDef.getAPIKey().Name, secDef.buildValue(value), secDef.GetTerraformConfigurationName())
@@ -0,0 +1,204 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cloudMetadataEndpoints contains the instance metadata server endpoints of the cloud providers supported
+var cloudMetadataEndpoints = map[string]string{
+	cloudIdentityProviderAWS:   "http://169.254.169.254",
+	cloudIdentityProviderGCP:   "http://metadata.google.internal",
+	cloudIdentityProviderAzure: "http://169.254.169.254",
+}
+
+// awsIMDSSessionTTL defines how long the IMDSv2 session tokens requested are valid for; the identity tokens are requested
+// again once the session expires
+const awsIMDSSessionTTL = 6 * time.Hour
+
+// cloudMetadataRequestTimeout defines the max time the requests to the instance metadata servers are allowed to take
+const cloudMetadataRequestTimeout = 10 * time.Second
+
+// cloudIdentityTokenExpiryMargin defines how long before their expiry the identity tokens are requested again, so the
+// tokens presented to the API do not expire in flight
+const cloudIdentityTokenExpiryMargin = time.Minute
+
+// apiCloudIdentityAuthenticator presents the identity token issued by the instance metadata server of the cloud provider
+// the provider runs on to the API as a Bearer token, enabling credential-free operation on cloud CI runners:
+// - aws: the PKCS7 signature of the instance identity document, requested with an IMDSv2 session token
+// - gcp: the identity token (JWT) of the default service account issued for the audience configured
+// - azure: the managed identity (MSI) access token issued for the audience (resource) configured
+// The identity tokens are cached until shortly before they expire. If the user configures a token in the provider
+// configuration, that token is presented instead
+type apiCloudIdentityAuthenticator struct {
+	terraformConfigurationName string
+	apiKey
+	provider         string
+	audience         string
+	metadataEndpoint string
+	httpClient       *http.Client
+	identityTokens   *accessTokenCache
+}
+
+func newAPICloudIdentityAuthenticator(name, value, provider, audience, terraformConfigurationName string) apiCloudIdentityAuthenticator {
+	return apiCloudIdentityAuthenticator{
+		terraformConfigurationName: terraformConfigurationName,
+		apiKey: apiKey{
+			name:  name,
+			value: value,
+		},
+		provider:         provider,
+		audience:         audience,
+		metadataEndpoint: cloudMetadataEndpoints[provider],
+		httpClient:       &http.Client{Timeout: cloudMetadataRequestTimeout},
+		identityTokens:   newAccessTokenCache(),
+	}
+}
+
+func (a apiCloudIdentityAuthenticator) getContext() interface{} {
+	return a.apiKey
+}
+
+func (a apiCloudIdentityAuthenticator) getType() authType {
+	return authTypeAPIKeyHeader
+}
+
+// prepareAuth adds the Authorization header containing the identity token issued by the instance metadata server, or the
+// token configured by the user if any
+func (a apiCloudIdentityAuthenticator) prepareAuth(authContext *authContext) error {
+	if authContext.headers == nil {
+		authContext.headers = map[string]string{}
+	}
+	if a.value != "" {
+		authContext.headers[a.name] = a.value
+		return nil
+	}
+	identityToken, cached := a.identityTokens.get(a.audience)
+	if !cached {
+		var expiresAt time.Time
+		var err error
+		if identityToken, expiresAt, err = a.requestIdentityToken(); err != nil {
+			return fmt.Errorf("failed to obtain the '%s' security definition identity token from the %s instance metadata server: %s", a.terraformConfigurationName, a.provider, err)
+		}
+		if !expiresAt.IsZero() {
+			a.identityTokens.put(a.audience, identityToken, time.Until(expiresAt)-cloudIdentityTokenExpiryMargin)
+		}
+	}
+	authContext.headers[a.name] = fmt.Sprintf("%s %s", bearerScheme, identityToken)
+	return nil
+}
+
+// requestIdentityToken requests the identity token to the instance metadata server and returns it along with its expiry
+// time (zero if unknown, in which case the token is not cached)
+func (a apiCloudIdentityAuthenticator) requestIdentityToken() (string, time.Time, error) {
+	switch a.provider {
+	case cloudIdentityProviderAWS:
+		return a.requestAWSIdentityToken()
+	case cloudIdentityProviderGCP:
+		return a.requestGCPIdentityToken()
+	case cloudIdentityProviderAzure:
+		return a.requestAzureIdentityToken()
+	}
+	return "", time.Time{}, fmt.Errorf("cloud identity provider '%s' not supported", a.provider)
+}
+
+func (a apiCloudIdentityAuthenticator) requestAWSIdentityToken() (string, time.Time, error) {
+	sessionToken, err := a.getMetadata(http.MethodPut, "/latest/api/token", map[string]string{"X-aws-ec2-metadata-token-ttl-seconds": strconv.Itoa(int(awsIMDSSessionTTL.Seconds()))})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	signature, err := a.getMetadata(http.MethodGet, "/latest/dynamic/instance-identity/pkcs7", map[string]string{"X-aws-ec2-metadata-token": sessionToken})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	// the signature is returned base64 encoded in several lines
+	return strings.Join(strings.Fields(signature), ""), time.Now().Add(awsIMDSSessionTTL), nil
+}
+
+func (a apiCloudIdentityAuthenticator) requestGCPIdentityToken() (string, time.Time, error) {
+	query := url.Values{"audience": []string{a.audience}, "format": []string{"full"}}
+	identityToken, err := a.getMetadata(http.MethodGet, "/computeMetadata/v1/instance/service-accounts/default/identity?"+query.Encode(), map[string]string{"Metadata-Flavor": "Google"})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return identityToken, getJWTExpiry(identityToken), nil
+}
+
+func (a apiCloudIdentityAuthenticator) requestAzureIdentityToken() (string, time.Time, error) {
+	query := url.Values{"api-version": []string{"2018-02-01"}, "resource": []string{a.audience}}
+	body, err := a.getMetadata(http.MethodGet, "/metadata/identity/oauth2/token?"+query.Encode(), map[string]string{"Metadata": "true"})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	token := struct {
+		AccessToken string      `json:"access_token"`
+		ExpiresOn   json.Number `json:"expires_on"`
+	}{}
+	if err := json.Unmarshal([]byte(body), &token); err != nil {
+		return "", time.Time{}, fmt.Errorf("token response not valid: %s", err)
+	}
+	if token.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("token response is missing the access token")
+	}
+	var expiresAt time.Time
+	if expiresOn, err := token.ExpiresOn.Int64(); err == nil {
+		expiresAt = time.Unix(expiresOn, 0)
+	}
+	return token.AccessToken, expiresAt, nil
+}
+
+// getMetadata performs the request against the instance metadata server and returns the response body
+func (a apiCloudIdentityAuthenticator) getMetadata(method, path string, headers map[string]string) (string, error) {
+	req, err := http.NewRequest(method, a.metadataEndpoint+path, nil)
+	if err != nil {
+		return "", err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s %s responded with status code '%d'", method, strings.SplitN(path, "?", 2)[0], resp.StatusCode)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// validate always succeeds since the token is obtained from the instance metadata server if not configured by the user
+func (a apiCloudIdentityAuthenticator) validate() error {
+	return nil
+}
+
+// getJWTExpiry returns the expiry time (exp claim) of the JWT passed in; zero if the token is not a JWT or does not have
+// the exp claim
+func getJWTExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+	claims := struct {
+		Exp int64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPICloudIdentityAuthenticator(t *testing.T) {
+	gcpToken := fmt.Sprintf("header.%s.signature", base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"aud":"https://api.example.com","exp":%d}`, time.Now().Add(time.Hour).Unix()))))
+	var requestsReceived []string
+	metadataServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestsReceived = append(requestsReceived, r.Method+" "+r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/latest/api/token":
+			assert.Equal(t, "21600", r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds"))
+			fmt.Fprint(w, "sessionToken")
+		case "/latest/dynamic/instance-identity/pkcs7":
+			if r.Header.Get("X-aws-ec2-metadata-token") != "sessionToken" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, "MIAGCSqGSIb3\nDQEHAqCAMIAC\n")
+		case "/computeMetadata/v1/instance/service-accounts/default/identity":
+			assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+			fmt.Fprint(w, gcpToken)
+		case "/metadata/identity/oauth2/token":
+			assert.Equal(t, "true", r.Header.Get("Metadata"))
+			fmt.Fprintf(w, `{"access_token":"azureToken","expires_on":"%d","resource":"%s"}`, time.Now().Add(time.Hour).Unix(), r.URL.Query().Get("resource"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer metadataServer.Close()
+
+	testCases := []struct {
+		name             string
+		provider         string
+		audience         string
+		expectedHeader   string
+		expectedRequests []string
+	}{
+		{
+			name:             "aws instance identity document signature",
+			provider:         cloudIdentityProviderAWS,
+			expectedHeader:   "Bearer MIAGCSqGSIb3DQEHAqCAMIAC",
+			expectedRequests: []string{"PUT /latest/api/token", "GET /latest/dynamic/instance-identity/pkcs7"},
+		},
+		{
+			name:             "gcp identity token",
+			provider:         cloudIdentityProviderGCP,
+			audience:         "https://api.example.com",
+			expectedHeader:   "Bearer " + gcpToken,
+			expectedRequests: []string{"GET /computeMetadata/v1/instance/service-accounts/default/identity?audience=https%3A%2F%2Fapi.example.com&format=full"},
+		},
+		{
+			name:             "azure managed identity token",
+			provider:         cloudIdentityProviderAzure,
+			audience:         "api://example",
+			expectedHeader:   "Bearer azureToken",
+			expectedRequests: []string{"GET /metadata/identity/oauth2/token?api-version=2018-02-01&resource=api%3A%2F%2Fexample"},
+		},
+	}
+	for _, tc := range testCases {
+		requestsReceived = nil
+		authenticator := newAPICloudIdentityAuthenticator(authorizationHeader, "", tc.provider, tc.audience, "cloud_identity")
+		authenticator.metadataEndpoint = metadataServer.URL
+		assert.NoError(t, authenticator.validate(), tc.name)
+		for i := 0; i < 2; i++ {
+			ctx := &authContext{}
+			assert.NoError(t, authenticator.prepareAuth(ctx), tc.name)
+			assert.Equal(t, tc.expectedHeader, ctx.headers[authorizationHeader], tc.name)
+		}
+		assert.Equal(t, tc.expectedRequests, requestsReceived, "%s: the identity token should be cached until it expires", tc.name)
+	}
+
+	t.Run("token configured by the user", func(t *testing.T) {
+		requestsReceived = nil
+		authenticator := newAPICloudIdentityAuthenticator(authorizationHeader, "Bearer userToken", cloudIdentityProviderAWS, "", "cloud_identity")
+		authenticator.metadataEndpoint = metadataServer.URL
+		ctx := &authContext{}
+		assert.NoError(t, authenticator.prepareAuth(ctx))
+		assert.Equal(t, "Bearer userToken", ctx.headers[authorizationHeader])
+		assert.Empty(t, requestsReceived)
+	})
+
+	t.Run("metadata server not available", func(t *testing.T) {
+		authenticator := newAPICloudIdentityAuthenticator(authorizationHeader, "", cloudIdentityProviderGCP, "https://api.example.com", "cloud_identity")
+		authenticator.metadataEndpoint = metadataServer.URL + "/unknown"
+		err := authenticator.prepareAuth(&authContext{})
+		assert.EqualError(t, err, "failed to obtain the 'cloud_identity' security definition identity token from the gcp instance metadata server: GET /computeMetadata/v1/instance/service-accounts/default/identity responded with status code '404'")
+	})
+}
+
+func TestGetJWTExpiry(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1609495200}`))
+	assert.Equal(t, time.Unix(1609495200, 0), getJWTExpiry("header."+payload+".signature"))
+	assert.True(t, getJWTExpiry("opaqueToken").IsZero())
+	assert.True(t, getJWTExpiry("header.notBase64!.signature").IsZero())
+	assert.True(t, getJWTExpiry("header."+base64.RawURLEncoding.EncodeToString([]byte(`{"aud":"api"}`))+".signature").IsZero())
+}
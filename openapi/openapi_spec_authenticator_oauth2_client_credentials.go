@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// oauth2TokenRequestTimeout defines the max time the access token requests are allowed to take
+const oauth2TokenRequestTimeout = 30 * time.Second
+
+// oauth2TokenExpiryMargin defines how long before their expiry the access tokens are requested again, so the tokens
+// presented to the API do not expire in flight
+const oauth2TokenExpiryMargin = time.Minute
+
+// oauth2UnknownExpiryTokenTTL defines how long the access tokens whose expiry is unknown (the token response does not
+// contain the expires_in field and the access token is not a JWT with the exp claim) are cached
+const oauth2UnknownExpiryTokenTTL = 5 * time.Minute
+
+// apiOAuth2ClientCredentialsAuthenticator presents the access token obtained following the OAuth2 client credentials flow
+// to the API as a Bearer token. The client credentials are exchanged for access tokens at the token URL, authenticating
+// the client with the HTTP Basic authentication scheme (https://tools.ietf.org/html/rfc6749#section-4.4). The access
+// tokens are cached per scope set until shortly before they expire, so they are refreshed automatically
+type apiOAuth2ClientCredentialsAuthenticator struct {
+	terraformConfigurationName string
+	apiKey
+	clientID     string
+	clientSecret string
+	tokenURL     string
+	httpClient   *http.Client
+	accessTokens *accessTokenCache
+}
+
+func newAPIOAuth2ClientCredentialsAuthenticator(name, clientID, clientSecret, tokenURL, terraformConfigurationName string) apiOAuth2ClientCredentialsAuthenticator {
+	return apiOAuth2ClientCredentialsAuthenticator{
+		terraformConfigurationName: terraformConfigurationName,
+		apiKey: apiKey{
+			name: name,
+		},
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		tokenURL:     tokenURL,
+		httpClient:   &http.Client{Timeout: oauth2TokenRequestTimeout},
+		accessTokens: newAccessTokenCache(),
+	}
+}
+
+func (a apiOAuth2ClientCredentialsAuthenticator) getContext() interface{} {
+	return a.apiKey
+}
+
+func (a apiOAuth2ClientCredentialsAuthenticator) getType() authType {
+	return authTypeAPIKeyHeader
+}
+
+// prepareAuth adds the Authorization header containing the access token issued for the default scopes of the client
+func (a apiOAuth2ClientCredentialsAuthenticator) prepareAuth(authContext *authContext) error {
+	return a.prepareScopedAuth(authContext, nil)
+}
+
+// prepareScopedAuth behaves as prepareAuth requesting the access token for the scopes passed in, which are sent in the
+// scope parameter of the token request
+func (a apiOAuth2ClientCredentialsAuthenticator) prepareScopedAuth(authContext *authContext, scopes []string) error {
+	scopeSet := getScopeSet(scopes)
+	accessToken, cached := a.accessTokens.get(scopeSet)
+	if !cached {
+		var expiresIn time.Duration
+		var err error
+		if accessToken, expiresIn, err = a.requestAccessToken(scopeSet); err != nil {
+			return fmt.Errorf("failed to obtain the '%s' security definition access token from '%s': %s", a.terraformConfigurationName, a.tokenURL, err)
+		}
+		a.accessTokens.put(scopeSet, accessToken, getOAuth2AccessTokenTTL(expiresIn))
+	}
+	if authContext.headers == nil {
+		authContext.headers = map[string]string{}
+	}
+	authContext.headers[a.name] = fmt.Sprintf("%s %s", bearerScheme, accessToken)
+	return nil
+}
+
+// requestAccessToken exchanges the client credentials for an access token (including the scope set if not empty) and
+// returns the access token along with how long it is valid for (zero if unknown)
+func (a apiOAuth2ClientCredentialsAuthenticator) requestAccessToken(scopeSet string) (string, time.Duration, error) {
+	form := url.Values{"grant_type": []string{"client_credentials"}}
+	if scopeSet != "" {
+		form.Set("scope", scopeSet)
+	}
+	req, err := http.NewRequest(http.MethodPost, a.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set(contentType, "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(a.clientID), url.QueryEscape(a.clientSecret))
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, err
+	}
+	token := struct {
+		AccessToken      string      `json:"access_token"`
+		TokenType        string      `json:"token_type"`
+		ExpiresIn        json.Number `json:"expires_in"`
+		Error            string      `json:"error"`
+		ErrorDescription string      `json:"error_description"`
+	}{}
+	jsonErr := json.Unmarshal(body, &token)
+	if resp.StatusCode != http.StatusOK {
+		if token.Error != "" {
+			return "", 0, fmt.Errorf("token response status code '%d' not matching expected response status code [%d]: %s %s", resp.StatusCode, http.StatusOK, token.Error, token.ErrorDescription)
+		}
+		return "", 0, fmt.Errorf("token response status code '%d' not matching expected response status code [%d]", resp.StatusCode, http.StatusOK)
+	}
+	if jsonErr != nil {
+		return "", 0, fmt.Errorf("token response not valid: %s", jsonErr)
+	}
+	if token.AccessToken == "" {
+		return "", 0, fmt.Errorf("token response is missing the access token")
+	}
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, bearerScheme) {
+		return "", 0, fmt.Errorf("token response token type '%s' not supported, only '%s' access tokens are supported", token.TokenType, bearerScheme)
+	}
+	var expiresIn time.Duration
+	if seconds, err := token.ExpiresIn.Int64(); err == nil {
+		expiresIn = time.Duration(seconds) * time.Second
+	} else if expiresAt := getJWTExpiry(token.AccessToken); !expiresAt.IsZero() {
+		expiresIn = time.Until(expiresAt)
+	}
+	return token.AccessToken, expiresIn, nil
+}
+
+func (a apiOAuth2ClientCredentialsAuthenticator) validate() error {
+	if a.clientID == "" || a.clientSecret == "" {
+		return fmt.Errorf("required security definition '%s' is missing the client credentials. Please make sure the properties '%s%s' and '%s%s' are configured with a value in the provider's terraform configuration", a.terraformConfigurationName, a.terraformConfigurationName, oauth2ClientIDSuffix, a.terraformConfigurationName, oauth2ClientSecretSuffix)
+	}
+	return nil
+}
+
+// getOAuth2AccessTokenTTL returns how long the access tokens valid for the time passed in are cached, so they are
+// requested again shortly before they expire. Short-lived tokens are cached for half of their lifetime instead, and the
+// tokens whose expiry is unknown (zero) for oauth2UnknownExpiryTokenTTL
+func getOAuth2AccessTokenTTL(expiresIn time.Duration) time.Duration {
+	if expiresIn == 0 {
+		return oauth2UnknownExpiryTokenTTL
+	}
+	if expiresIn <= 2*oauth2TokenExpiryMargin {
+		return expiresIn / 2
+	}
+	return expiresIn - oauth2TokenExpiryMargin
+}
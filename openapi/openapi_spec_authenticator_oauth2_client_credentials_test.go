@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIOAuth2ClientCredentialsAuthenticator(t *testing.T) {
+	var scopesRequested []string
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "client%2Fid" || clientSecret != "s3cr3t%21" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_client","error_description":"client authentication failed"}`)
+			return
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get(contentType))
+		assert.Equal(t, "client_credentials", r.FormValue("grant_type"))
+		scopesRequested = append(scopesRequested, r.FormValue("scope"))
+		fmt.Fprintf(w, `{"access_token":"token%d","token_type":"bearer","expires_in":3600}`, len(scopesRequested))
+	}))
+	defer tokenServer.Close()
+
+	authenticator := newAPIOAuth2ClientCredentialsAuthenticator(authorizationHeader, "client/id", "s3cr3t!", tokenServer.URL, "oauth2_auth")
+	require.NoError(t, authenticator.validate())
+
+	ctx := &authContext{}
+	require.NoError(t, authenticator.prepareAuth(ctx))
+	assert.Equal(t, "Bearer token1", ctx.headers[authorizationHeader])
+	ctx = &authContext{headers: map[string]string{}}
+	require.NoError(t, authenticator.prepareAuth(ctx))
+	assert.Equal(t, "Bearer token1", ctx.headers[authorizationHeader], "the access token should be cached until shortly before it expires")
+
+	ctx = &authContext{}
+	require.NoError(t, authenticator.prepareScopedAuth(ctx, []string{"cdns:write", "cdns:read"}))
+	assert.Equal(t, "Bearer token2", ctx.headers[authorizationHeader], "the access tokens should be cached per scope set")
+	assert.Equal(t, []string{"", "cdns:read cdns:write"}, scopesRequested)
+
+	authenticator.accessTokens.now = func() time.Time { return time.Now().Add(time.Hour - 30*time.Second) }
+	ctx = &authContext{}
+	require.NoError(t, authenticator.prepareAuth(ctx))
+	assert.Equal(t, "Bearer token3", ctx.headers[authorizationHeader], "the access token should be refreshed before it expires")
+
+	authenticator = newAPIOAuth2ClientCredentialsAuthenticator(authorizationHeader, "client/id", "wrong", tokenServer.URL, "oauth2_auth")
+	err := authenticator.prepareAuth(&authContext{})
+	assert.EqualError(t, err, fmt.Sprintf("failed to obtain the 'oauth2_auth' security definition access token from '%s': token response status code '401' not matching expected response status code [200]: invalid_client client authentication failed", tokenServer.URL))
+}
+
+func TestAPIOAuth2ClientCredentialsAuthenticatorTokenResponseErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		statusCode    int
+		response      string
+		expectedError string
+	}{
+		{name: "error response without error details", statusCode: http.StatusInternalServerError, response: "internal error", expectedError: "token response status code '500' not matching expected response status code [200]"},
+		{name: "response not valid", statusCode: http.StatusOK, response: "not json", expectedError: "token response not valid: invalid character 'o' in literal null (expecting 'u')"},
+		{name: "response missing the access token", statusCode: http.StatusOK, response: `{"token_type":"bearer"}`, expectedError: "token response is missing the access token"},
+		{name: "token type not supported", statusCode: http.StatusOK, response: `{"access_token":"token","token_type":"mac"}`, expectedError: "token response token type 'mac' not supported, only 'Bearer' access tokens are supported"},
+	}
+	for _, tc := range testCases {
+		tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.statusCode)
+			fmt.Fprint(w, tc.response)
+		}))
+		authenticator := newAPIOAuth2ClientCredentialsAuthenticator(authorizationHeader, "client_id", "client_secret", tokenServer.URL, "oauth2_auth")
+		_, _, err := authenticator.requestAccessToken("")
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+		tokenServer.Close()
+	}
+}
+
+func TestAPIOAuth2ClientCredentialsAuthenticatorValidate(t *testing.T) {
+	assert.NoError(t, newAPIOAuth2ClientCredentialsAuthenticator(authorizationHeader, "client_id", "client_secret", "https://auth.example.com/oauth2/token", "oauth2_auth").validate())
+	expectedError := "required security definition 'oauth2_auth' is missing the client credentials. Please make sure the properties 'oauth2_auth_client_id' and 'oauth2_auth_client_secret' are configured with a value in the provider's terraform configuration"
+	assert.EqualError(t, newAPIOAuth2ClientCredentialsAuthenticator(authorizationHeader, "", "client_secret", "https://auth.example.com/oauth2/token", "oauth2_auth").validate(), expectedError)
+	assert.EqualError(t, newAPIOAuth2ClientCredentialsAuthenticator(authorizationHeader, "client_id", "", "https://auth.example.com/oauth2/token", "oauth2_auth").validate(), expectedError)
+}
+
+func TestGetOAuth2AccessTokenTTL(t *testing.T) {
+	assert.Equal(t, 59*time.Minute, getOAuth2AccessTokenTTL(time.Hour))
+	assert.Equal(t, 45*time.Second, getOAuth2AccessTokenTTL(90*time.Second))
+	assert.Equal(t, oauth2UnknownExpiryTokenTTL, getOAuth2AccessTokenTTL(0), "the access tokens whose expiry is unknown should be cached for the default TTL")
+	assert.True(t, getOAuth2AccessTokenTTL(-time.Second) <= 0, "the access tokens already expired should not be cached")
+}
+
+func TestAPIOAuth2ClientCredentialsAuthenticatorUnknownExpiry(t *testing.T) {
+	var tokenRequests int
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		fmt.Fprintf(w, `{"access_token":"token%d","token_type":"bearer"}`, tokenRequests)
+	}))
+	defer tokenServer.Close()
+
+	authenticator := newAPIOAuth2ClientCredentialsAuthenticator(authorizationHeader, "client_id", "client_secret", tokenServer.URL, "oauth2_auth")
+	ctx := &authContext{}
+	require.NoError(t, authenticator.prepareAuth(ctx))
+	require.NoError(t, authenticator.prepareAuth(ctx))
+	assert.Equal(t, "Bearer token1", ctx.headers[authorizationHeader])
+	assert.Equal(t, 1, tokenRequests, "the access token whose expiry is unknown should be cached")
+
+	authenticator.accessTokens.now = func() time.Time { return time.Now().Add(oauth2UnknownExpiryTokenTTL) }
+	require.NoError(t, authenticator.prepareAuth(ctx))
+	assert.Equal(t, "Bearer token2", ctx.headers[authorizationHeader], "the access token should be requested again once the default TTL expires")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/dikhan/http_goclient"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -13,6 +14,9 @@ type apiRefreshTokenAuthenticator struct {
 	apiKey
 	refreshTokenURL string
 	httpClient      http_goclient.HttpClientIface
+	// accessTokens caches the access tokens keyed by the scopes they were requested for. Tokens are only cached if the
+	// refresh token response allows it (Cache-Control max-age)
+	accessTokens *accessTokenCache
 }
 
 func newAPIRefreshTokenAuthenticator(name, refreshToken, refreshTokenURL, terraformConfigurationName string) apiRefreshTokenAuthenticator {
@@ -24,6 +28,7 @@ func newAPIRefreshTokenAuthenticator(name, refreshToken, refreshTokenURL, terraf
 		},
 		refreshTokenURL: refreshTokenURL,
 		httpClient:      &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		accessTokens:    newAccessTokenCache(),
 	}
 }
 
@@ -38,24 +43,54 @@ func (a apiRefreshTokenAuthenticator) getType() authType {
 // prepareAuth will send a post request to the refreshTokenURL and get the access token from the response Authorization
 // header. Otherwise, it will fail.
 func (a apiRefreshTokenAuthenticator) prepareAuth(authContext *authContext) error {
+	return a.prepareScopedAuth(authContext, nil)
+}
+
+// prepareScopedAuth behaves as prepareAuth requesting the access token for the scopes passed in, which are sent in the scope
+// query parameter of the refresh token request (e,g: ?scope=cdns:read+cdns:write). The access tokens are cached per scope
+// set if the refresh token response allows caching (Cache-Control max-age)
+func (a apiRefreshTokenAuthenticator) prepareScopedAuth(authContext *authContext, scopes []string) error {
+	scopeSet := getScopeSet(scopes)
+	accessToken, cached := a.accessTokens.get(scopeSet)
+	if !cached {
+		var err error
+		if accessToken, err = a.requestAccessToken(scopeSet); err != nil {
+			return err
+		}
+	}
+	if authContext.headers == nil {
+		authContext.headers = map[string]string{}
+	}
+	authContext.headers[authorizationHeader] = accessToken
+	return nil
+}
+
+// requestAccessToken sends the post request to the refreshTokenURL (including the scope set if not empty) and returns the
+// access token contained in the response Authorization header
+func (a apiRefreshTokenAuthenticator) requestAccessToken(scopeSet string) (string, error) {
 	apiKey := a.getContext().(apiKey)
 	headers := map[string]string{apiKey.name: apiKey.value}
-	r, err := a.httpClient.PostJson(a.refreshTokenURL, headers, nil, nil)
+	refreshTokenURL := a.refreshTokenURL
+	if scopeSet != "" {
+		separator := "?"
+		if strings.Contains(refreshTokenURL, "?") {
+			separator = "&"
+		}
+		refreshTokenURL = fmt.Sprintf("%s%s%s", refreshTokenURL, separator, url.Values{"scope": []string{scopeSet}}.Encode())
+	}
+	r, err := a.httpClient.PostJson(refreshTokenURL, headers, nil, nil)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if r.StatusCode != http.StatusOK && r.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("refresh token POST response '%s' status code '%d' not matching expected response status code [%d, %d]", a.refreshTokenURL, r.StatusCode, http.StatusOK, http.StatusNoContent)
+		return "", fmt.Errorf("refresh token POST response '%s' status code '%d' not matching expected response status code [%d, %d]", a.refreshTokenURL, r.StatusCode, http.StatusOK, http.StatusNoContent)
 	}
 	accessToken := r.Header.Get(authorizationHeader)
 	if accessToken == "" {
-		return fmt.Errorf("refresh token POST response '%s' is missing the access token", a.refreshTokenURL)
-	}
-	if authContext.headers == nil {
-		authContext.headers = map[string]string{}
+		return "", fmt.Errorf("refresh token POST response '%s' is missing the access token", a.refreshTokenURL)
 	}
-	authContext.headers[authorizationHeader] = accessToken
-	return nil
+	a.accessTokens.put(scopeSet, accessToken, getCacheControlMaxAge(r.Header))
+	return accessToken, nil
 }
 
 func (a apiRefreshTokenAuthenticator) validate() error {
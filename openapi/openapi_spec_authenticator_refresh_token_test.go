@@ -129,3 +129,37 @@ func TestAPIRefreshTokenAuthenticatorValidate(t *testing.T) {
 		assert.Equal(t, tc.expectedError, err, tc.name)
 	}
 }
+
+func Test_ApiKeyRefreshTokenAuthenticator_PrepareScopedAuth(t *testing.T) {
+	var requestsReceived []string
+	cacheControl := ""
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestsReceived = append(requestsReceived, r.URL.RequestURI())
+		w.Header().Set("Cache-Control", cacheControl)
+		w.Header().Set(authorizationHeader, fmt.Sprintf("Bearer token%d", len(requestsReceived)))
+	}))
+	defer tokenServer.Close()
+
+	t.Run("access tokens are requested per scope set and not cached if the response does not allow it", func(t *testing.T) {
+		requestsReceived = nil
+		refreshTokenAuthenticator := newAPIRefreshTokenAuthenticator("my_fancy_name", "refreshToken", tokenServer.URL+"/token?client=terraform", "my_fancy_name")
+		for i := 0; i < 2; i++ {
+			ctx := &authContext{}
+			assert.NoError(t, refreshTokenAuthenticator.prepareScopedAuth(ctx, []string{"cdns:write", "cdns:read", "cdns:read"}))
+		}
+		assert.Equal(t, []string{"/token?client=terraform&scope=cdns%3Aread+cdns%3Awrite", "/token?client=terraform&scope=cdns%3Aread+cdns%3Awrite"}, requestsReceived)
+	})
+
+	t.Run("access tokens are cached per scope set if the response allows it", func(t *testing.T) {
+		requestsReceived = nil
+		cacheControl = "private, max-age=300"
+		refreshTokenAuthenticator := newAPIRefreshTokenAuthenticator("my_fancy_name", "refreshToken", tokenServer.URL, "my_fancy_name")
+		expectedTokens := []string{"Bearer token1", "Bearer token1", "Bearer token2", "Bearer token3"}
+		for i, scopes := range [][]string{{"cdns:read"}, {"cdns:read"}, {"cdns:write"}, nil} {
+			ctx := &authContext{}
+			assert.NoError(t, refreshTokenAuthenticator.prepareScopedAuth(ctx, scopes))
+			assert.Equal(t, expectedTokens[i], ctx.headers[authorizationHeader])
+		}
+		assert.Equal(t, []string{"/?scope=cdns%3Aread", "/?scope=cdns%3Awrite", "/"}, requestsReceived)
+	})
+}
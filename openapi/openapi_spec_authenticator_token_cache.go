@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// accessTokenCache caches the access tokens requested by the authenticators keyed by the set of scopes they were requested
+// for, so operations requiring the same scopes (e,g: all the operations of the same resource) reuse the token instead
+// of requesting a new one per API call. A nil cache does not cache any token.
+type accessTokenCache struct {
+	mutex  sync.Mutex
+	tokens map[string]cachedAccessToken
+	// now returns the current time, it can be overridden in tests
+	now func() time.Time
+}
+
+type cachedAccessToken struct {
+	value     string
+	expiresAt time.Time
+}
+
+func newAccessTokenCache() *accessTokenCache {
+	return &accessTokenCache{
+		tokens: map[string]cachedAccessToken{},
+		now:    time.Now,
+	}
+}
+
+// get returns the access token cached for the scope set passed in if it has not expired yet
+func (c *accessTokenCache) get(scopeSet string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	token, exists := c.tokens[scopeSet]
+	if !exists || !c.now().Before(token.expiresAt) {
+		return "", false
+	}
+	return token.value, true
+}
+
+// put caches the access token for the scope set passed in during the given time; the token is not cached if the time is
+// not positive
+func (c *accessTokenCache) put(scopeSet, value string, ttl time.Duration) {
+	if c == nil || ttl <= 0 {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.tokens[scopeSet] = cachedAccessToken{value: value, expiresAt: c.now().Add(ttl)}
+}
+
+// getScopeSet returns the scopes passed in sorted, without duplicates and space separated as expected in the scope
+// parameter of the token requests (e,g: "cdns:read cdns:write")
+func getScopeSet(scopes []string) string {
+	unique := map[string]bool{}
+	var scopeSet []string
+	for _, scope := range scopes {
+		if scope == "" || unique[scope] {
+			continue
+		}
+		unique[scope] = true
+		scopeSet = append(scopeSet, scope)
+	}
+	sort.Strings(scopeSet)
+	return strings.Join(scopeSet, " ")
+}
+
+// getCacheControlMaxAge returns how long the response can be cached for as defined in the max-age directive of the
+// Cache-Control header; zero if the header does not allow caching the response or does not define the max-age
+func getCacheControlMaxAge(header http.Header) time.Duration {
+	var maxAge time.Duration
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		nameValue := strings.SplitN(strings.TrimSpace(directive), "=", 2)
+		switch strings.ToLower(nameValue[0]) {
+		case "no-store", "no-cache":
+			return 0
+		case "max-age":
+			if len(nameValue) != 2 {
+				continue
+			}
+			if seconds, err := strconv.Atoi(strings.Trim(nameValue[1], `"`)); err == nil {
+				maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return maxAge
+}
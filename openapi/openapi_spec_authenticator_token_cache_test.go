@@ -0,0 +1,62 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessTokenCache(t *testing.T) {
+	now := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	cache := newAccessTokenCache()
+	cache.now = func() time.Time { return now }
+
+	_, cached := cache.get("cdns:read")
+	assert.False(t, cached)
+
+	cache.put("cdns:read", "token", time.Minute)
+	cache.put("cdns:write", "not cached token", 0)
+	token, cached := cache.get("cdns:read")
+	assert.True(t, cached)
+	assert.Equal(t, "token", token)
+	_, cached = cache.get("cdns:write")
+	assert.False(t, cached, "tokens should not be cached without a positive ttl")
+	_, cached = cache.get("")
+	assert.False(t, cached, "tokens should be cached per scope set")
+
+	now = now.Add(time.Minute)
+	_, cached = cache.get("cdns:read")
+	assert.False(t, cached, "expired tokens should not be returned")
+
+	var nilCache *accessTokenCache
+	nilCache.put("cdns:read", "token", time.Minute)
+	_, cached = nilCache.get("cdns:read")
+	assert.False(t, cached)
+}
+
+func TestGetScopeSet(t *testing.T) {
+	assert.Equal(t, "", getScopeSet(nil))
+	assert.Equal(t, "cdns:read", getScopeSet([]string{"cdns:read"}))
+	assert.Equal(t, "cdns:read cdns:write", getScopeSet([]string{"cdns:write", "", "cdns:read", "cdns:write"}))
+}
+
+func TestGetCacheControlMaxAge(t *testing.T) {
+	testCases := []struct {
+		cacheControl   string
+		expectedMaxAge time.Duration
+	}{
+		{cacheControl: "", expectedMaxAge: 0},
+		{cacheControl: "max-age=300", expectedMaxAge: 5 * time.Minute},
+		{cacheControl: "private, Max-Age=\"60\"", expectedMaxAge: time.Minute},
+		{cacheControl: "max-age=300, no-store", expectedMaxAge: 0},
+		{cacheControl: "no-cache", expectedMaxAge: 0},
+		{cacheControl: "max-age=forever", expectedMaxAge: 0},
+	}
+	for _, tc := range testCases {
+		header := http.Header{}
+		header.Set("Cache-Control", tc.cacheControl)
+		assert.Equal(t, tc.expectedMaxAge, getCacheControlMaxAge(header), tc.cacheControl)
+	}
+}
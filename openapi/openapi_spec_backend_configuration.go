@@ -8,4 +8,8 @@ type SpecBackendConfiguration interface {
 	getHostByRegion(region string) (string, error)
 	IsMultiRegion() (bool, string, []string, error)
 	GetDefaultRegion([]string) (string, error)
+	getFallbackHosts() []string
+	getFeatureFlags() ([]*SpecSchemaDefinitionProperty, error)
+	getSessionLogin() (*specSessionLogin, error)
+	getErrorHints() (specErrorHints, error)
 }
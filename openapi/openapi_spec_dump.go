@@ -0,0 +1,308 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// specModelDump is the internal model the OpenAPI document is translated into (see ExportSpecModelJSON)
+type specModelDump struct {
+	Resources   []*specResourceDump `json:"resources"`
+	DataSources []*specResourceDump `json:"data_sources"`
+}
+
+// specResourceDump is the internal model of a resource or data source
+type specResourceDump struct {
+	Name string `json:"name"`
+	Path string `json:"path,omitempty"`
+	// Host is the host the resource overrides the global one with (x-terraform-resource-host); empty if not overridden
+	Host string `json:"host,omitempty"`
+	// Ignored is true if the resource is excluded from the provider (x-terraform-exclude-resource)
+	Ignored bool `json:"ignored,omitempty"`
+	// ParentProperties contains the parent id properties of the sub-resources (e,g: cdn_v1_id)
+	ParentProperties []string                      `json:"parent_properties,omitempty"`
+	Operations       map[string]*specOperationDump `json:"operations,omitempty"`
+	Timeouts         map[string]string             `json:"timeouts,omitempty"`
+	Properties       []*specPropertyDump           `json:"properties"`
+	// Error contains the error translating the resource schema (if any), in which case the properties are not dumped
+	Error string `json:"error,omitempty"`
+}
+
+// specOperationDump is the internal model of a resource operation. The extensions contain the values the x-terraform
+// extensions of the operation were interpreted as, keyed by extension name; the extensions not valid are left out
+type specOperationDump struct {
+	SecuritySchemes  []string               `json:"security_schemes,omitempty"`
+	HeaderParameters []string               `json:"header_parameters,omitempty"`
+	Extensions       map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// specPropertyDump is the internal model of a resource property along with the terraform schema it is translated into
+type specPropertyDump struct {
+	Name           string `json:"name"`
+	TerraformName  string `json:"terraform_name"`
+	Type           string `json:"type"`
+	ArrayItemsType string `json:"array_items_type,omitempty"`
+	Required       bool   `json:"required"`
+	Optional       bool   `json:"optional"`
+	Computed       bool   `json:"computed"`
+	// ComputedReason explains why the property is computed in the terraform schema; empty if not computed
+	ComputedReason   string                 `json:"computed_reason,omitempty"`
+	ReadOnly         bool                   `json:"read_only,omitempty"`
+	ForceNew         bool                   `json:"force_new,omitempty"`
+	Sensitive        bool                   `json:"sensitive,omitempty"`
+	Immutable        bool                   `json:"immutable,omitempty"`
+	Identifier       bool                   `json:"identifier,omitempty"`
+	StatusIdentifier bool                   `json:"status_identifier,omitempty"`
+	ParentProperty   bool                   `json:"parent_property,omitempty"`
+	Default          interface{}            `json:"default,omitempty"`
+	Extensions       map[string]interface{} `json:"extensions,omitempty"`
+	Properties       []*specPropertyDump    `json:"properties,omitempty"`
+	// Error contains the error translating the property into the terraform schema (if any)
+	Error string `json:"error,omitempty"`
+}
+
+// ExportSpecModelJSON parses the OpenAPI document passed in (URL or path to a file) and returns the internal model of its
+// resources and data sources in JSON format, so extension authors can check how the x-terraform extensions were interpreted
+// (e,g: why a property is computed). Only the resources and data sources whose names are passed in are exported, all of
+// them if none are passed in
+func ExportSpecModelJSON(openAPIDocumentURL string, names []string) ([]byte, error) {
+	specAnalyser, err := CreateSpecAnalyser("", openAPIDocumentURL)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil, err
+	}
+	selected := map[string]bool{}
+	for _, name := range names {
+		selected[name] = true
+	}
+	model := specModelDump{Resources: []*specResourceDump{}, DataSources: []*specResourceDump{}}
+	for _, resource := range resources {
+		if len(selected) == 0 || selected[resource.GetResourceName()] {
+			model.Resources = append(model.Resources, newSpecResourceDump(resource))
+		}
+	}
+	for _, dataSource := range specAnalyser.GetTerraformCompliantDataSources() {
+		if len(selected) == 0 || selected[dataSource.GetResourceName()] {
+			model.DataSources = append(model.DataSources, newSpecResourceDump(dataSource))
+		}
+	}
+	return json.MarshalIndent(model, "", "  ")
+}
+
+func newSpecResourceDump(resource SpecResource) *specResourceDump {
+	dump := &specResourceDump{
+		Name:       resource.GetResourceName(),
+		Ignored:    resource.ShouldIgnoreResource(),
+		Properties: []*specPropertyDump{},
+	}
+	var parentIDs []string
+	if parentResourceInfo := resource.GetParentResourceInfo(); parentResourceInfo != nil {
+		dump.ParentProperties = parentResourceInfo.GetParentPropertiesNames()
+		for _, parentProperty := range dump.ParentProperties {
+			parentIDs = append(parentIDs, fmt.Sprintf("{%s}", parentProperty))
+		}
+	}
+	dump.Path, _ = resource.getResourcePath(parentIDs)
+	dump.Host, _ = resource.getHost()
+	operations := resource.getResourceOperations()
+	for name, operation := range map[string]*specResourceOperation{"list": operations.List, "post": operations.Post, "get": operations.Get, "put": operations.Put, "delete": operations.Delete} {
+		if operation == nil {
+			continue
+		}
+		if dump.Operations == nil {
+			dump.Operations = map[string]*specOperationDump{}
+		}
+		dump.Operations[name] = newSpecOperationDump(operation)
+	}
+	if timeouts, err := resource.getTimeouts(); err == nil && timeouts != nil {
+		dump.Timeouts = map[string]string{}
+		for name, timeout := range map[string]*time.Duration{"post": timeouts.Post, "get": timeouts.Get, "put": timeouts.Put, "delete": timeouts.Delete} {
+			if timeout != nil {
+				dump.Timeouts[name] = timeout.String()
+			}
+		}
+		if len(dump.Timeouts) == 0 {
+			dump.Timeouts = nil
+		}
+	}
+	resourceSchema, err := resource.GetResourceSchema()
+	if err != nil {
+		dump.Error = err.Error()
+		return dump
+	}
+	dump.Properties = newSpecPropertyDumps(resourceSchema)
+	return dump
+}
+
+func newSpecOperationDump(operation *specResourceOperation) *specOperationDump {
+	dump := &specOperationDump{}
+	for _, securityScheme := range operation.SecuritySchemes {
+		dump.SecuritySchemes = append(dump.SecuritySchemes, securityScheme.Name)
+	}
+	sort.Strings(dump.SecuritySchemes)
+	for _, headerParameter := range operation.HeaderParameters {
+		dump.HeaderParameters = append(dump.HeaderParameters, headerParameter.Name)
+	}
+	extensions := map[string]interface{}{
+		extTfResourceConditionalGet:     operation.isConditionalGetEnabled,
+		extTfGRPCMethod:                 operation.grpcMethod,
+		extTfRevisionProperty:           operation.revisionProperty,
+		extTfUserAgent:                  operation.userAgent,
+		extTfDataSourceDetailFetch:      operation.isDetailFetchEnabled,
+		extTfForceApplyParameter:        operation.forceApplyParameter,
+		extTfSkipUnchangedUpdate:        operation.skipUnchangedUpdate,
+		extTfResourceProvisioningEvents: operation.recordProvisioningEvents,
+		extTfWaitFor:                    operation.waitFor,
+		extTfUpdateStrategy:             operation.updateStrategy,
+		extTfOptionsPath:                operation.optionsPath,
+	}
+	if operation.graphQL != nil {
+		extensions[extTfGraphQL] = true
+	}
+	if operation.middlewares != nil {
+		extensions[extTfMiddleware] = operation.middlewares
+	}
+	if operation.fieldsFromSchema {
+		extensions[extTfFields] = true
+	} else if len(operation.fields) > 0 {
+		extensions[extTfFields] = operation.fields
+	}
+	if extensions[extTfFields] != nil {
+		extensions[extTfFieldsParameter] = operation.fieldsParameter
+	}
+	if operation.requestTimeout != nil {
+		extensions[extTfRequestTimeout] = operation.requestTimeout.String()
+	}
+	if operation.precheck != nil {
+		extensions[extTfPrecheckPath] = operation.precheck.path
+		extensions[extTfPrecheckField] = operation.precheck.field
+		extensions[extTfPrecheckMessageField] = operation.precheck.messageField
+	}
+	if operation.lock != nil {
+		extensions[extTfLockPath] = operation.lock.path
+	}
+	if operation.consistencyToken != nil {
+		extensions[extTfConsistencyToken] = map[string]interface{}{
+			"response_header":   operation.consistencyToken.responseHeader,
+			"response_field":    operation.consistencyToken.responseField,
+			"request_header":    operation.consistencyToken.requestHeader,
+			"request_parameter": operation.consistencyToken.requestParameter,
+		}
+	}
+	if operation.retry != nil {
+		retry := map[string]interface{}{
+			"retry_wait":         operation.retry.retryWait,
+			"max_retry_wait":     operation.retry.maxRetryWait,
+			"retry_status_codes": operation.retry.retryStatusCodes,
+		}
+		if operation.retry.maxRetries != nil {
+			retry["max_retries"] = *operation.retry.maxRetries
+		}
+		extensions[extTfRetry] = retry
+	}
+	if operation.count != nil {
+		extensions[extTfCountPath] = operation.count.path
+		extensions[extTfCountField] = operation.count.field
+	}
+	if operation.pagination != nil {
+		extensions[extTfPaginationTotalHeader] = operation.pagination.totalHeader
+		extensions[extTfPaginationNextCursorHeader] = operation.pagination.nextCursorHeader
+	}
+	for statusCode, response := range operation.responses {
+		if response == nil || !response.isPollingEnabled {
+			continue
+		}
+		extensions[fmt.Sprintf("%s (%d)", extTfResourcePollEnabled, statusCode)] = true
+		extensions[fmt.Sprintf("%s (%d)", extTfResourcePollTargetStatuses, statusCode)] = strings.Join(response.pollTargetStatuses, ",")
+		extensions[fmt.Sprintf("%s (%d)", extTfResourcePollPendingStatuses, statusCode)] = strings.Join(response.pollPendingStatuses, ",")
+	}
+	dump.Extensions = withoutZeroValues(extensions)
+	return dump
+}
+
+func newSpecPropertyDumps(schemaDefinition *SpecSchemaDefinition) []*specPropertyDump {
+	dumps := []*specPropertyDump{}
+	for _, property := range schemaDefinition.Properties {
+		dump := &specPropertyDump{
+			Name:             property.Name,
+			TerraformName:    property.GetTerraformCompliantPropertyName(),
+			Type:             string(property.Type),
+			ArrayItemsType:   string(property.ArrayItemsType),
+			ReadOnly:         property.ReadOnly,
+			ForceNew:         property.ForceNew,
+			Sensitive:        property.Sensitive,
+			Immutable:        property.Immutable,
+			Identifier:       property.IsIdentifier,
+			StatusIdentifier: property.IsStatusIdentifier,
+			ParentProperty:   property.IsParentProperty,
+			Default:          property.Default,
+			Extensions: withoutZeroValues(map[string]interface{}{
+				extTfIgnoreOrder:         property.IgnoreItemsOrder,
+				extTfOrdered:             property.Ordered,
+				extTfIgnoreServerItems:   property.IgnoreServerItems,
+				extTfUpdateStrategy:      property.UpdateStrategy,
+				extTfIDAliases:           property.IdentifierAliases,
+				extTfAttributeAliases:    property.AttributeAliases,
+				extTfEncryptInState:      property.EncryptInState,
+				extTfFingerprintInState:  property.FingerprintInState,
+				extTfCoerce:              property.Coerce,
+				extTfIgnoreServerChanges: property.IgnoreServerChanges,
+				extTfResolveByName:       property.ResolveByName != nil,
+				extTfUploadProperty:      property.Upload != nil,
+				extTfValidator:           property.Validator,
+				extTfFormat:              property.Format,
+			}),
+		}
+		if terraformSchema, err := property.terraformSchema(); err != nil {
+			dump.Error = err.Error()
+		} else {
+			dump.Required = terraformSchema.Required
+			dump.Optional = terraformSchema.Optional
+			dump.Computed = terraformSchema.Computed
+			dump.ComputedReason = getComputedReason(property, terraformSchema.Computed)
+		}
+		if property.SpecSchemaDefinition != nil {
+			dump.Properties = newSpecPropertyDumps(property.SpecSchemaDefinition)
+		}
+		dumps = append(dumps, dump)
+	}
+	return dumps
+}
+
+// getComputedReason returns why the property is computed in the terraform schema; empty if the property is not computed
+func getComputedReason(property *SpecSchemaDefinitionProperty, computed bool) string {
+	switch {
+	case !computed:
+		return ""
+	case property.isReadOnly():
+		return "the property is readOnly, so its value is only returned by the API"
+	case property.IsOptionalComputed():
+		return fmt.Sprintf("the property is optional, has the %s extension and no default value, so the API computes its value if not provided", extTfComputed)
+	case len(property.AttributeAliases) > 0:
+		return fmt.Sprintf("the property has the %s extension, so its value is populated from the alias configured", extTfAttributeAliases)
+	case property.ResolveByName != nil:
+		return fmt.Sprintf("the property has the %s extension, so its value is populated with the ID resolved from the name configured", extTfResolveByName)
+	}
+	return "the property is computed"
+}
+
+// withoutZeroValues returns the values passed in except the ones that are zero values (e,g: false, empty strings or nil)
+func withoutZeroValues(values map[string]interface{}) map[string]interface{} {
+	filtered := map[string]interface{}{}
+	for name, value := range values {
+		if value == nil || isZeroValue(value) {
+			continue
+		}
+		filtered[name] = value
+	}
+	if len(filtered) == 0 {
+		return nil
+	}
+	return filtered
+}
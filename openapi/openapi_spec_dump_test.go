@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const specDumpSwagger = `swagger: "2.0"
+host: "localhost:8443"
+schemes:
+- "https"
+paths:
+  /v1/cdns:
+    post:
+      x-terraform-update-strategy: merge-patch
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    get:
+      responses:
+        200:
+          schema:
+            type: "array"
+            items:
+              $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        204:
+          description: "successful operation"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    required:
+    - label
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"
+      ttl:
+        type: "integer"
+        x-terraform-computed: true
+      ip_address:
+        type: "string"
+        x-terraform-force-new: true`
+
+func TestExportSpecModelJSON(t *testing.T) {
+	file := initAPISpecFile(specDumpSwagger)
+	defer os.Remove(file.Name())
+
+	specModelJSON, err := ExportSpecModelJSON(file.Name(), nil)
+	require.NoError(t, err)
+	specModel := specModelDump{}
+	require.NoError(t, json.Unmarshal(specModelJSON, &specModel))
+	require.Len(t, specModel.Resources, 1)
+	resource := specModel.Resources[0]
+	assert.Equal(t, "cdns_v1", resource.Name)
+	assert.Equal(t, "/v1/cdns", resource.Path)
+	assert.Empty(t, resource.Host)
+	assert.Contains(t, resource.Operations, "post")
+	assert.Contains(t, resource.Operations, "delete")
+	assert.Equal(t, map[string]interface{}{extTfUpdateStrategy: operationUpdateStrategyMergePatch}, resource.Operations["post"].Extensions)
+	assert.Nil(t, resource.Operations["delete"].Extensions)
+
+	properties := map[string]*specPropertyDump{}
+	for _, property := range resource.Properties {
+		properties[property.Name] = property
+	}
+	require.Len(t, properties, 4)
+	assert.Equal(t, &specPropertyDump{Name: "id", TerraformName: "id", Type: "string", Optional: true, Computed: true, ComputedReason: "the property is readOnly, so its value is only returned by the API", ReadOnly: true}, properties["id"])
+	assert.Equal(t, &specPropertyDump{Name: "label", TerraformName: "label", Type: "string", Required: true}, properties["label"])
+	assert.True(t, properties["ttl"].Optional)
+	assert.True(t, properties["ttl"].Computed)
+	assert.Contains(t, properties["ttl"].ComputedReason, extTfComputed)
+	assert.True(t, properties["ip_address"].ForceNew)
+	assert.False(t, properties["ip_address"].Computed)
+	assert.Empty(t, properties["ip_address"].ComputedReason)
+}
+
+func TestExportSpecModelJSONFilteredByName(t *testing.T) {
+	file := initAPISpecFile(specDumpSwagger)
+	defer os.Remove(file.Name())
+
+	specModelJSON, err := ExportSpecModelJSON(file.Name(), []string{"other_v1"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"resources":[],"data_sources":[]}`, string(specModelJSON))
+}
+
+func TestExportSpecModelJSONSpecNotValid(t *testing.T) {
+	_, err := ExportSpecModelJSON("/does/not/exist.yaml", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// errorHintStatusCodeRegex matches the error hint keys referring to HTTP status codes (e,g: 403) or classes of status codes (e,g: 4XX)
+var errorHintStatusCodeRegex = regexp.MustCompile(`^[1-5]([0-9]{2}|[xX]{2})$`)
+
+// specErrorHint defines the remediation hint of the API errors matching the key it is defined for (x-terraform-error-hints),
+// so users get told how to fix the error (e,g: how to increase their quota) instead of just the API response
+type specErrorHint struct {
+	key     string
+	pattern *regexp.Regexp
+	hint    string
+}
+
+// newSpecErrorHint returns the hint of the errors matching the key passed in. The key can be either an HTTP status code
+// (e,g: 403), a class of HTTP status codes (e,g: 4XX) or a regular expression matched against the error, which contains
+// the API response body (e,g: an API error code such as QUOTA_EXCEEDED)
+func newSpecErrorHint(key, hint string) (*specErrorHint, error) {
+	expr := key
+	if errorHintStatusCodeRegex.MatchString(key) {
+		expr = fmt.Sprintf(`Status Code %s\b`, strings.NewReplacer("x", "[0-9]", "X", "[0-9]").Replace(key))
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("error hint '%s' not valid: %s", key, err)
+	}
+	return &specErrorHint{key: key, pattern: pattern, hint: hint}, nil
+}
+
+// specErrorHints contains the error hints defined in the OpenAPI document
+type specErrorHints []*specErrorHint
+
+// getHints returns the hints of the error message passed in, in the order the hints are defined
+func (h specErrorHints) getHints(errorMessage string) []string {
+	var hints []string
+	for _, errorHint := range h {
+		if errorHint.pattern.MatchString(errorMessage) {
+			hints = append(hints, errorHint.hint)
+		}
+	}
+	return hints
+}
+
+// appendHints returns the diagnostics passed in with the hints of the error diagnostics appended to their details
+func (h specErrorHints) appendHints(diags diag.Diagnostics) diag.Diagnostics {
+	for i, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		hints := h.getHints(d.Summary)
+		if len(hints) == 0 {
+			continue
+		}
+		if d.Detail != "" {
+			hints = append([]string{d.Detail}, hints...)
+		}
+		diags[i].Detail = strings.Join(hints, "\n")
+	}
+	return diags
+}
+
+// wrapCRUD returns the CRUD function passed in with the hints of the errors it returns appended to the diagnostics
+func (h specErrorHints) wrapCRUD(crudFunc func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if crudFunc == nil || len(h) == 0 {
+		return crudFunc
+	}
+	return func(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
+		return h.appendHints(crudFunc(ctx, data, i))
+	}
+}
+
+// apply appends the hints of the errors returned by the CRUD operations of the resources (or data sources) passed in to
+// their diagnostics. Both the CRUD operations subject to the schema timeouts and the ones handling the timeouts themselves
+// (e,g: the data sources ReadWithoutTimeout) are wrapped
+func (h specErrorHints) apply(resources map[string]*schema.Resource) {
+	if len(h) == 0 {
+		return
+	}
+	for _, resource := range resources {
+		resource.CreateContext = h.wrapCRUD(resource.CreateContext)
+		resource.ReadContext = h.wrapCRUD(resource.ReadContext)
+		resource.UpdateContext = h.wrapCRUD(resource.UpdateContext)
+		resource.DeleteContext = h.wrapCRUD(resource.DeleteContext)
+		resource.CreateWithoutTimeout = h.wrapCRUD(resource.CreateWithoutTimeout)
+		resource.ReadWithoutTimeout = h.wrapCRUD(resource.ReadWithoutTimeout)
+		resource.UpdateWithoutTimeout = h.wrapCRUD(resource.UpdateWithoutTimeout)
+		resource.DeleteWithoutTimeout = h.wrapCRUD(resource.DeleteWithoutTimeout)
+	}
+}
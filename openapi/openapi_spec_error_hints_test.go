@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSpecErrorHintsForTest(t *testing.T, keysAndHints ...string) specErrorHints {
+	var errorHints specErrorHints
+	for i := 0; i < len(keysAndHints); i += 2 {
+		errorHint, err := newSpecErrorHint(keysAndHints[i], keysAndHints[i+1])
+		require.NoError(t, err)
+		errorHints = append(errorHints, errorHint)
+	}
+	return errorHints
+}
+
+func TestSpecErrorHintsGetHints(t *testing.T) {
+	errorHints := newSpecErrorHintsForTest(t,
+		"403", "forbidden hint",
+		"4xx", "client error hint",
+		"QUOTA_EXCEEDED|LIMIT_REACHED", "quota hint",
+	)
+	testCases := []struct {
+		name          string
+		errorMessage  string
+		expectedHints []string
+	}{
+		{name: "status code and class of status codes matching", errorMessage: "[resource='cdn'] HTTP Response Status Code 403 not matching expected one [200] (forbidden)", expectedHints: []string{"forbidden hint", "client error hint"}},
+		{name: "class of status codes matching", errorMessage: "[resource='cdn'] HTTP Response Status Code 429 not matching expected one [200] (too many requests)", expectedHints: []string{"client error hint"}},
+		{name: "regular expression matching the response body", errorMessage: `[resource='cdn'] HTTP Response Status Code 500 not matching expected one [200] ({"code":"QUOTA_EXCEEDED"})`, expectedHints: []string{"quota hint"}},
+		{name: "status code not matching other numbers in the message", errorMessage: "[resource='cdn'] HTTP Response Status Code 500 not matching expected one [200] (4031 nodes)", expectedHints: nil},
+		{name: "no hints matching", errorMessage: "connection refused", expectedHints: nil},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedHints, errorHints.getHints(tc.errorMessage), tc.name)
+	}
+}
+
+func TestNewSpecErrorHintNotValid(t *testing.T) {
+	_, err := newSpecErrorHint("[A-Z", "some hint")
+	assert.EqualError(t, err, "error hint '[A-Z' not valid: error parsing regexp: missing closing ]: `[A-Z`")
+}
+
+func TestSpecErrorHintsAppendHints(t *testing.T) {
+	errorHints := newSpecErrorHintsForTest(t, "403", "Make sure the API key has the admin role")
+	diags := diag.Diagnostics{
+		{Severity: diag.Warning, Summary: "HTTP Response Status Code 403"},
+		{Severity: diag.Error, Summary: "HTTP Response Status Code 403 not matching expected one [200]"},
+		{Severity: diag.Error, Summary: "HTTP Response Status Code 403 not matching expected one [200]", Detail: "some detail"},
+		{Severity: diag.Error, Summary: "HTTP Response Status Code 500 not matching expected one [200]"},
+	}
+	expectedDiags := diag.Diagnostics{
+		{Severity: diag.Warning, Summary: "HTTP Response Status Code 403"},
+		{Severity: diag.Error, Summary: "HTTP Response Status Code 403 not matching expected one [200]", Detail: "Make sure the API key has the admin role"},
+		{Severity: diag.Error, Summary: "HTTP Response Status Code 403 not matching expected one [200]", Detail: "some detail\nMake sure the API key has the admin role"},
+		{Severity: diag.Error, Summary: "HTTP Response Status Code 500 not matching expected one [200]"},
+	}
+	assert.Equal(t, expectedDiags, errorHints.appendHints(diags))
+}
+
+func TestSpecErrorHintsApply(t *testing.T) {
+	crudFunc := func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return diag.FromErr(errors.New("HTTP Response Status Code 403 not matching expected one [200]"))
+	}
+	resources := map[string]*schema.Resource{
+		"cdn_v1": {CreateContext: crudFunc, ReadContext: crudFunc},
+	}
+	newSpecErrorHintsForTest(t, "403", "Make sure the API key has the admin role").apply(resources)
+
+	diags := resources["cdn_v1"].CreateContext(context.Background(), nil, nil)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Make sure the API key has the admin role", diags[0].Detail)
+	diags = resources["cdn_v1"].ReadContext(context.Background(), nil, nil)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Make sure the API key has the admin role", diags[0].Detail)
+	assert.Nil(t, resources["cdn_v1"].UpdateContext, "missing CRUD operations should not be wrapped")
+	assert.Nil(t, resources["cdn_v1"].DeleteContext, "missing CRUD operations should not be wrapped")
+}
+
+func TestSpecErrorHintsApplyDataSource(t *testing.T) {
+	dataSource, err := newDataSourceFactory(&specStubResource{
+		name: "cdn",
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+			},
+		},
+	}).createTerraformDataSource()
+	require.NoError(t, err)
+	dataSources := map[string]*schema.Resource{"cdn_v1": dataSource}
+	newSpecErrorHintsForTest(t, "403", "Make sure the API key has the admin role").apply(dataSources)
+
+	client := &clientOpenAPIStub{error: errors.New("HTTP Response Status Code 403 not matching expected one [200]")}
+	diags := dataSources["cdn_v1"].ReadWithoutTimeout(context.Background(), dataSource.TestResourceData(), client)
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Detail, "Make sure the API key has the admin role")
+	assert.Nil(t, dataSources["cdn_v1"].ReadContext, "missing CRUD operations should not be wrapped")
+}
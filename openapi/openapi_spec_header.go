@@ -10,6 +10,8 @@ type SpecHeaderParam struct {
 	Name          string
 	TerraformName string
 	IsRequired    bool
+	// Description contains the description of the header parameter as documented in the OpenAPI document
+	Description string
 }
 
 // GetHeaderTerraformConfigurationName returns the terraform compliant name of the header. If the header TerraformName
@@ -0,0 +1,178 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// specQuirksOpenAPIVersion defines the OpenAPI version the OpenAPI v3.1 documents are downgraded to in quirks mode, once
+// the v3.1 constructs the spec generators emit have been replaced with their v3.0 equivalents
+const specQuirksOpenAPIVersion = "3.0.3"
+
+// specQuirksNamedFields contains the fields whose values are maps keyed by names (e,g: property names or response codes),
+// so their keys must not be interpreted as schema fields
+var specQuirksNamedFields = map[string]bool{"properties": true, "definitions": true, "schemas": true, "responses": true, "headers": true}
+
+// specQuirksSkippedFields contains the fields whose values are not fixed since they are not schemas nor contain schemas
+// (e,g: example values which may look like schemas)
+var specQuirksSkippedFields = map[string]bool{"example": true, "examples": true, "default": true, "enum": true, "info": true}
+
+// specQuirksSchemaFields contains the schema fields that determine the type of the schema; title-only schemas are the
+// ones that do not have any of them
+var specQuirksSchemaFields = []string{"type", "$ref", "properties", "items", "additionalProperties", "allOf", "anyOf", "oneOf", "not", "enum"}
+
+// specQuirksFixer fixes the common artifacts of the popular spec generators (e,g: FastAPI or Springdoc) that the provider
+// would otherwise not support, keeping count of the fixes applied for logging purposes:
+// - nullable schemas wrapped in anyOf/oneOf along with a null schema (e,g: anyOf: [{type: string}, {type: 'null'}]) are
+// replaced with the non null schema; the same applies to the type arrays containing null (e,g: type: [string, 'null'])
+// - title-only inline schemas (e,g: {title: Metadata}), which generators emit for the properties of any type, are typed as strings
+// - numeric exclusiveMinimum/exclusiveMaximum (JSON schema draft 2019-09 onwards) are replaced with the minimum/maximum and
+// the boolean exclusiveMinimum/exclusiveMaximum supported by OpenAPI v2 and v3.0
+// - the OpenAPI v3.1 documents are downgraded to v3.0 once the above has been fixed
+type specQuirksFixer struct {
+	fixes map[string]int
+}
+
+// createSpecAnalyserWithQuirks creates the SpecAnalyser (see CreateSpecAnalyser) for the document located in the URL passed
+// in once the quirks of the spec generators have been fixed (see specQuirksFixer). The fixed document is held in memory,
+// while the host still falls back to where the original document is served from. The original document is retrieved with
+// the loader passed in
+func createSpecAnalyserWithQuirks(specAnalyserVersion SpecAnalyserVersion, openAPIDocumentURL string, load documentLoader) (SpecAnalyser, error) {
+	document, err := load(openAPIDocumentURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, err)
+	}
+	fixedDocument, err := fixSpecQuirks(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fix the quirks of the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, err)
+	}
+	specAnalyser, err := CreateSpecAnalyser(specAnalyserVersion, registerInMemoryDocument("quirks", fixedDocument))
+	if err != nil {
+		return nil, fmt.Errorf("quirks mode: %s", err)
+	}
+	switch s := specAnalyser.(type) {
+	case *specV2Analyser:
+		s.openAPIDocumentURL = openAPIDocumentURL
+	case *specV3Analyser:
+		s.openAPIDocumentURL = openAPIDocumentURL
+	}
+	return specAnalyser, nil
+}
+
+// fixSpecQuirks returns the document (JSON) resulting from fixing the quirks of the document (JSON or YAML) passed in
+func fixSpecQuirks(document []byte) ([]byte, error) {
+	var value interface{}
+	if err := yaml.Unmarshal(document, &value); err != nil {
+		return nil, fmt.Errorf("document is not valid JSON or YAML: %s", err)
+	}
+	openAPIDocument, ok := normalizeYAMLValue(value).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("document is not an object")
+	}
+	f := &specQuirksFixer{fixes: map[string]int{}}
+	openAPIDocument = f.fixValue(openAPIDocument, false).(map[string]interface{})
+	if version, _ := openAPIDocument["openapi"].(string); strings.HasPrefix(version, "3.1") {
+		openAPIDocument["openapi"] = specQuirksOpenAPIVersion
+		f.fixes[fmt.Sprintf("OpenAPI %s document downgraded to %s", version, specQuirksOpenAPIVersion)]++
+	}
+	for _, fix := range sortedKeys(f.fixes) {
+		analyserLog.Info("quirks mode: %s (%d)", fix, f.fixes[fix])
+	}
+	return json.Marshal(openAPIDocument)
+}
+
+// fixValue returns the value passed in with the quirks of the schemas it contains fixed. The named flag specifies whether
+// the value is keyed by names (see specQuirksNamedFields), in which case the value itself is not fixed
+func (f *specQuirksFixer) fixValue(value interface{}, named bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		fixed := make(map[string]interface{}, len(v))
+		for field, item := range v {
+			if !named && (specQuirksSkippedFields[field] || strings.HasPrefix(field, "x-")) {
+				fixed[field] = item
+				continue
+			}
+			fixed[field] = f.fixValue(item, !named && specQuirksNamedFields[field])
+		}
+		if named {
+			return fixed
+		}
+		return f.fixSchema(fixed)
+	case []interface{}:
+		fixed := make([]interface{}, len(v))
+		for i, item := range v {
+			fixed[i] = f.fixValue(item, false)
+		}
+		return fixed
+	}
+	return value
+}
+
+// fixSchema returns the schema passed in with its quirks fixed; the values that are not schemas are returned as is
+func (f *specQuirksFixer) fixSchema(schema map[string]interface{}) map[string]interface{} {
+	if types, ok := schema["type"].([]interface{}); ok {
+		if nonNullTypes := withoutNull(types); len(nonNullTypes) == 1 && len(types) > 1 {
+			schema["type"] = nonNullTypes[0]
+			f.fixes["nullable type arrays replaced with the non null type"]++
+		}
+	}
+	for _, field := range []string{"anyOf", "oneOf"} {
+		schemas, ok := schema[field].([]interface{})
+		if !ok {
+			continue
+		}
+		var nonNullSchemas []map[string]interface{}
+		for _, s := range schemas {
+			if s, ok := s.(map[string]interface{}); ok && s["type"] != "null" {
+				nonNullSchemas = append(nonNullSchemas, s)
+			}
+		}
+		if len(nonNullSchemas) != 1 || len(schemas) == 1 {
+			continue
+		}
+		delete(schema, field)
+		for name, value := range nonNullSchemas[0] {
+			if _, exists := schema[name]; !exists {
+				schema[name] = value
+			}
+		}
+		f.fixes[fmt.Sprintf("nullable schemas wrapped in %s replaced with the non null schema", field)]++
+	}
+	if _, ok := schema["title"].(string); ok && !hasAnyField(schema, specQuirksSchemaFields) {
+		schema["type"] = "string"
+		f.fixes["title-only schemas typed as strings"]++
+	}
+	for exclusiveField, boundField := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+		switch bound := schema[exclusiveField].(type) {
+		case int, int64, uint64, float64:
+			schema[boundField] = bound
+			schema[exclusiveField] = true
+			f.fixes[fmt.Sprintf("numeric %s replaced with %s", exclusiveField, boundField)]++
+		}
+	}
+	return schema
+}
+
+// withoutNull returns the types passed in except null
+func withoutNull(types []interface{}) []interface{} {
+	var nonNullTypes []interface{}
+	for _, t := range types {
+		if t != "null" {
+			nonNullTypes = append(nonNullTypes, t)
+		}
+	}
+	return nonNullTypes
+}
+
+// hasAnyField returns true if the value passed in contains any of the fields passed in
+func hasAnyField(value map[string]interface{}, fields []string) bool {
+	for _, field := range fields {
+		if _, exists := value[field]; exists {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastAPIDocument is an OpenAPI v3.1 document with the artifacts FastAPI generates for optional and any-typed properties
+const fastAPIDocument = `{
+  "openapi": "3.1.0",
+  "info": {"title": "CDN API", "version": "1.0.0"},
+  "paths": {
+    "/v1/cdns": {
+      "post": {
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/ContentDeliveryNetworkV1"}}}, "required": true},
+        "responses": {"201": {"description": "Created", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ContentDeliveryNetworkV1"}}}}}
+      }
+    },
+    "/v1/cdns/{id}": {
+      "get": {
+        "parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string", "title": "Id"}}],
+        "responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ContentDeliveryNetworkV1"}}}}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "ContentDeliveryNetworkV1": {
+        "type": "object",
+        "title": "ContentDeliveryNetworkV1",
+        "required": ["label"],
+        "properties": {
+          "id": {"type": "string", "title": "Id", "readOnly": true},
+          "label": {"type": "string", "title": "Label"},
+          "description": {"anyOf": [{"type": "string"}, {"type": "null"}], "title": "Description", "default": null},
+          "ttl": {"type": "integer", "title": "Ttl", "exclusiveMinimum": 0},
+          "metadata": {"title": "Metadata"}
+        }
+      }
+    }
+  }
+}`
+
+func TestFixSpecQuirksSchemas(t *testing.T) {
+	testCases := []struct {
+		name           string
+		schema         string
+		expectedSchema string
+	}{
+		{name: "nullable wrapped in anyOf", schema: `{"anyOf": [{"type": "string", "maxLength": 5}, {"type": "null"}], "title": "Name", "default": null}`, expectedSchema: `{"type": "string", "maxLength": 5, "title": "Name", "default": null}`},
+		{name: "nullable reference wrapped in oneOf", schema: `{"oneOf": [{"$ref": "#/components/schemas/Origin"}, {"type": "null"}]}`, expectedSchema: `{"$ref": "#/components/schemas/Origin"}`},
+		{name: "anyOf with several non null schemas", schema: `{"anyOf": [{"type": "string"}, {"type": "integer"}, {"type": "null"}]}`, expectedSchema: `{"anyOf": [{"type": "string"}, {"type": "integer"}, {"type": "null"}]}`},
+		{name: "nullable type array", schema: `{"type": ["integer", "null"]}`, expectedSchema: `{"type": "integer"}`},
+		{name: "title-only schema", schema: `{"title": "Metadata", "description": "Any metadata"}`, expectedSchema: `{"type": "string", "title": "Metadata", "description": "Any metadata"}`},
+		{name: "titled schema", schema: `{"title": "Tags", "type": "array", "items": {"type": "string"}}`, expectedSchema: `{"title": "Tags", "type": "array", "items": {"type": "string"}}`},
+		{name: "numeric exclusive bounds", schema: `{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 100.5}`, expectedSchema: `{"type": "number", "minimum": 0, "exclusiveMinimum": true, "maximum": 100.5, "exclusiveMaximum": true}`},
+		{name: "boolean exclusive bounds", schema: `{"type": "number", "minimum": 0, "exclusiveMinimum": true}`, expectedSchema: `{"type": "number", "minimum": 0, "exclusiveMinimum": true}`},
+		{name: "nested schemas", schema: `{"type": "object", "properties": {"title": {"title": "Title"}, "items": {"type": "array", "items": {"anyOf": [{"type": "string"}, {"type": "null"}]}}}}`, expectedSchema: `{"type": "object", "properties": {"title": {"type": "string", "title": "Title"}, "items": {"type": "array", "items": {"type": "string"}}}}`},
+		{name: "examples not fixed", schema: `{"type": "object", "example": {"title": "Metadata"}, "x-terraform-example": {"title": "Metadata"}}`, expectedSchema: `{"type": "object", "example": {"title": "Metadata"}, "x-terraform-example": {"title": "Metadata"}}`},
+	}
+	for _, tc := range testCases {
+		document, err := fixSpecQuirks([]byte(`{"openapi": "3.0.3", "components": {"schemas": {"Schema": ` + tc.schema + `}}}`))
+		require.NoError(t, err, tc.name)
+		assert.JSONEq(t, `{"openapi": "3.0.3", "components": {"schemas": {"Schema": `+tc.expectedSchema+`}}}`, string(document), tc.name)
+	}
+}
+
+func TestFixSpecQuirksOpenAPIVersion(t *testing.T) {
+	document, err := fixSpecQuirks([]byte("openapi: 3.1.0\ninfo:\n  title: CDN API\n  version: 1.0.0\n"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"openapi": "3.0.3", "info": {"title": "CDN API", "version": "1.0.0"}}`, string(document))
+
+	document, err = fixSpecQuirks([]byte(`{"swagger": "2.0", "info": {"title": "CDN API"}}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"swagger": "2.0", "info": {"title": "CDN API"}}`, string(document), "the info title should not be interpreted as a title-only schema")
+}
+
+func TestFixSpecQuirksDocumentNotValid(t *testing.T) {
+	_, err := fixSpecQuirks([]byte(`["not", "an", "object"]`))
+	assert.EqualError(t, err, "document is not an object")
+}
+
+func TestCreateSpecAnalyserWithQuirks(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fastAPIDocument))
+	}))
+	defer api.Close()
+
+	_, err := CreateSpecAnalyser("", api.URL+"/openapi.json")
+	require.Error(t, err, "the OpenAPI v3.1 document is not supported without the quirks mode")
+
+	specAnalyser, err := createSpecAnalyserWithQuirks("", api.URL+"/openapi.json", loadDocument)
+	require.NoError(t, err)
+	resources, err := specAnalyser.GetTerraformCompliantResources()
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	resourceSchema, err := resources[0].GetResourceSchema()
+	require.NoError(t, err)
+	description, err := resourceSchema.getProperty("description")
+	require.NoError(t, err)
+	assert.Equal(t, TypeString, description.Type)
+	assert.False(t, description.Required)
+	metadata, err := resourceSchema.getProperty("metadata")
+	require.NoError(t, err)
+	assert.Equal(t, TypeString, metadata.Type)
+	ttl, err := resourceSchema.getProperty("ttl")
+	require.NoError(t, err)
+	assert.Equal(t, TypeInt, ttl.Type)
+
+	backendConfiguration, err := specAnalyser.GetAPIBackendConfiguration()
+	require.NoError(t, err)
+	host, err := backendConfiguration.getHost()
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimPrefix(api.URL, "http://"), host, "the host should fall back to where the original document is served from")
+}
+
+func TestCreateSpecAnalyserWithQuirksDocumentNotFound(t *testing.T) {
+	_, err := createSpecAnalyserWithQuirks("", "/does/not/exist.json", loadDocument)
+	assert.Error(t, err)
+}
+
+func TestCreateSpecAnalyserQuirksMode(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fastAPIDocument))
+	}))
+	defer api.Close()
+
+	specAnalyser, err := createSpecAnalyser(&ServiceConfigStub{SwaggerURL: api.URL + "/openapi.json", QuirksMode: true}, loadDocument)
+	require.NoError(t, err)
+	assert.IsType(t, &specV3Analyser{}, specAnalyser)
+}
@@ -9,10 +9,16 @@ type SpecResource interface {
 	GetResourceName() string
 	getHost() (string, error)
 	getResourcePath(parentIDs []string) (string, error)
+	// getReadPath returns the resolved path the resource instance is read from if it differs from the resource instance
+	// path; an empty path is returned otherwise.
+	getReadPath(parentIDs []string, id string) (string, error)
 	GetResourceSchema() (*SpecSchemaDefinition, error)
 	ShouldIgnoreResource() bool
 	getResourceOperations() specResourceOperations
 	getTimeouts() (*specTimeouts, error)
+	// getBatchReadTTL returns how long the resource collection can be used to serve individual reads of the resource
+	// instances; nil is returned if batch read is not enabled for the resource.
+	getBatchReadTTL() (*time.Duration, error)
 	// GetParentResourceInfo returns a struct populated with relevant ParentResourceInfo if the resource is considered
 	// a sub-resource; nil otherwise.
 	GetParentResourceInfo() *ParentResourceInfo
@@ -1,5 +1,10 @@
 package openapi
 
+import (
+	"fmt"
+	"time"
+)
+
 type specResourceOperations struct {
 	List   *specResourceOperation
 	Post   *specResourceOperation
@@ -13,4 +18,292 @@ type specResourceOperation struct {
 	SecuritySchemes  SpecSecuritySchemes
 	HeaderParameters SpecHeaderParameters
 	responses        specResponses
+	// isConditionalGetEnabled is only applicable to the instance GET operation and defines whether the reads should be
+	// performed using conditional GET requests (If-None-Match)
+	isConditionalGetEnabled bool
+	// grpcMethod defines the gRPC method the operation is transcoded to when the gRPC backend is configured (e,g: CDNService/GetCDN)
+	grpcMethod string
+	// grpcPathParameters contains the names of the operation path parameters which are mapped to the gRPC request message fields
+	grpcPathParameters []string
+	// graphQL defines the GraphQL query or mutation the operation is performed with instead of the REST API call; nil if
+	// the operation is not a GraphQL operation
+	graphQL *specGraphQLOperation
+	// middlewares contains the names of the middlewares the operation API calls go through (x-terraform-middleware); nil
+	// if the operation does not select the middlewares, in which case all the middlewares configured are applied
+	middlewares []string
+	// revisionProperty is only applicable to the instance GET operation and defines the name of the resource property
+	// containing a monotonically increasing revision of the resource (x-terraform-revision-property). When configured,
+	// the refreshes fetch only the revision and perform the full read only if the revision changed
+	revisionProperty string
+	// fieldsParameter defines the name of the query parameter used to request a subset of the resource fields (sparse
+	// fieldsets)
+	fieldsParameter string
+	// fields contains the names of the resource fields requested when performing the GET operation (x-terraform-fields);
+	// empty if the whole resource representation is requested
+	fields []string
+	// fieldsFromSchema defines whether the resource fields requested when performing the GET operation are derived from
+	// the resource schema properties (x-terraform-fields: true)
+	fieldsFromSchema bool
+	// userAgent defines the template of the User-Agent header sent when performing the operation (x-terraform-user-agent)
+	// overriding the one configured for the service; empty if not specified
+	userAgent string
+	// isDetailFetchEnabled is only applicable to the root path GET operation and defines whether the data source matches
+	// found in the collection (summary representation) should be fetched via the instance GET operation so the data source
+	// is populated with the detailed representation of the resource (x-terraform-data-source-detail-fetch)
+	isDetailFetchEnabled bool
+	// forceApplyParameter is only applicable to the POST and PUT operations and defines the name of the query parameter
+	// sent (with value true) to force the changes when the API refuses to apply fields owned by other field managers
+	// (x-terraform-force-apply-parameter); empty if the operation does not support force applying the changes
+	forceApplyParameter string
+	// skipUnchangedUpdate is only applicable to the PUT operation and defines whether the update should be skipped
+	// when the desired payload matches the latest remote representation of the resource (x-terraform-skip-unchanged-update)
+	skipUnchangedUpdate bool
+	// requestTimeout defines the timeout of the HTTP requests performed by the operation (x-terraform-request-timeout)
+	// overriding the timeout of the HTTP client; nil if not specified
+	requestTimeout *time.Duration
+	// recordProvisioningEvents is only applicable to the POST and PUT operations and defines whether the statuses observed
+	// while polling the resource are recorded into the provisioning_events computed attribute (x-terraform-resource-provisioning-events)
+	recordProvisioningEvents bool
+	// waitFor is only applicable to the POST and PUT operations and defines whether the users can configure the wait_for
+	// block to wait for a resource attribute to have one of the values specified once the operation completes (x-terraform-wait-for)
+	waitFor bool
+	// precheck is only applicable to the POST operation and defines the request performed before creating the resource to
+	// check whether the resource can be created (e,g: quota or capacity endpoints); nil if not specified
+	precheck *specPrecheck
+	// updateStrategy is only applicable to the PUT operation and defines how the resource updates are sent to the API
+	// (x-terraform-update-strategy or json-patch if the update operation consumes JSON Patch documents); empty if the whole
+	// payload is sent in a PUT request
+	updateStrategy string
+	// lock is only applicable to the PUT and DELETE operations and defines the lock acquired from the API before the
+	// resource is updated or deleted and released afterwards (x-terraform-lock-path); nil if not specified
+	lock *specLock
+	// consistencyToken is only applicable to the POST and PUT operations and defines where the consistency token is returned
+	// in the response and how it is sent on the following reads of the resource (x-terraform-consistency-token); nil if
+	// not specified
+	consistencyToken *specConsistencyToken
+	// retry defines the retry settings of the operation API calls (x-terraform-retry) overriding the ones of the retry
+	// middleware configured for the service; nil if not specified
+	retry *specRetry
+	// optionsPath is only applicable to the POST operation and defines the path of the endpoint returning the values the API
+	// currently accepts for the resource properties, exposed via the resource options data source (x-terraform-options-path)
+	optionsPath string
+	// count is only applicable to the root path GET operation and defines the endpoint the count data source gets the number
+	// of items matching the filters from (x-terraform-count-path); nil if the items are counted from the collection instead
+	count *specCount
+	// pagination is only applicable to the root path GET operation and defines the response headers containing the
+	// pagination metadata of the collection (x-terraform-pagination-total-header, x-terraform-pagination-next-cursor-header);
+	// nil if not specified
+	pagination *specPagination
+}
+
+// isMergePatch returns true if the resource updates are sent as JSON merge patches (RFC 7386) containing only the changed
+// attributes in PATCH requests instead of PUT requests containing the whole payload
+func (o *specResourceOperation) isMergePatch() bool {
+	return o != nil && o.updateStrategy == operationUpdateStrategyMergePatch
+}
+
+// isJSONPatch returns true if the resource updates are sent as JSON Patch documents (RFC 6902) in PATCH requests instead
+// of PUT requests containing the whole payload
+func (o *specResourceOperation) isJSONPatch() bool {
+	return o != nil && o.updateStrategy == operationUpdateStrategyJSONPatch
+}
+
+// getUpdateMethod returns the HTTP method the resource updates are performed with: PATCH if the operation sends the updates
+// as JSON merge patches or JSON Patch documents; PUT otherwise
+func (o *specResourceOperation) getUpdateMethod() httpMethodSupported {
+	if o.isMergePatch() || o.isJSONPatch() {
+		return httpPatch
+	}
+	return httpPut
+}
+
+// getUpdateContentType returns the content type of the resource update request payloads if the updates are sent as JSON
+// merge patches or JSON Patch documents; empty otherwise
+func (o *specResourceOperation) getUpdateContentType() string {
+	switch {
+	case o.isMergePatch():
+		return mergePatchContentType
+	case o.isJSONPatch():
+		return jsonPatchContentType
+	}
+	return ""
+}
+
+// specPagination defines the response headers of the collection containing the pagination metadata exposed by the data
+// source, so users can detect when the collection returned is truncated (e,g: only the first page is returned)
+type specPagination struct {
+	// totalHeader contains the name of the response header containing the total number of items of the collection (e,g:
+	// X-Total-Count); empty if not specified
+	totalHeader string
+	// nextCursorHeader contains the name of the response header containing the cursor of the next page (e,g: X-Next-Cursor).
+	// If the header is the Link header, the URL of the link with relation type next is used as the cursor; empty if not specified
+	nextCursorHeader string
+}
+
+// specCount defines the server side count request performed by the count data source (x-terraform-count-path). The data
+// source filters are sent as query parameters (e,g: /v1/cdns/count?label=my_label)
+type specCount struct {
+	// path contains the path of the count endpoint (e,g: /v1/cdns/count). The path parameters (e,g: {project_id}) are
+	// resolved in order with the parent IDs of the data source
+	path string
+	// field contains the name of the response payload field containing the number of matching items (dot separated for
+	// nested fields, e,g: meta.total)
+	field string
+}
+
+// specPrecheck defines the request performed before creating a resource (x-terraform-precheck-path). The creation is aborted
+// if the API responds with a non 2xx status code or the precheck field of the response payload is falsy
+type specPrecheck struct {
+	// path contains the path of the precheck endpoint (e,g: /v1/quotas/clusters). The path parameters (e,g: {project_id})
+	// are resolved in order with the parent IDs of the resource
+	path string
+	// field contains the name of the response payload field that must be truthy for the resource to be created (dot
+	// separated for nested fields, e,g: quota.available); empty if only the response status code is checked
+	field string
+	// messageField contains the name of the response payload field containing the message returned to the user when the
+	// precheck fails (dot separated for nested fields)
+	messageField string
+}
+
+// specLock defines the named lock acquired from the API before updating or deleting a resource (x-terraform-lock-path), so
+// concurrent terraform runs changing resources that share the same parent are ordered instead of racing. The lock is
+// acquired with a POST request and released with a DELETE request against the lock path
+type specLock struct {
+	// path contains the path of the lock endpoint (e,g: /v1/projects/{project_id}/locks/cdns). The path parameters are
+	// resolved in order with the parent IDs of the resource followed by the resource ID, so locks can be scoped to the
+	// shared parent (e,g: {project_id}) or to the resource itself
+	path string
+}
+
+// specConsistencyToken defines the consistency token returned by the API when a resource is created or updated, which is
+// sent on the following reads of the resource so they are served by a replica that already reflects the write
+// (read-your-writes against eventually consistent read replicas)
+type specConsistencyToken struct {
+	// responseHeader contains the name of the response header containing the token; empty if the token is returned in the
+	// response payload
+	responseHeader string
+	// responseField contains the name of the response payload field containing the token (dot separated for nested
+	// fields, e,g: meta.consistency_token); empty if the token is returned in a response header
+	responseField string
+	// requestHeader contains the name of the header the token is sent in on the reads; empty if the token is sent in a
+	// query parameter
+	requestHeader string
+	// requestParameter contains the name of the query parameter the token is sent in on the reads; empty if the token is
+	// sent in a header
+	requestParameter string
+}
+
+// validate makes sure the token is read from either a response header or field, and sent in either a header or a query
+// parameter
+func (c *specConsistencyToken) validate() error {
+	if (c.responseHeader == "") == (c.responseField == "") {
+		return fmt.Errorf("either response_header or response_field must be provided")
+	}
+	if (c.requestHeader == "") == (c.requestParameter == "") {
+		return fmt.Errorf("either request_header or request_parameter must be provided")
+	}
+	return nil
+}
+
+// specRetry defines the retry settings of a resource or operation (x-terraform-retry), which override the settings of the
+// retry middleware configured for the service (see MiddlewareConfig). The settings not specified are inherited from the
+// retry middleware
+type specRetry struct {
+	// maxRetries defines the max number of retries; zero disables the retries. nil if not specified
+	maxRetries *int
+	// retryWait defines the wait before the first retry (e,g: 500ms); empty if not specified
+	retryWait string
+	// maxRetryWait defines the max wait in between retries (e,g: 10s); empty if not specified
+	maxRetryWait string
+	// retryStatusCodes contains the response status codes that are retried; empty if not specified
+	retryStatusCodes []int
+}
+
+// validate makes sure the retry waits are valid durations
+func (r *specRetry) validate() error {
+	for field, value := range map[string]string{"retry_wait": r.retryWait, "max_retry_wait": r.maxRetryWait} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("%s '%s' not valid: %s", field, value, err)
+		}
+	}
+	return nil
+}
+
+// apply returns the retry middleware configuration passed in with the settings of the resource or operation applied
+func (r *specRetry) apply(middlewareConfig MiddlewareConfig) MiddlewareConfig {
+	if r.maxRetries != nil {
+		middlewareConfig.MaxRetries = *r.maxRetries
+	}
+	if r.retryWait != "" {
+		middlewareConfig.RetryWait = r.retryWait
+	}
+	if r.maxRetryWait != "" {
+		middlewareConfig.MaxRetryWait = r.maxRetryWait
+	}
+	if len(r.retryStatusCodes) > 0 {
+		middlewareConfig.RetryStatusCodes = r.retryStatusCodes
+	}
+	return middlewareConfig
+}
+
+// specGraphQLOperation defines the GraphQL document (query or mutation) an operation is performed with
+type specGraphQLOperation struct {
+	// document contains the GraphQL query or mutation document
+	document string
+	// endpoint contains the path of the GraphQL endpoint relative to the API host or the full URL of the endpoint
+	endpoint string
+	// resultPath contains the path (dot separated) of the response data field that contains the resource
+	resultPath string
+}
+
+// getFieldsParameter returns the name of the query parameter used to request a subset of the resource fields
+func (o *specResourceOperation) getFieldsParameter() string {
+	if o == nil || o.fieldsParameter == "" {
+		return defaultFieldsParameter
+	}
+	return o.fieldsParameter
+}
+
+// getFields returns the names of the fields to request when performing the operation (sparse fieldsets). If the fields are
+// derived from the resource schema, the names of the top level properties of the resource schema are returned. Empty is
+// returned if the operation does not request a subset of the fields
+func (o *specResourceOperation) getFields(resource SpecResource) []string {
+	if o == nil {
+		return nil
+	}
+	if !o.fieldsFromSchema {
+		return o.fields
+	}
+	resourceSchema, err := resource.GetResourceSchema()
+	if err != nil {
+		return nil
+	}
+	fields := make([]string, 0, len(resourceSchema.Properties))
+	for _, property := range resourceSchema.Properties {
+		fields = append(fields, property.Name)
+	}
+	return fields
+}
+
+// isGraphQL returns true if the operation is performed via a GraphQL query or mutation
+func (o *specResourceOperation) isGraphQL() bool {
+	return o != nil && o.graphQL != nil
+}
+
+// isDataSourceDetailFetchEnabled returns true if the operation is configured to fetch the detailed representation of the
+// data source matches
+func (o *specResourceOperation) isDataSourceDetailFetchEnabled() bool {
+	return o != nil && o.isDetailFetchEnabled
+}
+
+// getForceApplyParameter returns the name of the query parameter used to force applying the changes; empty is returned
+// if the operation does not support force applying the changes
+func (o *specResourceOperation) getForceApplyParameter() string {
+	if o == nil {
+		return ""
+	}
+	return o.forceApplyParameter
 }
@@ -0,0 +1,30 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecResourceOperationGetFieldsParameter(t *testing.T) {
+	var nilOperation *specResourceOperation
+	assert.Equal(t, "fields", nilOperation.getFieldsParameter())
+	assert.Equal(t, "fields", (&specResourceOperation{}).getFieldsParameter())
+	assert.Equal(t, "include", (&specResourceOperation{fieldsParameter: "include"}).getFieldsParameter())
+}
+
+func TestSpecResourceOperationGetFields(t *testing.T) {
+	resource := &specStubResource{
+		schemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil),
+			},
+		},
+	}
+	var nilOperation *specResourceOperation
+	assert.Nil(t, nilOperation.getFields(resource))
+	assert.Nil(t, (&specResourceOperation{}).getFields(resource))
+	assert.Equal(t, []string{"id", "status"}, (&specResourceOperation{fields: []string{"id", "status"}}).getFields(resource))
+	assert.Equal(t, []string{"id", "label"}, (&specResourceOperation{fieldsFromSchema: true}).getFields(resource))
+}
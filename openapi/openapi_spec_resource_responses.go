@@ -1,11 +1,51 @@
 package openapi
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const (
+	// pollSucceededStatus and pollPendingStatus are the statuses the poll requests with success expression resolve to
+	pollSucceededStatus = "succeeded"
+	pollPendingStatus   = "pending"
+)
+
 type specResponses map[int]*specResponse
 
 type specResponse struct {
 	isPollingEnabled    bool
 	pollTargetStatuses  []string
 	pollPendingStatuses []string
+	// pollRequest defines the request performed to check the status of the resource when polling
+	// (x-terraform-resource-poll-request); nil if the resource instance is polled via the GET operation
+	pollRequest *specPollRequest
+}
+
+// specPollRequest defines a custom request used to check the status of the resource when polling (e,g: status endpoints
+// that require POST requests with a JSON-RPC body). The path, body and expressions are text templates; the path and body
+// templates are executed with the resource ID (e,g: {{.ID}}) and the expressions with the response payload of the request
+type specPollRequest struct {
+	method httpMethodSupported
+	// path contains the path of the status endpoint; empty if the request is performed against the resource instance path
+	path string
+	// body contains the JSON body of the request; empty if the request does not send a body
+	body string
+	// successExpression contains the expression that evaluates to true once the resource reaches a completion status; if
+	// empty, the status of the resource is read from the response payload as done with the GET operation
+	successExpression string
+	// failureExpression contains the expression that evaluates to true if the resource reached a failure status
+	failureExpression string
+}
+
+// pollRequestTemplateData defines the data the poll request path and body templates are executed with
+type pollRequestTemplateData struct {
+	ID string
+	// ParentIDs contains the IDs of the parent resources of sub-resources in the order they appear in the resource path
+	ParentIDs []string
 }
 
 func (s specResponses) getResponse(responseStatusCode int) *specResponse {
@@ -15,3 +55,87 @@ func (s specResponses) getResponse(responseStatusCode int) *specResponse {
 	}
 	return response
 }
+
+// validate makes sure the poll request method is supported and all the templates can be parsed
+func (p *specPollRequest) validate() error {
+	switch p.method {
+	case httpGet, httpPost, httpPut:
+	default:
+		return fmt.Errorf("method '%s' not supported, supported methods are [%s, %s, %s]", p.method, httpGet, httpPost, httpPut)
+	}
+	if p.failureExpression != "" && p.successExpression == "" {
+		return fmt.Errorf("failure_expression requires the success_expression to be configured")
+	}
+	for name, text := range map[string]string{"path": p.path, "body": p.body, "success_expression": p.successExpression, "failure_expression": p.failureExpression} {
+		if _, err := template.New(name).Parse(text); err != nil {
+			return fmt.Errorf("%s not valid: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// renderPath returns the path of the status endpoint for the resource instance passed in. The path parameters
+// (e,g: {cluster_id}) are resolved in order with the parent IDs passed in, as done with the precheck paths
+func (p *specPollRequest) renderPath(id string, parentIDs []string) (string, error) {
+	path, err := executePollTemplate("path", p.path, pollRequestTemplateData{ID: id, ParentIDs: parentIDs})
+	if err != nil {
+		return "", err
+	}
+	return resolvePathParameters(path, parentIDs)
+}
+
+// renderBody returns the request body (decoded from its JSON representation) for the resource instance passed in; nil is
+// returned if the poll request does not send a body
+func (p *specPollRequest) renderBody(id string, parentIDs []string) (interface{}, error) {
+	if p.body == "" {
+		return nil, nil
+	}
+	body, err := executePollTemplate("body", p.body, pollRequestTemplateData{ID: id, ParentIDs: parentIDs})
+	if err != nil {
+		return nil, err
+	}
+	var requestPayload interface{}
+	if err := json.Unmarshal([]byte(body), &requestPayload); err != nil {
+		return nil, fmt.Errorf("poll request body is not valid JSON: %s", err)
+	}
+	return requestPayload, nil
+}
+
+// isExpressionBased returns true if the status of the resource is resolved via the success (and failure) expressions
+func (p *specPollRequest) isExpressionBased() bool {
+	return p != nil && p.successExpression != ""
+}
+
+// getStatus evaluates the expressions against the response payload of the poll request returning pollSucceededStatus if
+// the success expression evaluates to true, and pollPendingStatus otherwise. An error is returned if the failure expression
+// evaluates to true. Expressions that can not be evaluated (e,g: fields not returned yet) are considered false
+func (p *specPollRequest) getStatus(responsePayload map[string]interface{}) (string, error) {
+	if p.failureExpression != "" && evaluatePollExpression("failure_expression", p.failureExpression, responsePayload) {
+		return "", fmt.Errorf("the poll request failure expression '%s' evaluated to true", p.failureExpression)
+	}
+	if evaluatePollExpression("success_expression", p.successExpression, responsePayload) {
+		return pollSucceededStatus, nil
+	}
+	return pollPendingStatus, nil
+}
+
+func evaluatePollExpression(name, expression string, responsePayload map[string]interface{}) bool {
+	result, err := executePollTemplate(name, expression, responsePayload)
+	if err != nil {
+		pollerLog.Debug("poll request %s '%s' could not be evaluated, considering it false: %s", name, expression, err)
+		return false
+	}
+	return strings.TrimSpace(result) == "true"
+}
+
+func executePollTemplate(name, text string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
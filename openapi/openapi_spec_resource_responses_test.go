@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecPollRequestValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pollRequest   *specPollRequest
+		expectedError string
+	}{
+		{name: "valid poll request", pollRequest: &specPollRequest{method: httpPost, path: "/v1/rpc", body: `{"id":"{{.ID}}"}`, successExpression: `{{eq .result.status "done"}}`, failureExpression: `{{eq .result.status "failed"}}`}},
+		{name: "method not supported", pollRequest: &specPollRequest{method: httpDelete}, expectedError: "method 'DELETE' not supported, supported methods are [GET, POST, PUT]"},
+		{name: "failure expression without success expression", pollRequest: &specPollRequest{method: httpGet, failureExpression: `{{eq .status "failed"}}`}, expectedError: "failure_expression requires the success_expression to be configured"},
+		{name: "body template not valid", pollRequest: &specPollRequest{method: httpPost, body: `{"id":"{{if .ID}}"}`}, expectedError: `body not valid: template: body:1: unexpected EOF`},
+	}
+	for _, tc := range testCases {
+		err := tc.pollRequest.validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestSpecPollRequestRender(t *testing.T) {
+	pollRequest := &specPollRequest{method: httpPost, path: "/v1/clusters/{{.ID}}/status", body: `{"jsonrpc":"2.0","params":{"id":"{{.ID}}"}}`}
+	path, err := pollRequest.renderPath("1234", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/clusters/1234/status", path)
+	body, err := pollRequest.renderBody("1234", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"jsonrpc": "2.0", "params": map[string]interface{}{"id": "1234"}}, body)
+
+	body, err = (&specPollRequest{method: httpGet}).renderBody("1234", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, body)
+
+	// sub-resources poll requests can refer to the parent IDs via the path parameters or the templates
+	pollRequest = &specPollRequest{method: httpPost, path: "/v1/clusters/{cluster_id}/nodes/{{.ID}}/status", body: `{"cluster":"{{index .ParentIDs 0}}","id":"{{.ID}}"}`}
+	path, err = pollRequest.renderPath("1234", []string{"parent-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/clusters/parent-1/nodes/1234/status", path)
+	body, err = pollRequest.renderBody("1234", []string{"parent-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"cluster": "parent-1", "id": "1234"}, body)
+
+	_, err = pollRequest.renderPath("1234", nil)
+	assert.EqualError(t, err, "could not resolve the path '/v1/clusters/{cluster_id}/nodes/1234/status' with the given ids - missing ids to resolve the path params properly: []")
+}
+
+func TestSpecPollRequestGetStatus(t *testing.T) {
+	pollRequest := &specPollRequest{method: httpPost, successExpression: `{{eq .result.status "done"}}`, failureExpression: `{{eq .result.status "failed"}}`}
+	assert.True(t, pollRequest.isExpressionBased())
+	assert.False(t, (&specPollRequest{method: httpPost}).isExpressionBased())
+
+	testCases := []struct {
+		name            string
+		responsePayload map[string]interface{}
+		expectedStatus  string
+		expectedError   string
+	}{
+		{name: "success expression evaluates to true", responsePayload: map[string]interface{}{"result": map[string]interface{}{"status": "done"}}, expectedStatus: pollSucceededStatus},
+		{name: "success expression evaluates to false", responsePayload: map[string]interface{}{"result": map[string]interface{}{"status": "running"}}, expectedStatus: pollPendingStatus},
+		{name: "expressions can not be evaluated", responsePayload: map[string]interface{}{}, expectedStatus: pollPendingStatus},
+		{name: "failure expression evaluates to true", responsePayload: map[string]interface{}{"result": map[string]interface{}{"status": "failed"}}, expectedError: `the poll request failure expression '{{eq .result.status "failed"}}' evaluated to true`},
+	}
+	for _, tc := range testCases {
+		status, err := pollRequest.getStatus(tc.responsePayload)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedStatus, status, tc.name)
+	}
+}
@@ -12,18 +12,35 @@ type SpecSchemaDefinitionProperties []*SpecSchemaDefinitionProperty
 // SpecSchemaDefinition defines a struct for a schema definition
 type SpecSchemaDefinition struct {
 	Properties SpecSchemaDefinitionProperties
+	// Description contains the description of the schema definition as documented in the OpenAPI document, which is
+	// exposed as the description of the resources (and data sources) in the terraform schema
+	Description string
+
+	// propertyIndex enables looking up properties by name without scanning the properties. The index is built once the
+	// schema definition is fully populated (see buildPropertyIndex); if not built lookups fall back to scanning the properties.
+	propertyIndex *specSchemaDefinitionPropertyIndex
+}
+
+// specSchemaDefinitionPropertyIndex indexes the properties of a SpecSchemaDefinition by both the original property name
+// and the terraform compliant property name
+type specSchemaDefinitionPropertyIndex struct {
+	numProperties   int
+	byName          map[string]*SpecSchemaDefinitionProperty
+	byTerraformName map[string]*SpecSchemaDefinitionProperty
 }
 
 // ConvertToDataSourceSpecSchemaDefinition transforms the current SpecSchemaDefinition into a data source SpecSchemaDefinition. This
 // means that all the properties that form the schema will be made optional, computed and they won't have default values.
 func (s *SpecSchemaDefinition) ConvertToDataSourceSpecSchemaDefinition() *SpecSchemaDefinition {
 	specSchemaDefinition := &SpecSchemaDefinition{
-		Properties: SpecSchemaDefinitionProperties{},
+		Properties:  SpecSchemaDefinitionProperties{},
+		Description: s.Description,
 	}
 	for _, p := range s.Properties {
 		dataSourceSpecSchemaDefinitionProperty := s.convertToDataSourceSpecSchemaDefinitionProperty(*p)
 		specSchemaDefinition.Properties = append(specSchemaDefinition.Properties, dataSourceSpecSchemaDefinitionProperty)
 	}
+	specSchemaDefinition.buildPropertyIndex()
 	return specSchemaDefinition
 }
 
@@ -34,6 +51,9 @@ func (s *SpecSchemaDefinition) convertToDataSourceSpecSchemaDefinitionProperty(s
 	specSchemaDefinitionProperty.Required = false
 	specSchemaDefinitionProperty.Computed = true
 	specSchemaDefinitionProperty.Default = nil
+	specSchemaDefinitionProperty.AttributeAliases = nil
+	specSchemaDefinitionProperty.ResolveByName = nil
+	specSchemaDefinitionProperty.Upload = nil
 	if specSchemaDefinitionProperty.SpecSchemaDefinition != nil {
 		dataSourceObjectSpecSchemaDefinition := &SpecSchemaDefinition{
 			Properties: SpecSchemaDefinitionProperties{},
@@ -42,6 +62,7 @@ func (s *SpecSchemaDefinition) convertToDataSourceSpecSchemaDefinitionProperty(s
 			dataSourceObjectProperty := s.convertToDataSourceSpecSchemaDefinitionProperty(*objectProperty)
 			dataSourceObjectSpecSchemaDefinition.Properties = append(dataSourceObjectSpecSchemaDefinition.Properties, dataSourceObjectProperty)
 		}
+		dataSourceObjectSpecSchemaDefinition.buildPropertyIndex()
 		specSchemaDefinitionProperty.SpecSchemaDefinition = dataSourceObjectSpecSchemaDefinition
 	}
 	return &specSchemaDefinitionProperty
@@ -76,6 +97,15 @@ func (s *SpecSchemaDefinition) createResourceSchemaIgnoreID(ignoreID bool) (map[
 			return nil, err
 		}
 		terraformSchema[property.GetTerraformCompliantPropertyName()] = tfSchema
+		for alias, aliasSchema := range property.terraformAttributeAliasesSchema(tfSchema) {
+			terraformSchema[alias] = aliasSchema
+		}
+		if attribute, attributeSchema := property.terraformResolveByNameSchema(tfSchema); attributeSchema != nil {
+			terraformSchema[attribute] = attributeSchema
+		}
+		if attribute, attributeSchema := property.terraformUploadSchema(tfSchema); attributeSchema != nil {
+			terraformSchema[attribute] = attributeSchema
+		}
 	}
 	return terraformSchema, nil
 }
@@ -120,6 +150,31 @@ func (s *SpecSchemaDefinition) getResourceIdentifier() (string, error) {
 	return identifierProperty, nil
 }
 
+// resolveResourceIdentifierAliases populates the identifier property in the given payload with the value of the first
+// identifier alias (x-terraform-id-aliases) found in the payload, if the payload does not contain the identifier property
+// already. This enables resources to keep working when the API renames the identifier field between versions (e,g: id
+// renamed to uuid). Aliases that are not properties of the schema are removed from the payload once resolved.
+func (s *SpecSchemaDefinition) resolveResourceIdentifierAliases(payload map[string]interface{}) {
+	identifierPropertyName, err := s.getResourceIdentifier()
+	if err != nil || payload[identifierPropertyName] != nil {
+		return
+	}
+	identifierProperty, err := s.getProperty(identifierPropertyName)
+	if err != nil {
+		return
+	}
+	for _, alias := range identifierProperty.IdentifierAliases {
+		if value := payload[alias]; value != nil {
+			payload[identifierPropertyName] = value
+			if _, err := s.getProperty(alias); err != nil {
+				delete(payload, alias)
+			}
+			resourceLog.Debug("identifier property '%s' resolved from alias '%s'", identifierPropertyName, alias)
+			return
+		}
+	}
+}
+
 // getStatusIdentifier returns the property name that is supposed to be used as the status field. The status field
 // is selected as follows:
 // 1.If the given schema definition contains a property configured with metadata 'x-terraform-field-status' set to true, that property
@@ -169,7 +224,38 @@ func (s *SpecSchemaDefinition) getStatusIdentifierFor(schemaDefinition *SpecSche
 	return statusHierarchy, nil
 }
 
+// buildPropertyIndex builds the index used to look up properties by name. It must be called once the schema definition
+// properties are fully populated; the index is ignored if properties are added or removed afterwards.
+func (s *SpecSchemaDefinition) buildPropertyIndex() {
+	index := &specSchemaDefinitionPropertyIndex{
+		numProperties:   len(s.Properties),
+		byName:          make(map[string]*SpecSchemaDefinitionProperty, len(s.Properties)),
+		byTerraformName: make(map[string]*SpecSchemaDefinitionProperty, len(s.Properties)),
+	}
+	// iterating backwards so in the unlikely case of duplicate names the first property wins, as it would when scanning
+	for i := len(s.Properties) - 1; i >= 0; i-- {
+		property := s.Properties[i]
+		index.byName[property.Name] = property
+		index.byTerraformName[property.GetTerraformCompliantPropertyName()] = property
+	}
+	s.propertyIndex = index
+}
+
+// getPropertyIndex returns the property index if built and still in sync with the properties; nil otherwise
+func (s *SpecSchemaDefinition) getPropertyIndex() *specSchemaDefinitionPropertyIndex {
+	if s.propertyIndex == nil || s.propertyIndex.numProperties != len(s.Properties) {
+		return nil
+	}
+	return s.propertyIndex
+}
+
 func (s *SpecSchemaDefinition) getProperty(name string) (*SpecSchemaDefinitionProperty, error) {
+	if index := s.getPropertyIndex(); index != nil {
+		if property, exists := index.byName[name]; exists {
+			return property, nil
+		}
+		return nil, fmt.Errorf("property with name '%s' not existing in resource schema definition", name)
+	}
 	for _, property := range s.Properties {
 		if property.Name == name {
 			return property, nil
@@ -179,6 +265,12 @@ func (s *SpecSchemaDefinition) getProperty(name string) (*SpecSchemaDefinitionPr
 }
 
 func (s *SpecSchemaDefinition) getPropertyBasedOnTerraformName(terraformName string) (*SpecSchemaDefinitionProperty, error) {
+	if index := s.getPropertyIndex(); index != nil {
+		if property, exists := index.byTerraformName[terraformName]; exists {
+			return property, nil
+		}
+		return nil, fmt.Errorf("property with terraform name '%s' not existing in resource schema definition", terraformName)
+	}
 	for _, property := range s.Properties {
 		if property.GetTerraformCompliantPropertyName() == terraformName {
 			return property, nil
@@ -1,10 +1,15 @@
 package openapi
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"hash/crc32"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -41,6 +46,22 @@ type SpecSchemaDefinitionProperty struct {
 
 	// IgnoreItemsOrder if set to true means that the array items order should be ignored
 	IgnoreItemsOrder bool
+	// Ordered if set to true means that the array items order is meaningful (e,g: priority rule chains). The items are
+	// compared by position and the order returned by the API is kept in the state (x-terraform-ordered)
+	Ordered bool
+	// IgnoreServerItems if set to true means that the items appended by the server are not stored in the state, only the
+	// items declared by the user are managed (x-terraform-ignore-server-items)
+	IgnoreServerItems bool
+	// UpdateStrategy defines how the changes of array properties are sent to the API when updating the resource
+	// (x-terraform-update-strategy): the whole collection in the update request payload (replace, default) or the items
+	// added and removed to the collection sub-endpoints (merge)
+	UpdateStrategy string
+	// UniqueItems if set to true means that the array items must be unique (uniqueItems attribute)
+	UniqueItems bool
+	// MinItems and MaxItems define the minimum and maximum number of items of array properties (minItems and maxItems
+	// attributes); zero means no limit
+	MinItems int
+	MaxItems int
 
 	Required bool
 	// ReadOnly properties are included in responses but not in request
@@ -55,13 +76,103 @@ type SpecSchemaDefinitionProperty struct {
 	Immutable          bool
 	IsIdentifier       bool
 	IsStatusIdentifier bool
+	// IdentifierAliases contains the previous names of the identifier property (x-terraform-id-aliases); if the payload
+	// returned by the API does not contain the identifier property, the value of the first alias present is used instead
+	IdentifierAliases []string
+	// AttributeAliases contains the previous terraform names of the property (x-terraform-attribute-aliases); the aliases
+	// are still accepted in the configuration (with a deprecation warning) and their values are sent as the property value
+	AttributeAliases []string
+	// EncryptInState defines whether the property value is stored encrypted in the state (x-terraform-encrypt-in-state)
+	EncryptInState bool
+	// FingerprintInState defines whether the property value is replaced with its fingerprint in the state
+	// (x-terraform-fingerprint-in-state)
+	FingerprintInState bool
+	// Coerce defines whether the string values returned by the API are converted into the type of the property (or the
+	// type of the items for arrays) when stored in the state (x-terraform-coerce)
+	Coerce bool
+	// IgnoreServerChanges defines whether the values returned by the API are ignored once the property has a value in the
+	// state, so changes made by the server do not show up as drift (x-terraform-ignore-server-changes)
+	IgnoreServerChanges bool
+	// ResolveByName defines the lookup used to resolve the ID of the resource referenced by the property from its name, so
+	// users can configure the human readable name instead (x-terraform-resolve-by-name); nil if not specified
+	ResolveByName *specResolveByName
+	// Upload defines how the content of the local file configured for the property is uploaded to the object storage,
+	// the property being populated with the key of the object uploaded (x-terraform-upload-property); nil if not specified
+	Upload *specUpload
 	// Default field is only for informative purposes to know what the openapi spec for the property stated the default value is
 	// As per the openapi spec default attributes, the value is expected to be computed by the API
 	Default interface{}
+	// Validator contains the name of the registered validator applied to the property value (x-terraform-validator)
+	Validator string
+	// Format contains the format of the property value (format attribute or x-terraform-format extension); for arrays it
+	// contains the format of the items. Network formats (ip, ipv4, ipv6 and cidr) are compared semantically
+	Format string
+	// Example contains the example value of the property (x-terraform-example or example attribute); it is used to generate
+	// the payloads of the smoke tests and the provider documentation
+	Example interface{}
+	// Enum contains the values allowed for the property (enum attribute); for arrays it contains the values allowed for the
+	// items. It is only for informative purposes (e,g: the provider documentation)
+	Enum []interface{}
 	// only for object type properties or arrays type properties with array items of type object
 	SpecSchemaDefinition *SpecSchemaDefinition
 }
 
+// specResolveByName defines how the ID of the resource referenced by a property (e,g: vpc_id) is looked up from its name
+// (x-terraform-resolve-by-name)
+type specResolveByName struct {
+	// attribute contains the name of the terraform attribute the name is configured with; empty if the attribute name is
+	// derived from the property name (e,g: vpc_id -> vpc_name)
+	attribute string
+	// path contains the path of the collection endpoint the referenced resources are listed from (e,g: /v1/vpcs). The path
+	// parameters are resolved in order with the parent IDs of the resource
+	path string
+	// nameField and idField contain the names of the fields of the items listed containing the name and ID of the resources
+	nameField string
+	idField   string
+	// itemsField contains the name of the response field containing the items listed (dot separated for nested fields);
+	// empty if the response payload is the list of items
+	itemsField string
+}
+
+// getResolveByNameAttribute returns the name of the terraform attribute the name of the resource referenced by the property
+// is configured with (e,g: vpc_name for the vpc_id property); empty if the property is not resolved by name
+func (s *SpecSchemaDefinitionProperty) getResolveByNameAttribute() string {
+	if s.ResolveByName == nil {
+		return ""
+	}
+	if s.ResolveByName.attribute != "" {
+		return s.ResolveByName.attribute
+	}
+	return strings.TrimSuffix(s.GetTerraformCompliantPropertyName(), "_id") + "_name"
+}
+
+// specUpload defines how the content of the local file configured for a property (e,g: bundle_key) is uploaded to the
+// object storage using a signed upload URL (x-terraform-upload-property)
+type specUpload struct {
+	// attribute contains the name of the terraform attribute the path of the local file is configured with; empty if the
+	// attribute name is derived from the property name (e,g: bundle_key -> bundle_file)
+	attribute string
+	// path contains the path of the endpoint the signed upload URL is requested from (e,g: /v1/uploads). The path
+	// parameters are resolved in order with the parent IDs of the resource
+	path string
+	// urlField and keyField contain the names of the response fields (dot separated for nested fields) containing the
+	// signed upload URL and the key of the object the property is populated with
+	urlField string
+	keyField string
+}
+
+// getUploadAttribute returns the name of the terraform attribute the path of the local file uploaded for the property is
+// configured with (e,g: bundle_file for the bundle_key property); empty if the property is not uploaded
+func (s *SpecSchemaDefinitionProperty) getUploadAttribute() string {
+	if s.Upload == nil {
+		return ""
+	}
+	if s.Upload.attribute != "" {
+		return s.Upload.attribute
+	}
+	return strings.TrimSuffix(s.GetTerraformCompliantPropertyName(), "_key") + "_file"
+}
+
 func (s *SpecSchemaDefinitionProperty) isPrimitiveProperty() bool {
 	if s.Type == TypeString || s.Type == TypeInt || s.Type == TypeFloat || s.Type == TypeBool {
 		return true
@@ -106,7 +217,7 @@ func (s *SpecSchemaDefinitionProperty) isArrayProperty() bool {
 }
 
 func (s *SpecSchemaDefinitionProperty) shouldIgnoreOrder() bool {
-	return s.Type == TypeList && s.IgnoreItemsOrder
+	return s.Type == TypeList && s.IgnoreItemsOrder && !s.Ordered
 }
 
 func (s *SpecSchemaDefinitionProperty) isArrayOfObjectsProperty() bool {
@@ -250,6 +361,9 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 
 	case TypeList:
 		if isListOfPrimitives, elemSchema := s.isTerraformListOfSimpleValues(); isListOfPrimitives {
+			if s.isNetworkFormatProperty() {
+				elemSchema.DiffSuppressFunc = s.suppressEquivalentNetworkValues
+			}
 			terraformSchema.Elem = elemSchema
 		} else {
 			objectSchema, err := s.terraformObjectSchema()
@@ -258,6 +372,20 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 			}
 			terraformSchema.Elem = objectSchema
 		}
+		// The number of items is validated at plan time by Terraform; readOnly properties are not validated since their
+		// values are not configured by the user
+		if !s.isReadOnly() {
+			if s.MaxItems > 0 && s.MinItems > s.MaxItems {
+				return nil, fmt.Errorf("property '%s' minItems (%d) must not be greater than maxItems (%d)", s.Name, s.MinItems, s.MaxItems)
+			}
+			terraformSchema.MinItems = s.MinItems
+			terraformSchema.MaxItems = s.MaxItems
+		}
+	}
+
+	// Different textual forms of the same IP address or CIDR block must not produce diffs
+	if s.Type == TypeString && s.isNetworkFormatProperty() {
+		terraformSchema.DiffSuppressFunc = s.suppressEquivalentNetworkValues
 	}
 
 	// A computed property could be one of:
@@ -284,6 +412,14 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 	if !s.isArrayProperty() && !s.isObjectProperty() {
 		terraformSchema.ValidateDiagFunc = s.validateDiagFunc()
 	}
+	if s.Validator != "" {
+		if s.isArrayProperty() || s.isObjectProperty() {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: validators are only supported on primitive properties", s.Name, extTfValidator)
+		}
+		if _, exists := getPropertyValidator(s.Validator); !exists {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: validator '%s' is not registered", s.Name, extTfValidator, s.Validator)
+		}
+	}
 
 	// Don't populate Default if property is readOnly as the property is expected to be computed by the API. Terraform does
 	// not allow properties with Computed = true having the Default field populated, otherwise the following error will be
@@ -298,6 +434,113 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 	return terraformSchema, nil
 }
 
+// terraformAttributeAliasesSchema returns the schemas of the attribute aliases (x-terraform-attribute-aliases) of the property
+// keyed by alias, adapting the terraform schema of the property passed in accordingly. The aliases are optional deprecated
+// attributes conflicting with the property (only one of them can be configured, exactly one if the property is required)
+// and the property becomes computed since it is populated with the value of the alias configured
+func (s *SpecSchemaDefinitionProperty) terraformAttributeAliasesSchema(terraformSchema *schema.Schema) map[string]*schema.Schema {
+	if len(s.AttributeAliases) == 0 {
+		return nil
+	}
+	name := s.GetTerraformCompliantPropertyName()
+	attributeNames := append([]string{name}, s.AttributeAliases...)
+	required := terraformSchema.Required
+	aliasesSchema := make(map[string]*schema.Schema, len(s.AttributeAliases))
+	for _, alias := range s.AttributeAliases {
+		aliasSchema := *terraformSchema
+		aliasSchema.Required = false
+		aliasSchema.Optional = true
+		aliasSchema.Computed = false
+		aliasSchema.Default = nil
+		aliasSchema.Deprecated = fmt.Sprintf("%s has been renamed to %s, use %s instead", alias, name, name)
+		aliasSchema.ConflictsWith, aliasSchema.ExactlyOneOf = attributeAliasesConstraints(alias, attributeNames, required)
+		aliasesSchema[alias] = &aliasSchema
+	}
+	terraformSchema.Required = false
+	terraformSchema.Optional = true
+	terraformSchema.Computed = true
+	terraformSchema.Default = nil
+	terraformSchema.ConflictsWith, terraformSchema.ExactlyOneOf = attributeAliasesConstraints(name, attributeNames, required)
+	return aliasesSchema
+}
+
+// terraformResolveByNameSchema returns the schema of the attribute the name of the resource referenced by the property is
+// configured with (x-terraform-resolve-by-name) along with the attribute name, adapting the terraform schema of the
+// property passed in accordingly. Only one of them can be configured (exactly one if the property is required) and the
+// property becomes computed since it is populated with the ID resolved from the name. A nil schema is returned if the
+// property is not resolved by name
+func (s *SpecSchemaDefinitionProperty) terraformResolveByNameSchema(terraformSchema *schema.Schema) (string, *schema.Schema) {
+	attribute := s.getResolveByNameAttribute()
+	if attribute == "" {
+		return "", nil
+	}
+	name := s.GetTerraformCompliantPropertyName()
+	attributeSchema := &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    terraformSchema.ForceNew,
+		Description: fmt.Sprintf("Name of the resource referenced by %s, the ID is looked up and stored in %s", name, name),
+	}
+	if terraformSchema.Required {
+		attributeSchema.ExactlyOneOf = []string{name, attribute}
+		terraformSchema.ExactlyOneOf = []string{name, attribute}
+	} else {
+		attributeSchema.ConflictsWith = []string{name}
+		terraformSchema.ConflictsWith = []string{attribute}
+	}
+	terraformSchema.Required = false
+	terraformSchema.Optional = true
+	terraformSchema.Computed = true
+	terraformSchema.Default = nil
+	return attribute, attributeSchema
+}
+
+// terraformUploadSchema returns the schema of the attribute the path of the local file uploaded for the property is
+// configured with (x-terraform-upload-property) along with the attribute name, adapting the terraform schema of the
+// property passed in accordingly. Only one of them can be configured (exactly one if the property is required) and the
+// property becomes computed since it is populated with the key of the object uploaded. A nil schema is returned if the
+// property is not uploaded
+func (s *SpecSchemaDefinitionProperty) terraformUploadSchema(terraformSchema *schema.Schema) (string, *schema.Schema) {
+	attribute := s.getUploadAttribute()
+	if attribute == "" {
+		return "", nil
+	}
+	name := s.GetTerraformCompliantPropertyName()
+	attributeSchema := &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    terraformSchema.ForceNew,
+		Description: fmt.Sprintf("Path of the local file uploaded to the object storage, the key of the object uploaded is stored in %s", name),
+	}
+	if terraformSchema.Required {
+		attributeSchema.ExactlyOneOf = []string{name, attribute}
+		terraformSchema.ExactlyOneOf = []string{name, attribute}
+	} else {
+		attributeSchema.ConflictsWith = []string{name}
+		terraformSchema.ConflictsWith = []string{attribute}
+	}
+	terraformSchema.Required = false
+	terraformSchema.Optional = true
+	terraformSchema.Computed = true
+	terraformSchema.Default = nil
+	return attribute, attributeSchema
+}
+
+// attributeAliasesConstraints returns the ConflictsWith and ExactlyOneOf constraints of the attribute passed in, which is
+// either the property or one of its aliases
+func attributeAliasesConstraints(attributeName string, attributeNames []string, required bool) ([]string, []string) {
+	if required {
+		return nil, attributeNames
+	}
+	var conflictsWith []string
+	for _, name := range attributeNames {
+		if name != attributeName {
+			conflictsWith = append(conflictsWith, name)
+		}
+	}
+	return conflictsWith, nil
+}
+
 func (s *SpecSchemaDefinitionProperty) validateDiagFunc() schema.SchemaValidateDiagFunc {
 	return func(v interface{}, p cty.Path) diag.Diagnostics {
 		_, errs := s.validateFunc()(v, "") // it's not clear what would be the value of k with the new schema.SchemaValidateDiagFunc and whether it can be extracted from the cty.Path
@@ -319,6 +562,11 @@ func (s *SpecSchemaDefinitionProperty) validateFunc() schema.SchemaValidateFunc
 		if s.Required && s.ReadOnly {
 			errors = append(errors, fmt.Errorf("property '%s' is configured as required and can not be configured as computed too", s.Name))
 		}
+		if validator, exists := getPropertyValidator(s.Validator); exists && v != nil {
+			if err := validator(v); err != nil {
+				errors = append(errors, fmt.Errorf("property '%s' value not valid: %s", s.Name, err))
+			}
+		}
 		return
 	}
 }
@@ -333,6 +581,9 @@ func (s *SpecSchemaDefinitionProperty) equalItems(itemsType schemaDefinitionProp
 		if !s.validateValueType(item1, reflect.String) || !s.validateValueType(item2, reflect.String) {
 			return false
 		}
+		if s.isNetworkFormatProperty() {
+			return s.equalNetworkValues(item1.(string), item2.(string))
+		}
 	case TypeInt:
 		if !s.validateValueType(item1, reflect.Int) || !s.validateValueType(item2, reflect.Int) {
 			return false
@@ -370,8 +621,11 @@ func (s *SpecSchemaDefinitionProperty) equalItems(itemsType schemaDefinitionProp
 			return true
 		}
 		for idx := range list1 {
-			return s.equalItems(s.ArrayItemsType, list1[idx], list2[idx])
+			if !s.equalItems(s.ArrayItemsType, list1[idx], list2[idx]) {
+				return false
+			}
 		}
+		return true
 	case TypeObject:
 		if !s.validateValueType(item1, reflect.Map) || !s.validateValueType(item2, reflect.Map) {
 			return false
@@ -398,3 +652,166 @@ func (s *SpecSchemaDefinitionProperty) validateValueType(item interface{}, expec
 	}
 	return true
 }
+
+// hashComplexObject calculates the hash of the given array item (object) taking into account only the user configurable
+// properties as per the array items schema definition. Computed properties are ignored so that the same object coming from
+// the Terraform state (user input) and from the API response (containing computed properties) result in the same hash.
+func (s *SpecSchemaDefinitionProperty) hashComplexObject(item interface{}) int {
+	var buf bytes.Buffer
+	writeConfigurableObjectHashKey(&buf, s.SpecSchemaDefinition, item)
+	// Terraform SDK 2.0 upgrade: https://www.terraform.io/docs/extend/guides/v2-upgrade-guide.html#removal-of-helper-hashcode-package
+	return int(crc32.ChecksumIEEE(buf.Bytes()))
+}
+
+// writeConfigurableObjectHashKey writes into the buffer a canonical representation of the object considering only the
+// user configurable properties of the schema definition. The properties are processed following the schema definition
+// order so the result is deterministic regardless of the map iteration order. Nothing is written if the object has no
+// configurable values. The hash keys are written straight into the buffer (no fmt.Sprintf or intermediate strings) since
+// this is called for every item of the lists processed, which can be considerably big.
+func writeConfigurableObjectHashKey(buf *bytes.Buffer, schemaDefinition *SpecSchemaDefinition, value interface{}) {
+	object := unwrapObjectValue(value)
+	if object == nil || schemaDefinition == nil {
+		return
+	}
+	start := buf.Len()
+	buf.WriteByte('{')
+	empty := true
+	for _, property := range schemaDefinition.Properties {
+		if property.isComputed() {
+			continue
+		}
+		propertyStart := buf.Len()
+		buf.WriteString(property.Name)
+		buf.WriteByte('=')
+		valueStart := buf.Len()
+		property.writeConfigurableHashKey(buf, getObjectPropertyValue(object, property))
+		if buf.Len() == valueStart {
+			buf.Truncate(propertyStart)
+			continue
+		}
+		buf.WriteByte(';')
+		empty = false
+	}
+	if empty {
+		buf.Truncate(start)
+		return
+	}
+	buf.WriteByte('}')
+}
+
+// writeConfigurableHashKey writes into the buffer a canonical representation of the given property value. Zero values are
+// treated as not set since the Terraform state contains zero values for optional properties not configured by the user
+// whereas the API might not return them at all. Items of lists with IgnoreItemsOrder enabled are sorted so the order does
+// not affect the result.
+func (s *SpecSchemaDefinitionProperty) writeConfigurableHashKey(buf *bytes.Buffer, value interface{}) {
+	if value == nil {
+		return
+	}
+	switch s.Type {
+	case TypeObject:
+		writeConfigurableObjectHashKey(buf, s.SpecSchemaDefinition, value)
+	case TypeList:
+		list, ok := value.([]interface{})
+		if !ok || len(list) == 0 {
+			return
+		}
+		if !s.shouldIgnoreOrder() {
+			buf.WriteByte('[')
+			for _, item := range list {
+				s.writeItemHashKey(buf, item)
+				buf.WriteByte(',')
+			}
+			buf.WriteByte(']')
+			return
+		}
+		var itemBuf bytes.Buffer
+		itemHashKeys := make([]string, 0, len(list))
+		for _, item := range list {
+			itemBuf.Reset()
+			s.writeItemHashKey(&itemBuf, item)
+			itemHashKeys = append(itemHashKeys, itemBuf.String())
+		}
+		sort.Strings(itemHashKeys)
+		buf.WriteByte('[')
+		for _, itemHashKey := range itemHashKeys {
+			buf.WriteString(itemHashKey)
+			buf.WriteByte(',')
+		}
+		buf.WriteByte(']')
+	default:
+		writePrimitiveHashKey(buf, s.Type, value)
+	}
+}
+
+func (s *SpecSchemaDefinitionProperty) writeItemHashKey(buf *bytes.Buffer, item interface{}) {
+	if s.ArrayItemsType == TypeObject {
+		writeConfigurableObjectHashKey(buf, s.SpecSchemaDefinition, item)
+		return
+	}
+	writePrimitiveHashKey(buf, s.ArrayItemsType, item)
+}
+
+// writePrimitiveHashKey writes into the buffer the representation of the primitive value. Numbers are normalised as the
+// API response values are always float64 whereas the Terraform state values are typed as per the schema (e,g: int).
+// String values are quoted so they can not be confused with the hash key separators.
+func writePrimitiveHashKey(buf *bytes.Buffer, valueType schemaDefinitionPropertyType, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+	case string:
+		if v != "" {
+			buf.WriteString(strconv.Quote(v))
+		}
+	case bool:
+		if v {
+			buf.WriteString("true")
+		}
+	case int:
+		if v == 0 {
+			return
+		}
+		if valueType == TypeFloat {
+			buf.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 64))
+			return
+		}
+		buf.WriteString(strconv.Itoa(v))
+	case float64:
+		if v == 0 {
+			return
+		}
+		if valueType == TypeInt {
+			buf.WriteString(strconv.Itoa(int(v)))
+			return
+		}
+		buf.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	default:
+		if !reflect.ValueOf(value).IsZero() {
+			buf.WriteString(fmt.Sprintf("%#v", value))
+		}
+	}
+}
+
+// getObjectPropertyValue returns the value of the property in the given object. Objects returned by the API are keyed by
+// the property name whereas objects coming from the Terraform state are keyed by the terraform compliant property name.
+func getObjectPropertyValue(object map[string]interface{}, property *SpecSchemaDefinitionProperty) interface{} {
+	if value, exists := object[property.Name]; exists {
+		return value
+	}
+	return object[property.GetTerraformCompliantPropertyName()]
+}
+
+// unwrapObjectValue returns the object contained in the value. Object properties are stored in the Terraform state as
+// lists of one element (refer to shouldUseLegacyTerraformSDKBlockApproachForComplexObjects) and therefore the object is
+// unwrapped from the list if needed. Nil is returned if the value is not an object.
+func unwrapObjectValue(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v
+	case []interface{}:
+		if len(v) == 1 {
+			if object, ok := v[0].(map[string]interface{}); ok {
+				return object
+			}
+		}
+	}
+	return nil
+}
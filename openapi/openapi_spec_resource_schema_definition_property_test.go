@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -2075,3 +2076,203 @@ func Test_shouldUseLegacyTerraformSDKBlockApproachForComplexObjects(t *testing.T
 		})
 	})
 }
+
+func TestHashComplexObject(t *testing.T) {
+	property := &SpecSchemaDefinitionProperty{
+		Name:           "list_prop",
+		Type:           TypeList,
+		ArrayItemsType: TypeObject,
+		SpecSchemaDefinition: &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "label", Type: TypeString, Required: true},
+				&SpecSchemaDefinitionProperty{Name: "sizeGb", Type: TypeInt},
+				&SpecSchemaDefinitionProperty{Name: "ratio", Type: TypeFloat},
+				&SpecSchemaDefinitionProperty{Name: "enabled", Type: TypeBool},
+				&SpecSchemaDefinitionProperty{Name: "tags", Type: TypeList, ArrayItemsType: TypeString, IgnoreItemsOrder: true},
+				&SpecSchemaDefinitionProperty{Name: "ordered", Type: TypeList, ArrayItemsType: TypeString},
+				&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true},
+				&SpecSchemaDefinitionProperty{Name: "optionalComputed", Type: TypeString, Computed: true},
+			},
+		},
+	}
+	testCases := []struct {
+		name          string
+		item1         interface{}
+		item2         interface{}
+		expectedEqual bool
+	}{
+		{
+			name:          "same configurable properties with different computed properties",
+			item1:         map[string]interface{}{"label": "a", "id": "", "optional_computed": ""},
+			item2:         map[string]interface{}{"label": "a", "id": "some-id", "optionalComputed": "computed"},
+			expectedEqual: true,
+		},
+		{
+			name:          "terraform compliant names and types (state) vs API names and types (response)",
+			item1:         map[string]interface{}{"label": "a", "size_gb": 10, "ratio": 1, "enabled": true},
+			item2:         map[string]interface{}{"label": "a", "sizeGb": 10.0, "ratio": 1.0, "enabled": true},
+			expectedEqual: true,
+		},
+		{
+			name:          "zero values are considered not set",
+			item1:         map[string]interface{}{"label": "a", "size_gb": 0, "ratio": 0.0, "enabled": false, "tags": []interface{}{}},
+			item2:         map[string]interface{}{"label": "a"},
+			expectedEqual: true,
+		},
+		{
+			name:          "lists with IgnoreItemsOrder enabled in different order",
+			item1:         map[string]interface{}{"label": "a", "tags": []interface{}{"t1", "t2"}},
+			item2:         map[string]interface{}{"label": "a", "tags": []interface{}{"t2", "t1"}},
+			expectedEqual: true,
+		},
+		{
+			name:          "lists without IgnoreItemsOrder enabled in different order",
+			item1:         map[string]interface{}{"label": "a", "ordered": []interface{}{"t1", "t2"}},
+			item2:         map[string]interface{}{"label": "a", "ordered": []interface{}{"t2", "t1"}},
+			expectedEqual: false,
+		},
+		{
+			name:          "different configurable properties",
+			item1:         map[string]interface{}{"label": "a", "sizeGb": 10.0},
+			item2:         map[string]interface{}{"label": "a", "sizeGb": 20.0},
+			expectedEqual: false,
+		},
+		{
+			name:          "values are not concatenated ambiguously",
+			item1:         map[string]interface{}{"label": "a", "ordered": []interface{}{"b,c"}},
+			item2:         map[string]interface{}{"label": "a", "ordered": []interface{}{"b", "c"}},
+			expectedEqual: false,
+		},
+	}
+	for _, tc := range testCases {
+		hash1 := property.hashComplexObject(tc.item1)
+		hash2 := property.hashComplexObject(tc.item2)
+		assert.Equal(t, tc.expectedEqual, hash1 == hash2, tc.name)
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, hash1, property.hashComplexObject(tc.item1), "hash must be deterministic: %s", tc.name)
+		}
+	}
+}
+
+func TestTerraformSchemaMinMaxItems(t *testing.T) {
+	testCases := []struct {
+		name             string
+		property         *SpecSchemaDefinitionProperty
+		expectedMinItems int
+		expectedMaxItems int
+		expectedError    string
+	}{
+		{
+			name:             "list property with min and max items",
+			property:         &SpecSchemaDefinitionProperty{Name: "data_centres", Type: TypeList, ArrayItemsType: TypeString, Required: true, MinItems: 1, MaxItems: 3},
+			expectedMinItems: 1,
+			expectedMaxItems: 3,
+		},
+		{
+			name:             "list of objects property with min items",
+			property:         &SpecSchemaDefinitionProperty{Name: "data_centres", Type: TypeList, ArrayItemsType: TypeObject, MinItems: 1, SpecSchemaDefinition: &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString}}}},
+			expectedMinItems: 1,
+		},
+		{
+			name:     "readOnly list property with min items",
+			property: &SpecSchemaDefinitionProperty{Name: "data_centres", Type: TypeList, ArrayItemsType: TypeString, ReadOnly: true, MinItems: 1},
+		},
+		{
+			name:          "list property with min items greater than max items",
+			property:      &SpecSchemaDefinitionProperty{Name: "data_centres", Type: TypeList, ArrayItemsType: TypeString, MinItems: 3, MaxItems: 1},
+			expectedError: "property 'data_centres' minItems (3) must not be greater than maxItems (1)",
+		},
+	}
+	for _, tc := range testCases {
+		terraformSchema, err := tc.property.terraformSchema()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedMinItems, terraformSchema.MinItems, tc.name)
+		assert.Equal(t, tc.expectedMaxItems, terraformSchema.MaxItems, tc.name)
+	}
+}
+
+func TestTerraformSchemaMinMaxItemsNestedValidation(t *testing.T) {
+	cluster := &SpecSchemaDefinitionProperty{Name: "cluster", Type: TypeObject, Required: true, SpecSchemaDefinition: &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "data_centres", Type: TypeList, ArrayItemsType: TypeString, Required: true, MinItems: 1, MaxItems: 2},
+		},
+	}}
+	terraformSchema, err := cluster.terraformSchema()
+	assert.NoError(t, err)
+	resource := &schema.Resource{Schema: map[string]*schema.Schema{"cluster": terraformSchema}}
+
+	diags := resource.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{"cluster": []interface{}{map[string]interface{}{"data_centres": []interface{}{"dc1"}}}}))
+	assert.False(t, diags.HasError())
+	diags = resource.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{"cluster": []interface{}{map[string]interface{}{"data_centres": []interface{}{}}}}))
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Not enough list items", diags[0].Summary)
+	diags = resource.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{"cluster": []interface{}{map[string]interface{}{"data_centres": []interface{}{"dc1", "dc2", "dc3"}}}}))
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Too many list items", diags[0].Summary)
+}
+
+func TestEqualOrderedList(t *testing.T) {
+	rules := &SpecSchemaDefinitionProperty{Name: "rules", Type: TypeList, ArrayItemsType: TypeObject, Ordered: true, SpecSchemaDefinition: &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "action", Type: TypeString},
+		},
+	}}
+	priorities := &SpecSchemaDefinitionProperty{Name: "priorities", Type: TypeList, ArrayItemsType: TypeString, Ordered: true}
+	testCases := []struct {
+		name          string
+		property      *SpecSchemaDefinitionProperty
+		list1         []interface{}
+		list2         []interface{}
+		expectedEqual bool
+	}{
+		{
+			name:          "same items in the same order",
+			property:      priorities,
+			list1:         []interface{}{"high", "low"},
+			list2:         []interface{}{"high", "low"},
+			expectedEqual: true,
+		},
+		{
+			name:          "same items in different order",
+			property:      priorities,
+			list1:         []interface{}{"high", "low"},
+			list2:         []interface{}{"low", "high"},
+			expectedEqual: false,
+		},
+		{
+			name:          "items differing after the first position",
+			property:      priorities,
+			list1:         []interface{}{"high", "medium", "low"},
+			list2:         []interface{}{"high", "low", "medium"},
+			expectedEqual: false,
+		},
+		{
+			name:          "empty lists",
+			property:      priorities,
+			list1:         []interface{}{},
+			list2:         []interface{}{},
+			expectedEqual: true,
+		},
+		{
+			name:          "objects in different order",
+			property:      rules,
+			list1:         []interface{}{map[string]interface{}{"action": "allow"}, map[string]interface{}{"action": "deny"}},
+			list2:         []interface{}{map[string]interface{}{"action": "deny"}, map[string]interface{}{"action": "allow"}},
+			expectedEqual: false,
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedEqual, tc.property.equal(tc.list1, tc.list2), tc.name)
+	}
+}
+
+func TestShouldIgnoreOrderOrderedList(t *testing.T) {
+	s := &SpecSchemaDefinitionProperty{Name: "rules", Type: TypeList, ArrayItemsType: TypeString, IgnoreItemsOrder: true}
+	assert.True(t, s.shouldIgnoreOrder())
+	s.Ordered = true
+	assert.False(t, s.shouldIgnoreOrder())
+}
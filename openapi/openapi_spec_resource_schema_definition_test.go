@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -819,3 +820,244 @@ func TestGetPropertyBasedOnTerraformName(t *testing.T) {
 	assert.EqualError(t, err, "property with terraform name 'badTerraformPropertyName' not existing in resource schema definition")
 
 }
+
+func TestGetPropertyWithPropertyIndex(t *testing.T) {
+	s := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "existingPropertyName", Type: TypeString},
+			&SpecSchemaDefinitionProperty{Name: "otherProperty", PreferredName: "preferred_name", Type: TypeInt},
+		},
+	}
+	s.buildPropertyIndex()
+	assert.NotNil(t, s.getPropertyIndex())
+
+	property, err := s.getProperty("existingPropertyName")
+	assert.NoError(t, err)
+	assert.Equal(t, s.Properties[0], property)
+
+	property, err = s.getPropertyBasedOnTerraformName("existing_property_name")
+	assert.NoError(t, err)
+	assert.Equal(t, s.Properties[0], property)
+
+	property, err = s.getPropertyBasedOnTerraformName("preferred_name")
+	assert.NoError(t, err)
+	assert.Equal(t, s.Properties[1], property)
+
+	_, err = s.getProperty("existing_property_name")
+	assert.EqualError(t, err, "property with name 'existing_property_name' not existing in resource schema definition")
+
+	_, err = s.getPropertyBasedOnTerraformName("existingPropertyName")
+	assert.EqualError(t, err, "property with terraform name 'existingPropertyName' not existing in resource schema definition")
+
+	// properties added after the index was built are still found since the index is ignored when out of sync
+	s.Properties = append(s.Properties, &SpecSchemaDefinitionProperty{Name: "newProperty", Type: TypeString})
+	assert.Nil(t, s.getPropertyIndex())
+	property, err = s.getProperty("newProperty")
+	assert.NoError(t, err)
+	assert.Equal(t, s.Properties[2], property)
+	property, err = s.getPropertyBasedOnTerraformName("new_property")
+	assert.NoError(t, err)
+	assert.Equal(t, s.Properties[2], property)
+}
+
+func TestBuildPropertyIndexDuplicateNames(t *testing.T) {
+	s := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "duplicate", Type: TypeString},
+			&SpecSchemaDefinitionProperty{Name: "duplicate", Type: TypeInt},
+		},
+	}
+	s.buildPropertyIndex()
+	property, err := s.getProperty("duplicate")
+	assert.NoError(t, err)
+	assert.Equal(t, TypeString, property.Type, "the first property should win as it would when scanning the properties")
+}
+
+func TestConvertToDataSourceSpecSchemaDefinitionBuildsPropertyIndex(t *testing.T) {
+	s := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{
+				Name: "objectProperty",
+				Type: TypeObject,
+				SpecSchemaDefinition: &SpecSchemaDefinition{
+					Properties: SpecSchemaDefinitionProperties{
+						&SpecSchemaDefinitionProperty{Name: "nestedProperty", Type: TypeString},
+					},
+				},
+			},
+		},
+	}
+	dataSourceSchemaDefinition := s.ConvertToDataSourceSpecSchemaDefinition()
+	assert.NotNil(t, dataSourceSchemaDefinition.getPropertyIndex())
+	objectProperty, err := dataSourceSchemaDefinition.getProperty("objectProperty")
+	assert.NoError(t, err)
+	assert.NotNil(t, objectProperty.SpecSchemaDefinition.getPropertyIndex())
+	nestedProperty, err := objectProperty.SpecSchemaDefinition.getPropertyBasedOnTerraformName("nested_property")
+	assert.NoError(t, err)
+	assert.Equal(t, "nestedProperty", nestedProperty.Name)
+}
+
+func TestResolveResourceIdentifierAliases(t *testing.T) {
+	s := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "uuid", Type: TypeString, ReadOnly: true, IsIdentifier: true, IdentifierAliases: []string{"cluster_id", "id"}},
+			&SpecSchemaDefinitionProperty{Name: "cluster_id", Type: TypeString, ReadOnly: true},
+			&SpecSchemaDefinitionProperty{Name: "label", Type: TypeString},
+		},
+	}
+	testCases := []struct {
+		name            string
+		payload         map[string]interface{}
+		expectedPayload map[string]interface{}
+	}{
+		{
+			name:            "payload containing the identifier property",
+			payload:         map[string]interface{}{"uuid": "1234", "id": "5678", "label": "label"},
+			expectedPayload: map[string]interface{}{"uuid": "1234", "id": "5678", "label": "label"},
+		},
+		{
+			name:            "payload containing an alias that is not a property of the schema",
+			payload:         map[string]interface{}{"id": "1234", "label": "label"},
+			expectedPayload: map[string]interface{}{"uuid": "1234", "label": "label"},
+		},
+		{
+			name:            "payload containing an alias that is a property of the schema",
+			payload:         map[string]interface{}{"cluster_id": "1234", "id": "5678"},
+			expectedPayload: map[string]interface{}{"uuid": "1234", "cluster_id": "1234", "id": "5678"},
+		},
+		{
+			name:            "payload not containing the identifier property nor any alias",
+			payload:         map[string]interface{}{"label": "label"},
+			expectedPayload: map[string]interface{}{"label": "label"},
+		},
+	}
+	for _, tc := range testCases {
+		s.resolveResourceIdentifierAliases(tc.payload)
+		assert.Equal(t, tc.expectedPayload, tc.payload, tc.name)
+	}
+}
+
+func newBenchmarkWideSpecSchemaDefinition(numProperties int) *SpecSchemaDefinition {
+	s := &SpecSchemaDefinition{}
+	for i := 0; i < numProperties; i++ {
+		s.Properties = append(s.Properties, &SpecSchemaDefinitionProperty{Name: fmt.Sprintf("property%d", i), Type: TypeString})
+	}
+	return s
+}
+
+func BenchmarkGetProperty(b *testing.B) {
+	for _, indexed := range []bool{false, true} {
+		b.Run(fmt.Sprintf("indexed=%t", indexed), func(b *testing.B) {
+			s := newBenchmarkWideSpecSchemaDefinition(500)
+			if indexed {
+				s.buildPropertyIndex()
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, property := range s.Properties {
+					if _, err := s.getProperty(property.Name); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCreateResourceSchemaAttributeAliases(t *testing.T) {
+	s := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "display_name", Type: TypeString, Required: true, AttributeAliases: []string{"label", "title"}},
+			&SpecSchemaDefinitionProperty{Name: "node_count", Type: TypeInt, Default: 3, AttributeAliases: []string{"nodes"}},
+		},
+	}
+	terraformSchema, err := s.createResourceSchema()
+	assert.NoError(t, err)
+	assert.NoError(t, schema.InternalMap(terraformSchema).InternalValidate(nil))
+	assert.Len(t, terraformSchema, 5)
+
+	// required properties must be configured via either the property or one of its aliases
+	assert.False(t, terraformSchema["display_name"].Required)
+	assert.True(t, terraformSchema["display_name"].Optional)
+	assert.True(t, terraformSchema["display_name"].Computed)
+	assert.Equal(t, []string{"display_name", "label", "title"}, terraformSchema["display_name"].ExactlyOneOf)
+	assert.Equal(t, []string{"display_name", "label", "title"}, terraformSchema["label"].ExactlyOneOf)
+	assert.True(t, terraformSchema["label"].Optional)
+	assert.False(t, terraformSchema["label"].Computed)
+	assert.Equal(t, "label has been renamed to display_name, use display_name instead", terraformSchema["label"].Deprecated)
+	assert.Equal(t, "title has been renamed to display_name, use display_name instead", terraformSchema["title"].Deprecated)
+
+	// optional properties conflict with their aliases
+	assert.True(t, terraformSchema["node_count"].Computed)
+	assert.Nil(t, terraformSchema["node_count"].Default)
+	assert.Equal(t, []string{"nodes"}, terraformSchema["node_count"].ConflictsWith)
+	assert.Equal(t, []string{"node_count"}, terraformSchema["nodes"].ConflictsWith)
+	assert.Equal(t, schema.TypeInt, terraformSchema["nodes"].Type)
+
+	// the data sources do not expose the aliases
+	dataSourceSchema, err := s.createDataSourceSchema()
+	assert.NoError(t, err)
+	assert.Len(t, dataSourceSchema, 2)
+}
+
+func TestCreateResourceSchemaResolveByName(t *testing.T) {
+	s := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "vpc_id", Type: TypeString, Required: true, ForceNew: true, ResolveByName: &specResolveByName{path: "/v1/vpcs"}},
+			&SpecSchemaDefinitionProperty{Name: "subnet", Type: TypeString, ResolveByName: &specResolveByName{path: "/v1/subnets"}},
+		},
+	}
+	terraformSchema, err := s.createResourceSchema()
+	assert.NoError(t, err)
+	assert.NoError(t, schema.InternalMap(terraformSchema).InternalValidate(nil))
+	assert.Len(t, terraformSchema, 4)
+
+	// required properties must be configured via either the property or its name
+	assert.True(t, terraformSchema["vpc_id"].Optional)
+	assert.True(t, terraformSchema["vpc_id"].Computed)
+	assert.Equal(t, []string{"vpc_id", "vpc_name"}, terraformSchema["vpc_id"].ExactlyOneOf)
+	assert.Equal(t, []string{"vpc_id", "vpc_name"}, terraformSchema["vpc_name"].ExactlyOneOf)
+	assert.True(t, terraformSchema["vpc_name"].Optional)
+	assert.True(t, terraformSchema["vpc_name"].ForceNew)
+
+	// optional properties conflict with their name
+	assert.True(t, terraformSchema["subnet"].Computed)
+	assert.Equal(t, []string{"subnet_name"}, terraformSchema["subnet"].ConflictsWith)
+	assert.Equal(t, []string{"subnet"}, terraformSchema["subnet_name"].ConflictsWith)
+	assert.False(t, terraformSchema["subnet_name"].ForceNew)
+
+	// the data sources do not expose the names
+	dataSourceSchema, err := s.createDataSourceSchema()
+	assert.NoError(t, err)
+	assert.Len(t, dataSourceSchema, 2)
+}
+
+func TestCreateResourceSchemaUploadProperty(t *testing.T) {
+	s := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "bundle_key", Type: TypeString, Required: true, Upload: &specUpload{path: "/v1/uploads"}},
+			&SpecSchemaDefinitionProperty{Name: "icon", Type: TypeString, ForceNew: true, Upload: &specUpload{path: "/v1/uploads"}},
+		},
+	}
+	terraformSchema, err := s.createResourceSchema()
+	assert.NoError(t, err)
+	assert.NoError(t, schema.InternalMap(terraformSchema).InternalValidate(nil))
+	assert.Len(t, terraformSchema, 4)
+
+	// required properties must be configured via either the object key or the file uploaded
+	assert.True(t, terraformSchema["bundle_key"].Optional)
+	assert.True(t, terraformSchema["bundle_key"].Computed)
+	assert.Equal(t, []string{"bundle_key", "bundle_file"}, terraformSchema["bundle_key"].ExactlyOneOf)
+	assert.Equal(t, []string{"bundle_key", "bundle_file"}, terraformSchema["bundle_file"].ExactlyOneOf)
+
+	// optional properties conflict with their file
+	assert.True(t, terraformSchema["icon"].Computed)
+	assert.Equal(t, []string{"icon_file"}, terraformSchema["icon"].ConflictsWith)
+	assert.Equal(t, []string{"icon"}, terraformSchema["icon_file"].ConflictsWith)
+	assert.True(t, terraformSchema["icon_file"].ForceNew)
+
+	// the data sources do not expose the files
+	dataSourceSchema, err := s.createDataSourceSchema()
+	assert.NoError(t, err)
+	assert.Len(t, dataSourceSchema, 2)
+}
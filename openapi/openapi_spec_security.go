@@ -10,4 +10,7 @@ type SpecSecurity interface {
 	// GetGlobalSecuritySchemes returns all the global security schemes from the OpenAPI document and translates those
 	// into SpecSecuritySchemes
 	GetGlobalSecuritySchemes() (SpecSecuritySchemes, error)
+	// GetSecurityDefinitionDescription returns the description of the security definition with the given name as documented
+	// in the OpenAPI document; empty if the security definition does not exist or is not documented
+	GetSecurityDefinitionDescription(securityDefinitionName string) string
 }
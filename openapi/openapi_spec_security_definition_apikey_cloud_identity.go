@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
+)
+
+const cloudIdentityProviderAWS = "aws"
+const cloudIdentityProviderGCP = "gcp"
+const cloudIdentityProviderAzure = "azure"
+
+type specAPIKeyHeaderCloudIdentitySecurityDefinition struct {
+	name     string
+	provider string
+	audience string
+}
+
+// newAPIKeyHeaderCloudIdentitySecurityDefinition constructs a SpecSecurityDefinition of Header type using the Bearer
+// authentication scheme whose token is the identity token issued by the instance metadata server of the cloud provider
+// (aws, gcp or azure) the provider runs on. The audience is the audience the identity tokens are requested for (required
+// for gcp and azure)
+func newAPIKeyHeaderCloudIdentitySecurityDefinition(secDefName, provider, audience string) specAPIKeyHeaderCloudIdentitySecurityDefinition {
+	return specAPIKeyHeaderCloudIdentitySecurityDefinition{secDefName, provider, audience}
+}
+
+func (s specAPIKeyHeaderCloudIdentitySecurityDefinition) getName() string {
+	return s.name
+}
+
+func (s specAPIKeyHeaderCloudIdentitySecurityDefinition) getType() securityDefinitionType {
+	return securityDefinitionAPIKeyCloudIdentity
+}
+
+func (s specAPIKeyHeaderCloudIdentitySecurityDefinition) GetTerraformConfigurationName() string {
+	return terraformutils.ConvertToTerraformCompliantName(s.name)
+}
+
+func (s specAPIKeyHeaderCloudIdentitySecurityDefinition) getAPIKey() specAPIKey {
+	apiKey := newAPIKeyHeader(authorizationHeader)
+	apiKey.Metadata = map[apiKeyMetadataKey]interface{}{
+		cloudIdentityProviderKey: s.provider,
+		cloudIdentityAudienceKey: s.audience,
+	}
+	return apiKey
+}
+
+// buildValue returns the token configured by the user (if any) with the Bearer scheme; the token is optional since the
+// identity token is obtained from the instance metadata server otherwise
+func (s specAPIKeyHeaderCloudIdentitySecurityDefinition) buildValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return newAPIKeyHeaderBearerSecurityDefinition(s.name).buildValue(value)
+}
+
+func (s specAPIKeyHeaderCloudIdentitySecurityDefinition) validate() error {
+	if s.name == "" {
+		return fmt.Errorf("specAPIKeyHeaderCloudIdentitySecurityDefinition missing mandatory security definition name")
+	}
+	switch s.provider {
+	case cloudIdentityProviderAWS:
+	case cloudIdentityProviderGCP, cloudIdentityProviderAzure:
+		if s.audience == "" {
+			return fmt.Errorf("security definition '%s' is missing the %s extension, which is required for the '%s' cloud identity provider", s.name, extTfCloudIdentityAudience, s.provider)
+		}
+	default:
+		return fmt.Errorf("security definition '%s' cloud identity provider '%s' not supported, supported providers are [%s, %s, %s]", s.name, s.provider, cloudIdentityProviderAWS, cloudIdentityProviderGCP, cloudIdentityProviderAzure)
+	}
+	return nil
+}
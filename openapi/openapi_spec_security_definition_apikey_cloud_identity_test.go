@@ -0,0 +1,49 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyHeaderCloudIdentitySecurityDefinition(t *testing.T) {
+	secDef := newAPIKeyHeaderCloudIdentitySecurityDefinition("cloudIdentity", cloudIdentityProviderGCP, "https://api.example.com")
+	var _ SpecSecurityDefinition = secDef
+	assert.Equal(t, "cloudIdentity", secDef.getName())
+	assert.Equal(t, "cloud_identity", secDef.GetTerraformConfigurationName())
+	assert.Equal(t, securityDefinitionAPIKeyCloudIdentity, secDef.getType())
+	assert.Equal(t, specAPIKey{
+		In:   inHeader,
+		Name: authorizationHeader,
+		Metadata: map[apiKeyMetadataKey]interface{}{
+			cloudIdentityProviderKey: cloudIdentityProviderGCP,
+			cloudIdentityAudienceKey: "https://api.example.com",
+		},
+	}, secDef.getAPIKey())
+	assert.Equal(t, "", secDef.buildValue(""), "the value should remain empty so the identity token is obtained from the metadata server")
+	assert.Equal(t, "Bearer token", secDef.buildValue("token"))
+	assert.Equal(t, "Bearer token", secDef.buildValue("Bearer token"))
+}
+
+func TestAPIKeyHeaderCloudIdentitySecurityDefinitionValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		secDef        specAPIKeyHeaderCloudIdentitySecurityDefinition
+		expectedError string
+	}{
+		{name: "aws without audience", secDef: newAPIKeyHeaderCloudIdentitySecurityDefinition("cloud_identity", cloudIdentityProviderAWS, "")},
+		{name: "gcp with audience", secDef: newAPIKeyHeaderCloudIdentitySecurityDefinition("cloud_identity", cloudIdentityProviderGCP, "https://api.example.com")},
+		{name: "azure with audience", secDef: newAPIKeyHeaderCloudIdentitySecurityDefinition("cloud_identity", cloudIdentityProviderAzure, "api://example")},
+		{name: "missing name", secDef: newAPIKeyHeaderCloudIdentitySecurityDefinition("", cloudIdentityProviderAWS, ""), expectedError: "specAPIKeyHeaderCloudIdentitySecurityDefinition missing mandatory security definition name"},
+		{name: "gcp without audience", secDef: newAPIKeyHeaderCloudIdentitySecurityDefinition("cloud_identity", cloudIdentityProviderGCP, ""), expectedError: "security definition 'cloud_identity' is missing the x-terraform-cloud-identity-audience extension, which is required for the 'gcp' cloud identity provider"},
+		{name: "unsupported provider", secDef: newAPIKeyHeaderCloudIdentitySecurityDefinition("cloud_identity", "oracle", ""), expectedError: "security definition 'cloud_identity' cloud identity provider 'oracle' not supported, supported providers are [aws, gcp, azure]"},
+	}
+	for _, tc := range testCases {
+		err := tc.secDef.validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
+)
+
+// headerTemplateValuePlaceholder defines the placeholder of the header templates the value configured by the user replaces
+const headerTemplateValuePlaceholder = "%s"
+
+// specAPIKeyHeaderTemplateSecurityDefinition defines a security definition of Header type whose header value is built out of
+// a template (e,g: "Token token=%s" or "SSWS %s"), supporting the APIs with bespoke Authorization header formats
+type specAPIKeyHeaderTemplateSecurityDefinition struct {
+	name     string
+	apiKey   specAPIKey
+	template string
+}
+
+// newAPIKeyHeaderTemplateSecurityDefinition constructs a SpecSecurityDefinition of Header type. The secDefName value is the
+// identifier of the security definition, the apiKeyName is the name of the header (Authorization if empty) and the template
+// is the format of the header value, where %s is replaced with the value configured by the user
+func newAPIKeyHeaderTemplateSecurityDefinition(secDefName, apiKeyName, template string) specAPIKeyHeaderTemplateSecurityDefinition {
+	if apiKeyName == "" {
+		apiKeyName = authorizationHeader
+	}
+	return specAPIKeyHeaderTemplateSecurityDefinition{secDefName, newAPIKeyHeader(apiKeyName), template}
+}
+
+func (s specAPIKeyHeaderTemplateSecurityDefinition) getName() string {
+	return s.name
+}
+
+func (s specAPIKeyHeaderTemplateSecurityDefinition) getType() securityDefinitionType {
+	return securityDefinitionAPIKey
+}
+
+func (s specAPIKeyHeaderTemplateSecurityDefinition) GetTerraformConfigurationName() string {
+	return terraformutils.ConvertToTerraformCompliantName(s.name)
+}
+
+func (s specAPIKeyHeaderTemplateSecurityDefinition) getAPIKey() specAPIKey {
+	return s.apiKey
+}
+
+// buildValue returns the header template with the placeholder replaced by the value passed in; the value is returned
+// as is if empty (so the missing value is still detected) or if it already matches the template (e,g: "SSWS token")
+func (s specAPIKeyHeaderTemplateSecurityDefinition) buildValue(value string) string {
+	if value == "" {
+		return value
+	}
+	prefix := strings.SplitN(s.template, headerTemplateValuePlaceholder, 2)[0]
+	if prefix != "" && strings.HasPrefix(value, prefix) {
+		return value
+	}
+	return strings.Replace(s.template, headerTemplateValuePlaceholder, value, 1)
+}
+
+func (s specAPIKeyHeaderTemplateSecurityDefinition) validate() error {
+	if s.name == "" {
+		return fmt.Errorf("specAPIKeyHeaderTemplateSecurityDefinition missing mandatory security definition name")
+	}
+	if strings.Count(s.template, headerTemplateValuePlaceholder) != 1 {
+		return fmt.Errorf("security definition '%s' %s '%s' not valid, the template must contain the %s placeholder exactly once", s.name, extTfAuthenticatorHeaderTemplate, s.template, headerTemplateValuePlaceholder)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyHeaderTemplateSecurityDefinition(t *testing.T) {
+	secDef := newAPIKeyHeaderTemplateSecurityDefinition("oktaAuth", "", "SSWS %s")
+	var _ SpecSecurityDefinition = secDef
+	assert.Equal(t, "oktaAuth", secDef.getName())
+	assert.Equal(t, "okta_auth", secDef.GetTerraformConfigurationName())
+	assert.Equal(t, securityDefinitionAPIKey, secDef.getType())
+	assert.Equal(t, newAPIKeyHeader(authorizationHeader), secDef.getAPIKey(), "the header name should default to Authorization")
+	assert.Equal(t, newAPIKeyHeader("X-Auth"), newAPIKeyHeaderTemplateSecurityDefinition("auth", "X-Auth", "SSWS %s").getAPIKey())
+
+	authenticator := createAPIKeyAuthenticator(secDef, "token")
+	ctx := &authContext{headers: map[string]string{}}
+	assert.NoError(t, authenticator.prepareAuth(ctx))
+	assert.Equal(t, "SSWS token", ctx.headers[authorizationHeader])
+}
+
+func TestAPIKeyHeaderTemplateSecurityDefinitionBuildValue(t *testing.T) {
+	testCases := []struct {
+		template      string
+		value         string
+		expectedValue string
+	}{
+		{template: "SSWS %s", value: "token", expectedValue: "SSWS token"},
+		{template: "Token token=%s", value: "token", expectedValue: "Token token=token"},
+		{template: "Token token=\"%s\", realm=api", value: "token", expectedValue: "Token token=\"token\", realm=api"},
+		{template: "SSWS %s", value: "SSWS token", expectedValue: "SSWS token"},
+		{template: "%s;v=2", value: "token", expectedValue: "token;v=2"},
+		{template: "SSWS %s", value: "", expectedValue: ""},
+	}
+	for _, tc := range testCases {
+		secDef := newAPIKeyHeaderTemplateSecurityDefinition("auth", "", tc.template)
+		assert.Equal(t, tc.expectedValue, secDef.buildValue(tc.value), tc.template)
+	}
+}
+
+func TestAPIKeyHeaderTemplateSecurityDefinitionValidate(t *testing.T) {
+	assert.NoError(t, newAPIKeyHeaderTemplateSecurityDefinition("auth", "", "SSWS %s").validate())
+	assert.EqualError(t, newAPIKeyHeaderTemplateSecurityDefinition("", "", "SSWS %s").validate(), "specAPIKeyHeaderTemplateSecurityDefinition missing mandatory security definition name")
+	assert.EqualError(t, newAPIKeyHeaderTemplateSecurityDefinition("auth", "", "SSWS").validate(), "security definition 'auth' x-terraform-authenticator-header-template 'SSWS' not valid, the template must contain the %s placeholder exactly once")
+	assert.EqualError(t, newAPIKeyHeaderTemplateSecurityDefinition("auth", "", "%s %s").validate(), "security definition 'auth' x-terraform-authenticator-header-template '%s %s' not valid, the template must contain the %s placeholder exactly once")
+}
@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
+)
+
+// oauth2ClientIDSuffix and oauth2ClientSecretSuffix are appended to the terraform compliant name of the oauth2 client
+// credentials security definitions to build the names of the provider properties containing the client credentials
+const oauth2ClientIDSuffix = "_client_id"
+const oauth2ClientSecretSuffix = "_client_secret" // #nosec G101
+
+type specOAuth2ClientCredentialsSecurityDefinition struct {
+	name     string
+	tokenURL string
+}
+
+// newOAuth2ClientCredentialsSecurityDefinition constructs a SpecSecurityDefinition of Header type using the Bearer
+// authentication scheme whose token is the access token obtained exchanging the client credentials (client id and client
+// secret) at the tokenURL following the OAuth2 client credentials flow
+func newOAuth2ClientCredentialsSecurityDefinition(secDefName, tokenURL string) specOAuth2ClientCredentialsSecurityDefinition {
+	return specOAuth2ClientCredentialsSecurityDefinition{secDefName, tokenURL}
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) getName() string {
+	return s.name
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) getType() securityDefinitionType {
+	return securityDefinitionOAuth2ClientCredentials
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) GetTerraformConfigurationName() string {
+	return terraformutils.ConvertToTerraformCompliantName(s.name)
+}
+
+// getClientIDConfigurationName returns the name of the provider property containing the client id
+func (s specOAuth2ClientCredentialsSecurityDefinition) getClientIDConfigurationName() string {
+	return s.GetTerraformConfigurationName() + oauth2ClientIDSuffix
+}
+
+// getClientSecretConfigurationName returns the name of the provider property containing the client secret
+func (s specOAuth2ClientCredentialsSecurityDefinition) getClientSecretConfigurationName() string {
+	return s.GetTerraformConfigurationName() + oauth2ClientSecretSuffix
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) getAPIKey() specAPIKey {
+	apiKey := newAPIKeyHeader(authorizationHeader)
+	apiKey.Metadata = map[apiKeyMetadataKey]interface{}{
+		oauth2TokenURLKey: s.tokenURL,
+	}
+	return apiKey
+}
+
+// buildValue returns the value as is since the client credentials are exchanged for access tokens instead of being sent
+// to the API
+func (s specOAuth2ClientCredentialsSecurityDefinition) buildValue(value string) string {
+	return value
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) validate() error {
+	if s.name == "" {
+		return fmt.Errorf("specOAuth2ClientCredentialsSecurityDefinition missing mandatory security definition name")
+	}
+	if s.tokenURL == "" {
+		return fmt.Errorf("security definition '%s' is missing the mandatory tokenUrl", s.name)
+	}
+	if !isURL(s.tokenURL) {
+		return fmt.Errorf("security definition '%s' tokenUrl '%s' must be a valid URL", s.name, s.tokenURL)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOAuth2ClientCredentialsSecurityDefinition(t *testing.T) {
+	secDef := newOAuth2ClientCredentialsSecurityDefinition("oauth2Auth", "https://auth.example.com/oauth2/token")
+	var _ SpecSecurityDefinition = secDef
+	assert.Equal(t, "oauth2Auth", secDef.getName())
+	assert.Equal(t, "oauth2_auth", secDef.GetTerraformConfigurationName())
+	assert.Equal(t, "oauth2_auth_client_id", secDef.getClientIDConfigurationName())
+	assert.Equal(t, "oauth2_auth_client_secret", secDef.getClientSecretConfigurationName())
+	assert.Equal(t, securityDefinitionOAuth2ClientCredentials, secDef.getType())
+	assert.Equal(t, specAPIKey{
+		In:       inHeader,
+		Name:     authorizationHeader,
+		Metadata: map[apiKeyMetadataKey]interface{}{oauth2TokenURLKey: "https://auth.example.com/oauth2/token"},
+	}, secDef.getAPIKey())
+	assert.Equal(t, "secret", secDef.buildValue("secret"))
+}
+
+func TestOAuth2ClientCredentialsSecurityDefinitionValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		secDef        specOAuth2ClientCredentialsSecurityDefinition
+		expectedError string
+	}{
+		{name: "valid security definition", secDef: newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", "https://auth.example.com/oauth2/token")},
+		{name: "missing name", secDef: newOAuth2ClientCredentialsSecurityDefinition("", "https://auth.example.com/oauth2/token"), expectedError: "specOAuth2ClientCredentialsSecurityDefinition missing mandatory security definition name"},
+		{name: "missing token URL", secDef: newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", ""), expectedError: "security definition 'oauth2_auth' is missing the mandatory tokenUrl"},
+		{name: "token URL not valid", secDef: newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", "/oauth2/token"), expectedError: "security definition 'oauth2_auth' tokenUrl '/oauth2/token' must be a valid URL"},
+	}
+	for _, tc := range testCases {
+		err := tc.secDef.validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
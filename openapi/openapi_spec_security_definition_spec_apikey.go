@@ -11,6 +11,12 @@ type apiKeyMetadataKey string
 
 const (
 	refreshTokenURLKey apiKeyMetadataKey = "refreshTokenURL"
+	// cloudIdentityProviderKey contains the cloud (aws, gcp or azure) whose instance metadata server issues the identity tokens
+	cloudIdentityProviderKey apiKeyMetadataKey = "cloudIdentityProvider"
+	// cloudIdentityAudienceKey contains the audience the identity tokens are issued for
+	cloudIdentityAudienceKey apiKeyMetadataKey = "cloudIdentityAudience"
+	// oauth2TokenURLKey contains the URL the oauth2 client credentials are exchanged for access tokens at
+	oauth2TokenURLKey apiKeyMetadataKey = "oauth2TokenURL"
 )
 
 type specAPIKey struct {
@@ -15,8 +15,11 @@ func (s SpecSecurityDefinitions) findSecurityDefinitionFor(securitySchemeName st
 type securityDefinitionType string
 
 const (
-	securityDefinitionAPIKey             securityDefinitionType = "apiKey"
-	securityDefinitionAPIKeyRefreshToken securityDefinitionType = "apiKeyRefreshToken"
+	securityDefinitionAPIKey              securityDefinitionType = "apiKey"
+	securityDefinitionAPIKeyRefreshToken  securityDefinitionType = "apiKeyRefreshToken"
+	securityDefinitionAPIKeyCloudIdentity securityDefinitionType = "apiKeyCloudIdentity"
+	// securityDefinitionOAuth2ClientCredentials defines the oauth2 security definitions using the client credentials flow
+	securityDefinitionOAuth2ClientCredentials securityDefinitionType = "oauth2ClientCredentials"
 )
 
 // SpecSecurityDefinition defines the behaviour expected for security definition implementations. This interface creates
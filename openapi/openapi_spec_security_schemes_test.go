@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSpecSecuritySchemeGetTerraformConfigurationName(t *testing.T) {
@@ -103,3 +104,16 @@ func TestCreateSecuritySchemes(t *testing.T) {
 		})
 	})
 }
+
+func TestCreateSecuritySchemes_Scopes(t *testing.T) {
+	securitySchemes := []map[string][]string{
+		{
+			"oauth2": {"cdns:read", "cdns:write"},
+			"apiKey": {},
+		},
+	}
+	specSecuritySchemes := createSecuritySchemes(securitySchemes)
+	assert.Len(t, specSecuritySchemes, 2)
+	assert.Contains(t, specSecuritySchemes, SpecSecurityScheme{Name: "oauth2", Scopes: []string{"cdns:read", "cdns:write"}})
+	assert.Contains(t, specSecuritySchemes, SpecSecurityScheme{Name: "apiKey"})
+}
@@ -3,7 +3,9 @@ package openapi
 type specSecurityStub struct {
 	securityDefinitions   *SpecSecurityDefinitions
 	globalSecuritySchemes SpecSecuritySchemes
-	error                 error
+	// securityDefinitionDescriptions contains the descriptions of the security definitions by name
+	securityDefinitionDescriptions map[string]string
+	error                          error
 }
 
 func (s *specSecurityStub) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinitions, error) {
@@ -13,6 +15,10 @@ func (s *specSecurityStub) GetAPIKeySecurityDefinitions() (*SpecSecurityDefiniti
 	return s.securityDefinitions, nil
 }
 
+func (s *specSecurityStub) GetSecurityDefinitionDescription(securityDefinitionName string) string {
+	return s.securityDefinitionDescriptions[securityDefinitionName]
+}
+
 func (s *specSecurityStub) GetGlobalSecuritySchemes() (SpecSecuritySchemes, error) {
 	if s.error != nil {
 		return nil, s.error
@@ -0,0 +1,50 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// specSessionLogin defines the login operation of the APIs authenticating the API calls with a session token (or cookie)
+// returned by a login endpoint the credentials are posted to (x-terraform-provider-session-login)
+type specSessionLogin struct {
+	// path is the path (relative to the base path) of the login endpoint the credentials are POSTed to
+	path string
+	// credentials maps the login request body fields to the provider properties containing their values
+	credentials map[string]string
+	// tokenPath is the path (dot separated) of the login response body field containing the session token
+	tokenPath string
+	// tokenCookie is the name of the cookie set by the login response containing the session token
+	tokenCookie string
+	// tokenHeader is the header the session token is sent in when the token is read from the response body
+	tokenHeader string
+	// tokenHeaderTemplate is the template (containing %s) the token header value is built from
+	tokenHeaderTemplate string
+	// expiresInPath is the path (dot separated) of the login response body field containing the number of seconds the
+	// session is valid for; empty if the session expiry is not returned in the response body
+	expiresInPath string
+}
+
+func (s *specSessionLogin) validate() error {
+	if s.path == "" {
+		return fmt.Errorf("missing the login endpoint path")
+	}
+	if len(s.credentials) == 0 {
+		return fmt.Errorf("missing the login credentials")
+	}
+	for field, property := range s.credentials {
+		if property == "" {
+			return fmt.Errorf("login credential '%s' is missing the provider property containing its value", field)
+		}
+	}
+	if (s.tokenPath == "") == (s.tokenCookie == "") {
+		return fmt.Errorf("either the token_path or the token_cookie must be specified")
+	}
+	if s.tokenCookie != "" && s.tokenHeader != "" {
+		return fmt.Errorf("token_header is not supported along with the token_cookie, the session cookie is sent in the Cookie header")
+	}
+	if strings.Count(s.tokenHeaderTemplate, "%s") != 1 {
+		return fmt.Errorf("token_header_template '%s' must contain exactly one %%s placeholder for the session token", s.tokenHeaderTemplate)
+	}
+	return nil
+}
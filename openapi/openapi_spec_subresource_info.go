@@ -1,6 +1,9 @@
 package openapi
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ParentResourceInfo contains the information related to the parent information. For instance, a subresource would have
 // this struct populated with the parent info so the resource name and corresponding parent properties can be configured in the
@@ -21,6 +24,12 @@ func (info *ParentResourceInfo) GetParentPropertiesNames() []string {
 	return parentPropertyNames
 }
 
+// GetParentResourceName returns the name of the closest parent resource, which is built from the names of all the parents
+// (e,g: cdns_v1_firewalls_v1 for the resource /v1/cdns/{id}/v1/firewalls/{id}/v1/rules)
+func (info *ParentResourceInfo) GetParentResourceName() string {
+	return strings.Join(info.parentResourceNames, "_")
+}
+
 // SetParentResourceNames sets the resource parent names
 func (info *ParentResourceInfo) SetParentResourceNames(parentResourceNames []string) {
 	info.parentResourceNames = parentResourceNames
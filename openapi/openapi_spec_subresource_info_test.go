@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_getParentPropertiesNames(t *testing.T) {
@@ -43,3 +44,9 @@ func Test_getParentPropertiesNames(t *testing.T) {
 		})
 	})
 }
+
+func TestGetParentResourceName(t *testing.T) {
+	assert.Empty(t, (&ParentResourceInfo{}).GetParentResourceName())
+	s := &ParentResourceInfo{parentResourceNames: []string{"cdns_v1", "firewalls_v2"}}
+	assert.Equal(t, "cdns_v1_firewalls_v2", s.GetParentResourceName())
+}
@@ -11,6 +11,11 @@ type specStubBackendConfiguration struct {
 	basePath         string
 	httpScheme       string
 	regions          []string
+	fallbackHosts    []string
+	featureFlags     []*SpecSchemaDefinitionProperty
+	featureFlagsErr  error
+	sessionLogin     *specSessionLogin
+	errorHints       specErrorHints
 	err              error
 	hostErr          error
 	defaultRegionErr error
@@ -72,6 +77,25 @@ func (s *specStubBackendConfiguration) GetDefaultRegion(regions []string) (strin
 	return s.regions[0], nil
 }
 
+func (s *specStubBackendConfiguration) getFallbackHosts() []string {
+	return s.fallbackHosts
+}
+
+func (s *specStubBackendConfiguration) getFeatureFlags() ([]*SpecSchemaDefinitionProperty, error) {
+	if s.featureFlagsErr != nil {
+		return nil, s.featureFlagsErr
+	}
+	return s.featureFlags, nil
+}
+
+func (s *specStubBackendConfiguration) getSessionLogin() (*specSessionLogin, error) {
+	return s.sessionLogin, nil
+}
+
+func (s *specStubBackendConfiguration) getErrorHints() (specErrorHints, error) {
+	return s.errorHints, nil
+}
+
 func (s *specStubBackendConfiguration) IsMultiRegion() (bool, string, []string, error) {
 	if s.err != nil {
 		return false, "", nil, s.err
@@ -1,10 +1,16 @@
 package openapi
 
+import (
+	"fmt"
+	"time"
+)
+
 // specStubResource is a stub implementation of SpecResource interface which is used for testing purposes
 type specStubResource struct {
 	name                    string
 	host                    string
 	path                    string
+	readPath                string
 	shouldIgnore            bool
 	schemaDefinition        *SpecSchemaDefinition
 	resourceGetOperation    *specResourceOperation
@@ -13,6 +19,7 @@ type specStubResource struct {
 	resourcePutOperation    *specResourceOperation
 	resourceDeleteOperation *specResourceOperation
 	timeouts                *specTimeouts
+	batchReadTTL            *time.Duration
 
 	parentResourceNames    []string
 	fullParentResourceName string
@@ -50,6 +57,13 @@ func (s *specStubResource) getResourcePath(parentIDs []string) (string, error) {
 	return s.path, nil
 }
 
+func (s *specStubResource) getReadPath(parentIDs []string, id string) (string, error) {
+	if s.readPath == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("%s/%s", s.readPath, id), nil
+}
+
 func (s *specStubResource) GetResourceSchema() (*SpecSchemaDefinition, error) {
 	if s.funcGetResourceSchema != nil {
 		return s.funcGetResourceSchema()
@@ -72,6 +86,13 @@ func (s *specStubResource) getResourceOperations() specResourceOperations {
 	}
 }
 
+func (s *specStubResource) getBatchReadTTL() (*time.Duration, error) {
+	if s.error != nil {
+		return nil, s.error
+	}
+	return s.batchReadTTL, nil
+}
+
 func (s *specStubResource) getTimeouts() (*specTimeouts, error) {
 	if s.funcGetTimeouts != nil {
 		return s.funcGetTimeouts()
@@ -3,15 +3,20 @@ package openapi
 import (
 	"errors"
 	"fmt"
-	"log"
+	"sort"
 	"strings"
 
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/openapiutils"
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
 	"github.com/go-openapi/spec"
 )
 
 const extTfProviderMultiRegionFQDN = "x-terraform-provider-multiregion-fqdn"
 const extTfProviderRegions = "x-terraform-provider-regions"
+const extTfProviderFallbackHosts = "x-terraform-provider-fallback-hosts"
+const extTfProviderFeatureFlags = "x-terraform-provider-feature-flags"
+const extTfProviderSessionLogin = "x-terraform-provider-session-login"
+const extTfErrorHints = "x-terraform-error-hints"
 
 type specV2BackendConfiguration struct {
 	openAPIDocumentURL string
@@ -30,7 +35,7 @@ func newOpenAPIBackendConfigurationV2(spec *spec.Swagger, openAPIDocumentURL str
 
 func (o specV2BackendConfiguration) getHost() (string, error) {
 	if o.spec.Host == "" {
-		log.Printf("[WARN] host field not specified in the swagger configuration, falling back to retrieving the host from where the OpenAPI document is served: '%s'", o.openAPIDocumentURL)
+		analyserLog.Warn("host field not specified in the swagger configuration, falling back to retrieving the host from where the OpenAPI document is served: '%s'", o.openAPIDocumentURL)
 		hostFromURL := openapiutils.GetHostFromURL(o.openAPIDocumentURL)
 		if hostFromURL == "" {
 			return "", fmt.Errorf("could not find valid host from URL provided: '%s'", o.openAPIDocumentURL)
@@ -115,6 +120,126 @@ func (o specV2BackendConfiguration) getProviderRegions() ([]string, error) {
 	return regions, nil
 }
 
+// getFallbackHosts returns the hosts the API calls fail over to when the API host is not available, as defined in the
+// comma separated x-terraform-provider-fallback-hosts extension; nil is returned if the extension is not present
+func (o specV2BackendConfiguration) getFallbackHosts() []string {
+	fallbackHostsExtensionValue, exists := o.spec.Extensions.GetString(extTfProviderFallbackHosts)
+	if !exists || strings.TrimSpace(fallbackHostsExtensionValue) == "" {
+		return nil
+	}
+	var fallbackHosts []string
+	for _, host := range strings.Split(strings.Replace(fallbackHostsExtensionValue, " ", "", -1), ",") {
+		if host != "" {
+			fallbackHosts = append(fallbackHosts, host)
+		}
+	}
+	return fallbackHosts
+}
+
+// getFeatureFlags returns the provider feature flags described by the properties of the definition referred by the
+// x-terraform-provider-feature-flags extension (e,g: #/definitions/FeatureFlags); nil is returned if the extension is
+// not present. Only primitive properties are supported as feature flags
+func (o specV2BackendConfiguration) getFeatureFlags() ([]*SpecSchemaDefinitionProperty, error) {
+	featureFlagsRef, exists := o.spec.Extensions.GetString(extTfProviderFeatureFlags)
+	if !exists || featureFlagsRef == "" {
+		return nil, nil
+	}
+	definitionName := strings.TrimPrefix(featureFlagsRef, "#/definitions/")
+	definition, exists := o.spec.Definitions[definitionName]
+	if !exists || definitionName == featureFlagsRef {
+		return nil, fmt.Errorf("%s extension value '%s' not valid: the value must refer to an existing definition (e,g: #/definitions/FeatureFlags)", extTfProviderFeatureFlags, featureFlagsRef)
+	}
+	var featureFlags []*SpecSchemaDefinitionProperty
+	for propertyName, property := range definition.Properties {
+		featureFlag := &SpecSchemaDefinitionProperty{
+			Name:        propertyName,
+			Description: property.Description,
+			Default:     property.Default,
+		}
+		if len(property.Type) > 0 {
+			featureFlag.Type = schemaDefinitionPropertyType(property.Type[0])
+		}
+		if !featureFlag.isPrimitiveProperty() {
+			return nil, fmt.Errorf("feature flag '%s' not valid: only string, integer, number and boolean feature flags are supported", propertyName)
+		}
+		featureFlags = append(featureFlags, featureFlag)
+	}
+	sort.Slice(featureFlags, func(i, j int) bool { return featureFlags[i].Name < featureFlags[j].Name })
+	return featureFlags, nil
+}
+
+// getSessionLogin returns the session login operation defined in the x-terraform-provider-session-login extension; nil is
+// returned if the extension is not present. The session token is sent in the Authorization header as a Bearer token unless
+// a different token header (and template) is specified
+func (o specV2BackendConfiguration) getSessionLogin() (*specSessionLogin, error) {
+	value, exists := o.spec.Extensions[extTfProviderSessionLogin]
+	if !exists {
+		return nil, nil
+	}
+	sessionLoginConfig, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s extension not valid: the value must be an object", extTfProviderSessionLogin)
+	}
+	sessionLogin := &specSessionLogin{credentials: map[string]string{}}
+	sessionLogin.path, _ = sessionLoginConfig["path"].(string)
+	if credentials, ok := sessionLoginConfig["credentials"].(map[string]interface{}); ok {
+		for field, property := range credentials {
+			propertyName, _ := property.(string)
+			sessionLogin.credentials[field] = terraformutils.ConvertToTerraformCompliantName(propertyName)
+		}
+	}
+	sessionLogin.tokenPath, _ = sessionLoginConfig["token_path"].(string)
+	sessionLogin.tokenCookie, _ = sessionLoginConfig["token_cookie"].(string)
+	sessionLogin.tokenHeader, _ = sessionLoginConfig["token_header"].(string)
+	sessionLogin.tokenHeaderTemplate, _ = sessionLoginConfig["token_header_template"].(string)
+	sessionLogin.expiresInPath, _ = sessionLoginConfig["expires_in_path"].(string)
+	if sessionLogin.tokenHeaderTemplate == "" {
+		sessionLogin.tokenHeaderTemplate = "%s"
+		if sessionLogin.tokenPath != "" && (sessionLogin.tokenHeader == "" || sessionLogin.tokenHeader == authorizationHeader) {
+			sessionLogin.tokenHeaderTemplate = bearerScheme + " %s"
+		}
+	}
+	if sessionLogin.tokenPath != "" && sessionLogin.tokenHeader == "" {
+		sessionLogin.tokenHeader = authorizationHeader
+	}
+	if err := sessionLogin.validate(); err != nil {
+		return nil, fmt.Errorf("%s extension not valid: %s", extTfProviderSessionLogin, err)
+	}
+	return sessionLogin, nil
+}
+
+// getErrorHints returns the hints of the API errors defined in the x-terraform-error-hints extension, which maps the error
+// codes (or regular expressions) to the hints; nil is returned if the extension is not present. The hints are sorted by key
+// so they are appended to the diagnostics in a deterministic order
+func (o specV2BackendConfiguration) getErrorHints() (specErrorHints, error) {
+	value, exists := o.spec.Extensions[extTfErrorHints]
+	if !exists {
+		return nil, nil
+	}
+	errorHintsConfig, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s extension not valid: the value must be an object mapping the error codes (or regular expressions) to the hints", extTfErrorHints)
+	}
+	var keys []string
+	for key := range errorHintsConfig {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var errorHints specErrorHints
+	for _, key := range keys {
+		hint, _ := errorHintsConfig[key].(string)
+		if hint == "" {
+			return nil, fmt.Errorf("%s extension not valid: the hint of '%s' must be a non empty string", extTfErrorHints, key)
+		}
+		errorHint, err := newSpecErrorHint(key, hint)
+		if err != nil {
+			return nil, fmt.Errorf("%s extension not valid: %s", extTfErrorHints, err)
+		}
+		errorHints = append(errorHints, errorHint)
+	}
+	return errorHints, nil
+}
+
 func (o specV2BackendConfiguration) getBasePath() string {
 	return o.spec.BasePath
 }
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -482,6 +484,206 @@ func TestIsHostMultiRegion(t *testing.T) {
 	})
 }
 
+func TestGetFallbackHosts(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		extensions            spec.Extensions
+		expectedFallbackHosts []string
+	}{
+		{name: "fallback hosts extension not present", extensions: spec.Extensions{}, expectedFallbackHosts: nil},
+		{name: "fallback hosts extension with one host", extensions: spec.Extensions{extTfProviderFallbackHosts: "api-passive.example.com"}, expectedFallbackHosts: []string{"api-passive.example.com"}},
+		{name: "fallback hosts extension with multiple hosts", extensions: spec.Extensions{extTfProviderFallbackHosts: "api-passive.example.com, api-dr.example.com"}, expectedFallbackHosts: []string{"api-passive.example.com", "api-dr.example.com"}},
+		{name: "fallback hosts extension with empty value", extensions: spec.Extensions{extTfProviderFallbackHosts: ""}, expectedFallbackHosts: nil},
+	}
+	for _, tc := range testCases {
+		swagger := &spec.Swagger{
+			VendorExtensible: spec.VendorExtensible{Extensions: tc.extensions},
+			SwaggerProps: spec.SwaggerProps{
+				Swagger: "2.0",
+				Host:    "api.example.com",
+			},
+		}
+		specV2BackendConfiguration, err := newOpenAPIBackendConfigurationV2(swagger, "www.domain.com")
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedFallbackHosts, specV2BackendConfiguration.getFallbackHosts(), tc.name)
+	}
+}
+
+func TestGetFeatureFlags(t *testing.T) {
+	featureFlagsDefinition := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Properties: map[string]spec.Schema{
+				"newBilling": {SchemaProps: spec.SchemaProps{Type: []string{"boolean"}, Default: false, Description: "Enables the new billing API"}},
+				"maxNodes":   {SchemaProps: spec.SchemaProps{Type: []string{"integer"}, Default: 10.0}},
+			},
+		},
+	}
+	objectFeatureFlagsDefinition := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Properties: map[string]spec.Schema{"settings": {SchemaProps: spec.SchemaProps{Type: []string{"object"}}}},
+		},
+	}
+	testCases := []struct {
+		name                 string
+		extensions           spec.Extensions
+		expectedFeatureFlags []*SpecSchemaDefinitionProperty
+		expectedError        string
+	}{
+		{name: "feature flags extension not present", extensions: spec.Extensions{}, expectedFeatureFlags: nil},
+		{
+			name:       "feature flags extension referring to a definition",
+			extensions: spec.Extensions{extTfProviderFeatureFlags: "#/definitions/FeatureFlags"},
+			expectedFeatureFlags: []*SpecSchemaDefinitionProperty{
+				{Name: "maxNodes", Type: TypeInt, Default: 10.0},
+				{Name: "newBilling", Type: TypeBool, Default: false, Description: "Enables the new billing API"},
+			},
+		},
+		{name: "feature flags extension referring to a missing definition", extensions: spec.Extensions{extTfProviderFeatureFlags: "#/definitions/Missing"}, expectedError: "x-terraform-provider-feature-flags extension value '#/definitions/Missing' not valid: the value must refer to an existing definition (e,g: #/definitions/FeatureFlags)"},
+		{name: "feature flags extension not referring to a definition", extensions: spec.Extensions{extTfProviderFeatureFlags: "FeatureFlags"}, expectedError: "x-terraform-provider-feature-flags extension value 'FeatureFlags' not valid: the value must refer to an existing definition (e,g: #/definitions/FeatureFlags)"},
+		{name: "feature flags definition with object properties", extensions: spec.Extensions{extTfProviderFeatureFlags: "#/definitions/ObjectFeatureFlags"}, expectedError: "feature flag 'settings' not valid: only string, integer, number and boolean feature flags are supported"},
+	}
+	for _, tc := range testCases {
+		swagger := &spec.Swagger{
+			VendorExtensible: spec.VendorExtensible{Extensions: tc.extensions},
+			SwaggerProps: spec.SwaggerProps{
+				Swagger:     "2.0",
+				Host:        "api.example.com",
+				Definitions: spec.Definitions{"FeatureFlags": featureFlagsDefinition, "ObjectFeatureFlags": objectFeatureFlagsDefinition},
+			},
+		}
+		specV2BackendConfiguration, err := newOpenAPIBackendConfigurationV2(swagger, "www.domain.com")
+		require.NoError(t, err, tc.name)
+		featureFlags, err := specV2BackendConfiguration.getFeatureFlags()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedFeatureFlags, featureFlags, tc.name)
+	}
+}
+
+func TestGetSessionLogin(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		extensions           spec.Extensions
+		expectedSessionLogin *specSessionLogin
+		expectedError        string
+	}{
+		{name: "session login extension not present", extensions: spec.Extensions{}, expectedSessionLogin: nil},
+		{
+			name: "session token read from the response body",
+			extensions: spec.Extensions{extTfProviderSessionLogin: map[string]interface{}{
+				"path":            "/login",
+				"credentials":     map[string]interface{}{"username": "username", "password": "apiPassword"},
+				"token_path":      "session.token",
+				"expires_in_path": "session.expires_in",
+			}},
+			expectedSessionLogin: &specSessionLogin{
+				path:                "/login",
+				credentials:         map[string]string{"username": "username", "password": "api_password"},
+				tokenPath:           "session.token",
+				tokenHeader:         authorizationHeader,
+				tokenHeaderTemplate: "Bearer %s",
+				expiresInPath:       "session.expires_in",
+			},
+		},
+		{
+			name: "session token sent in a custom header",
+			extensions: spec.Extensions{extTfProviderSessionLogin: map[string]interface{}{
+				"path":         "/login",
+				"credentials":  map[string]interface{}{"user": "username"},
+				"token_path":   "token",
+				"token_header": "X-Session-Token",
+			}},
+			expectedSessionLogin: &specSessionLogin{
+				path:                "/login",
+				credentials:         map[string]string{"user": "username"},
+				tokenPath:           "token",
+				tokenHeader:         "X-Session-Token",
+				tokenHeaderTemplate: "%s",
+			},
+		},
+		{
+			name: "session cookie",
+			extensions: spec.Extensions{extTfProviderSessionLogin: map[string]interface{}{
+				"path":         "/login",
+				"credentials":  map[string]interface{}{"user": "username"},
+				"token_cookie": "SESSIONID",
+			}},
+			expectedSessionLogin: &specSessionLogin{
+				path:                "/login",
+				credentials:         map[string]string{"user": "username"},
+				tokenCookie:         "SESSIONID",
+				tokenHeaderTemplate: "%s",
+			},
+		},
+		{name: "session login extension not an object", extensions: spec.Extensions{extTfProviderSessionLogin: "/login"}, expectedError: "x-terraform-provider-session-login extension not valid: the value must be an object"},
+		{name: "session login extension missing the path", extensions: spec.Extensions{extTfProviderSessionLogin: map[string]interface{}{"credentials": map[string]interface{}{"user": "username"}, "token_path": "token"}}, expectedError: "x-terraform-provider-session-login extension not valid: missing the login endpoint path"},
+		{name: "session login extension missing the credentials", extensions: spec.Extensions{extTfProviderSessionLogin: map[string]interface{}{"path": "/login", "token_path": "token"}}, expectedError: "x-terraform-provider-session-login extension not valid: missing the login credentials"},
+		{name: "session login extension with both token path and cookie", extensions: spec.Extensions{extTfProviderSessionLogin: map[string]interface{}{"path": "/login", "credentials": map[string]interface{}{"user": "username"}, "token_path": "token", "token_cookie": "SESSIONID"}}, expectedError: "x-terraform-provider-session-login extension not valid: either the token_path or the token_cookie must be specified"},
+		{name: "session login extension with a token header template missing the placeholder", extensions: spec.Extensions{extTfProviderSessionLogin: map[string]interface{}{"path": "/login", "credentials": map[string]interface{}{"user": "username"}, "token_path": "token", "token_header_template": "Session"}}, expectedError: "x-terraform-provider-session-login extension not valid: token_header_template 'Session' must contain exactly one %s placeholder for the session token"},
+	}
+	for _, tc := range testCases {
+		swagger := &spec.Swagger{
+			VendorExtensible: spec.VendorExtensible{Extensions: tc.extensions},
+			SwaggerProps:     spec.SwaggerProps{Swagger: "2.0", Host: "api.example.com"},
+		}
+		specV2BackendConfiguration, err := newOpenAPIBackendConfigurationV2(swagger, "www.domain.com")
+		require.NoError(t, err, tc.name)
+		sessionLogin, err := specV2BackendConfiguration.getSessionLogin()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedSessionLogin, sessionLogin, tc.name)
+	}
+}
+
+func TestGetErrorHints(t *testing.T) {
+	testCases := []struct {
+		name               string
+		extensions         spec.Extensions
+		expectedErrorHints []string
+		expectedError      string
+	}{
+		{name: "error hints extension not present", extensions: spec.Extensions{}, expectedErrorHints: nil},
+		{
+			name: "error hints sorted by key",
+			extensions: spec.Extensions{extTfErrorHints: map[string]interface{}{
+				"QUOTA_EXCEEDED": "Request a quota increase at https://example.com/quotas",
+				"403":            "Make sure the API key has the admin role",
+				"5XX":            "The API is unavailable, please try again later",
+			}},
+			expectedErrorHints: []string{"403", "5XX", "QUOTA_EXCEEDED"},
+		},
+		{name: "error hints extension not an object", extensions: spec.Extensions{extTfErrorHints: "403"}, expectedError: "x-terraform-error-hints extension not valid: the value must be an object mapping the error codes (or regular expressions) to the hints"},
+		{name: "error hint not a string", extensions: spec.Extensions{extTfErrorHints: map[string]interface{}{"403": 403}}, expectedError: "x-terraform-error-hints extension not valid: the hint of '403' must be a non empty string"},
+		{name: "error hint with an empty hint", extensions: spec.Extensions{extTfErrorHints: map[string]interface{}{"403": ""}}, expectedError: "x-terraform-error-hints extension not valid: the hint of '403' must be a non empty string"},
+		{name: "error hint with a regular expression not valid", extensions: spec.Extensions{extTfErrorHints: map[string]interface{}{"QUOTA_(": "some hint"}}, expectedError: "x-terraform-error-hints extension not valid: error hint 'QUOTA_(' not valid: error parsing regexp: missing closing ): `QUOTA_(`"},
+	}
+	for _, tc := range testCases {
+		swagger := &spec.Swagger{
+			VendorExtensible: spec.VendorExtensible{Extensions: tc.extensions},
+			SwaggerProps:     spec.SwaggerProps{Swagger: "2.0", Host: "api.example.com"},
+		}
+		specV2BackendConfiguration, err := newOpenAPIBackendConfigurationV2(swagger, "www.domain.com")
+		require.NoError(t, err, tc.name)
+		errorHints, err := specV2BackendConfiguration.getErrorHints()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		var keys []string
+		for _, errorHint := range errorHints {
+			keys = append(keys, errorHint.key)
+		}
+		assert.Equal(t, tc.expectedErrorHints, keys, tc.name)
+	}
+}
+
 func TestGetBasePath(t *testing.T) {
 	Convey("Given a specV2BackendConfiguration with the basePath configured", t, func() {
 		spec := &spec.Swagger{
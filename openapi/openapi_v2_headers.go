@@ -2,7 +2,6 @@ package openapi
 
 import (
 	"github.com/go-openapi/spec"
-	"log"
 )
 
 const extTfHeader = "x-terraform-header"
@@ -30,13 +29,13 @@ func getHeaderConfigurationsForParameterGroups(parametersGroup parameterGroups)
 				switch parameter.In {
 				case "header":
 					if preferredName, exists := parameter.Extensions.GetString(extTfHeader); exists {
-						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, TerraformName: preferredName, IsRequired: parameter.Required})
+						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, TerraformName: preferredName, IsRequired: parameter.Required, Description: parameter.Description})
 					} else {
-						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, IsRequired: parameter.Required})
+						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, IsRequired: parameter.Required, Description: parameter.Description})
 					}
 				}
 			} else {
-				log.Printf("[DEBUG] found duplicate header '%s' for an operation, ignoring it as it has been registered already", parameter.Name)
+				analyserLog.Debug("found duplicate header '%s' for an operation, ignoring it as it has been registered already", parameter.Name)
 			}
 		}
 	}
@@ -2,9 +2,10 @@ package openapi
 
 import (
 	"fmt"
-	"log"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/openapiutils"
@@ -13,6 +14,9 @@ import (
 
 const pathParameterRegex = "/({[\\w]*})*/"
 
+// readPathParameterRegex is used to find the path parameters in the read path configured via the x-terraform-read-path extension
+const readPathParameterRegex = "{[^/{}]+}"
+
 // resourceVersionRegexTemplate is used to identify the version attached to the given resource. The parameter in the
 // template will be replaced with the actual resource name so if there is a match the version grabbed is assured to belong
 // to the resource in question and not any other version showing in the path before the resource name
@@ -20,6 +24,9 @@ const resourceVersionRegexTemplate = "/(v[\\d]*)/%s"
 
 const resourceNameRegex = "((/[\\w-]*[/]?))+$"
 
+// attributeAliasRegex validates the attribute aliases (x-terraform-attribute-aliases) are terraform compliant names
+var attributeAliasRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
 // resourceParentNameRegex is the regex used to identify the different parents from a path that is a sub-resource. If used
 // calling FindStringSubmatch, any match will contain the following groups in the corresponding array index:
 // Index 0: This value will represent the full match containing also the path parameter (e,g: /v1/cdns/{id})
@@ -52,21 +59,94 @@ const resourceInstanceRegex = "((?:.*)){.*}"
 const extTfImmutable = "x-terraform-immutable"
 const extTfForceNew = "x-terraform-force-new"
 const extTfSensitive = "x-terraform-sensitive"
+const extTfEncryptInState = "x-terraform-encrypt-in-state"
+const extTfFingerprintInState = "x-terraform-fingerprint-in-state"
+const extTfCoerce = "x-terraform-coerce"
+const extTfIgnoreServerChanges = "x-terraform-ignore-server-changes"
+const extTfIgnoreServerItems = "x-terraform-ignore-server-items"
 const extTfFieldName = "x-terraform-field-name"
 const extTfFieldStatus = "x-terraform-field-status"
+const extTfExample = "x-terraform-example"
+const extTfValidator = "x-terraform-validator"
+const extTfFormat = "x-terraform-format"
 const extTfID = "x-terraform-id"
+const extTfIDAliases = "x-terraform-id-aliases"
+const extTfAttributeAliases = "x-terraform-attribute-aliases"
 const extTfComputed = "x-terraform-computed"
 const extTfIgnoreOrder = "x-terraform-ignore-order"
 const extIgnoreOrder = "x-ignore-order"
+const extTfOrdered = "x-terraform-ordered"
+const extTfUpdateStrategy = "x-terraform-update-strategy"
+const extTfResolveByName = "x-terraform-resolve-by-name"
+const extTfUploadProperty = "x-terraform-upload-property"
+const extTfFieldNameCollisions = "x-terraform-field-name-collisions"
 
 // Operation level extensions
 const extTfResourceTimeout = "x-terraform-resource-timeout"
 const extTfResourcePollEnabled = "x-terraform-resource-poll-enabled"
 const extTfResourcePollTargetStatuses = "x-terraform-resource-poll-completed-statuses"
 const extTfResourcePollPendingStatuses = "x-terraform-resource-poll-pending-statuses"
+const extTfResourcePollRequest = "x-terraform-resource-poll-request"
 const extTfExcludeResource = "x-terraform-exclude-resource"
 const extTfResourceName = "x-terraform-resource-name"
 const extTfResourceURL = "x-terraform-resource-host"
+const extTfResourceBatchRead = "x-terraform-resource-batch-read"
+const extTfResourceBatchReadTTL = "x-terraform-resource-batch-read-ttl"
+const extTfResourceConditionalGet = "x-terraform-resource-conditional-get"
+const extTfGRPCMethod = "x-terraform-grpc-method"
+const extTfGraphQL = "x-terraform-graphql"
+const extTfMiddleware = "x-terraform-middleware"
+const extTfRevisionProperty = "x-terraform-revision-property"
+const extTfFieldsParameter = "x-terraform-fields-parameter"
+const extTfFields = "x-terraform-fields"
+const extTfUserAgent = "x-terraform-user-agent"
+const extTfReadPath = "x-terraform-read-path"
+const extTfDataSourceDetailFetch = "x-terraform-data-source-detail-fetch"
+const extTfForceApplyParameter = "x-terraform-force-apply-parameter"
+const extTfSkipUnchangedUpdate = "x-terraform-skip-unchanged-update"
+const extTfRequestTimeout = "x-terraform-request-timeout"
+const extTfResourceProvisioningEvents = "x-terraform-resource-provisioning-events"
+const extTfPrecheckPath = "x-terraform-precheck-path"
+const extTfPrecheckField = "x-terraform-precheck-field"
+const extTfPrecheckMessageField = "x-terraform-precheck-message-field"
+const extTfLockPath = "x-terraform-lock-path"
+const extTfConsistencyToken = "x-terraform-consistency-token"
+const extTfRetry = "x-terraform-retry"
+const extTfWaitFor = "x-terraform-wait-for"
+const extTfOptionsPath = "x-terraform-options-path"
+const extTfCountPath = "x-terraform-count-path"
+const extTfCountField = "x-terraform-count-field"
+const extTfPaginationTotalHeader = "x-terraform-pagination-total-header"
+const extTfPaginationNextCursorHeader = "x-terraform-pagination-next-cursor-header"
+
+const (
+	// fieldNameCollisionsError fails the schema creation if the terraform compliant names of two or more properties collide
+	fieldNameCollisionsError = "error"
+	// fieldNameCollisionsSuffix suffixes the terraform compliant names of the colliding properties with a sequence number
+	fieldNameCollisionsSuffix = "suffix"
+)
+
+// reservedTerraformAttributeNames contains the names reserved by Terraform for the resource meta-arguments, which can not
+// be used as resource attribute names
+var reservedTerraformAttributeNames = map[string]struct{}{
+	"connection":  {},
+	"count":       {},
+	"depends_on":  {},
+	"for_each":    {},
+	"lifecycle":   {},
+	"provider":    {},
+	"provisioner": {},
+}
+
+// reservedAttributeNameSuffix is appended to the names of the properties using reserved terraform attribute names
+const reservedAttributeNameSuffix = "_value"
+
+// defaultFieldsParameter defines the query parameter used to request a subset of the resource fields if the operation
+// does not specify the x-terraform-fields-parameter extension
+const defaultFieldsParameter = "fields"
+
+// defaultBatchReadTTL defines how long the collection fetched when batch read is enabled is used to serve individual reads
+var defaultBatchReadTTL = time.Duration(30 * time.Second)
 
 // SpecV2Resource defines a struct that implements the SpecResource interface and it's based on OpenAPI v2 specification
 type SpecV2Resource struct {
@@ -87,7 +167,9 @@ type SpecV2Resource struct {
 	Paths map[string]spec.PathItem
 
 	// Cached objects that are loaded once (when the corresponding function that loads the object is called the first time) and
-	// on subsequent method calls the cached object is returned instead saving executing time.
+	// on subsequent method calls the cached object is returned instead saving executing time. The cached objects are guarded
+	// by cacheMutex since the resources are shared by the CRUD operations and schema lookups that terraform runs concurrently.
+	cacheMutex sync.RWMutex
 
 	// specSchemaDefinitionCached is cached in GetResourceSchema() method
 	specSchemaDefinitionCached *SpecSchemaDefinition
@@ -95,6 +177,9 @@ type SpecV2Resource struct {
 	parentResourceInfoCached *ParentResourceInfo
 	// resolvedPathCached is cached in getResourcePath() method
 	resolvedPathCached string
+
+	// namingVersion defines the version of the naming algorithm the resource name is built with; zero means the current version
+	namingVersion int
 }
 
 // newSpecV2Resource creates a SpecV2Resource with no region and default host
@@ -166,6 +251,20 @@ func (o *SpecV2Resource) buildResourceName() (string, error) {
 	return fullResourceName, nil
 }
 
+// getResourceNameForNamingVersion returns the name of the resource built with the given version of the naming algorithm
+func (o *SpecV2Resource) getResourceNameForNamingVersion(namingVersion int) (string, error) {
+	if !isResourceNamingVersionSupported(namingVersion) {
+		return "", fmt.Errorf("resource naming version %d not supported", namingVersion)
+	}
+	resource := &SpecV2Resource{
+		Path:          o.Path,
+		RootPathItem:  o.RootPathItem,
+		Paths:         o.Paths,
+		namingVersion: namingVersion,
+	}
+	return resource.buildResourceName()
+}
+
 // buildResourceNameFromPath returns the name of the resource (including the version if applicable and using the preferred name
 // if provided). The name will be calculated using the last part of the path which is meant to be the resource name that the URI
 // refers to (e,g: /resource/{id}). If the path is versioned /v1/resource/{id} then the corresponding returned name will
@@ -183,7 +282,9 @@ func (o *SpecV2Resource) buildResourceNameFromPath(resourcePath, preferredName s
 		return "", fmt.Errorf("could not find a valid name for resource instance path '%s'", resourcePath)
 	}
 	resourceName = strings.Replace(matches[len(matches)-1], "/", "", -1)
-	resourceName = strings.ReplaceAll(resourceName, "-", "_")
+	if o.namingVersion != resourceNamingV1 {
+		resourceName = strings.ReplaceAll(resourceName, "-", "_")
+	}
 
 	versionRegex, _ := regexp.Compile(fmt.Sprintf(resourceVersionRegexTemplate, resourceName))
 
@@ -206,9 +307,12 @@ func (o *SpecV2Resource) buildResourceNameFromPath(resourcePath, preferredName s
 // resource path "/v1/cdns/{cdn_id}/v1/firewalls" and the []strin{"cdnID"} the returned path will be "/v1/cdns/cdnID/v1/firewalls".
 // If the resource path is not parameterised, then regular path will be returned accordingly
 func (o *SpecV2Resource) getResourcePath(parentIDs []string) (string, error) {
-	if o.resolvedPathCached != "" {
-		log.Printf("[DEBUG] getResourcePath hit the cache for '%s'", o.Name)
-		return o.resolvedPathCached, nil
+	o.cacheMutex.RLock()
+	resolvedPathCached := o.resolvedPathCached
+	o.cacheMutex.RUnlock()
+	if resolvedPathCached != "" {
+		analyserLog.Debug("getResourcePath hit the cache for '%s'", o.Name)
+		return resolvedPathCached, nil
 	}
 	resolvedPath := o.Path
 
@@ -217,8 +321,8 @@ func (o *SpecV2Resource) getResourcePath(parentIDs []string) (string, error) {
 
 	switch {
 	case len(pathParamsMatches) == 0:
-		o.resolvedPathCached = resolvedPath
-		log.Printf("[DEBUG] getResourcePath cache loaded for '%s'", o.Name)
+		o.setResolvedPathCached(resolvedPath)
+		analyserLog.Debug("getResourcePath cache loaded for '%s'", o.Name)
 		return resolvedPath, nil
 
 	case len(parentIDs) > len(pathParamsMatches):
@@ -236,11 +340,17 @@ func (o *SpecV2Resource) getResourcePath(parentIDs []string) (string, error) {
 		resolvedPath = strings.Replace(resolvedPath, pathParamsMatches[idx][1], parentIDs[idx], 1)
 	}
 
-	o.resolvedPathCached = resolvedPath
-	log.Printf("[DEBUG] getResourcePath cache loaded for '%s'", o.Name)
+	o.setResolvedPathCached(resolvedPath)
+	analyserLog.Debug("getResourcePath cache loaded for '%s'", o.Name)
 	return resolvedPath, nil
 }
 
+func (o *SpecV2Resource) setResolvedPathCached(resolvedPath string) {
+	o.cacheMutex.Lock()
+	defer o.cacheMutex.Unlock()
+	o.resolvedPathCached = resolvedPath
+}
+
 // getHost can return an empty host in which case the expectation is that the host used will be the one specified in the
 // swagger host attribute or if not present the host used will be the host where the swagger file was served
 func (o *SpecV2Resource) getHost() (string, error) {
@@ -255,12 +365,51 @@ func (o *SpecV2Resource) getResourceOperations() specResourceOperations {
 	return specResourceOperations{
 		List:   o.createResourceOperation(o.RootPathItem.Get),
 		Post:   o.createResourceOperation(o.RootPathItem.Post),
-		Get:    o.createResourceOperation(o.InstancePathItem.Get),
-		Put:    o.createResourceOperation(o.InstancePathItem.Put),
+		Get:    o.createResourceOperation(o.getReadOperation()),
+		Put:    o.createUpdateOperation(),
 		Delete: o.createResourceOperation(o.InstancePathItem.Delete),
 	}
 }
 
+// getReadOperation returns the operation used to read the resource instances: the GET operation of the read path if the
+// resource is configured with the 'x-terraform-read-path' extension; otherwise the instance path GET operation
+func (o *SpecV2Resource) getReadOperation() *spec.Operation {
+	if readPath := getResourceReadPath(o.RootPathItem.Post); readPath != "" {
+		if readPathItem, exists := o.Paths[readPath]; exists {
+			return readPathItem.Get
+		}
+	}
+	return o.InstancePathItem.Get
+}
+
+// getReadPath returns the path the resource instance is read from when it is not the resource instance path, as configured
+// in the root path POST operation 'x-terraform-read-path' extension (e,g: /v1/clusters/{cluster_id}). The last path
+// parameter is resolved with the instance id and the preceding ones (if any) with the closest parent ids. An empty path is
+// returned if the resource is not configured with a read path.
+func (o *SpecV2Resource) getReadPath(parentIDs []string, id string) (string, error) {
+	readPath := getResourceReadPath(o.RootPathItem.Post)
+	if readPath == "" {
+		return "", nil
+	}
+	if strings.Contains(id, "/") {
+		return "", fmt.Errorf("instance ID (%s) contains not supported characters (forward slashes)", id)
+	}
+	ids := append(append([]string{}, parentIDs...), id)
+	readPathParameterRegex, _ := regexp.Compile(readPathParameterRegex)
+	pathParams := readPathParameterRegex.FindAllString(readPath, -1)
+	if len(pathParams) == 0 || len(pathParams) > len(ids) {
+		return "", fmt.Errorf("could not resolve read path '%s' with the given ids: %s", readPath, ids)
+	}
+	ids = ids[len(ids)-len(pathParams):]
+	for idx, pathParam := range pathParams {
+		if strings.Contains(ids[idx], "/") {
+			return "", fmt.Errorf("could not resolve read path '%s' due to parent IDs (%s) containing not supported characters (forward slashes)", readPath, parentIDs)
+		}
+		readPath = strings.Replace(readPath, pathParam, ids[idx], 1)
+	}
+	return readPath, nil
+}
+
 // ShouldIgnoreResource checks whether the POST operation for a given resource as the 'x-terraform-exclude-resource' extension
 // defined with true value. If so, the resource will not be exposed to the OpenAPI Terraform provider; otherwise it will
 // be exposed and users will be able to manage such resource via terraform.
@@ -278,9 +427,12 @@ func (o *SpecV2Resource) ShouldIgnoreResource() bool {
 
 // GetParentResourceInfo returns the information about the parent resources
 func (o *SpecV2Resource) GetParentResourceInfo() *ParentResourceInfo {
-	if o.parentResourceInfoCached != nil {
-		log.Printf("[DEBUG] GetParentResourceInfo hit the cache for '%s'", o.Name)
-		return o.parentResourceInfoCached
+	o.cacheMutex.RLock()
+	parentResourceInfoCached := o.parentResourceInfoCached
+	o.cacheMutex.RUnlock()
+	if parentResourceInfoCached != nil {
+		analyserLog.Debug("GetParentResourceInfo hit the cache for '%s'", o.Name)
+		return parentResourceInfoCached
 	}
 	resourceParentRegex, _ := regexp.Compile(resourceParentNameRegex)
 	parentMatches := resourceParentRegex.FindAllStringSubmatch(o.Path, -1)
@@ -314,7 +466,7 @@ func (o *SpecV2Resource) GetParentResourceInfo() *ParentResourceInfo {
 			}
 			parentResourceName, err := o.buildResourceNameFromPath(parentURI, preferredParentName)
 			if err != nil {
-				log.Printf("[ERROR] could not build parent resource info due to the following error: %s", err)
+				analyserLog.Error("could not build parent resource info due to the following error: %s", err)
 				return nil //untested
 			}
 			parentResourceNames = append(parentResourceNames, parentResourceName)
@@ -328,8 +480,10 @@ func (o *SpecV2Resource) GetParentResourceInfo() *ParentResourceInfo {
 			parentURIs:             parentURIs,
 			parentInstanceURIs:     parentInstanceURIs,
 		}
+		o.cacheMutex.Lock()
 		o.parentResourceInfoCached = sub
-		log.Printf("[DEBUG] GetParentResourceInfo cache loaded for '%s'", o.Name)
+		o.cacheMutex.Unlock()
+		analyserLog.Debug("GetParentResourceInfo cache loaded for '%s'", o.Name)
 		return sub
 	}
 	return nil
@@ -337,16 +491,25 @@ func (o *SpecV2Resource) GetParentResourceInfo() *ParentResourceInfo {
 
 // GetResourceSchema returns the resource schema
 func (o *SpecV2Resource) GetResourceSchema() (*SpecSchemaDefinition, error) {
-	if o.specSchemaDefinitionCached != nil {
-		log.Printf("[DEBUG] GetResourceSchema hit the cache for '%s'", o.Name)
-		return o.specSchemaDefinitionCached, nil
+	o.cacheMutex.RLock()
+	specSchemaDefinitionCached := o.specSchemaDefinitionCached
+	o.cacheMutex.RUnlock()
+	if specSchemaDefinitionCached != nil {
+		analyserLog.Debug("GetResourceSchema hit the cache for '%s'", o.Name)
+		return specSchemaDefinitionCached, nil
 	}
 	specSchemaDefinition, err := o.getSchemaDefinitionWithOptions(&o.SchemaDefinition, true)
 	if err != nil {
 		return nil, err
 	}
-	o.specSchemaDefinitionCached = specSchemaDefinition
-	log.Printf("[DEBUG] GetResourceSchema cache loaded for '%s'", o.Name)
+	o.cacheMutex.Lock()
+	defer o.cacheMutex.Unlock()
+	// another goroutine may have loaded the cache in the meantime, in which case that schema definition is kept so all
+	// the callers share the same object
+	if o.specSchemaDefinitionCached == nil {
+		o.specSchemaDefinitionCached = specSchemaDefinition
+		analyserLog.Debug("GetResourceSchema cache loaded for '%s'", o.Name)
+	}
 	return o.specSchemaDefinitionCached, nil
 }
 
@@ -360,6 +523,7 @@ func (o *SpecV2Resource) getSchemaDefinitionWithOptions(schema *spec.Schema, add
 	}
 	schemaDefinition := &SpecSchemaDefinition{}
 	schemaDefinition.Properties = SpecSchemaDefinitionProperties{}
+	schemaDefinition.Description = schema.Description
 
 	// This map ensures no duplicates will happen if the schema happens to have a parent id property. if so, it will be overridden with the expected parent property configuration (e,g: making the prop required)
 	schemaProps := map[string]*SpecSchemaDefinitionProperty{}
@@ -370,7 +534,12 @@ func (o *SpecV2Resource) getSchemaDefinitionWithOptions(schema *spec.Schema, add
 		}
 		schemaProps[propertyName] = schemaDefinitionProperty
 	}
+	// addParentProps is only enabled for the root schema of the resource, which is the one whose attributes are exposed at the
+	// top level of the terraform resource
 	if addParentProps {
+		if err := o.renameReservedProperties(schemaProps); err != nil {
+			return nil, err
+		}
 		parentResourceInfo := o.GetParentResourceInfo()
 		if parentResourceInfo != nil {
 			parentPropertyNames := parentResourceInfo.GetParentPropertiesNames()
@@ -382,12 +551,182 @@ func (o *SpecV2Resource) getSchemaDefinitionWithOptions(schema *spec.Schema, add
 		}
 	}
 
+	if err := o.resolvePropertyNameCollisions(schema, schemaProps); err != nil {
+		return nil, err
+	}
+	if err := o.validateAttributeAliases(schemaProps, addParentProps); err != nil {
+		return nil, err
+	}
+	if err := o.validateResolveByNameAttributes(schemaProps, addParentProps); err != nil {
+		return nil, err
+	}
+
 	for _, property := range schemaProps {
 		schemaDefinition.Properties = append(schemaDefinition.Properties, property)
 	}
+	schemaDefinition.buildPropertyIndex()
 	return schemaDefinition, nil
 }
 
+// renameReservedProperties renames the properties whose terraform compliant names are reserved by Terraform (e,g: count or
+// lifecycle) appending the _value suffix (e,g: count_value), so specs using such names can be used without upstream changes.
+// The id property is renamed too (id_value) if it is not the resource identifier (another property has the x-terraform-id
+// extension) since the id attribute is reserved for the resource identifier. The x-terraform-field-name extension can be
+// used to give the properties a different name
+func (o *SpecV2Resource) renameReservedProperties(schemaProps map[string]*SpecSchemaDefinitionProperty) error {
+	hasIdentifierProperty := false
+	for _, property := range schemaProps {
+		if property.IsIdentifier && !property.isPropertyNamedID() {
+			hasIdentifierProperty = true
+		}
+	}
+	for propertyName, property := range schemaProps {
+		terraformName := property.GetTerraformCompliantPropertyName()
+		_, reserved := reservedTerraformAttributeNames[terraformName]
+		if !reserved && !(hasIdentifierProperty && terraformName == idDefaultPropertyName) {
+			continue
+		}
+		if property.PreferredName != "" {
+			return fmt.Errorf("property '%s' %s extension not valid: '%s' is a reserved terraform attribute name", propertyName, extTfFieldName, property.PreferredName)
+		}
+		property.PreferredName = terraformName + reservedAttributeNameSuffix
+		analyserLog.Warn("property '%s' of resource '%s' uses the reserved terraform attribute name '%s', it has been renamed to '%s'", propertyName, o.Name, terraformName, property.PreferredName)
+	}
+	return nil
+}
+
+// resolvePropertyNameCollisions makes sure the terraform compliant names of the properties are unique, otherwise the
+// colliding properties (e,g: Name and name) would override each other in the terraform schema. By default, the collisions
+// fail the schema creation so the x-terraform-field-name extension is used to give the properties unique names; if the
+// schema has the x-terraform-field-name-collisions extension set to suffix, the colliding properties are renamed instead
+// keeping the terraform name for the property whose name matches it (e,g: name, name_2)
+func (o *SpecV2Resource) resolvePropertyNameCollisions(schema *spec.Schema, schemaProps map[string]*SpecSchemaDefinitionProperty) error {
+	propertyNamesByTerraformName := map[string][]string{}
+	for propertyName, property := range schemaProps {
+		terraformName := property.GetTerraformCompliantPropertyName()
+		propertyNamesByTerraformName[terraformName] = append(propertyNamesByTerraformName[terraformName], propertyName)
+	}
+	var collisions []string
+	for terraformName, propertyNames := range propertyNamesByTerraformName {
+		if len(propertyNames) > 1 {
+			collisions = append(collisions, terraformName)
+		}
+	}
+	if len(collisions) == 0 {
+		return nil
+	}
+	sort.Strings(collisions)
+
+	strategy := fieldNameCollisionsError
+	if value, exists := schema.Extensions.GetString(extTfFieldNameCollisions); exists {
+		strategy = value
+	}
+	switch strategy {
+	case fieldNameCollisionsError:
+		propertyNames := propertyNamesByTerraformName[collisions[0]]
+		sort.Strings(propertyNames)
+		return fmt.Errorf("properties '%s' are all converted into the terraform name '%s': the %s extension is required to give them unique names (or set the %s extension of the schema to '%s')", strings.Join(propertyNames, "', '"), collisions[0], extTfFieldName, extTfFieldNameCollisions, fieldNameCollisionsSuffix)
+	case fieldNameCollisionsSuffix:
+	default:
+		return fmt.Errorf("%s extension value '%s' not supported, supported values are [%s, %s]", extTfFieldNameCollisions, strategy, fieldNameCollisionsError, fieldNameCollisionsSuffix)
+	}
+
+	for _, terraformName := range collisions {
+		propertyNames := propertyNamesByTerraformName[terraformName]
+		// the parent properties and the property whose name matches the terraform name keep the terraform name
+		sort.Slice(propertyNames, func(i, j int) bool {
+			pi, pj := schemaProps[propertyNames[i]], schemaProps[propertyNames[j]]
+			if pi.IsParentProperty != pj.IsParentProperty {
+				return pi.IsParentProperty
+			}
+			if (propertyNames[i] == terraformName) != (propertyNames[j] == terraformName) {
+				return propertyNames[i] == terraformName
+			}
+			return propertyNames[i] < propertyNames[j]
+		})
+		sequence := 2
+		for _, propertyName := range propertyNames[1:] {
+			preferredName := fmt.Sprintf("%s_%d", terraformName, sequence)
+			for _, taken := propertyNamesByTerraformName[preferredName]; taken; _, taken = propertyNamesByTerraformName[preferredName] {
+				sequence++
+				preferredName = fmt.Sprintf("%s_%d", terraformName, sequence)
+			}
+			sequence++
+			propertyNamesByTerraformName[preferredName] = []string{propertyName}
+			schemaProps[propertyName].PreferredName = preferredName
+			analyserLog.Warn("property '%s' of resource '%s' collides with property '%s' (terraform name '%s'), it has been renamed to '%s'", propertyName, o.Name, propertyNames[0], terraformName, preferredName)
+		}
+	}
+	return nil
+}
+
+// validateAttributeAliases makes sure the attribute aliases (x-terraform-attribute-aliases) are only used on the top level
+// properties of the resource and are valid terraform attribute names not taken by any other property (or alias)
+func (o *SpecV2Resource) validateAttributeAliases(schemaProps map[string]*SpecSchemaDefinitionProperty, topLevel bool) error {
+	terraformNames := map[string]string{}
+	for propertyName, property := range schemaProps {
+		terraformNames[property.GetTerraformCompliantPropertyName()] = propertyName
+	}
+	propertyNames := make([]string, 0, len(schemaProps))
+	for propertyName := range schemaProps {
+		propertyNames = append(propertyNames, propertyName)
+	}
+	sort.Strings(propertyNames)
+	for _, propertyName := range propertyNames {
+		for _, alias := range schemaProps[propertyName].AttributeAliases {
+			if !topLevel {
+				return fmt.Errorf("property '%s' %s extension not valid: the extension is only supported on the top level properties of the resource", propertyName, extTfAttributeAliases)
+			}
+			if _, reserved := reservedTerraformAttributeNames[alias]; reserved || alias == idDefaultPropertyName || !attributeAliasRegex.MatchString(alias) {
+				return fmt.Errorf("property '%s' %s extension not valid: '%s' is not a valid terraform attribute name", propertyName, extTfAttributeAliases, alias)
+			}
+			if takenBy, taken := terraformNames[alias]; taken {
+				return fmt.Errorf("property '%s' %s extension not valid: '%s' is already used by property '%s'", propertyName, extTfAttributeAliases, alias, takenBy)
+			}
+			terraformNames[alias] = propertyName
+		}
+	}
+	return nil
+}
+
+// validateResolveByNameAttributes makes sure the properties resolved by name (x-terraform-resolve-by-name) and the upload
+// properties (x-terraform-upload-property) are top level properties of the resource and the attributes the names (or file
+// paths) are configured with are valid terraform attribute names not taken by any other property (or alias)
+func (o *SpecV2Resource) validateResolveByNameAttributes(schemaProps map[string]*SpecSchemaDefinitionProperty, topLevel bool) error {
+	terraformNames := map[string]string{}
+	for propertyName, property := range schemaProps {
+		terraformNames[property.GetTerraformCompliantPropertyName()] = propertyName
+		for _, alias := range property.AttributeAliases {
+			terraformNames[alias] = propertyName
+		}
+	}
+	propertyNames := make([]string, 0, len(schemaProps))
+	for propertyName := range schemaProps {
+		propertyNames = append(propertyNames, propertyName)
+	}
+	sort.Strings(propertyNames)
+	for _, propertyName := range propertyNames {
+		attribute, extension := schemaProps[propertyName].getResolveByNameAttribute(), extTfResolveByName
+		if attribute == "" {
+			attribute, extension = schemaProps[propertyName].getUploadAttribute(), extTfUploadProperty
+		}
+		if attribute == "" {
+			continue
+		}
+		if !topLevel {
+			return fmt.Errorf("property '%s' %s extension not valid: the extension is only supported on the top level properties of the resource", propertyName, extension)
+		}
+		if _, reserved := reservedTerraformAttributeNames[attribute]; reserved || attribute == idDefaultPropertyName || !attributeAliasRegex.MatchString(attribute) {
+			return fmt.Errorf("property '%s' %s extension not valid: '%s' is not a valid terraform attribute name", propertyName, extension, attribute)
+		}
+		if takenBy, taken := terraformNames[attribute]; taken {
+			return fmt.Errorf("property '%s' %s extension not valid: '%s' is already used by property '%s'", propertyName, extension, attribute, takenBy)
+		}
+		terraformNames[attribute] = propertyName
+	}
+	return nil
+}
+
 func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, property spec.Schema, requiredProperties []string) (*SpecSchemaDefinitionProperty, error) {
 	schemaDefinitionProperty := &SpecSchemaDefinitionProperty{}
 
@@ -408,7 +747,11 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 			return nil, err
 		}
 		schemaDefinitionProperty.SpecSchemaDefinition = objectSchemaDefinition
-		log.Printf("[DEBUG] found object type property '%s'", propertyName)
+		// Object properties referring to other definitions ($ref) are documented in the definition referred to
+		if schemaDefinitionProperty.Description == "" {
+			schemaDefinitionProperty.Description = objectSchemaDefinition.Description
+		}
+		analyserLog.Debug("found object type property '%s'", propertyName)
 	} else if isArray, itemsType, itemsSchema, err := o.isArrayProperty(property); isArray || err != nil {
 		if err != nil {
 			return nil, fmt.Errorf("failed to process array type property '%s': %s", propertyName, err)
@@ -426,14 +769,37 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 			}
 		}
 
+		// Arrays of objects referring to other definitions ($ref) are documented in the definition referred to
+		if schemaDefinitionProperty.Description == "" && itemsSchema != nil {
+			schemaDefinitionProperty.Description = itemsSchema.Description
+		}
+
 		schemaDefinitionProperty.ArrayItemsType = itemsType
 		schemaDefinitionProperty.SpecSchemaDefinition = itemsSchema // only diff than nil if type is object
 
 		if o.isBoolExtensionEnabled(property.Extensions, extTfIgnoreOrder) || o.isBoolExtensionEnabled(property.Extensions, extIgnoreOrder) {
 			schemaDefinitionProperty.IgnoreItemsOrder = true
 		}
+		if o.isBoolExtensionEnabled(property.Extensions, extTfOrdered) {
+			if schemaDefinitionProperty.IgnoreItemsOrder {
+				return nil, fmt.Errorf("failed to process array type property '%s': %s and %s extensions are mutually exclusive", propertyName, extTfOrdered, extTfIgnoreOrder)
+			}
+			schemaDefinitionProperty.Ordered = true
+		}
+		// the items appended by the server (e,g: system managed items) are treated as computed, only the items declared
+		// by the user are stored in the state
+		if o.isBoolExtensionEnabled(property.Extensions, extTfIgnoreServerItems) {
+			schemaDefinitionProperty.IgnoreServerItems = true
+		}
+		schemaDefinitionProperty.UniqueItems = property.UniqueItems
+		if property.MinItems != nil {
+			schemaDefinitionProperty.MinItems = int(*property.MinItems)
+		}
+		if property.MaxItems != nil {
+			schemaDefinitionProperty.MaxItems = int(*property.MaxItems)
+		}
 
-		log.Printf("[DEBUG] found array type property '%s' with items of type '%s'", propertyName, itemsType)
+		analyserLog.Debug("found array type property '%s' with items of type '%s'", propertyName, itemsType)
 	}
 
 	if preferredPropertyName, exists := property.Extensions.GetString(extTfFieldName); exists {
@@ -479,6 +845,37 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 		schemaDefinitionProperty.Sensitive = true
 	}
 
+	// An encrypt in state property means that the value returned by the API is encrypted before being stored in the
+	// state file, so secrets the API insists on returning are not stored in plaintext
+	if o.isBoolExtensionEnabled(property.Extensions, extTfEncryptInState) {
+		schemaDefinitionProperty.EncryptInState = true
+	}
+
+	// A fingerprint in state property means that the value returned by the API is replaced with its fingerprint in the
+	// state file, so changes of the value (e,g: secret rotated) are detected without storing the value
+	if o.isBoolExtensionEnabled(property.Extensions, extTfFingerprintInState) {
+		schemaDefinitionProperty.FingerprintInState = true
+	}
+
+	// A coerce property means that the string values returned by the API (e,g: "true" or "123") are converted into the
+	// type declared for the property when stored in the state, supporting APIs that return the values stringified
+	if o.isBoolExtensionEnabled(property.Extensions, extTfCoerce) {
+		if isListOfPrimitives, _ := schemaDefinitionProperty.isTerraformListOfSimpleValues(); !schemaDefinitionProperty.isPrimitiveProperty() && !isListOfPrimitives {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: the extension is only supported on primitive properties and arrays of primitives", propertyName, extTfCoerce)
+		}
+		schemaDefinitionProperty.Coerce = true
+	}
+
+	if o.isBoolExtensionEnabled(property.Extensions, extTfIgnoreServerItems) && !schemaDefinitionProperty.isArrayProperty() {
+		return nil, fmt.Errorf("property '%s' %s extension not valid: the extension is only supported on array properties", propertyName, extTfIgnoreServerItems)
+	}
+
+	// The server changes of the property are ignored once the property has a value in the state, supporting attributes
+	// that are owned by the API after creation (e,g: node counts managed by auto-scaling)
+	if o.isBoolExtensionEnabled(property.Extensions, extTfIgnoreServerChanges) {
+		schemaDefinitionProperty.IgnoreServerChanges = true
+	}
+
 	// field with extTfID metadata takes preference over 'id' fields as the service provider is the one acknowledging
 	// the fact that this field should be used as identifier of the resource
 	if o.isBoolExtensionEnabled(property.Extensions, extTfID) {
@@ -493,14 +890,156 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 		schemaDefinitionProperty.IsStatusIdentifier = true
 	}
 
+	// The validator is applied to the value configured by the user at plan time
+	if validator, exists := property.Extensions.GetString(extTfValidator); exists {
+		schemaDefinitionProperty.Validator = validator
+	}
+
+	// The format is used to compare the values semantically (e,g: cidr), the extension takes preference over the format
+	// attribute as the latter is limited to the formats defined in the OpenAPI specification
+	schemaDefinitionProperty.Format = property.Format
+	if schemaDefinitionProperty.isArrayProperty() && property.Items != nil && property.Items.Schema != nil {
+		schemaDefinitionProperty.Format = property.Items.Schema.Format
+	}
+	if format, exists := property.Extensions.GetString(extTfFormat); exists {
+		schemaDefinitionProperty.Format = format
+	}
+
 	// Use the default keyword in the parameter schema to specify the default value for an optional parameter. The default
 	// value is the one that the server uses if the client does not supply the parameter value in the request.
 	// Link: https://swagger.io/docs/specification/describing-parameters#default
 	schemaDefinitionProperty.Default = property.Default
 
+	// The example value is used to generate the payloads of the smoke tests. The x-terraform-example extension takes
+	// preference over the example keyword so spec authors can provide a value that is valid to create the resource
+	schemaDefinitionProperty.Example = property.Example
+	if example, exists := property.Extensions[extTfExample]; exists {
+		schemaDefinitionProperty.Example = example
+	}
+
+	schemaDefinitionProperty.Enum = property.Enum
+	if schemaDefinitionProperty.isArrayProperty() && property.Items != nil && property.Items.Schema != nil {
+		schemaDefinitionProperty.Enum = property.Items.Schema.Enum
+	}
+
+	// The aliases are the previous names of the identifier property, so payloads returned by API versions using the previous
+	// names can still be identified (e,g: id renamed to uuid)
+	if aliases, exists := property.Extensions.GetStringSlice(extTfIDAliases); exists {
+		if !schemaDefinitionProperty.IsIdentifier && !schemaDefinitionProperty.isPropertyNamedID() {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: the extension is only supported on the resource identifier property", propertyName, extTfIDAliases)
+		}
+		schemaDefinitionProperty.IdentifierAliases = aliases
+	}
+
+	// The attribute aliases are the previous terraform names of the property, so configurations using the names the property
+	// had before being renamed in the spec keep working
+	if aliases, exists := property.Extensions.GetStringSlice(extTfAttributeAliases); exists {
+		if schemaDefinitionProperty.isReadOnly() {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: readOnly properties can not be configured", propertyName, extTfAttributeAliases)
+		}
+		schemaDefinitionProperty.AttributeAliases = aliases
+	}
+
+	// The update strategy defines how the changes of array properties are sent to the API when updating the resource, some
+	// APIs do not support updating the whole collection and expose sub-endpoints to add and remove the items instead
+	if updateStrategy, exists := property.Extensions.GetString(extTfUpdateStrategy); exists {
+		if err := schemaDefinitionProperty.validateUpdateStrategy(updateStrategy); err != nil {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: %s", propertyName, extTfUpdateStrategy, err)
+		}
+		schemaDefinitionProperty.UpdateStrategy = updateStrategy
+	}
+
+	// The reference properties resolved by name enable users to configure the human readable name of the referenced resource
+	// instead of its ID, which is looked up by the provider when applying the changes
+	if value, exists := property.Extensions[extTfResolveByName]; exists {
+		if schemaDefinitionProperty.Type != TypeString || schemaDefinitionProperty.isReadOnly() {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: the extension is only supported on configurable string properties", propertyName, extTfResolveByName)
+		}
+		if len(schemaDefinitionProperty.AttributeAliases) > 0 {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: the extension can not be combined with the %s extension", propertyName, extTfResolveByName, extTfAttributeAliases)
+		}
+		resolveByName, err := o.getResolveByName(value)
+		if err != nil {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: %s", propertyName, extTfResolveByName, err)
+		}
+		schemaDefinitionProperty.ResolveByName = resolveByName
+	}
+
+	// The upload properties enable users to configure the path of a local file instead of the key of the object stored in
+	// the object storage, the file being uploaded by the provider using a signed upload URL requested to the API
+	if value, exists := property.Extensions[extTfUploadProperty]; exists {
+		if schemaDefinitionProperty.Type != TypeString || schemaDefinitionProperty.isReadOnly() {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: the extension is only supported on configurable string properties", propertyName, extTfUploadProperty)
+		}
+		if len(schemaDefinitionProperty.AttributeAliases) > 0 {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: the extension can not be combined with the %s extension", propertyName, extTfUploadProperty, extTfAttributeAliases)
+		}
+		if schemaDefinitionProperty.ResolveByName != nil {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: the extension can not be combined with the %s extension", propertyName, extTfUploadProperty, extTfResolveByName)
+		}
+		upload, err := o.getUpload(value)
+		if err != nil {
+			return nil, fmt.Errorf("property '%s' %s extension not valid: %s", propertyName, extTfUploadProperty, err)
+		}
+		schemaDefinitionProperty.Upload = upload
+	}
+
 	return schemaDefinitionProperty, nil
 }
 
+// getUpload returns the upload defined in the x-terraform-upload-property extension, which is either the path of the
+// endpoint the signed upload URL is requested from (e,g: /v1/uploads) or an object containing the path along with the
+// optional file_attribute, url_field (defaults to upload_url) and key_field (defaults to key)
+func (o *SpecV2Resource) getUpload(value interface{}) (*specUpload, error) {
+	upload := &specUpload{urlField: "upload_url", keyField: "key"}
+	switch v := value.(type) {
+	case string:
+		upload.path = v
+	case map[string]interface{}:
+		upload.path, _ = v["path"].(string)
+		upload.attribute, _ = v["file_attribute"].(string)
+		if urlField, ok := v["url_field"].(string); ok && urlField != "" {
+			upload.urlField = urlField
+		}
+		if keyField, ok := v["key_field"].(string); ok && keyField != "" {
+			upload.keyField = keyField
+		}
+	default:
+		return nil, fmt.Errorf("the value must be the upload path or an object")
+	}
+	if upload.path == "" {
+		return nil, fmt.Errorf("the upload path is mandatory")
+	}
+	return upload, nil
+}
+
+// getResolveByName returns the lookup defined in the x-terraform-resolve-by-name extension, which is either the path of the
+// collection endpoint (e,g: /v1/vpcs) or an object containing the path along with the optional name_attribute, name_field
+// (defaults to name), id_field (defaults to id) and items_field
+func (o *SpecV2Resource) getResolveByName(value interface{}) (*specResolveByName, error) {
+	resolveByName := &specResolveByName{nameField: "name", idField: "id"}
+	switch v := value.(type) {
+	case string:
+		resolveByName.path = v
+	case map[string]interface{}:
+		resolveByName.path, _ = v["path"].(string)
+		resolveByName.attribute, _ = v["name_attribute"].(string)
+		resolveByName.itemsField, _ = v["items_field"].(string)
+		if nameField, ok := v["name_field"].(string); ok && nameField != "" {
+			resolveByName.nameField = nameField
+		}
+		if idField, ok := v["id_field"].(string); ok && idField != "" {
+			resolveByName.idField = idField
+		}
+	default:
+		return nil, fmt.Errorf("the value must be the lookup path or an object")
+	}
+	if resolveByName.path == "" {
+		return nil, fmt.Errorf("the lookup path is mandatory")
+	}
+	return resolveByName, nil
+}
+
 func (o *SpecV2Resource) isBoolExtensionEnabled(extensions spec.Extensions, extension string) bool {
 	if extensions != nil {
 		if enabled, ok := extensions.GetBool(extension); ok && enabled {
@@ -702,10 +1241,314 @@ func (o *SpecV2Resource) createResourceOperation(operation *spec.Operation) *spe
 	headerParameters := getHeaderConfigurations(operation.Parameters)
 	securitySchemes := createSecuritySchemes(operation.Security)
 	return &specResourceOperation{
-		HeaderParameters: headerParameters,
-		SecuritySchemes:  securitySchemes,
-		responses:        o.createResponses(operation),
+		HeaderParameters:         headerParameters,
+		SecuritySchemes:          securitySchemes,
+		responses:                o.createResponses(operation),
+		isConditionalGetEnabled:  o.isBoolExtensionEnabled(operation.Extensions, extTfResourceConditionalGet),
+		grpcMethod:               o.getGRPCMethod(operation),
+		grpcPathParameters:       getPathParameterNames(operation.Parameters),
+		graphQL:                  o.getGraphQLOperation(operation),
+		middlewares:              o.getOperationMiddlewares(operation),
+		revisionProperty:         o.getExtensionStringValue(operation.Extensions, extTfRevisionProperty),
+		fieldsParameter:          o.getFieldsParameter(operation),
+		fields:                   o.getFields(operation),
+		fieldsFromSchema:         o.isBoolExtensionEnabled(operation.Extensions, extTfFields),
+		userAgent:                o.getExtensionStringValue(operation.Extensions, extTfUserAgent),
+		isDetailFetchEnabled:     o.isBoolExtensionEnabled(operation.Extensions, extTfDataSourceDetailFetch),
+		forceApplyParameter:      o.getExtensionStringValue(operation.Extensions, extTfForceApplyParameter),
+		skipUnchangedUpdate:      o.isBoolExtensionEnabled(operation.Extensions, extTfSkipUnchangedUpdate),
+		requestTimeout:           o.getRequestTimeout(operation),
+		recordProvisioningEvents: o.isBoolExtensionEnabled(operation.Extensions, extTfResourceProvisioningEvents),
+		waitFor:                  o.isBoolExtensionEnabled(operation.Extensions, extTfWaitFor),
+		updateStrategy:           o.getOperationUpdateStrategy(operation),
+		precheck:                 o.getPrecheck(operation),
+		lock:                     o.getLock(operation),
+		consistencyToken:         o.getConsistencyToken(operation),
+		retry:                    o.getRetry(operation),
+		optionsPath:              o.getExtensionStringValue(operation.Extensions, extTfOptionsPath),
+		count:                    o.getCount(operation),
+		pagination:               o.getPagination(operation),
+	}
+}
+
+// getPagination returns the response headers containing the pagination metadata as defined in the
+// x-terraform-pagination-total-header and x-terraform-pagination-next-cursor-header extensions; nil is returned if the
+// operation does not have any of the extensions
+func (o *SpecV2Resource) getPagination(operation *spec.Operation) *specPagination {
+	pagination := &specPagination{
+		totalHeader:      o.getExtensionStringValue(operation.Extensions, extTfPaginationTotalHeader),
+		nextCursorHeader: o.getExtensionStringValue(operation.Extensions, extTfPaginationNextCursorHeader),
+	}
+	if pagination.totalHeader == "" && pagination.nextCursorHeader == "" {
+		return nil
+	}
+	return pagination
+}
+
+// getCount returns the count request performed by the count data source as defined in the x-terraform-count-path extension
+// along with the optional x-terraform-count-field extension; nil is returned if the operation does not have the
+// x-terraform-count-path extension
+func (o *SpecV2Resource) getCount(operation *spec.Operation) *specCount {
+	path := o.getExtensionStringValue(operation.Extensions, extTfCountPath)
+	if path == "" {
+		return nil
+	}
+	count := &specCount{
+		path:  path,
+		field: o.getExtensionStringValue(operation.Extensions, extTfCountField),
+	}
+	if count.field == "" {
+		count.field = defaultCountField
+	}
+	return count
+}
+
+// getPrecheck returns the precheck request performed before creating the resource as defined in the x-terraform-precheck-path
+// extension along with the optional x-terraform-precheck-field and x-terraform-precheck-message-field extensions; nil is
+// returned if the operation does not have the x-terraform-precheck-path extension
+func (o *SpecV2Resource) getPrecheck(operation *spec.Operation) *specPrecheck {
+	path := o.getExtensionStringValue(operation.Extensions, extTfPrecheckPath)
+	if path == "" {
+		return nil
+	}
+	precheck := &specPrecheck{
+		path:         path,
+		field:        o.getExtensionStringValue(operation.Extensions, extTfPrecheckField),
+		messageField: o.getExtensionStringValue(operation.Extensions, extTfPrecheckMessageField),
+	}
+	if precheck.messageField == "" {
+		precheck.messageField = defaultPrecheckMessageField
+	}
+	return precheck
+}
+
+// getLock returns the lock acquired before performing the operation as defined in the x-terraform-lock-path extension; nil
+// is returned if the operation does not have the extension
+func (o *SpecV2Resource) getLock(operation *spec.Operation) *specLock {
+	path := o.getExtensionStringValue(operation.Extensions, extTfLockPath)
+	if path == "" {
+		return nil
+	}
+	return &specLock{path: path}
+}
+
+// getOperationUpdateStrategy returns the update strategy of the operation as defined in the x-terraform-update-strategy
+// extension; empty is returned if the operation does not have the extension or the extension is not valid
+func (o *SpecV2Resource) getOperationUpdateStrategy(operation *spec.Operation) string {
+	updateStrategy := o.getExtensionStringValue(operation.Extensions, extTfUpdateStrategy)
+	if updateStrategy == "" {
+		return ""
+	}
+	if updateStrategy != operationUpdateStrategyMergePatch && updateStrategy != operationUpdateStrategyJSONPatch {
+		analyserLog.Warn("resource '%s' %s extension not valid, ignoring it: update strategy '%s' not supported, the supported update strategies are '%s' and '%s'", o.Name, extTfUpdateStrategy, updateStrategy, operationUpdateStrategyMergePatch, operationUpdateStrategyJSONPatch)
+		return ""
+	}
+	return updateStrategy
+}
+
+// getUpdateOperation returns the operation the resource updates are performed with: the instance path PUT operation or,
+// if the instance path does not have a PUT operation, the PATCH operation as long as it consumes JSON Patch documents
+// (application/json-patch+json). Nil is returned if the resource can not be updated
+func (o *SpecV2Resource) getUpdateOperation() *spec.Operation {
+	if o.InstancePathItem.Put == nil && isJSONPatchOperation(o.InstancePathItem.Patch) {
+		return o.InstancePathItem.Patch
+	}
+	return o.InstancePathItem.Put
+}
+
+// createUpdateOperation creates the resource update operation (see getUpdateOperation). The updates are sent as JSON Patch
+// documents if the update operation is the PATCH operation consuming them
+func (o *SpecV2Resource) createUpdateOperation() *specResourceOperation {
+	operation := o.getUpdateOperation()
+	updateOperation := o.createResourceOperation(operation)
+	if updateOperation != nil && operation == o.InstancePathItem.Patch {
+		updateOperation.updateStrategy = operationUpdateStrategyJSONPatch
+	}
+	return updateOperation
+}
+
+// getConsistencyToken returns the consistency token returned by the operation as defined in the x-terraform-consistency-token
+// extension; nil is returned if the operation does not have the extension or the extension is not valid
+func (o *SpecV2Resource) getConsistencyToken(operation *spec.Operation) *specConsistencyToken {
+	value, exists := operation.Extensions[extTfConsistencyToken]
+	if !exists {
+		return nil
+	}
+	consistencyTokenConfig, ok := value.(map[string]interface{})
+	if !ok {
+		analyserLog.Warn("resource '%s' %s extension not valid, ignoring it: the value must be an object", o.Name, extTfConsistencyToken)
+		return nil
+	}
+	consistencyToken := &specConsistencyToken{}
+	consistencyToken.responseHeader, _ = consistencyTokenConfig["response_header"].(string)
+	consistencyToken.responseField, _ = consistencyTokenConfig["response_field"].(string)
+	consistencyToken.requestHeader, _ = consistencyTokenConfig["request_header"].(string)
+	consistencyToken.requestParameter, _ = consistencyTokenConfig["request_parameter"].(string)
+	if err := consistencyToken.validate(); err != nil {
+		analyserLog.Warn("resource '%s' %s extension not valid, ignoring it: %s", o.Name, extTfConsistencyToken, err)
+		return nil
+	}
+	return consistencyToken
+}
+
+// getRetry returns the retry settings defined in the x-terraform-retry extension of the operation, falling back to the
+// extension of the resource root path so the settings apply to all the resource operations; nil is returned if neither
+// has the extension or its value is not valid
+func (o *SpecV2Resource) getRetry(operation *spec.Operation) *specRetry {
+	value, exists := operation.Extensions[extTfRetry]
+	if !exists {
+		if value, exists = o.RootPathItem.Extensions[extTfRetry]; !exists {
+			return nil
+		}
+	}
+	retryConfig, ok := value.(map[string]interface{})
+	if !ok {
+		analyserLog.Warn("resource '%s' %s extension not valid, ignoring it: the value must be an object", o.Name, extTfRetry)
+		return nil
+	}
+	retry := &specRetry{}
+	if maxRetries, exists := retryConfig["max_retries"]; exists {
+		value, ok := getExtensionInt(maxRetries)
+		if !ok || value < 0 {
+			analyserLog.Warn("resource '%s' %s extension not valid, ignoring it: max_retries must be a positive integer or zero", o.Name, extTfRetry)
+			return nil
+		}
+		retry.maxRetries = &value
+	}
+	retry.retryWait, _ = retryConfig["retry_wait"].(string)
+	retry.maxRetryWait, _ = retryConfig["max_retry_wait"].(string)
+	if statusCodes, exists := retryConfig["retry_status_codes"]; exists {
+		values, _ := statusCodes.([]interface{})
+		for _, statusCode := range values {
+			value, ok := getExtensionInt(statusCode)
+			if !ok {
+				analyserLog.Warn("resource '%s' %s extension not valid, ignoring it: retry_status_codes must be a list of status codes", o.Name, extTfRetry)
+				return nil
+			}
+			retry.retryStatusCodes = append(retry.retryStatusCodes, value)
+		}
+	}
+	if err := retry.validate(); err != nil {
+		analyserLog.Warn("resource '%s' %s extension not valid, ignoring it: %s", o.Name, extTfRetry, err)
+		return nil
+	}
+	return retry
+}
+
+// getExtensionInt returns the integer value of the extension field passed in, which is decoded as a float64 when the
+// OpenAPI document is parsed
+func getExtensionInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		if v != float64(int(v)) {
+			return 0, false
+		}
+		return int(v), true
 	}
+	return 0, false
+}
+
+// getRequestTimeout returns the timeout of the HTTP requests performed by the operation as defined in the
+// x-terraform-request-timeout extension (e,g: 120s); nil is returned if the extension is not present or its value is not valid
+func (o *SpecV2Resource) getRequestTimeout(operation *spec.Operation) *time.Duration {
+	requestTimeout, err := o.getTimeDuration(operation.Extensions, extTfRequestTimeout)
+	if err != nil {
+		analyserLog.Warn("resource '%s' %s extension not valid, ignoring it: %s", o.Name, extTfRequestTimeout, err)
+		return nil
+	}
+	return requestTimeout
+}
+
+// getFields returns the names of the fields listed in the x-terraform-fields extension (comma separated list, e,g:
+// "id,label,status"); nil is returned if the operation does not have the extension or the extension is a boolean (in
+// which case the fields are derived from the resource schema)
+func (o *SpecV2Resource) getFields(operation *spec.Operation) []string {
+	value, exists := operation.Extensions.GetString(extTfFields)
+	if !exists {
+		return nil
+	}
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// getFieldsParameter returns the name of the query parameter used to request a subset of the resource fields
+// (x-terraform-fields-parameter); defaults to 'fields' if the operation does not have the extension
+func (o *SpecV2Resource) getFieldsParameter(operation *spec.Operation) string {
+	if fieldsParameter := o.getExtensionStringValue(operation.Extensions, extTfFieldsParameter); fieldsParameter != "" {
+		return fieldsParameter
+	}
+	return defaultFieldsParameter
+}
+
+// getOperationMiddlewares returns the names of the middlewares selected by the operation via the x-terraform-middleware
+// extension (comma separated list, e,g: "retry,logging"); nil is returned if the operation does not have the extension.
+// An empty value opts the operation out of all the middlewares
+func (o *SpecV2Resource) getOperationMiddlewares(operation *spec.Operation) []string {
+	value, exists := operation.Extensions.GetString(extTfMiddleware)
+	if !exists {
+		return nil
+	}
+	middlewares := []string{}
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			middlewares = append(middlewares, name)
+		}
+	}
+	return middlewares
+}
+
+// getGraphQLOperation returns the GraphQL operation defined in the x-terraform-graphql extension; nil is returned if the
+// operation does not have the extension. The extension value can be either the GraphQL document or an object containing
+// the document along with the endpoint and result path
+func (o *SpecV2Resource) getGraphQLOperation(operation *spec.Operation) *specGraphQLOperation {
+	value, exists := operation.Extensions[extTfGraphQL]
+	if !exists {
+		return nil
+	}
+	graphQLOperation := &specGraphQLOperation{endpoint: defaultGraphQLEndpoint}
+	switch graphQL := value.(type) {
+	case string:
+		graphQLOperation.document = graphQL
+	case map[string]interface{}:
+		graphQLOperation.document, _ = graphQL["document"].(string)
+		if endpoint, ok := graphQL["endpoint"].(string); ok && endpoint != "" {
+			graphQLOperation.endpoint = endpoint
+		}
+		graphQLOperation.resultPath, _ = graphQL["result_path"].(string)
+	}
+	return graphQLOperation
+}
+
+// getGRPCMethod returns the gRPC method the operation is transcoded to. The method can be specified explicitly via the
+// x-terraform-grpc-method extension (e,g: example.v1.CDNService/GetCDN); otherwise it's derived from the operation id
+// following the protoc-gen-openapiv2 naming convention (Service_Method). Empty is returned if the method can not be derived
+func (o *SpecV2Resource) getGRPCMethod(operation *spec.Operation) string {
+	if grpcMethod := o.getExtensionStringValue(operation.Extensions, extTfGRPCMethod); grpcMethod != "" {
+		return grpcMethod
+	}
+	service := strings.SplitN(operation.ID, "_", 2)
+	if len(service) != 2 || service[0] == "" || service[1] == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", service[0], service[1])
+}
+
+// getPathParameterNames returns the names of the path parameters in the order they are declared
+func getPathParameterNames(parameters []spec.Parameter) []string {
+	var names []string
+	for _, parameter := range parameters {
+		if parameter.In == "path" {
+			names = append(names, parameter.Name)
+		}
+	}
+	return names
 }
 
 func (o *SpecV2Resource) createResponses(operation *spec.Operation) specResponses {
@@ -715,6 +1558,7 @@ func (o *SpecV2Resource) createResponses(operation *spec.Operation) specResponse
 			isPollingEnabled:    o.isResourcePollingEnabled(response),
 			pollTargetStatuses:  o.getResourcePollTargetStatuses(response),
 			pollPendingStatuses: o.getResourcePollPendingStatuses(response),
+			pollRequest:         o.getResourcePollRequest(response),
 		}
 	}
 	return responses
@@ -738,6 +1582,34 @@ func (o *SpecV2Resource) getResourcePollPendingStatuses(response spec.Response)
 	return o.getPollingStatuses(response, extTfResourcePollPendingStatuses)
 }
 
+// getResourcePollRequest returns the custom request used to check the status of the resource when polling as defined in
+// the x-terraform-resource-poll-request extension; nil is returned if the extension is not present or its value is not
+// valid, in which case the resource is polled via the GET operation
+func (o *SpecV2Resource) getResourcePollRequest(response spec.Response) *specPollRequest {
+	value, exists := response.Extensions[extTfResourcePollRequest]
+	if !exists {
+		return nil
+	}
+	pollRequestConfig, ok := value.(map[string]interface{})
+	if !ok {
+		analyserLog.Warn("resource '%s' %s extension not valid, ignoring it: the value must be an object", o.Name, extTfResourcePollRequest)
+		return nil
+	}
+	pollRequest := &specPollRequest{method: httpGet}
+	if method, ok := pollRequestConfig["method"].(string); ok && method != "" {
+		pollRequest.method = httpMethodSupported(strings.ToUpper(method))
+	}
+	pollRequest.path, _ = pollRequestConfig["path"].(string)
+	pollRequest.body, _ = pollRequestConfig["body"].(string)
+	pollRequest.successExpression, _ = pollRequestConfig["success_expression"].(string)
+	pollRequest.failureExpression, _ = pollRequestConfig["failure_expression"].(string)
+	if err := pollRequest.validate(); err != nil {
+		analyserLog.Warn("resource '%s' %s extension not valid, ignoring it: %s", o.Name, extTfResourcePollRequest, err)
+		return nil
+	}
+	return pollRequest
+}
+
 func (o *SpecV2Resource) getPollingStatuses(response spec.Response, extension string) []string {
 	var statuses []string
 	if resourcePollTargets, exists := response.Extensions.GetString(extension); exists {
@@ -759,7 +1631,7 @@ func (o *SpecV2Resource) getTimeouts() (*specTimeouts, error) {
 	if getTimeout, err = o.getResourceTimeout(o.InstancePathItem.Get); err != nil {
 		return nil, err
 	}
-	if putTimeout, err = o.getResourceTimeout(o.InstancePathItem.Put); err != nil {
+	if putTimeout, err = o.getResourceTimeout(o.getUpdateOperation()); err != nil {
 		return nil, err
 	}
 	if deleteTimeout, err = o.getResourceTimeout(o.InstancePathItem.Delete); err != nil {
@@ -773,6 +1645,24 @@ func (o *SpecV2Resource) getTimeouts() (*specTimeouts, error) {
 	}, nil
 }
 
+// getBatchReadTTL returns the time the collection fetched via the root path GET operation can be used to serve individual
+// reads of the resource instances. Nil is returned if the root path GET operation does not have the 'x-terraform-resource-batch-read'
+// extension enabled. The TTL defaults to 30s and can be overridden with the 'x-terraform-resource-batch-read-ttl' extension.
+func (o *SpecV2Resource) getBatchReadTTL() (*time.Duration, error) {
+	rootPathGet := o.RootPathItem.Get
+	if rootPathGet == nil || !o.isBoolExtensionEnabled(rootPathGet.Extensions, extTfResourceBatchRead) {
+		return nil, nil
+	}
+	ttl, err := o.getTimeDuration(rootPathGet.Extensions, extTfResourceBatchReadTTL)
+	if err != nil {
+		return nil, fmt.Errorf("resource '%s' %s extension not valid: %s", o.GetResourceName(), extTfResourceBatchReadTTL, err)
+	}
+	if ttl == nil {
+		return &defaultBatchReadTTL, nil
+	}
+	return ttl, nil
+}
+
 func (o *SpecV2Resource) getResourceTimeout(operation *spec.Operation) (*time.Duration, error) {
 	if operation == nil {
 		return nil, nil
@@ -799,6 +1689,16 @@ func (o *SpecV2Resource) getDuration(t string) (*time.Duration, error) {
 	return &duration, err
 }
 
+// getResourceReadPath checks if the x-terraform-read-path extension is present and if so returns its value. This path
+// is used to read the resource instances instead of the resource instance path
+func getResourceReadPath(rootPathItemPost *spec.Operation) string {
+	if rootPathItemPost == nil {
+		return ""
+	}
+	readPath, _ := rootPathItemPost.Extensions.GetString(extTfReadPath)
+	return readPath
+}
+
 // getResourceOverrideHost checks if the x-terraform-resource-host extension is present and if so returns its value. This
 // value will override the global host value, and the API calls for this resource will be made against the value returned
 func getResourceOverrideHost(rootPathItemPost *spec.Operation) string {
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -1431,6 +1433,38 @@ func TestGetResourceSchema(t *testing.T) {
 	})
 }
 
+func TestGetResourceSchema_Concurrent(t *testing.T) {
+	r := &SpecV2Resource{
+		Path: "/v1/cdns/{id}/v1/firewalls",
+		SchemaDefinition: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Properties: map[string]spec.Schema{
+					"label": {
+						SchemaProps: spec.SchemaProps{
+							Type: spec.StringOrArray{"string"},
+						},
+					},
+				},
+			},
+		},
+	}
+	const concurrency = 20
+	specSchemaDefinitions := make([]*SpecSchemaDefinition, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			specSchemaDefinitions[i], _ = r.GetResourceSchema()
+			r.GetParentResourceInfo()
+		}(i)
+	}
+	wg.Wait()
+	for _, specSchemaDefinition := range specSchemaDefinitions {
+		assert.Same(t, r.specSchemaDefinitionCached, specSchemaDefinition)
+	}
+}
+
 func TestGetSchemaDefinition(t *testing.T) {
 	Convey("Given a SpecV2Resource containing a root path", t, func() {
 		r := &SpecV2Resource{
@@ -2199,6 +2233,50 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			})
 		})
 
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-encrypt-in-state' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: true,
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfEncryptInState: true,
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be configured as expected", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.EncryptInState, ShouldBeTrue)
+				So(schemaDefinitionProperty.ReadOnly, ShouldBeTrue)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-fingerprint-in-state' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: true,
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfFingerprintInState: true,
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be configured as expected", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.FingerprintInState, ShouldBeTrue)
+				So(schemaDefinitionProperty.EncryptInState, ShouldBeFalse)
+			})
+		})
+
 		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-id' extension", func() {
 			expectedIsIdentifierValue := true
 			propertySchema := spec.Schema{
@@ -3724,199 +3802,1007 @@ func TestGetTimeouts(t *testing.T) {
 	})
 }
 
-func TestGetResourceTimeout(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := SpecV2Resource{}
-		Convey(fmt.Sprintf("When getResourceTimeout method is called with an operation that has the extension '%s'", extTfResourceTimeout), func() {
-			expectedTimeout := "30s"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, expectedTimeout)
-			post := &spec.Operation{
-				VendorExtensible: spec.VendorExtensible{
-					Extensions: extensions,
-				},
-			}
-			duration, err := r.getResourceTimeout(post)
-			Convey("Then the result returned should be the expected one", func() {
-				So(err, ShouldBeNil)
-				So(*duration, ShouldEqual, time.Duration(30*time.Second))
-			})
-		})
-	})
-}
-
-func TestGetTimeDuration(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := SpecV2Resource{}
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that contains the extension passed in '%s' with value in seconds", extTfResourceTimeout), func() {
-			expectedTimeout := "30s"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, expectedTimeout)
-			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
-			Convey("Then the result returned should be the expected one", func() {
-				So(err, ShouldBeNil)
-				So(*duration, ShouldEqual, time.Duration(30*time.Second))
-			})
-		})
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that contains the extension passed in '%s' with value in minutes (using fractions)", extTfResourceTimeout), func() {
-			expectedTimeout := "20.5m"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, expectedTimeout)
-			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
-			Convey("Then the result returned should be the expected one", func() {
-				So(err, ShouldBeNil)
-				So(*duration, ShouldEqual, time.Duration((20*time.Minute)+(30*time.Second)))
-			})
-		})
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that contains the extension passed in '%s' with value in hours", extTfResourceTimeout), func() {
-			expectedTimeout := "1h"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, expectedTimeout)
-			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
-			Convey("Then the result returned should be the expected one", func() {
-				So(err, ShouldBeNil)
-				So(*duration, ShouldEqual, time.Duration(1*time.Hour))
-			})
-		})
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES NOT contain the extension passed in '%s'", extTfResourceTimeout), func() {
-			expectedTimeout := "30s"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, expectedTimeout)
-			duration, err := r.getTimeDuration(extensions, "nonExistingExtension")
-			Convey("Then the duration returned should be nil", func() {
-				So(err, ShouldBeNil)
-				So(duration, ShouldBeNil)
-			})
-		})
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES contain the extension passed in '%s' BUT the value is an empty string", extTfResourceTimeout), func() {
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, "")
-			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
-			Convey("Then the result returned should be the expected one", func() {
-				So(err, ShouldNotBeNil)
-				So(err.Error(), ShouldContainSubstring, "invalid duration value: ''. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)")
-				So(duration, ShouldBeNil)
-			})
-		})
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES contain the extension passed in '%s' BUT the value is a negative duration", extTfResourceTimeout), func() {
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, "-1.5h")
-			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
-			Convey("Then the result returned should be the expected one", func() {
-				So(err, ShouldNotBeNil)
-				So(err.Error(), ShouldContainSubstring, "invalid duration value: '-1.5h'. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)")
-				So(duration, ShouldBeNil)
-			})
-		})
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES contain the extension passed in '%s' BUT the value is NOT supported (distinct than s,m and h)", extTfResourceTimeout), func() {
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, "300ms")
-			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
-			Convey("Then the result returned should be the expected one", func() {
-				So(err, ShouldNotBeNil)
-				So(err.Error(), ShouldContainSubstring, "invalid duration value: '300ms'. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)")
-				So(duration, ShouldBeNil)
-			})
-		})
-	})
-}
-
-func TestGetDuration(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := SpecV2Resource{}
-		Convey("When getDuration method is called a valid formatted time'", func() {
-			duration, err := r.getDuration("30s")
-			Convey("Then the result returned should be the expected one", func() {
-				So(err, ShouldBeNil)
-				So(*duration, ShouldEqual, time.Duration(30*time.Second))
-			})
-		})
-		Convey("When getDuration method is called a invalid formatted time'", func() {
-			_, err := r.getDuration("some invalid formatted time")
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldNotBeNil)
-			})
-		})
-	})
-}
-
-func TestSpecV2ResourceGetHost(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := SpecV2Resource{
-			RootPathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Post: &spec.Operation{
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								extTfResourceURL: "www.some-host.com",
-							},
-						},
-					},
-				},
-			},
-		}
-		Convey("When getHost is called", func() {
-			host, err := r.getHost()
-			Convey("Then the host returned should be the override host", func() {
-				So(err, ShouldBeNil)
-				So(host, ShouldEqual, "www.some-host.com")
-			})
-		})
-	})
-	Convey("Given a SpecV2Resource without an override host specified", t, func() {
+func TestGetBatchReadTTL(t *testing.T) {
+	fiveMinutes := 5 * time.Minute
+	testCases := []struct {
+		name          string
+		rootPathGet   *spec.Operation
+		expectedTTL   *time.Duration
+		expectedError string
+	}{
+		{
+			name:        "resource without root path GET operation",
+			rootPathGet: nil,
+			expectedTTL: nil,
+		},
+		{
+			name:        "root path GET operation without batch read extension",
+			rootPathGet: &spec.Operation{},
+			expectedTTL: nil,
+		},
+		{
+			name:        "root path GET operation with batch read extension disabled",
+			rootPathGet: newOperationWithExtensions(map[string]interface{}{extTfResourceBatchRead: false}),
+			expectedTTL: nil,
+		},
+		{
+			name:        "root path GET operation with batch read extension enabled",
+			rootPathGet: newOperationWithExtensions(map[string]interface{}{extTfResourceBatchRead: true}),
+			expectedTTL: &defaultBatchReadTTL,
+		},
+		{
+			name:        "root path GET operation with batch read extension enabled and custom TTL",
+			rootPathGet: newOperationWithExtensions(map[string]interface{}{extTfResourceBatchRead: true, extTfResourceBatchReadTTL: "5m"}),
+			expectedTTL: &fiveMinutes,
+		},
+		{
+			name:          "root path GET operation with batch read extension enabled and invalid TTL",
+			rootPathGet:   newOperationWithExtensions(map[string]interface{}{extTfResourceBatchRead: true, extTfResourceBatchReadTTL: "5 minutes"}),
+			expectedError: "resource 'cdns_v1' x-terraform-resource-batch-read-ttl extension not valid: invalid duration value: '5 minutes'. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)",
+		},
+	}
+	for _, tc := range testCases {
 		r := SpecV2Resource{
+			Name: "cdns_v1",
+			Path: "/v1/cdns",
 			RootPathItem: spec.PathItem{
 				PathItemProps: spec.PathItemProps{
-					Post: &spec.Operation{},
+					Get: tc.rootPathGet,
 				},
 			},
 		}
-		Convey("When getHost is called", func() {
-			host, err := r.getHost()
-			Convey("Then the host returned should be the override host", func() {
-				So(err, ShouldBeNil)
-				So(host, ShouldEqual, "")
-			})
-		})
-	})
-	Convey("Given a SpecV2Resource that doesn't have a POST operation specified", t, func() {
-		r := SpecV2Resource{
-			RootPathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Post: nil,
-				},
-			},
+		ttl, err := r.getBatchReadTTL()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
 		}
-		Convey("When getHost is called", func() {
-			host, err := r.getHost()
-			Convey("Then the host returned should be an empty string", func() {
-				So(err, ShouldBeNil)
-				So(host, ShouldBeEmpty)
-			})
-		})
-	})
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedTTL, ttl, tc.name)
+	}
 }
 
-func TestGetResourceOverrideHost(t *testing.T) {
-	Convey("Given a terraform compliant resource that has a POST operation containing the x-terraform-resource-host with a non parametrized host containing the host to use", t, func() {
-		expectedHost := "some.api.domain.com"
-		r := SpecV2Resource{
-			RootPathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Post: &spec.Operation{
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								extTfResourceURL: expectedHost,
-							},
-						},
-					},
-				},
+func TestCreateSchemaDefinitionPropertyExample(t *testing.T) {
+	r := SpecV2Resource{}
+	testCases := []struct {
+		name            string
+		property        spec.Schema
+		expectedExample interface{}
+	}{
+		{
+			name:            "property without example",
+			property:        spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			expectedExample: nil,
+		},
+		{
+			name:            "property with example attribute",
+			property:        spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}, SwaggerSchemaProps: spec.SwaggerSchemaProps{Example: "some value"}},
+			expectedExample: "some value",
+		},
+		{
+			name: "property with example attribute and x-terraform-example extension",
+			property: spec.Schema{
+				SchemaProps:        spec.SchemaProps{Type: []string{"string"}},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{Example: "some value"},
+				VendorExtensible:   spec.VendorExtensible{Extensions: spec.Extensions{extTfExample: "some valid value"}},
 			},
-		}
-		Convey("When getResourceOverrideHost method is called", func() {
-			host := getResourceOverrideHost(r.RootPathItem.Post)
-			Convey("Then the value returned should be the host value", func() {
-				So(host, ShouldEqual, expectedHost)
+			expectedExample: "some valid value",
+		},
+	}
+	for _, tc := range testCases {
+		schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("property", tc.property, nil)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedExample, schemaDefinitionProperty.Example, tc.name)
+	}
+}
+
+func TestCreateSchemaDefinitionPropertyEnum(t *testing.T) {
+	r := SpecV2Resource{}
+	testCases := []struct {
+		name         string
+		property     spec.Schema
+		expectedEnum []interface{}
+	}{
+		{
+			name:         "property without enum",
+			property:     spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			expectedEnum: nil,
+		},
+		{
+			name:         "property with enum",
+			property:     spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}, Enum: []interface{}{"small", "large"}}},
+			expectedEnum: []interface{}{"small", "large"},
+		},
+		{
+			name: "array property with items enum",
+			property: spec.Schema{SchemaProps: spec.SchemaProps{
+				Type:  []string{"array"},
+				Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"integer"}, Enum: []interface{}{80, 443}}}},
+			}},
+			expectedEnum: []interface{}{80, 443},
+		},
+	}
+	for _, tc := range testCases {
+		schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("property", tc.property, nil)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedEnum, schemaDefinitionProperty.Enum, tc.name)
+	}
+}
+
+func TestCreateSchemaDefinitionPropertyValidator(t *testing.T) {
+	r := SpecV2Resource{}
+	schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("property", spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, schemaDefinitionProperty.Validator)
+
+	property := spec.Schema{
+		SchemaProps:      spec.SchemaProps{Type: []string{"string"}},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfValidator: "cidr"}},
+	}
+	schemaDefinitionProperty, err = r.createSchemaDefinitionProperty("property", property, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "cidr", schemaDefinitionProperty.Validator)
+}
+
+func TestCreateSchemaDefinitionPropertyFormat(t *testing.T) {
+	r := SpecV2Resource{}
+	testCases := []struct {
+		name           string
+		property       spec.Schema
+		expectedFormat string
+	}{
+		{
+			name:           "property without format",
+			property:       spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+			expectedFormat: "",
+		},
+		{
+			name:           "property with format attribute",
+			property:       spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}, Format: "ipv4"}},
+			expectedFormat: "ipv4",
+		},
+		{
+			name: "property with format attribute and x-terraform-format extension",
+			property: spec.Schema{
+				SchemaProps:      spec.SchemaProps{Type: []string{"string"}, Format: "ipv4"},
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfFormat: "cidr"}},
+			},
+			expectedFormat: "cidr",
+		},
+		{
+			name: "array property with items format attribute",
+			property: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type:  []string{"array"},
+					Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}, Format: "ipv6"}}},
+				},
+			},
+			expectedFormat: "ipv6",
+		},
+	}
+	for _, tc := range testCases {
+		schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("property", tc.property, nil)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedFormat, schemaDefinitionProperty.Format, tc.name)
+	}
+}
+
+func TestCreateSchemaDefinitionPropertyUniqueItems(t *testing.T) {
+	r := SpecV2Resource{}
+	property := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:        []string{"array"},
+			UniqueItems: true,
+			Items:       &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}},
+		},
+	}
+	schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("property", property, nil)
+	assert.NoError(t, err)
+	assert.True(t, schemaDefinitionProperty.UniqueItems)
+
+	property.UniqueItems = false
+	schemaDefinitionProperty, err = r.createSchemaDefinitionProperty("property", property, nil)
+	assert.NoError(t, err)
+	assert.False(t, schemaDefinitionProperty.UniqueItems)
+}
+
+func TestCreateSchemaDefinitionPropertyMinMaxItems(t *testing.T) {
+	r := SpecV2Resource{}
+	property := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:  []string{"array"},
+			Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}},
+		},
+	}
+	schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("property", property, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, schemaDefinitionProperty.MinItems)
+	assert.Equal(t, 0, schemaDefinitionProperty.MaxItems)
+
+	property.WithMinItems(1).WithMaxItems(5)
+	schemaDefinitionProperty, err = r.createSchemaDefinitionProperty("property", property, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, schemaDefinitionProperty.MinItems)
+	assert.Equal(t, 5, schemaDefinitionProperty.MaxItems)
+}
+
+func TestCreateSchemaDefinitionPropertyOrdered(t *testing.T) {
+	r := SpecV2Resource{}
+	property := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:  []string{"array"},
+			Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}},
+		},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{}},
+	}
+	schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("rules", property, nil)
+	assert.NoError(t, err)
+	assert.False(t, schemaDefinitionProperty.Ordered)
+
+	property.Extensions.Add(extTfOrdered, true)
+	schemaDefinitionProperty, err = r.createSchemaDefinitionProperty("rules", property, nil)
+	assert.NoError(t, err)
+	assert.True(t, schemaDefinitionProperty.Ordered)
+	assert.False(t, schemaDefinitionProperty.IgnoreItemsOrder)
+
+	property.Extensions.Add(extTfIgnoreOrder, true)
+	_, err = r.createSchemaDefinitionProperty("rules", property, nil)
+	assert.EqualError(t, err, "failed to process array type property 'rules': x-terraform-ordered and x-terraform-ignore-order extensions are mutually exclusive")
+}
+
+func TestCreateSchemaDefinitionPropertyIdentifierAliases(t *testing.T) {
+	r := SpecV2Resource{}
+	property := spec.Schema{
+		SchemaProps:      spec.SchemaProps{Type: []string{"string"}},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfID: true, extTfIDAliases: []interface{}{"id"}}},
+	}
+	schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("uuid", property, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id"}, schemaDefinitionProperty.IdentifierAliases)
+
+	property.Extensions = spec.Extensions{extTfIDAliases: []interface{}{"uuid"}}
+	schemaDefinitionProperty, err = r.createSchemaDefinitionProperty("id", property, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"uuid"}, schemaDefinitionProperty.IdentifierAliases)
+
+	_, err = r.createSchemaDefinitionProperty("label", property, nil)
+	assert.EqualError(t, err, "property 'label' x-terraform-id-aliases extension not valid: the extension is only supported on the resource identifier property")
+}
+
+func TestCreateSchemaDefinitionPropertyCoerce(t *testing.T) {
+	r := SpecV2Resource{}
+	property := spec.Schema{
+		SchemaProps:      spec.SchemaProps{Type: []string{"boolean"}},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{}},
+	}
+	schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("enabled", property, nil)
+	assert.NoError(t, err)
+	assert.False(t, schemaDefinitionProperty.Coerce)
+
+	property.Extensions.Add(extTfCoerce, true)
+	schemaDefinitionProperty, err = r.createSchemaDefinitionProperty("enabled", property, nil)
+	assert.NoError(t, err)
+	assert.True(t, schemaDefinitionProperty.Coerce)
+
+	property.Type = []string{"array"}
+	property.Items = &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"integer"}}}}
+	schemaDefinitionProperty, err = r.createSchemaDefinitionProperty("ports", property, nil)
+	assert.NoError(t, err)
+	assert.True(t, schemaDefinitionProperty.Coerce)
+
+	property.Type = []string{"object"}
+	property.Items = nil
+	property.Properties = map[string]spec.Schema{"name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}}}
+	_, err = r.createSchemaDefinitionProperty("settings", property, nil)
+	assert.EqualError(t, err, "property 'settings' x-terraform-coerce extension not valid: the extension is only supported on primitive properties and arrays of primitives")
+}
+
+func TestGetSchemaDefinitionPropertyNameCollisions(t *testing.T) {
+	stringProperty := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}
+	testCases := []struct {
+		name                   string
+		properties             map[string]spec.Schema
+		collisionsStrategy     interface{}
+		expectedTerraformNames map[string]string
+		expectedError          string
+	}{
+		{
+			name:                   "properties without collisions",
+			properties:             map[string]spec.Schema{"name": stringProperty, "displayName": stringProperty},
+			expectedTerraformNames: map[string]string{"name": "name", "displayName": "display_name"},
+		},
+		{
+			name:          "colliding properties fail by default",
+			properties:    map[string]spec.Schema{"name": stringProperty, "Name": stringProperty},
+			expectedError: "properties 'Name', 'name' are all converted into the terraform name 'name': the x-terraform-field-name extension is required to give them unique names (or set the x-terraform-field-name-collisions extension of the schema to 'suffix')",
+		},
+		{
+			name: "colliding properties disambiguated with x-terraform-field-name",
+			properties: map[string]spec.Schema{
+				"name": stringProperty,
+				"Name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfFieldName: "legacy_name"}}},
+			},
+			expectedTerraformNames: map[string]string{"name": "name", "Name": "legacy_name"},
+		},
+		{
+			name:                   "colliding properties suffixed",
+			properties:             map[string]spec.Schema{"Name": stringProperty, "name": stringProperty, "NAME": stringProperty, "name_2": stringProperty},
+			collisionsStrategy:     "suffix",
+			expectedTerraformNames: map[string]string{"name": "name", "NAME": "name_3", "Name": "name_4", "name_2": "name_2"},
+		},
+		{
+			name:               "unsupported collisions strategy",
+			properties:         map[string]spec.Schema{"name": stringProperty, "Name": stringProperty},
+			collisionsStrategy: "drop",
+			expectedError:      "x-terraform-field-name-collisions extension value 'drop' not supported, supported values are [error, suffix]",
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{Name: "cdn_v1"}
+		schema := &spec.Schema{SchemaProps: spec.SchemaProps{Properties: tc.properties}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{}}}
+		if tc.collisionsStrategy != nil {
+			schema.Extensions.Add(extTfFieldNameCollisions, tc.collisionsStrategy)
+		}
+		schemaDefinition, err := r.getSchemaDefinition(schema)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		if !assert.NoError(t, err, tc.name) {
+			continue
+		}
+		terraformNames := map[string]string{}
+		for _, property := range schemaDefinition.Properties {
+			terraformNames[property.Name] = property.GetTerraformCompliantPropertyName()
+		}
+		assert.Equal(t, tc.expectedTerraformNames, terraformNames, tc.name)
+	}
+}
+
+func TestGetReadPath(t *testing.T) {
+	testCases := []struct {
+		name             string
+		readPath         interface{}
+		parentIDs        []string
+		id               string
+		expectedReadPath string
+		expectedError    string
+	}{
+		{
+			name:             "resource without read path",
+			id:               "1234",
+			expectedReadPath: "",
+		},
+		{
+			name:             "resource with read path",
+			readPath:         "/v1/clusters/{cluster_id}",
+			id:               "1234",
+			expectedReadPath: "/v1/clusters/1234",
+		},
+		{
+			name:             "subresource with read path containing the parent path parameter",
+			readPath:         "/v1/projects/{project_id}/clusters/{cluster_id}",
+			parentIDs:        []string{"project"},
+			id:               "1234",
+			expectedReadPath: "/v1/projects/project/clusters/1234",
+		},
+		{
+			name:             "subresource with read path not containing the parent path parameter",
+			readPath:         "/v1/clusters/{cluster_id}",
+			parentIDs:        []string{"project"},
+			id:               "1234",
+			expectedReadPath: "/v1/clusters/1234",
+		},
+		{
+			name:          "resource with read path containing more path parameters than ids",
+			readPath:      "/v1/projects/{project_id}/clusters/{cluster_id}",
+			id:            "1234",
+			expectedError: "could not resolve read path '/v1/projects/{project_id}/clusters/{cluster_id}' with the given ids: [1234]",
+		},
+		{
+			name:          "resource with read path without path parameters",
+			readPath:      "/v1/clusters",
+			id:            "1234",
+			expectedError: "could not resolve read path '/v1/clusters' with the given ids: [1234]",
+		},
+		{
+			name:          "resource with read path and id containing forward slashes",
+			readPath:      "/v1/clusters/{cluster_id}",
+			id:            "12/34",
+			expectedError: "instance ID (12/34) contains not supported characters (forward slashes)",
+		},
+	}
+	for _, tc := range testCases {
+		extensions := map[string]interface{}{}
+		if tc.readPath != nil {
+			extensions[extTfReadPath] = tc.readPath
+		}
+		r := SpecV2Resource{
+			Path: "/v1/deployments",
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: newOperationWithExtensions(extensions),
+				},
+			},
+		}
+		readPath, err := r.getReadPath(tc.parentIDs, tc.id)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedReadPath, readPath, tc.name)
+	}
+}
+
+func TestGetReadOperation(t *testing.T) {
+	instanceGet := newOperationWithExtensions(map[string]interface{}{extTfRevisionProperty: "deployment_revision"})
+	readPathGet := newOperationWithExtensions(map[string]interface{}{extTfRevisionProperty: "cluster_revision"})
+	newResource := func(readPath string) SpecV2Resource {
+		return SpecV2Resource{
+			Path: "/v1/deployments",
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: newOperationWithExtensions(map[string]interface{}{extTfReadPath: readPath}),
+				},
+			},
+			InstancePathItem: spec.PathItem{PathItemProps: spec.PathItemProps{Get: instanceGet}},
+			Paths: map[string]spec.PathItem{
+				"/v1/clusters/{cluster_id}": {PathItemProps: spec.PathItemProps{Get: readPathGet}},
+			},
+		}
+	}
+	r := newResource("")
+	assert.Equal(t, instanceGet, r.getReadOperation())
+	r = newResource("/v1/clusters/{cluster_id}")
+	assert.Equal(t, readPathGet, r.getReadOperation())
+}
+
+func TestCreateResourceOperationConditionalGet(t *testing.T) {
+	testCases := []struct {
+		name                            string
+		operation                       *spec.Operation
+		expectedIsConditionalGetEnabled bool
+	}{
+		{
+			name:                            "operation without conditional GET extension",
+			operation:                       &spec.Operation{},
+			expectedIsConditionalGetEnabled: false,
+		},
+		{
+			name:                            "operation with conditional GET extension enabled",
+			operation:                       newOperationWithExtensions(map[string]interface{}{extTfResourceConditionalGet: true}),
+			expectedIsConditionalGetEnabled: true,
+		},
+		{
+			name:                            "operation with conditional GET extension disabled",
+			operation:                       newOperationWithExtensions(map[string]interface{}{extTfResourceConditionalGet: false}),
+			expectedIsConditionalGetEnabled: false,
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{}
+		tc.operation.Responses = &spec.Responses{}
+		operation := r.createResourceOperation(tc.operation)
+		assert.Equal(t, tc.expectedIsConditionalGetEnabled, operation.isConditionalGetEnabled, tc.name)
+	}
+}
+
+func TestCreateResourceOperationDataSourceDetailFetch(t *testing.T) {
+	testCases := []struct {
+		name                       string
+		operation                  *spec.Operation
+		expectedDetailFetchEnabled bool
+	}{
+		{
+			name:                       "operation without data source detail fetch extension",
+			operation:                  &spec.Operation{},
+			expectedDetailFetchEnabled: false,
+		},
+		{
+			name:                       "operation with data source detail fetch extension enabled",
+			operation:                  newOperationWithExtensions(map[string]interface{}{extTfDataSourceDetailFetch: true}),
+			expectedDetailFetchEnabled: true,
+		},
+		{
+			name:                       "operation with data source detail fetch extension disabled",
+			operation:                  newOperationWithExtensions(map[string]interface{}{extTfDataSourceDetailFetch: false}),
+			expectedDetailFetchEnabled: false,
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{}
+		tc.operation.Responses = &spec.Responses{}
+		operation := r.createResourceOperation(tc.operation)
+		assert.Equal(t, tc.expectedDetailFetchEnabled, operation.isDataSourceDetailFetchEnabled(), tc.name)
+	}
+	var operation *specResourceOperation
+	assert.False(t, operation.isDataSourceDetailFetchEnabled())
+}
+
+func TestCreateResourceOperationForceApplyParameter(t *testing.T) {
+	r := SpecV2Resource{}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.Empty(t, operation.getForceApplyParameter())
+	forceApplyOperation := newOperationWithExtensions(map[string]interface{}{extTfForceApplyParameter: "force"})
+	forceApplyOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(forceApplyOperation)
+	assert.Equal(t, "force", operation.getForceApplyParameter())
+}
+
+func TestCreateResourceOperationSkipUnchangedUpdate(t *testing.T) {
+	r := SpecV2Resource{}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.False(t, operation.skipUnchangedUpdate)
+	skipUnchangedOperation := newOperationWithExtensions(map[string]interface{}{extTfSkipUnchangedUpdate: true})
+	skipUnchangedOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(skipUnchangedOperation)
+	assert.True(t, operation.skipUnchangedUpdate)
+}
+
+func TestCreateResourceOperationProvisioningEvents(t *testing.T) {
+	r := SpecV2Resource{}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.False(t, operation.recordProvisioningEvents)
+	provisioningEventsOperation := newOperationWithExtensions(map[string]interface{}{extTfResourceProvisioningEvents: true})
+	provisioningEventsOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(provisioningEventsOperation)
+	assert.True(t, operation.recordProvisioningEvents)
+}
+
+func TestCreateResourceOperationWaitFor(t *testing.T) {
+	r := SpecV2Resource{}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.False(t, operation.waitFor)
+	waitForOperation := newOperationWithExtensions(map[string]interface{}{extTfWaitFor: true})
+	waitForOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(waitForOperation)
+	assert.True(t, operation.waitFor)
+}
+
+func TestCreateResourceOperationUpdateStrategy(t *testing.T) {
+	r := SpecV2Resource{Name: "cdn"}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.False(t, operation.isMergePatch())
+	mergePatchOperation := newOperationWithExtensions(map[string]interface{}{extTfUpdateStrategy: "merge-patch"})
+	mergePatchOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(mergePatchOperation)
+	assert.True(t, operation.isMergePatch())
+	jsonPatchOperation := newOperationWithExtensions(map[string]interface{}{extTfUpdateStrategy: "json-patch"})
+	jsonPatchOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(jsonPatchOperation)
+	assert.True(t, operation.isJSONPatch())
+	notSupportedOperation := newOperationWithExtensions(map[string]interface{}{extTfUpdateStrategy: "strategic-merge-patch"})
+	notSupportedOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(notSupportedOperation)
+	assert.Empty(t, operation.updateStrategy, "update strategies not supported should be ignored")
+}
+
+func TestCreateUpdateOperation(t *testing.T) {
+	putOperation := &spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}}
+	jsonPatchOperation := &spec.Operation{OperationProps: spec.OperationProps{Consumes: []string{"application/json-patch+json"}, Responses: &spec.Responses{}}}
+	mergePatchOperation := &spec.Operation{OperationProps: spec.OperationProps{Consumes: []string{"application/merge-patch+json"}, Responses: &spec.Responses{}}}
+
+	r := SpecV2Resource{Name: "cdn", InstancePathItem: spec.PathItem{PathItemProps: spec.PathItemProps{Put: putOperation, Patch: jsonPatchOperation}}}
+	operation := r.createUpdateOperation()
+	require.NotNil(t, operation)
+	assert.False(t, operation.isJSONPatch(), "the PUT operation should take preference over the PATCH operation")
+
+	r = SpecV2Resource{Name: "cdn", InstancePathItem: spec.PathItem{PathItemProps: spec.PathItemProps{Patch: jsonPatchOperation}}}
+	operation = r.createUpdateOperation()
+	require.NotNil(t, operation)
+	assert.True(t, operation.isJSONPatch(), "the PATCH operation consuming JSON Patch documents should be the update operation if there is no PUT operation")
+
+	r = SpecV2Resource{Name: "cdn", InstancePathItem: spec.PathItem{PathItemProps: spec.PathItemProps{Patch: mergePatchOperation}}}
+	assert.Nil(t, r.createUpdateOperation(), "PATCH operations not consuming JSON Patch documents should not be the update operation")
+}
+
+func TestCreateResourceOperationRequestTimeout(t *testing.T) {
+	r := SpecV2Resource{Name: "cdn"}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.Nil(t, operation.requestTimeout)
+
+	timeoutOperation := newOperationWithExtensions(map[string]interface{}{extTfRequestTimeout: "120s"})
+	timeoutOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(timeoutOperation)
+	if assert.NotNil(t, operation.requestTimeout) {
+		assert.Equal(t, 120*time.Second, *operation.requestTimeout)
+	}
+
+	invalidTimeoutOperation := newOperationWithExtensions(map[string]interface{}{extTfRequestTimeout: "two minutes"})
+	invalidTimeoutOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(invalidTimeoutOperation)
+	assert.Nil(t, operation.requestTimeout)
+}
+
+func TestCreateSchemaDefinitionPropertyUpdateStrategy(t *testing.T) {
+	r := SpecV2Resource{}
+	arrayProperty := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:  spec.StringOrArray{"array"},
+			Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}}},
+		},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfUpdateStrategy: "merge"}},
+	}
+	property, err := r.createSchemaDefinitionProperty("tags", arrayProperty, []string{})
+	assert.NoError(t, err)
+	assert.Equal(t, updateStrategyMerge, property.UpdateStrategy)
+
+	stringProperty := spec.Schema{
+		SchemaProps:      spec.SchemaProps{Type: spec.StringOrArray{"string"}},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfUpdateStrategy: "merge"}},
+	}
+	_, err = r.createSchemaDefinitionProperty("name", stringProperty, []string{})
+	assert.EqualError(t, err, "property 'name' x-terraform-update-strategy extension not valid: the 'merge' update strategy is only supported by array properties")
+}
+
+func TestCreateResourceOperationMiddlewares(t *testing.T) {
+	testCases := []struct {
+		name                string
+		operation           *spec.Operation
+		expectedMiddlewares []string
+	}{
+		{
+			name:                "operation without middleware extension",
+			operation:           &spec.Operation{},
+			expectedMiddlewares: nil,
+		},
+		{
+			name:                "operation with middleware extension",
+			operation:           newOperationWithExtensions(map[string]interface{}{extTfMiddleware: "retry, logging"}),
+			expectedMiddlewares: []string{"retry", "logging"},
+		},
+		{
+			name:                "operation with empty middleware extension",
+			operation:           newOperationWithExtensions(map[string]interface{}{extTfMiddleware: ""}),
+			expectedMiddlewares: []string{},
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{}
+		tc.operation.Responses = &spec.Responses{}
+		operation := r.createResourceOperation(tc.operation)
+		assert.Equal(t, tc.expectedMiddlewares, operation.middlewares, tc.name)
+	}
+}
+
+func TestCreateResourceOperationRevisionProperty(t *testing.T) {
+	r := SpecV2Resource{}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.Equal(t, "", operation.revisionProperty)
+	assert.Equal(t, "fields", operation.fieldsParameter)
+
+	specOperation := newOperationWithExtensions(map[string]interface{}{extTfRevisionProperty: "revision", extTfFieldsParameter: "select"})
+	specOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(specOperation)
+	assert.Equal(t, "revision", operation.revisionProperty)
+	assert.Equal(t, "select", operation.fieldsParameter)
+}
+
+func TestCreateResourceOperationUserAgent(t *testing.T) {
+	r := SpecV2Resource{}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.Equal(t, "", operation.userAgent)
+
+	specOperation := newOperationWithExtensions(map[string]interface{}{extTfUserAgent: "{{.ProviderName}}-importer/{{.ProviderVersion}}"})
+	specOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(specOperation)
+	assert.Equal(t, "{{.ProviderName}}-importer/{{.ProviderVersion}}", operation.userAgent)
+}
+
+func TestCreateResourceOperationFields(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		operation                *spec.Operation
+		expectedFields           []string
+		expectedFieldsFromSchema bool
+	}{
+		{
+			name:      "operation without fields extension",
+			operation: &spec.Operation{},
+		},
+		{
+			name:           "operation with fields extension listing the fields",
+			operation:      newOperationWithExtensions(map[string]interface{}{extTfFields: "id, label,status"}),
+			expectedFields: []string{"id", "label", "status"},
+		},
+		{
+			name:                     "operation with fields extension enabled",
+			operation:                newOperationWithExtensions(map[string]interface{}{extTfFields: true}),
+			expectedFieldsFromSchema: true,
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{}
+		tc.operation.Responses = &spec.Responses{}
+		operation := r.createResourceOperation(tc.operation)
+		assert.Equal(t, tc.expectedFields, operation.fields, tc.name)
+		assert.Equal(t, tc.expectedFieldsFromSchema, operation.fieldsFromSchema, tc.name)
+	}
+}
+
+func TestCreateResourceOperationGRPC(t *testing.T) {
+	operationWithID := func(operation *spec.Operation, id string, parameters ...spec.Parameter) *spec.Operation {
+		operation.ID = id
+		operation.Parameters = parameters
+		return operation
+	}
+	testCases := []struct {
+		name                       string
+		operation                  *spec.Operation
+		expectedGRPCMethod         string
+		expectedGRPCPathParameters []string
+	}{
+		{
+			name:               "operation without operation id",
+			operation:          &spec.Operation{},
+			expectedGRPCMethod: "",
+		},
+		{
+			name:               "operation with operation id not following the Service_Method convention",
+			operation:          operationWithID(&spec.Operation{}, "GetCDN"),
+			expectedGRPCMethod: "",
+		},
+		{
+			name:                       "operation with operation id following the Service_Method convention",
+			operation:                  operationWithID(&spec.Operation{}, "CDNService_GetCDN", *spec.PathParam("cdn_id"), *spec.HeaderParam("x-request-id"), *spec.PathParam("id")),
+			expectedGRPCMethod:         "CDNService/GetCDN",
+			expectedGRPCPathParameters: []string{"cdn_id", "id"},
+		},
+		{
+			name:               "operation with gRPC method extension",
+			operation:          operationWithID(newOperationWithExtensions(map[string]interface{}{extTfGRPCMethod: "example.v1.CDNService/GetCDN"}), "CDNService_GetCDN"),
+			expectedGRPCMethod: "example.v1.CDNService/GetCDN",
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{}
+		tc.operation.Responses = &spec.Responses{}
+		operation := r.createResourceOperation(tc.operation)
+		assert.Equal(t, tc.expectedGRPCMethod, operation.grpcMethod, tc.name)
+		assert.Equal(t, tc.expectedGRPCPathParameters, operation.grpcPathParameters, tc.name)
+	}
+}
+
+func TestCreateResourceOperationGraphQL(t *testing.T) {
+	testCases := []struct {
+		name            string
+		operation       *spec.Operation
+		expectedGraphQL *specGraphQLOperation
+	}{
+		{
+			name:            "operation without GraphQL extension",
+			operation:       &spec.Operation{},
+			expectedGraphQL: nil,
+		},
+		{
+			name:            "operation with GraphQL extension containing the document",
+			operation:       newOperationWithExtensions(map[string]interface{}{extTfGraphQL: "query($id: ID!) { cdn(id: $id) { id } }"}),
+			expectedGraphQL: &specGraphQLOperation{document: "query($id: ID!) { cdn(id: $id) { id } }", endpoint: "/graphql"},
+		},
+		{
+			name: "operation with GraphQL extension containing the document, endpoint and result path",
+			operation: newOperationWithExtensions(map[string]interface{}{extTfGraphQL: map[string]interface{}{
+				"document":    "query($id: ID!) { viewer { cdn(id: $id) { id } } }",
+				"endpoint":    "/api/graphql",
+				"result_path": "viewer.cdn",
+			}}),
+			expectedGraphQL: &specGraphQLOperation{document: "query($id: ID!) { viewer { cdn(id: $id) { id } } }", endpoint: "/api/graphql", resultPath: "viewer.cdn"},
+		},
+		{
+			name:            "operation with GraphQL extension missing the document",
+			operation:       newOperationWithExtensions(map[string]interface{}{extTfGraphQL: map[string]interface{}{}}),
+			expectedGraphQL: &specGraphQLOperation{endpoint: "/graphql"},
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{}
+		tc.operation.Responses = &spec.Responses{}
+		operation := r.createResourceOperation(tc.operation)
+		assert.Equal(t, tc.expectedGraphQL, operation.graphQL, tc.name)
+	}
+}
+
+func newOperationWithExtensions(extensions map[string]interface{}) *spec.Operation {
+	ext := spec.Extensions{}
+	for k, v := range extensions {
+		ext.Add(k, v)
+	}
+	return &spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: ext}}
+}
+
+func TestGetResourceTimeout(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When getResourceTimeout method is called with an operation that has the extension '%s'", extTfResourceTimeout), func() {
+			expectedTimeout := "30s"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, expectedTimeout)
+			post := &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: extensions,
+				},
+			}
+			duration, err := r.getResourceTimeout(post)
+			Convey("Then the result returned should be the expected one", func() {
+				So(err, ShouldBeNil)
+				So(*duration, ShouldEqual, time.Duration(30*time.Second))
+			})
+		})
+	})
+}
+
+func TestGetTimeDuration(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that contains the extension passed in '%s' with value in seconds", extTfResourceTimeout), func() {
+			expectedTimeout := "30s"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, expectedTimeout)
+			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
+			Convey("Then the result returned should be the expected one", func() {
+				So(err, ShouldBeNil)
+				So(*duration, ShouldEqual, time.Duration(30*time.Second))
+			})
+		})
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that contains the extension passed in '%s' with value in minutes (using fractions)", extTfResourceTimeout), func() {
+			expectedTimeout := "20.5m"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, expectedTimeout)
+			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
+			Convey("Then the result returned should be the expected one", func() {
+				So(err, ShouldBeNil)
+				So(*duration, ShouldEqual, time.Duration((20*time.Minute)+(30*time.Second)))
+			})
+		})
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that contains the extension passed in '%s' with value in hours", extTfResourceTimeout), func() {
+			expectedTimeout := "1h"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, expectedTimeout)
+			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
+			Convey("Then the result returned should be the expected one", func() {
+				So(err, ShouldBeNil)
+				So(*duration, ShouldEqual, time.Duration(1*time.Hour))
+			})
+		})
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES NOT contain the extension passed in '%s'", extTfResourceTimeout), func() {
+			expectedTimeout := "30s"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, expectedTimeout)
+			duration, err := r.getTimeDuration(extensions, "nonExistingExtension")
+			Convey("Then the duration returned should be nil", func() {
+				So(err, ShouldBeNil)
+				So(duration, ShouldBeNil)
+			})
+		})
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES contain the extension passed in '%s' BUT the value is an empty string", extTfResourceTimeout), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, "")
+			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
+			Convey("Then the result returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "invalid duration value: ''. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)")
+				So(duration, ShouldBeNil)
+			})
+		})
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES contain the extension passed in '%s' BUT the value is a negative duration", extTfResourceTimeout), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, "-1.5h")
+			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
+			Convey("Then the result returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "invalid duration value: '-1.5h'. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)")
+				So(duration, ShouldBeNil)
+			})
+		})
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES contain the extension passed in '%s' BUT the value is NOT supported (distinct than s,m and h)", extTfResourceTimeout), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, "300ms")
+			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
+			Convey("Then the result returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "invalid duration value: '300ms'. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)")
+				So(duration, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestGetDuration(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getDuration method is called a valid formatted time'", func() {
+			duration, err := r.getDuration("30s")
+			Convey("Then the result returned should be the expected one", func() {
+				So(err, ShouldBeNil)
+				So(*duration, ShouldEqual, time.Duration(30*time.Second))
+			})
+		})
+		Convey("When getDuration method is called a invalid formatted time'", func() {
+			_, err := r.getDuration("some invalid formatted time")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestSpecV2ResourceGetHost(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfResourceURL: "www.some-host.com",
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getHost is called", func() {
+			host, err := r.getHost()
+			Convey("Then the host returned should be the override host", func() {
+				So(err, ShouldBeNil)
+				So(host, ShouldEqual, "www.some-host.com")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource without an override host specified", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{},
+				},
+			},
+		}
+		Convey("When getHost is called", func() {
+			host, err := r.getHost()
+			Convey("Then the host returned should be the override host", func() {
+				So(err, ShouldBeNil)
+				So(host, ShouldEqual, "")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource that doesn't have a POST operation specified", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: nil,
+				},
+			},
+		}
+		Convey("When getHost is called", func() {
+			host, err := r.getHost()
+			Convey("Then the host returned should be an empty string", func() {
+				So(err, ShouldBeNil)
+				So(host, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetResourceOverrideHost(t *testing.T) {
+	Convey("Given a terraform compliant resource that has a POST operation containing the x-terraform-resource-host with a non parametrized host containing the host to use", t, func() {
+		expectedHost := "some.api.domain.com"
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfResourceURL: expectedHost,
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getResourceOverrideHost method is called", func() {
+			host := getResourceOverrideHost(r.RootPathItem.Post)
+			Convey("Then the value returned should be the host value", func() {
+				So(host, ShouldEqual, expectedHost)
 			})
 		})
 	})
@@ -3983,3 +4869,596 @@ func TestGetResourceOverrideHost(t *testing.T) {
 		})
 	})
 }
+
+func TestGetSchemaDefinitionReservedPropertyNames(t *testing.T) {
+	stringProperty := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}
+	identifierProperty := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfID: true}}}
+	testCases := []struct {
+		name                   string
+		properties             map[string]spec.Schema
+		expectedTerraformNames map[string]string
+		expectedError          string
+	}{
+		{
+			name:                   "reserved properties are renamed",
+			properties:             map[string]spec.Schema{"id": stringProperty, "count": stringProperty, "Provider": stringProperty, "dependsOn": stringProperty, "lifecycle": stringProperty},
+			expectedTerraformNames: map[string]string{"id": "id", "count": "count_value", "Provider": "provider_value", "dependsOn": "depends_on_value", "lifecycle": "lifecycle_value"},
+		},
+		{
+			name:                   "id property is renamed if it is not the identifier",
+			properties:             map[string]spec.Schema{"id": stringProperty, "uuid": identifierProperty},
+			expectedTerraformNames: map[string]string{"id": "id_value", "uuid": "uuid"},
+		},
+		{
+			name: "reserved properties with x-terraform-field-name are not renamed",
+			properties: map[string]spec.Schema{
+				"id":    stringProperty,
+				"count": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfFieldName: "total"}}},
+			},
+			expectedTerraformNames: map[string]string{"id": "id", "count": "total"},
+		},
+		{
+			name: "x-terraform-field-name with reserved name",
+			properties: map[string]spec.Schema{
+				"id":    stringProperty,
+				"total": {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfFieldName: "count"}}},
+			},
+			expectedError: "property 'total' x-terraform-field-name extension not valid: 'count' is a reserved terraform attribute name",
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{Name: "cdn_v1"}
+		schema := &spec.Schema{SchemaProps: spec.SchemaProps{Properties: tc.properties}}
+		schemaDefinition, err := r.getSchemaDefinitionWithOptions(schema, true)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		if !assert.NoError(t, err, tc.name) {
+			continue
+		}
+		terraformNames := map[string]string{}
+		for _, property := range schemaDefinition.Properties {
+			terraformNames[property.Name] = property.GetTerraformCompliantPropertyName()
+		}
+		assert.Equal(t, tc.expectedTerraformNames, terraformNames, tc.name)
+	}
+}
+
+func TestCreateSchemaDefinitionPropertyIgnoreServerChanges(t *testing.T) {
+	r := SpecV2Resource{}
+	property := spec.Schema{
+		SchemaProps:      spec.SchemaProps{Type: []string{"integer"}},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{}},
+	}
+	schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("node_count", property, nil)
+	assert.NoError(t, err)
+	assert.False(t, schemaDefinitionProperty.IgnoreServerChanges)
+
+	property.Extensions.Add(extTfIgnoreServerChanges, true)
+	schemaDefinitionProperty, err = r.createSchemaDefinitionProperty("node_count", property, nil)
+	assert.NoError(t, err)
+	assert.True(t, schemaDefinitionProperty.IgnoreServerChanges)
+}
+
+func TestCreateSchemaDefinitionPropertyIgnoreServerItems(t *testing.T) {
+	r := SpecV2Resource{}
+	property := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:  []string{"array"},
+			Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}},
+		},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{}},
+	}
+	schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("tags", property, nil)
+	assert.NoError(t, err)
+	assert.False(t, schemaDefinitionProperty.IgnoreServerItems)
+
+	property.Extensions.Add(extTfIgnoreServerItems, true)
+	schemaDefinitionProperty, err = r.createSchemaDefinitionProperty("tags", property, nil)
+	assert.NoError(t, err)
+	assert.True(t, schemaDefinitionProperty.IgnoreServerItems)
+
+	property.Type = []string{"string"}
+	property.Items = nil
+	_, err = r.createSchemaDefinitionProperty("label", property, nil)
+	assert.EqualError(t, err, "property 'label' x-terraform-ignore-server-items extension not valid: the extension is only supported on array properties")
+}
+
+func TestGetSchemaDefinitionAttributeAliases(t *testing.T) {
+	aliasedProperty := func(aliases ...interface{}) spec.Schema {
+		return spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfAttributeAliases: aliases}}}
+	}
+	stringProperty := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}
+	testCases := []struct {
+		name            string
+		properties      map[string]spec.Schema
+		topLevel        bool
+		expectedAliases []string
+		expectedError   string
+	}{
+		{
+			name:            "aliases of top level properties",
+			properties:      map[string]spec.Schema{"display_name": aliasedProperty("label", "title")},
+			topLevel:        true,
+			expectedAliases: []string{"label", "title"},
+		},
+		{
+			name:          "aliases of nested properties",
+			properties:    map[string]spec.Schema{"display_name": aliasedProperty("label")},
+			expectedError: "property 'display_name' x-terraform-attribute-aliases extension not valid: the extension is only supported on the top level properties of the resource",
+		},
+		{
+			name:          "alias used by another property",
+			properties:    map[string]spec.Schema{"display_name": aliasedProperty("label"), "label": stringProperty},
+			topLevel:      true,
+			expectedError: "property 'display_name' x-terraform-attribute-aliases extension not valid: 'label' is already used by property 'label'",
+		},
+		{
+			name:          "alias used by another alias",
+			properties:    map[string]spec.Schema{"display_name": aliasedProperty("label"), "title": aliasedProperty("label")},
+			topLevel:      true,
+			expectedError: "property 'title' x-terraform-attribute-aliases extension not valid: 'label' is already used by property 'display_name'",
+		},
+		{
+			name:          "alias not terraform compliant",
+			properties:    map[string]spec.Schema{"display_name": aliasedProperty("displayName")},
+			topLevel:      true,
+			expectedError: "property 'display_name' x-terraform-attribute-aliases extension not valid: 'displayName' is not a valid terraform attribute name",
+		},
+		{
+			name:          "alias with reserved name",
+			properties:    map[string]spec.Schema{"total": aliasedProperty("count")},
+			topLevel:      true,
+			expectedError: "property 'total' x-terraform-attribute-aliases extension not valid: 'count' is not a valid terraform attribute name",
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{Name: "cdn_v1"}
+		schema := &spec.Schema{SchemaProps: spec.SchemaProps{Properties: tc.properties}}
+		schemaDefinition, err := r.getSchemaDefinitionWithOptions(schema, tc.topLevel)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		if !assert.NoError(t, err, tc.name) {
+			continue
+		}
+		property, err := schemaDefinition.getProperty("display_name")
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedAliases, property.AttributeAliases, tc.name)
+	}
+}
+
+func TestCreateSchemaDefinitionPropertyAttributeAliasesReadOnly(t *testing.T) {
+	r := SpecV2Resource{}
+	property := spec.Schema{
+		SchemaProps:        spec.SchemaProps{Type: []string{"string"}},
+		SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true},
+		VendorExtensible:   spec.VendorExtensible{Extensions: spec.Extensions{extTfAttributeAliases: []interface{}{"state"}}},
+	}
+	_, err := r.createSchemaDefinitionProperty("status", property, nil)
+	assert.EqualError(t, err, "property 'status' x-terraform-attribute-aliases extension not valid: readOnly properties can not be configured")
+}
+
+func TestGetResourcePollRequest(t *testing.T) {
+	testCases := []struct {
+		name                string
+		extensions          spec.Extensions
+		expectedPollRequest *specPollRequest
+	}{
+		{
+			name:       "extension not present",
+			extensions: spec.Extensions{},
+		},
+		{
+			name: "poll request with all the options",
+			extensions: spec.Extensions{extTfResourcePollRequest: map[string]interface{}{
+				"method":             "post",
+				"path":               "/v1/rpc",
+				"body":               `{"id":"{{.ID}}"}`,
+				"success_expression": `{{eq .result.status "done"}}`,
+				"failure_expression": `{{eq .result.status "failed"}}`,
+			}},
+			expectedPollRequest: &specPollRequest{method: httpPost, path: "/v1/rpc", body: `{"id":"{{.ID}}"}`, successExpression: `{{eq .result.status "done"}}`, failureExpression: `{{eq .result.status "failed"}}`},
+		},
+		{
+			name:                "poll request method defaults to GET",
+			extensions:          spec.Extensions{extTfResourcePollRequest: map[string]interface{}{"success_expression": `{{.ready}}`}},
+			expectedPollRequest: &specPollRequest{method: httpGet, successExpression: `{{.ready}}`},
+		},
+		{
+			name:       "poll request not valid is ignored",
+			extensions: spec.Extensions{extTfResourcePollRequest: map[string]interface{}{"method": "delete"}},
+		},
+		{
+			name:       "poll request not an object is ignored",
+			extensions: spec.Extensions{extTfResourcePollRequest: "POST"},
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{Name: "cdn_v1"}
+		pollRequest := r.getResourcePollRequest(spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: tc.extensions}})
+		assert.Equal(t, tc.expectedPollRequest, pollRequest, tc.name)
+	}
+}
+
+func TestCreateResourceOperationPrecheck(t *testing.T) {
+	r := SpecV2Resource{}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.Nil(t, operation.precheck)
+
+	precheckOperation := newOperationWithExtensions(map[string]interface{}{extTfPrecheckPath: "/v1/quotas/clusters"})
+	precheckOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(precheckOperation)
+	assert.Equal(t, &specPrecheck{path: "/v1/quotas/clusters", messageField: "message"}, operation.precheck)
+
+	precheckOperation = newOperationWithExtensions(map[string]interface{}{extTfPrecheckPath: "/v1/quotas/clusters", extTfPrecheckField: "quota.available", extTfPrecheckMessageField: "error.detail"})
+	precheckOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(precheckOperation)
+	assert.Equal(t, &specPrecheck{path: "/v1/quotas/clusters", field: "quota.available", messageField: "error.detail"}, operation.precheck)
+}
+
+func TestCreateResourceOperationLock(t *testing.T) {
+	r := SpecV2Resource{}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.Nil(t, operation.lock)
+
+	lockOperation := newOperationWithExtensions(map[string]interface{}{extTfLockPath: "/v1/projects/{project_id}/locks/cdns"})
+	lockOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(lockOperation)
+	assert.Equal(t, &specLock{path: "/v1/projects/{project_id}/locks/cdns"}, operation.lock)
+}
+
+func TestCreateResourceOperationConsistencyToken(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		extensions               map[string]interface{}
+		expectedConsistencyToken *specConsistencyToken
+	}{
+		{
+			name: "extension not present",
+		},
+		{
+			name:                     "token returned in a header and sent in a header",
+			extensions:               map[string]interface{}{extTfConsistencyToken: map[string]interface{}{"response_header": "X-Consistency-Token", "request_header": "X-Min-Consistency-Token"}},
+			expectedConsistencyToken: &specConsistencyToken{responseHeader: "X-Consistency-Token", requestHeader: "X-Min-Consistency-Token"},
+		},
+		{
+			name:                     "token returned in a field and sent in a query parameter",
+			extensions:               map[string]interface{}{extTfConsistencyToken: map[string]interface{}{"response_field": "meta.consistency_token", "request_parameter": "min_token"}},
+			expectedConsistencyToken: &specConsistencyToken{responseField: "meta.consistency_token", requestParameter: "min_token"},
+		},
+		{
+			name:       "extension not an object",
+			extensions: map[string]interface{}{extTfConsistencyToken: "X-Consistency-Token"},
+		},
+		{
+			name:       "both response header and field",
+			extensions: map[string]interface{}{extTfConsistencyToken: map[string]interface{}{"response_header": "X-Consistency-Token", "response_field": "meta.consistency_token", "request_header": "X-Min-Consistency-Token"}},
+		},
+		{
+			name:       "missing request header or parameter",
+			extensions: map[string]interface{}{extTfConsistencyToken: map[string]interface{}{"response_header": "X-Consistency-Token"}},
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{Name: "cdn_v1"}
+		operation := newOperationWithExtensions(tc.extensions)
+		operation.Responses = &spec.Responses{}
+		assert.Equal(t, tc.expectedConsistencyToken, r.createResourceOperation(operation).consistencyToken, tc.name)
+	}
+}
+
+func TestCreateResourceOperationRetry(t *testing.T) {
+	maxRetries, noRetries := 5, 0
+	testCases := []struct {
+		name               string
+		extensions         map[string]interface{}
+		rootPathExtensions map[string]interface{}
+		expectedRetry      *specRetry
+	}{
+		{
+			name: "extension not present",
+		},
+		{
+			name:          "all the retry settings",
+			extensions:    map[string]interface{}{extTfRetry: map[string]interface{}{"max_retries": float64(5), "retry_wait": "2s", "max_retry_wait": "1m", "retry_status_codes": []interface{}{float64(409), float64(503)}}},
+			expectedRetry: &specRetry{maxRetries: &maxRetries, retryWait: "2s", maxRetryWait: "1m", retryStatusCodes: []int{409, 503}},
+		},
+		{
+			name:          "retries disabled",
+			extensions:    map[string]interface{}{extTfRetry: map[string]interface{}{"max_retries": float64(0)}},
+			expectedRetry: &specRetry{maxRetries: &noRetries},
+		},
+		{
+			name:               "extension of the resource root path",
+			rootPathExtensions: map[string]interface{}{extTfRetry: map[string]interface{}{"retry_wait": "2s"}},
+			expectedRetry:      &specRetry{retryWait: "2s"},
+		},
+		{
+			name:               "operation extension takes precedence over the resource root path one",
+			extensions:         map[string]interface{}{extTfRetry: map[string]interface{}{"retry_wait": "5s"}},
+			rootPathExtensions: map[string]interface{}{extTfRetry: map[string]interface{}{"retry_wait": "2s"}},
+			expectedRetry:      &specRetry{retryWait: "5s"},
+		},
+		{
+			name:       "extension not an object",
+			extensions: map[string]interface{}{extTfRetry: "3"},
+		},
+		{
+			name:       "negative max retries",
+			extensions: map[string]interface{}{extTfRetry: map[string]interface{}{"max_retries": float64(-1)}},
+		},
+		{
+			name:       "invalid retry status codes",
+			extensions: map[string]interface{}{extTfRetry: map[string]interface{}{"retry_status_codes": []interface{}{"503"}}},
+		},
+		{
+			name:       "invalid retry wait",
+			extensions: map[string]interface{}{extTfRetry: map[string]interface{}{"retry_wait": "soon"}},
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{Name: "cdn_v1", RootPathItem: spec.PathItem{VendorExtensible: newOperationWithExtensions(tc.rootPathExtensions).VendorExtensible}}
+		operation := newOperationWithExtensions(tc.extensions)
+		operation.Responses = &spec.Responses{}
+		assert.Equal(t, tc.expectedRetry, r.createResourceOperation(operation).retry, tc.name)
+	}
+}
+
+func TestCreateResourceOperationOptionsPath(t *testing.T) {
+	r := SpecV2Resource{}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.Empty(t, operation.optionsPath)
+
+	optionsOperation := newOperationWithExtensions(map[string]interface{}{extTfOptionsPath: "/v1/clusters/options"})
+	optionsOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(optionsOperation)
+	assert.Equal(t, "/v1/clusters/options", operation.optionsPath)
+}
+
+func TestCreateResourceOperationCount(t *testing.T) {
+	r := SpecV2Resource{}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.Nil(t, operation.count)
+
+	countOperation := newOperationWithExtensions(map[string]interface{}{extTfCountPath: "/v1/cdns/count"})
+	countOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(countOperation)
+	assert.Equal(t, &specCount{path: "/v1/cdns/count", field: "count"}, operation.count)
+
+	countOperation = newOperationWithExtensions(map[string]interface{}{extTfCountPath: "/v1/cdns/count", extTfCountField: "meta.total"})
+	countOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(countOperation)
+	assert.Equal(t, &specCount{path: "/v1/cdns/count", field: "meta.total"}, operation.count)
+}
+
+func TestCreateResourceOperationPagination(t *testing.T) {
+	r := SpecV2Resource{}
+	operation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+	assert.Nil(t, operation.pagination)
+
+	paginationOperation := newOperationWithExtensions(map[string]interface{}{extTfPaginationTotalHeader: "X-Total-Count"})
+	paginationOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(paginationOperation)
+	assert.Equal(t, &specPagination{totalHeader: "X-Total-Count"}, operation.pagination)
+
+	paginationOperation = newOperationWithExtensions(map[string]interface{}{extTfPaginationTotalHeader: "X-Total-Count", extTfPaginationNextCursorHeader: "Link"})
+	paginationOperation.Responses = &spec.Responses{}
+	operation = r.createResourceOperation(paginationOperation)
+	assert.Equal(t, &specPagination{totalHeader: "X-Total-Count", nextCursorHeader: "Link"}, operation.pagination)
+}
+
+func TestGetSchemaDefinitionResolveByName(t *testing.T) {
+	resolvedProperty := func(value interface{}) spec.Schema {
+		return spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResolveByName: value}}}
+	}
+	stringProperty := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}
+	testCases := []struct {
+		name                  string
+		properties            map[string]spec.Schema
+		topLevel              bool
+		expectedResolveByName *specResolveByName
+		expectedAttribute     string
+		expectedError         string
+	}{
+		{
+			name:                  "lookup path",
+			properties:            map[string]spec.Schema{"vpc_id": resolvedProperty("/v1/vpcs")},
+			topLevel:              true,
+			expectedResolveByName: &specResolveByName{path: "/v1/vpcs", nameField: "name", idField: "id"},
+			expectedAttribute:     "vpc_name",
+		},
+		{
+			name: "lookup object",
+			properties: map[string]spec.Schema{"vpc_id": resolvedProperty(map[string]interface{}{
+				"path": "/v1/vpcs", "name_attribute": "network", "name_field": "label", "id_field": "uuid", "items_field": "data.items",
+			})},
+			topLevel:              true,
+			expectedResolveByName: &specResolveByName{attribute: "network", path: "/v1/vpcs", nameField: "label", idField: "uuid", itemsField: "data.items"},
+			expectedAttribute:     "network",
+		},
+		{
+			name:          "lookup path missing",
+			properties:    map[string]spec.Schema{"vpc_id": resolvedProperty(map[string]interface{}{"name_field": "label"})},
+			topLevel:      true,
+			expectedError: "property 'vpc_id' x-terraform-resolve-by-name extension not valid: the lookup path is mandatory",
+		},
+		{
+			name:          "nested properties",
+			properties:    map[string]spec.Schema{"vpc_id": resolvedProperty("/v1/vpcs")},
+			expectedError: "property 'vpc_id' x-terraform-resolve-by-name extension not valid: the extension is only supported on the top level properties of the resource",
+		},
+		{
+			name:          "name attribute used by another property",
+			properties:    map[string]spec.Schema{"vpc_id": resolvedProperty("/v1/vpcs"), "vpc_name": stringProperty},
+			topLevel:      true,
+			expectedError: "property 'vpc_id' x-terraform-resolve-by-name extension not valid: 'vpc_name' is already used by property 'vpc_name'",
+		},
+		{
+			name:          "name attribute not terraform compliant",
+			properties:    map[string]spec.Schema{"vpc_id": resolvedProperty(map[string]interface{}{"path": "/v1/vpcs", "name_attribute": "vpcName"})},
+			topLevel:      true,
+			expectedError: "property 'vpc_id' x-terraform-resolve-by-name extension not valid: 'vpcName' is not a valid terraform attribute name",
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{Name: "cluster"}
+		schema := &spec.Schema{SchemaProps: spec.SchemaProps{Properties: tc.properties}}
+		schemaDefinition, err := r.getSchemaDefinitionWithOptions(schema, tc.topLevel)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		if !assert.NoError(t, err, tc.name) {
+			continue
+		}
+		property, err := schemaDefinition.getProperty("vpc_id")
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedResolveByName, property.ResolveByName, tc.name)
+		assert.Equal(t, tc.expectedAttribute, property.getResolveByNameAttribute(), tc.name)
+	}
+}
+
+func TestCreateSchemaDefinitionPropertyResolveByNameNotSupported(t *testing.T) {
+	r := SpecV2Resource{}
+	property := spec.Schema{
+		SchemaProps:      spec.SchemaProps{Type: []string{"integer"}},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResolveByName: "/v1/vpcs"}},
+	}
+	_, err := r.createSchemaDefinitionProperty("vpc_id", property, nil)
+	assert.EqualError(t, err, "property 'vpc_id' x-terraform-resolve-by-name extension not valid: the extension is only supported on configurable string properties")
+
+	property = spec.Schema{
+		SchemaProps:      spec.SchemaProps{Type: []string{"string"}},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResolveByName: "/v1/vpcs", extTfAttributeAliases: []interface{}{"network_id"}}},
+	}
+	_, err = r.createSchemaDefinitionProperty("vpc_id", property, nil)
+	assert.EqualError(t, err, "property 'vpc_id' x-terraform-resolve-by-name extension not valid: the extension can not be combined with the x-terraform-attribute-aliases extension")
+}
+
+func TestGetSchemaDefinitionUploadProperty(t *testing.T) {
+	uploadProperty := func(value interface{}) spec.Schema {
+		return spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfUploadProperty: value}}}
+	}
+	stringProperty := spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}}}
+	testCases := []struct {
+		name              string
+		properties        map[string]spec.Schema
+		topLevel          bool
+		expectedUpload    *specUpload
+		expectedAttribute string
+		expectedError     string
+	}{
+		{
+			name:              "upload path",
+			properties:        map[string]spec.Schema{"bundle_key": uploadProperty("/v1/uploads")},
+			topLevel:          true,
+			expectedUpload:    &specUpload{path: "/v1/uploads", urlField: "upload_url", keyField: "key"},
+			expectedAttribute: "bundle_file",
+		},
+		{
+			name: "upload object",
+			properties: map[string]spec.Schema{"bundle_key": uploadProperty(map[string]interface{}{
+				"path": "/v1/uploads", "file_attribute": "source", "url_field": "upload.url", "key_field": "object_key",
+			})},
+			topLevel:          true,
+			expectedUpload:    &specUpload{attribute: "source", path: "/v1/uploads", urlField: "upload.url", keyField: "object_key"},
+			expectedAttribute: "source",
+		},
+		{
+			name:          "upload path missing",
+			properties:    map[string]spec.Schema{"bundle_key": uploadProperty(map[string]interface{}{"key_field": "object_key"})},
+			topLevel:      true,
+			expectedError: "property 'bundle_key' x-terraform-upload-property extension not valid: the upload path is mandatory",
+		},
+		{
+			name:          "nested properties",
+			properties:    map[string]spec.Schema{"bundle_key": uploadProperty("/v1/uploads")},
+			expectedError: "property 'bundle_key' x-terraform-upload-property extension not valid: the extension is only supported on the top level properties of the resource",
+		},
+		{
+			name:          "file attribute used by another property",
+			properties:    map[string]spec.Schema{"bundle_key": uploadProperty("/v1/uploads"), "bundle_file": stringProperty},
+			topLevel:      true,
+			expectedError: "property 'bundle_key' x-terraform-upload-property extension not valid: 'bundle_file' is already used by property 'bundle_file'",
+		},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{Name: "function"}
+		schema := &spec.Schema{SchemaProps: spec.SchemaProps{Properties: tc.properties}}
+		schemaDefinition, err := r.getSchemaDefinitionWithOptions(schema, tc.topLevel)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		if !assert.NoError(t, err, tc.name) {
+			continue
+		}
+		property, err := schemaDefinition.getProperty("bundle_key")
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedUpload, property.Upload, tc.name)
+		assert.Equal(t, tc.expectedAttribute, property.getUploadAttribute(), tc.name)
+	}
+}
+
+func TestCreateSchemaDefinitionPropertyUploadPropertyNotSupported(t *testing.T) {
+	r := SpecV2Resource{}
+	property := spec.Schema{
+		SchemaProps:      spec.SchemaProps{Type: []string{"integer"}},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfUploadProperty: "/v1/uploads"}},
+	}
+	_, err := r.createSchemaDefinitionProperty("bundle_key", property, nil)
+	assert.EqualError(t, err, "property 'bundle_key' x-terraform-upload-property extension not valid: the extension is only supported on configurable string properties")
+
+	property = spec.Schema{
+		SchemaProps:      spec.SchemaProps{Type: []string{"string"}},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfUploadProperty: "/v1/uploads", extTfResolveByName: "/v1/bundles"}},
+	}
+	_, err = r.createSchemaDefinitionProperty("bundle_key", property, nil)
+	assert.EqualError(t, err, "property 'bundle_key' x-terraform-upload-property extension not valid: the extension can not be combined with the x-terraform-resolve-by-name extension")
+}
+
+func TestGetSchemaDefinitionDescriptions(t *testing.T) {
+	r := SpecV2Resource{
+		SchemaDefinitions: map[string]spec.Schema{
+			"Listener": {
+				SchemaProps: spec.SchemaProps{
+					Type:        spec.StringOrArray{"object"},
+					Description: "Listener accepting the incoming traffic",
+					Properties:  map[string]spec.Schema{"protocol": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}, Description: "Protocol of the listener"}}},
+				},
+			},
+		},
+	}
+	listenerRef := spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Listener")}
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Description: "Load balancer distributing the traffic across the instances",
+			Properties: map[string]spec.Schema{
+				"name":             {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}, Description: "Name of the load balancer"}},
+				"default_listener": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, Ref: listenerRef}},
+				"listeners":        {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"array"}, Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, Ref: listenerRef}}}}},
+				"admin_listener":   {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, Ref: listenerRef, Description: "Listener of the admin console"}},
+			},
+		},
+	}
+	schemaDefinition, err := r.getSchemaDefinition(schema)
+	require.NoError(t, err)
+	assert.Equal(t, "Load balancer distributing the traffic across the instances", schemaDefinition.Description)
+	expectedDescriptions := map[string]string{
+		"name":             "Name of the load balancer",
+		"default_listener": "Listener accepting the incoming traffic",
+		"listeners":        "Listener accepting the incoming traffic",
+		"admin_listener":   "Listener of the admin console",
+	}
+	for propertyName, expectedDescription := range expectedDescriptions {
+		property, err := schemaDefinition.getProperty(propertyName)
+		require.NoError(t, err)
+		assert.Equal(t, expectedDescription, property.Description, propertyName)
+	}
+	property, err := schemaDefinition.getProperty("default_listener")
+	require.NoError(t, err)
+	assert.Equal(t, "Protocol of the listener", property.SpecSchemaDefinition.Properties[0].Description)
+}
@@ -7,6 +7,9 @@ import (
 
 const extTfAuthenticationSchemeBearer = "x-terraform-authentication-scheme-bearer"
 const extTfAuthenticationRefreshToken = "x-terraform-refresh-token-url" // #nosec G101
+const extTfCloudIdentityProvider = "x-terraform-cloud-identity-provider"
+const extTfCloudIdentityAudience = "x-terraform-cloud-identity-audience"
+const extTfAuthenticatorHeaderTemplate = "x-terraform-authenticator-header-template"
 
 type specV2Security struct {
 	SecurityDefinitions spec.SecurityDefinitions
@@ -14,16 +17,31 @@ type specV2Security struct {
 }
 
 // GetAPIKeySecurityDefinitions returns a list of SpecSecurityDefinition after looping through the SecurityDefinitions
-// and selecting only the SecurityDefinitions of type apiKey
+// and selecting only the SecurityDefinitions of type apiKey and the oauth2 ones using the client credentials (application) flow
 func (s *specV2Security) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinitions, error) {
 	securityDefinitions := &SpecSecurityDefinitions{}
 	for secDefName, secDef := range s.SecurityDefinitions {
+		if secDef.Type == "oauth2" && secDef.Flow == "application" {
+			securityDefinition := newOAuth2ClientCredentialsSecurityDefinition(secDefName, secDef.TokenURL)
+			if err := securityDefinition.validate(); err != nil {
+				return nil, err
+			}
+			*securityDefinitions = append(*securityDefinitions, securityDefinition)
+			continue
+		}
 		if secDef.Type == "apiKey" {
 			var securityDefinition SpecSecurityDefinition
 			switch secDef.In {
 			case "header":
 				if refreshTokenURL := s.isRefreshTokenAuth(secDef); refreshTokenURL != "" {
 					securityDefinition = newAPIKeyHeaderRefreshTokenSecurityDefinition(secDefName, refreshTokenURL)
+				} else if provider, audience := s.getCloudIdentity(secDef); provider != "" {
+					securityDefinition = newAPIKeyHeaderCloudIdentitySecurityDefinition(secDefName, provider, audience)
+				} else if template, isTemplate := secDef.Extensions.GetString(extTfAuthenticatorHeaderTemplate); isTemplate {
+					if s.isBearerScheme(secDef) {
+						return nil, fmt.Errorf("security definition '%s' can not have both the %s and %s extensions, please define the Bearer scheme in the header template instead (e,g: \"Bearer %%s\")", secDefName, extTfAuthenticatorHeaderTemplate, extTfAuthenticationSchemeBearer)
+					}
+					securityDefinition = newAPIKeyHeaderTemplateSecurityDefinition(secDefName, secDef.Name, template)
 				} else if s.isBearerScheme(secDef) {
 					securityDefinition = newAPIKeyHeaderBearerSecurityDefinition(secDefName)
 				} else {
@@ -47,6 +65,14 @@ func (s *specV2Security) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinition
 	return securityDefinitions, nil
 }
 
+// GetSecurityDefinitionDescription returns the description of the security definition with the given name
+func (s *specV2Security) GetSecurityDefinitionDescription(securityDefinitionName string) string {
+	if secDef, exists := s.SecurityDefinitions[securityDefinitionName]; exists && secDef != nil {
+		return secDef.Description
+	}
+	return ""
+}
+
 func (s *specV2Security) isBearerScheme(secDef *spec.SecurityScheme) bool {
 	authScheme, enabled := secDef.Extensions.GetBool(extTfAuthenticationSchemeBearer)
 	if authScheme && enabled {
@@ -63,6 +89,15 @@ func (s *specV2Security) isRefreshTokenAuth(secDef *spec.SecurityScheme) string
 	return ""
 }
 
+// getCloudIdentity returns the cloud provider whose instance metadata server issues the identity tokens and the audience
+// they are requested for as defined in the security definition extensions; empty if the security definition does not
+// have the x-terraform-cloud-identity-provider extension
+func (s *specV2Security) getCloudIdentity(secDef *spec.SecurityScheme) (string, string) {
+	provider, _ := secDef.Extensions.GetString(extTfCloudIdentityProvider)
+	audience, _ := secDef.Extensions.GetString(extTfCloudIdentityAudience)
+	return provider, audience
+}
+
 // GetGlobalSecuritySchemes returns a list of SpecSecuritySchemes that have their corresponding SpecSecurityDefinition
 func (s *specV2Security) GetGlobalSecuritySchemes() (SpecSecuritySchemes, error) {
 	securitySchemes := createSecuritySchemes(s.GlobalSecurity)
@@ -3,6 +3,7 @@ package openapi
 import (
 	"github.com/go-openapi/spec"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
@@ -387,3 +388,89 @@ func TestIsRefreshTokenAuth(t *testing.T) {
 		})
 	})
 }
+
+func TestGetAPIKeySecurityDefinitionsCloudIdentity(t *testing.T) {
+	newSpecV2Security := func(extensions spec.Extensions) specV2Security {
+		return specV2Security{
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"cloud_identity": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{In: "header", Type: "apiKey", Name: authorizationHeader},
+					VendorExtensible:    spec.VendorExtensible{Extensions: extensions},
+				},
+			},
+		}
+	}
+
+	specV2Security := newSpecV2Security(spec.Extensions{extTfCloudIdentityProvider: "gcp", extTfCloudIdentityAudience: "https://api.example.com"})
+	securityDefinitions, err := specV2Security.GetAPIKeySecurityDefinitions()
+	assert.NoError(t, err)
+	assert.Equal(t, &SpecSecurityDefinitions{newAPIKeyHeaderCloudIdentitySecurityDefinition("cloud_identity", "gcp", "https://api.example.com")}, securityDefinitions)
+
+	specV2Security = newSpecV2Security(spec.Extensions{extTfCloudIdentityProvider: "gcp"})
+	_, err = specV2Security.GetAPIKeySecurityDefinitions()
+	assert.EqualError(t, err, "security definition 'cloud_identity' is missing the x-terraform-cloud-identity-audience extension, which is required for the 'gcp' cloud identity provider")
+}
+
+func TestGetAPIKeySecurityDefinitionsHeaderTemplate(t *testing.T) {
+	newSpecV2Security := func(extensions spec.Extensions) specV2Security {
+		return specV2Security{
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"okta_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{In: "header", Type: "apiKey", Name: authorizationHeader},
+					VendorExtensible:    spec.VendorExtensible{Extensions: extensions},
+				},
+			},
+		}
+	}
+
+	specV2Security := newSpecV2Security(spec.Extensions{extTfAuthenticatorHeaderTemplate: "SSWS %s"})
+	securityDefinitions, err := specV2Security.GetAPIKeySecurityDefinitions()
+	assert.NoError(t, err)
+	assert.Equal(t, &SpecSecurityDefinitions{newAPIKeyHeaderTemplateSecurityDefinition("okta_auth", authorizationHeader, "SSWS %s")}, securityDefinitions)
+
+	specV2Security = newSpecV2Security(spec.Extensions{extTfAuthenticatorHeaderTemplate: "SSWS"})
+	_, err = specV2Security.GetAPIKeySecurityDefinitions()
+	assert.EqualError(t, err, "security definition 'okta_auth' x-terraform-authenticator-header-template 'SSWS' not valid, the template must contain the %s placeholder exactly once")
+
+	specV2Security = newSpecV2Security(spec.Extensions{extTfAuthenticatorHeaderTemplate: "Token %s", extTfAuthenticationSchemeBearer: true})
+	_, err = specV2Security.GetAPIKeySecurityDefinitions()
+	assert.EqualError(t, err, "security definition 'okta_auth' can not have both the x-terraform-authenticator-header-template and x-terraform-authentication-scheme-bearer extensions, please define the Bearer scheme in the header template instead (e,g: \"Bearer %s\")")
+}
+
+func TestGetAPIKeySecurityDefinitionsOAuth2ClientCredentials(t *testing.T) {
+	newSpecV2Security := func(flow, tokenURL string) specV2Security {
+		return specV2Security{
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"oauth2_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{Type: "oauth2", Flow: flow, TokenURL: tokenURL},
+				},
+			},
+		}
+	}
+
+	specV2Security := newSpecV2Security("application", "https://auth.example.com/oauth2/token")
+	securityDefinitions, err := specV2Security.GetAPIKeySecurityDefinitions()
+	assert.NoError(t, err)
+	assert.Equal(t, &SpecSecurityDefinitions{newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", "https://auth.example.com/oauth2/token")}, securityDefinitions)
+
+	specV2Security = newSpecV2Security("accessCode", "https://auth.example.com/oauth2/token")
+	securityDefinitions, err = specV2Security.GetAPIKeySecurityDefinitions()
+	assert.NoError(t, err)
+	assert.Empty(t, *securityDefinitions, "oauth2 flows other than the client credentials one should be ignored")
+
+	specV2Security = newSpecV2Security("application", "")
+	_, err = specV2Security.GetAPIKeySecurityDefinitions()
+	assert.EqualError(t, err, "security definition 'oauth2_auth' is missing the mandatory tokenUrl")
+}
+
+func TestGetSecurityDefinitionDescription(t *testing.T) {
+	specV2Security := specV2Security{
+		SecurityDefinitions: spec.SecurityDefinitions{
+			"apikey_auth": &spec.SecurityScheme{
+				SecuritySchemeProps: spec.SecuritySchemeProps{In: "header", Type: "apiKey", Name: authorizationHeader, Description: "API key issued in the account settings"},
+			},
+		},
+	}
+	assert.Equal(t, "API key issued in the account settings", specV2Security.GetSecurityDefinitionDescription("apikey_auth"))
+	assert.Empty(t, specV2Security.GetSecurityDefinitionDescription("non_existing"))
+}
@@ -1,9 +1,10 @@
 package openapi
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"regexp"
 	"strings"
@@ -27,7 +28,17 @@ func newSpecAnalyserV2(openAPIDocumentFilename string) (*specV2Analyser, error)
 	if openAPIDocumentFilename == "" {
 		return nil, errors.New("open api document filename argument empty, please provide the url of the OpenAPI document")
 	}
-	apiSpec, err := loads.JSONSpec(openAPIDocumentFilename)
+	document, err := loadDocument(openAPIDocumentFilename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
+	}
+	return newSpecAnalyserV2FromDocument(openAPIDocumentFilename, document)
+}
+
+// newSpecAnalyserV2FromDocument creates the specV2Analyser for the content of the OpenAPI v2 document already loaded from
+// the URL passed in (e,g: to detect its spec format), so the document is not retrieved again
+func newSpecAnalyserV2FromDocument(openAPIDocumentFilename string, document []byte) (*specV2Analyser, error) {
+	apiSpec, err := loads.Analyzed(document, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
 	}
@@ -48,17 +59,17 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantDataSources() []SpecRes
 	for resourcePath, pathItem := range paths.Paths {
 		schemaDefinition, err := specAnalyser.isEndPointTerraformDataSourceCompliant(pathItem)
 		if err != nil {
-			log.Printf("[DEBUG] resource path '%s' not terraform data source compliant: %s", resourcePath, err)
+			analyserLog.Debug("resource path '%s' not terraform data source compliant: %s", resourcePath, err)
 			continue
 		}
 
 		d, err := newSpecV2DataSource(resourcePath, *schemaDefinition, pathItem, specAnalyser.d.Spec().Paths.Paths)
 		if err != nil {
-			log.Printf("[WARN] ignoring data source '%s' due to an error while creating a creating the SpecV2Resource: %s", resourcePath, err)
+			analyserLog.Warn("ignoring data source '%s' due to an error while creating a creating the SpecV2Resource: %s", resourcePath, err)
 			continue
 		}
 
-		log.Printf("[INFO] found terraform compliant data source [name='%s', rootPath='%s']", d.GetResourceName(), resourcePath)
+		analyserLog.Info("found terraform compliant data source [name='%s', rootPath='%s']", d.GetResourceName(), resourcePath)
 		dataSources = append(dataSources, d)
 	}
 	return dataSources
@@ -72,30 +83,30 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantResources() ([]SpecReso
 	for resourcePath, pathItem := range paths.Paths {
 		resourceRootPath, resourceRoot, resourcePayloadSchemaDef, err := specAnalyser.isEndPointFullyTerraformResourceCompliant(resourcePath)
 		if err != nil {
-			log.Printf("[DEBUG] resource path '%s' not terraform compliant: %s", resourcePath, err)
+			analyserLog.Debug("resource path '%s' not terraform compliant: %s", resourcePath, err)
 			continue
 		}
 
 		r, err := newSpecV2Resource(resourceRootPath, *resourcePayloadSchemaDef, *resourceRoot, pathItem, specAnalyser.d.Spec().Definitions, specAnalyser.d.Spec().Paths.Paths)
 		if err != nil {
-			log.Printf("[WARN] ignoring resource '%s' due to an error while creating a creating the SpecV2Resource: %s", resourceRootPath, err)
+			analyserLog.Warn("ignoring resource '%s' due to an error while creating a creating the SpecV2Resource: %s", resourceRootPath, err)
 			continue
 		}
 
-		err = specAnalyser.validateSubResourceTerraformCompliance(*r)
+		err = specAnalyser.validateSubResourceTerraformCompliance(r)
 		if err != nil {
-			log.Printf("[WARN] ignoring subresource name='%s' with rootPath='%s' due to not meeting validation requirements: %s", r.GetResourceName(), resourceRootPath, err)
+			analyserLog.Warn("ignoring subresource name='%s' with rootPath='%s' due to not meeting validation requirements: %s", r.GetResourceName(), resourceRootPath, err)
 			continue
 		}
 
-		log.Printf("[INFO] found terraform compliant resource [name='%s', rootPath='%s', instancePath='%s']", r.GetResourceName(), resourceRootPath, resourcePath)
+		analyserLog.Info("found terraform compliant resource [name='%s', rootPath='%s', instancePath='%s']", r.GetResourceName(), resourceRootPath, resourcePath)
 		resources = append(resources, r)
 	}
-	log.Printf("[INFO] found %d terraform compliant resources (time: %s)", len(resources), time.Since(start))
+	analyserLog.Info("found %d terraform compliant resources (time: %s)", len(resources), time.Since(start))
 	return resources, nil
 }
 
-func (specAnalyser *specV2Analyser) validateSubResourceTerraformCompliance(r SpecV2Resource) error {
+func (specAnalyser *specV2Analyser) validateSubResourceTerraformCompliance(r *SpecV2Resource) error {
 	parentResourceInfo := r.GetParentResourceInfo()
 	if parentResourceInfo != nil {
 		resourcePath := r.Path
@@ -121,7 +132,7 @@ func (specAnalyser *specV2Analyser) validateSubResourceTerraformCompliance(r Spe
 func (specAnalyser *specV2Analyser) pathExists(path string) (bool, spec.PathItem) {
 	p, exists := specAnalyser.d.Spec().Paths.Paths[path]
 	if !exists {
-		log.Printf("[WARN] path %s not found, falling back to checking if the path with trailing slash %s/ exists", path, path)
+		analyserLog.Warn("path %s not found, falling back to checking if the path with trailing slash %s/ exists", path, path)
 		p, exists = specAnalyser.d.Spec().Paths.Paths[path+"/"]
 		if !exists {
 			return false, spec.PathItem{}
@@ -150,6 +161,18 @@ func (specAnalyser *specV2Analyser) GetAPIBackendConfiguration() (SpecBackendCon
 	return newOpenAPIBackendConfigurationV2(specAnalyser.d.Spec(), specAnalyser.openAPIDocumentURL)
 }
 
+// GetSpecInfo returns the API version documented in the info section of the swagger file along with the SHA256 checksum
+// of the raw (not expanded) swagger document
+func (specAnalyser *specV2Analyser) GetSpecInfo() SpecInfo {
+	specInfo := SpecInfo{}
+	if info := specAnalyser.d.Spec().Info; info != nil {
+		specInfo.Version = info.Version
+	}
+	checksum := sha256.Sum256(specAnalyser.d.Raw())
+	specInfo.Checksum = hex.EncodeToString(checksum[:])
+	return specInfo
+}
+
 // isEndPointFullyTerraformResourceCompliant returns true only if:
 // - The path given 'resourcePath' is an instance path (e,g: "/users/{username}")
 // - The path given has GET operation defined (required). PUT and DELETE are optional
@@ -204,7 +227,7 @@ func (specAnalyser *specV2Analyser) GetAPIBackendConfiguration() (SpecBackendCon
 // then the expected returned value is true. Otherwise if the above criteria is not met, it is considered that
 // the resourcePath provided is not terraform resource compliant.
 func (specAnalyser *specV2Analyser) isEndPointFullyTerraformResourceCompliant(resourcePath string) (string, *spec.PathItem, *spec.Schema, error) {
-	log.Printf("[DEBUG] validating end point terraform compatibility %s", resourcePath)
+	analyserLog.Debug("validating end point terraform compatibility %s", resourcePath)
 	err := specAnalyser.validateInstancePath(resourcePath)
 	if err != nil {
 		return "", nil, nil, err
@@ -249,12 +272,43 @@ func (specAnalyser *specV2Analyser) validateInstancePath(path string) error {
 		return fmt.Errorf("path '%s' is not a resource instance path", path)
 	}
 	endPoint := specAnalyser.d.Spec().Paths.Paths[path]
-	if endPoint.Get == nil {
+	if endPoint.Get == nil && !specAnalyser.readPathDefined(path) {
 		return fmt.Errorf("resource instance path '%s' missing required GET operation", path)
 	}
 	return nil
 }
 
+// readPathDefined checks whether the resource the instance path belongs to is read from a different path, configured
+// in the root path POST operation with the 'x-terraform-read-path' extension
+func (specAnalyser *specV2Analyser) readPathDefined(resourceInstancePath string) bool {
+	resourceRootPath, err := specAnalyser.findMatchingResourceRootPath(resourceInstancePath)
+	if err != nil {
+		return false
+	}
+	resourceRootPathItem := specAnalyser.d.Spec().Paths.Paths[resourceRootPath]
+	return getResourceReadPath(resourceRootPathItem.Post) != ""
+}
+
+// validateReadPath checks that the read path configured with the 'x-terraform-read-path' extension (if any) is defined
+// in the spec with a GET operation and contains the path parameter for the resource instance id
+func (specAnalyser *specV2Analyser) validateReadPath(resourceRootPath string, resourceRootPostOperation *spec.Operation) error {
+	readPath := getResourceReadPath(resourceRootPostOperation)
+	if readPath == "" {
+		return nil
+	}
+	readPathItem, exists := specAnalyser.d.Spec().Paths.Paths[readPath]
+	if !exists {
+		return fmt.Errorf("resource root path '%s' %s '%s' is not defined", resourceRootPath, extTfReadPath, readPath)
+	}
+	if readPathItem.Get == nil {
+		return fmt.Errorf("resource root path '%s' %s '%s' missing required GET operation", resourceRootPath, extTfReadPath, readPath)
+	}
+	if !specAnalyser.isResourceInstanceEndPoint(readPath) {
+		return fmt.Errorf("resource root path '%s' %s '%s' is not a resource instance path", resourceRootPath, extTfReadPath, readPath)
+	}
+	return nil
+}
+
 func (specAnalyser *specV2Analyser) validateRootPath(resourcePath string) (string, *spec.PathItem, *spec.Schema, error) {
 	resourceRootPath, err := specAnalyser.findMatchingResourceRootPath(resourcePath)
 	if err != nil {
@@ -269,6 +323,10 @@ func (specAnalyser *specV2Analyser) validateRootPath(resourcePath string) (strin
 	resourceRootPathItem, _ := specAnalyser.d.Spec().Paths.Paths[resourceRootPath]
 	resourceRootPostOperation := resourceRootPathItem.Post
 
+	if err := specAnalyser.validateReadPath(resourceRootPath, resourceRootPostOperation); err != nil {
+		return "", nil, nil, err
+	}
+
 	resourceRootPostRequestSchemaDef, err := specAnalyser.getBodyParameterBodySchema(resourceRootPostOperation)
 	if err != nil {
 		bodyParam := specAnalyser.bodyParameterExists(resourceRootPostOperation)
@@ -289,24 +347,24 @@ func (specAnalyser *specV2Analyser) validateRootPath(resourcePath string) (strin
 
 	resourceRootPostResponseSchemaDef, err := specAnalyser.getSuccessfulResponseDefinition(resourceRootPostOperation)
 	if err != nil {
-		log.Printf("[DEBUG] failed to get the resource '%s' root path POST successful response configuration: %s", resourceRootPath, err)
+		analyserLog.Debug("failed to get the resource '%s' root path POST successful response configuration: %s", resourceRootPath, err)
 		return "", nil, nil, fmt.Errorf("resource root path '%s' POST operation is missing a successful response definition: %s", resourceRootPath, err)
 	}
 
 	if specAnalyser.schemaIsEqual(resourceRootPostRequestSchemaDef, resourceRootPostResponseSchemaDef) {
-		log.Printf("[DEBUG] resource '%s' root path POST's req and resp schema definitions are the same", resourceRootPath)
+		analyserLog.Debug("resource '%s' root path POST's req and resp schema definitions are the same", resourceRootPath)
 		return resourceRootPath, &resourceRootPathItem, resourceRootPostRequestSchemaDef, nil
 	}
 
 	// Use case where resource POST's request payload model is different than the response payload (eg: request payload does not contain the id property (or any computed properties) and the response payload contains the inputs (as computed props already) and any other computed property that might be returned by the POST operation
-	log.Printf("[DEBUG] resource '%s' root path POST's req and resp schemas not matching, checking if request schema is contained in the response schema and attemping to merge into one schema containing both the request and response schemas that contain both the required/optional inputs as well as all the computed properties", resourceRootPath)
+	analyserLog.Debug("resource '%s' root path POST's req and resp schemas not matching, checking if request schema is contained in the response schema and attemping to merge into one schema containing both the request and response schemas that contain both the required/optional inputs as well as all the computed properties", resourceRootPath)
 	// if response payload contains the request properties but readOnly then that's a valid use case too
 	mergedPostReqAndRespPayloadSchemas, err := specAnalyser.mergeRequestAndResponseSchemas(resourceRootPostRequestSchemaDef, resourceRootPostResponseSchemaDef)
 	if err != nil {
-		log.Printf("[DEBUG] failed to merge resource '%s' root path POST request and response schemas: %s", resourceRootPath, err)
+		analyserLog.Debug("failed to merge resource '%s' root path POST request and response schemas: %s", resourceRootPath, err)
 		return "", nil, nil, fmt.Errorf("resource root path '%s' POST operation does not meet any of the supported use cases", resourceRootPath)
 	}
-	log.Printf("[INFO] resource '%s' root path POST's req and resp merged into one: %+v", resourceRootPath, mergedPostReqAndRespPayloadSchemas)
+	analyserLog.Info("resource '%s' root path POST's req and resp merged into one: %+v", resourceRootPath, mergedPostReqAndRespPayloadSchemas)
 	return resourceRootPath, &resourceRootPathItem, mergedPostReqAndRespPayloadSchemas, nil
 }
 
@@ -345,7 +403,7 @@ func (specAnalyser *specV2Analyser) mergeRequestAndResponseSchemas(requestSchema
 	// the response schema with the requests it would override the original response schema property too
 	for responsePropName, responseProp := range responseSchemaProps {
 		if !responseProp.ReadOnly {
-			log.Printf("[WARN] resource's response schema property '%s' must be readOnly as response properties are considered computed (returned by the API). Therefore, the provider will automatically convert it to readOnly in the final resource schema", responsePropName)
+			analyserLog.Warn("resource's response schema property '%s' must be readOnly as response properties are considered computed (returned by the API). Therefore, the provider will automatically convert it to readOnly in the final resource schema", responsePropName)
 			responseProp.ReadOnly = true
 		}
 		mergedSchema.Properties[responsePropName] = responseProp
@@ -503,7 +561,7 @@ func (specAnalyser *specV2Analyser) isResourceInstanceEndPoint(p string) bool {
 func (specAnalyser *specV2Analyser) findMatchingResourceRootPath(resourceInstancePath string) (string, error) {
 	r, _ := regexp.Compile(resourceInstanceRegex)
 	result := r.FindStringSubmatch(resourceInstancePath)
-	log.Printf("[DEBUG] resource '%s' root path match: %s", resourceInstancePath, result)
+	analyserLog.Debug("resource '%s' root path match: %s", resourceInstancePath, result)
 	if len(result) != 2 {
 		return "", fmt.Errorf("resource instance path '%s' missing valid resource root path, more than two results returned from match '%s'", resourceInstancePath, result)
 	}
@@ -511,14 +569,14 @@ func (specAnalyser *specV2Analyser) findMatchingResourceRootPath(resourceInstanc
 	resourceRootPath := result[1] // e,g: /v1/cdns/{id} /v1/cdns/
 
 	if _, exists := specAnalyser.d.Spec().Paths.Paths[resourceRootPath]; exists {
-		log.Printf("[DEBUG] found resource root path with trailing '/' - %+s", resourceRootPath)
+		analyserLog.Debug("found resource root path with trailing '/' - %+s", resourceRootPath)
 		return resourceRootPath, nil
 	}
 
 	// Handles the case where the swagger file root path does not have a trailing slash in the path
 	resourceRootPath = strings.TrimRight(resourceRootPath, "/")
 	if _, exists := specAnalyser.d.Spec().Paths.Paths[resourceRootPath]; exists {
-		log.Printf("[DEBUG] found resource root path without trailing '/' - %+s", resourceRootPath)
+		analyserLog.Debug("found resource root path without trailing '/' - %+s", resourceRootPath)
 		return resourceRootPath, nil
 	}
 
@@ -1968,6 +1968,35 @@ func TestGetAPIBackendConfiguration(t *testing.T) {
 	})
 }
 
+func TestGetSpecInfo(t *testing.T) {
+	testCases := []struct {
+		name            string
+		swaggerJSON     string
+		expectedVersion string
+	}{
+		{
+			name:            "swagger with info version",
+			swaggerJSON:     `{"swagger":"2.0","info":{"version":"1.2.3","title":"some api"}}`,
+			expectedVersion: "1.2.3",
+		},
+		{
+			name:            "swagger without info section",
+			swaggerJSON:     `{"swagger":"2.0"}`,
+			expectedVersion: "",
+		},
+	}
+	for _, tc := range testCases {
+		a := initAPISpecAnalyser(tc.swaggerJSON)
+		specInfo := a.GetSpecInfo()
+		assert.Equal(t, tc.expectedVersion, specInfo.Version, tc.name)
+		assert.Len(t, specInfo.Checksum, 64, tc.name)
+		assert.Equal(t, specInfo.Checksum, a.GetSpecInfo().Checksum, tc.name)
+	}
+	a := initAPISpecAnalyser(`{"swagger":"2.0","info":{"version":"1.2.4","title":"some api"}}`)
+	b := initAPISpecAnalyser(`{"swagger":"2.0","info":{"version":"1.2.3","title":"some api"}}`)
+	assert.NotEqual(t, a.GetSpecInfo().Checksum, b.GetSpecInfo().Checksum)
+}
+
 func TestResourceInstanceEndPoint(t *testing.T) {
 	Convey("Given an specV2Analyser", t, func() {
 		a := specV2Analyser{}
@@ -3594,7 +3623,7 @@ func getExpectedResource(terraformCompliantResources []SpecResource, expectedRes
 func TestValidateSubResourceTerraformCompliance(t *testing.T) {
 	type testCasesDef []struct {
 		name          string
-		inputResource SpecV2Resource
+		inputResource *SpecV2Resource
 		expectedError string
 	}
 
@@ -3607,12 +3636,12 @@ paths:
   /cdns/{id}/firewalls/{id}:`
 		a := initAPISpecAnalyser(swaggerContent)
 		testCases := testCasesDef{
-			{name: "resource containing a subresource path where the parent path exists in the swagger file", inputResource: SpecV2Resource{Path: "/cdns/{id}/firewalls"}, expectedError: ""},
-			{name: "resource containing a subresource path where the input resource path path params DO NOT match the parents", inputResource: SpecV2Resource{Path: "/cdns/{cdn_id}/firewalls"}, expectedError: "subresource with path '/cdns/{cdn_id}/firewalls' is missing parent path instance definition '/cdns/{cdn_id}'"},
-			{name: "resource containing a subresource path (containing multiple parents) where the parent paths exist in the swagger file", inputResource: SpecV2Resource{Path: "/cdns/{id}/firewalls/{id}/rules"}, expectedError: ""},
-			{name: "resource containing a subresource path (containing multiple parents) where one of the parent path DOES NOT exist in the swagger file", inputResource: SpecV2Resource{Path: "/notexisting/{id}/firewalls/{id}/rules"}, expectedError: "subresource with path '/notexisting/{id}/firewalls/{id}/rules' is missing parent path instance definition '/notexisting/{id}'"},
-			{name: "resource containing a subresource path where the parent path DOES NOT exists in the swagger file", inputResource: SpecV2Resource{Path: "/resource/{id}/firewalls"}, expectedError: "subresource with path '/resource/{id}/firewalls' is missing parent path instance definition '/resource/{id}'"},
-			{name: "resource that is not a subresource", inputResource: SpecV2Resource{Path: "/cdns"}, expectedError: ""},
+			{name: "resource containing a subresource path where the parent path exists in the swagger file", inputResource: &SpecV2Resource{Path: "/cdns/{id}/firewalls"}, expectedError: ""},
+			{name: "resource containing a subresource path where the input resource path path params DO NOT match the parents", inputResource: &SpecV2Resource{Path: "/cdns/{cdn_id}/firewalls"}, expectedError: "subresource with path '/cdns/{cdn_id}/firewalls' is missing parent path instance definition '/cdns/{cdn_id}'"},
+			{name: "resource containing a subresource path (containing multiple parents) where the parent paths exist in the swagger file", inputResource: &SpecV2Resource{Path: "/cdns/{id}/firewalls/{id}/rules"}, expectedError: ""},
+			{name: "resource containing a subresource path (containing multiple parents) where one of the parent path DOES NOT exist in the swagger file", inputResource: &SpecV2Resource{Path: "/notexisting/{id}/firewalls/{id}/rules"}, expectedError: "subresource with path '/notexisting/{id}/firewalls/{id}/rules' is missing parent path instance definition '/notexisting/{id}'"},
+			{name: "resource containing a subresource path where the parent path DOES NOT exists in the swagger file", inputResource: &SpecV2Resource{Path: "/resource/{id}/firewalls"}, expectedError: "subresource with path '/resource/{id}/firewalls' is missing parent path instance definition '/resource/{id}'"},
+			{name: "resource that is not a subresource", inputResource: &SpecV2Resource{Path: "/cdns"}, expectedError: ""},
 		}
 
 		for _, tc := range testCases {
@@ -3634,8 +3663,8 @@ paths:
   /v1/cdns/{id}/v2/firewalls/{id}:`
 		a := initAPISpecAnalyser(swaggerContent)
 		testCases := testCasesDef{
-			{name: "subresource path where the parent path exists in the swagger file", inputResource: SpecV2Resource{Path: "/v1/cdns/{id}/v2/firewalls"}, expectedError: ""},
-			{name: "subresource path (containing multiple parents) where the parent paths exist in the swagger file", inputResource: SpecV2Resource{Path: "/v1/cdns/{id}/v2/firewalls/{id}/rules"}, expectedError: ""},
+			{name: "subresource path where the parent path exists in the swagger file", inputResource: &SpecV2Resource{Path: "/v1/cdns/{id}/v2/firewalls"}, expectedError: ""},
+			{name: "subresource path (containing multiple parents) where the parent paths exist in the swagger file", inputResource: &SpecV2Resource{Path: "/v1/cdns/{id}/v2/firewalls/{id}/rules"}, expectedError: ""},
 		}
 		for _, tc := range testCases {
 			Convey(fmt.Sprintf("When validateSubResourceTerraformCompliance method is called with a %s", tc.name), func() {
@@ -3656,8 +3685,8 @@ paths:
   /cdns/{id}/firewalls/{id}/:`
 		a := initAPISpecAnalyser(swaggerContent)
 		testCases := testCasesDef{
-			{name: "1 level subresource path where the parent path exists in the swagger file", inputResource: SpecV2Resource{Path: "/cdns/{id}/firewalls"}, expectedError: ""},
-			{name: "1 level subresource path with trailing / where the parent path exists in the swagger file", inputResource: SpecV2Resource{Path: "/cdns/{id}/firewalls/"}, expectedError: ""},
+			{name: "1 level subresource path where the parent path exists in the swagger file", inputResource: &SpecV2Resource{Path: "/cdns/{id}/firewalls"}, expectedError: ""},
+			{name: "1 level subresource path with trailing / where the parent path exists in the swagger file", inputResource: &SpecV2Resource{Path: "/cdns/{id}/firewalls/"}, expectedError: ""},
 		}
 		for _, tc := range testCases {
 			Convey(fmt.Sprintf("When validateSubResourceTerraformCompliance method is called with a %s", tc.name), func() {
@@ -3678,7 +3707,7 @@ paths:
   /cdns/{id}:`
 		a := initAPISpecAnalyser(swaggerContent)
 		Convey("When validateSubResourceTerraformCompliance method is called with a subresource path where the parent path exists in the swagger file", func() {
-			inputResource := SpecV2Resource{Path: "/cdns/{id}/firewalls"}
+			inputResource := &SpecV2Resource{Path: "/cdns/{id}/firewalls"}
 			err := a.validateSubResourceTerraformCompliance(inputResource)
 			Convey("Then the error returned should be the expected one", func() {
 				So(err.Error(), ShouldEqual, "subresource with path '/cdns/{id}/firewalls' contains a parent /cdns that is marked as ignored, therefore ignoring the subresource too")
@@ -3692,7 +3721,7 @@ paths:
   /cdns/{id}:`
 		a := initAPISpecAnalyser(swaggerContent)
 		Convey("When validateSubResourceTerraformCompliance method is called with a subresource path where the parent path DOES NOT exists in the swagger file", func() {
-			inputResource := SpecV2Resource{Path: "/cdns/{id}/firewalls"}
+			inputResource := &SpecV2Resource{Path: "/cdns/{id}/firewalls"}
 			err := a.validateSubResourceTerraformCompliance(inputResource)
 			Convey("Then the error returned should be the expected one", func() {
 				So(err.Error(), ShouldEqual, "subresource with path '/cdns/{id}/firewalls' is missing parent root path definition '/cdns'")
@@ -4740,6 +4769,125 @@ definitions:
 			})
 		})
 	})
+	Convey("Given an specV2Analyser loaded with a swagger file containing a resource /v1/deployments that is read from the path configured in the x-terraform-read-path extension", t, func() {
+		swaggerContent := `swagger: "2.0"
+host: 127.0.0.1
+paths:
+ /v1/deployments:
+   post:
+     x-terraform-read-path: "/v1/clusters/{cluster_id}"
+     parameters:
+     - in: "body"
+       name: "body"
+       required: true
+       schema:
+         $ref: "#/definitions/Deployment"
+     responses:
+       201:
+         schema:
+           $ref: "#/definitions/Deployment"
+ /v1/deployments/{id}:
+   delete:
+     parameters:
+     - name: "id"
+       in: "path"
+       required: true
+       type: "string"
+     responses:
+       204:
+         description: "successful operation, no content is returned"
+ /v1/clusters/{cluster_id}:
+   get:
+     parameters:
+     - name: "cluster_id"
+       in: "path"
+       required: true
+       type: "string"
+     responses:
+       200:
+         schema:
+           $ref: "#/definitions/Deployment"
+definitions:
+ Deployment:
+   type: "object"
+   properties:
+     id:
+       type: "string"
+       readOnly: true
+     label:
+       type: "string"`
+
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When GetTerraformCompliantResources method is called ", func() {
+			terraformCompliantResources, err := a.GetTerraformCompliantResources()
+			Convey("Then the resource should be considered compliant even though the instance path is missing the GET operation", func() {
+				So(err, ShouldBeNil)
+				So(len(terraformCompliantResources), ShouldEqual, 1)
+				So(terraformCompliantResources[0].GetResourceName(), ShouldEqual, "deployments_v1")
+				readPath, err := terraformCompliantResources[0].getReadPath(nil, "1234")
+				So(err, ShouldBeNil)
+				So(readPath, ShouldEqual, "/v1/clusters/1234")
+				So(terraformCompliantResources[0].getResourceOperations().Get, ShouldNotBeNil)
+			})
+		})
+	})
+	Convey("Given an specV2Analyser loaded with a swagger file containing a resource /v1/deployments that is read from a path that is not defined in the spec", t, func() {
+		swaggerContent := `swagger: "2.0"
+host: 127.0.0.1
+paths:
+ /v1/deployments:
+   post:
+     x-terraform-read-path: "/v1/nodes/{node_id}"
+     parameters:
+     - in: "body"
+       name: "body"
+       required: true
+       schema:
+         $ref: "#/definitions/Deployment"
+     responses:
+       201:
+         schema:
+           $ref: "#/definitions/Deployment"
+ /v1/deployments/{id}:
+   delete:
+     parameters:
+     - name: "id"
+       in: "path"
+       required: true
+       type: "string"
+     responses:
+       204:
+         description: "successful operation, no content is returned"
+ /v1/clusters/{cluster_id}:
+   get:
+     parameters:
+     - name: "cluster_id"
+       in: "path"
+       required: true
+       type: "string"
+     responses:
+       200:
+         schema:
+           $ref: "#/definitions/Deployment"
+definitions:
+ Deployment:
+   type: "object"
+   properties:
+     id:
+       type: "string"
+       readOnly: true
+     label:
+       type: "string"`
+
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When GetTerraformCompliantResources method is called ", func() {
+			terraformCompliantResources, err := a.GetTerraformCompliantResources()
+			Convey("Then the list of resources returned should be empty since the read path is not defined in the spec", func() {
+				So(err, ShouldBeNil)
+				So(terraformCompliantResources, ShouldBeEmpty)
+			})
+		})
+	})
 
 }
 
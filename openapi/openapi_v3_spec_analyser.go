@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// specV3Analyser defines an SpecAnalyser implementation for OpenAPI v3.0 specification. The OpenAPI v3 document is translated
+// into the equivalent OpenAPI v2 document (see openAPIV3Translator) which is then analysed by the OpenAPI v2 SpecAnalyser,
+// so the OpenAPI v3 documents support the same terraform compliant resource requirements and extensions
+type specV3Analyser struct {
+	*specV2Analyser
+	// document contains the raw OpenAPI v3 document
+	document []byte
+}
+
+// newSpecAnalyserV3 creates an instance of specV3Analyser which implements the SpecAnalyser interface
+// This implementation provides an analyser that understands an OpenAPI v3.0 document
+func newSpecAnalyserV3(openAPIDocumentURL string) (*specV3Analyser, error) {
+	if openAPIDocumentURL == "" {
+		return nil, errors.New("open api document filename argument empty, please provide the url of the OpenAPI document")
+	}
+	document, err := loadDocument(openAPIDocumentURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, err)
+	}
+	return newSpecAnalyserV3FromDocument(openAPIDocumentURL, document)
+}
+
+// newSpecAnalyserV3FromDocument creates the specV3Analyser for the content of the OpenAPI v3 document already loaded from
+// the URL passed in (e,g: to detect its spec format), so the document is not retrieved again
+func newSpecAnalyserV3FromDocument(openAPIDocumentURL string, document []byte) (*specV3Analyser, error) {
+	if !isOpenAPIV3Document(document) {
+		return nil, fmt.Errorf("the document '%s' is not an OpenAPI v3.0 document", openAPIDocumentURL)
+	}
+	translatedDocument, err := translateOpenAPIV3Document(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate the OpenAPI v3 document from '%s' - error = %s", openAPIDocumentURL, err)
+	}
+	specAnalyser, err := newSpecAnalyserV2(registerInMemoryDocument("openapi-v3", translatedDocument))
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyse the OpenAPI v3 document from '%s' - %s", openAPIDocumentURL, err)
+	}
+	// the host falls back to where the original document is served from if the servers are not specified or relative
+	specAnalyser.openAPIDocumentURL = openAPIDocumentURL
+	return &specV3Analyser{specV2Analyser: specAnalyser, document: document}, nil
+}
+
+// GetSpecInfo returns the API version documented in the info section of the OpenAPI document along with the SHA256
+// checksum of the raw OpenAPI v3 document
+func (specAnalyser *specV3Analyser) GetSpecInfo() SpecInfo {
+	specInfo := specAnalyser.specV2Analyser.GetSpecInfo()
+	checksum := sha256.Sum256(specAnalyser.document)
+	specInfo.Checksum = hex.EncodeToString(checksum[:])
+	return specInfo
+}
+
+// isOpenAPIV3Document returns true if the document passed in (JSON or YAML) is an OpenAPI v3.0 document
+func isOpenAPIV3Document(document []byte) bool {
+	doc := struct {
+		OpenAPI string `yaml:"openapi"`
+	}{}
+	if err := yaml.Unmarshal(document, &doc); err != nil {
+		return false
+	}
+	return strings.HasPrefix(doc.OpenAPI, "3.0")
+}
@@ -0,0 +1,207 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const openAPIV3Document = `openapi: 3.0.3
+info:
+  title: CDN API
+  version: 1.2.0
+servers:
+  - url: https://{environment}.example.com/api/
+    variables:
+      environment:
+        default: cdn
+security:
+  - bearer_auth: []
+paths:
+  /v1/cdns:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/ContentDeliveryNetwork'
+      responses:
+        201:
+          description: created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/ContentDeliveryNetwork'
+  /v1/cdns/{id}:
+    parameters:
+      - $ref: '#/components/parameters/ID'
+    get:
+      parameters:
+        - name: X-Request-ID
+          in: header
+          schema:
+            type: string
+      responses:
+        '200':
+          $ref: '#/components/responses/ContentDeliveryNetwork'
+    delete:
+      responses:
+        '204':
+          description: deleted
+components:
+  parameters:
+    ID:
+      name: id
+      in: path
+      required: true
+      schema:
+        type: string
+  responses:
+    ContentDeliveryNetwork:
+      description: the CDN
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/ContentDeliveryNetwork'
+  securitySchemes:
+    bearer_auth:
+      type: http
+      scheme: bearer
+  schemas:
+    ContentDeliveryNetwork:
+      type: object
+      description: Content delivery network
+      required:
+        - label
+      properties:
+        id:
+          type: string
+          readOnly: true
+        label:
+          type: string
+        description:
+          type: string
+          nullable: true
+        ips:
+          type: array
+          items:
+            type: string
+`
+
+func TestNewSpecAnalyserV3(t *testing.T) {
+	file := initAPISpecFile(openAPIV3Document)
+	defer os.Remove(file.Name())
+
+	specAnalyser, err := CreateSpecAnalyser("", file.Name())
+	require.NoError(t, err)
+	assert.IsType(t, &specV3Analyser{}, specAnalyser)
+
+	resources, err := specAnalyser.GetTerraformCompliantResources()
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "cdns_v1", resources[0].GetResourceName())
+	resourceSchema, err := resources[0].GetResourceSchema()
+	require.NoError(t, err)
+	assert.Equal(t, "Content delivery network", resourceSchema.Description)
+	label, err := resourceSchema.getProperty("label")
+	require.NoError(t, err)
+	assert.True(t, label.Required)
+	id, err := resourceSchema.getProperty("id")
+	require.NoError(t, err)
+	assert.True(t, id.ReadOnly)
+	ips, err := resourceSchema.getProperty("ips")
+	require.NoError(t, err)
+	assert.Equal(t, TypeList, ips.Type)
+
+	backendConfiguration, err := specAnalyser.GetAPIBackendConfiguration()
+	require.NoError(t, err)
+	host, err := backendConfiguration.getHost()
+	require.NoError(t, err)
+	assert.Equal(t, "cdn.example.com", host)
+	assert.Equal(t, "/api", backendConfiguration.getBasePath())
+	scheme, err := backendConfiguration.getHTTPScheme()
+	require.NoError(t, err)
+	assert.Equal(t, "https", scheme)
+
+	securityDefinitions, err := specAnalyser.GetSecurity().GetAPIKeySecurityDefinitions()
+	require.NoError(t, err)
+	require.Len(t, *securityDefinitions, 1)
+	securityDefinition := (*securityDefinitions)[0]
+	assert.Equal(t, "bearer_auth", securityDefinition.getName())
+	assert.Equal(t, authorizationHeader, securityDefinition.getAPIKey().Name)
+	assert.Equal(t, "Bearer some-token", securityDefinition.buildValue("some-token"))
+
+	assert.Equal(t, SpecHeaderParameters{SpecHeaderParam{Name: "X-Request-ID"}}, specAnalyser.GetAllHeaderParameters())
+
+	checksum := sha256.Sum256([]byte(openAPIV3Document))
+	assert.Equal(t, SpecInfo{Version: "1.2.0", Checksum: hex.EncodeToString(checksum[:])}, specAnalyser.GetSpecInfo())
+}
+
+func TestNewSpecAnalyserV3Errors(t *testing.T) {
+	swaggerFile := initAPISpecFile(`swagger: "2.0"`)
+	defer os.Remove(swaggerFile.Name())
+	brokenRefFile := initAPISpecFile(`openapi: 3.0.0
+paths:
+  /v1/cdns:
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/Missing'
+      responses: {}
+`)
+	defer os.Remove(brokenRefFile.Name())
+
+	testCases := []struct {
+		name          string
+		documentURL   string
+		expectedError string
+	}{
+		{name: "empty document URL", documentURL: "", expectedError: "open api document filename argument empty, please provide the url of the OpenAPI document"},
+		{name: "document can not be loaded", documentURL: "some non existing file", expectedError: "failed to retrieve the OpenAPI document from 'some non existing file' - error = open some non existing file: no such file or directory"},
+		{name: "document is not an OpenAPI v3 document", documentURL: swaggerFile.Name(), expectedError: "the document '" + swaggerFile.Name() + "' is not an OpenAPI v3.0 document"},
+		{name: "document with references to missing components", documentURL: brokenRefFile.Name(), expectedError: "failed to translate the OpenAPI v3 document from '" + brokenRefFile.Name() + "' - error = path '/v1/cdns' POST operation not valid: reference '#/components/requestBodies/Missing' not found, only references to the document components are supported"},
+	}
+	for _, tc := range testCases {
+		_, err := newSpecAnalyserV3(tc.documentURL)
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
+
+func TestNewSpecAnalyserV3RelativeServer(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("openapi: 3.0.0\nservers:\n  - url: /api\npaths: {}"))
+	}))
+	defer api.Close()
+	specAnalyser, err := CreateSpecAnalyser(specAnalyserV3, api.URL+"/openapi.yaml")
+	require.NoError(t, err)
+	backendConfiguration, err := specAnalyser.GetAPIBackendConfiguration()
+	require.NoError(t, err)
+	assert.Equal(t, "/api", backendConfiguration.getBasePath())
+	host, err := backendConfiguration.getHost()
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimPrefix(api.URL, "http://"), host, "the host should fall back to where the OpenAPI document is served from")
+}
+
+func TestIsOpenAPIV3Document(t *testing.T) {
+	testCases := []struct {
+		name     string
+		document string
+		expected bool
+	}{
+		{name: "OpenAPI v3 YAML document", document: "openapi: 3.0.3\ninfo:\n  title: some api", expected: true},
+		{name: "OpenAPI v3 JSON document", document: `{"openapi":"3.0.0","info":{"title":"some api"}}`, expected: true},
+		{name: "OpenAPI v3.1 document", document: `openapi: 3.1.0`, expected: false},
+		{name: "OpenAPI v2 document", document: `swagger: "2.0"`, expected: false},
+		{name: "document that is not JSON nor YAML", document: "{not valid", expected: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, isOpenAPIV3Document([]byte(tc.document)), tc.name)
+	}
+}
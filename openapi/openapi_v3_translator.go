@@ -0,0 +1,491 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// openAPIV3Methods contains the OpenAPI v3 operations that have an OpenAPI v2 equivalent (trace is not supported by OpenAPI v2)
+var openAPIV3Methods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// openAPIV3ParameterSchemaFields contains the schema fields that OpenAPI v2 defines in the (non body) parameters themselves
+var openAPIV3ParameterSchemaFields = []string{"type", "format", "items", "collectionFormat", "default", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "enum", "multipleOf"}
+
+// openAPIV3Translator translates an OpenAPI v3 document into the equivalent OpenAPI v2 (swagger) document:
+// - the first server is translated into the host, base path and schemes (server variables are replaced with their defaults)
+// - the components schemas are translated into definitions and the schema references updated accordingly
+// - the security schemes are translated into security definitions (http bearer schemes into apiKey Authorization headers
+// with the x-terraform-authentication-scheme-bearer extension)
+// - the request bodies are translated into body parameters and the response contents into response schemas, selecting
+// the JSON media type if there are several
+// - the components parameters, request bodies, responses and headers are inlined where referenced
+// The x-terraform extensions are kept, so the OpenAPI v3 documents support the same extensions as the OpenAPI v2 documents.
+// OpenAPI v3 features without OpenAPI v2 equivalent (e,g: cookie parameters, callbacks, links) are ignored
+type openAPIV3Translator struct {
+	document   map[string]interface{}
+	components map[string]interface{}
+}
+
+// translateOpenAPIV3Document returns the OpenAPI v2 document (JSON) equivalent to the OpenAPI v3 document (JSON or YAML)
+// passed in
+func translateOpenAPIV3Document(document []byte) ([]byte, error) {
+	var value interface{}
+	if err := yaml.Unmarshal(document, &value); err != nil {
+		return nil, fmt.Errorf("document is not valid JSON or YAML: %s", err)
+	}
+	openAPIDocument, ok := normalizeYAMLValue(value).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("document is not an object")
+	}
+	components, _ := openAPIDocument["components"].(map[string]interface{})
+	t := openAPIV3Translator{document: openAPIDocument, components: components}
+	swagger, err := t.translate()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(swagger)
+}
+
+// normalizeYAMLValue returns the value decoded from YAML with its maps keyed by strings, so it can be encoded as JSON
+// (e,g: response codes not quoted in the YAML document are decoded as integers)
+func normalizeYAMLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[fmt.Sprintf("%v", key)] = normalizeYAMLValue(item)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeYAMLValue(item)
+		}
+		return normalized
+	}
+	return value
+}
+
+func (t openAPIV3Translator) translate() (map[string]interface{}, error) {
+	swagger := map[string]interface{}{"swagger": "2.0"}
+	copyOpenAPIFields(t.document, swagger, "info", "tags", "externalDocs", "security")
+	copyOpenAPIExtensions(t.document, swagger)
+	if err := t.translateServers(swagger); err != nil {
+		return nil, err
+	}
+	definitions := map[string]interface{}{}
+	schemas, _ := t.components["schemas"].(map[string]interface{})
+	for name, schema := range schemas {
+		definitions[name] = translateOpenAPIV3Schema(schema)
+	}
+	swagger["definitions"] = definitions
+	securityDefinitions, err := t.translateSecuritySchemes()
+	if err != nil {
+		return nil, err
+	}
+	swagger["securityDefinitions"] = securityDefinitions
+	paths, err := t.translatePaths()
+	if err != nil {
+		return nil, err
+	}
+	swagger["paths"] = paths
+	return swagger, nil
+}
+
+// translateServers sets the host, base path and schemes of the swagger document based on the first server of the document.
+// Relative server URLs only set the base path, the host being the one the document is served from
+func (t openAPIV3Translator) translateServers(swagger map[string]interface{}) error {
+	servers, _ := t.document["servers"].([]interface{})
+	if len(servers) == 0 {
+		return nil
+	}
+	if len(servers) > 1 {
+		analyserLog.Warn("the OpenAPI v3 document defines %d servers, only the first one is used", len(servers))
+	}
+	server, _ := servers[0].(map[string]interface{})
+	serverURL, _ := server["url"].(string)
+	variables, _ := server["variables"].(map[string]interface{})
+	for name, value := range variables {
+		variable, _ := value.(map[string]interface{})
+		serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", fmt.Sprintf("%v", variable["default"]))
+	}
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return fmt.Errorf("server URL '%s' not valid: %s", serverURL, err)
+	}
+	if u.Host != "" {
+		swagger["host"] = u.Host
+	}
+	if u.Scheme != "" {
+		swagger["schemes"] = []interface{}{u.Scheme}
+	}
+	if basePath := strings.TrimSuffix(u.Path, "/"); basePath != "" {
+		swagger["basePath"] = basePath
+	}
+	return nil
+}
+
+// translateSecuritySchemes returns the security definitions equivalent to the security schemes of the document. The
+// security schemes without OpenAPI v2 equivalent (e,g: cookie API keys or openIdConnect) are ignored
+func (t openAPIV3Translator) translateSecuritySchemes() (map[string]interface{}, error) {
+	securityDefinitions := map[string]interface{}{}
+	securitySchemes, _ := t.components["securitySchemes"].(map[string]interface{})
+	for name, value := range securitySchemes {
+		securityScheme, err := t.resolve(value, "securitySchemes")
+		if err != nil {
+			return nil, err
+		}
+		securityDefinition := map[string]interface{}{}
+		copyOpenAPIFields(securityScheme, securityDefinition, "description")
+		schemeType, _ := securityScheme["type"].(string)
+		switch schemeType {
+		case "apiKey":
+			if securityScheme["in"] == "cookie" {
+				analyserLog.Warn("ignoring security scheme '%s': cookie API keys are not supported", name)
+				continue
+			}
+			copyOpenAPIFields(securityScheme, securityDefinition, "type", "name", "in")
+		case "http":
+			scheme, _ := securityScheme["scheme"].(string)
+			switch strings.ToLower(scheme) {
+			case "bearer":
+				securityDefinition["type"] = "apiKey"
+				securityDefinition["in"] = "header"
+				securityDefinition["name"] = authorizationHeader
+				securityDefinition[extTfAuthenticationSchemeBearer] = true
+			case "basic":
+				securityDefinition["type"] = "basic"
+			default:
+				analyserLog.Warn("ignoring security scheme '%s': http scheme '%s' not supported", name, scheme)
+				continue
+			}
+		case "oauth2":
+			flows, _ := securityScheme["flows"].(map[string]interface{})
+			flow, flowName := selectOAuth2Flow(flows)
+			if flow == nil {
+				analyserLog.Warn("ignoring security scheme '%s': no supported oauth2 flow found", name)
+				continue
+			}
+			securityDefinition["type"] = "oauth2"
+			securityDefinition["flow"] = flowName
+			securityDefinition["scopes"] = map[string]interface{}{}
+			copyOpenAPIFields(flow, securityDefinition, "authorizationUrl", "tokenUrl", "scopes")
+		default:
+			analyserLog.Warn("ignoring security scheme '%s': security scheme type '%s' not supported", name, schemeType)
+			continue
+		}
+		copyOpenAPIExtensions(securityScheme, securityDefinition)
+		securityDefinitions[name] = securityDefinition
+	}
+	return securityDefinitions, nil
+}
+
+// selectOAuth2Flow returns the first OAuth2 flow defined along with the name of the equivalent OpenAPI v2 flow
+func selectOAuth2Flow(flows map[string]interface{}) (map[string]interface{}, string) {
+	for _, f := range []struct{ v3, v2 string }{{"clientCredentials", "application"}, {"password", "password"}, {"authorizationCode", "accessCode"}, {"implicit", "implicit"}} {
+		if flow, ok := flows[f.v3].(map[string]interface{}); ok {
+			return flow, f.v2
+		}
+	}
+	return nil, ""
+}
+
+func (t openAPIV3Translator) translatePaths() (map[string]interface{}, error) {
+	paths := map[string]interface{}{}
+	documentPaths, _ := t.document["paths"].(map[string]interface{})
+	for path, value := range documentPaths {
+		pathItem, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		translatedPathItem := map[string]interface{}{}
+		copyOpenAPIExtensions(pathItem, translatedPathItem)
+		parameters, err := t.translateParameters(pathItem["parameters"])
+		if err != nil {
+			return nil, fmt.Errorf("path '%s' not valid: %s", path, err)
+		}
+		if len(parameters) > 0 {
+			translatedPathItem["parameters"] = parameters
+		}
+		for _, method := range openAPIV3Methods {
+			operation, ok := pathItem[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			translatedOperation, err := t.translateOperation(operation)
+			if err != nil {
+				return nil, fmt.Errorf("path '%s' %s operation not valid: %s", path, strings.ToUpper(method), err)
+			}
+			translatedPathItem[method] = translatedOperation
+		}
+		paths[path] = translatedPathItem
+	}
+	return paths, nil
+}
+
+func (t openAPIV3Translator) translateOperation(operation map[string]interface{}) (map[string]interface{}, error) {
+	translatedOperation := map[string]interface{}{}
+	copyOpenAPIFields(operation, translatedOperation, "tags", "summary", "description", "externalDocs", "operationId", "deprecated", "security")
+	copyOpenAPIExtensions(operation, translatedOperation)
+
+	parameters, err := t.translateParameters(operation["parameters"])
+	if err != nil {
+		return nil, err
+	}
+	if requestBody, exists := operation["requestBody"]; exists {
+		bodyParameter, mediaType, err := t.translateRequestBody(requestBody)
+		if err != nil {
+			return nil, err
+		}
+		if name, ok := operation["x-codegen-request-body-name"].(string); ok && name != "" {
+			bodyParameter["name"] = name
+		}
+		parameters = append(parameters, bodyParameter)
+		translatedOperation["consumes"] = []interface{}{mediaType}
+	}
+	if len(parameters) > 0 {
+		translatedOperation["parameters"] = parameters
+	}
+
+	responses := map[string]interface{}{}
+	mediaTypes := map[string]bool{}
+	operationResponses, _ := operation["responses"].(map[string]interface{})
+	for code, value := range operationResponses {
+		response, mediaType, err := t.translateResponse(value)
+		if err != nil {
+			return nil, fmt.Errorf("response '%s' not valid: %s", code, err)
+		}
+		if mediaType != "" {
+			mediaTypes[mediaType] = true
+		}
+		responses[code] = response
+	}
+	translatedOperation["responses"] = responses
+	if len(mediaTypes) > 0 {
+		var produces []interface{}
+		for _, mediaType := range sortedKeys(mediaTypes) {
+			produces = append(produces, mediaType)
+		}
+		translatedOperation["produces"] = produces
+	}
+	return translatedOperation, nil
+}
+
+// translateParameters returns the OpenAPI v2 equivalent of the (non body) parameters passed in. Cookie parameters are not
+// supported by OpenAPI v2 and are ignored
+func (t openAPIV3Translator) translateParameters(value interface{}) ([]interface{}, error) {
+	values, _ := value.([]interface{})
+	var parameters []interface{}
+	for _, value := range values {
+		parameter, err := t.resolve(value, "parameters")
+		if err != nil {
+			return nil, err
+		}
+		if parameter["in"] == "cookie" {
+			analyserLog.Warn("ignoring parameter '%v': cookie parameters are not supported", parameter["name"])
+			continue
+		}
+		translatedParameter := map[string]interface{}{}
+		copyOpenAPIFields(parameter, translatedParameter, "name", "in", "description", "required", "allowEmptyValue")
+		copyOpenAPIExtensions(parameter, translatedParameter)
+		schema, err := t.resolve(translateOpenAPIV3Schema(parameter["schema"]), "schemas")
+		if err != nil {
+			return nil, err
+		}
+		copyOpenAPIFields(schema, translatedParameter, openAPIV3ParameterSchemaFields...)
+		// query parameters are exploded by default in OpenAPI v3 (e,g: ?id=1&id=2)
+		if translatedParameter["type"] == "array" && parameter["in"] == "query" && parameter["explode"] != false {
+			translatedParameter["collectionFormat"] = "multi"
+		}
+		parameters = append(parameters, translatedParameter)
+	}
+	return parameters, nil
+}
+
+// translateRequestBody returns the body parameter equivalent to the request body passed in along with the media type
+// of the request body content selected
+func (t openAPIV3Translator) translateRequestBody(value interface{}) (map[string]interface{}, string, error) {
+	requestBody, err := t.resolve(value, "requestBodies")
+	if err != nil {
+		return nil, "", err
+	}
+	bodyParameter := map[string]interface{}{"in": "body", "name": "body"}
+	copyOpenAPIFields(requestBody, bodyParameter, "description", "required")
+	copyOpenAPIExtensions(requestBody, bodyParameter)
+	content, _ := requestBody["content"].(map[string]interface{})
+	mediaType, mediaTypeObject := selectOpenAPIV3MediaType(content)
+	if mediaTypeObject == nil {
+		return nil, "", fmt.Errorf("request body is missing the content")
+	}
+	bodyParameter["schema"] = translateOpenAPIV3Schema(mediaTypeObject["schema"])
+	return bodyParameter, mediaType, nil
+}
+
+// translateResponse returns the OpenAPI v2 equivalent of the response passed in along with the media type of the response
+// content selected; empty if the response has no content
+func (t openAPIV3Translator) translateResponse(value interface{}) (map[string]interface{}, string, error) {
+	response, err := t.resolve(value, "responses")
+	if err != nil {
+		return nil, "", err
+	}
+	translatedResponse := map[string]interface{}{"description": ""}
+	copyOpenAPIFields(response, translatedResponse, "description")
+	copyOpenAPIExtensions(response, translatedResponse)
+	if headers, ok := response["headers"].(map[string]interface{}); ok {
+		translatedHeaders := map[string]interface{}{}
+		for name, value := range headers {
+			header, err := t.resolve(value, "headers")
+			if err != nil {
+				return nil, "", err
+			}
+			translatedHeader := map[string]interface{}{}
+			copyOpenAPIFields(header, translatedHeader, "description")
+			schema, err := t.resolve(translateOpenAPIV3Schema(header["schema"]), "schemas")
+			if err != nil {
+				return nil, "", err
+			}
+			copyOpenAPIFields(schema, translatedHeader, openAPIV3ParameterSchemaFields...)
+			translatedHeaders[name] = translatedHeader
+		}
+		translatedResponse["headers"] = translatedHeaders
+	}
+	content, _ := response["content"].(map[string]interface{})
+	mediaType, mediaTypeObject := selectOpenAPIV3MediaType(content)
+	if mediaTypeObject == nil {
+		return translatedResponse, "", nil
+	}
+	if schema, exists := mediaTypeObject["schema"]; exists {
+		translatedResponse["schema"] = translateOpenAPIV3Schema(schema)
+	}
+	return translatedResponse, mediaType, nil
+}
+
+// resolve returns the object passed in, or the component it refers to if the object is a reference to a component of
+// the given type (e,g: #/components/parameters/ProjectID)
+func (t openAPIV3Translator) resolve(value interface{}, componentType string) (map[string]interface{}, error) {
+	object, _ := value.(map[string]interface{})
+	ref, isRef := object["$ref"].(string)
+	if !isRef {
+		return object, nil
+	}
+	prefix := fmt.Sprintf("#/components/%s/", componentType)
+	if componentType == "schemas" {
+		// the schemas references have already been translated into definitions references
+		prefix = "#/definitions/"
+	}
+	components, _ := t.components[componentType].(map[string]interface{})
+	component, exists := components[strings.TrimPrefix(ref, prefix)]
+	if !strings.HasPrefix(ref, prefix) || !exists {
+		return nil, fmt.Errorf("reference '%s' not found, only references to the document components are supported", ref)
+	}
+	if componentType == "schemas" {
+		component = translateOpenAPIV3Schema(component)
+	}
+	return t.resolve(component, componentType)
+}
+
+// translateOpenAPIV3Schema returns the OpenAPI v2 equivalent of the schema passed in: the references to the components
+// schemas point to the definitions, the discriminator object is replaced with its property name and the nullable field
+// is dropped (OpenAPI v2 does not support null values)
+func translateOpenAPIV3Schema(value interface{}) interface{} {
+	schema, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	translatedSchema := make(map[string]interface{}, len(schema))
+	for field, value := range schema {
+		switch field {
+		case "$ref":
+			ref, _ := value.(string)
+			translatedSchema[field] = strings.Replace(ref, "#/components/schemas/", "#/definitions/", 1)
+		case "nullable":
+		case "discriminator":
+			if discriminator, ok := value.(map[string]interface{}); ok {
+				translatedSchema[field] = discriminator["propertyName"]
+			}
+		case "properties":
+			properties, _ := value.(map[string]interface{})
+			translatedProperties := make(map[string]interface{}, len(properties))
+			for name, property := range properties {
+				translatedProperties[name] = translateOpenAPIV3Schema(property)
+			}
+			translatedSchema[field] = translatedProperties
+		case "items", "additionalProperties", "not":
+			translatedSchema[field] = translateOpenAPIV3Schema(value)
+		case "allOf", "anyOf", "oneOf":
+			schemas, _ := value.([]interface{})
+			translatedSchemas := make([]interface{}, len(schemas))
+			for i, schema := range schemas {
+				translatedSchemas[i] = translateOpenAPIV3Schema(schema)
+			}
+			translatedSchema[field] = translatedSchemas
+		default:
+			translatedSchema[field] = value
+		}
+	}
+	return translatedSchema
+}
+
+// selectOpenAPIV3MediaType returns the JSON media type of the content passed in (application/json or any other JSON
+// media type, e,g: application/merge-patch+json), or the first one in alphabetical order if there are no JSON media types
+func selectOpenAPIV3MediaType(content map[string]interface{}) (string, map[string]interface{}) {
+	if len(content) == 0 {
+		return "", nil
+	}
+	mediaTypes := sortedKeys(content)
+	selected := mediaTypes[0]
+	for _, mediaType := range mediaTypes {
+		if mediaType == "application/json" {
+			selected = mediaType
+			break
+		}
+		if strings.Contains(mediaType, "json") && !strings.Contains(selected, "json") {
+			selected = mediaType
+		}
+	}
+	mediaTypeObject, _ := content[selected].(map[string]interface{})
+	if mediaTypeObject == nil {
+		mediaTypeObject = map[string]interface{}{}
+	}
+	return selected, mediaTypeObject
+}
+
+// copyOpenAPIFields copies the given fields from the source object to the target one, if present
+func copyOpenAPIFields(source, target map[string]interface{}, fields ...string) {
+	for _, field := range fields {
+		if value, exists := source[field]; exists {
+			target[field] = value
+		}
+	}
+}
+
+// copyOpenAPIExtensions copies the extensions (x- fields) from the source object to the target one
+func copyOpenAPIExtensions(source, target map[string]interface{}) {
+	for field, value := range source {
+		if strings.HasPrefix(strings.ToLower(field), "x-") {
+			target[field] = value
+		}
+	}
+}
+
+// sortedKeys returns the keys of the map passed in sorted alphabetically
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch v := m.(type) {
+	case map[string]interface{}:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case map[string]bool:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
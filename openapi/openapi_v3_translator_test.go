@@ -0,0 +1,248 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func translateOpenAPIV3DocumentForTest(t *testing.T, document string) map[string]interface{} {
+	translatedDocument, err := translateOpenAPIV3Document([]byte(document))
+	require.NoError(t, err)
+	swagger := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(translatedDocument, &swagger))
+	return swagger
+}
+
+func TestTranslateOpenAPIV3DocumentSchemas(t *testing.T) {
+	swagger := translateOpenAPIV3DocumentForTest(t, `openapi: 3.0.0
+x-terraform-provider-name: cdn
+components:
+  schemas:
+    Pet:
+      type: object
+      discriminator:
+        propertyName: pet_type
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+        nickname:
+          type: string
+          nullable: true
+        toys:
+          type: array
+          items:
+            $ref: '#/components/schemas/Toy'
+        details:
+          allOf:
+            - $ref: '#/components/schemas/Details'
+`)
+	assert.Equal(t, "2.0", swagger["swagger"])
+	assert.Equal(t, "cdn", swagger["x-terraform-provider-name"], "the root extensions should be kept")
+	expectedPet := map[string]interface{}{
+		"type":          "object",
+		"discriminator": "pet_type",
+		"properties": map[string]interface{}{
+			"owner":    map[string]interface{}{"$ref": "#/definitions/Owner"},
+			"nickname": map[string]interface{}{"type": "string"},
+			"toys":     map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/definitions/Toy"}},
+			"details":  map[string]interface{}{"allOf": []interface{}{map[string]interface{}{"$ref": "#/definitions/Details"}}},
+		},
+	}
+	assert.Equal(t, map[string]interface{}{"Pet": expectedPet}, swagger["definitions"])
+}
+
+func TestTranslateOpenAPIV3DocumentServers(t *testing.T) {
+	testCases := []struct {
+		name             string
+		servers          string
+		expectedHost     interface{}
+		expectedSchemes  interface{}
+		expectedBasePath interface{}
+	}{
+		{name: "absolute server URL", servers: "[{url: 'https://api.example.com/v1/'}]", expectedHost: "api.example.com", expectedSchemes: []interface{}{"https"}, expectedBasePath: "/v1"},
+		{name: "server URL with variables", servers: "[{url: 'http://{region}.example.com:{port}', variables: {region: {default: eu}, port: {default: 8080}}}]", expectedHost: "eu.example.com:8080", expectedSchemes: []interface{}{"http"}},
+		{name: "relative server URL", servers: "[{url: /api}]", expectedBasePath: "/api"},
+		{name: "several servers", servers: "[{url: 'https://a.example.com'}, {url: 'https://b.example.com'}]", expectedHost: "a.example.com", expectedSchemes: []interface{}{"https"}},
+		{name: "no servers", servers: "[]"},
+	}
+	for _, tc := range testCases {
+		swagger := translateOpenAPIV3DocumentForTest(t, "openapi: 3.0.0\nservers: "+tc.servers)
+		assert.Equal(t, tc.expectedHost, swagger["host"], tc.name)
+		assert.Equal(t, tc.expectedSchemes, swagger["schemes"], tc.name)
+		assert.Equal(t, tc.expectedBasePath, swagger["basePath"], tc.name)
+	}
+}
+
+func TestTranslateOpenAPIV3DocumentSecuritySchemes(t *testing.T) {
+	swagger := translateOpenAPIV3DocumentForTest(t, `openapi: 3.0.0
+components:
+  securitySchemes:
+    api_key:
+      type: apiKey
+      in: header
+      name: X-API-Key
+      description: API key
+      x-terraform-refresh-token-url: https://api.example.com/token
+    cookie_key:
+      type: apiKey
+      in: cookie
+      name: session
+    bearer:
+      type: http
+      scheme: bearer
+    basic:
+      type: http
+      scheme: basic
+    digest:
+      type: http
+      scheme: digest
+    oauth:
+      type: oauth2
+      flows:
+        authorizationCode:
+          authorizationUrl: https://example.com/authorize
+          tokenUrl: https://example.com/token
+          scopes:
+            read: read access
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes: {}
+    oidc:
+      type: openIdConnect
+      openIdConnectUrl: https://example.com/.well-known/openid-configuration
+`)
+	expected := map[string]interface{}{
+		"api_key": map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-API-Key", "description": "API key", "x-terraform-refresh-token-url": "https://api.example.com/token"},
+		"bearer":  map[string]interface{}{"type": "apiKey", "in": "header", "name": "Authorization", "x-terraform-authentication-scheme-bearer": true},
+		"basic":   map[string]interface{}{"type": "basic"},
+		"oauth":   map[string]interface{}{"type": "oauth2", "flow": "application", "tokenUrl": "https://example.com/token", "scopes": map[string]interface{}{}},
+	}
+	assert.Equal(t, expected, swagger["securityDefinitions"])
+}
+
+func TestTranslateOpenAPIV3DocumentPaths(t *testing.T) {
+	swagger := translateOpenAPIV3DocumentForTest(t, `openapi: 3.0.0
+paths:
+  /v1/cdns/{id}:
+    x-terraform-resource-name: cdn
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    put:
+      operationId: UpdateCDN
+      x-terraform-resource-timeout: 30s
+      parameters:
+        - $ref: '#/components/parameters/Tags'
+        - name: session
+          in: cookie
+          schema:
+            type: string
+        - name: X-Region
+          in: header
+          x-terraform-header-name: region
+          schema:
+            $ref: '#/components/schemas/Region'
+      x-codegen-request-body-name: cdn
+      requestBody:
+        $ref: '#/components/requestBodies/CDN'
+      responses:
+        200:
+          description: updated
+          x-terraform-resource-poll-enabled: true
+          headers:
+            X-Rate-Limit:
+              schema:
+                type: integer
+          content:
+            application/xml:
+              schema:
+                type: string
+            application/json:
+              schema:
+                $ref: '#/components/schemas/CDN'
+        default:
+          $ref: '#/components/responses/Error'
+    trace:
+      responses: {}
+components:
+  parameters:
+    Tags:
+      name: tags
+      in: query
+      schema:
+        type: array
+        items:
+          type: string
+  requestBodies:
+    CDN:
+      required: true
+      content:
+        application/merge-patch+json:
+          schema:
+            $ref: '#/components/schemas/CDN'
+        text/plain:
+          schema:
+            type: string
+  responses:
+    Error:
+      description: error
+  schemas:
+    Region:
+      type: string
+      enum: [eu, us]
+      default: eu
+`)
+	expectedPathItem := map[string]interface{}{
+		"x-terraform-resource-name": "cdn",
+		"parameters": []interface{}{
+			map[string]interface{}{"name": "id", "in": "path", "required": true, "type": "string"},
+		},
+		"put": map[string]interface{}{
+			"operationId":                  "UpdateCDN",
+			"x-terraform-resource-timeout": "30s",
+			"x-codegen-request-body-name":  "cdn",
+			"parameters": []interface{}{
+				map[string]interface{}{"name": "tags", "in": "query", "type": "array", "items": map[string]interface{}{"type": "string"}, "collectionFormat": "multi"},
+				map[string]interface{}{"name": "X-Region", "in": "header", "x-terraform-header-name": "region", "type": "string", "enum": []interface{}{"eu", "us"}, "default": "eu"},
+				map[string]interface{}{"name": "cdn", "in": "body", "required": true, "schema": map[string]interface{}{"$ref": "#/definitions/CDN"}},
+			},
+			"consumes": []interface{}{"application/merge-patch+json"},
+			"produces": []interface{}{"application/json"},
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description":                       "updated",
+					"x-terraform-resource-poll-enabled": true,
+					"headers":                           map[string]interface{}{"X-Rate-Limit": map[string]interface{}{"type": "integer"}},
+					"schema":                            map[string]interface{}{"$ref": "#/definitions/CDN"},
+				},
+				"default": map[string]interface{}{"description": "error"},
+			},
+		},
+	}
+	assert.Equal(t, map[string]interface{}{"/v1/cdns/{id}": expectedPathItem}, swagger["paths"])
+}
+
+func TestTranslateOpenAPIV3DocumentErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		document      string
+		expectedError string
+	}{
+		{name: "document not valid", document: "{not valid", expectedError: "document is not valid JSON or YAML: yaml: line 1: did not find expected ',' or '}'"},
+		{name: "document is not an object", document: "- openapi", expectedError: "document is not an object"},
+		{name: "server URL not valid", document: "openapi: 3.0.0\nservers: [{url: 'http://exa mple.com'}]", expectedError: "server URL 'http://exa mple.com' not valid: parse \"http://exa mple.com\": invalid character \" \" in host name"},
+		{name: "external reference", document: "openapi: 3.0.0\npaths: {/v1/cdns: {get: {parameters: [{$ref: 'common.yaml#/components/parameters/ID'}]}}}", expectedError: "path '/v1/cdns' GET operation not valid: reference 'common.yaml#/components/parameters/ID' not found, only references to the document components are supported"},
+		{name: "request body without content", document: "openapi: 3.0.0\npaths: {/v1/cdns: {post: {requestBody: {required: true}}}}", expectedError: "path '/v1/cdns' POST operation not valid: request body is missing the content"},
+		{name: "response reference not found", document: "openapi: 3.0.0\npaths: {/v1/cdns: {get: {responses: {200: {$ref: '#/components/responses/Missing'}}}}}", expectedError: "path '/v1/cdns' GET operation not valid: response '200' not valid: reference '#/components/responses/Missing' not found, only references to the document components are supported"},
+	}
+	for _, tc := range testCases {
+		_, err := translateOpenAPIV3Document([]byte(tc.document))
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
@@ -0,0 +1,55 @@
+package openapi
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// propertyPanic annotates a panic raised while processing the value of a property with the property name and the value,
+// so the diagnostics reported point to the property of the payload that caused it
+type propertyPanic struct {
+	property string
+	value    interface{}
+	cause    interface{}
+	// stack contains the stack trace of the original panic
+	stack []byte
+}
+
+// annotatePropertyPanic re-panics the panic raised while processing the value of the given property (if any) annotated
+// with the property name and the value. It must be called as a deferred function
+func annotatePropertyPanic(propertyName string, value interface{}) {
+	if r := recover(); r != nil {
+		if _, annotated := r.(*propertyPanic); annotated {
+			// nested properties are annotated with the innermost property
+			panic(r)
+		}
+		panic(&propertyPanic{property: propertyName, value: value, cause: r, stack: debug.Stack()})
+	}
+}
+
+// recoverCRUDPanic recovers the panic raised while running the given resource operation (if any) and sends the diagnostics
+// describing it to the channel instead of letting the panic crash the provider plugin. The stack trace is logged so the
+// issue can be reported. It must be called as a deferred function
+func recoverCRUDPanic(resourceName, operation string, diagsChan chan<- diag.Diagnostics) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	cause, stack := r, debug.Stack()
+	detail := ""
+	if p, ok := r.(*propertyPanic); ok {
+		cause, stack = p.cause, p.stack
+		detail = fmt.Sprintf("property '%s' with value of type %T: ", p.property, p.value)
+	}
+	detail += fmt.Sprintf("%v", cause)
+	resourceLog.Error("recovered from panic while running the %s operation of '%s': %s\n%s", operation, resourceName, detail, stack)
+	diagsChan <- diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("unexpected error while running the %s operation of '%s'", operation, resourceName),
+			Detail:   fmt.Sprintf("%s. This is likely caused by an API payload that does not match the OpenAPI document, please report the issue along with the stack trace in the provider logs", detail),
+		},
+	}
+}
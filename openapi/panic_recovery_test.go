@@ -0,0 +1,52 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrudWithContextRecoversPanic(t *testing.T) {
+	crudFunc := func(data *schema.ResourceData, i interface{}) error {
+		var value interface{} = "true"
+		_ = value.(bool)
+		return nil
+	}
+	data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	diags := crudWithContext(crudFunc, schema.TimeoutRead, "cdn_v1")(context.Background(), data, nil)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "unexpected error while running the read operation of 'cdn_v1'", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "interface conversion: interface {} is string, not bool")
+}
+
+func TestCrudWithContextRecoversPropertyPanic(t *testing.T) {
+	crudFunc := func(data *schema.ResourceData, i interface{}) error {
+		func() {
+			defer annotatePropertyPanic("ports", map[string]interface{}{})
+			func() {
+				defer annotatePropertyPanic("port", "8080")
+				panic("unexpected value")
+			}()
+		}()
+		return nil
+	}
+	data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	diags := crudWithContext(crudFunc, schema.TimeoutCreate, "cdn_v1")(context.Background(), data, nil)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "unexpected error while running the create operation of 'cdn_v1'", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "property 'port' with value of type string: unexpected value")
+}
+
+func TestCrudWithContextNoPanic(t *testing.T) {
+	crudFunc := func(data *schema.ResourceData, i interface{}) error {
+		return nil
+	}
+	data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	diags := crudWithContext(crudFunc, schema.TimeoutRead, "cdn_v1")(context.Background(), data, nil)
+	assert.Empty(t, diags)
+}
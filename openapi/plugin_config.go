@@ -2,12 +2,13 @@ package openapi
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/base64"
 	"fmt"
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
 	"gopkg.in/yaml.v2"
 	"io"
 	"io/ioutil"
-	"log"
 	"os"
 	"strconv"
 	"strings"
@@ -17,8 +18,10 @@ import (
 const OpenAPIPluginConfigurationFileName = "terraform-provider-openapi.yaml"
 
 const otfVarSwaggerURL = "OTF_VAR_%s_SWAGGER_URL"
+const otfVarSwaggerSpecB64 = "OTF_VAR_%s_SWAGGER_SPEC_B64"
 const otfVarInsecureSkipVerify = "OTF_INSECURE_SKIP_VERIFY"
 const otfVarPluginConfigurationFile = "OTF_VAR_%s_PLUGIN_CONFIGURATION_FILE"
+const otfVarProfile = "OTF_VAR_%s_PROFILE"
 
 // PluginConfiguration defines the OpenAPI plugin's configuration
 type PluginConfiguration struct {
@@ -36,14 +39,19 @@ type PluginConfiguration struct {
 // NewPluginConfiguration creates a new PluginConfiguration
 func NewPluginConfiguration(providerName string) (*PluginConfiguration, error) {
 	var configurationFile io.Reader
+	// The OpenAPI document provided via OTF_VAR_<provider_name>_SWAGGER_SPEC_B64 takes preference over the plugin configuration
+	// file so the file is not looked up, enabling environments without home directory or filesystem access
+	if _, specEnvVar, err := getSwaggerSpecFromEnv(providerName); err == nil && specEnvVar != "" {
+		return &PluginConfiguration{ProviderName: providerName}, nil
+	}
 	configurationFilePath, err := getPluginConfigurationPath(providerName)
 	if err != nil {
 		return nil, err
 	}
 	if _, err := os.Stat(configurationFilePath); os.IsNotExist(err) {
-		log.Printf("[INFO] open api plugin configuration not present at %s", configurationFilePath)
+		configLog.Info("open api plugin configuration not present at %s", configurationFilePath)
 	} else {
-		log.Printf("[INFO] found open api plugin configuration at %s", configurationFilePath)
+		configLog.Info("found open api plugin configuration at %s", configurationFilePath)
 		file, err := os.Open(configurationFilePath) // #nosec G304
 		if err != nil {
 			return nil, err
@@ -71,12 +79,29 @@ func getPluginConfigurationPath(providerName string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	expandedTerraformVendorDir, err := terraformUtils.GetTerraformPluginsVendorDir()
-	if err != nil {
-		return "", err
+	return terraformUtils.GetTerraformPluginsVendorFilePath(OpenAPIPluginConfigurationFileName)
+}
+
+// getSwaggerSpecFromEnv returns the OpenAPI document provided base64 encoded in the OTF_VAR_<provider_name>_SWAGGER_SPEC_B64
+// environment variable (either using the lower case or upper case provider name) along with the name of the variable
+// set. An empty variable name is returned if the environment variable is not set
+func getSwaggerSpecFromEnv(providerName string) ([]byte, string, error) {
+	specEnvVar := fmt.Sprintf(otfVarSwaggerSpecB64, providerName)
+	for _, envVar := range []string{specEnvVar, strings.ToUpper(specEnvVar)} {
+		encodedSpec := strings.TrimSpace(os.Getenv(envVar))
+		if encodedSpec == "" {
+			continue
+		}
+		spec, err := base64.StdEncoding.DecodeString(encodedSpec)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to decode the OpenAPI document provided in %s env variable, the value must be base64 encoded: %s", envVar, err)
+		}
+		if len(bytes.TrimSpace(spec)) == 0 {
+			return nil, "", fmt.Errorf("the OpenAPI document provided in %s env variable is empty", envVar)
+		}
+		return spec, envVar, nil
 	}
-	configurationFilePath := fmt.Sprintf("%s/%s", expandedTerraformVendorDir, OpenAPIPluginConfigurationFileName)
-	return configurationFilePath, nil
+	return nil, "", nil
 }
 
 func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, error) {
@@ -91,11 +116,21 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 	if err != nil {
 		return nil, err
 	}
-	// Found OTF_VAR_%s_SWAGGER_URL env variable
+	// Found OTF_VAR_%s_SWAGGER_SPEC_B64 env variable, the OpenAPI document is held in memory so no filesystem access is needed
+	spec, specEnvVar, err := getSwaggerSpecFromEnv(p.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	if specEnvVar != "" {
+		apiDiscoveryURL = registerInMemoryDocument(p.ProviderName, spec)
+		configLog.Info("%s set, using the OpenAPI document provided in memory (%s)", specEnvVar, apiDiscoveryURL)
+	} else if apiDiscoveryURL != "" {
+		configLog.Info("%s set with value %s", swaggerURLEnvVar, apiDiscoveryURL)
+	}
+	// Found OTF_VAR_%s_SWAGGER_URL or OTF_VAR_%s_SWAGGER_SPEC_B64 env variable
 	if apiDiscoveryURL != "" {
-		log.Printf("[INFO] %s set with value %s", swaggerURLEnvVar, apiDiscoveryURL)
 		skipVerify, _ := strconv.ParseBool(os.Getenv(otfVarInsecureSkipVerify))
-		log.Printf("[INFO] %s set with value %t", otfVarInsecureSkipVerify, skipVerify)
+		configLog.Info("%s set with value %t", otfVarInsecureSkipVerify, skipVerify)
 		pluginConfigV1.Services = map[string]*ServiceConfigV1{}
 		pluginConfigV1.Services[p.ProviderName] = NewServiceConfigV1(apiDiscoveryURL, skipVerify, nil)
 		serviceConfig, err = pluginConfigV1.GetServiceConfig(p.ProviderName)
@@ -123,13 +158,16 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 			if err != nil {
 				return nil, fmt.Errorf("error occurred when getting service configuration from plugin configuration file %s - error = %s", OpenAPIPluginConfigurationFileName, err)
 			}
+			if err = p.selectServiceProfile(pluginConfigV1.Services[p.ProviderName]); err != nil {
+				return nil, fmt.Errorf("error occurred when selecting the service profile from plugin configuration file %s - error = %s", OpenAPIPluginConfigurationFileName, err)
+			}
 		}
 	}
 
-	log.Printf("[DEBUG] serviceConfig = %+v", serviceConfig)
+	configLog.Debug("serviceConfig = %+v", serviceConfig)
 
 	if serviceConfig == nil || serviceConfig.GetSwaggerURL() == "" {
-		return nil, fmt.Errorf("swagger url not provided, please export OTF_VAR_<provider_name>_SWAGGER_URL env variable with the URL where '%s' service provider is exposing the swagger file OR create a plugin configuration file at ~/.terraform.d/plugins following the Plugin configuration schema specifications", p.ProviderName)
+		return nil, fmt.Errorf("swagger url not provided, please export OTF_VAR_<provider_name>_SWAGGER_URL env variable with the URL where '%s' service provider is exposing the swagger file (or OTF_VAR_<provider_name>_SWAGGER_SPEC_B64 with the base64 encoded swagger file) OR create a plugin configuration file at ~/.terraform.d/plugins following the Plugin configuration schema specifications", p.ProviderName)
 	}
 
 	if err = serviceConfig.Validate(); err != nil {
@@ -138,3 +176,21 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 
 	return serviceConfig, err
 }
+
+// selectServiceProfile selects the service profile specified in the OTF_VAR_<provider_name>_PROFILE environment variable,
+// falling back to the service default profile if the environment variable is not set
+func (p *PluginConfiguration) selectServiceProfile(serviceConfig *ServiceConfigV1) error {
+	profileEnvVar := fmt.Sprintf(otfVarProfile, p.ProviderName)
+	profileEnvVars := []string{profileEnvVar, strings.ToUpper(profileEnvVar)}
+	profile, err := terraformutils.MultiEnvDefaultString(profileEnvVars, "")
+	if err != nil {
+		return err
+	}
+	if err := serviceConfig.selectProfile(profile); err != nil {
+		return err
+	}
+	if serviceConfig.GetSelectedProfile() != "" {
+		configLog.Info("service profile '%s' selected", serviceConfig.GetSelectedProfile())
+	}
+	return nil
+}
@@ -3,8 +3,10 @@ package openapi
 import (
 	"fmt"
 	"github.com/asaskevich/govalidator"
-	"log"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 )
 
 // ServiceConfiguration defines the interface/expected behaviour for ServiceConfiguration implementations.
@@ -20,6 +22,75 @@ type ServiceConfiguration interface {
 	Validate() error
 	// GetTelemetryConfiguration returns the telemetry configuration for this service provider
 	GetTelemetryConfiguration() TelemetryProvider
+	// GetSpecVersionCheckConfiguration returns the configuration of the API endpoint advertising the minimum OpenAPI document
+	// version supported by the API; nil is returned if not configured
+	GetSpecVersionCheckConfiguration() *SpecVersionCheckConfig
+	// GetRequestCompressionConfiguration returns the configuration for compressing the request bodies sent to the API; nil
+	// is returned if not configured
+	GetRequestCompressionConfiguration() *RequestCompressionConfig
+	// GetMaxResponseBodySize returns the max size in bytes of the API response bodies; zero means no limit
+	GetMaxResponseBodySize() int64
+	// GetSpecFormat returns the spec format of the document located in the swagger URL which determines the SpecAnalyser
+	// used to analyse the document; empty is returned if not configured
+	GetSpecFormat() SpecAnalyserVersion
+	// GetBackendConfiguration returns the configuration of the backend the API calls are performed against; nil is returned
+	// if not configured (REST API)
+	GetBackendConfiguration() *BackendConfig
+	// GetAdditionalSwaggerURLs returns the URLs of the additional OpenAPI documents whose resources are aggregated into
+	// the provider; empty is returned if not configured
+	GetAdditionalSwaggerURLs() []string
+	// GetMiddlewareConfiguration returns the middleware chain the API calls go through; empty is returned if not configured
+	GetMiddlewareConfiguration() []MiddlewareConfig
+	// GetUserAgent returns the template of the User-Agent header sent in the API calls; empty is returned if not configured
+	GetUserAgent() string
+	// GetIdentificationHeaders returns the static headers sent in all the API calls to identify the client; empty is
+	// returned if not configured
+	GetIdentificationHeaders() map[string]string
+	// GetTLSConfiguration returns the TLS configuration of the client transport used to perform the API calls; nil is
+	// returned if not configured
+	GetTLSConfiguration() *TLSConfig
+	// GetAuditLogConfiguration returns the configuration of the audit log of the mutating API calls; nil is returned if
+	// not configured
+	GetAuditLogConfiguration() *AuditLogConfig
+	// GetWebhookConfigurations returns the webhooks notified of the resources created, updated or deleted by the provider;
+	// empty is returned if not configured
+	GetWebhookConfigurations() []WebhookConfig
+	// GetHealthCheckConfiguration returns the configuration of the API health endpoint checked before performing the
+	// mutating API calls; nil is returned if not configured
+	GetHealthCheckConfiguration() *HealthCheckConfig
+	// GetRateLimitRetryConfiguration returns the budget of the retries of the API calls responding 429 Too Many Requests;
+	// nil is returned if not configured
+	GetRateLimitRetryConfiguration() *RateLimitRetryConfig
+	// GetStateEncryptionConfiguration returns the configuration of the key used to encrypt the properties stored in the
+	// state with the x-terraform-encrypt-in-state extension; nil is returned if not configured
+	GetStateEncryptionConfiguration() *StateEncryptionConfig
+	// GetProfileConfigurations returns the named environment profiles (e,g: dev, staging or prod) configured for the
+	// service; empty is returned if not configured
+	GetProfileConfigurations() map[string]*ServiceProfileConfig
+	// GetSelectedProfile returns the name of the profile selected when the configuration was loaded; empty is returned if
+	// no profile is selected
+	GetSelectedProfile() string
+	// GetResourceNamingVersion returns the version of the naming algorithm the resource and data source names are built
+	// with; the current version is returned if not configured
+	GetResourceNamingVersion() int
+	// GetFailoverConfiguration returns the configuration of the fallback hosts the API calls fail over to when the API host
+	// is not available; nil is returned if not configured
+	GetFailoverConfiguration() *FailoverConfig
+	// GetServiceDiscoveryConfiguration returns the configuration of the DNS SRV record the API host is resolved from; nil
+	// is returned if not configured
+	GetServiceDiscoveryConfiguration() *ServiceDiscoveryConfig
+	// GetDialerConfiguration returns the configuration of the dialer the connections to the API are established with; nil
+	// is returned if not configured
+	GetDialerConfiguration() *DialerConfig
+	// IsVerifyWritesEnabled returns true if the resources should be read after being created or updated to verify the
+	// API kept the values sent (read-after-write verification)
+	IsVerifyWritesEnabled() bool
+	// GetVaultConfiguration returns the configuration of the HashiCorp Vault secret the auth credentials are read from;
+	// nil is returned if not configured
+	GetVaultConfiguration() *VaultConfig
+	// IsQuirksModeEnabled returns true if the common artifacts of the spec generators (e,g: FastAPI or Springdoc) should
+	// be fixed before analysing the OpenAPI documents
+	IsQuirksModeEnabled() bool
 }
 
 // TelemetryConfig contains the configuration for the telemetry
@@ -30,6 +101,457 @@ type TelemetryConfig struct {
 	HTTPEndpoint *TelemetryProviderHTTPEndpoint `yaml:"http_endpoint,omitempty"`
 }
 
+// SpecVersionCheckConfig contains the configuration of the API version/metadata endpoint that advertises the minimum OpenAPI
+// document version the API expects clients to use. If configured, the provider will check the endpoint when configured
+// and warn the user if the OpenAPI document loaded is older than the version advertised by the API (e,g: stale cached document)
+type SpecVersionCheckConfig struct {
+	// Path defines the path of the API version/metadata endpoint relative to the API host (e,g: /version). Full URLs are also supported
+	Path string `yaml:"path"`
+	// VersionProperty defines the property of the JSON response that contains the version advertised by the API. If not
+	// provided, the default 'version' property will be used
+	VersionProperty string `yaml:"version_property,omitempty"`
+}
+
+// RequestCompressionConfig contains the configuration for compressing the request bodies sent to the API
+type RequestCompressionConfig struct {
+	// GzipThreshold defines the size in bytes of the request body above which the body is sent gzip compressed
+	// (Content-Encoding: gzip). Zero means all request bodies are compressed
+	GzipThreshold int `yaml:"gzip_threshold"`
+}
+
+const (
+	// backendTypeREST performs the API calls against the REST API described in the OpenAPI document
+	backendTypeREST = "rest"
+	// backendTypeGRPC performs the API calls against the gRPC server the REST API is transcoded to (e,g: grpc-gateway)
+	backendTypeGRPC = "grpc"
+)
+
+// BackendConfig contains the configuration of the backend the API calls are performed against
+type BackendConfig struct {
+	// Type defines the backend type: rest (default) or grpc
+	Type string `yaml:"type"`
+	// Address defines the gRPC server address (host:port); only applicable to the grpc backend
+	Address string `yaml:"address,omitempty"`
+	// Insecure disables the transport security of the gRPC connection; only applicable to the grpc backend
+	Insecure bool `yaml:"insecure,omitempty"`
+}
+
+const (
+	// middlewareTypeRetry retries the API calls that failed due to transient errors
+	middlewareTypeRetry = "retry"
+	// middlewareTypeRateLimit limits the rate at which the API calls are performed
+	middlewareTypeRateLimit = "rate_limit"
+	// middlewareTypeHeaders injects static headers in the API calls
+	middlewareTypeHeaders = "headers"
+	// middlewareTypeLogging logs the API calls performed along with the response status code and latency
+	middlewareTypeLogging = "logging"
+	// middlewareTypeCSRF fetches the CSRF token required by the API and echoes it in the mutating API calls
+	middlewareTypeCSRF = "csrf"
+)
+
+// MiddlewareConfig contains the configuration of a middleware the API calls go through
+type MiddlewareConfig struct {
+	// Name identifies the middleware so resource operations can select it via the x-terraform-middleware extension. If
+	// not provided, the middleware type is used as the name
+	Name string `yaml:"name,omitempty"`
+	// Type defines the middleware type: retry, rate_limit, headers, logging or csrf
+	Type string `yaml:"type"`
+	// MaxRetries defines the max number of retries; only applicable to the retry middleware
+	MaxRetries int `yaml:"max_retries,omitempty"`
+	// RetryWait defines the wait before the first retry (e,g: 500ms), doubled on each subsequent retry. If not provided,
+	// 1s is used; only applicable to the retry middleware
+	RetryWait string `yaml:"retry_wait,omitempty"`
+	// MaxRetryWait defines the max wait in between retries (e,g: 10s). If not provided, 30s is used; only applicable to the
+	// retry middleware
+	MaxRetryWait string `yaml:"max_retry_wait,omitempty"`
+	// RetryStatusCodes defines the response status codes that are retried. If not provided, 429, 502, 503 and 504 are
+	// retried; only applicable to the retry middleware
+	RetryStatusCodes []int `yaml:"retry_status_codes,omitempty"`
+	// RequestsPerSecond defines the max number of API calls per second; only applicable to the rate_limit middleware
+	RequestsPerSecond float64 `yaml:"requests_per_second,omitempty"`
+	// Burst defines the max number of API calls that can be performed at once. If not provided, 1 is used; only applicable
+	// to the rate_limit middleware
+	Burst int `yaml:"burst,omitempty"`
+	// Headers defines the headers injected in the API calls; only applicable to the headers middleware
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// TokenPath defines the path (e,g: /api/csrf) or URL the CSRF token is fetched from via GET. Paths are resolved
+	// against the URL of the API call; only applicable to the csrf middleware
+	TokenPath string `yaml:"token_path,omitempty"`
+	// TokenHeader defines the header the CSRF token is echoed in. If not provided, X-CSRF-Token is used; only applicable
+	// to the csrf middleware
+	TokenHeader string `yaml:"token_header,omitempty"`
+	// TokenField defines the path (dot separated) of the token response body field containing the CSRF token. If not
+	// provided, the token is read from the token header of the response; only applicable to the csrf middleware
+	TokenField string `yaml:"token_field,omitempty"`
+	// FeatureFlag defines the boolean feature flag (x-terraform-provider-feature-flags) that enables the middleware. If
+	// provided, the middleware is only applied when the feature flag is enabled in the provider configuration
+	FeatureFlag string `yaml:"feature_flag,omitempty"`
+}
+
+// GetName returns the name of the middleware; the middleware type is returned if the name is not configured
+func (m MiddlewareConfig) GetName() string {
+	if m.Name != "" {
+		return m.Name
+	}
+	return m.Type
+}
+
+// Validate makes sure the middleware configuration is valid
+func (m MiddlewareConfig) Validate() error {
+	switch m.Type {
+	case middlewareTypeRetry:
+		if m.MaxRetries <= 0 {
+			return fmt.Errorf("max_retries must be greater than zero for the %s middleware", m.Type)
+		}
+		if m.RetryWait != "" {
+			if _, err := time.ParseDuration(m.RetryWait); err != nil {
+				return fmt.Errorf("retry_wait '%s' not valid: %s", m.RetryWait, err)
+			}
+		}
+		if m.MaxRetryWait != "" {
+			if _, err := time.ParseDuration(m.MaxRetryWait); err != nil {
+				return fmt.Errorf("max_retry_wait '%s' not valid: %s", m.MaxRetryWait, err)
+			}
+		}
+	case middlewareTypeRateLimit:
+		if m.RequestsPerSecond <= 0 {
+			return fmt.Errorf("requests_per_second must be greater than zero for the %s middleware", m.Type)
+		}
+		if m.Burst < 0 {
+			return fmt.Errorf("burst must not be negative for the %s middleware", m.Type)
+		}
+	case middlewareTypeHeaders:
+		if len(m.Headers) == 0 {
+			return fmt.Errorf("headers must not be empty for the %s middleware", m.Type)
+		}
+	case middlewareTypeLogging:
+	case middlewareTypeCSRF:
+		if m.TokenPath == "" {
+			return fmt.Errorf("token_path must not be empty for the %s middleware", m.Type)
+		}
+		if _, err := url.Parse(m.TokenPath); err != nil {
+			return fmt.Errorf("token_path '%s' not valid: %s", m.TokenPath, err)
+		}
+	default:
+		return fmt.Errorf("type '%s' not supported, supported types are [%s, %s, %s, %s, %s]", m.Type, middlewareTypeRetry, middlewareTypeRateLimit, middlewareTypeHeaders, middlewareTypeLogging, middlewareTypeCSRF)
+	}
+	return nil
+}
+
+// TLSConfig contains the TLS configuration of the client transport used to perform the API calls
+type TLSConfig struct {
+	// MinVersion defines the min TLS version negotiated with the API: 1.2 (default) or 1.3
+	MinVersion string `yaml:"min_version,omitempty"`
+	// CipherSuites defines the TLS 1.2 cipher suites (IANA names, e,g: TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256) allowed
+	// when negotiating the connections with the API. If not provided, the Go default cipher suites are used. TLS 1.3
+	// cipher suites are not configurable
+	CipherSuites []string `yaml:"cipher_suites,omitempty"`
+}
+
+// Validate checks that the TLS versions and cipher suites configured are supported. If the provider binary is built in
+// FIPS mode, only the FIPS-approved cipher suites are allowed
+func (t *TLSConfig) Validate() error {
+	if _, err := getTLSVersion(t.MinVersion); err != nil {
+		return err
+	}
+	for _, cipherSuite := range t.CipherSuites {
+		if _, err := getTLSCipherSuiteID(cipherSuite); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AuditLogConfig contains the configuration of the audit log of the mutating API calls (POST, PUT and DELETE)
+type AuditLogConfig struct {
+	// Path defines the file the audit log entries are appended to, one JSON line per API call
+	Path string `yaml:"path"`
+	// Identity defines the requester identity recorded in the audit log entries (e,g: the CI pipeline or the team applying
+	// the changes). If not provided, the name of the OS user running the provider is recorded
+	Identity string `yaml:"identity,omitempty"`
+}
+
+const (
+	webhookEventCreate = "create"
+	webhookEventUpdate = "update"
+	webhookEventDelete = "delete"
+)
+
+// WebhookConfig contains the configuration of the webhook notified of the resources successfully created, updated or
+// deleted by the provider (e,g: CMDB or ITSM systems tracking the terraform driven changes)
+type WebhookConfig struct {
+	// URL defines the URL the notifications are POSTed to
+	URL string `yaml:"url"`
+	// Events defines the actions the webhook is notified of: create, update and/or delete. If not provided, the webhook is
+	// notified of all the actions
+	Events []string `yaml:"events,omitempty"`
+	// Headers defines static headers sent in the notifications (e,g: the token the webhook endpoint authenticates the
+	// provider with)
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Timeout defines the max time the notifications are allowed to take (e,g: 5s). If not provided, 10s is used
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// Validate makes sure the webhook configuration is valid
+func (w *WebhookConfig) Validate() error {
+	if !isURL(w.URL) {
+		return fmt.Errorf("url '%s' is not a valid URL", w.URL)
+	}
+	for _, event := range w.Events {
+		switch event {
+		case webhookEventCreate, webhookEventUpdate, webhookEventDelete:
+		default:
+			return fmt.Errorf("event '%s' not supported, supported events are [%s, %s, %s]", event, webhookEventCreate, webhookEventUpdate, webhookEventDelete)
+		}
+	}
+	if w.Timeout != "" {
+		if _, err := time.ParseDuration(w.Timeout); err != nil {
+			return fmt.Errorf("timeout '%s' not valid: %s", w.Timeout, err)
+		}
+	}
+	return nil
+}
+
+// isNotifiedOf returns true if the webhook is notified of the event passed in
+func (w *WebhookConfig) isNotifiedOf(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// HealthCheckConfig contains the configuration of the API health endpoint checked before performing the mutating API calls,
+// so applies fail fast while the API is degraded or under maintenance instead of hanging. The reads are still allowed
+type HealthCheckConfig struct {
+	// Path defines the path of the health endpoint, resolved against the API host and base path (e,g: /health). Full URLs
+	// are also supported
+	Path string `yaml:"path"`
+	// StatusProperty defines the property of the JSON response containing the API status (dot separated for nested
+	// properties, e,g: status.indicator). If not provided, 'status' is used
+	StatusProperty string `yaml:"status_property,omitempty"`
+	// BlockingStatuses defines the statuses (case insensitive) the mutating API calls are refused on. If not provided,
+	// degraded and maintenance are used
+	BlockingStatuses []string `yaml:"blocking_statuses,omitempty"`
+	// MessageProperty defines the property of the JSON response containing the message explaining the status (e,g: the
+	// maintenance window), which is included in the error returned to the user
+	MessageProperty string `yaml:"message_property,omitempty"`
+	// CacheTTL defines how long the API status is cached for (e,g: 1m). If not provided, 30s is used
+	CacheTTL string `yaml:"cache_ttl,omitempty"`
+}
+
+// Validate makes sure the health check configuration is valid
+func (h *HealthCheckConfig) Validate() error {
+	if h.Path == "" {
+		return fmt.Errorf("path must not be empty")
+	}
+	if h.CacheTTL != "" {
+		if _, err := time.ParseDuration(h.CacheTTL); err != nil {
+			return fmt.Errorf("cache_ttl '%s' not valid: %s", h.CacheTTL, err)
+		}
+	}
+	return nil
+}
+
+// RateLimitRetryConfig contains the budget of the retries of the API calls responding 429 Too Many Requests, which are
+// retried after the wait the API asks for in the Retry-After response header
+type RateLimitRetryConfig struct {
+	// MaxRetries defines the max number of retries per API call. Zero disables the retries. If not provided, 3 is used
+	MaxRetries *int `yaml:"max_retries,omitempty"`
+	// MaxWait defines the max total time an API call is allowed to wait for across all its retries (e,g: 2m). The API call
+	// fails if the API asks to wait longer than the remaining budget. If not provided, 1m is used
+	MaxWait string `yaml:"max_wait,omitempty"`
+}
+
+// Validate makes sure the rate limit retry configuration is valid
+func (r *RateLimitRetryConfig) Validate() error {
+	if r.MaxRetries != nil && *r.MaxRetries < 0 {
+		return fmt.Errorf("max_retries must not be negative")
+	}
+	if r.MaxWait != "" {
+		if _, err := time.ParseDuration(r.MaxWait); err != nil {
+			return fmt.Errorf("max_wait '%s' not valid: %s", r.MaxWait, err)
+		}
+	}
+	return nil
+}
+
+// StateEncryptionConfig contains the configuration of the key used to encrypt the properties stored in the state with the
+// x-terraform-encrypt-in-state extension. The key must be a base64 encoded 256-bit key, provided either via an environment
+// variable or the output of a command (e,g: decrypting the data key with a KMS or age)
+type StateEncryptionConfig struct {
+	// KeyEnv defines the environment variable containing the key
+	KeyEnv string `yaml:"key_env,omitempty"`
+	// KeyCommand defines the command (and its arguments) that prints the key
+	KeyCommand []string `yaml:"key_command,omitempty"`
+	// KeyCommandTimeout defines the max time in seconds the key command is allowed to run; defaults to 10s
+	KeyCommandTimeout int `yaml:"key_command_timeout,omitempty"`
+}
+
+// FailoverConfig contains the configuration of the fallback hosts the API calls fail over to when the API host fails with
+// connection errors or 5xx responses (e,g: active/passive control plane deployments)
+type FailoverConfig struct {
+	// Hosts defines the fallback hosts in the order they are tried (e,g: api-passive.example.com). If provided, they take
+	// preference over the fallback hosts defined in the OpenAPI document (x-terraform-provider-fallback-hosts)
+	Hosts []string `yaml:"hosts,omitempty"`
+	// UnhealthyCooldown defines how long a host that failed is skipped before it is tried again (e,g: 1m). If not
+	// provided, 30s is used
+	UnhealthyCooldown string `yaml:"unhealthy_cooldown,omitempty"`
+}
+
+// Validate makes sure the failover configuration is valid
+func (f *FailoverConfig) Validate() error {
+	for _, host := range f.Hosts {
+		if host == "" {
+			return fmt.Errorf("hosts must not contain empty values")
+		}
+	}
+	if f.UnhealthyCooldown != "" {
+		if _, err := time.ParseDuration(f.UnhealthyCooldown); err != nil {
+			return fmt.Errorf("unhealthy_cooldown '%s' not valid: %s", f.UnhealthyCooldown, err)
+		}
+	}
+	return nil
+}
+
+// ServiceDiscoveryConfig contains the configuration of the DNS SRV record the API host is resolved from (e,g: internal
+// service discovery setups where the API host is not known beforehand)
+type ServiceDiscoveryConfig struct {
+	// SRV defines the name of the DNS SRV record the API host and port are resolved from (e,g: _api._tcp.example.internal)
+	SRV string `yaml:"srv"`
+	// TTL defines how long the host resolved is used before the SRV record is resolved again (e,g: 5m). If not provided,
+	// 60s is used
+	TTL string `yaml:"ttl,omitempty"`
+}
+
+// Validate makes sure the service discovery configuration is valid
+func (d *ServiceDiscoveryConfig) Validate() error {
+	if d.SRV == "" {
+		return fmt.Errorf("srv must not be empty")
+	}
+	if d.TTL != "" {
+		if _, err := time.ParseDuration(d.TTL); err != nil {
+			return fmt.Errorf("ttl '%s' not valid: %s", d.TTL, err)
+		}
+	}
+	return nil
+}
+
+// DialerConfig contains the configuration of the dialer the connections to the API are established with (e,g: APIs only
+// reachable over IPv6 or through a split-horizon DNS resolver)
+type DialerConfig struct {
+	// PreferIPv6 dials the IPv6 addresses of the API host first, falling back to IPv4 if the IPv6 connection is not
+	// established within the fallback delay (happy eyeballs)
+	PreferIPv6 bool `yaml:"prefer_ipv6,omitempty"`
+	// DisableIPv4 only dials the IPv6 addresses of the API host
+	DisableIPv4 bool `yaml:"disable_ipv4,omitempty"`
+	// FallbackDelay defines how long the IPv6 connection attempt is given before the IPv4 one is started (e,g: 100ms). If
+	// not provided, 300ms is used. A negative value disables the racing, only falling back to IPv4 if the IPv6 attempt fails
+	FallbackDelay string `yaml:"fallback_delay,omitempty"`
+	// Resolver defines the address (host:port) of the DNS resolver the API hosts are resolved with (e,g: 10.0.0.2:53). If
+	// not provided, the resolver in the system configuration is used
+	Resolver string `yaml:"resolver,omitempty"`
+}
+
+// Validate makes sure the dialer configuration is valid
+func (d *DialerConfig) Validate() error {
+	if d.FallbackDelay != "" {
+		if _, err := time.ParseDuration(d.FallbackDelay); err != nil {
+			return fmt.Errorf("fallback_delay '%s' not valid: %s", d.FallbackDelay, err)
+		}
+	}
+	if d.Resolver != "" {
+		if err := validateResolverAddress(d.Resolver); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VaultConfig contains the configuration of the HashiCorp Vault secret the auth credentials (security definitions values)
+// are read from when the provider is configured, so the credentials do not need to be provided in the provider
+// configuration, environment variables or tfvars files
+type VaultConfig struct {
+	// Address defines the address of the Vault server (e,g: https://vault.example.com:8200)
+	Address string `yaml:"address"`
+	// Path defines the path of the secret containing the credentials (e,g: secret/data/terraform/cdn for a KV v2 secret
+	// engine mounted at secret/)
+	Path string `yaml:"path"`
+	// AuthMethod defines the method used to log in to Vault: token (default), kubernetes or jwt
+	AuthMethod string `yaml:"auth_method,omitempty"`
+	// AuthMount defines the path the auth method is mounted at; defaults to the auth method name
+	AuthMount string `yaml:"auth_mount,omitempty"`
+	// Role defines the role logged in with; required for the kubernetes and jwt auth methods
+	Role string `yaml:"role,omitempty"`
+	// JWTFile defines the file containing the JWT logged in with. For the kubernetes auth method, it defaults to the service
+	// account token mounted in the pod; required for the jwt auth method (e,g: the OIDC token issued to the CI job)
+	JWTFile string `yaml:"jwt_file,omitempty"`
+	// TokenFile defines the file containing the Vault token used with the token auth method; defaults to ~/.vault-token
+	// (e,g: the sink file written by the Vault agent)
+	TokenFile string `yaml:"token_file,omitempty"`
+	// Namespace defines the Vault namespace the secret and auth method belong to (Vault Enterprise)
+	Namespace string `yaml:"namespace,omitempty"`
+	// Fields maps the security definition names (as exposed in the provider configuration) to the secret fields containing
+	// their values. If a security definition is not mapped, the value is read from the secret field with the same name
+	Fields map[string]string `yaml:"fields,omitempty"`
+}
+
+// Validate makes sure the Vault configuration is valid
+func (v *VaultConfig) Validate() error {
+	if v.Address == "" {
+		return fmt.Errorf("address must not be empty")
+	}
+	if v.Path == "" {
+		return fmt.Errorf("path must not be empty")
+	}
+	switch v.getAuthMethod() {
+	case vaultAuthMethodToken:
+	case vaultAuthMethodKubernetes:
+		if v.Role == "" {
+			return fmt.Errorf("role must not be empty for the %s auth method", vaultAuthMethodKubernetes)
+		}
+	case vaultAuthMethodJWT:
+		if v.Role == "" || v.JWTFile == "" {
+			return fmt.Errorf("role and jwt_file must not be empty for the %s auth method", vaultAuthMethodJWT)
+		}
+	default:
+		return fmt.Errorf("auth_method '%s' not supported, supported auth methods are [%s, %s, %s]", v.AuthMethod, vaultAuthMethodToken, vaultAuthMethodKubernetes, vaultAuthMethodJWT)
+	}
+	return nil
+}
+
+// ServiceProfileConfig contains the configuration of a named environment profile of the service (e,g: dev, staging or
+// prod). The values configured in the profile override the ones configured for the service
+type ServiceProfileConfig struct {
+	// SwaggerURL defines where the swagger of the environment is located. If not provided, the service swagger-url is used
+	SwaggerURL string `yaml:"swagger-url,omitempty"`
+	// InsecureSkipVerify overrides the service insecure_skip_verify configuration for the environment
+	InsecureSkipVerify *bool `yaml:"insecure_skip_verify,omitempty"`
+	// Host defines the host the API calls are made against overriding the host defined in the OpenAPI document (e,g:
+	// api.staging.example.com). Not applicable to multi-region providers
+	Host string `yaml:"host,omitempty"`
+	// AuthEnvVars maps the provider properties (security definitions and headers) to the environment variables their
+	// values are read from when not provided in the provider configuration (e,g: apikey_auth: STAGING_API_KEY)
+	AuthEnvVars map[string]string `yaml:"auth_env_vars,omitempty"`
+}
+
+// getAuthEnvVarValue returns the value of the environment variable configured in the profile auth_env_vars for the given
+// provider property; empty is returned if the profile is nil or the property is not configured
+func (p *ServiceProfileConfig) getAuthEnvVarValue(propertyName string) string {
+	if p == nil {
+		return ""
+	}
+	envVar, exists := p.AuthEnvVars[propertyName]
+	if !exists {
+		return ""
+	}
+	return os.Getenv(envVar)
+}
+
 // ServiceConfigV1 defines configuration for the service provider
 type ServiceConfigV1 struct {
 	// SwaggerURL defines where the swagger is located
@@ -41,6 +563,83 @@ type ServiceConfigV1 struct {
 	SchemaConfigurationV1 []ServiceSchemaPropertyConfigurationV1 `yaml:"schema_configuration,omitempty"`
 
 	TelemetryConfig *TelemetryConfig `yaml:"telemetry,omitempty"`
+	// SpecVersionCheck defines the configuration of the API endpoint advertising the minimum OpenAPI document version
+	SpecVersionCheck *SpecVersionCheckConfig `yaml:"spec_version_check,omitempty"`
+	// RequestCompression defines the configuration for compressing the request bodies sent to the API
+	RequestCompression *RequestCompressionConfig `yaml:"request_compression,omitempty"`
+	// MaxResponseBodySize defines the max size in bytes of the API response bodies (e,g: protecting the provider against
+	// list endpoints returning huge responses). Zero means no limit
+	MaxResponseBodySize int64 `yaml:"max_response_body_size,omitempty"`
+	// SpecFormat defines the format of the document located in the swagger URL (e,g: v2 for OpenAPI v2 documents or any other
+	// format registered via RegisterSpecAnalyser). If not provided, the format is detected based on the document content
+	SpecFormat string `yaml:"spec_format,omitempty"`
+	// Backend defines the backend the API calls are performed against. If not provided, the API calls are performed
+	// against the REST API
+	Backend *BackendConfig `yaml:"backend,omitempty"`
+	// AdditionalSwaggerURLs defines the URLs of additional OpenAPI documents whose resources and data sources are merged
+	// into the provider (e,g: when the API is split into several OpenAPI documents, one per microservice)
+	AdditionalSwaggerURLs []string `yaml:"additional_swagger_urls,omitempty"`
+	// Middleware defines the chain of middlewares the API calls go through (e,g: retries, rate limiting). The middlewares
+	// are applied in the order they are defined, the first one being the outermost
+	Middleware []MiddlewareConfig `yaml:"middleware,omitempty"`
+	// UserAgent defines the template (Go text/template) of the User-Agent header sent in the API calls (e,g:
+	// "{{.ProviderName}}/{{.ProviderVersion}} terraform/{{.TerraformVersion}}"). If not provided, the default OpenAPI
+	// Terraform provider User-Agent is sent
+	UserAgent string `yaml:"user_agent,omitempty"`
+	// IdentificationHeaders defines static headers sent in all the API calls to identify the client (e,g: API gateways
+	// requiring client attribution or quota assignment headers)
+	IdentificationHeaders map[string]string `yaml:"identification_headers,omitempty"`
+	// TLS defines the TLS configuration of the client transport used to perform the API calls (e,g: restricting the cipher
+	// suites allowed)
+	TLS *TLSConfig `yaml:"tls,omitempty"`
+	// AuditLog defines the configuration of the audit log of the mutating API calls. If not provided, the API calls are
+	// not audited
+	AuditLog *AuditLogConfig `yaml:"audit_log,omitempty"`
+	// Webhooks defines the webhooks notified of the resources successfully created, updated or deleted by the provider.
+	// If not provided, no notifications are sent
+	Webhooks []WebhookConfig `yaml:"webhooks,omitempty"`
+	// HealthCheck defines the configuration of the API health endpoint checked before performing the mutating API calls.
+	// If not provided, the API health is not checked
+	HealthCheck *HealthCheckConfig `yaml:"health_check,omitempty"`
+	// RateLimitRetry defines the budget of the retries of the API calls responding 429 Too Many Requests. If not provided,
+	// the API calls are retried up to 3 times waiting up to 1m in total
+	RateLimitRetry *RateLimitRetryConfig `yaml:"rate_limit_retry,omitempty"`
+	// StateEncryption defines the configuration of the key used to encrypt the properties stored in the state with the
+	// x-terraform-encrypt-in-state extension
+	StateEncryption *StateEncryptionConfig `yaml:"state_encryption,omitempty"`
+	// Profiles defines the named environment profiles of the service (e,g: dev, staging or prod), each one with its own
+	// swagger URL, host and auth environment variables. The profile is selected via the OTF_VAR_<provider_name>_PROFILE
+	// environment variable or the profile provider property
+	Profiles map[string]*ServiceProfileConfig `yaml:"profiles,omitempty"`
+	// DefaultProfile defines the profile selected if the OTF_VAR_<provider_name>_PROFILE environment variable is not set
+	DefaultProfile string `yaml:"default_profile,omitempty"`
+	// ResourceNamingVersion pins the version of the naming algorithm the resource and data source names are built with,
+	// so the names (and therefore the existing state addresses) do not change when the provider is upgraded. If not
+	// configured, the current version of the naming algorithm is used
+	ResourceNamingVersion int `yaml:"resource_naming_version,omitempty"`
+	// Failover defines the fallback hosts the API calls fail over to when the API host is not available. If not provided,
+	// the fallback hosts defined in the OpenAPI document (if any) are used
+	Failover *FailoverConfig `yaml:"failover,omitempty"`
+	// ServiceDiscovery defines the DNS SRV record the API host is resolved from when the provider is configured. The host
+	// resolved takes preference over the host defined in the OpenAPI document
+	ServiceDiscovery *ServiceDiscoveryConfig `yaml:"service_discovery,omitempty"`
+	// Dialer defines the configuration of the dialer the connections to the API are established with (e,g: prefer IPv6
+	// or use a custom DNS resolver). If not provided, the Go defaults are used
+	Dialer *DialerConfig `yaml:"dialer,omitempty"`
+	// VerifyWrites enables the read-after-write verification: the resources are read after being created or updated and
+	// warnings are emitted listing the properties sent whose values the API did not keep (e,g: APIs that accept and ignore
+	// unknown or invalid values)
+	VerifyWrites bool `yaml:"verify_writes,omitempty"`
+	// Vault defines the HashiCorp Vault secret the auth credentials are read from when the provider is configured. The
+	// values provided in the provider configuration take preference over the ones read from Vault
+	Vault *VaultConfig `yaml:"vault,omitempty"`
+	// QuirksMode enables the quirks mode: the common artifacts of the spec generators (e,g: FastAPI or Springdoc) that
+	// the provider would otherwise not support, like nullable schemas wrapped in anyOf, are fixed before analysing the
+	// OpenAPI documents
+	QuirksMode bool `yaml:"quirks_mode,omitempty"`
+
+	// selectedProfile contains the name of the profile selected when the configuration was loaded
+	selectedProfile string
 }
 
 // NewServiceConfigV1 creates a new instance of NewServiceConfigV1 struct with the values provided
@@ -53,14 +652,21 @@ func NewServiceConfigV1(swaggerURL string, insecureSkipVerifyEnabled bool, telem
 	}
 }
 
-// GetSwaggerURL returns the URL where the service swagger doc is exposed
+// GetSwaggerURL returns the URL where the service swagger doc is exposed. The swagger URL of the selected profile takes
+// preference if configured
 func (s *ServiceConfigV1) GetSwaggerURL() string {
+	if profile := s.getSelectedProfileConfiguration(); profile != nil && profile.SwaggerURL != "" {
+		return profile.SwaggerURL
+	}
 	return s.SwaggerURL
 }
 
 // IsInsecureSkipVerifyEnabled returns true if the given provider's service configuration has InsecureSkipVerify enabled; false
-// otherwise
+// otherwise. The insecure_skip_verify configuration of the selected profile takes preference if configured
 func (s *ServiceConfigV1) IsInsecureSkipVerifyEnabled() bool {
+	if profile := s.getSelectedProfileConfiguration(); profile != nil && profile.InsecureSkipVerify != nil {
+		return *profile.InsecureSkipVerify
+	}
 	return s.InsecureSkipVerify
 }
 
@@ -68,34 +674,184 @@ func (s *ServiceConfigV1) IsInsecureSkipVerifyEnabled() bool {
 func (s *ServiceConfigV1) GetTelemetryConfiguration() TelemetryProvider {
 	if s.TelemetryConfig != nil {
 		if s.TelemetryConfig.Graphite != nil && s.TelemetryConfig.HTTPEndpoint != nil {
-			log.Printf("[WARN] ignoring telemetry due multiple telemetry providers configured (graphite and http_endpoint): select only one")
+			configLog.Warn("ignoring telemetry due multiple telemetry providers configured (graphite and http_endpoint): select only one")
 			return nil
 		}
 		if s.TelemetryConfig.Graphite != nil {
-			log.Printf("[DEBUG] graphite telemetry configuration present")
+			configLog.Debug("graphite telemetry configuration present")
 			err := s.TelemetryConfig.Graphite.Validate()
 			if err != nil {
-				log.Printf("[WARN] ignoring graphite telemetry due to the following validation error: %s", err)
+				configLog.Warn("ignoring graphite telemetry due to the following validation error: %s", err)
 				return nil
 			}
-			log.Printf("[DEBUG] graphite telemetry provider enabled")
+			configLog.Debug("graphite telemetry provider enabled")
 			return s.TelemetryConfig.Graphite
 		}
 		if s.TelemetryConfig.HTTPEndpoint != nil {
-			log.Printf("[DEBUG] http endpoint telemetry configuration present")
+			configLog.Debug("http endpoint telemetry configuration present")
 			err := s.TelemetryConfig.HTTPEndpoint.Validate()
 			if err != nil {
-				log.Printf("[WARN] ignoring http endpoint telemetry due to the following validation error: %s", err)
+				configLog.Warn("ignoring http endpoint telemetry due to the following validation error: %s", err)
 				return nil
 			}
-			log.Printf("[DEBUG] http endpoint telemetry provider enabled")
+			configLog.Debug("http endpoint telemetry provider enabled")
 			return s.TelemetryConfig.HTTPEndpoint
 		}
 	}
-	log.Printf("[DEBUG] telemetry not configured")
+	configLog.Debug("telemetry not configured")
+	return nil
+}
+
+// GetSpecVersionCheckConfiguration returns the spec version check configuration; nil is returned if not configured
+func (s *ServiceConfigV1) GetSpecVersionCheckConfiguration() *SpecVersionCheckConfig {
+	return s.SpecVersionCheck
+}
+
+// GetRequestCompressionConfiguration returns the request compression configuration; nil is returned if not configured
+func (s *ServiceConfigV1) GetRequestCompressionConfiguration() *RequestCompressionConfig {
+	return s.RequestCompression
+}
+
+// GetMaxResponseBodySize returns the max size in bytes of the API response bodies; zero means no limit
+func (s *ServiceConfigV1) GetMaxResponseBodySize() int64 {
+	return s.MaxResponseBodySize
+}
+
+// GetSpecFormat returns the spec format configured; empty is returned if not configured
+func (s *ServiceConfigV1) GetSpecFormat() SpecAnalyserVersion {
+	return SpecAnalyserVersion(s.SpecFormat)
+}
+
+// GetBackendConfiguration returns the backend configuration; nil is returned if not configured
+func (s *ServiceConfigV1) GetBackendConfiguration() *BackendConfig {
+	return s.Backend
+}
+
+// GetAdditionalSwaggerURLs returns the additional swagger URLs configured; empty is returned if not configured
+func (s *ServiceConfigV1) GetAdditionalSwaggerURLs() []string {
+	return s.AdditionalSwaggerURLs
+}
+
+// GetMiddlewareConfiguration returns the middleware chain configured; empty is returned if not configured
+func (s *ServiceConfigV1) GetMiddlewareConfiguration() []MiddlewareConfig {
+	return s.Middleware
+}
+
+// GetUserAgent returns the User-Agent template configured; empty is returned if not configured
+func (s *ServiceConfigV1) GetUserAgent() string {
+	return s.UserAgent
+}
+
+// GetIdentificationHeaders returns the identification headers configured; empty is returned if not configured
+func (s *ServiceConfigV1) GetIdentificationHeaders() map[string]string {
+	return s.IdentificationHeaders
+}
+
+// GetTLSConfiguration returns the TLS configuration; nil is returned if not configured
+func (s *ServiceConfigV1) GetTLSConfiguration() *TLSConfig {
+	return s.TLS
+}
+
+// GetAuditLogConfiguration returns the audit log configuration; nil is returned if not configured
+func (s *ServiceConfigV1) GetAuditLogConfiguration() *AuditLogConfig {
+	return s.AuditLog
+}
+
+// GetWebhookConfigurations returns the webhooks configured; empty is returned if not configured
+func (s *ServiceConfigV1) GetWebhookConfigurations() []WebhookConfig {
+	return s.Webhooks
+}
+
+// GetHealthCheckConfiguration returns the health check configuration; nil is returned if not configured
+func (s *ServiceConfigV1) GetHealthCheckConfiguration() *HealthCheckConfig {
+	return s.HealthCheck
+}
+
+// GetRateLimitRetryConfiguration returns the rate limit retry configuration; nil is returned if not configured
+func (s *ServiceConfigV1) GetRateLimitRetryConfiguration() *RateLimitRetryConfig {
+	return s.RateLimitRetry
+}
+
+// GetStateEncryptionConfiguration returns the state encryption configuration; nil is returned if not configured
+func (s *ServiceConfigV1) GetStateEncryptionConfiguration() *StateEncryptionConfig {
+	return s.StateEncryption
+}
+
+// GetProfileConfigurations returns the profiles configured; empty is returned if not configured
+func (s *ServiceConfigV1) GetProfileConfigurations() map[string]*ServiceProfileConfig {
+	return s.Profiles
+}
+
+// GetSelectedProfile returns the name of the profile selected; empty is returned if no profile is selected
+func (s *ServiceConfigV1) GetSelectedProfile() string {
+	return s.selectedProfile
+}
+
+// GetResourceNamingVersion returns the version of the naming algorithm the resource and data source names are built with;
+// the current version is returned if not configured
+func (s *ServiceConfigV1) GetResourceNamingVersion() int {
+	if s.ResourceNamingVersion == 0 {
+		return CurrentResourceNamingVersion
+	}
+	return s.ResourceNamingVersion
+}
+
+// GetFailoverConfiguration returns the configuration of the fallback hosts the API calls fail over to; nil is returned if
+// not configured
+func (s *ServiceConfigV1) GetFailoverConfiguration() *FailoverConfig {
+	return s.Failover
+}
+
+// GetServiceDiscoveryConfiguration returns the configuration of the DNS SRV record the API host is resolved from; nil is
+// returned if not configured
+func (s *ServiceConfigV1) GetServiceDiscoveryConfiguration() *ServiceDiscoveryConfig {
+	return s.ServiceDiscovery
+}
+
+// GetDialerConfiguration returns the configuration of the dialer the connections to the API are established with; nil is
+// returned if not configured
+func (s *ServiceConfigV1) GetDialerConfiguration() *DialerConfig {
+	return s.Dialer
+}
+
+// IsVerifyWritesEnabled returns true if the read-after-write verification is enabled
+func (s *ServiceConfigV1) IsVerifyWritesEnabled() bool {
+	return s.VerifyWrites
+}
+
+// GetVaultConfiguration returns the configuration of the Vault secret the auth credentials are read from; nil is returned
+// if not configured
+func (s *ServiceConfigV1) GetVaultConfiguration() *VaultConfig {
+	return s.Vault
+}
+
+// IsQuirksModeEnabled returns true if the quirks mode is enabled
+func (s *ServiceConfigV1) IsQuirksModeEnabled() bool {
+	return s.QuirksMode
+}
+
+// selectProfile selects the profile with the given name, falling back to the default profile if the name is empty
+func (s *ServiceConfigV1) selectProfile(name string) error {
+	if name == "" {
+		name = s.DefaultProfile
+	}
+	if name == "" {
+		return nil
+	}
+	if _, exists := s.Profiles[name]; !exists {
+		return fmt.Errorf("profile '%s' not found in the service profiles configuration", name)
+	}
+	s.selectedProfile = name
 	return nil
 }
 
+func (s *ServiceConfigV1) getSelectedProfileConfiguration() *ServiceProfileConfig {
+	if s.selectedProfile == "" {
+		return nil
+	}
+	return s.Profiles[s.selectedProfile]
+}
+
 // GetSchemaPropertyConfiguration returns the external configuration for the given schema property name; nil is returned
 // if no such property exists
 func (s *ServiceConfigV1) GetSchemaPropertyConfiguration(schemaPropertyName string) ServiceSchemaPropertyConfiguration {
@@ -109,10 +865,130 @@ func (s *ServiceConfigV1) GetSchemaPropertyConfiguration(schemaPropertyName stri
 
 // Validate makes sure the configuration is valid:
 func (s *ServiceConfigV1) Validate() error {
-	if !govalidator.IsURL(s.SwaggerURL) {
+	_, isInMemoryDocument := getInMemoryDocument(s.GetSwaggerURL())
+	if !isInMemoryDocument && !govalidator.IsURL(s.GetSwaggerURL()) {
 		// fall back to try to load the swagger file from disk in case the path provided is a path to a file on disk
-		if _, err := os.Stat(s.SwaggerURL); os.IsNotExist(err) {
-			return fmt.Errorf("service swagger URL configuration not valid ('%s'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk", s.SwaggerURL)
+		if _, err := os.Stat(s.GetSwaggerURL()); os.IsNotExist(err) {
+			return fmt.Errorf("service swagger URL configuration not valid ('%s'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk", s.GetSwaggerURL())
+		}
+	}
+	if s.DefaultProfile != "" {
+		if _, exists := s.Profiles[s.DefaultProfile]; !exists {
+			return fmt.Errorf("service default_profile configuration not valid: profile '%s' not found in the service profiles configuration", s.DefaultProfile)
+		}
+	}
+	for name, profile := range s.Profiles {
+		if profile == nil {
+			return fmt.Errorf("service profiles configuration not valid: profile '%s' must not be empty", name)
+		}
+		for propertyName, envVar := range profile.AuthEnvVars {
+			if propertyName == "" || envVar == "" {
+				return fmt.Errorf("service profiles configuration not valid: profile '%s' auth_env_vars must not contain empty property names or environment variable names", name)
+			}
+		}
+	}
+	for _, additionalSwaggerURL := range s.AdditionalSwaggerURLs {
+		if !govalidator.IsURL(additionalSwaggerURL) {
+			if _, err := os.Stat(additionalSwaggerURL); os.IsNotExist(err) {
+				return fmt.Errorf("service additional_swagger_urls configuration not valid ('%s'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk", additionalSwaggerURL)
+			}
+		}
+	}
+	if s.ResourceNamingVersion != 0 && !isResourceNamingVersionSupported(s.ResourceNamingVersion) {
+		return fmt.Errorf("service resource_naming_version configuration not valid: version %d not supported, supported versions are %d to %d", s.ResourceNamingVersion, resourceNamingV1, CurrentResourceNamingVersion)
+	}
+	if s.SpecVersionCheck != nil && s.SpecVersionCheck.Path == "" {
+		return fmt.Errorf("service spec_version_check configuration not valid: path must not be empty")
+	}
+	if s.RequestCompression != nil && s.RequestCompression.GzipThreshold < 0 {
+		return fmt.Errorf("service request_compression configuration not valid: gzip_threshold must not be negative")
+	}
+	if s.MaxResponseBodySize < 0 {
+		return fmt.Errorf("service max_response_body_size configuration not valid: value must not be negative")
+	}
+	if s.Backend != nil {
+		switch s.Backend.Type {
+		case backendTypeREST:
+		case backendTypeGRPC:
+			if s.Backend.Address == "" {
+				return fmt.Errorf("service backend configuration not valid: address must not be empty for the grpc backend")
+			}
+		default:
+			return fmt.Errorf("service backend configuration not valid: type '%s' not supported, supported types are [%s, %s]", s.Backend.Type, backendTypeREST, backendTypeGRPC)
+		}
+	}
+	middlewareNames := map[string]bool{}
+	for _, middleware := range s.Middleware {
+		if err := middleware.Validate(); err != nil {
+			return fmt.Errorf("service middleware configuration not valid: %s", err)
+		}
+		if middlewareNames[middleware.GetName()] {
+			return fmt.Errorf("service middleware configuration not valid: middleware name '%s' is duplicated", middleware.GetName())
+		}
+		middlewareNames[middleware.GetName()] = true
+	}
+	if s.UserAgent != "" {
+		if _, err := newUserAgentTemplate(s.UserAgent); err != nil {
+			return fmt.Errorf("service user_agent configuration not valid: %s", err)
+		}
+	}
+	for name := range s.IdentificationHeaders {
+		if name == "" {
+			return fmt.Errorf("service identification_headers configuration not valid: header name must not be empty")
+		}
+		if strings.EqualFold(name, userAgentHeader) {
+			return fmt.Errorf("service identification_headers configuration not valid: the %s header must be configured using user_agent", userAgentHeader)
+		}
+	}
+	if s.TLS != nil {
+		if err := s.TLS.Validate(); err != nil {
+			return fmt.Errorf("service tls configuration not valid: %s", err)
+		}
+	}
+	if s.AuditLog != nil && s.AuditLog.Path == "" {
+		return fmt.Errorf("service audit_log configuration not valid: path must not be empty")
+	}
+	for _, webhook := range s.Webhooks {
+		if err := webhook.Validate(); err != nil {
+			return fmt.Errorf("service webhooks configuration not valid: %s", err)
+		}
+	}
+	if s.HealthCheck != nil {
+		if err := s.HealthCheck.Validate(); err != nil {
+			return fmt.Errorf("service health_check configuration not valid: %s", err)
+		}
+	}
+	if s.RateLimitRetry != nil {
+		if err := s.RateLimitRetry.Validate(); err != nil {
+			return fmt.Errorf("service rate_limit_retry configuration not valid: %s", err)
+		}
+	}
+	if s.Failover != nil {
+		if err := s.Failover.Validate(); err != nil {
+			return fmt.Errorf("service failover configuration not valid: %s", err)
+		}
+	}
+	if s.ServiceDiscovery != nil {
+		if err := s.ServiceDiscovery.Validate(); err != nil {
+			return fmt.Errorf("service service_discovery configuration not valid: %s", err)
+		}
+	}
+	if s.Dialer != nil {
+		if err := s.Dialer.Validate(); err != nil {
+			return fmt.Errorf("service dialer configuration not valid: %s", err)
+		}
+	}
+	if s.Vault != nil {
+		if err := s.Vault.Validate(); err != nil {
+			return fmt.Errorf("service vault configuration not valid: %s", err)
+		}
+	}
+	if s.StateEncryption != nil {
+		if (s.StateEncryption.KeyEnv == "") == (len(s.StateEncryption.KeyCommand) == 0) {
+			return fmt.Errorf("service state_encryption configuration not valid: either key_env or key_command must be provided")
+		}
+		if s.StateEncryption.KeyCommandTimeout < 0 {
+			return fmt.Errorf("service state_encryption configuration not valid: key_command_timeout must not be negative")
 		}
 	}
 	return nil
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/oliveagle/jsonpath"
-	"log"
 	"os/exec"
 	"time"
 )
@@ -51,7 +50,7 @@ type ServiceSchemaPropertyExternalConfigurationV1 struct {
 func (s ServiceSchemaPropertyConfigurationV1) GetDefaultValue() (string, error) {
 	if &s.ExternalConfiguration != nil {
 		if s.ExternalConfiguration.File != "" {
-			log.Printf("[DEBUG] provider schema property '%s' configured to use as default value [ContentType=%s; File=%s, KeyName=%s]", s.SchemaPropertyName, s.ExternalConfiguration.ContentType, s.ExternalConfiguration.File, s.ExternalConfiguration.KeyName)
+			configLog.Debug("provider schema property '%s' configured to use as default value [ContentType=%s; File=%s, KeyName=%s]", s.SchemaPropertyName, s.ExternalConfiguration.ContentType, s.ExternalConfiguration.File, s.ExternalConfiguration.KeyName)
 			schemaFileParser, err := s.ExternalConfiguration.getFileParser()
 			if err != nil {
 				return "", fmt.Errorf("failed to read external configuration file '%s' for schema property '%s': %s", s.ExternalConfiguration.File, s.SchemaPropertyName, err)
@@ -85,7 +84,7 @@ func (s ServiceSchemaPropertyConfigurationV1) ExecuteCommand() error {
 func (s ServiceSchemaPropertyConfigurationV1) exec(doneChan chan error) {
 	if len(s.Command) > 0 {
 		start := time.Now()
-		log.Printf("[INFO] executing '%s' command '%s'", s.SchemaPropertyName, s.Command)
+		configLog.Info("executing '%s' command '%s'", s.SchemaPropertyName, s.Command)
 
 		timeout := cmdTimeout
 		if s.CommandTimeout > 0 {
@@ -118,7 +117,7 @@ func (s ServiceSchemaPropertyConfigurationV1) exec(doneChan chan error) {
 			doneChan <- fmt.Errorf("command '%s' failed: %s(%s)", s.Command, stderr.String(), err)
 			return
 		}
-		log.Printf("[INFO] provider schema property '%s' command '%s' executed successfully (time:%s): %s", s.SchemaPropertyName, s.Command, time.Since(start), stdout.String())
+		configLog.Info("provider schema property '%s' command '%s' executed successfully (time:%s): %s", s.SchemaPropertyName, s.Command, time.Since(start), stdout.String())
 	}
 	doneChan <- nil
 }
@@ -131,7 +130,7 @@ func (c ServiceSchemaPropertyExternalConfigurationV1) getFileParser() (schemaFil
 	switch c.ContentType {
 	case "raw":
 		if c.KeyName != "" {
-			log.Printf("[WARN] service external configuration of type 'raw' configured with key value '%s'", c.KeyName)
+			configLog.Warn("service external configuration of type 'raw' configured with key value '%s'", c.KeyName)
 		}
 		return parserRaw{content: schemaFileContent}, nil
 	case "json":
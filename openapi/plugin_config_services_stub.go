@@ -4,12 +4,36 @@ package openapi
 // provider by calling the CreateSchemaProviderWithConfiguration function passing in the stub wit the swagger URL populated
 // with the URL where the openapi doc is hosted.
 type ServiceConfigStub struct {
-	SwaggerURL          string
-	PluginVersion       string
-	InsecureSkipVerify  bool
-	Telemetry           TelemetryProvider
-	SchemaConfiguration []*ServiceSchemaPropertyConfigurationStub
-	Err                 error
+	SwaggerURL            string
+	PluginVersion         string
+	InsecureSkipVerify    bool
+	Telemetry             TelemetryProvider
+	SchemaConfiguration   []*ServiceSchemaPropertyConfigurationStub
+	SpecVersionCheck      *SpecVersionCheckConfig
+	RequestCompression    *RequestCompressionConfig
+	MaxResponseBodySize   int64
+	SpecFormat            SpecAnalyserVersion
+	Backend               *BackendConfig
+	AdditionalSwaggerURLs []string
+	Middleware            []MiddlewareConfig
+	UserAgent             string
+	IdentificationHeaders map[string]string
+	TLS                   *TLSConfig
+	AuditLog              *AuditLogConfig
+	Webhooks              []WebhookConfig
+	HealthCheck           *HealthCheckConfig
+	RateLimitRetry        *RateLimitRetryConfig
+	StateEncryption       *StateEncryptionConfig
+	Profiles              map[string]*ServiceProfileConfig
+	SelectedProfile       string
+	ResourceNamingVersion int
+	Failover              *FailoverConfig
+	ServiceDiscovery      *ServiceDiscoveryConfig
+	Dialer                *DialerConfig
+	VerifyWrites          bool
+	Vault                 *VaultConfig
+	QuirksMode            bool
+	Err                   error
 }
 
 // ServiceSchemaPropertyConfigurationStub implements the ServiceSchemaPropertyConfiguration and can be used to simplify
@@ -52,6 +76,130 @@ func (s ServiceConfigStub) GetTelemetryConfiguration() TelemetryProvider {
 	return s.Telemetry
 }
 
+// GetSpecVersionCheckConfiguration returns the SpecVersionCheckConfig configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetSpecVersionCheckConfiguration() *SpecVersionCheckConfig {
+	return s.SpecVersionCheck
+}
+
+// GetRequestCompressionConfiguration returns the RequestCompressionConfig configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetRequestCompressionConfiguration() *RequestCompressionConfig {
+	return s.RequestCompression
+}
+
+// GetMaxResponseBodySize returns the MaxResponseBodySize configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetMaxResponseBodySize() int64 {
+	return s.MaxResponseBodySize
+}
+
+// GetSpecFormat returns the SpecFormat configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetSpecFormat() SpecAnalyserVersion {
+	return s.SpecFormat
+}
+
+// GetBackendConfiguration returns the BackendConfig configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetBackendConfiguration() *BackendConfig {
+	return s.Backend
+}
+
+// GetAdditionalSwaggerURLs returns the AdditionalSwaggerURLs configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetAdditionalSwaggerURLs() []string {
+	return s.AdditionalSwaggerURLs
+}
+
+// GetMiddlewareConfiguration returns the Middleware configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetMiddlewareConfiguration() []MiddlewareConfig {
+	return s.Middleware
+}
+
+// GetUserAgent returns the UserAgent configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetUserAgent() string {
+	return s.UserAgent
+}
+
+// GetIdentificationHeaders returns the IdentificationHeaders configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetIdentificationHeaders() map[string]string {
+	return s.IdentificationHeaders
+}
+
+// GetTLSConfiguration returns the TLS configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetTLSConfiguration() *TLSConfig {
+	return s.TLS
+}
+
+// GetAuditLogConfiguration returns the AuditLog configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetAuditLogConfiguration() *AuditLogConfig {
+	return s.AuditLog
+}
+
+// GetWebhookConfigurations returns the Webhooks configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetWebhookConfigurations() []WebhookConfig {
+	return s.Webhooks
+}
+
+// GetHealthCheckConfiguration returns the HealthCheck configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetHealthCheckConfiguration() *HealthCheckConfig {
+	return s.HealthCheck
+}
+
+// GetRateLimitRetryConfiguration returns the RateLimitRetry configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetRateLimitRetryConfiguration() *RateLimitRetryConfig {
+	return s.RateLimitRetry
+}
+
+// GetStateEncryptionConfiguration returns the StateEncryption configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetStateEncryptionConfiguration() *StateEncryptionConfig {
+	return s.StateEncryption
+}
+
+// GetProfileConfigurations returns the Profiles configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetProfileConfigurations() map[string]*ServiceProfileConfig {
+	return s.Profiles
+}
+
+// GetSelectedProfile returns the SelectedProfile configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetSelectedProfile() string {
+	return s.SelectedProfile
+}
+
+// GetResourceNamingVersion returns the ResourceNamingVersion configured in the ServiceConfigStub; the current version is
+// returned if not configured
+func (s ServiceConfigStub) GetResourceNamingVersion() int {
+	if s.ResourceNamingVersion == 0 {
+		return CurrentResourceNamingVersion
+	}
+	return s.ResourceNamingVersion
+}
+
+// GetFailoverConfiguration returns the Failover configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetFailoverConfiguration() *FailoverConfig {
+	return s.Failover
+}
+
+// GetServiceDiscoveryConfiguration returns the ServiceDiscovery configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetServiceDiscoveryConfiguration() *ServiceDiscoveryConfig {
+	return s.ServiceDiscovery
+}
+
+// GetDialerConfiguration returns the Dialer configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetDialerConfiguration() *DialerConfig {
+	return s.Dialer
+}
+
+// IsVerifyWritesEnabled returns the VerifyWrites configured in the ServiceConfigStub
+func (s ServiceConfigStub) IsVerifyWritesEnabled() bool {
+	return s.VerifyWrites
+}
+
+// GetVaultConfiguration returns the Vault configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetVaultConfiguration() *VaultConfig {
+	return s.Vault
+}
+
+// IsQuirksModeEnabled returns the QuirksMode configured in the ServiceConfigStub
+func (s ServiceConfigStub) IsQuirksModeEnabled() bool {
+	return s.QuirksMode
+}
+
 // GetDefaultValue returns the default value configured in the ServiceSchemaPropertyConfigurationStub.defaultValue field
 func (s *ServiceSchemaPropertyConfigurationStub) GetDefaultValue() (string, error) {
 	if s.GetDefaultValueFunc != nil {
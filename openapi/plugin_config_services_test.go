@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"bytes"
+	"fmt"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
@@ -116,6 +117,483 @@ func TestServiceConfigV1Validate(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a ServiceConfigV1 containing a spec version check configuration with an empty path", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL:       "http://sevice-api.com/swagger.yaml",
+			SpecVersionCheck: &SpecVersionCheckConfig{},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service spec_version_check configuration not valid: path must not be empty")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing a request compression configuration with a negative gzip threshold", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL:         "http://sevice-api.com/swagger.yaml",
+			RequestCompression: &RequestCompressionConfig{GzipThreshold: -1},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service request_compression configuration not valid: gzip_threshold must not be negative")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing a negative max response body size", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL:          "http://sevice-api.com/swagger.yaml",
+			MaxResponseBodySize: -1,
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service max_response_body_size configuration not valid: value must not be negative")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing a backend configuration with a type not supported", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			Backend:    &BackendConfig{Type: "graphql"},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service backend configuration not valid: type 'graphql' not supported, supported types are [rest, grpc]")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing a grpc backend configuration without address", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			Backend:    &BackendConfig{Type: "grpc"},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service backend configuration not valid: address must not be empty for the grpc backend")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing additional swagger urls that are not valid", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL:            "http://sevice-api.com/swagger.yaml",
+			AdditionalSwaggerURLs: []string{"http://other-sevice-api.com/swagger.yaml", "htpt:/non-valid-url"},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service additional_swagger_urls configuration not valid ('htpt:/non-valid-url'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing a middleware with a type not supported", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			Middleware: []MiddlewareConfig{{Type: "circuit_breaker"}},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service middleware configuration not valid: type 'circuit_breaker' not supported, supported types are [retry, rate_limit, headers, logging, csrf]")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing middlewares with the same name", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			Middleware: []MiddlewareConfig{{Type: "logging"}, {Type: "logging"}},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service middleware configuration not valid: middleware name 'logging' is duplicated")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing a user agent template that is not valid", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			UserAgent:  "{{.ProviderName",
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldStartWith, "service user_agent configuration not valid: template: user_agent:1: unclosed action")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing the User-Agent header in the identification headers", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL:            "http://sevice-api.com/swagger.yaml",
+			IdentificationHeaders: map[string]string{"user-agent": "my-agent"},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service identification_headers configuration not valid: the User-Agent header must be configured using user_agent")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing a tls configuration with a cipher suite not supported", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			TLS:        &TLSConfig{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service tls configuration not valid: cipher suite 'TLS_RSA_WITH_RC4_128_SHA' not supported")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing an audit log configuration without path", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			AuditLog:   &AuditLogConfig{Identity: "ci-pipeline"},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service audit_log configuration not valid: path must not be empty")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing a state encryption configuration without key", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL:      "http://sevice-api.com/swagger.yaml",
+			StateEncryption: &StateEncryptionConfig{},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service state_encryption configuration not valid: either key_env or key_command must be provided")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing a state encryption configuration with both key_env and key_command", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL:      "http://sevice-api.com/swagger.yaml",
+			StateEncryption: &StateEncryptionConfig{KeyEnv: "OTF_STATE_ENCRYPTION_KEY", KeyCommand: []string{"age", "-d", "key.age"}},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service state_encryption configuration not valid: either key_env or key_command must be provided")
+			})
+		})
+	})
+}
+
+func TestGetMiddlewareConfiguration(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Empty(t, serviceConfiguration.GetMiddlewareConfiguration())
+	expectedConfig := []MiddlewareConfig{{Type: "retry", MaxRetries: 3}, {Name: "audit", Type: "logging"}}
+	serviceConfiguration = &ServiceConfigV1{Middleware: expectedConfig}
+	assert.Equal(t, expectedConfig, serviceConfiguration.GetMiddlewareConfiguration())
+}
+
+func TestGetUserAgent(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Empty(t, serviceConfiguration.GetUserAgent())
+	serviceConfiguration = &ServiceConfigV1{UserAgent: "{{.ProviderName}}/{{.ProviderVersion}}"}
+	assert.Equal(t, "{{.ProviderName}}/{{.ProviderVersion}}", serviceConfiguration.GetUserAgent())
+}
+
+func TestGetIdentificationHeaders(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Empty(t, serviceConfiguration.GetIdentificationHeaders())
+	serviceConfiguration = &ServiceConfigV1{IdentificationHeaders: map[string]string{"X-Client-Id": "platform-team"}}
+	assert.Equal(t, map[string]string{"X-Client-Id": "platform-team"}, serviceConfiguration.GetIdentificationHeaders())
+}
+
+func TestGetTLSConfiguration(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Nil(t, serviceConfiguration.GetTLSConfiguration())
+	serviceConfiguration = &ServiceConfigV1{TLS: &TLSConfig{MinVersion: "1.3"}}
+	assert.Equal(t, &TLSConfig{MinVersion: "1.3"}, serviceConfiguration.GetTLSConfiguration())
+}
+
+func TestGetAuditLogConfiguration(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Nil(t, serviceConfiguration.GetAuditLogConfiguration())
+	serviceConfiguration = &ServiceConfigV1{AuditLog: &AuditLogConfig{Path: "/var/log/terraform-audit.log"}}
+	assert.Equal(t, &AuditLogConfig{Path: "/var/log/terraform-audit.log"}, serviceConfiguration.GetAuditLogConfiguration())
+}
+
+func TestGetWebhookConfigurations(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Empty(t, serviceConfiguration.GetWebhookConfigurations())
+	serviceConfiguration = &ServiceConfigV1{Webhooks: []WebhookConfig{{URL: "https://cmdb.example.com/terraform", Events: []string{"create", "delete"}}}}
+	assert.Equal(t, []WebhookConfig{{URL: "https://cmdb.example.com/terraform", Events: []string{"create", "delete"}}}, serviceConfiguration.GetWebhookConfigurations())
+}
+
+func TestWebhookConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		webhook       WebhookConfig
+		expectedError string
+	}{
+		{name: "all events", webhook: WebhookConfig{URL: "https://cmdb.example.com/terraform"}},
+		{name: "events and timeout", webhook: WebhookConfig{URL: "https://cmdb.example.com/terraform", Events: []string{"create", "update", "delete"}, Timeout: "5s"}},
+		{name: "url not valid", webhook: WebhookConfig{URL: "cmdb.example.com"}, expectedError: "url 'cmdb.example.com' is not a valid URL"},
+		{name: "event not supported", webhook: WebhookConfig{URL: "https://cmdb.example.com/terraform", Events: []string{"read"}}, expectedError: "event 'read' not supported, supported events are [create, update, delete]"},
+		{name: "timeout not valid", webhook: WebhookConfig{URL: "https://cmdb.example.com/terraform", Timeout: "5"}, expectedError: "timeout '5' not valid: time: missing unit in duration \"5\""},
+	}
+	for _, tc := range testCases {
+		err := tc.webhook.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+	serviceConfiguration := &ServiceConfigV1{SwaggerURL: "http://sevice-api.com/swagger.yaml", Webhooks: []WebhookConfig{{URL: "https://cmdb.example.com/terraform", Events: []string{"read"}}}}
+	assert.EqualError(t, serviceConfiguration.Validate(), "service webhooks configuration not valid: event 'read' not supported, supported events are [create, update, delete]")
+}
+
+func TestWebhookConfigIsNotifiedOf(t *testing.T) {
+	webhook := WebhookConfig{URL: "https://cmdb.example.com/terraform"}
+	assert.True(t, webhook.isNotifiedOf(webhookEventUpdate), "the webhook should be notified of all the events if none are configured")
+	webhook.Events = []string{webhookEventCreate, webhookEventDelete}
+	assert.True(t, webhook.isNotifiedOf(webhookEventDelete))
+	assert.False(t, webhook.isNotifiedOf(webhookEventUpdate))
+}
+
+func TestGetHealthCheckConfiguration(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Nil(t, serviceConfiguration.GetHealthCheckConfiguration())
+	serviceConfiguration = &ServiceConfigV1{HealthCheck: &HealthCheckConfig{Path: "/health"}}
+	assert.Equal(t, &HealthCheckConfig{Path: "/health"}, serviceConfiguration.GetHealthCheckConfiguration())
+}
+
+func TestHealthCheckConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		healthCheck   HealthCheckConfig
+		expectedError string
+	}{
+		{name: "path only", healthCheck: HealthCheckConfig{Path: "/health"}},
+		{name: "all properties", healthCheck: HealthCheckConfig{Path: "https://status.example.com/api/v2/status.json", StatusProperty: "status.indicator", BlockingStatuses: []string{"major", "critical"}, MessageProperty: "status.description", CacheTTL: "1m"}},
+		{name: "path missing", healthCheck: HealthCheckConfig{}, expectedError: "path must not be empty"},
+		{name: "cache ttl not valid", healthCheck: HealthCheckConfig{Path: "/health", CacheTTL: "30"}, expectedError: "cache_ttl '30' not valid: time: missing unit in duration \"30\""},
+	}
+	for _, tc := range testCases {
+		err := tc.healthCheck.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+	serviceConfiguration := &ServiceConfigV1{SwaggerURL: "http://sevice-api.com/swagger.yaml", HealthCheck: &HealthCheckConfig{}}
+	assert.EqualError(t, serviceConfiguration.Validate(), "service health_check configuration not valid: path must not be empty")
+}
+
+func TestGetRateLimitRetryConfiguration(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Nil(t, serviceConfiguration.GetRateLimitRetryConfiguration())
+	serviceConfiguration = &ServiceConfigV1{RateLimitRetry: &RateLimitRetryConfig{MaxWait: "2m"}}
+	assert.Equal(t, &RateLimitRetryConfig{MaxWait: "2m"}, serviceConfiguration.GetRateLimitRetryConfiguration())
+}
+
+func TestRateLimitRetryConfigValidate(t *testing.T) {
+	maxRetries, negativeMaxRetries := 5, -1
+	testCases := []struct {
+		name           string
+		rateLimitRetry RateLimitRetryConfig
+		expectedError  string
+	}{
+		{name: "defaults", rateLimitRetry: RateLimitRetryConfig{}},
+		{name: "max retries and max wait", rateLimitRetry: RateLimitRetryConfig{MaxRetries: &maxRetries, MaxWait: "2m"}},
+		{name: "max retries negative", rateLimitRetry: RateLimitRetryConfig{MaxRetries: &negativeMaxRetries}, expectedError: "max_retries must not be negative"},
+		{name: "max wait not valid", rateLimitRetry: RateLimitRetryConfig{MaxWait: "2"}, expectedError: "max_wait '2' not valid: time: missing unit in duration \"2\""},
+	}
+	for _, tc := range testCases {
+		err := tc.rateLimitRetry.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+	serviceConfiguration := &ServiceConfigV1{SwaggerURL: "http://sevice-api.com/swagger.yaml", RateLimitRetry: &RateLimitRetryConfig{MaxRetries: &negativeMaxRetries}}
+	assert.EqualError(t, serviceConfiguration.Validate(), "service rate_limit_retry configuration not valid: max_retries must not be negative")
+}
+
+func TestGetStateEncryptionConfiguration(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Nil(t, serviceConfiguration.GetStateEncryptionConfiguration())
+	serviceConfiguration = &ServiceConfigV1{StateEncryption: &StateEncryptionConfig{KeyEnv: "OTF_STATE_ENCRYPTION_KEY"}}
+	assert.Equal(t, &StateEncryptionConfig{KeyEnv: "OTF_STATE_ENCRYPTION_KEY"}, serviceConfiguration.GetStateEncryptionConfiguration())
+}
+
+func TestServiceConfigV1Profiles(t *testing.T) {
+	insecureSkipVerify := true
+	serviceConfiguration := &ServiceConfigV1{
+		SwaggerURL: "http://host.com/swagger.json",
+		Profiles: map[string]*ServiceProfileConfig{
+			"dev":     {SwaggerURL: "http://dev.host.com/swagger.json", InsecureSkipVerify: &insecureSkipVerify},
+			"staging": {Host: "staging.host.com"},
+		},
+		DefaultProfile: "staging",
+	}
+	assert.Len(t, serviceConfiguration.GetProfileConfigurations(), 2)
+	assert.Empty(t, serviceConfiguration.GetSelectedProfile())
+
+	assert.NoError(t, serviceConfiguration.selectProfile(""))
+	assert.Equal(t, "staging", serviceConfiguration.GetSelectedProfile())
+	assert.Equal(t, "http://host.com/swagger.json", serviceConfiguration.GetSwaggerURL())
+	assert.False(t, serviceConfiguration.IsInsecureSkipVerifyEnabled())
+
+	assert.NoError(t, serviceConfiguration.selectProfile("dev"))
+	assert.Equal(t, "dev", serviceConfiguration.GetSelectedProfile())
+	assert.Equal(t, "http://dev.host.com/swagger.json", serviceConfiguration.GetSwaggerURL())
+	assert.True(t, serviceConfiguration.IsInsecureSkipVerifyEnabled())
+
+	assert.EqualError(t, serviceConfiguration.selectProfile("prod"), "profile 'prod' not found in the service profiles configuration")
+
+	serviceConfiguration = &ServiceConfigV1{SwaggerURL: "http://host.com/swagger.json"}
+	assert.NoError(t, serviceConfiguration.selectProfile(""))
+	assert.Empty(t, serviceConfiguration.GetSelectedProfile())
+}
+
+func TestServiceConfigV1ResourceNamingVersion(t *testing.T) {
+	assert.Equal(t, CurrentResourceNamingVersion, (&ServiceConfigV1{}).GetResourceNamingVersion())
+	assert.Equal(t, resourceNamingV1, (&ServiceConfigV1{ResourceNamingVersion: resourceNamingV1}).GetResourceNamingVersion())
+
+	serviceConfig := &ServiceConfigV1{SwaggerURL: "http://host.com/swagger.json", ResourceNamingVersion: resourceNamingV1}
+	assert.NoError(t, serviceConfig.Validate())
+	serviceConfig.ResourceNamingVersion = CurrentResourceNamingVersion + 1
+	assert.EqualError(t, serviceConfig.Validate(), fmt.Sprintf("service resource_naming_version configuration not valid: version %d not supported, supported versions are 1 to %d", CurrentResourceNamingVersion+1, CurrentResourceNamingVersion))
+}
+
+func TestServiceConfigV1ValidateProfiles(t *testing.T) {
+	testCases := []struct {
+		name          string
+		serviceConfig *ServiceConfigV1
+		expectedError string
+	}{
+		{
+			name: "valid profiles",
+			serviceConfig: &ServiceConfigV1{
+				SwaggerURL:     "http://host.com/swagger.json",
+				Profiles:       map[string]*ServiceProfileConfig{"dev": {AuthEnvVars: map[string]string{"apikey_auth": "DEV_API_KEY"}}},
+				DefaultProfile: "dev",
+			},
+		},
+		{
+			name: "default profile not found",
+			serviceConfig: &ServiceConfigV1{
+				SwaggerURL:     "http://host.com/swagger.json",
+				Profiles:       map[string]*ServiceProfileConfig{"dev": {}},
+				DefaultProfile: "prod",
+			},
+			expectedError: "service default_profile configuration not valid: profile 'prod' not found in the service profiles configuration",
+		},
+		{
+			name: "empty profile",
+			serviceConfig: &ServiceConfigV1{
+				SwaggerURL: "http://host.com/swagger.json",
+				Profiles:   map[string]*ServiceProfileConfig{"dev": nil},
+			},
+			expectedError: "service profiles configuration not valid: profile 'dev' must not be empty",
+		},
+		{
+			name: "auth env var with empty environment variable name",
+			serviceConfig: &ServiceConfigV1{
+				SwaggerURL: "http://host.com/swagger.json",
+				Profiles:   map[string]*ServiceProfileConfig{"dev": {AuthEnvVars: map[string]string{"apikey_auth": ""}}},
+			},
+			expectedError: "service profiles configuration not valid: profile 'dev' auth_env_vars must not contain empty property names or environment variable names",
+		},
+		{
+			name: "selected profile swagger URL not valid",
+			serviceConfig: &ServiceConfigV1{
+				SwaggerURL:      "http://host.com/swagger.json",
+				Profiles:        map[string]*ServiceProfileConfig{"dev": {SwaggerURL: "non-valid-url"}},
+				selectedProfile: "dev",
+			},
+			expectedError: "service swagger URL configuration not valid ('non-valid-url'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk",
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.serviceConfig.Validate()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+	}
+}
+
+func TestServiceProfileConfigGetAuthEnvVarValue(t *testing.T) {
+	t.Setenv("OTF_TEST_STAGING_API_KEY", "stagingAPIKey")
+	profile := &ServiceProfileConfig{AuthEnvVars: map[string]string{"apikey_auth": "OTF_TEST_STAGING_API_KEY", "other_auth": "OTF_TEST_NOT_SET"}}
+	assert.Equal(t, "stagingAPIKey", profile.getAuthEnvVarValue("apikey_auth"))
+	assert.Empty(t, profile.getAuthEnvVarValue("other_auth"))
+	assert.Empty(t, profile.getAuthEnvVarValue("unknown"))
+	var nilProfile *ServiceProfileConfig
+	assert.Empty(t, nilProfile.getAuthEnvVarValue("apikey_auth"))
+}
+
+func TestMiddlewareConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		middleware    MiddlewareConfig
+		expectedError string
+	}{
+		{name: "retry middleware", middleware: MiddlewareConfig{Type: "retry", MaxRetries: 3, RetryWait: "500ms"}},
+		{name: "retry middleware without max retries", middleware: MiddlewareConfig{Type: "retry"}, expectedError: "max_retries must be greater than zero for the retry middleware"},
+		{name: "retry middleware with invalid retry wait", middleware: MiddlewareConfig{Type: "retry", MaxRetries: 3, RetryWait: "soon"}, expectedError: "retry_wait 'soon' not valid: time: invalid duration \"soon\""},
+		{name: "retry middleware with invalid max retry wait", middleware: MiddlewareConfig{Type: "retry", MaxRetries: 3, MaxRetryWait: "later"}, expectedError: "max_retry_wait 'later' not valid: time: invalid duration \"later\""},
+		{name: "rate limit middleware", middleware: MiddlewareConfig{Type: "rate_limit", RequestsPerSecond: 0.5, Burst: 2}},
+		{name: "rate limit middleware without requests per second", middleware: MiddlewareConfig{Type: "rate_limit"}, expectedError: "requests_per_second must be greater than zero for the rate_limit middleware"},
+		{name: "rate limit middleware with negative burst", middleware: MiddlewareConfig{Type: "rate_limit", RequestsPerSecond: 1, Burst: -1}, expectedError: "burst must not be negative for the rate_limit middleware"},
+		{name: "headers middleware", middleware: MiddlewareConfig{Type: "headers", Headers: map[string]string{"X-Team": "platform"}}},
+		{name: "headers middleware without headers", middleware: MiddlewareConfig{Type: "headers"}, expectedError: "headers must not be empty for the headers middleware"},
+		{name: "logging middleware", middleware: MiddlewareConfig{Type: "logging"}},
+		{name: "csrf middleware", middleware: MiddlewareConfig{Type: "csrf", TokenPath: "/api/csrf", TokenHeader: "X-XSRF-Token"}},
+		{name: "csrf middleware without token path", middleware: MiddlewareConfig{Type: "csrf"}, expectedError: "token_path must not be empty for the csrf middleware"},
+		{name: "csrf middleware with invalid token path", middleware: MiddlewareConfig{Type: "csrf", TokenPath: "http://[::1"}, expectedError: "token_path 'http://[::1' not valid: parse \"http://[::1\": missing ']' in host"},
+	}
+	for _, tc := range testCases {
+		err := tc.middleware.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestMiddlewareConfigGetName(t *testing.T) {
+	assert.Equal(t, "retry", MiddlewareConfig{Type: "retry"}.GetName())
+	assert.Equal(t, "retry_reads", MiddlewareConfig{Name: "retry_reads", Type: "retry"}.GetName())
+}
+
+func TestGetSpecVersionCheckConfiguration(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Nil(t, serviceConfiguration.GetSpecVersionCheckConfiguration())
+	expectedConfig := &SpecVersionCheckConfig{Path: "/version", VersionProperty: "min_spec_version"}
+	serviceConfiguration = &ServiceConfigV1{SpecVersionCheck: expectedConfig}
+	assert.Equal(t, expectedConfig, serviceConfiguration.GetSpecVersionCheckConfiguration())
 }
 
 func TestGetTelemetryConfiguration(t *testing.T) {
@@ -139,7 +617,7 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 			},
 			inputPluginName: "pluginName",
 			expectedType:    &TelemetryProviderGraphite{},
-			expectedLogging: []string{"[DEBUG] graphite telemetry provider enabled"},
+			expectedLogging: []string{"[DEBUG] [config] graphite telemetry provider enabled"},
 		},
 		{
 			name: "service is configured correctly with a httpendpoint provider",
@@ -152,7 +630,7 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 			},
 			inputPluginName: "pluginName",
 			expectedType:    &TelemetryProviderHTTPEndpoint{},
-			expectedLogging: []string{"[DEBUG] http endpoint telemetry provider enabled"},
+			expectedLogging: []string{"[DEBUG] [config] http endpoint telemetry provider enabled"},
 		},
 		{
 			name: "service is configured correctly with graphite and httpendpoint providers",
@@ -169,7 +647,7 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 			},
 			inputPluginName: "pluginName",
 			expectedType:    nil,
-			expectedLogging: []string{"[WARN] ignoring telemetry due multiple telemetry providers configured (graphite and http_endpoint): select only one"},
+			expectedLogging: []string{"[WARN] [config] ignoring telemetry due multiple telemetry providers configured (graphite and http_endpoint): select only one"},
 		},
 		{
 			name: "service skips graphite telemetry due to the validation not passing",
@@ -183,7 +661,7 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 			},
 			inputPluginName: "pluginName",
 			expectedType:    nil,
-			expectedLogging: []string{"[WARN] ignoring graphite telemetry due to the following validation error: graphite telemetry configuration is missing a value for the 'host property'"},
+			expectedLogging: []string{"[WARN] [config] ignoring graphite telemetry due to the following validation error: graphite telemetry configuration is missing a value for the 'host property'"},
 		},
 		{
 			name: "service skips httpendpoint telemetry due to the validation not passing",
@@ -196,7 +674,7 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 			},
 			inputPluginName: "pluginName",
 			expectedType:    nil,
-			expectedLogging: []string{"[WARN] ignoring http endpoint telemetry due to the following validation error: http endpoint telemetry configuration is missing a value for the 'url property'"},
+			expectedLogging: []string{"[WARN] [config] ignoring http endpoint telemetry due to the following validation error: http endpoint telemetry configuration is missing a value for the 'url property'"},
 		},
 		{
 			name: "TelemetryConfig is nil",
@@ -205,7 +683,7 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 			},
 			inputPluginName: "pluginName",
 			expectedType:    nil,
-			expectedLogging: []string{"[DEBUG] telemetry not configured"},
+			expectedLogging: []string{"[DEBUG] [config] telemetry not configured"},
 		},
 	}
 	for _, tc := range testCases {
@@ -218,3 +696,148 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 		}
 	}
 }
+
+func TestGetRequestCompressionConfiguration(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Nil(t, serviceConfiguration.GetRequestCompressionConfiguration())
+	expectedConfig := &RequestCompressionConfig{GzipThreshold: 1024}
+	serviceConfiguration = &ServiceConfigV1{RequestCompression: expectedConfig}
+	assert.Equal(t, expectedConfig, serviceConfiguration.GetRequestCompressionConfiguration())
+}
+
+func TestGetMaxResponseBodySize(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Equal(t, int64(0), serviceConfiguration.GetMaxResponseBodySize())
+	serviceConfiguration = &ServiceConfigV1{MaxResponseBodySize: 1024}
+	assert.Equal(t, int64(1024), serviceConfiguration.GetMaxResponseBodySize())
+}
+
+func TestGetSpecFormat(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Equal(t, SpecAnalyserVersion(""), serviceConfiguration.GetSpecFormat())
+	serviceConfiguration = &ServiceConfigV1{SpecFormat: "v2"}
+	assert.Equal(t, specAnalyserV2, serviceConfiguration.GetSpecFormat())
+}
+
+func TestGetBackendConfiguration(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Nil(t, serviceConfiguration.GetBackendConfiguration())
+	expectedConfig := &BackendConfig{Type: "grpc", Address: "localhost:9090", Insecure: true}
+	serviceConfiguration = &ServiceConfigV1{Backend: expectedConfig}
+	assert.Equal(t, expectedConfig, serviceConfiguration.GetBackendConfiguration())
+}
+
+func TestGetAdditionalSwaggerURLs(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.Empty(t, serviceConfiguration.GetAdditionalSwaggerURLs())
+	serviceConfiguration = &ServiceConfigV1{AdditionalSwaggerURLs: []string{"http://sevice-api.com/swagger.yaml"}}
+	assert.Equal(t, []string{"http://sevice-api.com/swagger.yaml"}, serviceConfiguration.GetAdditionalSwaggerURLs())
+}
+
+func TestIsVerifyWritesEnabled(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.False(t, serviceConfiguration.IsVerifyWritesEnabled())
+	serviceConfiguration = &ServiceConfigV1{VerifyWrites: true}
+	assert.True(t, serviceConfiguration.IsVerifyWritesEnabled())
+}
+
+func TestIsQuirksModeEnabled(t *testing.T) {
+	serviceConfiguration := &ServiceConfigV1{}
+	assert.False(t, serviceConfiguration.IsQuirksModeEnabled())
+	serviceConfiguration = &ServiceConfigV1{QuirksMode: true}
+	assert.True(t, serviceConfiguration.IsQuirksModeEnabled())
+}
+
+func TestServiceConfigV1ValidateFailover(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failover      *FailoverConfig
+		expectedError string
+	}{
+		{name: "valid failover configuration", failover: &FailoverConfig{Hosts: []string{"api-passive.example.com"}, UnhealthyCooldown: "1m"}},
+		{name: "failover configuration with empty host", failover: &FailoverConfig{Hosts: []string{""}}, expectedError: "service failover configuration not valid: hosts must not contain empty values"},
+		{name: "failover configuration with invalid cooldown", failover: &FailoverConfig{UnhealthyCooldown: "soon"}, expectedError: "service failover configuration not valid: unhealthy_cooldown 'soon' not valid: time: invalid duration \"soon\""},
+	}
+	for _, tc := range testCases {
+		serviceConfig := &ServiceConfigV1{SwaggerURL: "http://host.com/swagger.json", Failover: tc.failover}
+		err := serviceConfig.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+			assert.Equal(t, tc.failover, serviceConfig.GetFailoverConfiguration(), tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestServiceConfigV1ValidateServiceDiscovery(t *testing.T) {
+	testCases := []struct {
+		name             string
+		serviceDiscovery *ServiceDiscoveryConfig
+		expectedError    string
+	}{
+		{name: "valid service discovery configuration", serviceDiscovery: &ServiceDiscoveryConfig{SRV: "_api._tcp.example.internal", TTL: "5m"}},
+		{name: "service discovery configuration without srv", serviceDiscovery: &ServiceDiscoveryConfig{}, expectedError: "service service_discovery configuration not valid: srv must not be empty"},
+		{name: "service discovery configuration with invalid ttl", serviceDiscovery: &ServiceDiscoveryConfig{SRV: "_api._tcp.example.internal", TTL: "forever"}, expectedError: "service service_discovery configuration not valid: ttl 'forever' not valid: time: invalid duration \"forever\""},
+	}
+	for _, tc := range testCases {
+		serviceConfig := &ServiceConfigV1{SwaggerURL: "http://host.com/swagger.json", ServiceDiscovery: tc.serviceDiscovery}
+		err := serviceConfig.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+			assert.Equal(t, tc.serviceDiscovery, serviceConfig.GetServiceDiscoveryConfiguration(), tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestServiceConfigV1ValidateDialer(t *testing.T) {
+	testCases := []struct {
+		name          string
+		dialer        *DialerConfig
+		expectedError string
+	}{
+		{name: "valid dialer configuration", dialer: &DialerConfig{PreferIPv6: true, FallbackDelay: "100ms", Resolver: "10.0.0.2:53"}},
+		{name: "dialer configuration with IPv6 resolver", dialer: &DialerConfig{DisableIPv4: true, Resolver: "[fd00::53]:53"}},
+		{name: "dialer configuration with invalid fallback_delay", dialer: &DialerConfig{PreferIPv6: true, FallbackDelay: "soon"}, expectedError: "service dialer configuration not valid: fallback_delay 'soon' not valid: time: invalid duration \"soon\""},
+		{name: "dialer configuration with resolver missing the port", dialer: &DialerConfig{Resolver: "10.0.0.2"}, expectedError: "service dialer configuration not valid: resolver '10.0.0.2' not valid, the address must be in host:port form: address 10.0.0.2: missing port in address"},
+	}
+	for _, tc := range testCases {
+		serviceConfig := &ServiceConfigV1{SwaggerURL: "http://host.com/swagger.json", Dialer: tc.dialer}
+		err := serviceConfig.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+			assert.Equal(t, tc.dialer, serviceConfig.GetDialerConfiguration(), tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestServiceConfigV1ValidateVault(t *testing.T) {
+	testCases := []struct {
+		name          string
+		vault         *VaultConfig
+		expectedError string
+	}{
+		{name: "valid vault configuration with the token auth method", vault: &VaultConfig{Address: "https://vault.example.com:8200", Path: "secret/data/terraform/cdn"}},
+		{name: "valid vault configuration with the kubernetes auth method", vault: &VaultConfig{Address: "https://vault.example.com:8200", Path: "secret/data/terraform/cdn", AuthMethod: "kubernetes", Role: "terraform"}},
+		{name: "valid vault configuration with the jwt auth method", vault: &VaultConfig{Address: "https://vault.example.com:8200", Path: "secret/data/terraform/cdn", AuthMethod: "jwt", Role: "terraform", JWTFile: "/tmp/id_token"}},
+		{name: "vault configuration without address", vault: &VaultConfig{Path: "secret/data/terraform/cdn"}, expectedError: "service vault configuration not valid: address must not be empty"},
+		{name: "vault configuration without path", vault: &VaultConfig{Address: "https://vault.example.com:8200"}, expectedError: "service vault configuration not valid: path must not be empty"},
+		{name: "vault configuration with the kubernetes auth method without role", vault: &VaultConfig{Address: "https://vault.example.com:8200", Path: "secret/data/terraform/cdn", AuthMethod: "kubernetes"}, expectedError: "service vault configuration not valid: role must not be empty for the kubernetes auth method"},
+		{name: "vault configuration with the jwt auth method without jwt_file", vault: &VaultConfig{Address: "https://vault.example.com:8200", Path: "secret/data/terraform/cdn", AuthMethod: "jwt", Role: "terraform"}, expectedError: "service vault configuration not valid: role and jwt_file must not be empty for the jwt auth method"},
+		{name: "vault configuration with unsupported auth method", vault: &VaultConfig{Address: "https://vault.example.com:8200", Path: "secret/data/terraform/cdn", AuthMethod: "ldap"}, expectedError: "service vault configuration not valid: auth_method 'ldap' not supported, supported auth methods are [token, kubernetes, jwt]"},
+	}
+	for _, tc := range testCases {
+		serviceConfig := &ServiceConfigV1{SwaggerURL: "http://host.com/swagger.json", Vault: tc.vault}
+		err := serviceConfig.Validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+			assert.Equal(t, tc.vault, serviceConfig.GetVaultConfiguration(), tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
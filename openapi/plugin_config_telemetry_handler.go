@@ -3,7 +3,6 @@ package openapi
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"log"
 	"time"
 )
 
@@ -34,7 +33,7 @@ type MetricSubmitter func() error
 
 func (t telemetryHandlerTimeoutSupport) SubmitPluginExecutionMetrics() {
 	if t.telemetryProvider == nil {
-		log.Println("[INFO] Telemetry provider not configured")
+		telemetryLog.Info("Telemetry provider not configured")
 		return
 	}
 	telemetryConfig := t.telemetryProvider.GetTelemetryProviderConfiguration(t.data)
@@ -45,7 +44,7 @@ func (t telemetryHandlerTimeoutSupport) SubmitPluginExecutionMetrics() {
 
 func (t telemetryHandlerTimeoutSupport) SubmitResourceExecutionMetrics(resourceName string, tfOperation TelemetryResourceOperation) {
 	if t.telemetryProvider == nil {
-		log.Println("[INFO] Telemetry provider not configured")
+		telemetryLog.Info("Telemetry provider not configured")
 		return
 	}
 	telemetryConfig := t.telemetryProvider.GetTelemetryProviderConfiguration(t.data)
@@ -63,10 +62,10 @@ func (t telemetryHandlerTimeoutSupport) submitMetric(metricName string, metricSu
 	select {
 	case err := <-doneChan:
 		if err != nil {
-			log.Printf("metric '%s' submission failed: %s", metricName, err)
+			telemetryLog.Warn("metric '%s' submission failed: %s", metricName, err)
 		}
 	case <-time.After(time.Duration(t.timeout) * time.Second):
-		log.Printf("metric '%s' submission did not finish within the expected time %ds", metricName, t.timeout)
+		telemetryLog.Warn("metric '%s' submission did not finish within the expected time %ds", metricName, t.timeout)
 	}
 }
 
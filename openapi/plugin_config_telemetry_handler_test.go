@@ -32,7 +32,7 @@ func TestSubmitPluginExecutionMetrics_FailsNilTelemetryProvider(t *testing.T) {
 		telemetryProvider: nil,
 	}
 	ths.SubmitPluginExecutionMetrics()
-	assert.Contains(t, buf.String(), "[INFO] Telemetry provider not configured")
+	assert.Contains(t, buf.String(), "[INFO] [telemetry] Telemetry provider not configured")
 }
 
 func TestSubmitResourceExecutionMetrics(t *testing.T) {
@@ -62,7 +62,7 @@ func TestSubmitResourceExecutionMetrics_FailsNilTelemetryProvider(t *testing.T)
 		telemetryProvider: nil,
 	}
 	ths.SubmitResourceExecutionMetrics("resourceName", TelemetryResourceOperationCreate)
-	assert.Contains(t, buf.String(), "[INFO] Telemetry provider not configured")
+	assert.Contains(t, buf.String(), "[INFO] [telemetry] Telemetry provider not configured")
 }
 
 func TestSubmitMetric(t *testing.T) {
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/DataDog/datadog-go/statsd"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"log"
 	"strings"
 )
 
@@ -40,11 +39,11 @@ func (g TelemetryProviderGraphite) IncOpenAPIPluginVersionTotalRunsCounter(openA
 	tags := []string{"openapi_plugin_version:" + version}
 	metricName := "terraform.openapi_plugin_version.total_runs"
 
-	log.Printf("[INFO] graphite metric to be submitted: %s", metricName)
+	telemetryLog.Info("graphite metric to be submitted: %s", metricName)
 	if err := g.submitMetric(metricName, tags); err != nil {
 		return err
 	}
-	log.Printf("[INFO] graphite metric successfully submitted: %s (tags: %s)", metricName, tags)
+	telemetryLog.Info("graphite metric successfully submitted: %s (tags: %s)", metricName, tags)
 	return nil
 }
 
@@ -53,11 +52,11 @@ func (g TelemetryProviderGraphite) IncOpenAPIPluginVersionTotalRunsCounter(openA
 func (g TelemetryProviderGraphite) IncServiceProviderResourceTotalRunsCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
 	tags := []string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation)}
 	metricName := "terraform.provider"
-	log.Printf("[INFO] graphite metric to be submitted: %s", metricName)
+	telemetryLog.Info("graphite metric to be submitted: %s", metricName)
 	if err := g.submitMetric("terraform.provider", tags); err != nil {
 		return err
 	}
-	log.Printf("[INFO] graphite metric successfully submitted: %s (tags: %s)", metricName, tags)
+	telemetryLog.Info("graphite metric successfully submitted: %s (tags: %s)", metricName, tags)
 	return nil
 }
 
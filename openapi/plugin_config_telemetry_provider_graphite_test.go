@@ -50,8 +50,8 @@ func TestTelemetryProviderGraphite_Validate(t *testing.T) {
 
 func TestTelemetryProviderGraphite_IncOpenAPIPluginVersionTotalRunsCounter(t *testing.T) {
 	openAPIPluginVersion := "0.25.0"
-	expectedLogMetricToSubmit := "[INFO] graphite metric to be submitted: terraform.openapi_plugin_version.total_runs"
-	expectedLogMetricSuccess := "[INFO] graphite metric successfully submitted: terraform.openapi_plugin_version.total_runs (tags: [openapi_plugin_version:0_25_0])"
+	expectedLogMetricToSubmit := "[INFO] [telemetry] graphite metric to be submitted: terraform.openapi_plugin_version.total_runs"
+	expectedLogMetricSuccess := "[INFO] [telemetry] graphite metric successfully submitted: terraform.openapi_plugin_version.total_runs (tags: [openapi_plugin_version:0_25_0])"
 	expectedMetric := "myPrefixName.terraform.openapi_plugin_version.total_runs:1|c|#openapi_plugin_version:0_25_0"
 
 	var logging bytes.Buffer
@@ -87,8 +87,8 @@ func TestTelemetryProviderGraphite_IncOpenAPIPluginVersionTotalRunsCounter_BadHo
 
 func TestTelemetryProviderGraphite_IncServiceProviderResourceTotalRunsCounter(t *testing.T) {
 	providerName := "myProviderName"
-	expectedLogMetricToSubmit := "[INFO] graphite metric to be submitted: terraform.provider"
-	expectedLogMetricSuccess := "[INFO] graphite metric successfully submitted: terraform.provider (tags: [provider_name:myProviderName resource_name:cdn_v1 terraform_operation:create])"
+	expectedLogMetricToSubmit := "[INFO] [telemetry] graphite metric to be submitted: terraform.provider"
+	expectedLogMetricSuccess := "[INFO] [telemetry] graphite metric successfully submitted: terraform.provider (tags: [provider_name:myProviderName resource_name:cdn_v1 terraform_operation:create])"
 	expectedMetric := "myPrefixName.terraform.provider:1|c|#provider_name:myProviderName,resource_name:cdn_v1,terraform_operation:create"
 
 	var logging bytes.Buffer
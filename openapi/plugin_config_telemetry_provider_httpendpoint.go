@@ -7,7 +7,6 @@ import (
 	"github.com/asaskevich/govalidator"
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"log"
 	"net/http"
 	"runtime"
 	"strings"
@@ -113,7 +112,7 @@ func (g TelemetryProviderHTTPEndpoint) submitMetric(metric telemetryMetric, tele
 		}
 	}
 
-	log.Printf("[INFO] http endpoint metric to be submitted: %s", metric.MetricName)
+	telemetryLog.Info("http endpoint metric to be submitted: %s", metric.MetricName)
 	req, err := g.createNewRequest(metric, &telemetryConfiguration)
 	if err != nil {
 		return err
@@ -126,7 +125,7 @@ func (g TelemetryProviderHTTPEndpoint) submitMetric(metric telemetryMetric, tele
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
 		return fmt.Errorf("response returned from POST '%s' returned a non expected status code %d", g.URL, resp.StatusCode)
 	}
-	log.Printf("[INFO] http endpoint metric successfully submitted: %s", metric)
+	telemetryLog.Info("http endpoint metric successfully submitted: %s", metric)
 	return nil
 }
 
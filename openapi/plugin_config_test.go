@@ -1,10 +1,13 @@
 package openapi
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"github.com/smartystreets/assertions/should"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"log"
 	"net"
 	"os"
@@ -299,6 +302,116 @@ services:
 
 }
 
+func TestGetServiceConfigurationSwaggerSpecFromEnv(t *testing.T) {
+	spec := "swagger: \"2.0\"\nhost: localhost:8443"
+	specEnvVar := fmt.Sprintf(otfVarSwaggerSpecB64, providerName)
+	testCases := []struct {
+		name          string
+		envVar        string
+		envVarValue   string
+		expectedError string
+	}{
+		{
+			name:        "base64 encoded spec provided using lower case provider name",
+			envVar:      specEnvVar,
+			envVarValue: base64.StdEncoding.EncodeToString([]byte(spec)),
+		},
+		{
+			name:        "base64 encoded spec provided using upper case provider name",
+			envVar:      strings.ToUpper(specEnvVar),
+			envVarValue: base64.StdEncoding.EncodeToString([]byte(spec)) + "\n",
+		},
+		{
+			name:          "spec not base64 encoded",
+			envVar:        specEnvVar,
+			envVarValue:   spec,
+			expectedError: "failed to decode the OpenAPI document provided in OTF_VAR_test_SWAGGER_SPEC_B64 env variable, the value must be base64 encoded: illegal base64 data at input byte 7",
+		},
+		{
+			name:          "empty spec",
+			envVar:        specEnvVar,
+			envVarValue:   base64.StdEncoding.EncodeToString([]byte(" ")),
+			expectedError: "the OpenAPI document provided in OTF_VAR_test_SWAGGER_SPEC_B64 env variable is empty",
+		},
+	}
+	for _, tc := range testCases {
+		os.Setenv(tc.envVar, tc.envVarValue)
+		// the spec provided via env variable takes preference over the swagger URL and the plugin configuration file
+		os.Setenv(otfVarNameLc, otfVarSwaggerURLValue)
+		pluginConfiguration, err := NewPluginConfiguration(providerName)
+		require.NoError(t, err, tc.name)
+		pluginConfiguration.Configuration = strings.NewReader(fmt.Sprintf("version: '1'\nservices:\n  %s:\n    swagger-url: http://some-other-api/swagger.yaml", providerName))
+		serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+		os.Unsetenv(tc.envVar)
+		os.Unsetenv(otfVarNameLc)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.True(t, strings.HasPrefix(serviceConfiguration.GetSwaggerURL(), inMemoryDocumentScheme), tc.name)
+		document, err := loadDocument(serviceConfiguration.GetSwaggerURL())
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, spec, string(document), tc.name)
+	}
+}
+
+func TestGetServiceConfigurationProfiles(t *testing.T) {
+	pluginConfig := fmt.Sprintf(`version: '1'
+services:
+    %s:
+        swagger-url: http://host.com/swagger.yaml
+        default_profile: dev
+        profiles:
+            dev:
+                host: dev.host.com
+            prod:
+                swagger-url: http://prod.host.com/swagger.yaml
+                auth_env_vars:
+                    apikey_auth: PROD_API_KEY`, providerName)
+	testCases := []struct {
+		name               string
+		profileEnvVar      string
+		expectedProfile    string
+		expectedSwaggerURL string
+		expectedError      string
+	}{
+		{
+			name:               "default profile selected",
+			expectedProfile:    "dev",
+			expectedSwaggerURL: "http://host.com/swagger.yaml",
+		},
+		{
+			name:               "profile selected via environment variable",
+			profileEnvVar:      "prod",
+			expectedProfile:    "prod",
+			expectedSwaggerURL: "http://prod.host.com/swagger.yaml",
+		},
+		{
+			name:          "profile selected via environment variable not found",
+			profileEnvVar: "staging",
+			expectedError: "error occurred when selecting the service profile from plugin configuration file terraform-provider-openapi.yaml - error = profile 'staging' not found in the service profiles configuration",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(fmt.Sprintf(otfVarProfile, providerName), tc.profileEnvVar)
+			pluginConfiguration := PluginConfiguration{
+				ProviderName:  providerName,
+				Configuration: strings.NewReader(pluginConfig),
+			}
+			serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedProfile, serviceConfiguration.GetSelectedProfile())
+			assert.Equal(t, tc.expectedSwaggerURL, serviceConfiguration.GetSwaggerURL())
+		})
+	}
+}
+
 func udpServer(metricChannel chan string) (net.PacketConn, string, string) {
 	pc, err := net.ListenPacket("udp", "127.0.0.1:")
 	if err != nil {
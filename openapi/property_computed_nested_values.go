@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// unknownPlanValue is the value the Terraform SDK uses to represent values that are not known at plan time (known after apply)
+const unknownPlanValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+// planComputedNestedValues is part of the CustomizeDiff of the resources. The Terraform SDK plans the readOnly properties
+// nested in arrays of objects by position, that is the values are taken from the item that had the same index in the
+// state, so adding, removing or reordering items plans the computed values of the wrong items. Instead, the items are
+// matched with the ones in the state based on their user configurable properties (refer to hashComplexObject): matched
+// items keep their computed values and only the computed values of the items not matched are planned as unknown.
+// The SDK only allows planning the values of top level computed properties, so only the properties that are readOnly or
+// optional computed (x-terraform-computed) are processed.
+func (r resourceFactory) planComputedNestedValues(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
+	schemaDefinition, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	for _, property := range schemaDefinition.Properties {
+		path := property.GetTerraformCompliantPropertyName()
+		if !property.isComputed() || !property.hasReadOnlyNestedProperties() || !diff.HasChange(path) || !diff.NewValueKnown(path) {
+			continue
+		}
+		oldValue, newValue := diff.GetChange(path)
+		plannedValue, ok := property.planComputedNestedValue(path, oldValue, newValue, diff.NewValueKnown)
+		if !ok || reflect.DeepEqual(plannedValue, newValue) {
+			continue
+		}
+		if err := diff.SetNew(path, plannedValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasReadOnlyNestedProperties returns true if the property is an object or an array of objects containing readOnly
+// properties at any level
+func (s *SpecSchemaDefinitionProperty) hasReadOnlyNestedProperties() bool {
+	if (!s.isObjectProperty() && !s.isArrayOfObjectsProperty()) || s.SpecSchemaDefinition == nil {
+		return false
+	}
+	for _, property := range s.SpecSchemaDefinition.Properties {
+		if property.isReadOnly() || property.hasReadOnlyNestedProperties() {
+			return true
+		}
+	}
+	return false
+}
+
+// planComputedNestedValue returns the planned value of the object (or array of objects) property where the readOnly
+// nested properties of each item are taken from the matching item in the state. The values are expected to be in the
+// terraform state format, so objects are lists of one item (refer to shouldUseLegacyTerraformSDKBlockApproachForComplexObjects).
+// False is returned if the value can not be planned, in which case the value planned by the SDK should be used.
+func (s *SpecSchemaDefinitionProperty) planComputedNestedValue(path string, oldValue, newValue interface{}, isKnown func(key string) bool) (interface{}, bool) {
+	newItems, ok := newValue.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	oldItems, _ := oldValue.([]interface{})
+	matchedOldItems := make([]bool, len(oldItems))
+	plannedItems := make([]interface{}, 0, len(newItems))
+	for idx, newItem := range newItems {
+		newObject, ok := newItem.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		var oldObject map[string]interface{}
+		if s.isObjectProperty() {
+			if len(oldItems) > 0 {
+				oldObject, _ = oldItems[0].(map[string]interface{})
+			}
+		} else {
+			newItemHash := s.hashComplexObject(newObject)
+			for oldIdx, oldItem := range oldItems {
+				if !matchedOldItems[oldIdx] && s.hashComplexObject(oldItem) == newItemHash {
+					matchedOldItems[oldIdx] = true
+					oldObject, _ = oldItem.(map[string]interface{})
+					break
+				}
+			}
+		}
+		plannedObject, ok := s.SpecSchemaDefinition.planComputedValues(fmt.Sprintf("%s.%d", path, idx), oldObject, newObject, isKnown)
+		if !ok {
+			return nil, false
+		}
+		plannedItems = append(plannedItems, plannedObject)
+	}
+	return plannedItems, true
+}
+
+// planComputedValues returns the planned object where the readOnly properties are taken from the old object, or planned
+// as unknown if there is no old object. False is returned if the object contains configured values not known yet, or
+// readOnly properties that are not strings and have no old value, since the SDK can not represent those unknown values
+// when planning the whole object.
+func (s *SpecSchemaDefinition) planComputedValues(path string, oldObject, newObject map[string]interface{}, isKnown func(key string) bool) (map[string]interface{}, bool) {
+	plannedObject := map[string]interface{}{}
+	for _, property := range s.Properties {
+		propertyName := property.GetTerraformCompliantPropertyName()
+		propertyPath := fmt.Sprintf("%s.%s", path, propertyName)
+		switch {
+		case property.isReadOnly():
+			if oldObject != nil {
+				plannedObject[propertyName] = oldObject[propertyName]
+				continue
+			}
+			if property.Type != TypeString {
+				return nil, false
+			}
+			plannedObject[propertyName] = unknownPlanValue
+		case !isKnown(propertyPath):
+			return nil, false
+		case property.hasReadOnlyNestedProperties():
+			var oldValue interface{}
+			if oldObject != nil {
+				oldValue = oldObject[propertyName]
+			}
+			plannedValue, ok := property.planComputedNestedValue(propertyPath, oldValue, newObject[propertyName], isKnown)
+			if !ok {
+				return nil, false
+			}
+			plannedObject[propertyName] = plannedValue
+		default:
+			plannedObject[propertyName] = newObject[propertyName]
+		}
+	}
+	return plannedObject, true
+}
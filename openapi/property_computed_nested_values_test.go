@@ -0,0 +1,92 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanComputedNestedValues(t *testing.T) {
+	rules := &SpecSchemaDefinitionProperty{Name: "rules", Type: TypeList, ArrayItemsType: TypeObject, Computed: true, SpecSchemaDefinition: &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString},
+			&SpecSchemaDefinitionProperty{Name: "status", Type: TypeString, ReadOnly: true},
+		},
+	}}
+	specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{rules}}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{})
+	resource, err := newResourceFactory(specResource).createTerraformResource()
+	require.NoError(t, err)
+	state := &terraform.InstanceState{ID: "id", Attributes: map[string]string{
+		"id":             "id",
+		"rules.#":        "2",
+		"rules.0.name":   "rule1",
+		"rules.0.status": "status1",
+		"rules.1.name":   "rule2",
+		"rules.1.status": "status2",
+	}}
+
+	testCases := []struct {
+		name               string
+		config             map[string]interface{}
+		expectedAttributes map[string]*terraform.ResourceAttrDiff
+	}{
+		{
+			name:               "items not changed",
+			config:             map[string]interface{}{"rules": []interface{}{map[string]interface{}{"name": "rule1"}, map[string]interface{}{"name": "rule2"}}},
+			expectedAttributes: map[string]*terraform.ResourceAttrDiff{},
+		},
+		{
+			name:   "first item removed keeps the computed values of the remaining item",
+			config: map[string]interface{}{"rules": []interface{}{map[string]interface{}{"name": "rule2"}}},
+			expectedAttributes: map[string]*terraform.ResourceAttrDiff{
+				"rules.#":        {Old: "2", New: "1"},
+				"rules.0.name":   {Old: "rule1", New: "rule2"},
+				"rules.0.status": {Old: "status1", New: "status2"},
+				"rules.1.name":   {Old: "rule2", New: "", NewRemoved: true},
+			},
+		},
+		{
+			// the SDK converts the unknown value into an unknown (known after apply) value in the planned state
+			name:   "new item plans its computed values as unknown",
+			config: map[string]interface{}{"rules": []interface{}{map[string]interface{}{"name": "rule3"}, map[string]interface{}{"name": "rule2"}}},
+			expectedAttributes: map[string]*terraform.ResourceAttrDiff{
+				"rules.0.name":   {Old: "rule1", New: "rule3"},
+				"rules.0.status": {Old: "status1", New: unknownPlanValue},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(tc.config), nil)
+		require.NoError(t, err, tc.name)
+		actualAttributes := map[string]*terraform.ResourceAttrDiff{}
+		if diff != nil {
+			actualAttributes = diff.Attributes
+		}
+		assert.Equal(t, tc.expectedAttributes, actualAttributes, tc.name)
+	}
+}
+
+func TestPlanComputedValuesUnknownValues(t *testing.T) {
+	schemaDefinition := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString},
+			&SpecSchemaDefinitionProperty{Name: "status", Type: TypeString, ReadOnly: true},
+			&SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt, ReadOnly: true},
+		},
+	}
+	isKnown := func(key string) bool {
+		return key != "rules.0.name"
+	}
+	_, ok := schemaDefinition.planComputedValues("rules.0", nil, map[string]interface{}{"name": ""}, isKnown)
+	assert.False(t, ok, "configured values not known yet can not be planned")
+
+	_, ok = schemaDefinition.planComputedValues("rules.1", nil, map[string]interface{}{"name": "rule"}, isKnown)
+	assert.False(t, ok, "readOnly values other than strings can not be planned as unknown")
+
+	plannedObject, ok := schemaDefinition.planComputedValues("rules.1", map[string]interface{}{"name": "rule", "status": "status", "port": 80}, map[string]interface{}{"name": "rule"}, isKnown)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"name": "rule", "status": "status", "port": 80}, plannedObject)
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Network formats supported by properties (format attribute or x-terraform-format extension). Values of properties with
+// these formats are compared semantically, so different textual forms of the same address (e,g: 2001:DB8::1 and
+// 2001:db8::1) do not produce diffs
+const (
+	formatIP   = "ip"
+	formatIPv4 = "ipv4"
+	formatIPv6 = "ipv6"
+	formatCIDR = "cidr"
+)
+
+// isNetworkFormatProperty returns true if the property is a string property (or a list of strings) with one of the
+// supported network formats
+func (s *SpecSchemaDefinitionProperty) isNetworkFormatProperty() bool {
+	if s.Type != TypeString && !(s.Type == TypeList && s.ArrayItemsType == TypeString) {
+		return false
+	}
+	switch s.Format {
+	case formatIP, formatIPv4, formatIPv6, formatCIDR:
+		return true
+	}
+	return false
+}
+
+// equalNetworkValues returns true if both values represent the same IP address or CIDR block. Values that can not be
+// parsed as per the property format are compared textually
+func (s *SpecSchemaDefinitionProperty) equalNetworkValues(value1, value2 string) bool {
+	if value1 == value2 {
+		return true
+	}
+	normalizedValue1, err := normalizeNetworkValue(s.Format, value1)
+	if err != nil {
+		return false
+	}
+	normalizedValue2, err := normalizeNetworkValue(s.Format, value2)
+	if err != nil {
+		return false
+	}
+	return normalizedValue1 == normalizedValue2
+}
+
+// suppressEquivalentNetworkValues is the DiffSuppressFunc of the network format properties
+func (s *SpecSchemaDefinitionProperty) suppressEquivalentNetworkValues(k, old, new string, d *schema.ResourceData) bool {
+	return s.equalNetworkValues(old, new)
+}
+
+// normalizeNetworkValue returns the canonical form of the given IP address or CIDR block (e,g: IPv6 addresses in lower case
+// and with the zeros compressed). The host bits of CIDR blocks are kept as they are, so 10.0.0.1/24 and 10.0.0.0/24 are
+// not considered equal
+func normalizeNetworkValue(format, value string) (string, error) {
+	if format == formatCIDR {
+		ip, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			return "", err
+		}
+		prefixLength, _ := ipNet.Mask.Size()
+		return fmt.Sprintf("%s/%d", ip, prefixLength), nil
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return "", fmt.Errorf("'%s' is not a valid IP address", value)
+	}
+	return ip.String(), nil
+}
+
+// keepEquivalentNetworkValues returns the remote value of the network property replacing the values (or list items) that
+// are equivalent to the input ones with the input values, so the textual form configured by the user is kept in the state
+func keepEquivalentNetworkValues(property SpecSchemaDefinitionProperty, inputPropertyValue, remoteValue interface{}) interface{} {
+	switch remote := remoteValue.(type) {
+	case string:
+		if input, ok := inputPropertyValue.(string); ok && property.equalNetworkValues(input, remote) {
+			return input
+		}
+	case []interface{}:
+		input, ok := inputPropertyValue.([]interface{})
+		if !ok || len(input) != len(remote) {
+			return remoteValue
+		}
+		newPropertyValue := make([]interface{}, len(remote))
+		for idx, remoteItemValue := range remote {
+			newPropertyValue[idx] = keepEquivalentNetworkValues(property, input[idx], remoteItemValue)
+		}
+		return newPropertyValue
+	}
+	return remoteValue
+}
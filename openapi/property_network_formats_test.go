@@ -0,0 +1,100 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqualNetworkValues(t *testing.T) {
+	testCases := []struct {
+		format        string
+		value1        string
+		value2        string
+		expectedEqual bool
+	}{
+		{format: formatIPv4, value1: "10.0.0.1", value2: "10.0.0.1", expectedEqual: true},
+		{format: formatIPv4, value1: "10.0.0.1", value2: "10.0.0.2", expectedEqual: false},
+		{format: formatIPv6, value1: "2001:DB8::1", value2: "2001:db8::1", expectedEqual: true},
+		{format: formatIPv6, value1: "2001:db8:0:0:0:0:0:1", value2: "2001:db8::1", expectedEqual: true},
+		{format: formatIP, value1: "::ffff:10.0.0.1", value2: "10.0.0.1", expectedEqual: true},
+		{format: formatCIDR, value1: "10.0.0.0/24", value2: "10.0.0.0/24", expectedEqual: true},
+		{format: formatCIDR, value1: "2001:DB8:0:0::/32", value2: "2001:db8::/32", expectedEqual: true},
+		{format: formatCIDR, value1: "10.0.0.0/24", value2: "10.0.0.0/16", expectedEqual: false},
+		{format: formatCIDR, value1: "10.0.0.1/24", value2: "10.0.0.0/24", expectedEqual: false},
+		{format: formatCIDR, value1: "not a cidr", value2: "10.0.0.0/24", expectedEqual: false},
+		{format: formatIP, value1: "not an ip", value2: "NOT AN IP", expectedEqual: false},
+	}
+	for _, tc := range testCases {
+		s := &SpecSchemaDefinitionProperty{Name: "network", Type: TypeString, Format: tc.format}
+		assert.Equal(t, tc.expectedEqual, s.equalNetworkValues(tc.value1, tc.value2), "%s: %s vs %s", tc.format, tc.value1, tc.value2)
+		assert.Equal(t, tc.expectedEqual, s.equal(tc.value1, tc.value2), "%s: %s vs %s", tc.format, tc.value1, tc.value2)
+	}
+}
+
+func TestIsNetworkFormatProperty(t *testing.T) {
+	testCases := []struct {
+		name     string
+		property *SpecSchemaDefinitionProperty
+		expected bool
+	}{
+		{name: "string property with cidr format", property: &SpecSchemaDefinitionProperty{Type: TypeString, Format: formatCIDR}, expected: true},
+		{name: "string property with ipv6 format", property: &SpecSchemaDefinitionProperty{Type: TypeString, Format: formatIPv6}, expected: true},
+		{name: "list of strings with ipv4 format", property: &SpecSchemaDefinitionProperty{Type: TypeList, ArrayItemsType: TypeString, Format: formatIPv4}, expected: true},
+		{name: "string property with other format", property: &SpecSchemaDefinitionProperty{Type: TypeString, Format: "date-time"}, expected: false},
+		{name: "string property without format", property: &SpecSchemaDefinitionProperty{Type: TypeString}, expected: false},
+		{name: "integer property with cidr format", property: &SpecSchemaDefinitionProperty{Type: TypeInt, Format: formatCIDR}, expected: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, tc.property.isNetworkFormatProperty(), tc.name)
+	}
+}
+
+func TestNetworkFormatPropertyTerraformSchema(t *testing.T) {
+	s := &SpecSchemaDefinitionProperty{Name: "cidr_block", Type: TypeString, Format: formatCIDR}
+	terraformSchema, err := s.terraformSchema()
+	require.NoError(t, err)
+	require.NotNil(t, terraformSchema.DiffSuppressFunc)
+	assert.True(t, terraformSchema.DiffSuppressFunc("cidr_block", "2001:db8::/32", "2001:DB8::/32", nil))
+	assert.False(t, terraformSchema.DiffSuppressFunc("cidr_block", "2001:db8::/32", "2001:db8::/48", nil))
+
+	s = &SpecSchemaDefinitionProperty{Name: "allowed_ips", Type: TypeList, ArrayItemsType: TypeString, Format: formatIP}
+	terraformSchema, err = s.terraformSchema()
+	require.NoError(t, err)
+	assert.Nil(t, terraformSchema.DiffSuppressFunc)
+	elemSchema := terraformSchema.Elem.(*schema.Schema)
+	require.NotNil(t, elemSchema.DiffSuppressFunc)
+	assert.True(t, elemSchema.DiffSuppressFunc("allowed_ips.0", "fe80::1", "FE80:0::1", nil))
+
+	s = &SpecSchemaDefinitionProperty{Name: "label", Type: TypeString}
+	terraformSchema, err = s.terraformSchema()
+	require.NoError(t, err)
+	assert.Nil(t, terraformSchema.DiffSuppressFunc)
+}
+
+func TestKeepEquivalentNetworkValues(t *testing.T) {
+	cidrProperty := SpecSchemaDefinitionProperty{Name: "cidr_block", Type: TypeString, Format: formatCIDR}
+	assert.Equal(t, "2001:DB8::/32", keepEquivalentNetworkValues(cidrProperty, "2001:DB8::/32", "2001:db8::/32"))
+	assert.Equal(t, "2001:db8::/48", keepEquivalentNetworkValues(cidrProperty, "2001:DB8::/32", "2001:db8::/48"))
+	assert.Equal(t, "2001:db8::/32", keepEquivalentNetworkValues(cidrProperty, nil, "2001:db8::/32"))
+
+	ipsProperty := SpecSchemaDefinitionProperty{Name: "allowed_ips", Type: TypeList, ArrayItemsType: TypeString, Format: formatIP}
+	assert.Equal(t, []interface{}{"FE80::1", "10.0.0.2"}, keepEquivalentNetworkValues(ipsProperty, []interface{}{"FE80::1", "10.0.0.1"}, []interface{}{"fe80::1", "10.0.0.2"}))
+	assert.Equal(t, []interface{}{"fe80::1"}, keepEquivalentNetworkValues(ipsProperty, []interface{}{"FE80::1", "10.0.0.1"}, []interface{}{"fe80::1"}))
+}
+
+func TestUpdateStateWithPayloadDataNetworkFormatProperty(t *testing.T) {
+	cidrProperty := newStringSchemaDefinitionPropertyWithDefaults("cidr_block", "", true, false, "2001:DB8::/32")
+	cidrProperty.Format = formatCIDR
+	r, resourceData := testCreateResourceFactory(t, cidrProperty)
+
+	err := updateStateWithPayloadData(r.openAPIResource, map[string]interface{}{"cidr_block": "2001:db8::/32"}, resourceData)
+	require.NoError(t, err)
+	assert.Equal(t, "2001:DB8::/32", resourceData.Get("cidr_block"))
+
+	err = updateStateWithPayloadData(r.openAPIResource, map[string]interface{}{"cidr_block": "2001:db8::/48"}, resourceData)
+	require.NoError(t, err)
+	assert.Equal(t, "2001:db8::/48", resourceData.Get("cidr_block"))
+}
@@ -0,0 +1,74 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateUniqueItems is the CustomizeDiff function of the resources. It detects at plan time duplicate items in array
+// properties with uniqueItems set to true (including the ones nested in objects), which the API would reject otherwise
+func (r resourceFactory) validateUniqueItems(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
+	schemaDefinition, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	for _, property := range schemaDefinition.Properties {
+		path := property.GetTerraformCompliantPropertyName()
+		if err := checkUniqueItems(property, path, diff.Get(path), diff.NewValueKnown); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUniqueItems returns an error identifying the attribute path of the first duplicate item found in the given value
+// if the property has uniqueItems set to true. Items which value is not known yet (e,g: interpolated from other resources
+// not created yet) are not checked. The value is expected to be in the terraform state format, so objects are lists of
+// one item (refer to shouldUseLegacyTerraformSDKBlockApproachForComplexObjects).
+func checkUniqueItems(property *SpecSchemaDefinitionProperty, path string, value interface{}, isKnown func(key string) bool) error {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	if property.isArrayProperty() && property.UniqueItems {
+		for idx := range items {
+			if !isKnown(fmt.Sprintf("%s.%d", path, idx)) {
+				continue
+			}
+			for idx2 := idx + 1; idx2 < len(items); idx2++ {
+				if isKnown(fmt.Sprintf("%s.%d", path, idx2)) && property.equalUniqueItems(items[idx], items[idx2]) {
+					return fmt.Errorf("%s.%d: duplicate item %v (same as %s.%d); property '%s' only allows unique items", path, idx2, items[idx2], path, idx, property.Name)
+				}
+			}
+		}
+	}
+	if property.SpecSchemaDefinition == nil {
+		return nil
+	}
+	for idx, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, objectProperty := range property.SpecSchemaDefinition.Properties {
+			objectPropertyName := objectProperty.GetTerraformCompliantPropertyName()
+			objectPropertyPath := fmt.Sprintf("%s.%d.%s", path, idx, objectPropertyName)
+			if err := checkUniqueItems(objectProperty, objectPropertyPath, object[objectPropertyName], isKnown); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// equalUniqueItems compares the given array items; primitive items are compared as per equalItems (e,g: network formats
+// are compared semantically) and object items are compared by value
+func (s *SpecSchemaDefinitionProperty) equalUniqueItems(item1, item2 interface{}) bool {
+	if s.ArrayItemsType == TypeObject {
+		return reflect.DeepEqual(item1, item2)
+	}
+	return s.equalItems(s.ArrayItemsType, item1, item2)
+}
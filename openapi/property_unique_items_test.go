@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUniqueItems(t *testing.T) {
+	cidrBlocks := &SpecSchemaDefinitionProperty{Name: "cidrBlocks", Type: TypeList, ArrayItemsType: TypeString, UniqueItems: true, Format: formatCIDR}
+	ports := &SpecSchemaDefinitionProperty{Name: "ports", Type: TypeList, ArrayItemsType: TypeInt, UniqueItems: true}
+	tags := &SpecSchemaDefinitionProperty{Name: "tags", Type: TypeList, ArrayItemsType: TypeString}
+	rules := &SpecSchemaDefinitionProperty{Name: "rules", Type: TypeList, ArrayItemsType: TypeObject, UniqueItems: true, SpecSchemaDefinition: &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString, Required: true},
+			ports,
+		},
+	}}
+	specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{cidrBlocks, tags, rules}}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{})
+	resource, err := newResourceFactory(specResource).createTerraformResource()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		config        map[string]interface{}
+		expectedError string
+	}{
+		{
+			name: "unique items",
+			config: map[string]interface{}{
+				"cidr_blocks": []interface{}{"10.0.0.0/8", "192.168.0.0/16"},
+				"tags":        []interface{}{"tag", "tag"},
+				"rules":       []interface{}{map[string]interface{}{"name": "rule1", "ports": []interface{}{80, 443}}, map[string]interface{}{"name": "rule2"}},
+			},
+		},
+		{
+			name:          "duplicate items",
+			config:        map[string]interface{}{"cidr_blocks": []interface{}{"10.0.0.0/8", "192.168.0.0/16", "10.0.0.0/8"}},
+			expectedError: "cidr_blocks.2: duplicate item 10.0.0.0/8 (same as cidr_blocks.0); property 'cidrBlocks' only allows unique items",
+		},
+		{
+			name:          "semantically duplicate items",
+			config:        map[string]interface{}{"cidr_blocks": []interface{}{"2001:db8::/32", "2001:DB8::/32"}},
+			expectedError: "cidr_blocks.1: duplicate item 2001:DB8::/32 (same as cidr_blocks.0); property 'cidrBlocks' only allows unique items",
+		},
+		{
+			name:          "duplicate items nested in objects",
+			config:        map[string]interface{}{"rules": []interface{}{map[string]interface{}{"name": "rule1", "ports": []interface{}{80, 443, 80}}}},
+			expectedError: "rules.0.ports.2: duplicate item 80 (same as rules.0.ports.0); property 'ports' only allows unique items",
+		},
+		{
+			name:          "duplicate object items",
+			config:        map[string]interface{}{"rules": []interface{}{map[string]interface{}{"name": "rule1"}, map[string]interface{}{"name": "rule1"}}},
+			expectedError: "rules.1: duplicate item map[name:rule1 ports:[]] (same as rules.0); property 'rules' only allows unique items",
+		},
+	}
+	for _, tc := range testCases {
+		_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tc.config), nil)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+	}
+}
+
+func TestCheckUniqueItemsUnknownValues(t *testing.T) {
+	property := &SpecSchemaDefinitionProperty{Name: "names", Type: TypeList, ArrayItemsType: TypeString, UniqueItems: true}
+	isKnown := func(key string) bool {
+		return key != "names.1" && key != "names.2"
+	}
+	err := checkUniqueItems(property, "names", []interface{}{"name", "", ""}, isKnown)
+	assert.NoError(t, err)
+}
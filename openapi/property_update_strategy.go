@@ -0,0 +1,194 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// updateStrategyReplace sends the whole desired collection in the update request payload (default)
+	updateStrategyReplace = "replace"
+	// updateStrategyMerge sends the items added to and removed from the collection to the collection sub-endpoints (e,g:
+	// POST /v1/groups/1234/tags and DELETE /v1/groups/1234/tags/{key}) instead of sending the whole collection
+	updateStrategyMerge = "merge"
+)
+
+// updateStrategyDelta contains the changes of a property using the merge update strategy
+type updateStrategyDelta struct {
+	property *SpecSchemaDefinitionProperty
+	// removedKeys contains the keys of the items removed from the collection
+	removedKeys []string
+	// addedItems contains the items added to the collection (in their API representation)
+	addedItems []interface{}
+}
+
+// validateUpdateStrategy checks that the update strategy is supported by the property. The merge update strategy is only
+// supported by array properties; the items of arrays of objects are identified by their identifier property
+func (s *SpecSchemaDefinitionProperty) validateUpdateStrategy(updateStrategy string) error {
+	switch updateStrategy {
+	case updateStrategyReplace:
+		return nil
+	case updateStrategyMerge:
+		if !s.isArrayProperty() {
+			return fmt.Errorf("the '%s' update strategy is only supported by array properties", updateStrategyMerge)
+		}
+		if s.isArrayOfObjectsProperty() {
+			if s.SpecSchemaDefinition == nil {
+				return fmt.Errorf("the '%s' update strategy requires the array items to have an identifier property", updateStrategyMerge)
+			}
+			if _, err := s.SpecSchemaDefinition.getResourceIdentifier(); err != nil {
+				return fmt.Errorf("the '%s' update strategy requires the array items to have an identifier property: %s", updateStrategyMerge, err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("update strategy '%s' not supported, the supported update strategies are '%s' and '%s'", updateStrategy, updateStrategyReplace, updateStrategyMerge)
+}
+
+// isMergeUpdateStrategy returns true if the property changes are sent to the collection sub-endpoints when updating the resource
+func (s *SpecSchemaDefinitionProperty) isMergeUpdateStrategy() bool {
+	return s.UpdateStrategy == updateStrategyMerge
+}
+
+// getUpdateStrategyItemKey returns the key identifying the collection item in the sub-endpoint path: the item value for
+// primitive items and the value of the identifier property for object items
+func (s *SpecSchemaDefinitionProperty) getUpdateStrategyItemKey(item interface{}) (string, error) {
+	if !s.isArrayOfObjectsProperty() {
+		return fmt.Sprintf("%v", item), nil
+	}
+	identifier, err := s.SpecSchemaDefinition.getResourceIdentifier()
+	if err != nil {
+		return "", err
+	}
+	object, ok := item.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("unexpected item type '%T'", item)
+	}
+	value, exists := object[identifier]
+	if !exists || value == nil {
+		return "", fmt.Errorf("item is missing the identifier property '%s'", identifier)
+	}
+	return fmt.Sprintf("%v", value), nil
+}
+
+// newUpdateStrategyDelta returns the delta between the current and desired items of the collection. Items whose value
+// changed are removed and added again so the sub-endpoints are not expected to support updating items
+func newUpdateStrategyDelta(property *SpecSchemaDefinitionProperty, currentItems, desiredItems []interface{}) (*updateStrategyDelta, error) {
+	delta := &updateStrategyDelta{property: property}
+	desiredItemsByKey := map[string]interface{}{}
+	for _, item := range desiredItems {
+		key, err := property.getUpdateStrategyItemKey(item)
+		if err != nil {
+			return nil, err
+		}
+		desiredItemsByKey[key] = item
+	}
+	currentItemsByKey := map[string]interface{}{}
+	for _, item := range currentItems {
+		key, err := property.getUpdateStrategyItemKey(item)
+		if err != nil {
+			return nil, err
+		}
+		currentItemsByKey[key] = item
+		if desiredItem, exists := desiredItemsByKey[key]; !exists || !reflect.DeepEqual(item, desiredItem) {
+			delta.removedKeys = append(delta.removedKeys, key)
+		}
+	}
+	for _, item := range desiredItems {
+		key, _ := property.getUpdateStrategyItemKey(item)
+		if currentItem, exists := currentItemsByKey[key]; !exists || !reflect.DeepEqual(item, currentItem) {
+			delta.addedItems = append(delta.addedItems, item)
+		}
+	}
+	return delta, nil
+}
+
+// excludeMergeUpdateStrategyProperties removes from the update request payload the properties using the merge update
+// strategy since their changes are sent to the collection sub-endpoints instead
+func (r resourceFactory) excludeMergeUpdateStrategyProperties(requestPayload map[string]interface{}) {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return
+	}
+	for _, property := range resourceSchema.Properties {
+		if property.isMergeUpdateStrategy() {
+			delete(requestPayload, property.Name)
+		}
+	}
+}
+
+// getUpdateStrategyDeltas returns the deltas of the properties using the merge update strategy that changed
+func (r resourceFactory) getUpdateStrategyDeltas(data *schema.ResourceData) ([]*updateStrategyDelta, error) {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	var deltas []*updateStrategyDelta
+	for _, property := range resourceSchema.Properties {
+		propertyName := property.GetTerraformCompliantPropertyName()
+		if !property.isMergeUpdateStrategy() || !data.HasChange(propertyName) {
+			continue
+		}
+		currentValue, desiredValue := data.GetChange(propertyName)
+		currentItems, err := r.getUpdateStrategyItems(property, currentValue)
+		if err != nil {
+			return nil, err
+		}
+		desiredItems, err := r.getUpdateStrategyItems(property, desiredValue)
+		if err != nil {
+			return nil, err
+		}
+		delta, err := newUpdateStrategyDelta(property, currentItems, desiredItems)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute the changes of property '%s': %s", propertyName, err)
+		}
+		deltas = append(deltas, delta)
+	}
+	return deltas, nil
+}
+
+// getUpdateStrategyItems returns the items of the collection property value in their API representation
+func (r resourceFactory) getUpdateStrategyItems(property *SpecSchemaDefinitionProperty, value interface{}) ([]interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	payload := map[string]interface{}{}
+	if err := r.populatePayload(payload, property, value); err != nil {
+		return nil, err
+	}
+	items, _ := payload[property.Name].([]interface{})
+	return items, nil
+}
+
+// applyUpdateStrategyDeltas sends the changes of the properties using the merge update strategy to the collection
+// sub-endpoints: removed items are deleted (DELETE {resource instance path}/{property}/{key}) and added items are
+// created (POST {resource instance path}/{property})
+func (r resourceFactory) applyUpdateStrategyDeltas(providerClient ClientOpenAPI, resourcePath string, id string, deltas []*updateStrategyDelta, parentIDs ...string) error {
+	resourceName := r.openAPIResource.GetResourceName()
+	for _, delta := range deltas {
+		for _, key := range delta.removedKeys {
+			subResourcePath := fmt.Sprintf("%s/%s", delta.property.Name, url.PathEscape(key))
+			res, err := providerClient.DeleteSubResource(r.openAPIResource, id, subResourcePath, parentIDs...)
+			if err != nil {
+				return err
+			}
+			if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusAccepted, http.StatusNoContent, http.StatusNotFound}); err != nil {
+				return fmt.Errorf("[resource='%s'] DELETE %s/%s/%s failed: %s", resourceName, resourcePath, id, subResourcePath, err)
+			}
+		}
+		for _, item := range delta.addedItems {
+			res, err := providerClient.PostSubResource(r.openAPIResource, id, delta.property.Name, item, parentIDs...)
+			if err != nil {
+				return err
+			}
+			if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent}); err != nil {
+				return fmt.Errorf("[resource='%s'] POST %s/%s/%s failed: %s", resourceName, resourcePath, id, delta.property.Name, err)
+			}
+		}
+	}
+	return nil
+}
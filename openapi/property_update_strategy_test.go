@@ -0,0 +1,167 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMergeUpdateStrategyListProperty(name string, itemsType schemaDefinitionPropertyType, itemsSchemaDefinition *SpecSchemaDefinition) *SpecSchemaDefinitionProperty {
+	property := newListSchemaDefinitionPropertyWithDefaults(name, "", false, false, false, nil, itemsType, itemsSchemaDefinition)
+	property.UpdateStrategy = updateStrategyMerge
+	return property
+}
+
+func TestValidateUpdateStrategy(t *testing.T) {
+	itemsWithIdentifier := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{newStringSchemaDefinitionPropertyWithDefaults("id", "", false, false, nil)}}
+	itemsWithoutIdentifier := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{newStringSchemaDefinitionPropertyWithDefaults("value", "", false, false, nil)}}
+	testCases := []struct {
+		name           string
+		property       *SpecSchemaDefinitionProperty
+		updateStrategy string
+		expectedError  string
+	}{
+		{
+			name:           "replace strategy is supported by any property",
+			property:       stringProperty,
+			updateStrategy: updateStrategyReplace,
+		},
+		{
+			name:           "merge strategy on array of primitives",
+			property:       newListSchemaDefinitionPropertyWithDefaults("tags", "", false, false, false, nil, TypeString, nil),
+			updateStrategy: updateStrategyMerge,
+		},
+		{
+			name:           "merge strategy on array of objects with identifier",
+			property:       newListSchemaDefinitionPropertyWithDefaults("rules", "", false, false, false, nil, TypeObject, itemsWithIdentifier),
+			updateStrategy: updateStrategyMerge,
+		},
+		{
+			name:           "merge strategy on array of objects without identifier",
+			property:       newListSchemaDefinitionPropertyWithDefaults("rules", "", false, false, false, nil, TypeObject, itemsWithoutIdentifier),
+			updateStrategy: updateStrategyMerge,
+			expectedError:  "the 'merge' update strategy requires the array items to have an identifier property: could not find any identifier property in the resource schema definition",
+		},
+		{
+			name:           "merge strategy on non array property",
+			property:       stringProperty,
+			updateStrategy: updateStrategyMerge,
+			expectedError:  "the 'merge' update strategy is only supported by array properties",
+		},
+		{
+			name:           "unknown strategy",
+			property:       stringProperty,
+			updateStrategy: "patch",
+			expectedError:  "update strategy 'patch' not supported, the supported update strategies are 'replace' and 'merge'",
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.property.validateUpdateStrategy(tc.updateStrategy)
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestNewUpdateStrategyDelta(t *testing.T) {
+	itemsSchemaDefinition := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{newStringSchemaDefinitionPropertyWithDefaults("id", "", false, false, nil)}}
+	testCases := []struct {
+		name                string
+		property            *SpecSchemaDefinitionProperty
+		currentItems        []interface{}
+		desiredItems        []interface{}
+		expectedRemovedKeys []string
+		expectedAddedItems  []interface{}
+		expectedError       string
+	}{
+		{
+			name:                "primitive items added and removed",
+			property:            newMergeUpdateStrategyListProperty("tags", TypeString, nil),
+			currentItems:        []interface{}{"a", "b"},
+			desiredItems:        []interface{}{"b", "c"},
+			expectedRemovedKeys: []string{"a"},
+			expectedAddedItems:  []interface{}{"c"},
+		},
+		{
+			name:                "object items identified by their identifier property",
+			property:            newMergeUpdateStrategyListProperty("rules", TypeObject, itemsSchemaDefinition),
+			currentItems:        []interface{}{map[string]interface{}{"id": "r1", "port": 80}, map[string]interface{}{"id": "r2", "port": 443}},
+			desiredItems:        []interface{}{map[string]interface{}{"id": "r1", "port": 8080}, map[string]interface{}{"id": "r2", "port": 443}},
+			expectedRemovedKeys: []string{"r1"},
+			expectedAddedItems:  []interface{}{map[string]interface{}{"id": "r1", "port": 8080}},
+		},
+		{
+			name:         "no changes",
+			property:     newMergeUpdateStrategyListProperty("tags", TypeString, nil),
+			currentItems: []interface{}{"a"},
+			desiredItems: []interface{}{"a"},
+		},
+		{
+			name:          "object item missing the identifier",
+			property:      newMergeUpdateStrategyListProperty("rules", TypeObject, itemsSchemaDefinition),
+			desiredItems:  []interface{}{map[string]interface{}{"port": 80}},
+			expectedError: "item is missing the identifier property 'id'",
+		},
+	}
+	for _, tc := range testCases {
+		delta, err := newUpdateStrategyDelta(tc.property, tc.currentItems, tc.desiredItems)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedRemovedKeys, delta.removedKeys, tc.name)
+		assert.Equal(t, tc.expectedAddedItems, delta.addedItems, tc.name)
+	}
+}
+
+func TestUpdateWithMergeUpdateStrategy(t *testing.T) {
+	tagsProperty := newMergeUpdateStrategyListProperty("tags", TypeString, nil)
+	r, _ := testCreateResourceFactory(t, stringProperty, tagsProperty)
+	resourceSchema, err := r.createTerraformResourceSchema()
+	require.NoError(t, err)
+	resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{stringProperty.Name: stringProperty.Default, "tags": []interface{}{"a", "b"}})
+	resourceData.SetId("id")
+	client := &clientOpenAPIStub{
+		responsePayload: map[string]interface{}{stringProperty.Name: stringProperty.Default, "tags": []interface{}{"a", "b"}},
+	}
+
+	err = r.update(resourceData, client)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST tags", "POST tags"}, client.subResourceRequests)
+	assert.Equal(t, []interface{}{"a", "b"}, client.subResourcePayloads)
+	assert.Equal(t, []interface{}{"a", "b"}, resourceData.Get("tags"))
+}
+
+func TestUpdateWithMergeUpdateStrategyFails(t *testing.T) {
+	tagsProperty := newMergeUpdateStrategyListProperty("tags", TypeString, nil)
+	r, _ := testCreateResourceFactory(t, stringProperty, tagsProperty)
+	resourceSchema, err := r.createTerraformResourceSchema()
+	require.NoError(t, err)
+	resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{stringProperty.Name: stringProperty.Default, "tags": []interface{}{"a"}})
+	resourceData.SetId("id")
+	client := &clientOpenAPIStub{
+		responsePayload: map[string]interface{}{stringProperty.Name: stringProperty.Default},
+		returnHTTPCode:  http.StatusBadRequest,
+		funcGet: func() (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		},
+	}
+
+	err = r.update(resourceData, client)
+
+	assert.EqualError(t, err, "[resource='resourceName'] POST /v1/resource/id/tags failed: [resource='resourceName'] HTTP Response Status Code 400 not matching expected one [200 201 202 204] ()")
+}
+
+func TestExcludeMergeUpdateStrategyProperties(t *testing.T) {
+	r, _ := testCreateResourceFactory(t, stringProperty, newMergeUpdateStrategyListProperty("tags", TypeString, nil))
+	requestPayload := map[string]interface{}{stringProperty.Name: "value", "tags": []interface{}{"a"}}
+	r.excludeMergeUpdateStrategyProperties(requestPayload)
+	assert.Equal(t, map[string]interface{}{stringProperty.Name: "value"}, requestPayload)
+}
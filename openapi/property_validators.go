@@ -0,0 +1,181 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PropertyValidator validates the value configured for a property with the x-terraform-validator extension. The value
+// is of the type of the property (e,g: string for string properties). The error returned is reported to the user at plan
+// time.
+type PropertyValidator func(value interface{}) error
+
+var hostnameRegex = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// semverRegex is the regular expression suggested in https://semver.org
+var semverRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// cronFields defines the allowed range and names of the fields of the cron expressions (minute, hour, day of month,
+// month and day of week)
+var cronFields = []struct {
+	name     string
+	min, max int
+	names    []string
+}{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// propertyValidatorRegistry contains the validators available for the x-terraform-validator extension, including the
+// standard library of validators shipped with the provider
+var propertyValidatorRegistry = struct {
+	sync.RWMutex
+	validators map[string]PropertyValidator
+}{
+	validators: map[string]PropertyValidator{
+		"cidr":     validateCIDR,
+		"hostname": validateHostname,
+		"semver":   validateSemver,
+		"cron":     validateCron,
+	},
+}
+
+// RegisterPropertyValidator registers a validator that can be applied to properties with the x-terraform-validator
+// extension set to the given name, enabling third parties embedding the provider to validate custom types at plan time.
+// This function is meant to be called before the provider is created (e,g: in the main function of the provider binary)
+func RegisterPropertyValidator(name string, validator PropertyValidator) error {
+	if name == "" {
+		return errors.New("property validator registration failed: name must not be empty")
+	}
+	if validator == nil {
+		return fmt.Errorf("property validator registration failed: validator '%s' must not be nil", name)
+	}
+	propertyValidatorRegistry.Lock()
+	defer propertyValidatorRegistry.Unlock()
+	if _, exists := propertyValidatorRegistry.validators[name]; exists {
+		return fmt.Errorf("property validator registration failed: validator '%s' is already registered", name)
+	}
+	propertyValidatorRegistry.validators[name] = validator
+	providerLog.Info("property validator '%s' registered", name)
+	return nil
+}
+
+func getPropertyValidator(name string) (PropertyValidator, bool) {
+	propertyValidatorRegistry.RLock()
+	defer propertyValidatorRegistry.RUnlock()
+	validator, exists := propertyValidatorRegistry.validators[name]
+	return validator, exists
+}
+
+func getStringValue(value interface{}) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a string value but got %T", value)
+	}
+	return s, nil
+}
+
+func validateCIDR(value interface{}) error {
+	s, err := getStringValue(value)
+	if err != nil {
+		return err
+	}
+	if _, _, err := net.ParseCIDR(s); err != nil {
+		return fmt.Errorf("'%s' is not a valid CIDR notation IP address and prefix length (e,g: 192.168.0.0/16)", s)
+	}
+	return nil
+}
+
+func validateHostname(value interface{}) error {
+	s, err := getStringValue(value)
+	if err != nil {
+		return err
+	}
+	if len(s) > 253 || !hostnameRegex.MatchString(s) {
+		return fmt.Errorf("'%s' is not a valid hostname (RFC 1123)", s)
+	}
+	return nil
+}
+
+func validateSemver(value interface{}) error {
+	s, err := getStringValue(value)
+	if err != nil {
+		return err
+	}
+	if !semverRegex.MatchString(s) {
+		return fmt.Errorf("'%s' is not a valid semantic version (e,g: 1.2.3)", s)
+	}
+	return nil
+}
+
+// validateCron validates standard cron expressions with five fields (minute, hour, day of month, month and day of week).
+// Each field supports wildcards, ranges, steps and lists (e,g: */15 0-6,18-23 * JAN-JUN MON-FRI)
+func validateCron(value interface{}) error {
+	s, err := getStringValue(value)
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(s)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("'%s' is not a valid cron expression: expected %d fields (minute, hour, day of month, month and day of week) but got %d", s, len(cronFields), len(fields))
+	}
+	for i, field := range fields {
+		for _, item := range strings.Split(field, ",") {
+			if err := validateCronItem(item, cronFields[i].min, cronFields[i].max, cronFields[i].names); err != nil {
+				return fmt.Errorf("'%s' is not a valid cron expression: %s field '%s' not valid: %s", s, cronFields[i].name, field, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateCronItem(item string, min, max int, names []string) error {
+	rangeValue := item
+	if i := strings.Index(item, "/"); i >= 0 {
+		rangeValue = item[:i]
+		step, err := strconv.Atoi(item[i+1:])
+		if err != nil || step < 1 {
+			return fmt.Errorf("step '%s' must be a positive number", item[i+1:])
+		}
+	}
+	if rangeValue == "*" {
+		return nil
+	}
+	bounds := strings.SplitN(rangeValue, "-", 2)
+	var values []int
+	for _, bound := range bounds {
+		v, err := parseCronValue(bound, min, names)
+		if err != nil {
+			return err
+		}
+		if v < min || v > max {
+			return fmt.Errorf("value '%s' out of range [%d-%d]", bound, min, max)
+		}
+		values = append(values, v)
+	}
+	if len(values) == 2 && values[0] > values[1] {
+		return fmt.Errorf("range '%s' start is greater than its end", rangeValue)
+	}
+	return nil
+}
+
+func parseCronValue(value string, min int, names []string) (int, error) {
+	for i, name := range names {
+		if strings.EqualFold(value, name) {
+			return i + min, nil
+		}
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("value '%s' is not a number", value)
+	}
+	return v, nil
+}
@@ -0,0 +1,111 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterPropertyValidator(t *testing.T) {
+	defer func() {
+		propertyValidatorRegistry.Lock()
+		delete(propertyValidatorRegistry.validators, "even")
+		propertyValidatorRegistry.Unlock()
+	}()
+	even := func(value interface{}) error {
+		if value.(int)%2 != 0 {
+			return errors.New("value must be even")
+		}
+		return nil
+	}
+
+	assert.NoError(t, RegisterPropertyValidator("even", even))
+	validator, exists := getPropertyValidator("even")
+	assert.True(t, exists)
+	assert.NoError(t, validator(2))
+	assert.EqualError(t, validator(3), "value must be even")
+
+	assert.EqualError(t, RegisterPropertyValidator("even", even), "property validator registration failed: validator 'even' is already registered")
+	assert.EqualError(t, RegisterPropertyValidator("cidr", even), "property validator registration failed: validator 'cidr' is already registered")
+	assert.EqualError(t, RegisterPropertyValidator("", even), "property validator registration failed: name must not be empty")
+	assert.EqualError(t, RegisterPropertyValidator("odd", nil), "property validator registration failed: validator 'odd' must not be nil")
+}
+
+func TestStandardPropertyValidators(t *testing.T) {
+	testCases := []struct {
+		validator     string
+		value         interface{}
+		expectedError string
+	}{
+		{validator: "cidr", value: "192.168.0.0/16"},
+		{validator: "cidr", value: "2001:db8::/32"},
+		{validator: "cidr", value: "192.168.0.0", expectedError: "'192.168.0.0' is not a valid CIDR notation IP address and prefix length (e,g: 192.168.0.0/16)"},
+		{validator: "cidr", value: 10, expectedError: "expected a string value but got int"},
+		{validator: "hostname", value: "api.example.com"},
+		{validator: "hostname", value: "localhost"},
+		{validator: "hostname", value: "-api.example.com", expectedError: "'-api.example.com' is not a valid hostname (RFC 1123)"},
+		{validator: "hostname", value: "api_example.com", expectedError: "'api_example.com' is not a valid hostname (RFC 1123)"},
+		{validator: "semver", value: "1.2.3"},
+		{validator: "semver", value: "1.0.0-alpha.1+build.5"},
+		{validator: "semver", value: "v1.2.3", expectedError: "'v1.2.3' is not a valid semantic version (e,g: 1.2.3)"},
+		{validator: "semver", value: "1.2", expectedError: "'1.2' is not a valid semantic version (e,g: 1.2.3)"},
+		{validator: "cron", value: "*/15 0-6,18-23 * JAN-JUN MON-FRI"},
+		{validator: "cron", value: "0 0 1 1 0"},
+		{validator: "cron", value: "0 0 * *", expectedError: "'0 0 * *' is not a valid cron expression: expected 5 fields (minute, hour, day of month, month and day of week) but got 4"},
+		{validator: "cron", value: "60 0 * * *", expectedError: "'60 0 * * *' is not a valid cron expression: minute field '60' not valid: value '60' out of range [0-59]"},
+		{validator: "cron", value: "0 0 0 * *", expectedError: "'0 0 0 * *' is not a valid cron expression: day of month field '0' not valid: value '0' out of range [1-31]"},
+		{validator: "cron", value: "0 5-1 * * *", expectedError: "'0 5-1 * * *' is not a valid cron expression: hour field '5-1' not valid: range '5-1' start is greater than its end"},
+		{validator: "cron", value: "*/0 * * * *", expectedError: "'*/0 * * * *' is not a valid cron expression: minute field '*/0' not valid: step '0' must be a positive number"},
+		{validator: "cron", value: "0 0 * FOO *", expectedError: "'0 0 * FOO *' is not a valid cron expression: month field 'FOO' not valid: value 'FOO' is not a number"},
+	}
+	for _, tc := range testCases {
+		validator, exists := getPropertyValidator(tc.validator)
+		assert.True(t, exists, tc.validator)
+		err := validator(tc.value)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, "%s: %v", tc.validator, tc.value)
+			continue
+		}
+		assert.NoError(t, err, "%s: %v", tc.validator, tc.value)
+	}
+}
+
+func TestSchemaDefinitionPropertyValidator(t *testing.T) {
+	s := &SpecSchemaDefinitionProperty{Name: "cidr_block", Type: TypeString, Validator: "cidr"}
+	terraformSchema, err := s.terraformSchema()
+	require.NoError(t, err)
+	require.NotNil(t, terraformSchema.ValidateDiagFunc)
+
+	_, errs := s.validateFunc()("10.0.0.0/8", "cidr_block")
+	assert.Empty(t, errs)
+	_, errs = s.validateFunc()("10.0.0.0", "cidr_block")
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "property 'cidr_block' value not valid: '10.0.0.0' is not a valid CIDR notation IP address and prefix length (e,g: 192.168.0.0/16)")
+	_, errs = s.validateFunc()(nil, "cidr_block")
+	assert.Empty(t, errs)
+}
+
+func TestSchemaDefinitionPropertyValidatorNotValid(t *testing.T) {
+	testCases := []struct {
+		name          string
+		property      *SpecSchemaDefinitionProperty
+		expectedError string
+	}{
+		{
+			name:          "validator not registered",
+			property:      &SpecSchemaDefinitionProperty{Name: "cidr_block", Type: TypeString, Validator: "unknown"},
+			expectedError: "property 'cidr_block' x-terraform-validator extension not valid: validator 'unknown' is not registered",
+		},
+		{
+			name:          "validator on an array property",
+			property:      &SpecSchemaDefinitionProperty{Name: "cidr_blocks", Type: TypeList, ArrayItemsType: TypeString, Validator: "cidr"},
+			expectedError: "property 'cidr_blocks' x-terraform-validator extension not valid: validators are only supported on primitive properties",
+		},
+	}
+	for _, tc := range testCases {
+		_, err := tc.property.terraformSchema()
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
@@ -3,8 +3,9 @@ package openapi
 import (
 	"crypto/tls"
 	"fmt"
-	"log"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -12,8 +13,14 @@ import (
 // ProviderOpenAPI defines the struct for the OpenAPI Terraform Provider
 type ProviderOpenAPI struct {
 	ProviderName string
-	provider     *schema.Provider
-	err          error
+	// ProviderVersion defines the version of custom provider builds (e,g: terraform-provider-{name} built with the OpenAPI
+	// Terraform provider library). The version is reported by the provider info data source, if not specified the OpenAPI
+	// Terraform provider version is reported instead
+	ProviderVersion string
+	provider        *schema.Provider
+	err             error
+	// mutex guards the provider creation so concurrent calls (e,g: schema extraction) get the same cached provider
+	mutex sync.Mutex
 }
 
 // CreateSchemaProvider returns a terraform.ResourceProvider.
@@ -27,6 +34,8 @@ func (p *ProviderOpenAPI) CreateSchemaProvider() (*schema.Provider, error) {
 
 // CreateSchemaProviderFromServiceConfiguration helper function to enable creation of schema.Provider with the given serviceConfiguration
 func (p *ProviderOpenAPI) CreateSchemaProviderFromServiceConfiguration(serviceConfiguration ServiceConfiguration) (*schema.Provider, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 	if p.err != nil {
 		return nil, p.err
 	}
@@ -34,19 +43,33 @@ func (p *ProviderOpenAPI) CreateSchemaProviderFromServiceConfiguration(serviceCo
 		return p.provider, nil
 	}
 
-	log.Printf("[DEBUG] service configuration = %+v", serviceConfiguration)
+	providerLog.Debug("service configuration = %+v", serviceConfiguration)
 
-	if serviceConfiguration.IsInsecureSkipVerifyEnabled() {
-		log.Printf("[WARN] Provider '%s' is using insecure skip verify, therefore the HTTPs client will not verify the API server's certificate chain and host name. This should only be used for testing purposes and it's highly recommended avoiding the use of OTF_INSECURE_SKIP_VERIFY env variable or configuring the ServiceConfiguration with InsecureSkipVerifyEnabled when executing this provider", p.ProviderName)
-		tr := http.DefaultTransport.(*http.Transport)
-		// #nosec G402
-		tr.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true,
-		}
-		log.Printf("[WARN] TLSClientConfig has been configured with InsecureSkipVerify set to true, this means that TLS connections will accept any certificate presented by the server and any host name in that certificate")
+	providerFactory, err := p.newProviderFactory(serviceConfiguration)
+	if err != nil {
+		return nil, err
 	}
 
-	openAPISpecAnalyser, err := CreateSpecAnalyser(specAnalyserV2, serviceConfiguration.GetSwaggerURL())
+	p.provider, err = providerFactory.createProvider()
+	if err != nil {
+		return nil, fmt.Errorf("plugin terraform-provider-%s init error while creating schema provider: %s", p.ProviderName, err)
+	}
+	return p.provider, nil
+}
+
+// newProviderFactory returns the provider factory for the OpenAPI document(s) configured in the service configuration. The
+// OpenAPI document(s) are retrieved with the provider transport, which is also used by the provider API calls
+func (p *ProviderOpenAPI) newProviderFactory(serviceConfiguration ServiceConfiguration) (*providerFactory, error) {
+	if fipsModeEnabled {
+		providerLog.Info("Provider '%s' is built in FIPS mode, the TLS connections are restricted to FIPS-approved settings", p.ProviderName)
+	}
+
+	transport, err := p.newProviderTransport(serviceConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	openAPISpecAnalyser, err := createSpecAnalyser(serviceConfiguration, newDocumentLoader(transport))
 	if err != nil {
 		return nil, fmt.Errorf("plugin OpenAPI spec analyser error: %s", err)
 	}
@@ -55,12 +78,70 @@ func (p *ProviderOpenAPI) CreateSchemaProviderFromServiceConfiguration(serviceCo
 	if err != nil {
 		return nil, fmt.Errorf("plugin provider factory init error: %s", err)
 	}
+	providerFactory.providerVersion = p.ProviderVersion
+	providerFactory.transport = transport
+	return providerFactory, nil
+}
 
-	p.provider, err = providerFactory.createProvider()
+// newProviderTransport returns the transport of the provider: a clone of the default transport configured with the plugin
+// TLS and dialer settings. The default transport itself is not modified so the settings of the provider do not leak into
+// other providers served by the same process, nor into any other http client relying on the default transport
+func (p *ProviderOpenAPI) newProviderTransport(serviceConfiguration ServiceConfiguration) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig, err := serviceConfiguration.GetTLSConfiguration().newTLSClientConfig()
 	if err != nil {
-		return nil, fmt.Errorf("plugin terraform-provider-%s init error while creating schema provider: %s", p.ProviderName, err)
+		return nil, fmt.Errorf("plugin TLS configuration error: %s", err)
 	}
-	return p.provider, nil
+
+	if serviceConfiguration.IsInsecureSkipVerifyEnabled() {
+		providerLog.Warn("Provider '%s' is using insecure skip verify, therefore the HTTPs client will not verify the API server's certificate chain and host name. This should only be used for testing purposes and it's highly recommended avoiding the use of OTF_INSECURE_SKIP_VERIFY env variable or configuring the ServiceConfiguration with InsecureSkipVerifyEnabled when executing this provider", p.ProviderName)
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		// #nosec G402
+		tlsConfig.InsecureSkipVerify = true
+		providerLog.Warn("TLSClientConfig has been configured with InsecureSkipVerify set to true, this means that TLS connections will accept any certificate presented by the server and any host name in that certificate")
+	}
+
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	if dialContext := serviceConfiguration.GetDialerConfiguration().newDialContext(); dialContext != nil {
+		providerLog.Info("Provider '%s' is using a custom dialer configuration to connect to the API", p.ProviderName)
+		transport.DialContext = dialContext
+	}
+	return transport, nil
+}
+
+// createSpecAnalyser creates the SpecAnalyser for the swagger URL configured in the service configuration. If additional
+// swagger URLs are configured, the resources from all the OpenAPI documents are aggregated into the provider. If the quirks
+// mode is enabled, the quirks of the spec generators are fixed in all the OpenAPI documents. The OpenAPI documents are
+// retrieved with the loader passed in
+func createSpecAnalyser(serviceConfiguration ServiceConfiguration, load documentLoader) (SpecAnalyser, error) {
+	createDocumentSpecAnalyser := createSpecAnalyserWithLoader
+	if serviceConfiguration.IsQuirksModeEnabled() {
+		createDocumentSpecAnalyser = createSpecAnalyserWithQuirks
+	}
+	openAPISpecAnalyser, err := createDocumentSpecAnalyser(serviceConfiguration.GetSpecFormat(), serviceConfiguration.GetSwaggerURL(), load)
+	if err != nil {
+		return nil, err
+	}
+	additionalSwaggerURLs := serviceConfiguration.GetAdditionalSwaggerURLs()
+	if len(additionalSwaggerURLs) == 0 {
+		return openAPISpecAnalyser, nil
+	}
+	documents := []specAggregatedDocument{{url: serviceConfiguration.GetSwaggerURL(), analyser: openAPISpecAnalyser}}
+	for _, additionalSwaggerURL := range additionalSwaggerURLs {
+		additionalSpecAnalyser, err := createDocumentSpecAnalyser(serviceConfiguration.GetSpecFormat(), additionalSwaggerURL, load)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, specAggregatedDocument{url: additionalSwaggerURL, analyser: additionalSpecAnalyser})
+	}
+	providerLog.Info("aggregating the resources from the following OpenAPI documents into the provider: %s, %s", serviceConfiguration.GetSwaggerURL(), strings.Join(additionalSwaggerURLs, ", "))
+	return newSpecAggregatedAnalyser(documents)
 }
 
 // This function is implemented with temporary code thus it can serve as an example
@@ -77,6 +158,6 @@ func getServiceConfiguration(providerName string) (ServiceConfiguration, error)
 		return nil, err
 	}
 
-	log.Printf("[INFO] Provider %s is using the following swagger file: %s", providerName, serviceConfiguration.GetSwaggerURL())
+	providerLog.Info("Provider %s is using the following swagger file: %s", providerName, serviceConfiguration.GetSwaggerURL())
 	return serviceConfiguration, nil
 }
@@ -1,11 +1,25 @@
 package openapi
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const providerPropertyRegion = "region"
 const providerPropertyEndPoints = "endpoints"
+const providerPropertyProfile = "profile"
+const providerPropertyForceApply = "force_apply"
+const providerPropertyFeatures = "features"
+const providerPropertyAcceptLanguage = "accept_language"
+const providerPropertyClientCertificate = "client_certificate"
+const providerPropertyClientKey = "client_key"
+const providerPropertyCACertificate = "ca_certificate"
+
+// acceptLanguageRangeRegex matches the language ranges of the Accept-Language header, optionally weighted (e,g: de-DE, de;q=0.9 or *)
+var acceptLanguageRangeRegex = regexp.MustCompile(`^(\*|[a-zA-Z]{1,8}(-[a-zA-Z0-9]{1,8})*)(\s*;\s*q=[01](\.[0-9]{0,3})?)?$`)
 
 // providerConfiguration contains all the configuration related to the OpenAPI provider. The configuration at the moment
 // supports:
@@ -15,16 +29,31 @@ const providerPropertyEndPoints = "endpoints"
 // file. These headers may be sent as part of the HTTP calls if the resource requires them (as specified in the swagger doc)
 // - Endpoints contains the endpoints configured by the user, which effectively will override the default host set in the swagger file
 // - Region contains the region if user provided value for it (only supported for multi-region providers)
+// - Host contains the host configured in the selected service profile (if any), which overrides the default host set in the swagger file
+// - ForceApply defines whether the changes should be forced when the API refuses to apply fields owned by other field managers
+// - AcceptLanguage contains the languages the API messages (e,g: error messages) should be localized in, sent in the Accept-Language header
+// - ClientCertificate, ClientKey and CACertificate contain the client certificate presented to the API (mutual TLS) and the CA
+// the API server certificate is verified with, either as file paths or PEM contents
 type providerConfiguration struct {
 	Headers                   map[string]string
 	SecuritySchemaDefinitions map[string]specAPIKeyAuthenticator
 	Endpoints                 map[string]string
 	Region                    string
+	Host                      string
+	ForceApply                bool
+	AcceptLanguage            string
+	ClientCertificate         string
+	ClientKey                 string
+	CACertificate             string
+	// FeatureFlags contains the values of the feature flags (x-terraform-provider-feature-flags) keyed by their terraform
+	// compliant names
+	FeatureFlags map[string]interface{}
 }
 
 // createProviderConfig returns a providerConfiguration populated with the values provided by the user in the provider's terraform
-// configuration mapped to the corresponding
-func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceData, providerConfigurationEndPoints *providerConfigurationEndPoints) (*providerConfiguration, error) {
+// configuration mapped to the corresponding. The security definitions not configured by the user are read from the Vault
+// credentials (if configured), falling back to the profile auth environment variables
+func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceData, providerConfigurationEndPoints *providerConfigurationEndPoints, profile *ServiceProfileConfig, vault *vaultCredentials) (*providerConfiguration, error) {
 	providerConfiguration := &providerConfiguration{}
 	providerConfiguration.Headers = map[string]string{}
 	providerConfiguration.Endpoints = map[string]string{}
@@ -37,8 +66,22 @@ func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceDa
 	if securitySchemaDefinitions != nil {
 		for _, secDef := range *securitySchemaDefinitions {
 			secDefTerraformCompliantName := secDef.GetTerraformConfigurationName()
+			if oauth2SecDef, ok := secDef.(specOAuth2ClientCredentialsSecurityDefinition); ok {
+				clientID := getProviderPropertyValue(data, profile, oauth2SecDef.getClientIDConfigurationName())
+				clientSecret := getProviderPropertyValue(data, profile, oauth2SecDef.getClientSecretConfigurationName())
+				providerConfiguration.SecuritySchemaDefinitions[secDefTerraformCompliantName] = newAPIOAuth2ClientCredentialsAuthenticator(secDef.getAPIKey().Name, clientID, clientSecret, secDef.getAPIKey().Metadata[oauth2TokenURLKey].(string), secDefTerraformCompliantName)
+				continue
+			}
 			if value, exists := data.GetOkExists(secDefTerraformCompliantName); exists {
 				providerConfiguration.SecuritySchemaDefinitions[secDefTerraformCompliantName] = createAPIKeyAuthenticator(secDef, value.(string))
+			} else if vault != nil {
+				vaultAuthenticator, err := newVaultAPIKeyAuthenticator(secDef, vault)
+				if err != nil {
+					return nil, err
+				}
+				providerConfiguration.SecuritySchemaDefinitions[secDefTerraformCompliantName] = vaultAuthenticator
+			} else if value := profile.getAuthEnvVarValue(secDefTerraformCompliantName); value != "" {
+				providerConfiguration.SecuritySchemaDefinitions[secDefTerraformCompliantName] = createAPIKeyAuthenticator(secDef, value)
 			} else {
 				// Initialise the api authenticator with an empty value since the user did not provide one
 				providerConfiguration.SecuritySchemaDefinitions[secDefTerraformCompliantName] = createAPIKeyAuthenticator(secDef, "")
@@ -52,6 +95,8 @@ func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceDa
 			headerTerraformCompliantName := headerParam.GetHeaderTerraformConfigurationName()
 			if value, exists := data.GetOkExists(headerTerraformCompliantName); exists {
 				providerConfiguration.Headers[headerTerraformCompliantName] = value.(string)
+			} else if value := profile.getAuthEnvVarValue(headerTerraformCompliantName); value != "" {
+				providerConfiguration.Headers[headerTerraformCompliantName] = value
 			}
 		}
 	}
@@ -65,9 +110,34 @@ func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceDa
 		providerConfiguration.Endpoints = providerConfigurationEndPoints.configureEndpoints(data)
 	}
 
+	if profile != nil {
+		providerConfiguration.Host = profile.Host
+	}
+
+	if forceApply, ok := data.Get(providerPropertyForceApply).(bool); ok {
+		providerConfiguration.ForceApply = forceApply
+	}
+
+	if acceptLanguage, ok := data.Get(providerPropertyAcceptLanguage).(string); ok {
+		providerConfiguration.AcceptLanguage = acceptLanguage
+	}
+
+	providerConfiguration.ClientCertificate = getProviderPropertyValue(data, profile, providerPropertyClientCertificate)
+	providerConfiguration.ClientKey = getProviderPropertyValue(data, profile, providerPropertyClientKey)
+	providerConfiguration.CACertificate = getProviderPropertyValue(data, profile, providerPropertyCACertificate)
+
 	return providerConfiguration, nil
 }
 
+// getProviderPropertyValue returns the value of the provider property passed in, falling back to the profile auth environment
+// variables; empty if not configured
+func getProviderPropertyValue(data *schema.ResourceData, profile *ServiceProfileConfig, propertyName string) string {
+	if value, exists := data.GetOkExists(propertyName); exists {
+		return value.(string)
+	}
+	return profile.getAuthEnvVarValue(propertyName)
+}
+
 func (p *providerConfiguration) getAuthenticatorFor(s SpecSecurityScheme) specAPIKeyAuthenticator {
 	securitySchemeConfigName := s.GetTerraformConfigurationName()
 	return p.SecuritySchemaDefinitions[securitySchemeConfigName]
@@ -78,6 +148,34 @@ func (p *providerConfiguration) getHeaderValueFor(s SpecHeaderParam) string {
 	return p.Headers[headerConfigName]
 }
 
+// getHost returns the host configured in the selected service profile; empty if not configured
+func (p *providerConfiguration) getHost() string {
+	return p.Host
+}
+
+// isForceApplyEnabled returns true if the user enabled force applying the changes in the provider configuration
+func (p *providerConfiguration) isForceApplyEnabled() bool {
+	return p.ForceApply
+}
+
+// getAcceptLanguage returns the languages configured by the user the API messages should be localized in; empty if not
+// configured
+func (p *providerConfiguration) getAcceptLanguage() string {
+	return p.AcceptLanguage
+}
+
+// isMutualTLSConfigured returns true if the user configured a client certificate or CA certificate in the provider
+// configuration
+func (p *providerConfiguration) isMutualTLSConfigured() bool {
+	return p.ClientCertificate != "" || p.ClientKey != "" || p.CACertificate != ""
+}
+
+// getFeatureFlags returns the values of the feature flags keyed by their terraform compliant names; nil if the OpenAPI
+// document does not define feature flags
+func (p *providerConfiguration) getFeatureFlags() map[string]interface{} {
+	return p.FeatureFlags
+}
+
 // getRegion returns the region value provided by the user in the configuration for the provider
 func (p *providerConfiguration) getRegion() string {
 	return p.Region
@@ -92,3 +190,15 @@ func (p *providerConfiguration) getEndPoint(resourceName string) string {
 	}
 	return ""
 }
+
+// validateAcceptLanguage validates the accept_language property value is a comma separated list of language ranges as
+// expected by the Accept-Language header (e,g: de-DE, de;q=0.9, en;q=0.8)
+func validateAcceptLanguage(value interface{}, key string) ([]string, []error) {
+	acceptLanguage, _ := value.(string)
+	for _, languageRange := range strings.Split(acceptLanguage, ",") {
+		if !acceptLanguageRangeRegex.MatchString(strings.TrimSpace(languageRange)) {
+			return nil, []error{fmt.Errorf("property %s value '%s' is not valid, please make sure the value is a comma separated list of languages (e,g: de-DE, de;q=0.9, en;q=0.8)", key, acceptLanguage)}
+		}
+	}
+	return nil, nil
+}
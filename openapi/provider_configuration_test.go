@@ -1,8 +1,10 @@
 package openapi
 
 import (
-	. "github.com/smartystreets/goconvey/convey"
 	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewProviderConfiguration(t *testing.T) {
@@ -28,7 +30,7 @@ func TestNewProviderConfiguration(t *testing.T) {
 
 		data := newTestSchema(stringProperty, stringWithPreferredNameProperty, headerProperty).getResourceData(t)
 		Convey("When newProviderConfiguration method is called", func() {
-			providerConfiguration, err := newProviderConfiguration(specAnalyser, data, providerConfigurationEndPoints)
+			providerConfiguration, err := newProviderConfiguration(specAnalyser, data, providerConfigurationEndPoints, nil, nil)
 			Convey("Then the error providerConfiguretion headers and security definitions should be configured as expected and the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 				So(providerConfiguration.Headers, ShouldContainKey, headerProperty.GetTerraformCompliantPropertyName())
@@ -51,14 +53,14 @@ func TestGetAuthenticatorFor(t *testing.T) {
 			},
 		}
 		Convey("When getAuthenticatorFor method with an existing sec def", func() {
-			apiKeyAuth := providerConfiguration.getAuthenticatorFor(SpecSecurityScheme{"registered_sec_def_name"})
+			apiKeyAuth := providerConfiguration.getAuthenticatorFor(SpecSecurityScheme{Name: "registered_sec_def_name"})
 			Convey("Then the apikey name should be headerName and the apikey value should have the expected value", func() {
 				So(apiKeyAuth.getContext().(apiKey).name, ShouldEqual, "headerName")
 				So(apiKeyAuth.getContext().(apiKey).value, ShouldEqual, "value")
 			})
 		})
 		Convey("When getAuthenticatorFor method with a NON existing sec def", func() {
-			apiKeyAuth := providerConfiguration.getAuthenticatorFor(SpecSecurityScheme{"nonExistingSecDef"})
+			apiKeyAuth := providerConfiguration.getAuthenticatorFor(SpecSecurityScheme{Name: "nonExistingSecDef"})
 			Convey("Then the apiKeyAuth returned should be nil", func() {
 				So(apiKeyAuth, ShouldBeNil)
 			})
@@ -146,3 +148,25 @@ func TestGetEndPoint(t *testing.T) {
 		})
 	})
 }
+
+func TestValidateAcceptLanguage(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expectedValid bool
+	}{
+		{name: "single language", value: "de", expectedValid: true},
+		{name: "language with region", value: "de-DE", expectedValid: true},
+		{name: "weighted languages", value: "de-DE, de;q=0.9, en;q=0.8, *;q=0.1", expectedValid: true},
+		{name: "language with script and region", value: "zh-Hant-TW", expectedValid: true},
+		{name: "empty language", value: "de,,en", expectedValid: false},
+		{name: "language with underscore", value: "de_DE", expectedValid: false},
+		{name: "weight not valid", value: "de;q=2", expectedValid: false},
+	}
+	for _, tc := range testCases {
+		_, errs := validateAcceptLanguage(tc.value, providerPropertyAcceptLanguage)
+		assert.Equal(t, tc.expectedValid, len(errs) == 0, tc.name)
+	}
+	_, errs := validateAcceptLanguage("de_DE", providerPropertyAcceptLanguage)
+	assert.EqualError(t, errs[0], "property accept_language value 'de_DE' is not valid, please make sure the value is a comma separated list of languages (e,g: de-DE, de;q=0.9, en;q=0.8)")
+}
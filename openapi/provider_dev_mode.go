@@ -0,0 +1,209 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// devModeSpecPollInterval defines how often the local OpenAPI document is checked for changes in dev mode
+var devModeSpecPollInterval = time.Second
+
+// devModeProviderServer implements the tfprotov5.ProviderServer interface delegating the calls to the provider server created
+// from the latest version of the local OpenAPI document. When the document changes, the provider schemas are rebuilt and the
+// new provider is configured with the last provider configuration received so subsequent calls can be served straight away.
+// If the new version of the document is not valid, the previous provider server keeps serving the calls.
+type devModeProviderServer struct {
+	mutex            sync.RWMutex
+	server           tfprotov5.ProviderServer
+	configureRequest *tfprotov5.ConfigureProviderRequest
+
+	specFile       string
+	specModTime    time.Time
+	specSize       int64
+	createProvider func() (*schema.Provider, error)
+}
+
+// CreateDevModeProviderServer creates a provider server that watches the local OpenAPI document configured for the provider
+// and rebuilds the provider schemas when the document changes. This enables spec authors to iterate on the OpenAPI document
+// without having to restart the provider. The document is watched until the context passed in is cancelled.
+func (p *ProviderOpenAPI) CreateDevModeProviderServer(ctx context.Context) (tfprotov5.ProviderServer, error) {
+	serviceConfiguration, err := getServiceConfiguration(p.ProviderName)
+	if err != nil {
+		return nil, fmt.Errorf("plugin init error: %s", err)
+	}
+	return p.createDevModeProviderServer(ctx, serviceConfiguration)
+}
+
+func (p *ProviderOpenAPI) createDevModeProviderServer(ctx context.Context, serviceConfiguration ServiceConfiguration) (*devModeProviderServer, error) {
+	specFile := serviceConfiguration.GetSwaggerURL()
+	if strings.HasPrefix(specFile, "http://") || strings.HasPrefix(specFile, "https://") {
+		return nil, fmt.Errorf("dev mode requires the OpenAPI document to be a local file, the document configured is '%s'", specFile)
+	}
+	s := &devModeProviderServer{
+		specFile: specFile,
+		createProvider: func() (*schema.Provider, error) {
+			providerOpenAPI := &ProviderOpenAPI{ProviderName: p.ProviderName, ProviderVersion: p.ProviderVersion}
+			return providerOpenAPI.CreateSchemaProviderFromServiceConfiguration(serviceConfiguration)
+		},
+	}
+	if _, err := s.specChanged(); err != nil {
+		return nil, err
+	}
+	if err := s.reload(ctx); err != nil {
+		return nil, err
+	}
+	go s.watch(ctx)
+	providerLog.Info("dev mode enabled for provider '%s', watching OpenAPI document '%s' for changes", p.ProviderName, specFile)
+	return s, nil
+}
+
+// watch polls the local OpenAPI document and reloads the provider when the document changes
+func (s *devModeProviderServer) watch(ctx context.Context) {
+	ticker := time.NewTicker(devModeSpecPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := s.specChanged()
+			if err != nil {
+				providerLog.Error("dev mode failed to check the OpenAPI document '%s' for changes: %s", s.specFile, err)
+				continue
+			}
+			if !changed {
+				continue
+			}
+			providerLog.Info("dev mode detected changes in the OpenAPI document '%s', reloading the provider", s.specFile)
+			if err := s.reload(ctx); err != nil {
+				providerLog.Error("dev mode failed to reload the provider, the previous version of the OpenAPI document will keep being used: %s", err)
+			}
+		}
+	}
+}
+
+// specChanged returns true if the modification time or the size of the local OpenAPI document changed since the last check
+func (s *devModeProviderServer) specChanged() (bool, error) {
+	info, err := os.Stat(s.specFile)
+	if err != nil {
+		return false, err
+	}
+	if info.ModTime().Equal(s.specModTime) && info.Size() == s.specSize {
+		return false, nil
+	}
+	s.specModTime = info.ModTime()
+	s.specSize = info.Size()
+	return true, nil
+}
+
+// reload creates the provider server from the current version of the local OpenAPI document. If the provider was already
+// configured, the new provider server gets configured with the same configuration
+func (s *devModeProviderServer) reload(ctx context.Context) error {
+	provider, err := s.createProvider()
+	if err != nil {
+		return err
+	}
+	server := schema.NewGRPCProviderServer(provider)
+	configureRequest := s.getConfigureRequest()
+	if configureRequest != nil {
+		resp, err := server.ConfigureProvider(ctx, configureRequest)
+		if err != nil {
+			return err
+		}
+		for _, diagnostic := range resp.Diagnostics {
+			if diagnostic.Severity == tfprotov5.DiagnosticSeverityError {
+				return fmt.Errorf("failed to configure the reloaded provider: %s %s", diagnostic.Summary, diagnostic.Detail)
+			}
+		}
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.server = server
+	return nil
+}
+
+func (s *devModeProviderServer) current() tfprotov5.ProviderServer {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.server
+}
+
+func (s *devModeProviderServer) getConfigureRequest() *tfprotov5.ConfigureProviderRequest {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.configureRequest
+}
+
+// GetProviderSchema returns the schema of the provider created from the latest version of the OpenAPI document
+func (s *devModeProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	return s.current().GetProviderSchema(ctx, req)
+}
+
+// PrepareProviderConfig validates the provider configuration
+func (s *devModeProviderServer) PrepareProviderConfig(ctx context.Context, req *tfprotov5.PrepareProviderConfigRequest) (*tfprotov5.PrepareProviderConfigResponse, error) {
+	return s.current().PrepareProviderConfig(ctx, req)
+}
+
+// ConfigureProvider configures the provider and keeps the configuration so providers reloaded afterwards get configured too
+func (s *devModeProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	if req == nil {
+		return nil, errors.New("configure provider request must not be nil")
+	}
+	s.mutex.Lock()
+	s.configureRequest = req
+	s.mutex.Unlock()
+	return s.current().ConfigureProvider(ctx, req)
+}
+
+// StopProvider stops the provider
+func (s *devModeProviderServer) StopProvider(ctx context.Context, req *tfprotov5.StopProviderRequest) (*tfprotov5.StopProviderResponse, error) {
+	return s.current().StopProvider(ctx, req)
+}
+
+// ValidateResourceTypeConfig validates the resource configuration
+func (s *devModeProviderServer) ValidateResourceTypeConfig(ctx context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	return s.current().ValidateResourceTypeConfig(ctx, req)
+}
+
+// UpgradeResourceState upgrades the resource state
+func (s *devModeProviderServer) UpgradeResourceState(ctx context.Context, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
+	return s.current().UpgradeResourceState(ctx, req)
+}
+
+// ReadResource refreshes the resource state
+func (s *devModeProviderServer) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	return s.current().ReadResource(ctx, req)
+}
+
+// PlanResourceChange plans the resource changes
+func (s *devModeProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	return s.current().PlanResourceChange(ctx, req)
+}
+
+// ApplyResourceChange applies the resource changes
+func (s *devModeProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	return s.current().ApplyResourceChange(ctx, req)
+}
+
+// ImportResourceState imports the resource state
+func (s *devModeProviderServer) ImportResourceState(ctx context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	return s.current().ImportResourceState(ctx, req)
+}
+
+// ValidateDataSourceConfig validates the data source configuration
+func (s *devModeProviderServer) ValidateDataSourceConfig(ctx context.Context, req *tfprotov5.ValidateDataSourceConfigRequest) (*tfprotov5.ValidateDataSourceConfigResponse, error) {
+	return s.current().ValidateDataSourceConfig(ctx, req)
+}
+
+// ReadDataSource reads the data source
+func (s *devModeProviderServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
+	return s.current().ReadDataSource(ctx, req)
+}
@@ -0,0 +1,109 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const devModeTestSpec = `swagger: "2.0"
+host: localhost:8443
+paths:
+  /v1/%[1]s:
+    post:
+      parameters:
+      - in: body
+        name: body
+        schema:
+          $ref: "#/definitions/Resource"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/Resource"
+  /v1/%[1]s/{id}:
+    get:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        type: string
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Resource"
+definitions:
+  Resource:
+    type: object
+    properties:
+      id:
+        type: string
+        readOnly: true
+      label:
+        type: string`
+
+func TestCreateDevModeProviderServer(t *testing.T) {
+	defer func(pollInterval time.Duration) { devModeSpecPollInterval = pollInterval }(devModeSpecPollInterval)
+	devModeSpecPollInterval = 10 * time.Millisecond
+
+	file, err := ioutil.TempFile("", "dev_mode_spec.yaml")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	require.NoError(t, ioutil.WriteFile(file.Name(), []byte(fmt.Sprintf(devModeTestSpec, "cdns")), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := &ProviderOpenAPI{ProviderName: "foo"}
+	server, err := p.createDevModeProviderServer(ctx, &ServiceConfigStub{SwaggerURL: file.Name()})
+	require.NoError(t, err)
+
+	resourceSchemas := func() map[string]*tfprotov5.Schema {
+		resp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+		require.NoError(t, err)
+		return resp.ResourceSchemas
+	}
+	assert.Contains(t, resourceSchemas(), "foo_cdns_v1")
+
+	// the provider schemas are rebuilt when the OpenAPI document changes
+	require.NoError(t, ioutil.WriteFile(file.Name(), []byte(fmt.Sprintf(devModeTestSpec, "firewalls")), 0600))
+	assert.Eventually(t, func() bool {
+		_, found := resourceSchemas()["foo_firewalls_v1"]
+		return found
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NotContains(t, resourceSchemas(), "foo_cdns_v1")
+
+	// the previous provider keeps serving the calls if the new version of the OpenAPI document is not valid
+	require.NoError(t, ioutil.WriteFile(file.Name(), []byte("some non valid open api document"), 0600))
+	time.Sleep(100 * time.Millisecond)
+	assert.Contains(t, resourceSchemas(), "foo_firewalls_v1")
+}
+
+func TestCreateDevModeProviderServerErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		swaggerURL    string
+		expectedError string
+	}{
+		{
+			name:          "OpenAPI document exposed via URL",
+			swaggerURL:    "https://api.foo.com/swagger.yaml",
+			expectedError: "dev mode requires the OpenAPI document to be a local file, the document configured is 'https://api.foo.com/swagger.yaml'",
+		},
+		{
+			name:          "OpenAPI document does not exist",
+			swaggerURL:    "non-existing-spec.yaml",
+			expectedError: "stat non-existing-spec.yaml: no such file or directory",
+		},
+	}
+	for _, tc := range testCases {
+		p := &ProviderOpenAPI{ProviderName: "foo"}
+		_, err := p.createDevModeProviderServer(context.Background(), &ServiceConfigStub{SwaggerURL: tc.swaggerURL})
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
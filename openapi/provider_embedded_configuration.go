@@ -0,0 +1,163 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// embeddedConfigurationMagic marks the provider binaries that have been built with an embedded configuration. The binary
+// layout is: <provider binary><embedded configuration JSON><embedded configuration JSON length (uint64 big endian)><magic>
+const embeddedConfigurationMagic = "OTF_EMBEDDED_CONFIGURATION_V1"
+
+// embeddedConfigurationTrailerSize defines the size of the trailer appended at the end of the binary (length + magic)
+const embeddedConfigurationTrailerSize = 8 + len(embeddedConfigurationMagic)
+
+// EmbeddedConfiguration defines the configuration embedded in self-contained provider binaries. Self-contained binaries
+// do not rely on the OTF_VAR_<provider_name>_SWAGGER_URL environment variable nor the plugin configuration file to discover
+// the OpenAPI document
+type EmbeddedConfiguration struct {
+	// ProviderName defines the name of the provider (terraform-provider-<provider_name>)
+	ProviderName string `json:"provider_name"`
+	// SwaggerURL defines the URL where the OpenAPI document is exposed. Only used if the Spec is not embedded
+	SwaggerURL string `json:"swagger_url,omitempty"`
+	// Spec contains the OpenAPI document content
+	Spec []byte `json:"spec,omitempty"`
+	// SpecFormat defines the spec format of the OpenAPI document. If not specified, it will be detected
+	SpecFormat string `json:"spec_format,omitempty"`
+	// ProviderVersion defines the version of the provider reported by the provider info data source
+	ProviderVersion string `json:"provider_version,omitempty"`
+}
+
+// BuildProviderBinary creates the provider binary at outputPath copying the source binary (e,g: terraform-provider-openapi)
+// and embedding the configuration passed in. If the source binary already contains an embedded configuration, it gets
+// replaced with the new one
+func BuildProviderBinary(sourceBinaryPath, outputPath string, embeddedConfiguration EmbeddedConfiguration) error {
+	if embeddedConfiguration.ProviderName == "" {
+		return errors.New("the provider name must be specified")
+	}
+	if embeddedConfiguration.SwaggerURL == "" && len(embeddedConfiguration.Spec) == 0 {
+		return errors.New("either the swagger url or the spec must be specified")
+	}
+	source, err := ioutil.ReadFile(sourceBinaryPath) // #nosec G304
+	if err != nil {
+		return fmt.Errorf("failed to read the source binary '%s': %s", sourceBinaryPath, err)
+	}
+	if length, found := getEmbeddedConfigurationLength(source); found && length <= uint64(len(source)-embeddedConfigurationTrailerSize) {
+		source = source[:len(source)-embeddedConfigurationTrailerSize-int(length)]
+	}
+	payload, err := json.Marshal(embeddedConfiguration)
+	if err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	b.Write(source)
+	b.Write(payload)
+	length := make([]byte, 8)
+	binary.BigEndian.PutUint64(length, uint64(len(payload)))
+	b.Write(length)
+	b.WriteString(embeddedConfigurationMagic)
+	// #nosec G306 - the provider binary must be executable
+	if err := ioutil.WriteFile(outputPath, b.Bytes(), 0755); err != nil {
+		return fmt.Errorf("failed to write the provider binary '%s': %s", outputPath, err)
+	}
+	return nil
+}
+
+// ReadEmbeddedConfiguration returns the configuration embedded in the binary passed in. If the binary does not contain
+// an embedded configuration, nil is returned
+func ReadEmbeddedConfiguration(binaryPath string) (*EmbeddedConfiguration, error) {
+	f, err := os.Open(binaryPath) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < int64(embeddedConfigurationTrailerSize) {
+		return nil, nil
+	}
+	trailer := make([]byte, embeddedConfigurationTrailerSize)
+	if _, err := f.ReadAt(trailer, info.Size()-int64(embeddedConfigurationTrailerSize)); err != nil {
+		return nil, err
+	}
+	length, found := getEmbeddedConfigurationLength(trailer)
+	if !found {
+		return nil, nil
+	}
+	if length > uint64(info.Size()-int64(embeddedConfigurationTrailerSize)) {
+		return nil, fmt.Errorf("the embedded configuration in binary '%s' is corrupted", binaryPath)
+	}
+	payload := make([]byte, length)
+	if _, err := f.ReadAt(payload, info.Size()-int64(embeddedConfigurationTrailerSize)-int64(length)); err != nil && err != io.EOF {
+		return nil, err
+	}
+	embeddedConfiguration := &EmbeddedConfiguration{}
+	if err := json.Unmarshal(payload, embeddedConfiguration); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the embedded configuration in binary '%s': %s", binaryPath, err)
+	}
+	return embeddedConfiguration, nil
+}
+
+// getEmbeddedConfigurationLength returns the embedded configuration length stored in the trailer at the end of the content
+// passed in and whether the trailer was found
+func getEmbeddedConfigurationLength(content []byte) (uint64, bool) {
+	if len(content) < embeddedConfigurationTrailerSize || !bytes.HasSuffix(content, []byte(embeddedConfigurationMagic)) {
+		return 0, false
+	}
+	trailer := content[len(content)-embeddedConfigurationTrailerSize:]
+	return binary.BigEndian.Uint64(trailer[:8]), true
+}
+
+// getServiceConfiguration returns the service configuration for the embedded configuration. The embedded spec is held in
+// memory so the provider does not need a writable filesystem (e,g: Terraform Cloud agents running in read-only containers)
+func (e *EmbeddedConfiguration) getServiceConfiguration() (ServiceConfiguration, error) {
+	swaggerURL := e.SwaggerURL
+	if len(e.Spec) > 0 {
+		swaggerURL = registerInMemoryDocument(e.ProviderName, e.Spec)
+	}
+	serviceConfiguration := NewServiceConfigV1(swaggerURL, false, nil)
+	serviceConfiguration.SpecFormat = e.SpecFormat
+	if err := serviceConfiguration.Validate(); err != nil {
+		return nil, fmt.Errorf("embedded configuration for '%s' not valid: %s", e.ProviderName, err)
+	}
+	return serviceConfiguration, nil
+}
+
+// CreateSchemaProviderFromEmbeddedConfiguration creates the schema.Provider for the configuration embedded in self-contained
+// provider binaries
+func (p *ProviderOpenAPI) CreateSchemaProviderFromEmbeddedConfiguration(embeddedConfiguration *EmbeddedConfiguration) (*schema.Provider, error) {
+	serviceConfiguration, err := embeddedConfiguration.getServiceConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("plugin init error: %s", err)
+	}
+	if p.ProviderVersion == "" {
+		p.ProviderVersion = embeddedConfiguration.ProviderVersion
+	}
+	providerLog.Info("Provider %s is using the following embedded swagger file: %s", p.ProviderName, serviceConfiguration.GetSwaggerURL())
+	return p.CreateSchemaProviderFromServiceConfiguration(serviceConfiguration)
+}
+
+// CreateSchemaProviderFromSpec creates the schema.Provider for the OpenAPI document content passed in. This enables custom
+// provider builds to compile the OpenAPI document into the binary and run without having to retrieve it at runtime:
+//
+//	//go:embed openapi.yaml
+//	var spec []byte
+//
+//	p := openapi.ProviderOpenAPI{ProviderName: "foo", ProviderVersion: Version}
+//	provider, err := p.CreateSchemaProviderFromSpec(spec)
+func (p *ProviderOpenAPI) CreateSchemaProviderFromSpec(spec []byte) (*schema.Provider, error) {
+	if len(spec) == 0 {
+		return nil, errors.New("plugin init error: the OpenAPI document must not be empty")
+	}
+	return p.CreateSchemaProviderFromEmbeddedConfiguration(&EmbeddedConfiguration{ProviderName: p.ProviderName, Spec: spec})
+}
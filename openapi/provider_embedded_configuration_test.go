@@ -0,0 +1,138 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildProviderBinary(t *testing.T) {
+	dir, err := ioutil.TempDir("", "embedded_configuration")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sourceBinary := filepath.Join(dir, "terraform-provider-openapi")
+	require.NoError(t, ioutil.WriteFile(sourceBinary, []byte("some binary content"), 0600))
+
+	fooBinary := filepath.Join(dir, "terraform-provider-foo")
+	err = BuildProviderBinary(sourceBinary, fooBinary, EmbeddedConfiguration{ProviderName: "foo", Spec: []byte(`swagger: "2.0"`)})
+	require.NoError(t, err)
+	embeddedConfiguration, err := ReadEmbeddedConfiguration(fooBinary)
+	require.NoError(t, err)
+	assert.Equal(t, &EmbeddedConfiguration{ProviderName: "foo", Spec: []byte(`swagger: "2.0"`)}, embeddedConfiguration)
+
+	// building from an already self-contained binary replaces the embedded configuration
+	barBinary := filepath.Join(dir, "terraform-provider-bar")
+	err = BuildProviderBinary(fooBinary, barBinary, EmbeddedConfiguration{ProviderName: "bar", SwaggerURL: "https://api.bar.com/swagger.json", SpecFormat: "v2"})
+	require.NoError(t, err)
+	embeddedConfiguration, err = ReadEmbeddedConfiguration(barBinary)
+	require.NoError(t, err)
+	assert.Equal(t, &EmbeddedConfiguration{ProviderName: "bar", SwaggerURL: "https://api.bar.com/swagger.json", SpecFormat: "v2"}, embeddedConfiguration)
+	content, err := ioutil.ReadFile(barBinary)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "some binary content")
+	assert.NotContains(t, string(content), `"provider_name":"foo"`)
+}
+
+func TestBuildProviderBinaryErrors(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		embeddedConfiguration EmbeddedConfiguration
+		expectedError         string
+	}{
+		{name: "missing provider name", embeddedConfiguration: EmbeddedConfiguration{SwaggerURL: "https://api.foo.com/swagger.json"}, expectedError: "the provider name must be specified"},
+		{name: "missing spec and swagger url", embeddedConfiguration: EmbeddedConfiguration{ProviderName: "foo"}, expectedError: "either the swagger url or the spec must be specified"},
+		{name: "missing source binary", embeddedConfiguration: EmbeddedConfiguration{ProviderName: "foo", SwaggerURL: "https://api.foo.com/swagger.json"}, expectedError: "failed to read the source binary 'non-existing-binary': open non-existing-binary: no such file or directory"},
+	}
+	for _, tc := range testCases {
+		err := BuildProviderBinary("non-existing-binary", "terraform-provider-foo", tc.embeddedConfiguration)
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
+
+func TestReadEmbeddedConfigurationNotPresent(t *testing.T) {
+	file, err := ioutil.TempFile("", "terraform-provider-openapi")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.Write([]byte("some binary content without embedded configuration"))
+	require.NoError(t, err)
+	embeddedConfiguration, err := ReadEmbeddedConfiguration(file.Name())
+	assert.NoError(t, err)
+	assert.Nil(t, embeddedConfiguration)
+}
+
+func TestEmbeddedConfigurationGetServiceConfiguration(t *testing.T) {
+	embeddedConfiguration := &EmbeddedConfiguration{ProviderName: "foo", Spec: []byte(`swagger: "2.0"`), SpecFormat: "v2"}
+	serviceConfiguration, err := embeddedConfiguration.getServiceConfiguration()
+	require.NoError(t, err)
+	assert.Equal(t, specAnalyserV2, serviceConfiguration.GetSpecFormat())
+	// the embedded spec is held in memory so no file is written to disk
+	assert.True(t, strings.HasPrefix(serviceConfiguration.GetSwaggerURL(), inMemoryDocumentScheme))
+	spec, err := loadDocument(serviceConfiguration.GetSwaggerURL())
+	require.NoError(t, err)
+	assert.Equal(t, `swagger: "2.0"`, string(spec))
+
+	embeddedConfiguration = &EmbeddedConfiguration{ProviderName: "foo", SwaggerURL: "https://api.foo.com/swagger.json"}
+	serviceConfiguration, err = embeddedConfiguration.getServiceConfiguration()
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.foo.com/swagger.json", serviceConfiguration.GetSwaggerURL())
+}
+
+func TestCreateSchemaProviderFromSpec(t *testing.T) {
+	spec := []byte(`swagger: "2.0"
+host: localhost:8443
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: body
+        name: body
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        type: string
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+    delete:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        type: string
+      responses:
+        204:
+          description: deleted
+definitions:
+  ContentDeliveryNetwork:
+    type: object
+    properties:
+      id:
+        type: string
+        readOnly: true
+      label:
+        type: string`)
+	p := ProviderOpenAPI{ProviderName: "foo", ProviderVersion: "2.0.0"}
+	provider, err := p.CreateSchemaProviderFromSpec(spec)
+	require.NoError(t, err)
+	assert.Contains(t, provider.ResourcesMap, "foo_cdns_v1")
+	assert.Contains(t, provider.DataSourcesMap, "foo_provider_info")
+
+	p = ProviderOpenAPI{ProviderName: "foo"}
+	_, err = p.CreateSchemaProviderFromSpec(nil)
+	assert.EqualError(t, err, "plugin init error: the OpenAPI document must not be empty")
+}
@@ -1,17 +1,18 @@
 package openapi
 
 import (
+	"context"
 	"fmt"
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/version"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/terraformutils"
 
-	"log"
-
 	"github.com/dikhan/http_goclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -19,6 +20,14 @@ type providerFactory struct {
 	name                 string
 	specAnalyser         SpecAnalyser
 	serviceConfiguration ServiceConfiguration
+	// providerVersion contains the version of custom provider builds which is reported by the provider info data source
+	providerVersion string
+	// stateEncrypter is shared by all the resources and data sources to encrypt the properties with the
+	// x-terraform-encrypt-in-state extension; nil if the state encryption is not configured
+	stateEncrypter *stateEncrypter
+	// transport is the provider transport configured with the plugin TLS and dialer settings (see ProviderOpenAPI.newProviderTransport);
+	// nil if the provider factory was not created by the ProviderOpenAPI, in which case a clone of the default transport is used
+	transport *http.Transport
 }
 
 func newProviderFactory(name string, specAnalyser SpecAnalyser, serviceConfiguration ServiceConfiguration) (*providerFactory, error) {
@@ -38,6 +47,7 @@ func newProviderFactory(name string, specAnalyser SpecAnalyser, serviceConfigura
 		name:                 name,
 		specAnalyser:         specAnalyser,
 		serviceConfiguration: serviceConfiguration,
+		stateEncrypter:       newStateEncrypter(serviceConfiguration.GetStateEncryptionConfiguration()),
 	}, nil
 }
 
@@ -71,12 +81,23 @@ func (p providerFactory) createProvider() (*schema.Provider, error) {
 		dataSources[k] = v
 	}
 
+	if err = p.registerProviderInfoDataSource(dataSources, resourceMap); err != nil {
+		return nil, err
+	}
+
+	errorHints, err := openAPIBackendConfiguration.getErrorHints()
+	if err != nil {
+		return nil, err
+	}
+	errorHints.apply(resourceMap)
+	errorHints.apply(dataSources)
+
 	provider := &schema.Provider{
 		Schema:         providerSchema,
 		ResourcesMap:   resourceMap,
 		DataSourcesMap: dataSources,
-		ConfigureFunc:  p.configureProvider(openAPIBackendConfiguration, providerConfigurationEndPoints),
 	}
+	provider.ConfigureContextFunc = p.configureProvider(openAPIBackendConfiguration, providerConfigurationEndPoints, provider)
 	return provider, nil
 }
 
@@ -92,10 +113,11 @@ func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfigurati
 		return nil, err
 	}
 	if isMultiRegion {
-		log.Printf("[DEBUG] service provider is configured with multi-region. API calls will be made against %s and the region provided by the user (or the default value otherwise, being the first element of supported region list: %+v), unless overridden by specific resources", host, regions)
+		providerLog.Debug("service provider is configured with multi-region. API calls will be made against %s and the region provided by the user (or the default value otherwise, being the first element of supported region list: %+v), unless overridden by specific resources", host, regions)
 		if err := p.configureProviderProperty(s, providerPropertyRegion, regions[0], true, regions); err != nil {
 			return nil, err
 		}
+		s[providerPropertyRegion].Description = fmt.Sprintf("Region the API calls are made against, one of %s (defaults to %s)", strings.Join(regions, ", "), regions[0])
 	}
 
 	// Override security definitions to required if they are global security schemes
@@ -109,20 +131,114 @@ func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfigurati
 	if err != nil {
 		return nil, err
 	}
+	// Security definitions whose values can be read from Vault or obtained from the cloud instance metadata server are not
+	// required in the provider configuration
+	vaultConfigured := p.serviceConfiguration != nil && p.serviceConfiguration.GetVaultConfiguration() != nil
 	for _, securityDefinition := range *securityDefinitions {
 		secDefName := securityDefinition.GetTerraformConfigurationName()
+		// The oauth2 client credentials are exposed as two properties (client id and client secret) and can not be read from Vault
+		if oauth2SecurityDefinition, ok := securityDefinition.(specOAuth2ClientCredentialsSecurityDefinition); ok {
+			required := globalSecuritySchemes.securitySchemeExists(securityDefinition)
+			clientID, clientSecret := oauth2SecurityDefinition.getClientIDConfigurationName(), oauth2SecurityDefinition.getClientSecretConfigurationName()
+			p.configureProviderPropertyFromPluginConfig(s, clientID, required)
+			s[clientID].Description = fmt.Sprintf("Client id of the '%s' security definition the access tokens the API calls are authenticated with are requested for", securityDefinition.getName())
+			p.configureProviderPropertyFromPluginConfig(s, clientSecret, required)
+			s[clientSecret].Sensitive = true
+			s[clientSecret].Description = fmt.Sprintf("Client secret of the '%s' security definition the access tokens the API calls are authenticated with are requested for", securityDefinition.getName())
+			continue
+		}
 		required := false
-		if globalSecuritySchemes.securitySchemeExists(securityDefinition) {
+		if globalSecuritySchemes.securitySchemeExists(securityDefinition) && !vaultConfigured && securityDefinition.getType() != securityDefinitionAPIKeyCloudIdentity {
 			required = true
 		}
 		p.configureProviderPropertyFromPluginConfig(s, secDefName, required)
+		s[secDefName].Description = p.specAnalyser.GetSecurity().GetSecurityDefinitionDescription(securityDefinition.getName())
+		if s[secDefName].Description == "" {
+			s[secDefName].Description = fmt.Sprintf("Value of the '%s' security definition the API calls are authenticated with", securityDefinition.getName())
+		}
 	}
 
 	headers := p.specAnalyser.GetAllHeaderParameters()
-	log.Printf("[DEBUG] all header parameters: %+v", headers)
+	providerLog.Debug("all header parameters: %+v", headers)
 	for _, headerParam := range headers {
 		headerTerraformCompliantName := headerParam.GetHeaderTerraformConfigurationName()
 		p.configureProviderPropertyFromPluginConfig(s, headerTerraformCompliantName, false)
+		s[headerTerraformCompliantName].Description = headerParam.Description
+		if s[headerTerraformCompliantName].Description == "" {
+			s[headerTerraformCompliantName].Description = fmt.Sprintf("Value of the '%s' header sent in the API calls", headerParam.Name)
+		}
+	}
+
+	// The session login credentials are required since the API calls can not be authenticated otherwise
+	sessionLogin, err := openAPIBackendConfiguration.getSessionLogin()
+	if err != nil {
+		return nil, err
+	}
+	if sessionLogin != nil {
+		for field, credentialProperty := range sessionLogin.credentials {
+			p.configureProviderPropertyFromPluginConfig(s, credentialProperty, true)
+			s[credentialProperty].Sensitive = true
+			s[credentialProperty].Description = fmt.Sprintf("Credential sent in the '%s' field of the login request", field)
+		}
+	}
+
+	forceApplySupported, err := p.isForceApplySupported()
+	if err != nil {
+		return nil, err
+	}
+	if forceApplySupported {
+		s[providerPropertyForceApply] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to force the changes when the API refuses to apply fields owned by other field managers",
+		}
+	}
+
+	// The Accept-Language header is configured via the header property instead if the OpenAPI document defines it
+	if _, exists := s[providerPropertyAcceptLanguage]; !exists {
+		s[providerPropertyAcceptLanguage] = terraformutils.CreateStringSchemaProperty(providerPropertyAcceptLanguage, false, "")
+		s[providerPropertyAcceptLanguage].ValidateFunc = validateAcceptLanguage
+		s[providerPropertyAcceptLanguage].Description = "Languages the API messages (e,g: error messages) should be localized in, sent in the Accept-Language header (e,g: de-DE, de;q=0.9, en;q=0.8)"
+	}
+
+	mutualTLSProperties := map[string]string{
+		providerPropertyClientCertificate: "Client certificate (file path or PEM contents) presented to the API when mutual TLS is required",
+		providerPropertyClientKey:         "Private key (file path or PEM contents) of the client certificate presented to the API",
+		providerPropertyCACertificate:     "CA certificate (file path or PEM contents) the API server certificate is verified with, instead of the system CAs",
+	}
+	for propertyName, description := range mutualTLSProperties {
+		if _, exists := s[propertyName]; exists {
+			continue
+		}
+		s[propertyName] = terraformutils.CreateStringSchemaProperty(propertyName, false, "")
+		s[propertyName].Description = description
+	}
+	s[providerPropertyClientKey].Sensitive = true
+
+	if profiles := p.serviceConfiguration.GetProfileConfigurations(); len(profiles) > 0 {
+		var profileNames []string
+		for profileName := range profiles {
+			profileNames = append(profileNames, profileName)
+		}
+		sort.Strings(profileNames)
+		s[providerPropertyProfile] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: p.createValidateFunc(profileNames),
+			Description:  fmt.Sprintf("Profile of the service configuration the provider is configured with, one of %s", strings.Join(profileNames, ", ")),
+		}
+	}
+
+	featureFlags, err := openAPIBackendConfiguration.getFeatureFlags()
+	if err != nil {
+		return nil, err
+	}
+	if len(featureFlags) > 0 {
+		s[providerPropertyFeatures], err = featureFlagsSchema(featureFlags)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if providerConfigurationEndPoints != nil {
@@ -135,6 +251,21 @@ func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfigurati
 	return s, nil
 }
 
+// isForceApplySupported returns true if any of the resources supports force applying the changes when the API refuses to
+// apply fields owned by other field managers (x-terraform-force-apply-parameter)
+func (p providerFactory) isForceApplySupported() (bool, error) {
+	openAPIResources, err := p.specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return false, err
+	}
+	for _, openAPIResource := range openAPIResources {
+		if isForceApplySupported(openAPIResource) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // getResourceNames returns the resources exposed by the provider. The list of resources names returned will then be
 // used to create the provider's endpoint schema property as well as to configure the endpoints values with the data
 // provided bu the user
@@ -153,21 +284,21 @@ func (p providerFactory) configureProviderPropertyFromPluginConfig(providerSchem
 	if schemaPropertyConfiguration != nil {
 		err = schemaPropertyConfiguration.ExecuteCommand()
 		if err != nil {
-			log.Printf("[ERROR] %s", err)
+			providerLog.Error("%s", err)
 		}
 		defaultValue, err = schemaPropertyConfiguration.GetDefaultValue()
 		if err != nil {
-			log.Printf("[ERROR] %s", err)
+			providerLog.Error("%s", err)
 		}
 	}
 	providerSchema[schemaPropertyName] = terraformutils.CreateStringSchemaProperty(schemaPropertyName, required, defaultValue)
-	log.Printf("[DEBUG] registered new property '%s' (required=%t) into provider schema", schemaPropertyName, required)
+	providerLog.Debug("registered new property '%s' (required=%t) into provider schema", schemaPropertyName, required)
 }
 
 func (p providerFactory) configureProviderProperty(providerSchema map[string]*schema.Schema, schemaPropertyName string, defaultValue string, required bool, allowedValues []string) error {
 	providerSchema[schemaPropertyName] = terraformutils.CreateStringSchemaProperty(schemaPropertyName, required, defaultValue)
 	providerSchema[schemaPropertyName].ValidateFunc = p.createValidateFunc(allowedValues)
-	log.Printf("[DEBUG] registered new property '%s' into provider schema", schemaPropertyName)
+	providerLog.Debug("registered new property '%s' into provider schema", schemaPropertyName)
 	return nil
 }
 
@@ -190,26 +321,61 @@ func (p providerFactory) createTerraformProviderDataSourceMap() (map[string]*sch
 	dataSourceMap := map[string]*schema.Resource{}
 	openAPIDataResources := p.specAnalyser.GetTerraformCompliantDataSources()
 	for _, openAPIDataSource := range openAPIDataResources {
-		dataSourceName, err := p.getProviderResourceName(openAPIDataSource.GetResourceName())
+		dataSourceName, err := p.getProviderResourceName(GetResourceNameForNamingVersion(openAPIDataSource, p.getResourceNamingVersion()))
 		if err != nil {
 			return nil, err
 		}
 		start := time.Now()
 		d := newDataSourceFactory(openAPIDataSource)
+		d.stateEncrypter = p.stateEncrypter
 		dataSourceTFSchema, err := d.createTerraformDataSource()
 		if err != nil {
 			return nil, err
 		}
-		log.Printf("[INFO] data source '%s' successfully registered in the provider (time:%s)", dataSourceName, time.Since(start))
+		providerLog.Info("data source '%s' successfully registered in the provider (time:%s)", dataSourceName, time.Since(start))
 		dataSourceMap[dataSourceName] = dataSourceTFSchema
+
+		// Register count data source
+		countDataSourceName := dataSourceCountName(dataSourceName)
+		if _, alreadyThere := dataSourceMap[countDataSourceName]; alreadyThere {
+			providerLog.Warn("'%s' data source name is already in use, skipping registration of the count data source", countDataSourceName)
+			continue
+		}
+		countDataSourceTFSchema, err := newDataSourceCountFactory(d).createTerraformCountDataSource()
+		if err != nil {
+			return nil, err
+		}
+		providerLog.Info("data source '%s' successfully registered in the provider", countDataSourceName)
+		dataSourceMap[countDataSourceName] = countDataSourceTFSchema
 	}
 	return dataSourceMap, nil
 }
 
+// registerProviderInfoDataSource registers the built-in <provider_name>_provider_info data source that describes the provider
+// (OpenAPI document version and checksum, provider version, resources registered and effective host/region). If the
+// OpenAPI document already exposes a data source with the same name, the one from the document takes preference.
+func (p providerFactory) registerProviderInfoDataSource(dataSources map[string]*schema.Resource, resourceMap map[string]*schema.Resource) error {
+	dataSourceName, err := p.getProviderResourceName(dataSourceProviderInfoName)
+	if err != nil {
+		return err
+	}
+	if _, alreadyThere := dataSources[dataSourceName]; alreadyThere {
+		providerLog.Warn("'%s' data source name is already in use, skipping registration of the built-in provider info data source", dataSourceName)
+		return nil
+	}
+	d := newDataSourceProviderInfoFactory(p.specAnalyser.GetSpecInfo(), p.getResourceNames(resourceMap), p.providerVersion)
+	d.resourceNamingVersion = p.getResourceNamingVersion()
+	dataSources[dataSourceName] = d.createTerraformProviderInfoDataSource()
+	providerLog.Info("data source '%s' successfully registered in the provider", dataSourceName)
+	return nil
+}
+
 // createTerraformProviderResourceMapAndDataSourceInstanceMap is responsible for building the following:
 // - a map containing the resources that are terraform compatible
 // - a map containing the data sources from the resources that are terraform compatible. This data sources enable data
 //  source configuration on the resource instance GET operation.
+// - the options data sources of the resources with the x-terraform-options-path extension, which are included in the data
+//  source instance map.
 func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstanceMap() (resourceMap, dataSourceInstanceMap map[string]*schema.Resource, err error) {
 	resourceMap = map[string]*schema.Resource{}
 	dataSourceInstanceMap = map[string]*schema.Resource{}
@@ -220,24 +386,31 @@ func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstance
 	for _, openAPIResource := range openAPIResources {
 		start := time.Now()
 
-		resourceName, err := p.getProviderResourceName(openAPIResource.GetResourceName())
+		namingVersionResourceName := GetResourceNameForNamingVersion(openAPIResource, p.getResourceNamingVersion())
+		resourceName, err := p.getProviderResourceName(namingVersionResourceName)
 		if err != nil {
 			return nil, nil, err
 		}
 
 		if openAPIResource.ShouldIgnoreResource() {
-			log.Printf("[WARN] '%s' is marked to be ignored and therefore skipping resource registration into the provider", openAPIResource.GetResourceName())
+			providerLog.Warn("'%s' is marked to be ignored and therefore skipping resource registration into the provider", openAPIResource.GetResourceName())
 			continue
 		}
 
 		r := newResourceFactory(openAPIResource)
+		r.stateEncrypter = p.stateEncrypter
+		r.verifyWrites = p.serviceConfiguration.IsVerifyWritesEnabled()
 		d := newDataSourceInstanceFactory(openAPIResource)
-		fullDataSourceInstanceName, _ := p.getProviderResourceName(d.getDataSourceInstanceName())
+		d.stateEncrypter = p.stateEncrypter
+		fullDataSourceInstanceName, _ := p.getProviderResourceName(dataSourceInstanceName(namingVersionResourceName))
+		o := newDataSourceOptionsFactory(openAPIResource)
+		fullDataSourceOptionsName, _ := p.getProviderResourceName(dataSourceOptionsName(namingVersionResourceName))
 
 		if _, alreadyThere := resourceMap[resourceName]; alreadyThere {
-			log.Printf("[WARN] '%s' is a duplicate resource name and is being removed from the provider", openAPIResource.GetResourceName())
+			providerLog.Warn("'%s' is a duplicate resource name and is being removed from the provider", openAPIResource.GetResourceName())
 			delete(resourceMap, resourceName)
 			delete(dataSourceInstanceMap, fullDataSourceInstanceName)
+			delete(dataSourceInstanceMap, fullDataSourceOptionsName)
 			continue
 		}
 
@@ -246,28 +419,73 @@ func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstance
 		if err != nil {
 			return nil, nil, err
 		}
-		log.Printf("[INFO] resource '%s' successfully registered in the provider (time:%s)", resourceName, time.Since(start))
+		providerLog.Info("resource '%s' successfully registered in the provider (time:%s)", resourceName, time.Since(start))
 		resourceMap[resourceName] = resource
 
 		// Register data source instance
 		dataSourceInstance, _ := d.createTerraformInstanceDataSource() // if createTerraformResource did not throw an error, it's assumed that the data source instance would work too considering it's subset of the resource
-		log.Printf("[INFO] data source instance '%s' successfully registered in the provider (time:%s)", fullDataSourceInstanceName, time.Since(start))
+		providerLog.Info("data source instance '%s' successfully registered in the provider (time:%s)", fullDataSourceInstanceName, time.Since(start))
 		dataSourceInstanceMap[fullDataSourceInstanceName] = dataSourceInstance
+
+		// Register options data source
+		if o.getOptionsPath() != "" {
+			dataSourceOptions, err := o.createTerraformOptionsDataSource()
+			if err != nil {
+				return nil, nil, err
+			}
+			providerLog.Info("data source options '%s' successfully registered in the provider (time:%s)", fullDataSourceOptionsName, time.Since(start))
+			dataSourceInstanceMap[fullDataSourceOptionsName] = dataSourceOptions
+		}
 	}
 	return resourceMap, dataSourceInstanceMap, nil
 }
 
-func (p providerFactory) configureProvider(openAPIBackendConfiguration SpecBackendConfiguration, providerConfigurationEndPoints *providerConfigurationEndPoints) schema.ConfigureFunc {
-	return func(data *schema.ResourceData) (interface{}, error) {
+// configureProvider returns the function that configures the provider client. The provider passed in is used to read the
+// Terraform version which the SDK populates before calling the configure function
+func (p providerFactory) configureProvider(openAPIBackendConfiguration SpecBackendConfiguration, providerConfigurationEndPoints *providerConfigurationEndPoints, provider *schema.Provider) schema.ConfigureContextFunc {
+	return func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		globalSecuritySchemes, err := p.specAnalyser.GetSecurity().GetGlobalSecuritySchemes()
 		if err != nil {
-			return nil, err
+			return nil, diag.FromErr(err)
 		}
 		authenticator := newAPIAuthenticator(&globalSecuritySchemes)
 		config, err := p.createProviderConfig(data, providerConfigurationEndPoints)
 		if err != nil {
-			return nil, err
+			return nil, diag.FromErr(err)
+		}
+		featureFlags, err := openAPIBackendConfiguration.getFeatureFlags()
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		config.FeatureFlags, err = readFeatureFlags(featureFlags, data)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		middlewares, err := newMiddlewareChain(p.serviceConfiguration.GetMiddlewareConfiguration(), config.getFeatureFlags())
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		userAgentTemplateData := newUserAgentTemplateData(p.name, p.providerVersion, provider.TerraformVersion)
+		userAgentTemplateData.Features = config.getFeatureFlags()
+		tlsConfig, err := p.serviceConfiguration.GetTLSConfiguration().newTLSClientConfig()
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		transport := p.getTransport()
+		if config.isMutualTLSConfigured() {
+			transport = transport.Clone()
+			// the plugin TLS configuration (min version and cipher suites) is the base so the restrictions are kept along with mTLS
+			tlsConfig, err = newMutualTLSConfig(tlsConfig, config.ClientCertificate, config.ClientKey, config.CACertificate)
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+			if p.serviceConfiguration.IsInsecureSkipVerifyEnabled() {
+				// #nosec G402
+				tlsConfig.InsecureSkipVerify = true
+			}
+			transport.TLSClientConfig = tlsConfig
 		}
+		httpClient := &http.Client{Transport: transport}
 		telemetryHandler := p.GetTelemetryHandler(data)
 		if telemetryHandler != nil {
 			telemetryHandler.SubmitPluginExecutionMetrics()
@@ -275,12 +493,80 @@ func (p providerFactory) configureProvider(openAPIBackendConfiguration SpecBacke
 		openAPIClient := &ProviderClient{
 			openAPIBackendConfiguration: openAPIBackendConfiguration,
 			apiAuthenticator:            authenticator,
-			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: httpClient},
 			providerConfiguration:       *config,
 			telemetryHandler:            telemetryHandler,
+			requestCompression:          p.serviceConfiguration.GetRequestCompressionConfiguration(),
+			maxResponseBodySize:         p.serviceConfiguration.GetMaxResponseBodySize(),
+			middlewares:                 middlewares,
+			userAgent:                   p.serviceConfiguration.GetUserAgent(),
+			userAgentTemplateData:       userAgentTemplateData,
+			identificationHeaders:       p.serviceConfiguration.GetIdentificationHeaders(),
+			tlsConfig:                   tlsConfig,
+			dialContext:                 p.serviceConfiguration.GetDialerConfiguration().newDialContext(),
+			consistencyTokens:           newConsistencyTokens(),
+			rateLimitRetry:              newRateLimitRetry(p.serviceConfiguration.GetRateLimitRetryConfiguration()),
+		}
+		if serviceDiscoveryConfig := p.serviceConfiguration.GetServiceDiscoveryConfiguration(); serviceDiscoveryConfig != nil {
+			openAPIClient.srvHostResolver = newSRVHostResolver(serviceDiscoveryConfig, p.serviceConfiguration.GetDialerConfiguration().newResolver())
+			if _, err := openAPIClient.srvHostResolver.getHost(); err != nil {
+				return nil, diag.FromErr(err)
+			}
+		}
+		if host, _, err := openAPIClient.GetProviderHost(); err == nil {
+			openAPIClient.hostFailover = newHostFailover(host, p.serviceConfiguration.GetFailoverConfiguration(), openAPIBackendConfiguration)
+			if openAPIClient.hostFailover != nil {
+				providerLog.Info("API calls made against '%s' will fail over to the following hosts if not available: %s", host, strings.Join(openAPIClient.hostFailover.hosts[1:], ", "))
+			}
+		}
+		sessionLogin, err := openAPIBackendConfiguration.getSessionLogin()
+		if err != nil {
+			return nil, diag.FromErr(err)
 		}
-		return openAPIClient, nil
+		if sessionLogin != nil {
+			credentials := map[string]string{}
+			for field, credentialProperty := range sessionLogin.credentials {
+				credentials[field], _ = data.Get(credentialProperty).(string)
+			}
+			openAPIClient.session = newAPISession(sessionLogin, func() (string, error) { return openAPIClient.getEndpointURL(sessionLogin.path) }, credentials)
+			openAPIClient.session.httpClient.Transport = transport
+		}
+		var diags diag.Diagnostics
+		if err := p.checkSpecVersionSkew(openAPIClient); err != nil {
+			providerLog.Warn("%s", err)
+			diags = append(diags, diag.Diagnostic{Severity: diag.Warning, Summary: "OpenAPI document version check", Detail: err.Error()})
+		}
+		var client ClientOpenAPI = openAPIClient
+		if backendConfig := p.serviceConfiguration.GetBackendConfiguration(); backendConfig != nil && backendConfig.Type == backendTypeGRPC {
+			grpcClient, err := newGRPCClient(openAPIClient, backendConfig)
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+			client = grpcClient
+		}
+		if auditLogConfig := p.serviceConfiguration.GetAuditLogConfiguration(); auditLogConfig != nil {
+			providerLog.Info("mutating API calls will be recorded in the audit log '%s'", auditLogConfig.Path)
+			client = &auditLogClient{ClientOpenAPI: client, auditLog: newAuditLog(auditLogConfig)}
+		}
+		if webhooks := p.serviceConfiguration.GetWebhookConfigurations(); len(webhooks) > 0 {
+			providerLog.Info("the resources created, updated and deleted will be notified to %d webhook(s)", len(webhooks))
+			client = &webhookClient{ClientOpenAPI: client, notifier: newWebhookNotifier(webhooks, p.name, p.getResourceNamingVersion())}
+		}
+		if healthCheckConfig := p.serviceConfiguration.GetHealthCheckConfiguration(); healthCheckConfig != nil {
+			providerLog.Info("mutating API calls will be refused while the health endpoint '%s' reports a blocking status", healthCheckConfig.Path)
+			healthURL := func() (string, error) { return openAPIClient.getEndpointURL(healthCheckConfig.Path) }
+			client = &healthGatedClient{ClientOpenAPI: client, healthCheck: newHealthCheck(healthCheckConfig, healthURL, httpClient.Transport)}
+		}
+		return client, diags
+	}
+}
+
+// getTransport returns the transport the provider API calls are performed with
+func (p providerFactory) getTransport() *http.Transport {
+	if p.transport != nil {
+		return p.transport
 	}
+	return http.DefaultTransport.(*http.Transport).Clone()
 }
 
 // GetTelemetryHandler returns a handler containing validated telemetry providers
@@ -289,7 +575,7 @@ func (p providerFactory) GetTelemetryHandler(data *schema.ResourceData) Telemetr
 	if telemetryProvider != nil {
 		err := telemetryProvider.Validate()
 		if err != nil {
-			log.Printf("[WARN] telemetry validation failed: %s, ignoring telemetry", err)
+			providerLog.Warn("telemetry validation failed: %s, ignoring telemetry", err)
 			return nil
 		}
 	}
@@ -307,13 +593,62 @@ func (p providerFactory) GetTelemetryHandler(data *schema.ResourceData) Telemetr
 // - Security definition values that might be required by API operations (or globally)
 // configuration mapped to the corresponding
 func (p providerFactory) createProviderConfig(data *schema.ResourceData, providerConfigurationEndPoints *providerConfigurationEndPoints) (*providerConfiguration, error) {
-	providerConfiguration, err := newProviderConfiguration(p.specAnalyser, data, providerConfigurationEndPoints)
+	profile, err := p.getProviderProfile(data)
+	if err != nil {
+		return nil, err
+	}
+	var vault *vaultCredentials
+	if p.serviceConfiguration != nil {
+		if vault, err = newVaultCredentials(p.serviceConfiguration.GetVaultConfiguration()); err != nil {
+			return nil, err
+		}
+	}
+	providerConfiguration, err := newProviderConfiguration(p.specAnalyser, data, providerConfigurationEndPoints, profile, vault)
 	if err != nil {
 		return nil, err
 	}
 	return providerConfiguration, nil
 }
 
+// getProviderProfile returns the configuration of the service profile selected in the provider configuration (profile
+// property), falling back to the profile selected when the plugin configuration was loaded. Nil is returned if no profile
+// is selected. Since the provider schema is built out of the swagger file before the provider configuration is read, the
+// profile property can not select a profile with a different swagger URL
+func (p providerFactory) getProviderProfile(data *schema.ResourceData) (*ServiceProfileConfig, error) {
+	if p.serviceConfiguration == nil {
+		return nil, nil
+	}
+	profiles := p.serviceConfiguration.GetProfileConfigurations()
+	if len(profiles) == 0 {
+		return nil, nil
+	}
+	selectedProfile := p.serviceConfiguration.GetSelectedProfile()
+	profileName := selectedProfile
+	if value, exists := data.GetOk(providerPropertyProfile); exists {
+		profileName = value.(string)
+	}
+	if profileName == "" {
+		return nil, nil
+	}
+	profile, exists := profiles[profileName]
+	if !exists || profile == nil {
+		return nil, fmt.Errorf("profile '%s' not found in the service profiles configuration", profileName)
+	}
+	if profileName != selectedProfile && profile.SwaggerURL != "" && profile.SwaggerURL != p.serviceConfiguration.GetSwaggerURL() {
+		return nil, fmt.Errorf("profile '%s' can not be selected in the provider configuration since its swagger-url ('%s') differs from the one the provider was loaded with ('%s'), please select the profile exporting the %s environment variable instead", profileName, profile.SwaggerURL, p.serviceConfiguration.GetSwaggerURL(), fmt.Sprintf(otfVarProfile, p.name))
+	}
+	providerLog.Info("service profile '%s' selected", profileName)
+	return profile, nil
+}
+
+// getResourceNamingVersion returns the version of the naming algorithm the resource and data source names are built with
+func (p providerFactory) getResourceNamingVersion() int {
+	if p.serviceConfiguration == nil {
+		return CurrentResourceNamingVersion
+	}
+	return p.serviceConfiguration.GetResourceNamingVersion()
+}
+
 func (p providerFactory) getProviderResourceName(resourceName string) (string, error) {
 	if resourceName == "" {
 		return "", fmt.Errorf("resource name can not be empty")
@@ -2,12 +2,18 @@ package openapi
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"github.com/dikhan/http_goclient"
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/version"
+	"github.com/go-openapi/spec"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -653,12 +659,12 @@ func TestConfigureProvider(t *testing.T) {
 		testProviderSchema := newTestSchema(apiKeyAuthProperty, headerProperty)
 		Convey("When configureProvider is called with a backend that is not multi-region and the returned configureFunc is invoked upon ", func() {
 			backendConfig := &specStubBackendConfiguration{}
-			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{})
-			client, err := configureFunc(testProviderSchema.getResourceData(t))
+			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{}, &schema.Provider{})
+			client, diags := configureFunc(context.Background(), testProviderSchema.getResourceData(t))
 			providerClient := client.(*ProviderClient)
 			Convey("And the client should implement ClientOpenAPI interface and the telemetry server should have been received the expected counter metrics increase", func() {
 				var _ ClientOpenAPI = providerClient
-				So(err, ShouldBeNil)
+				So(diags, ShouldBeEmpty)
 				assertExpectedMetric(t, metricChannel, "openapi.terraform.openapi_plugin_version.total_runs:1|c|#openapi_plugin_version:dev")
 			})
 		})
@@ -708,17 +714,17 @@ func TestConfigureProvider(t *testing.T) {
 		testProviderSchema := newTestSchema(apiKeyAuthProperty, headerProperty)
 		Convey("When configureProvider is called with a backend that is not multi-region and the returned configureFunc is invoked upon ", func() {
 			backendConfig := &specStubBackendConfiguration{}
-			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{})
-			client, err := configureFunc(testProviderSchema.getResourceData(t))
+			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{}, &schema.Provider{})
+			client, diags := configureFunc(context.Background(), testProviderSchema.getResourceData(t))
 			providerClient := client.(*ProviderClient)
 			Convey("And the client should implement ClientOpenAPI interface and the http_endpoint telemetry server should have been received the expected counter metrics increase", func() {
-				So(err, ShouldBeNil)
+				So(diags, ShouldBeEmpty)
 				var _ ClientOpenAPI = providerClient
 				So(httpMetricsSubmitted, ShouldBeTrue)
 				So(headersReceived.Get("header_name"), ShouldEqual, "someHeaderValue")
 
 				tm := telemetryMetric{}
-				err = json.Unmarshal(metricsReceived, &tm)
+				err := json.Unmarshal(metricsReceived, &tm)
 				So(err, ShouldBeNil)
 				So(tm.MetricType, ShouldEqual, metricTypeCounter)
 				So(tm.MetricName, ShouldEqual, "openapi.terraform.openapi_plugin_version.total_runs")
@@ -850,6 +856,275 @@ func TestCreateProviderConfig(t *testing.T) {
 
 }
 
+func TestCreateProviderConfigWithProfile(t *testing.T) {
+	t.Setenv("OTF_TEST_DEV_API_KEY", "devAPIKey")
+	t.Setenv("OTF_TEST_STAGING_API_KEY", "stagingAPIKey")
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			security: &specSecurityStub{
+				securityDefinitions: &SpecSecurityDefinitions{
+					newAPIKeyHeaderSecurityDefinition("apikey_auth", authorizationHeader),
+				},
+				globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{
+			SwaggerURL: "http://host.com/swagger.json",
+			Profiles: map[string]*ServiceProfileConfig{
+				"dev":     {Host: "dev.host.com", AuthEnvVars: map[string]string{"apikey_auth": "OTF_TEST_DEV_API_KEY"}},
+				"staging": {SwaggerURL: "http://host.com/swagger.json", Host: "staging.host.com", AuthEnvVars: map[string]string{"apikey_auth": "OTF_TEST_STAGING_API_KEY"}},
+				"prod":    {SwaggerURL: "http://prod.host.com/swagger.json", Host: "prod.host.com"},
+			},
+			SelectedProfile: "dev",
+		},
+	}
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	require.Contains(t, providerSchema, providerPropertyProfile)
+	assert.True(t, providerSchema[providerPropertyProfile].Optional)
+	_, errs := providerSchema[providerPropertyProfile].ValidateFunc("qa", providerPropertyProfile)
+	assert.EqualError(t, errs[0], "property profile value qa is not valid, please make sure the value is one of [dev prod staging]")
+
+	testCases := []struct {
+		name           string
+		config         map[string]interface{}
+		expectedHost   string
+		expectedAPIKey string
+		expectedError  string
+	}{
+		{
+			name:           "profile selected when the plugin configuration was loaded",
+			config:         map[string]interface{}{},
+			expectedHost:   "dev.host.com",
+			expectedAPIKey: "devAPIKey",
+		},
+		{
+			name:           "profile selected in the provider configuration",
+			config:         map[string]interface{}{providerPropertyProfile: "staging"},
+			expectedHost:   "staging.host.com",
+			expectedAPIKey: "stagingAPIKey",
+		},
+		{
+			name:           "api key provided in the provider configuration takes preference over the profile auth env var",
+			config:         map[string]interface{}{providerPropertyProfile: "staging", "apikey_auth": "someAPIKey"},
+			expectedHost:   "staging.host.com",
+			expectedAPIKey: "someAPIKey",
+		},
+		{
+			name:          "profile selected in the provider configuration with a different swagger URL",
+			config:        map[string]interface{}{providerPropertyProfile: "prod"},
+			expectedError: "profile 'prod' can not be selected in the provider configuration since its swagger-url ('http://prod.host.com/swagger.json') differs from the one the provider was loaded with ('http://host.com/swagger.json'), please select the profile exporting the OTF_VAR_provider_PROFILE environment variable instead",
+		},
+	}
+	for _, tc := range testCases {
+		data := schema.TestResourceDataRaw(t, providerSchema, tc.config)
+		providerConfiguration, err := p.createProviderConfig(data, nil)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedHost, providerConfiguration.getHost(), tc.name)
+		assert.Equal(t, tc.expectedAPIKey, providerConfiguration.SecuritySchemaDefinitions["apikey_auth"].getContext().(apiKey).value, tc.name)
+	}
+}
+
+func TestCreateTerraformProviderSchemaForceApply(t *testing.T) {
+	newProviderFactory := func(resources ...SpecResource) providerFactory {
+		return providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				resources: resources,
+				security: &specSecurityStub{
+					securityDefinitions:   &SpecSecurityDefinitions{},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+				},
+			},
+			serviceConfiguration: &ServiceConfigStub{},
+		}
+	}
+	p := newProviderFactory(&specStubResource{name: "cdn"})
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, providerSchema, providerPropertyForceApply)
+
+	p = newProviderFactory(&specStubResource{name: "cdn"}, &specStubResource{name: "deployment", resourcePutOperation: &specResourceOperation{forceApplyParameter: "force"}})
+	providerSchema, err = p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	require.Contains(t, providerSchema, providerPropertyForceApply)
+	assert.Equal(t, schema.TypeBool, providerSchema[providerPropertyForceApply].Type)
+	assert.True(t, providerSchema[providerPropertyForceApply].Optional)
+
+	providerConfiguration, err := p.createProviderConfig(schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{providerPropertyForceApply: true}), nil)
+	require.NoError(t, err)
+	assert.True(t, providerConfiguration.isForceApplyEnabled())
+}
+
+func TestCreateTerraformProviderSchemaOAuth2ClientCredentials(t *testing.T) {
+	oauth2SecurityDefinition := newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", "https://auth.example.com/oauth2/token")
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			security: &specSecurityStub{
+				securityDefinitions:   &SpecSecurityDefinitions{oauth2SecurityDefinition},
+				globalSecuritySchemes: createSecuritySchemes([]map[string][]string{{"oauth2_auth": []string{}}}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{},
+	}
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, providerSchema, "oauth2_auth")
+	require.Contains(t, providerSchema, "oauth2_auth_client_id")
+	assert.True(t, providerSchema["oauth2_auth_client_id"].Required)
+	assert.False(t, providerSchema["oauth2_auth_client_id"].Sensitive)
+	require.Contains(t, providerSchema, "oauth2_auth_client_secret")
+	assert.True(t, providerSchema["oauth2_auth_client_secret"].Required)
+	assert.True(t, providerSchema["oauth2_auth_client_secret"].Sensitive)
+
+	providerConfiguration, err := p.createProviderConfig(schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{"oauth2_auth_client_id": "client_id", "oauth2_auth_client_secret": "client_secret"}), nil)
+	require.NoError(t, err)
+	authenticator, ok := providerConfiguration.SecuritySchemaDefinitions["oauth2_auth"].(apiOAuth2ClientCredentialsAuthenticator)
+	require.True(t, ok)
+	assert.Equal(t, "client_id", authenticator.clientID)
+	assert.Equal(t, "client_secret", authenticator.clientSecret)
+	assert.Equal(t, "https://auth.example.com/oauth2/token", authenticator.tokenURL)
+}
+
+func TestCreateTerraformProviderSchemaAcceptLanguage(t *testing.T) {
+	newProviderFactory := func(headers SpecHeaderParameters) providerFactory {
+		return providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				headers: headers,
+				security: &specSecurityStub{
+					securityDefinitions:   &SpecSecurityDefinitions{},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+				},
+			},
+			serviceConfiguration: &ServiceConfigStub{},
+		}
+	}
+	p := newProviderFactory(nil)
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	require.Contains(t, providerSchema, providerPropertyAcceptLanguage)
+	assert.Equal(t, schema.TypeString, providerSchema[providerPropertyAcceptLanguage].Type)
+	assert.True(t, providerSchema[providerPropertyAcceptLanguage].Optional)
+	assert.NotNil(t, providerSchema[providerPropertyAcceptLanguage].ValidateFunc)
+
+	providerConfiguration, err := p.createProviderConfig(schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{providerPropertyAcceptLanguage: "de-DE, de;q=0.9"}), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "de-DE, de;q=0.9", providerConfiguration.getAcceptLanguage())
+
+	p = newProviderFactory(SpecHeaderParameters{SpecHeaderParam{Name: "Accept-Language", TerraformName: providerPropertyAcceptLanguage, Description: "Language of the API messages"}})
+	providerSchema, err = p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	require.Contains(t, providerSchema, providerPropertyAcceptLanguage)
+	assert.Equal(t, "Language of the API messages", providerSchema[providerPropertyAcceptLanguage].Description, "the header property should be kept if the OpenAPI document defines the Accept-Language header")
+	assert.Nil(t, providerSchema[providerPropertyAcceptLanguage].ValidateFunc)
+}
+
+func TestCreateTerraformProviderSchemaMutualTLS(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			security: &specSecurityStub{
+				securityDefinitions:   &SpecSecurityDefinitions{},
+				globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{},
+	}
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	for _, propertyName := range []string{providerPropertyClientCertificate, providerPropertyClientKey, providerPropertyCACertificate} {
+		require.Contains(t, providerSchema, propertyName)
+		assert.Equal(t, schema.TypeString, providerSchema[propertyName].Type)
+		assert.True(t, providerSchema[propertyName].Optional)
+	}
+	assert.True(t, providerSchema[providerPropertyClientKey].Sensitive)
+
+	providerConfiguration, err := p.createProviderConfig(schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{}), nil)
+	require.NoError(t, err)
+	assert.False(t, providerConfiguration.isMutualTLSConfigured())
+
+	providerConfiguration, err = p.createProviderConfig(schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{providerPropertyClientCertificate: "client.crt", providerPropertyClientKey: "client.key", providerPropertyCACertificate: "ca.crt"}), nil)
+	require.NoError(t, err)
+	assert.True(t, providerConfiguration.isMutualTLSConfigured())
+	assert.Equal(t, "client.crt", providerConfiguration.ClientCertificate)
+	assert.Equal(t, "client.key", providerConfiguration.ClientKey)
+	assert.Equal(t, "ca.crt", providerConfiguration.CACertificate)
+}
+
+func TestConfigureProviderMutualTLSKeepsTLSConfiguration(t *testing.T) {
+	caCertificate, _ := newTestCertificate(t, "ca", nil, nil)
+	caCertificatePEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCertificate.Raw}))
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			security: &specSecurityStub{
+				securityDefinitions:   &SpecSecurityDefinitions{},
+				globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{
+			TLS: &TLSConfig{MinVersion: "1.3", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+		},
+	}
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	configureFunc := p.configureProvider(&specStubBackendConfiguration{}, nil, &schema.Provider{})
+	client, diags := configureFunc(context.Background(), schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{providerPropertyCACertificate: caCertificatePEM}))
+	require.Empty(t, diags)
+
+	providerClient := client.(*ProviderClient)
+	require.NotNil(t, providerClient.tlsConfig)
+	assert.NotNil(t, providerClient.tlsConfig.RootCAs)
+	assert.Equal(t, uint16(tls.VersionTLS13), providerClient.tlsConfig.MinVersion, "the min version should be kept along with mTLS")
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384}, providerClient.tlsConfig.CipherSuites, "the cipher suites should be kept along with mTLS")
+	transport := providerClient.httpClient.(*http_goclient.HttpClient).HttpClient.Transport.(*http.Transport)
+	assert.Equal(t, providerClient.tlsConfig, transport.TLSClientConfig)
+}
+
+func TestCreateTerraformProviderSchemaFeatureFlags(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			security: &specSecurityStub{
+				securityDefinitions:   &SpecSecurityDefinitions{},
+				globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{},
+	}
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, providerSchema, providerPropertyFeatures)
+
+	backendConfiguration := &specStubBackendConfiguration{
+		featureFlags: []*SpecSchemaDefinitionProperty{
+			{Name: "newBilling", Type: TypeBool, Default: false},
+			{Name: "maxNodes", Type: TypeInt, Default: 10.0},
+		},
+	}
+	providerSchema, err = p.createTerraformProviderSchema(backendConfiguration, nil)
+	require.NoError(t, err)
+	require.Contains(t, providerSchema, providerPropertyFeatures)
+	assert.Equal(t, schema.TypeList, providerSchema[providerPropertyFeatures].Type)
+	assert.Equal(t, 1, providerSchema[providerPropertyFeatures].MaxItems)
+	featuresSchema := providerSchema[providerPropertyFeatures].Elem.(*schema.Resource).Schema
+	assert.Equal(t, schema.TypeBool, featuresSchema["new_billing"].Type)
+	assert.Equal(t, false, featuresSchema["new_billing"].Default)
+	assert.Equal(t, schema.TypeInt, featuresSchema["max_nodes"].Type)
+	assert.Equal(t, 10, featuresSchema["max_nodes"].Default)
+
+	backendConfiguration.featureFlagsErr = errors.New("feature flags error")
+	_, err = p.createTerraformProviderSchema(backendConfiguration, nil)
+	assert.EqualError(t, err, "feature flags error")
+}
+
 func TestGetProviderResourceName(t *testing.T) {
 	Convey("Given a provider factory", t, func() {
 		p := providerFactory{
@@ -948,6 +1223,42 @@ func TestCreateTerraformProviderResourceMapAndDataSourceInstanceMap(t *testing.T
 	})
 }
 
+func TestCreateTerraformProviderResourceMapAndDataSourceInstanceMapResourceNamingVersion(t *testing.T) {
+	resource, err := newSpecV2Resource("/v1/cdn-edges", spec.Schema{}, spec.PathItem{}, spec.PathItem{}, nil, map[string]spec.PathItem{})
+	require.NoError(t, err)
+	resource.specSchemaDefinitionCached = &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{idProperty}}
+	testCases := []struct {
+		name                   string
+		serviceConfiguration   ServiceConfiguration
+		expectedResourceName   string
+		expectedDataSourceName string
+	}{
+		{
+			name:                   "current naming version",
+			serviceConfiguration:   &ServiceConfigStub{},
+			expectedResourceName:   "provider_cdn_edges",
+			expectedDataSourceName: "provider_cdn_edges_instance",
+		},
+		{
+			name:                   "naming version pinned to version 1",
+			serviceConfiguration:   &ServiceConfigStub{ResourceNamingVersion: resourceNamingV1},
+			expectedResourceName:   "provider_cdn-edges_v1",
+			expectedDataSourceName: "provider_cdn-edges_v1_instance",
+		},
+	}
+	for _, tc := range testCases {
+		p := providerFactory{
+			name:                 "provider",
+			specAnalyser:         &specAnalyserStub{resources: []SpecResource{resource}},
+			serviceConfiguration: tc.serviceConfiguration,
+		}
+		resourceMap, dataSourceMap, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+		require.NoError(t, err, tc.name)
+		assert.Contains(t, resourceMap, tc.expectedResourceName, tc.name)
+		assert.Contains(t, dataSourceMap, tc.expectedDataSourceName, tc.name)
+	}
+}
+
 func TestCreateTerraformProviderDataSourceInstanceMap_ignore_resource(t *testing.T) {
 	p := providerFactory{
 		name: "provider",
@@ -1039,6 +1350,28 @@ func TestCreateTerraformProviderDataSourceMap(t *testing.T) {
 	})
 }
 
+func TestRegisterProviderInfoDataSource(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			specInfo: SpecInfo{Version: "1.0.0", Checksum: "checksum"},
+		},
+	}
+	resourceMap := map[string]*schema.Resource{"provider_cdn_v1": {}}
+
+	dataSources := map[string]*schema.Resource{}
+	err := p.registerProviderInfoDataSource(dataSources, resourceMap)
+	assert.NoError(t, err)
+	assert.Contains(t, dataSources, "provider_provider_info")
+	assert.Contains(t, dataSources["provider_provider_info"].Schema, dataSourceProviderInfoResourcesProperty)
+
+	existingDataSource := &schema.Resource{}
+	dataSources = map[string]*schema.Resource{"provider_provider_info": existingDataSource}
+	err = p.registerProviderInfoDataSource(dataSources, resourceMap)
+	assert.NoError(t, err)
+	assert.Equal(t, existingDataSource, dataSources["provider_provider_info"], "data sources exposed by the OpenAPI document take preference")
+}
+
 func TestGetTelemetryHandler(t *testing.T) {
 	Convey("Given a providerFactory configured with a telemetry provider", t, func() {
 		expectedTelemetryProvider := &TelemetryProviderHTTPEndpoint{
@@ -1107,3 +1440,116 @@ func assertExpectedMetricAndLogging(t *testing.T, metricChannel chan string, exp
 		t.Fatalf("[FAIL] '%s' not reveided within the expected timeframe (timed out)", expectedMetric)
 	}
 }
+
+func TestCreateTerraformProviderResourceMapAndDataSourceInstanceMapOptions(t *testing.T) {
+	optionsResource := newSpecStubResourceWithOperations("cluster", "/v1/clusters", false, &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{idProperty}}, &specResourceOperation{optionsPath: "/v1/clusters/options"}, nil, nil, nil)
+	resource := newSpecStubResource("cdn", "/v1/cdns", false, &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{idProperty}})
+	p := providerFactory{
+		name:                 "provider",
+		specAnalyser:         &specAnalyserStub{resources: []SpecResource{optionsResource, resource}},
+		serviceConfiguration: &ServiceConfigStub{},
+	}
+	resourceMap, dataSourceInstanceMap, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+	require.NoError(t, err)
+	assert.Len(t, resourceMap, 2)
+	assert.Contains(t, dataSourceInstanceMap, "provider_cluster_options")
+	assert.NotContains(t, dataSourceInstanceMap, "provider_cdn_options")
+}
+
+func TestCreateTerraformProviderSchemaWithVault(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			headers: SpecHeaderParameters{},
+			security: &specSecurityStub{
+				securityDefinitions: &SpecSecurityDefinitions{
+					newAPIKeyHeaderSecurityDefinition("apikey_auth", authorizationHeader),
+				},
+				globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					{
+						"apikey_auth": []string{},
+					},
+				}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{Vault: &VaultConfig{Address: "https://vault.example.com:8200", Path: "secret/data/terraform/cdn"}},
+	}
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	assert.NoError(t, err)
+	assert.False(t, providerSchema["apikey_auth"].Required, "global security definitions should not be required if their values can be read from Vault")
+	assert.True(t, providerSchema["apikey_auth"].Optional)
+}
+
+func TestCreateTerraformProviderSchemaWithCloudIdentity(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			headers: SpecHeaderParameters{},
+			security: &specSecurityStub{
+				securityDefinitions: &SpecSecurityDefinitions{
+					newAPIKeyHeaderCloudIdentitySecurityDefinition("cloud_identity", cloudIdentityProviderAWS, ""),
+				},
+				globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					{
+						"cloud_identity": []string{},
+					},
+				}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{},
+	}
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	assert.NoError(t, err)
+	assert.True(t, providerSchema["cloud_identity"].Optional, "cloud identity security definitions should not be required since the token is obtained from the instance metadata server")
+}
+
+func TestCreateTerraformProviderSchemaWithSessionLogin(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			headers: SpecHeaderParameters{},
+			security: &specSecurityStub{
+				securityDefinitions:   &SpecSecurityDefinitions{},
+				globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{},
+	}
+	backendConfiguration := &specStubBackendConfiguration{
+		sessionLogin: &specSessionLogin{path: "/login", credentials: map[string]string{"user": "username", "password": "password"}, tokenPath: "token"},
+	}
+	providerSchema, err := p.createTerraformProviderSchema(backendConfiguration, nil)
+	require.NoError(t, err)
+	for _, credentialProperty := range []string{"username", "password"} {
+		require.Contains(t, providerSchema, credentialProperty)
+		assert.True(t, providerSchema[credentialProperty].Required, "session login credentials should be required")
+		assert.True(t, providerSchema[credentialProperty].Sensitive, "session login credentials should be sensitive")
+	}
+}
+
+func TestCreateTerraformProviderSchemaDescriptions(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			headers: SpecHeaderParameters{
+				SpecHeaderParam{Name: "X-Request-ID", Description: "Identifier of the request used for tracing"},
+				SpecHeaderParam{Name: "X-Tenant"},
+			},
+			security: &specSecurityStub{
+				securityDefinitions: &SpecSecurityDefinitions{
+					newAPIKeyHeaderSecurityDefinition("apikey_auth", authorizationHeader),
+					newAPIKeyHeaderSecurityDefinition("legacy_auth", "X-API-Key"),
+				},
+				securityDefinitionDescriptions: map[string]string{"apikey_auth": "API key issued in the account settings"},
+				globalSecuritySchemes:          createSecuritySchemes([]map[string][]string{}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{},
+	}
+	providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "API key issued in the account settings", providerSchema["apikey_auth"].Description)
+	assert.Equal(t, "Value of the 'legacy_auth' security definition the API calls are authenticated with", providerSchema["legacy_auth"].Description)
+	assert.Equal(t, "Identifier of the request used for tracing", providerSchema["x_request_id"].Description)
+	assert.Equal(t, "Value of the 'X-Tenant' header sent in the API calls", providerSchema["x_tenant"].Description)
+}
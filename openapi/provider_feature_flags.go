@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// featureFlagsSchema returns the schema of the features block of the provider configuration, containing one optional
+// property per feature flag defined in the OpenAPI document (x-terraform-provider-feature-flags)
+func featureFlagsSchema(featureFlags []*SpecSchemaDefinitionProperty) (*schema.Schema, error) {
+	featureFlagsSchema := map[string]*schema.Schema{}
+	for _, featureFlag := range featureFlags {
+		terraformType, err := featureFlag.terraformType()
+		if err != nil {
+			return nil, fmt.Errorf("feature flag '%s' not valid: %s", featureFlag.Name, err)
+		}
+		defaultValue, err := featureFlagDefaultValue(featureFlag)
+		if err != nil {
+			return nil, err
+		}
+		featureFlagsSchema[featureFlag.GetTerraformCompliantPropertyName()] = &schema.Schema{
+			Type:        terraformType,
+			Optional:    true,
+			Default:     defaultValue,
+			Description: featureFlag.Description,
+		}
+	}
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Feature flags enabling behavioural changes of the provider",
+		Elem:        &schema.Resource{Schema: featureFlagsSchema},
+	}, nil
+}
+
+// readFeatureFlags returns the values of the feature flags keyed by their terraform compliant names. The values configured
+// in the features block of the provider configuration take preference over the defaults defined in the OpenAPI document
+func readFeatureFlags(featureFlags []*SpecSchemaDefinitionProperty, data *schema.ResourceData) (map[string]interface{}, error) {
+	if len(featureFlags) == 0 {
+		return nil, nil
+	}
+	var configuredFeatureFlags map[string]interface{}
+	if features, ok := data.Get(providerPropertyFeatures).([]interface{}); ok && len(features) > 0 && features[0] != nil {
+		configuredFeatureFlags, _ = features[0].(map[string]interface{})
+	}
+	values := make(map[string]interface{}, len(featureFlags))
+	for _, featureFlag := range featureFlags {
+		name := featureFlag.GetTerraformCompliantPropertyName()
+		if value, exists := configuredFeatureFlags[name]; exists {
+			values[name] = value
+			continue
+		}
+		defaultValue, err := featureFlagDefaultValue(featureFlag)
+		if err != nil {
+			return nil, err
+		}
+		if defaultValue == nil {
+			defaultValue = featureFlagZeroValue(featureFlag.Type)
+		}
+		values[name] = defaultValue
+	}
+	return values, nil
+}
+
+// featureFlagDefaultValue returns the default value of the feature flag converted into the type of the flag (JSON numbers
+// are decoded as float64 regardless of the flag type)
+func featureFlagDefaultValue(featureFlag *SpecSchemaDefinitionProperty) (interface{}, error) {
+	if featureFlag.Default == nil {
+		return nil, nil
+	}
+	var ok bool
+	defaultValue := featureFlag.Default
+	switch featureFlag.Type {
+	case TypeInt:
+		var value float64
+		if value, ok = defaultValue.(float64); ok {
+			defaultValue = int(value)
+		} else {
+			_, ok = defaultValue.(int)
+		}
+	case TypeFloat:
+		_, ok = defaultValue.(float64)
+	case TypeBool:
+		_, ok = defaultValue.(bool)
+	case TypeString:
+		_, ok = defaultValue.(string)
+	}
+	if !ok {
+		return nil, fmt.Errorf("feature flag '%s' not valid: default value '%v' is not of type %s", featureFlag.Name, featureFlag.Default, featureFlag.Type)
+	}
+	return defaultValue, nil
+}
+
+func featureFlagZeroValue(featureFlagType schemaDefinitionPropertyType) interface{} {
+	switch featureFlagType {
+	case TypeInt:
+		return 0
+	case TypeFloat:
+		return 0.0
+	case TypeBool:
+		return false
+	}
+	return ""
+}
+
+// isFeatureFlagEnabled returns true if the feature flag passed in is a boolean flag with value true
+func isFeatureFlagEnabled(featureFlags map[string]interface{}, name string) bool {
+	enabled, _ := featureFlags[name].(bool)
+	return enabled
+}
@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlagsSchema(t *testing.T) {
+	featureFlags := []*SpecSchemaDefinitionProperty{
+		{Name: "newBilling", Type: TypeBool, Description: "Enables the new billing API"},
+		{Name: "region_mode", Type: TypeString, Default: "legacy"},
+	}
+	s, err := featureFlagsSchema(featureFlags)
+	require.NoError(t, err)
+	assert.Equal(t, schema.TypeList, s.Type)
+	assert.True(t, s.Optional)
+	assert.Equal(t, 1, s.MaxItems)
+	featuresSchema := s.Elem.(*schema.Resource).Schema
+	assert.Equal(t, &schema.Schema{Type: schema.TypeBool, Optional: true, Description: "Enables the new billing API"}, featuresSchema["new_billing"])
+	assert.Equal(t, &schema.Schema{Type: schema.TypeString, Optional: true, Default: "legacy"}, featuresSchema["region_mode"])
+
+	_, err = featureFlagsSchema([]*SpecSchemaDefinitionProperty{{Name: "newBilling", Type: TypeBool, Default: "yes"}})
+	assert.EqualError(t, err, "feature flag 'newBilling' not valid: default value 'yes' is not of type boolean")
+}
+
+func TestReadFeatureFlags(t *testing.T) {
+	featureFlags := []*SpecSchemaDefinitionProperty{
+		{Name: "newBilling", Type: TypeBool},
+		{Name: "maxNodes", Type: TypeInt, Default: 10.0},
+		{Name: "ratio", Type: TypeFloat},
+	}
+	s, err := featureFlagsSchema(featureFlags)
+	require.NoError(t, err)
+	providerSchema := map[string]*schema.Schema{providerPropertyFeatures: s}
+
+	testCases := []struct {
+		name                 string
+		config               map[string]interface{}
+		expectedFeatureFlags map[string]interface{}
+	}{
+		{
+			name:                 "features block not configured",
+			config:               map[string]interface{}{},
+			expectedFeatureFlags: map[string]interface{}{"new_billing": false, "max_nodes": 10, "ratio": 0.0},
+		},
+		{
+			name:                 "features block configured",
+			config:               map[string]interface{}{providerPropertyFeatures: []interface{}{map[string]interface{}{"new_billing": true, "max_nodes": 20}}},
+			expectedFeatureFlags: map[string]interface{}{"new_billing": true, "max_nodes": 20, "ratio": 0.0},
+		},
+	}
+	for _, tc := range testCases {
+		values, err := readFeatureFlags(featureFlags, schema.TestResourceDataRaw(t, providerSchema, tc.config))
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedFeatureFlags, values, tc.name)
+	}
+
+	values, err := readFeatureFlags(nil, schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{}))
+	assert.NoError(t, err)
+	assert.Nil(t, values)
+}
+
+func TestIsFeatureFlagEnabled(t *testing.T) {
+	featureFlags := map[string]interface{}{"new_billing": true, "legacy_mode": false, "region_mode": "true"}
+	assert.True(t, isFeatureFlagEnabled(featureFlags, "new_billing"))
+	assert.False(t, isFeatureFlagEnabled(featureFlags, "legacy_mode"))
+	assert.False(t, isFeatureFlagEnabled(featureFlags, "region_mode"))
+	assert.False(t, isFeatureFlagEnabled(featureFlags, "missing"))
+}
@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// providerSchemasFormatVersion defines the format version of the 'terraform providers schema -json' output
+const providerSchemasFormatVersion = "1.0"
+
+// ExportProviderSchemaJSON returns the schema of the provider passed in following the 'terraform providers schema -json'
+// output format, so tools that consume the provider schemas (e,g: cdktf, pulumi terraform bridge, code generators) can be
+// fed without having to install terraform and initialise a configuration using the provider. The providerSourceAddress
+// is the provider source address as Terraform would render it (e,g: registry.terraform.io/examplecorp/openapi)
+func ExportProviderSchemaJSON(provider *schema.Provider, providerSourceAddress string) ([]byte, error) {
+	providerSchemaResponse, err := schema.NewGRPCProviderServer(provider).GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, diagnostic := range providerSchemaResponse.Diagnostics {
+		if diagnostic.Severity == tfprotov5.DiagnosticSeverityError {
+			return nil, fmt.Errorf("failed to get the provider schema: %s %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+	providerSchema := &tfjson.ProviderSchema{
+		ResourceSchemas:   map[string]*tfjson.Schema{},
+		DataSourceSchemas: map[string]*tfjson.Schema{},
+	}
+	if providerSchema.ConfigSchema, err = newTFJSONSchema(providerSchemaResponse.Provider); err != nil {
+		return nil, fmt.Errorf("failed to export the provider configuration schema: %s", err)
+	}
+	for name, resourceSchema := range providerSchemaResponse.ResourceSchemas {
+		if providerSchema.ResourceSchemas[name], err = newTFJSONSchema(resourceSchema); err != nil {
+			return nil, fmt.Errorf("failed to export the resource '%s' schema: %s", name, err)
+		}
+	}
+	for name, dataSourceSchema := range providerSchemaResponse.DataSourceSchemas {
+		if providerSchema.DataSourceSchemas[name], err = newTFJSONSchema(dataSourceSchema); err != nil {
+			return nil, fmt.Errorf("failed to export the data source '%s' schema: %s", name, err)
+		}
+	}
+	return json.Marshal(&tfjson.ProviderSchemas{
+		FormatVersion: providerSchemasFormatVersion,
+		Schemas:       map[string]*tfjson.ProviderSchema{providerSourceAddress: providerSchema},
+	})
+}
+
+func newTFJSONSchema(s *tfprotov5.Schema) (*tfjson.Schema, error) {
+	if s == nil {
+		return nil, nil
+	}
+	block, err := newTFJSONSchemaBlock(s.Block)
+	if err != nil {
+		return nil, err
+	}
+	return &tfjson.Schema{Version: uint64(s.Version), Block: block}, nil
+}
+
+func newTFJSONSchemaBlock(block *tfprotov5.SchemaBlock) (*tfjson.SchemaBlock, error) {
+	if block == nil {
+		return &tfjson.SchemaBlock{DescriptionKind: tfjson.SchemaDescriptionKindPlain}, nil
+	}
+	schemaBlock := &tfjson.SchemaBlock{
+		Description:     block.Description,
+		DescriptionKind: getTFJSONDescriptionKind(block.DescriptionKind),
+		Deprecated:      block.Deprecated,
+	}
+	for _, attribute := range block.Attributes {
+		attributeType, err := getCtyType(attribute.Type)
+		if err != nil {
+			return nil, fmt.Errorf("attribute '%s' type not supported: %s", attribute.Name, err)
+		}
+		if schemaBlock.Attributes == nil {
+			schemaBlock.Attributes = map[string]*tfjson.SchemaAttribute{}
+		}
+		schemaBlock.Attributes[attribute.Name] = &tfjson.SchemaAttribute{
+			AttributeType:   attributeType,
+			Description:     attribute.Description,
+			DescriptionKind: getTFJSONDescriptionKind(attribute.DescriptionKind),
+			Deprecated:      attribute.Deprecated,
+			Required:        attribute.Required,
+			Optional:        attribute.Optional,
+			Computed:        attribute.Computed,
+			Sensitive:       attribute.Sensitive,
+		}
+	}
+	for _, blockType := range block.BlockTypes {
+		nestedBlock, err := newTFJSONSchemaBlock(blockType.Block)
+		if err != nil {
+			return nil, fmt.Errorf("block '%s' not supported: %s", blockType.TypeName, err)
+		}
+		if schemaBlock.NestedBlocks == nil {
+			schemaBlock.NestedBlocks = map[string]*tfjson.SchemaBlockType{}
+		}
+		schemaBlock.NestedBlocks[blockType.TypeName] = &tfjson.SchemaBlockType{
+			NestingMode: getTFJSONNestingMode(blockType.Nesting),
+			Block:       nestedBlock,
+			MinItems:    uint64(blockType.MinItems),
+			MaxItems:    uint64(blockType.MaxItems),
+		}
+	}
+	return schemaBlock, nil
+}
+
+// getCtyType returns the cty type equivalent to the tftypes type passed in. Both types share the same JSON representation
+// (e,g: "string", ["list","string"]) which is used to convert one into the other
+func getCtyType(attributeType tftypes.Type) (cty.Type, error) {
+	marshaler, ok := attributeType.(json.Marshaler)
+	if !ok {
+		return cty.NilType, fmt.Errorf("type '%s' can not be represented in JSON", attributeType)
+	}
+	b, err := marshaler.MarshalJSON()
+	if err != nil {
+		return cty.NilType, err
+	}
+	var ctyType cty.Type
+	if err := ctyType.UnmarshalJSON(b); err != nil {
+		return cty.NilType, err
+	}
+	return ctyType, nil
+}
+
+func getTFJSONDescriptionKind(kind tfprotov5.StringKind) tfjson.SchemaDescriptionKind {
+	if kind == tfprotov5.StringKindMarkdown {
+		return tfjson.SchemaDescriptionKindMarkdown
+	}
+	return tfjson.SchemaDescriptionKindPlain
+}
+
+func getTFJSONNestingMode(nestingMode tfprotov5.SchemaNestedBlockNestingMode) tfjson.SchemaNestingMode {
+	switch nestingMode {
+	case tfprotov5.SchemaNestedBlockNestingModeSingle:
+		return tfjson.SchemaNestingModeSingle
+	case tfprotov5.SchemaNestedBlockNestingModeList:
+		return tfjson.SchemaNestingModeList
+	case tfprotov5.SchemaNestedBlockNestingModeSet:
+		return tfjson.SchemaNestingModeSet
+	case tfprotov5.SchemaNestedBlockNestingModeMap:
+		return tfjson.SchemaNestingModeMap
+	case tfprotov5.SchemaNestedBlockNestingModeGroup:
+		return tfjson.SchemaNestingModeGroup
+	}
+	return ""
+}
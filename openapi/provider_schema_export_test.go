@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestExportProviderSchemaJSON(t *testing.T) {
+	provider := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"apikey_auth": {Type: schema.TypeString, Required: true, Sensitive: true, Description: "API key"},
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"openapi_cdn_v1": {
+				Schema: map[string]*schema.Schema{
+					"label":  {Type: schema.TypeString, Required: true},
+					"ips":    {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+					"status": {Type: schema.TypeString, Computed: true},
+					"object_property": {
+						Type:     schema.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"account": {Type: schema.TypeInt, Optional: true},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"openapi_cdn_v1_instance": {
+				Schema: map[string]*schema.Schema{
+					"id":    {Type: schema.TypeString, Required: true},
+					"label": {Type: schema.TypeString, Computed: true},
+				},
+			},
+		},
+	}
+
+	b, err := ExportProviderSchemaJSON(provider, "registry.terraform.io/examplecorp/openapi")
+	require.NoError(t, err)
+
+	providerSchemas := &tfjson.ProviderSchemas{}
+	require.NoError(t, providerSchemas.UnmarshalJSON(b))
+	assert.Equal(t, "1.0", providerSchemas.FormatVersion)
+	require.Contains(t, providerSchemas.Schemas, "registry.terraform.io/examplecorp/openapi")
+	providerSchema := providerSchemas.Schemas["registry.terraform.io/examplecorp/openapi"]
+
+	assert.Equal(t, &tfjson.SchemaAttribute{AttributeType: cty.String, Description: "API key", DescriptionKind: tfjson.SchemaDescriptionKindPlain, Required: true, Sensitive: true}, providerSchema.ConfigSchema.Block.Attributes["apikey_auth"])
+
+	require.Contains(t, providerSchema.ResourceSchemas, "openapi_cdn_v1")
+	resourceBlock := providerSchema.ResourceSchemas["openapi_cdn_v1"].Block
+	assert.Equal(t, cty.String, resourceBlock.Attributes["label"].AttributeType)
+	assert.True(t, resourceBlock.Attributes["label"].Required)
+	assert.Equal(t, cty.List(cty.String), resourceBlock.Attributes["ips"].AttributeType)
+	assert.True(t, resourceBlock.Attributes["ips"].Optional)
+	assert.True(t, resourceBlock.Attributes["status"].Computed)
+	assert.True(t, resourceBlock.Attributes["id"].Computed)
+	require.Contains(t, resourceBlock.NestedBlocks, "object_property")
+	assert.Equal(t, tfjson.SchemaNestingModeList, resourceBlock.NestedBlocks["object_property"].NestingMode)
+	assert.Equal(t, uint64(1), resourceBlock.NestedBlocks["object_property"].MaxItems)
+	assert.Equal(t, cty.Number, resourceBlock.NestedBlocks["object_property"].Block.Attributes["account"].AttributeType)
+
+	require.Contains(t, providerSchema.DataSourceSchemas, "openapi_cdn_v1_instance")
+	dataSourceBlock := providerSchema.DataSourceSchemas["openapi_cdn_v1_instance"].Block
+	assert.True(t, dataSourceBlock.Attributes["id"].Required)
+	assert.True(t, dataSourceBlock.Attributes["label"].Computed)
+}
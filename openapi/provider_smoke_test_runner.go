@@ -0,0 +1,164 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// SmokeTestStatus defines the outcome of the smoke test of a resource
+type SmokeTestStatus string
+
+const (
+	// SmokeTestPassed is the status of the resources that were successfully created, read and deleted
+	SmokeTestPassed SmokeTestStatus = "passed"
+	// SmokeTestFailed is the status of the resources where any of the create, read or delete operations failed
+	SmokeTestFailed SmokeTestStatus = "failed"
+	// SmokeTestInsufficientExamples is the status of the resources that have required properties without example values,
+	// the create payload can not be generated for them
+	SmokeTestInsufficientExamples SmokeTestStatus = "insufficient examples"
+	// SmokeTestSkipped is the status of the resources that are not supported by the smoke tests (e,g: sub-resources)
+	SmokeTestSkipped SmokeTestStatus = "skipped"
+)
+
+// SmokeTestResult contains the outcome of the smoke test of a resource
+type SmokeTestResult struct {
+	ResourceName string
+	Status       SmokeTestStatus
+	// Reason describes why the smoke test did not pass; empty if the smoke test passed
+	Reason string
+}
+
+// RunSmokeTests runs a create/read/delete smoke test for each of the resources exposed by the provider against the API
+// configured for the provider (e,g: a mock server or a sandbox). The create payloads are generated from the property
+// examples (x-terraform-example extension or example attribute); the resources with required properties missing an example
+// are reported with the SmokeTestInsufficientExamples status. The provider is configured with the provider configuration
+// passed in (e,g: the API keys). If resource names are provided, only those resources are tested.
+func (p *ProviderOpenAPI) RunSmokeTests(ctx context.Context, providerConfiguration map[string]interface{}, resourceNames []string) ([]SmokeTestResult, error) {
+	serviceConfiguration, err := getServiceConfiguration(p.ProviderName)
+	if err != nil {
+		return nil, fmt.Errorf("plugin init error: %s", err)
+	}
+	return p.runSmokeTests(ctx, serviceConfiguration, providerConfiguration, resourceNames)
+}
+
+func (p *ProviderOpenAPI) runSmokeTests(ctx context.Context, serviceConfiguration ServiceConfiguration, providerConfiguration map[string]interface{}, resourceNames []string) ([]SmokeTestResult, error) {
+	providerFactory, err := p.newProviderFactory(serviceConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := providerFactory.createProvider()
+	if err != nil {
+		return nil, fmt.Errorf("plugin terraform-provider-%s init error while creating schema provider: %s", p.ProviderName, err)
+	}
+	if diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(providerConfiguration)); diags.HasError() {
+		return nil, fmt.Errorf("failed to configure the provider: %s", diagnosticsError(diags))
+	}
+	openAPIResources, err := providerFactory.specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil, err
+	}
+	selectedResourceNames := map[string]bool{}
+	for _, resourceName := range resourceNames {
+		selectedResourceNames[resourceName] = true
+	}
+	var results []SmokeTestResult
+	for _, openAPIResource := range openAPIResources {
+		resourceName, err := providerFactory.getProviderResourceName(openAPIResource.GetResourceName())
+		if err != nil {
+			return nil, err
+		}
+		if len(selectedResourceNames) > 0 && !selectedResourceNames[resourceName] {
+			continue
+		}
+		resource, exists := provider.ResourcesMap[resourceName]
+		if !exists {
+			continue
+		}
+		result := runResourceSmokeTest(ctx, resource, provider.Meta(), openAPIResource)
+		result.ResourceName = resourceName
+		providerLog.Info("smoke test of resource '%s' %s %s", resourceName, result.Status, result.Reason)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// runResourceSmokeTest creates the resource with the payload generated from the property examples, reads it and deletes it
+func runResourceSmokeTest(ctx context.Context, resource *schema.Resource, meta interface{}, openAPIResource SpecResource) SmokeTestResult {
+	if parentResourceInfo := openAPIResource.GetParentResourceInfo(); parentResourceInfo != nil {
+		return SmokeTestResult{Status: SmokeTestSkipped, Reason: "sub-resources are not supported"}
+	}
+	resourceSchema, err := openAPIResource.GetResourceSchema()
+	if err != nil {
+		return SmokeTestResult{Status: SmokeTestFailed, Reason: err.Error()}
+	}
+	payload, missingExamples := newSmokeTestPayload(resourceSchema)
+	if len(missingExamples) > 0 {
+		sort.Strings(missingExamples)
+		return SmokeTestResult{Status: SmokeTestInsufficientExamples, Reason: fmt.Sprintf("missing example for the required properties: %s", strings.Join(missingExamples, ", "))}
+	}
+	data := resource.Data(nil)
+	if err := dataSourceUpdateStateWithPayloadData(openAPIResource, payload, data); err != nil {
+		return SmokeTestResult{Status: SmokeTestFailed, Reason: fmt.Sprintf("failed to populate the create payload: %s", err)}
+	}
+	if diags := resource.CreateContext(ctx, data, meta); diags.HasError() {
+		return SmokeTestResult{Status: SmokeTestFailed, Reason: fmt.Sprintf("create failed: %s", diagnosticsError(diags))}
+	}
+	id := data.Id()
+	if diags := resource.ReadContext(ctx, data, meta); diags.HasError() {
+		return SmokeTestResult{Status: SmokeTestFailed, Reason: fmt.Sprintf("read of resource '%s' failed: %s", id, diagnosticsError(diags))}
+	}
+	if data.Id() == "" {
+		return SmokeTestResult{Status: SmokeTestFailed, Reason: fmt.Sprintf("resource '%s' not found after being created", id)}
+	}
+	if diags := resource.DeleteContext(ctx, data, meta); diags.HasError() {
+		return SmokeTestResult{Status: SmokeTestFailed, Reason: fmt.Sprintf("delete of resource '%s' failed, the resource might need to be deleted manually: %s", id, diagnosticsError(diags))}
+	}
+	return SmokeTestResult{Status: SmokeTestPassed}
+}
+
+// newSmokeTestPayload returns the create payload generated from the property examples along with the names of the required
+// properties missing an example. Required object properties without an example are generated from their own properties'
+// examples; optional properties without an example are not included in the payload
+func newSmokeTestPayload(schemaDefinition *SpecSchemaDefinition) (map[string]interface{}, []string) {
+	payload := map[string]interface{}{}
+	var missingExamples []string
+	for _, property := range schemaDefinition.Properties {
+		if property.ReadOnly || property.IsParentProperty {
+			continue
+		}
+		if property.Example != nil {
+			payload[property.Name] = property.Example
+			continue
+		}
+		if !property.Required {
+			continue
+		}
+		if property.Type == TypeObject && property.SpecSchemaDefinition != nil {
+			objectPayload, objectMissingExamples := newSmokeTestPayload(property.SpecSchemaDefinition)
+			for _, objectMissingExample := range objectMissingExamples {
+				missingExamples = append(missingExamples, fmt.Sprintf("%s.%s", property.Name, objectMissingExample))
+			}
+			payload[property.Name] = objectPayload
+			continue
+		}
+		missingExamples = append(missingExamples, property.Name)
+	}
+	return payload, missingExamples
+}
+
+// diagnosticsError returns the summaries of the error diagnostics joined in a single string
+func diagnosticsError(diags diag.Diagnostics) string {
+	var errs []string
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			errs = append(errs, d.Summary)
+		}
+	}
+	return strings.Join(errs, "; ")
+}
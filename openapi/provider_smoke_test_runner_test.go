@@ -0,0 +1,207 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const smokeTestSpec = `swagger: "2.0"
+host: %s
+schemes:
+- http
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: body
+        name: body
+        schema:
+          $ref: "#/definitions/CDN"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/CDN"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        type: string
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/CDN"
+    delete:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        type: string
+      responses:
+        204:
+          description: successful operation, no content is returned
+  /v1/lbs:
+    post:
+      parameters:
+      - in: body
+        name: body
+        schema:
+          $ref: "#/definitions/LB"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/LB"
+  /v1/lbs/{id}:
+    get:
+      parameters:
+      - name: id
+        in: path
+        required: true
+        type: string
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/LB"
+definitions:
+  CDN:
+    type: object
+    required:
+    - label
+    - origin
+    properties:
+      id:
+        type: string
+        readOnly: true
+      label:
+        type: string
+        example: some label
+      port:
+        type: integer
+        x-terraform-example: 8080
+      origin:
+        type: object
+        required:
+        - host
+        properties:
+          host:
+            type: string
+            example: origin.domain.com
+  LB:
+    type: object
+    required:
+    - name
+    - backends
+    properties:
+      id:
+        type: string
+        readOnly: true
+      name:
+        type: string
+      backends:
+        type: array
+        items:
+          type: string`
+
+func TestRunSmokeTests(t *testing.T) {
+	var mutex sync.Mutex
+	cdns := map[string]map[string]interface{}{}
+	var requestsReceived []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requestsReceived = append(requestsReceived, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/cdns":
+			cdn := map[string]interface{}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&cdn))
+			cdn["id"] = "1234"
+			cdns["1234"] = cdn
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(cdn)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/cdns/"):
+			cdn, exists := cdns[strings.TrimPrefix(r.URL.Path, "/v1/cdns/")]
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(cdn)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v1/cdns/"):
+			delete(cdns, strings.TrimPrefix(r.URL.Path, "/v1/cdns/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+
+	file, err := ioutil.TempFile("", "smoke_test_spec.yaml")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	require.NoError(t, ioutil.WriteFile(file.Name(), []byte(fmt.Sprintf(smokeTestSpec, strings.TrimPrefix(api.URL, "http://"))), 0600))
+
+	p := &ProviderOpenAPI{ProviderName: "smoke"}
+	results, err := p.runSmokeTests(context.Background(), &ServiceConfigV1{SwaggerURL: file.Name()}, map[string]interface{}{}, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	resultsByName := map[string]SmokeTestResult{}
+	for _, result := range results {
+		resultsByName[result.ResourceName] = result
+	}
+
+	assert.Equal(t, SmokeTestResult{ResourceName: "smoke_cdns_v1", Status: SmokeTestPassed}, resultsByName["smoke_cdns_v1"])
+	assert.Equal(t, SmokeTestResult{ResourceName: "smoke_lbs_v1", Status: SmokeTestInsufficientExamples, Reason: "missing example for the required properties: backends, name"}, resultsByName["smoke_lbs_v1"])
+	assert.Equal(t, []string{"POST /v1/cdns", "GET /v1/cdns/1234", "DELETE /v1/cdns/1234"}, requestsReceived)
+	assert.Empty(t, cdns)
+}
+
+func TestRunSmokeTestsSelectedResources(t *testing.T) {
+	file, err := ioutil.TempFile("", "smoke_test_spec.yaml")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	require.NoError(t, ioutil.WriteFile(file.Name(), []byte(fmt.Sprintf(smokeTestSpec, "localhost:8443")), 0600))
+
+	p := &ProviderOpenAPI{ProviderName: "smoke"}
+	results, err := p.runSmokeTests(context.Background(), &ServiceConfigV1{SwaggerURL: file.Name()}, map[string]interface{}{}, []string{"smoke_lbs_v1"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "smoke_lbs_v1", results[0].ResourceName)
+	assert.Equal(t, SmokeTestInsufficientExamples, results[0].Status)
+}
+
+func TestNewSmokeTestPayload(t *testing.T) {
+	schemaDefinition := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true, Example: "1234"},
+			&SpecSchemaDefinitionProperty{Name: "label", Type: TypeString, Required: true, Example: "some label"},
+			&SpecSchemaDefinitionProperty{Name: "description", Type: TypeString},
+			&SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt, Example: float64(8080)},
+			&SpecSchemaDefinitionProperty{Name: "parent_id", Type: TypeString, Required: true, IsParentProperty: true},
+			&SpecSchemaDefinitionProperty{Name: "origin", Type: TypeObject, Required: true, SpecSchemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					&SpecSchemaDefinitionProperty{Name: "host", Type: TypeString, Required: true},
+					&SpecSchemaDefinitionProperty{Name: "path", Type: TypeString, Example: "/"},
+				},
+			}},
+			&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString, Required: true},
+		},
+	}
+	payload, missingExamples := newSmokeTestPayload(schemaDefinition)
+	assert.Equal(t, map[string]interface{}{
+		"label":  "some label",
+		"port":   float64(8080),
+		"origin": map[string]interface{}{"path": "/"},
+	}, payload)
+	assert.Equal(t, []string{"origin.host", "name"}, missingExamples)
+}
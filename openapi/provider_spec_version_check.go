@@ -0,0 +1,88 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi/version"
+	goversion "github.com/hashicorp/go-version"
+)
+
+const defaultSpecVersionCheckVersionProperty = "version"
+
+// checkSpecVersionSkew calls the API version/metadata endpoint configured in the service configuration (if any) and returns
+// an error if the version of the OpenAPI document loaded by the provider is older than the version advertised by the API.
+// This helps catching early issues caused by stale cached OpenAPI documents. Nil is returned if the check is not configured
+// or the OpenAPI document loaded does not specify the info.version.
+func (p providerFactory) checkSpecVersionSkew(openAPIClient *ProviderClient) error {
+	if p.serviceConfiguration == nil {
+		return nil
+	}
+	specVersionCheckConfig := p.serviceConfiguration.GetSpecVersionCheckConfiguration()
+	if specVersionCheckConfig == nil {
+		return nil
+	}
+	specVersion := p.specAnalyser.GetSpecInfo().Version
+	if specVersion == "" {
+		providerLog.Debug("skipping spec version check: the OpenAPI document loaded does not specify the info.version")
+		return nil
+	}
+	url, err := p.getSpecVersionCheckURL(openAPIClient, specVersionCheckConfig.Path)
+	if err != nil {
+		return fmt.Errorf("spec version check failed to build the API version endpoint URL: %s", err)
+	}
+	headers := map[string]string{userAgentHeader: version.BuildUserAgent(runtime.GOOS, runtime.GOARCH)}
+	responsePayload := map[string]interface{}{}
+	providerLog.Debug("Performing %s %s", httpGet, url)
+	res, err := openAPIClient.httpClient.Get(url, headers, &responsePayload)
+	if err != nil {
+		return fmt.Errorf("spec version check failed to call the API version endpoint %s: %s", url, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("spec version check failed: API version endpoint %s responded with unexpected status code %d", url, res.StatusCode)
+	}
+	versionProperty := specVersionCheckConfig.VersionProperty
+	if versionProperty == "" {
+		versionProperty = defaultSpecVersionCheckVersionProperty
+	}
+	apiVersionValue, exists := responsePayload[versionProperty]
+	if !exists || apiVersionValue == nil {
+		return fmt.Errorf("spec version check failed: API version endpoint %s response is missing the '%s' property", url, versionProperty)
+	}
+	apiVersion := fmt.Sprintf("%v", apiVersionValue)
+	loadedVersion, err := goversion.NewVersion(specVersion)
+	if err != nil {
+		return fmt.Errorf("spec version check failed: OpenAPI document version '%s' is not a valid version: %s", specVersion, err)
+	}
+	advertisedVersion, err := goversion.NewVersion(apiVersion)
+	if err != nil {
+		return fmt.Errorf("spec version check failed: API advertised version '%s' is not a valid version: %s", apiVersion, err)
+	}
+	if loadedVersion.LessThan(advertisedVersion) {
+		return fmt.Errorf("the OpenAPI document loaded by the provider (version %s) is older than the version advertised by the API (version %s); the OpenAPI document might be stale (e,g: cached), please make sure the provider is using the latest OpenAPI document", specVersion, apiVersion)
+	}
+	providerLog.Debug("spec version check passed: OpenAPI document version %s, API advertised version %s", specVersion, apiVersion)
+	return nil
+}
+
+// getSpecVersionCheckURL returns the URL of the API version endpoint. If the path provided is already a URL it is returned
+// as is; otherwise the path is appended to the provider host (taking into account the region for multi-region providers)
+func (p providerFactory) getSpecVersionCheckURL(openAPIClient *ProviderClient, path string) (string, error) {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path, nil
+	}
+	host, _, err := openAPIClient.GetProviderHost()
+	if err != nil {
+		return "", err
+	}
+	scheme, err := openAPIClient.openAPIBackendConfiguration.getHTTPScheme()
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = fmt.Sprintf("/%s", path)
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, host, path), nil
+}
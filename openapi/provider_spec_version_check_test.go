@@ -0,0 +1,228 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSpecVersionSkew(t *testing.T) {
+	testCases := []struct {
+		name               string
+		specVersion        string
+		specVersionCheck   *SpecVersionCheckConfig
+		responseStatusCode int
+		responseBody       string
+		expectedError      string
+	}{
+		{
+			name:             "spec version check not configured",
+			specVersion:      "1.0.0",
+			specVersionCheck: nil,
+		},
+		{
+			name:             "OpenAPI document does not specify the version",
+			specVersion:      "",
+			specVersionCheck: &SpecVersionCheckConfig{Path: "/version"},
+		},
+		{
+			name:               "OpenAPI document version is the same as the API advertised version",
+			specVersion:        "1.2.0",
+			specVersionCheck:   &SpecVersionCheckConfig{Path: "/version"},
+			responseStatusCode: http.StatusOK,
+			responseBody:       `{"version":"1.2.0"}`,
+		},
+		{
+			name:               "OpenAPI document version is newer than the API advertised version",
+			specVersion:        "1.3.0",
+			specVersionCheck:   &SpecVersionCheckConfig{Path: "/version"},
+			responseStatusCode: http.StatusOK,
+			responseBody:       `{"version":"1.2.0"}`,
+		},
+		{
+			name:               "OpenAPI document version is older than the API advertised version",
+			specVersion:        "1.1.0",
+			specVersionCheck:   &SpecVersionCheckConfig{Path: "/version"},
+			responseStatusCode: http.StatusOK,
+			responseBody:       `{"version":"1.2.0"}`,
+			expectedError:      "the OpenAPI document loaded by the provider (version 1.1.0) is older than the version advertised by the API (version 1.2.0); the OpenAPI document might be stale (e,g: cached), please make sure the provider is using the latest OpenAPI document",
+		},
+		{
+			name:               "custom version property with numeric value",
+			specVersion:        "1",
+			specVersionCheck:   &SpecVersionCheckConfig{Path: "version", VersionProperty: "min_spec_version"},
+			responseStatusCode: http.StatusOK,
+			responseBody:       `{"min_spec_version":2}`,
+			expectedError:      "the OpenAPI document loaded by the provider (version 1) is older than the version advertised by the API (version 2); the OpenAPI document might be stale (e,g: cached), please make sure the provider is using the latest OpenAPI document",
+		},
+		{
+			name:               "API version endpoint responds with unexpected status code",
+			specVersion:        "1.0.0",
+			specVersionCheck:   &SpecVersionCheckConfig{Path: "/version"},
+			responseStatusCode: http.StatusNotFound,
+			responseBody:       `{}`,
+			expectedError:      "spec version check failed: API version endpoint %s/version responded with unexpected status code 404",
+		},
+		{
+			name:               "API version endpoint response is missing the version property",
+			specVersion:        "1.0.0",
+			specVersionCheck:   &SpecVersionCheckConfig{Path: "/version"},
+			responseStatusCode: http.StatusOK,
+			responseBody:       `{"other":"1.0.0"}`,
+			expectedError:      "spec version check failed: API version endpoint %s/version response is missing the 'version' property",
+		},
+		{
+			name:               "API advertised version is not valid",
+			specVersion:        "1.0.0",
+			specVersionCheck:   &SpecVersionCheckConfig{Path: "/version"},
+			responseStatusCode: http.StatusOK,
+			responseBody:       `{"version":"not_valid"}`,
+			expectedError:      "spec version check failed: API advertised version 'not_valid' is not a valid version: Malformed version: not_valid",
+		},
+		{
+			name:               "OpenAPI document version is not valid",
+			specVersion:        "not_valid",
+			specVersionCheck:   &SpecVersionCheckConfig{Path: "/version"},
+			responseStatusCode: http.StatusOK,
+			responseBody:       `{"version":"1.0.0"}`,
+			expectedError:      "spec version check failed: OpenAPI document version 'not_valid' is not a valid version: Malformed version: not_valid",
+		},
+	}
+
+	for _, tc := range testCases {
+		var requestedPath, requestedUserAgent string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestedPath = r.URL.Path
+			requestedUserAgent = r.Header.Get(userAgentHeader)
+			w.WriteHeader(tc.responseStatusCode)
+			w.Write([]byte(tc.responseBody))
+		}))
+		apiHost := strings.TrimPrefix(api.URL, "http://")
+		p := providerFactory{
+			specAnalyser:         &specAnalyserStub{specInfo: SpecInfo{Version: tc.specVersion}},
+			serviceConfiguration: &ServiceConfigStub{SpecVersionCheck: tc.specVersionCheck},
+		}
+		openAPIClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration(apiHost, "", "http"),
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		}
+		err := p.checkSpecVersionSkew(openAPIClient)
+		api.Close()
+		if tc.expectedError != "" {
+			expectedError := tc.expectedError
+			if strings.Contains(expectedError, "%s") {
+				expectedError = fmt.Sprintf(expectedError, api.URL)
+			}
+			assert.EqualError(t, err, expectedError, tc.name)
+		} else {
+			assert.NoError(t, err, tc.name)
+		}
+		if tc.responseStatusCode != 0 {
+			assert.Equal(t, "/version", requestedPath, tc.name)
+			assert.NotEmpty(t, requestedUserAgent, tc.name)
+		}
+	}
+}
+
+func TestCheckSpecVersionSkewAPIRequestFails(t *testing.T) {
+	p := providerFactory{
+		specAnalyser:         &specAnalyserStub{specInfo: SpecInfo{Version: "1.0.0"}},
+		serviceConfiguration: &ServiceConfigStub{SpecVersionCheck: &SpecVersionCheckConfig{Path: "https://api.server.com/version"}},
+	}
+	openAPIClient := &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration("www.host.com", "", "https"),
+		httpClient:                  &http_goclient.HttpClientStub{Error: errors.New("some error")},
+	}
+	err := p.checkSpecVersionSkew(openAPIClient)
+	assert.EqualError(t, err, "spec version check failed to call the API version endpoint https://api.server.com/version: some error")
+}
+
+func TestConfigureProviderSpecVersionSkewWarning(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"version":"2.0.0"}`))
+	}))
+	defer api.Close()
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			specInfo: SpecInfo{Version: "1.0.0"},
+			security: &specSecurityStub{
+				securityDefinitions:   &SpecSecurityDefinitions{},
+				globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{SpecVersionCheck: &SpecVersionCheckConfig{Path: "/version"}},
+	}
+	backendConfig := newStubBackendConfiguration(strings.TrimPrefix(api.URL, "http://"), "", "http")
+	providerSchema, err := p.createTerraformProviderSchema(backendConfig, nil)
+	require.NoError(t, err)
+	configureFunc := p.configureProvider(backendConfig, nil, &schema.Provider{})
+	client, diags := configureFunc(context.Background(), schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{}))
+	assert.NotNil(t, client, "the version skew should not prevent the provider from being configured")
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "OpenAPI document version check", diags[0].Summary)
+	assert.Equal(t, "the OpenAPI document loaded by the provider (version 1.0.0) is older than the version advertised by the API (version 2.0.0); the OpenAPI document might be stale (e,g: cached), please make sure the provider is using the latest OpenAPI document", diags[0].Detail)
+}
+
+func TestGetSpecVersionCheckURL(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		backendConfiguration *specStubBackendConfiguration
+		path                 string
+		expectedURL          string
+		expectedError        error
+	}{
+		{
+			name:                 "path relative to the provider host",
+			backendConfiguration: newStubBackendConfiguration("www.host.com", "/api", "https"),
+			path:                 "/version",
+			expectedURL:          "https://www.host.com/version",
+		},
+		{
+			name:                 "path relative to the provider host without leading forward slash",
+			backendConfiguration: newStubBackendConfiguration("www.host.com", "", "http"),
+			path:                 "version",
+			expectedURL:          "http://www.host.com/version",
+		},
+		{
+			name:                 "path relative to the provider host for multi-region providers",
+			backendConfiguration: &specStubBackendConfiguration{host: "www.%s.host.com", httpScheme: "https", regions: []string{"rst1", "dub1"}},
+			path:                 "/version",
+			expectedURL:          "https://www.rst1.host.com/version",
+		},
+		{
+			name:                 "path is a full URL",
+			backendConfiguration: newStubBackendConfiguration("www.host.com", "", "https"),
+			path:                 "https://metadata.host.com/version",
+			expectedURL:          "https://metadata.host.com/version",
+		},
+		{
+			name:                 "provider host fails to be resolved",
+			backendConfiguration: &specStubBackendConfiguration{hostErr: errors.New("some error")},
+			path:                 "/version",
+			expectedError:        errors.New("some error"),
+		},
+	}
+	for _, tc := range testCases {
+		p := providerFactory{}
+		openAPIClient := &ProviderClient{openAPIBackendConfiguration: tc.backendConfiguration}
+		url, err := p.getSpecVersionCheckURL(openAPIClient, tc.path)
+		if tc.expectedError != nil {
+			assert.EqualError(t, err, tc.expectedError.Error(), tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedURL, url, tc.name)
+	}
+}
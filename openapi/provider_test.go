@@ -11,6 +11,8 @@ import (
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestOpenAPIProvider(t *testing.T) {
@@ -230,7 +232,7 @@ definitions:
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Schema["label"].Required, ShouldBeFalse)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Schema["label"].Computed, ShouldBeTrue)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Create, ShouldBeNil)
-				So(tfProvider.DataSourcesMap[dataSourceInstanceName].ReadContext, ShouldNotBeNil)
+				So(tfProvider.DataSourcesMap[dataSourceInstanceName].ReadWithoutTimeout, ShouldNotBeNil)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Read, ShouldBeNil)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Update, ShouldBeNil)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Delete, ShouldBeNil)
@@ -264,14 +266,14 @@ definitions:
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Schema["name"].Required, ShouldBeFalse)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Schema["name"].Computed, ShouldBeTrue)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Create, ShouldBeNil)
-				So(tfProvider.DataSourcesMap[dataSourceInstanceName].ReadContext, ShouldNotBeNil)
+				So(tfProvider.DataSourcesMap[dataSourceInstanceName].ReadWithoutTimeout, ShouldNotBeNil)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Read, ShouldBeNil)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Update, ShouldBeNil)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Delete, ShouldBeNil)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Importer, ShouldBeNil)
 
 				// the provider configuration function should not be nil
-				So(tfProvider.ConfigureFunc, ShouldNotBeNil)
+				So(tfProvider.ConfigureContextFunc, ShouldNotBeNil)
 			})
 		})
 	})
@@ -377,13 +379,13 @@ definitions:
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Schema["label"].Required, ShouldBeFalse)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Schema["label"].Computed, ShouldBeTrue)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].CreateContext, ShouldBeNil)
-				So(tfProvider.DataSourcesMap[dataSourceInstanceName].ReadContext, ShouldNotBeNil)
+				So(tfProvider.DataSourcesMap[dataSourceInstanceName].ReadWithoutTimeout, ShouldNotBeNil)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].UpdateContext, ShouldBeNil)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].DeleteContext, ShouldBeNil)
 				So(tfProvider.DataSourcesMap[dataSourceInstanceName].Importer, ShouldBeNil)
 
 				// the provider configuration function should not be nil
-				So(tfProvider.ConfigureFunc, ShouldNotBeNil)
+				So(tfProvider.ConfigureContextFunc, ShouldNotBeNil)
 			})
 		})
 	})
@@ -463,7 +465,8 @@ definitions:
 
 				// the provider dataSource map should contain the cdn resource with the expected configuration
 				So(tfProvider.DataSourcesMap, ShouldNotBeNil)
-				So(len(tfProvider.DataSourcesMap), ShouldEqual, 1)
+				So(len(tfProvider.DataSourcesMap), ShouldEqual, 2)
+				So(tfProvider.DataSourcesMap, ShouldContainKey, fmt.Sprintf("%s_provider_info", providerName))
 				resourceName := fmt.Sprintf("%s_cdn_datasource_v1", providerName)
 				So(tfProvider.DataSourcesMap, ShouldContainKey, resourceName)
 				resourceName = fmt.Sprintf("%s_cdn_datasource_v1", providerName)
@@ -487,14 +490,14 @@ definitions:
 				So(elements["values"].Type, ShouldEqual, schema.TypeList)
 
 				// the provider cdn-datasource data source should have only the READ operation configured
-				So(tfProvider.DataSourcesMap[resourceName].ReadContext, ShouldNotBeNil)
+				So(tfProvider.DataSourcesMap[resourceName].ReadWithoutTimeout, ShouldNotBeNil)
 				So(tfProvider.DataSourcesMap[resourceName].Read, ShouldBeNil)
 				So(tfProvider.DataSourcesMap[resourceName].Create, ShouldBeNil)
 				So(tfProvider.DataSourcesMap[resourceName].Delete, ShouldBeNil)
 
 				// the provider resource map must be nil as no resources are configured in the swagger"
 				So(tfProvider.ResourcesMap, ShouldBeEmpty)
-				So(tfProvider.ConfigureFunc, ShouldNotBeNil)
+				So(tfProvider.ConfigureContextFunc, ShouldNotBeNil)
 			})
 		})
 	})
@@ -541,7 +544,8 @@ definitions:
 				So(err, ShouldBeNil)
 				So(tfProvider.Schema, ShouldNotBeNil)
 				So(tfProvider.DataSourcesMap, ShouldNotBeNil)
-				So(len(tfProvider.DataSourcesMap), ShouldEqual, 1)
+				So(len(tfProvider.DataSourcesMap), ShouldEqual, 2)
+				So(tfProvider.DataSourcesMap, ShouldContainKey, fmt.Sprintf("%s_provider_info", providerName))
 
 				dataSourceName := fmt.Sprintf("%s_cdns_v1_firewalls", providerName)
 				So(tfProvider.DataSourcesMap, ShouldContainKey, dataSourceName)
@@ -561,10 +565,10 @@ definitions:
 				elements := tfProvider.DataSourcesMap[dataSourceName].Schema["filter"].Elem.(*schema.Resource).Schema
 				So(elements["name"].Type, ShouldEqual, schema.TypeString)
 				So(elements["values"].Type, ShouldEqual, schema.TypeList)
-				So(tfProvider.DataSourcesMap[dataSourceName].ReadContext, ShouldNotBeNil)
+				So(tfProvider.DataSourcesMap[dataSourceName].ReadWithoutTimeout, ShouldNotBeNil)
 				So(tfProvider.DataSourcesMap[dataSourceName].Read, ShouldBeNil)
 				So(tfProvider.ResourcesMap, ShouldBeEmpty)
-				So(tfProvider.ConfigureFunc, ShouldNotBeNil)
+				So(tfProvider.ConfigureContextFunc, ShouldNotBeNil)
 			})
 		})
 	})
@@ -670,7 +674,7 @@ definitions:
 				So(tfProvider.ResourcesMap[resourceName].DeleteContext, ShouldNotBeNil)
 				So(tfProvider.ResourcesMap[resourceName].Importer, ShouldNotBeNil)
 
-				So(tfProvider.ConfigureFunc, ShouldNotBeNil)
+				So(tfProvider.ConfigureContextFunc, ShouldNotBeNil)
 			})
 		})
 	})
@@ -762,9 +766,9 @@ definitions:
 			Convey("And the tfProvider returned should not be nil (skipping schema verification since that's covered in other tests)", func() {
 				So(tfProvider, ShouldNotBeNil)
 			})
-			Convey("And default TLS transport configuration should be configured to skip verify", func() {
+			Convey("And default TLS transport configuration should not be modified", func() {
 				tr := http.DefaultTransport.(*http.Transport)
-				So(tr.TLSClientConfig.InsecureSkipVerify, ShouldBeTrue)
+				So(tr.TLSClientConfig == nil || !tr.TLSClientConfig.InsecureSkipVerify, ShouldBeTrue)
 			})
 
 		})
@@ -789,6 +793,25 @@ definitions:
 
 }
 
+func TestNewProviderFactoryTransport(t *testing.T) {
+	swaggerServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`swagger: "2.0"`))
+	}))
+	defer swaggerServer.Close()
+	p := &ProviderOpenAPI{ProviderName: providerName}
+
+	_, err := p.newProviderFactory(&ServiceConfigStub{SwaggerURL: swaggerServer.URL})
+	assert.Error(t, err, "the self-signed certificate of the server should not be trusted")
+
+	factory, err := p.newProviderFactory(&ServiceConfigStub{SwaggerURL: swaggerServer.URL, InsecureSkipVerify: true})
+	require.NoError(t, err, "the OpenAPI document should be retrieved with the provider transport")
+	require.NotNil(t, factory.transport)
+	assert.True(t, factory.transport.TLSClientConfig.InsecureSkipVerify)
+	assert.Same(t, factory.transport, factory.getTransport())
+	defaultTLSConfig := http.DefaultTransport.(*http.Transport).TLSClientConfig
+	assert.True(t, defaultTLSConfig == nil || !defaultTLSConfig.InsecureSkipVerify, "the default transport should not be modified")
+}
+
 func Test_colliding_resource_names(t *testing.T) {
 	makeSwaggerDoc := func(path1, preferredName1, path2, preferredName2 string, markIgnorePath1 bool) string {
 		if path1 == "" {
@@ -0,0 +1,355 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const vaultAuthMethodToken = "token"
+const vaultAuthMethodKubernetes = "kubernetes"
+const vaultAuthMethodJWT = "jwt"
+
+// vaultDefaultTokenFile defines the file the Vault token is read from with the token auth method if not configured; it is
+// the file the Vault CLI and agent write the token to by default
+const vaultDefaultTokenFile = "~/.vault-token"
+
+// vaultDefaultKubernetesJWTFile defines the file the service account token is read from with the kubernetes auth method
+// if not configured
+const vaultDefaultKubernetesJWTFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+const vaultTokenHeader = "X-Vault-Token"
+const vaultNamespaceHeader = "X-Vault-Namespace"
+
+// vaultRequestTimeout defines the max time the requests to Vault are allowed to take
+const vaultRequestTimeout = 30 * time.Second
+
+// getAuthMethod returns the auth method configured, defaulting to the token auth method
+func (v *VaultConfig) getAuthMethod() string {
+	if v.AuthMethod == "" {
+		return vaultAuthMethodToken
+	}
+	return v.AuthMethod
+}
+
+// getField returns the secret field containing the value of the security definition with the given name
+func (v *VaultConfig) getField(securityDefinitionName string) string {
+	if field, exists := v.Fields[securityDefinitionName]; exists && field != "" {
+		return field
+	}
+	return securityDefinitionName
+}
+
+// vaultResponse defines the subset of the Vault API responses used to log in, read the secrets and renew the leases
+type vaultResponse struct {
+	LeaseID       string                 `json:"lease_id"`
+	LeaseDuration int                    `json:"lease_duration"`
+	Renewable     bool                   `json:"renewable"`
+	Data          map[string]interface{} `json:"data"`
+	Auth          *vaultAuth             `json:"auth"`
+}
+
+type vaultAuth struct {
+	ClientToken   string `json:"client_token"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+}
+
+// vaultLease contains the lease of a Vault token or secret
+type vaultLease struct {
+	id        string
+	renewable bool
+	// renewAt contains the time the lease should be renewed at, which is two thirds of its duration so it is renewed
+	// before it expires; zero if the lease does not expire
+	renewAt time.Time
+}
+
+func newVaultLease(id string, renewable bool, duration int, now time.Time) vaultLease {
+	lease := vaultLease{id: id, renewable: renewable}
+	if duration > 0 {
+		lease.renewAt = now.Add(time.Duration(duration) * time.Second * 2 / 3)
+	}
+	return lease
+}
+
+// isDue returns true if the lease must be renewed
+func (l vaultLease) isDue(now time.Time) bool {
+	return !l.renewAt.IsZero() && !now.Before(l.renewAt)
+}
+
+// vaultCredentials contains the values of the secret the auth credentials are read from. Both the Vault token and the
+// secret lease are renewed before they expire, so long running applies keep working with fresh credentials. If a
+// lease can not be renewed, the provider logs in again and/or reads the secret again
+type vaultCredentials struct {
+	config     *VaultConfig
+	httpClient *http.Client
+	mutex      sync.Mutex
+	token      string
+	tokenLease vaultLease
+	secret     map[string]string
+	lease      vaultLease
+	// now returns the current time, it can be overridden in tests
+	now func() time.Time
+}
+
+// newVaultCredentials logs in to Vault and reads the secret configured; nil is returned if Vault is not configured
+func newVaultCredentials(config *VaultConfig) (*vaultCredentials, error) {
+	if config == nil {
+		return nil, nil
+	}
+	v := &vaultCredentials{
+		config:     config,
+		httpClient: &http.Client{Timeout: vaultRequestTimeout},
+		now:        time.Now,
+	}
+	if err := v.login(); err != nil {
+		return nil, err
+	}
+	if err := v.readSecret(); err != nil {
+		return nil, err
+	}
+	providerLog.Info("auth credentials read from the Vault secret '%s'", config.Path)
+	return v, nil
+}
+
+// get returns the value of the secret field passed in (empty if the secret does not contain the field), renewing the
+// secret beforehand if its lease is due
+func (v *vaultCredentials) get(field string) (string, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if v.lease.isDue(v.now()) {
+		if err := v.renewSecret(); err != nil {
+			return "", err
+		}
+	}
+	return v.secret[field], nil
+}
+
+// renewSecret renews the lease of the secret if renewable, reading the secret again otherwise (e,g: KV secrets, whose lease
+// duration is just a hint of how often the secret should be read again)
+func (v *vaultCredentials) renewSecret() error {
+	if v.lease.id != "" && v.lease.renewable {
+		response, err := v.do(http.MethodPut, "/v1/sys/leases/renew", map[string]interface{}{"lease_id": v.lease.id})
+		if err == nil {
+			v.lease = newVaultLease(response.LeaseID, response.Renewable, response.LeaseDuration, v.now())
+			return nil
+		}
+		providerLog.Warn("failed to renew the Vault secret '%s' lease, reading the secret again: %s", v.config.Path, err)
+	}
+	return v.readSecret()
+}
+
+// readSecret reads the secret configured, renewing the token beforehand if its lease is due. The data of KV v2 secrets
+// (which is nested in a data field along with the secret metadata) is unwrapped
+func (v *vaultCredentials) readSecret() error {
+	if v.tokenLease.isDue(v.now()) {
+		if err := v.renewToken(); err != nil {
+			return err
+		}
+	}
+	response, err := v.do(http.MethodGet, "/v1/"+strings.TrimPrefix(v.config.Path, "/"), nil)
+	if err != nil {
+		return fmt.Errorf("failed to read the Vault secret '%s': %s", v.config.Path, err)
+	}
+	data := response.Data
+	if nestedData, ok := data["data"].(map[string]interface{}); ok {
+		if _, isKVv2 := data["metadata"]; isKVv2 {
+			data = nestedData
+		}
+	}
+	v.secret = map[string]string{}
+	for field, value := range data {
+		if s, ok := value.(string); ok {
+			v.secret[field] = s
+		} else {
+			v.secret[field] = fmt.Sprintf("%v", value)
+		}
+	}
+	v.lease = newVaultLease(response.LeaseID, response.Renewable, response.LeaseDuration, v.now())
+	return nil
+}
+
+// renewToken renews the Vault token if renewable, logging in again otherwise
+func (v *vaultCredentials) renewToken() error {
+	if v.tokenLease.renewable {
+		response, err := v.do(http.MethodPost, "/v1/auth/token/renew-self", map[string]interface{}{})
+		if err == nil && response.Auth != nil {
+			v.tokenLease = newVaultLease("", response.Auth.Renewable, response.Auth.LeaseDuration, v.now())
+			return nil
+		}
+		providerLog.Warn("failed to renew the Vault token, logging in again: %v", err)
+	}
+	return v.login()
+}
+
+// login logs in to Vault with the auth method configured. With the token auth method, the token is read from the token
+// file (which the Vault agent keeps renewed), hence it is not renewed by the provider
+func (v *vaultCredentials) login() error {
+	authMethod := v.config.getAuthMethod()
+	if authMethod == vaultAuthMethodToken {
+		tokenFile := v.config.TokenFile
+		if tokenFile == "" {
+			tokenFile = vaultDefaultTokenFile
+		}
+		token, err := getFileContent(tokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the Vault token file '%s': %s", tokenFile, err)
+		}
+		v.token = strings.TrimSpace(token)
+		v.tokenLease = vaultLease{}
+		return nil
+	}
+	jwtFile := v.config.JWTFile
+	if jwtFile == "" {
+		jwtFile = vaultDefaultKubernetesJWTFile
+	}
+	jwt, err := getFileContent(jwtFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the Vault %s auth method JWT file '%s': %s", authMethod, jwtFile, err)
+	}
+	authMount := v.config.AuthMount
+	if authMount == "" {
+		authMount = authMethod
+	}
+	v.token = ""
+	response, err := v.do(http.MethodPost, fmt.Sprintf("/v1/auth/%s/login", strings.Trim(authMount, "/")), map[string]interface{}{"role": v.config.Role, "jwt": strings.TrimSpace(jwt)})
+	if err != nil {
+		return fmt.Errorf("failed to log in to Vault with the %s auth method: %s", authMethod, err)
+	}
+	if response.Auth == nil || response.Auth.ClientToken == "" {
+		return fmt.Errorf("failed to log in to Vault with the %s auth method: the response is missing the client token", authMethod)
+	}
+	v.token = response.Auth.ClientToken
+	v.tokenLease = newVaultLease("", response.Auth.Renewable, response.Auth.LeaseDuration, v.now())
+	return nil
+}
+
+// do performs the request against the Vault API and returns the response decoded
+func (v *vaultCredentials) do(method, path string, body map[string]interface{}) (*vaultResponse, error) {
+	var requestBody []byte
+	if body != nil {
+		var err error
+		if requestBody, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(v.config.Address, "/")+path, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	if v.token != "" {
+		req.Header.Set(vaultTokenHeader, v.token)
+	}
+	if v.config.Namespace != "" {
+		req.Header.Set(vaultNamespaceHeader, v.config.Namespace)
+	}
+	if body != nil {
+		req.Header.Set(contentType, "application/json")
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		// the response body is not included in the error since it may contain sensitive information
+		return nil, fmt.Errorf("%s %s responded with status code '%d'", method, path, resp.StatusCode)
+	}
+	response := &vaultResponse{}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return nil, fmt.Errorf("%s %s response not valid: %s", method, path, err)
+	}
+	return response, nil
+}
+
+// vaultAPIKeyAuthenticator wraps the authenticator of a security definition whose value is read from Vault. The value is
+// read from the Vault credentials every time the authentication is prepared, so renewed credentials are picked up; the
+// wrapped authenticator is only created again if the value changes (keeping, for instance, the access tokens cached)
+type vaultAPIKeyAuthenticator struct {
+	secDef      SpecSecurityDefinition
+	field       string
+	credentials *vaultCredentials
+	mutex       sync.Mutex
+	value       string
+	// authenticator contains the authenticator of the security definition configured with the current value
+	authenticator specAPIKeyAuthenticator
+}
+
+func newVaultAPIKeyAuthenticator(secDef SpecSecurityDefinition, credentials *vaultCredentials) (*vaultAPIKeyAuthenticator, error) {
+	a := &vaultAPIKeyAuthenticator{
+		secDef:      secDef,
+		field:       credentials.config.getField(secDef.GetTerraformConfigurationName()),
+		credentials: credentials,
+	}
+	if _, err := a.getAuthenticator(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// getAuthenticator returns the authenticator of the security definition configured with the current secret value
+func (a *vaultAPIKeyAuthenticator) getAuthenticator() (specAPIKeyAuthenticator, error) {
+	value, err := a.credentials.get(a.field)
+	if err != nil {
+		return nil, err
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.authenticator == nil || value != a.value {
+		a.authenticator = createAPIKeyAuthenticator(a.secDef, value)
+		a.value = value
+	}
+	return a.authenticator, nil
+}
+
+func (a *vaultAPIKeyAuthenticator) getContext() interface{} {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.authenticator.getContext()
+}
+
+func (a *vaultAPIKeyAuthenticator) getType() authType {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.authenticator.getType()
+}
+
+func (a *vaultAPIKeyAuthenticator) prepareAuth(authContext *authContext) error {
+	authenticator, err := a.getAuthenticator()
+	if err != nil {
+		return err
+	}
+	return authenticator.prepareAuth(authContext)
+}
+
+func (a *vaultAPIKeyAuthenticator) prepareScopedAuth(authContext *authContext, scopes []string) error {
+	authenticator, err := a.getAuthenticator()
+	if err != nil {
+		return err
+	}
+	if scopedAuthenticator, ok := authenticator.(specScopedAuthenticator); ok {
+		return scopedAuthenticator.prepareScopedAuth(authContext, scopes)
+	}
+	return authenticator.prepareAuth(authContext)
+}
+
+func (a *vaultAPIKeyAuthenticator) validate() error {
+	if _, err := a.getAuthenticator(); err != nil {
+		return err
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.value == "" {
+		return fmt.Errorf("required security definition '%s' is missing the value. Please make sure the Vault secret '%s' contains the field '%s'", a.secDef.GetTerraformConfigurationName(), a.credentials.config.Path, a.field)
+	}
+	return a.authenticator.validate()
+}
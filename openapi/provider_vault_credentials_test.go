@@ -0,0 +1,240 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vaultServerStub fakes the subset of the Vault API used by the vault credentials, recording the requests received
+type vaultServerStub struct {
+	*httptest.Server
+	requests []string
+	apiKey   string
+	// secretResponse contains the response of the secret read, the api key is set in the secret data
+	secretResponse map[string]interface{}
+}
+
+func newVaultServerStub(t *testing.T) *vaultServerStub {
+	v := &vaultServerStub{apiKey: "apiKeyValue"}
+	v.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v.requests = append(v.requests, r.Method+" "+r.URL.Path)
+		var response interface{}
+		switch r.URL.Path {
+		case "/v1/auth/kubernetes/login":
+			body := map[string]interface{}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if body["role"] != "terraform" || body["jwt"] != "serviceAccountToken" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			response = map[string]interface{}{"auth": map[string]interface{}{"client_token": "vaultToken", "lease_duration": 3600, "renewable": true}}
+		case "/v1/auth/token/renew-self":
+			response = map[string]interface{}{"auth": map[string]interface{}{"client_token": "vaultToken", "lease_duration": 3600, "renewable": true}}
+		case "/v1/secret/data/terraform/cdn":
+			if r.Header.Get(vaultTokenHeader) != "vaultToken" || r.Header.Get(vaultNamespaceHeader) != "team" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			response = map[string]interface{}{
+				"lease_duration": 0,
+				"data": map[string]interface{}{
+					"data":     map[string]interface{}{"apikey_auth": v.apiKey, "port": 8080},
+					"metadata": map[string]interface{}{"version": 1},
+				},
+			}
+			if v.secretResponse != nil {
+				v.secretResponse["data"] = map[string]interface{}{"apikey_auth": v.apiKey}
+				response = v.secretResponse
+			}
+		case "/v1/sys/leases/renew":
+			response = map[string]interface{}{"lease_id": "database/creds/terraform/123", "lease_duration": 60, "renewable": true}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	return v
+}
+
+func newVaultTestConfig(t *testing.T, address string) *VaultConfig {
+	jwtFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, ioutil.WriteFile(jwtFile, []byte("serviceAccountToken\n"), 0600))
+	return &VaultConfig{
+		Address:    address,
+		Path:       "secret/data/terraform/cdn",
+		AuthMethod: vaultAuthMethodKubernetes,
+		Role:       "terraform",
+		JWTFile:    jwtFile,
+		Namespace:  "team",
+	}
+}
+
+func TestNewVaultCredentials(t *testing.T) {
+	t.Run("vault not configured", func(t *testing.T) {
+		vault, err := newVaultCredentials(nil)
+		assert.NoError(t, err)
+		assert.Nil(t, vault)
+	})
+
+	t.Run("kubernetes auth method and KV v2 secret", func(t *testing.T) {
+		vaultServer := newVaultServerStub(t)
+		defer vaultServer.Close()
+		vault, err := newVaultCredentials(newVaultTestConfig(t, vaultServer.URL))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"POST /v1/auth/kubernetes/login", "GET /v1/secret/data/terraform/cdn"}, vaultServer.requests)
+		value, err := vault.get("apikey_auth")
+		assert.NoError(t, err)
+		assert.Equal(t, "apiKeyValue", value)
+		value, err = vault.get("port")
+		assert.NoError(t, err)
+		assert.Equal(t, "8080", value)
+		value, err = vault.get("missing")
+		assert.NoError(t, err)
+		assert.Empty(t, value)
+	})
+
+	t.Run("token auth method", func(t *testing.T) {
+		vaultServer := newVaultServerStub(t)
+		defer vaultServer.Close()
+		tokenFile := filepath.Join(t.TempDir(), ".vault-token")
+		require.NoError(t, ioutil.WriteFile(tokenFile, []byte("vaultToken"), 0600))
+		vault, err := newVaultCredentials(&VaultConfig{Address: vaultServer.URL, Path: "/secret/data/terraform/cdn", TokenFile: tokenFile, Namespace: "team"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"GET /v1/secret/data/terraform/cdn"}, vaultServer.requests)
+		value, err := vault.get("apikey_auth")
+		assert.NoError(t, err)
+		assert.Equal(t, "apiKeyValue", value)
+	})
+
+	t.Run("login fails", func(t *testing.T) {
+		vaultServer := newVaultServerStub(t)
+		defer vaultServer.Close()
+		config := newVaultTestConfig(t, vaultServer.URL)
+		config.Role = "admin"
+		_, err := newVaultCredentials(config)
+		assert.EqualError(t, err, "failed to log in to Vault with the kubernetes auth method: POST /v1/auth/kubernetes/login responded with status code '400'")
+	})
+
+	t.Run("token file missing", func(t *testing.T) {
+		_, err := newVaultCredentials(&VaultConfig{Address: "http://127.0.0.1:8200", Path: "secret/data/terraform/cdn", TokenFile: filepath.Join(t.TempDir(), "missing")})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read the Vault token file")
+	})
+
+	t.Run("secret read fails", func(t *testing.T) {
+		vaultServer := newVaultServerStub(t)
+		defer vaultServer.Close()
+		config := newVaultTestConfig(t, vaultServer.URL)
+		config.Path = "secret/data/terraform/other"
+		_, err := newVaultCredentials(config)
+		assert.EqualError(t, err, "failed to read the Vault secret 'secret/data/terraform/other': GET /v1/secret/data/terraform/other responded with status code '404'")
+	})
+}
+
+func TestVaultCredentialsRenewal(t *testing.T) {
+	now := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("secret read again once its lease is due", func(t *testing.T) {
+		vaultServer := newVaultServerStub(t)
+		defer vaultServer.Close()
+		vaultServer.secretResponse = map[string]interface{}{"lease_duration": 90}
+		vault, err := newVaultCredentials(newVaultTestConfig(t, vaultServer.URL))
+		require.NoError(t, err)
+		vault.lease.renewAt = now.Add(time.Minute)
+		vault.now = func() time.Time { return now }
+		vaultServer.apiKey = "rotatedAPIKeyValue"
+
+		value, err := vault.get("apikey_auth")
+		assert.NoError(t, err)
+		assert.Equal(t, "apiKeyValue", value, "the secret should not be read again before its lease is due")
+
+		now = now.Add(time.Minute)
+		value, err = vault.get("apikey_auth")
+		assert.NoError(t, err)
+		assert.Equal(t, "rotatedAPIKeyValue", value)
+		assert.Equal(t, now.Add(time.Minute), vault.lease.renewAt, "the secret should be renewed at two thirds of its lease duration")
+		assert.Equal(t, []string{"POST /v1/auth/kubernetes/login", "GET /v1/secret/data/terraform/cdn", "GET /v1/secret/data/terraform/cdn"}, vaultServer.requests)
+	})
+
+	t.Run("renewable secret lease renewed and token renewed before reading the secret", func(t *testing.T) {
+		vaultServer := newVaultServerStub(t)
+		defer vaultServer.Close()
+		vaultServer.secretResponse = map[string]interface{}{"lease_id": "database/creds/terraform/123", "lease_duration": 60, "renewable": true}
+		vault, err := newVaultCredentials(newVaultTestConfig(t, vaultServer.URL))
+		require.NoError(t, err)
+		vault.lease.renewAt = now
+		vault.now = func() time.Time { return now }
+
+		_, err = vault.get("apikey_auth")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"POST /v1/auth/kubernetes/login", "GET /v1/secret/data/terraform/cdn", "PUT /v1/sys/leases/renew"}, vaultServer.requests)
+
+		vault.lease = vaultLease{renewAt: now}
+		vault.tokenLease.renewAt = now
+		_, err = vault.get("apikey_auth")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"POST /v1/auth/kubernetes/login", "GET /v1/secret/data/terraform/cdn", "PUT /v1/sys/leases/renew", "POST /v1/auth/token/renew-self", "GET /v1/secret/data/terraform/cdn"}, vaultServer.requests)
+	})
+}
+
+func TestVaultAPIKeyAuthenticator(t *testing.T) {
+	vaultServer := newVaultServerStub(t)
+	defer vaultServer.Close()
+	config := newVaultTestConfig(t, vaultServer.URL)
+	config.Fields = map[string]string{"other_auth": "missing_field"}
+	vault, err := newVaultCredentials(config)
+	require.NoError(t, err)
+	now := time.Now()
+	vault.now = func() time.Time { return now }
+
+	authenticator, err := newVaultAPIKeyAuthenticator(newAPIKeyHeaderBearerSecurityDefinition("apikey_auth"), vault)
+	require.NoError(t, err)
+	assert.NoError(t, authenticator.validate())
+	assert.Equal(t, authTypeAPIKeyHeader, authenticator.getType())
+	assert.Equal(t, apiKey{name: authorizationHeader, value: "Bearer apiKeyValue"}, authenticator.getContext())
+	ctx := &authContext{headers: map[string]string{}}
+	assert.NoError(t, authenticator.prepareAuth(ctx))
+	assert.Equal(t, "Bearer apiKeyValue", ctx.headers[authorizationHeader])
+
+	vaultServer.apiKey = "rotatedAPIKeyValue"
+	vault.lease.renewAt = now
+	ctx = &authContext{headers: map[string]string{}}
+	assert.NoError(t, authenticator.prepareScopedAuth(ctx, []string{"cdns:read"}))
+	assert.Equal(t, "Bearer rotatedAPIKeyValue", ctx.headers[authorizationHeader], "the value rotated in Vault should be used once the secret is read again")
+
+	otherAuthenticator, err := newVaultAPIKeyAuthenticator(newAPIKeyQuerySecurityDefinition("other_auth", "token"), vault)
+	require.NoError(t, err)
+	assert.EqualError(t, otherAuthenticator.validate(), "required security definition 'other_auth' is missing the value. Please make sure the Vault secret 'secret/data/terraform/cdn' contains the field 'missing_field'")
+}
+
+func TestNewProviderConfigurationWithVault(t *testing.T) {
+	vaultServer := newVaultServerStub(t)
+	defer vaultServer.Close()
+	vault, err := newVaultCredentials(newVaultTestConfig(t, vaultServer.URL))
+	require.NoError(t, err)
+	specAnalyser := &specAnalyserStub{
+		security: &specSecurityStub{
+			securityDefinitions: &SpecSecurityDefinitions{
+				newAPIKeyHeaderSecurityDefinition("apikey_auth", authorizationHeader),
+				newAPIKeyHeaderSecurityDefinition(stringProperty.GetTerraformCompliantPropertyName(), "X-Other"),
+			},
+			globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+		},
+	}
+	data := newTestSchema(stringProperty).getResourceData(t)
+
+	providerConfiguration, err := newProviderConfiguration(specAnalyser, data, nil, nil, vault)
+	require.NoError(t, err)
+	assert.IsType(t, &vaultAPIKeyAuthenticator{}, providerConfiguration.SecuritySchemaDefinitions["apikey_auth"])
+	assert.Equal(t, "apiKeyValue", providerConfiguration.SecuritySchemaDefinitions["apikey_auth"].getContext().(apiKey).value)
+	assert.Equal(t, stringProperty.Default, providerConfiguration.SecuritySchemaDefinitions[stringProperty.GetTerraformCompliantPropertyName()].getContext().(apiKey).value, "the values configured in the provider should take preference over the ones in Vault")
+}
@@ -0,0 +1,82 @@
+package openapi
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// resourceBatchReadCache holds the resource collections fetched via the root path GET operation when batch read is enabled
+// for a resource (x-terraform-resource-batch-read). This allows serving the individual reads performed by Terraform when
+// refreshing many instances of the same resource from a single collection GET call instead of one GET call per instance.
+// The collections are cached per provider client and parent IDs (sub-resources) and are only valid for the configured TTL.
+type resourceBatchReadCache struct {
+	mutex   sync.Mutex
+	entries map[resourceBatchReadCacheKey]*resourceBatchReadCacheEntry
+	now     func() time.Time
+}
+
+type resourceBatchReadCacheKey struct {
+	client    ClientOpenAPI
+	parentIDs string
+}
+
+// resourceBatchReadCacheEntry contains the collection items indexed by the resource identifier. The entry has its own mutex
+// so concurrent reads waiting on the same collection result in one single collection GET call.
+type resourceBatchReadCacheEntry struct {
+	mutex     sync.Mutex
+	items     map[string]map[string]interface{}
+	expiresAt time.Time
+}
+
+// resourceBatchReadFetchFunc returns the resource collection items indexed by the resource identifier
+type resourceBatchReadFetchFunc func() (map[string]map[string]interface{}, error)
+
+func newResourceBatchReadCache() *resourceBatchReadCache {
+	return &resourceBatchReadCache{
+		entries: map[resourceBatchReadCacheKey]*resourceBatchReadCacheEntry{},
+		now:     time.Now,
+	}
+}
+
+// get returns the item with the given id from the cached collection. If the collection is not cached yet or it has expired,
+// the fetch function is called to retrieve it. The bool returned is false if the collection does not contain the item.
+func (c *resourceBatchReadCache) get(client ClientOpenAPI, parentIDs []string, id string, ttl time.Duration, fetch resourceBatchReadFetchFunc) (map[string]interface{}, bool, error) {
+	entry := c.getEntry(client, parentIDs)
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	if entry.items == nil || !c.now().Before(entry.expiresAt) {
+		items, err := fetch()
+		if err != nil {
+			return nil, false, err
+		}
+		entry.items = items
+		entry.expiresAt = c.now().Add(ttl)
+	}
+	item, exists := entry.items[id]
+	return item, exists, nil
+}
+
+// invalidate removes the cached collection for the given client and parent IDs. This is called after the resource
+// is created, updated or deleted so subsequent reads do not get served with stale data.
+func (c *resourceBatchReadCache) invalidate(client ClientOpenAPI, parentIDs []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.entries, c.key(client, parentIDs))
+}
+
+func (c *resourceBatchReadCache) getEntry(client ClientOpenAPI, parentIDs []string) *resourceBatchReadCacheEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	key := c.key(client, parentIDs)
+	entry, exists := c.entries[key]
+	if !exists {
+		entry = &resourceBatchReadCacheEntry{}
+		c.entries[key] = entry
+	}
+	return entry
+}
+
+func (c *resourceBatchReadCache) key(client ClientOpenAPI, parentIDs []string) resourceBatchReadCacheKey {
+	return resourceBatchReadCacheKey{client: client, parentIDs: strings.Join(parentIDs, "/")}
+}
@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceBatchReadCacheGet(t *testing.T) {
+	client := &clientOpenAPIStub{}
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := newResourceBatchReadCache()
+	c.now = func() time.Time { return now }
+
+	fetchCalls := 0
+	fetch := func() (map[string]map[string]interface{}, error) {
+		fetchCalls++
+		return map[string]map[string]interface{}{
+			"1": {"id": "1"},
+			"2": {"id": "2"},
+		}, nil
+	}
+
+	item, exists, err := c.get(client, nil, "1", time.Minute, fetch)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, map[string]interface{}{"id": "1"}, item)
+
+	item, exists, err = c.get(client, nil, "2", time.Minute, fetch)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, map[string]interface{}{"id": "2"}, item)
+	assert.Equal(t, 1, fetchCalls, "collection should be fetched only once within the TTL")
+
+	_, exists, err = c.get(client, nil, "3", time.Minute, fetch)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, 1, fetchCalls)
+
+	_, _, err = c.get(client, []string{"parentID"}, "1", time.Minute, fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fetchCalls, "collections with different parent IDs should be cached separately")
+
+	_, _, err = c.get(&clientOpenAPIStub{}, nil, "1", time.Minute, fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fetchCalls, "collections from different clients should be cached separately")
+
+	now = now.Add(time.Minute)
+	_, _, err = c.get(client, nil, "1", time.Minute, fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, fetchCalls, "collection should be fetched again once the TTL expires")
+
+	c.invalidate(client, nil)
+	_, _, err = c.get(client, nil, "1", time.Minute, fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, fetchCalls, "collection should be fetched again once invalidated")
+}
+
+func TestResourceBatchReadCacheGetFetchError(t *testing.T) {
+	client := &clientOpenAPIStub{}
+	c := newResourceBatchReadCache()
+	fetchCalls := 0
+	fetch := func() (map[string]map[string]interface{}, error) {
+		fetchCalls++
+		return nil, errors.New("some error")
+	}
+	_, exists, err := c.get(client, nil, "1", time.Minute, fetch)
+	assert.EqualError(t, err, "some error")
+	assert.False(t, exists)
+	_, _, err = c.get(client, nil, "1", time.Minute, fetch)
+	assert.EqualError(t, err, "some error")
+	assert.Equal(t, 2, fetchCalls, "failed fetches should not be cached")
+}
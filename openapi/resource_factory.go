@@ -1,15 +1,18 @@
 package openapi
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"math"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/openapierr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -20,11 +23,27 @@ type resourceFactory struct {
 	defaultPollInterval   time.Duration
 	defaultPollMinTimeout time.Duration
 	defaultPollDelay      time.Duration
+	// batchReadCache is used to serve the reads from the resource collection when batch read is enabled for the resource
+	batchReadCache *resourceBatchReadCache
+	// stateEncrypter is used to encrypt the properties with the x-terraform-encrypt-in-state extension; nil if the state
+	// encryption is not configured
+	stateEncrypter *stateEncrypter
+	// verifyWrites defines whether the resource is read after being created or updated to verify the API kept the values
+	// sent (read-after-write verification)
+	verifyWrites bool
 }
 
 // only applicable when remote resource no longer exists and GET operations return 404 NotFound
 const defaultDestroyStatus = "destroyed"
 
+// resourceETagPropertyName is the name of the computed property where the ETag returned by the API is stored when the
+// resource has conditional GET enabled
+const resourceETagPropertyName = "resource_etag"
+
+// provisioningEventsPropertyName is the name of the computed property where the statuses observed while polling the
+// resource are recorded when the create or update operations have x-terraform-resource-provisioning-events enabled
+const provisioningEventsPropertyName = "provisioning_events"
+
 var defaultPollInterval = time.Duration(5 * time.Second)
 var defaultPollMinTimeout = time.Duration(10 * time.Second)
 var defaultPollDelay = time.Duration(1 * time.Second)
@@ -37,6 +56,7 @@ func newResourceFactory(openAPIResource SpecResource) resourceFactory {
 		defaultPollInterval:   defaultPollInterval,
 		defaultPollMinTimeout: defaultPollMinTimeout,
 		defaultTimeout:        defaultTimeout,
+		batchReadCache:        newResourceBatchReadCache(),
 	}
 }
 
@@ -45,19 +65,28 @@ func (r resourceFactory) createTerraformResource() (*schema.Resource, error) {
 	if err != nil {
 		return nil, err
 	}
-	//log.Printf("[DEBUG] '%s' terraform schema: %+v", r.openAPIResource.GetResourceName(), s)
+	//resourceLog.Debug("'%s' terraform schema: %+v", r.openAPIResource.GetResourceName(), s)
 	//spew.Dump(s)
 	timeouts, err := r.createSchemaResourceTimeout()
 	if err != nil {
 		return nil, err
 	}
+	if _, err := r.openAPIResource.getBatchReadTTL(); err != nil {
+		return nil, err
+	}
+	schemaDefinition, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
 	resourceName := r.openAPIResource.GetResourceName()
 	return &schema.Resource{
+		Description:   schemaDefinition.Description,
 		Schema:        s,
 		CreateContext: crudWithContext(r.create, schema.TimeoutCreate, resourceName),
 		ReadContext:   crudWithContext(r.read, schema.TimeoutRead, resourceName),
 		DeleteContext: crudWithContext(r.delete, schema.TimeoutDelete, resourceName),
 		UpdateContext: crudWithContext(r.update, schema.TimeoutUpdate, resourceName),
+		CustomizeDiff: customdiff.Sequence(r.validateUniqueItems, r.planComputedNestedValues, r.planPrecheck, r.planResolveByName, r.planUpload),
 		Importer:      r.importer(),
 		Timeouts:      timeouts,
 	}, nil
@@ -83,8 +112,65 @@ func (r resourceFactory) createTerraformResourceSchema() (map[string]*schema.Sch
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("[DEBUG] resource '%s' schemaDefinition: %s", r.openAPIResource.GetResourceName(), sPrettyPrint(schemaDefinition))
-	return schemaDefinition.createResourceSchema()
+	resourceLog.Debug("resource '%s' schemaDefinition: %s", r.openAPIResource.GetResourceName(), sPrettyPrint(schemaDefinition))
+	s, err := schemaDefinition.createResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	if r.isConditionalGetEnabled() {
+		if _, exists := s[resourceETagPropertyName]; exists {
+			return nil, fmt.Errorf("resource '%s' has %s enabled but the schema already contains a property named '%s'", r.openAPIResource.GetResourceName(), extTfResourceConditionalGet, resourceETagPropertyName)
+		}
+		s[resourceETagPropertyName] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ETag returned by the API the last time the resource was read; used to perform conditional reads",
+		}
+	}
+	if r.isProvisioningEventsEnabled() {
+		if _, exists := s[provisioningEventsPropertyName]; exists {
+			return nil, fmt.Errorf("resource '%s' has %s enabled but the schema already contains a property named '%s'", r.openAPIResource.GetResourceName(), extTfResourceProvisioningEvents, provisioningEventsPropertyName)
+		}
+		s[provisioningEventsPropertyName] = provisioningEventsSchema()
+	}
+	if r.isWaitForEnabled() {
+		if _, exists := s[waitForPropertyName]; exists {
+			return nil, fmt.Errorf("resource '%s' has %s enabled but the schema already contains a property named '%s'", r.openAPIResource.GetResourceName(), extTfWaitFor, waitForPropertyName)
+		}
+		s[waitForPropertyName] = waitForSchema()
+	}
+	if err := validateProtectedStateProperties(r.openAPIResource, schemaDefinition, r.stateEncrypter); err != nil {
+		return nil, err
+	}
+	if revisionProperty := r.getRevisionProperty(); revisionProperty != "" {
+		if _, err := schemaDefinition.getProperty(revisionProperty); err != nil {
+			return nil, fmt.Errorf("resource '%s' %s extension not valid: %s", r.openAPIResource.GetResourceName(), extTfRevisionProperty, err)
+		}
+	}
+	return s, nil
+}
+
+// getRevisionProperty returns the name of the resource property containing the resource revision if the resource instance
+// GET operation has the x-terraform-revision-property extension; empty otherwise
+func (r resourceFactory) getRevisionProperty() string {
+	getOperation := r.openAPIResource.getResourceOperations().Get
+	if getOperation == nil {
+		return ""
+	}
+	return getOperation.revisionProperty
+}
+
+// isConditionalGetEnabled returns true if the resource instance GET operation has conditional GET enabled (x-terraform-resource-conditional-get)
+func (r resourceFactory) isConditionalGetEnabled() bool {
+	getOperation := r.openAPIResource.getResourceOperations().Get
+	return getOperation != nil && getOperation.isConditionalGetEnabled
+}
+
+// isProvisioningEventsEnabled returns true if the resource POST or PUT operations record the statuses observed while polling
+// the resource (x-terraform-resource-provisioning-events)
+func (r resourceFactory) isProvisioningEventsEnabled() bool {
+	operations := r.openAPIResource.getResourceOperations()
+	return (operations.Post != nil && operations.Post.recordProvisioningEvents) || (operations.Put != nil && operations.Put.recordProvisioningEvents)
 }
 
 func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error {
@@ -101,9 +187,20 @@ func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error
 	if err != nil {
 		return err
 	}
+	defer r.invalidateBatchReadCache(providerClient, parentIDs)
+
+	if err := r.precheck(providerClient, parentIDs...); err != nil {
+		return err
+	}
 
 	operation := r.openAPIResource.getResourceOperations().Post
 	requestPayload := r.createPayloadFromLocalStateData(data)
+	if err := r.resolveNames(data, providerClient, requestPayload, parentIDs...); err != nil {
+		return err
+	}
+	if err := r.uploadFiles(data, providerClient, requestPayload, parentIDs...); err != nil {
+		return err
+	}
 	responsePayload := map[string]interface{}{}
 
 	res, err := providerClient.Post(r.openAPIResource, requestPayload, &responsePayload, parentIDs...)
@@ -113,19 +210,30 @@ func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error
 	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}); err != nil {
 		return fmt.Errorf("[resource='%s'] POST %s failed: %s", r.openAPIResource.GetResourceName(), resourcePath, err)
 	}
+	if shouldPollLocation(operation, res) {
+		if responsePayload, err = r.pollLocation(data, providerClient, res.Header.Get(locationHeader), responsePayload, parentIDs...); err != nil {
+			return fmt.Errorf("polling mechanism failed after POST %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
+		}
+	}
 
 	err = setStateID(r.openAPIResource, data, responsePayload)
 	if err != nil {
 		return err
 	}
-	log.Printf("[INFO] Resource '%s' ID: %s", resourcePath, data.Id())
+	resourceLog.Info("Resource '%s' ID: %s", resourcePath, data.Id())
 
-	err = r.handlePollingIfConfigured(&responsePayload, data, providerClient, operation, res.StatusCode, schema.TimeoutCreate)
+	err = r.handlePollingIfConfigured(&responsePayload, data, providerClient, operation, res.StatusCode, schema.TimeoutCreate, parentIDs...)
 	if err != nil {
 		return fmt.Errorf("polling mechanism failed after POST %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
 	}
+	if responsePayload, err = r.waitFor(data, providerClient, operation, responsePayload, schema.TimeoutCreate, parentIDs...); err != nil {
+		return err
+	}
 
-	return updateStateWithPayloadData(r.openAPIResource, responsePayload, data)
+	if err := r.updateStateWithPayloadData(responsePayload, data); err != nil {
+		return err
+	}
+	return r.verifyWrite(data, providerClient, "create", requestPayload, parentIDs...)
 }
 
 func (r resourceFactory) readWithOptions(data *schema.ResourceData, i interface{}, handleNotFoundErr bool) error {
@@ -143,7 +251,24 @@ func (r resourceFactory) readWithOptions(data *schema.ResourceData, i interface{
 		return err
 	}
 
-	remoteData, err := r.readRemote(data.Id(), openAPIClient, parentsIDs...)
+	if r.isRevisionUnchanged(data, openAPIClient, parentsIDs...) {
+		resourceLog.Debug("'%s' (%s) revision has not changed, skipping state update", resourceName, data.Id())
+		return nil
+	}
+
+	var remoteData map[string]interface{}
+	var eTag string
+	if r.isConditionalGetEnabled() {
+		var notModified bool
+		currentETag, _ := data.Get(resourceETagPropertyName).(string)
+		remoteData, eTag, notModified, err = r.readRemoteIfNoneMatch(data.Id(), currentETag, openAPIClient, parentsIDs...)
+		if err == nil && notModified {
+			resourceLog.Debug("GET '%s' (%s) responded with 304 Not Modified, skipping state update", resourceName, data.Id())
+			return nil
+		}
+	} else {
+		remoteData, err = r.readRemoteBatched(data.Id(), openAPIClient, parentsIDs...)
+	}
 
 	if err != nil {
 		if openapiErr, ok := err.(openapierr.Error); ok {
@@ -154,9 +279,112 @@ func (r resourceFactory) readWithOptions(data *schema.ResourceData, i interface{
 		return fmt.Errorf("[resource='%s'] GET %s/%s failed: %s", r.openAPIResource.GetResourceName(), resourcePath, data.Id(), err)
 	}
 
+	if err := r.updateStateWithPayloadData(remoteData, data); err != nil {
+		return err
+	}
+	if r.isConditionalGetEnabled() {
+		return data.Set(resourceETagPropertyName, eTag)
+	}
+	return nil
+}
+
+// updateStateWithPayloadData saves the remote data into the state protecting the values of the properties with the
+// x-terraform-encrypt-in-state and x-terraform-fingerprint-in-state extensions and keeping the values of the properties
+// with the x-terraform-ignore-server-changes extension
+func (r resourceFactory) updateStateWithPayloadData(remoteData map[string]interface{}, data *schema.ResourceData) error {
+	remoteData, err := ignoreServerChanges(r.openAPIResource, remoteData, data)
+	if err != nil {
+		return err
+	}
+	remoteData, err = protectStateProperties(r.openAPIResource, r.stateEncrypter, remoteData, data)
+	if err != nil {
+		return err
+	}
 	return updateStateWithPayloadData(r.openAPIResource, remoteData, data)
 }
 
+// ignoreServerChanges returns the remote data without the values of the properties with the x-terraform-ignore-server-changes
+// extension that already have a value in the state, so the changes made by the server to those properties (e,g: node counts
+// managed by auto-scaling) never show up as drift. The remote values are kept for the properties that do not have a value in
+// the state yet (e,g: computed properties after the resource is created or imported)
+func ignoreServerChanges(openAPIResource SpecResource, remoteData map[string]interface{}, resourceLocalData *schema.ResourceData) (map[string]interface{}, error) {
+	resourceSchema, err := openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	var filteredData map[string]interface{}
+	for _, property := range resourceSchema.Properties {
+		if !property.IgnoreServerChanges {
+			continue
+		}
+		if _, exists := remoteData[property.Name]; !exists {
+			continue
+		}
+		if _, ok := resourceLocalData.GetOk(property.GetTerraformCompliantPropertyName()); !ok {
+			continue
+		}
+		if filteredData == nil {
+			filteredData = make(map[string]interface{}, len(remoteData))
+			for k, v := range remoteData {
+				filteredData[k] = v
+			}
+		}
+		resourceLog.Debug("ignoring the server value of the property '%s' since it has the %s extension", property.Name, extTfIgnoreServerChanges)
+		delete(filteredData, property.Name)
+	}
+	if filteredData == nil {
+		return remoteData, nil
+	}
+	return filteredData, nil
+}
+
+// isRevisionUnchanged returns true if the resource has the x-terraform-revision-property extension and the revision returned
+// by the API matches the revision stored in the state. Only the revision property is requested to the API (sparse fieldset)
+// so the refresh of resources that rarely change does not need to perform the full read. False is returned if the revision
+// can not be retrieved, in which case the full read is performed.
+func (r resourceFactory) isRevisionUnchanged(data *schema.ResourceData, providerClient ClientOpenAPI, parentIDs ...string) bool {
+	revisionProperty := r.getRevisionProperty()
+	if revisionProperty == "" || data.Id() == "" {
+		return false
+	}
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return false
+	}
+	property, err := resourceSchema.getProperty(revisionProperty)
+	if err != nil {
+		return false
+	}
+	currentRevision, exists := data.GetOk(property.GetTerraformCompliantPropertyName())
+	if !exists {
+		return false
+	}
+	responsePayload := map[string]interface{}{}
+	resp, err := providerClient.GetFields(r.openAPIResource, data.Id(), []string{revisionProperty}, &responsePayload, parentIDs...)
+	if err == nil {
+		err = checkHTTPStatusCode(r.openAPIResource, resp, []int{http.StatusOK})
+	}
+	if err != nil {
+		resourceLog.Debug("failed to read '%s' (%s) revision, performing the full read: %s", r.openAPIResource.GetResourceName(), data.Id(), err)
+		return false
+	}
+	remoteRevision, exists := responsePayload[revisionProperty]
+	if !exists {
+		resourceLog.Warn("'%s' (%s) response does not contain the revision property '%s', performing the full read", r.openAPIResource.GetResourceName(), data.Id(), revisionProperty)
+		return false
+	}
+	return getRevisionString(remoteRevision) == getRevisionString(currentRevision)
+}
+
+// getRevisionString returns the string representation of the revision value passed in. Numeric revisions decoded from
+// JSON (float64) are represented the same way as the ones stored in the state (int)
+func getRevisionString(revision interface{}) string {
+	if number, ok := revision.(float64); ok && number == math.Trunc(number) {
+		return strconv.FormatInt(int64(number), 10)
+	}
+	return fmt.Sprintf("%v", revision)
+}
+
 func (r resourceFactory) read(data *schema.ResourceData, i interface{}) error {
 	return r.readWithOptions(data, i, false)
 }
@@ -173,10 +401,94 @@ func (r resourceFactory) readRemote(id string, providerClient ClientOpenAPI, par
 		return nil, err
 	}
 
-	log.Printf("[DEBUG] GET '%s' response received", r.openAPIResource.GetResourceName())
+	resourceLog.Debug("GET '%s' response received", r.openAPIResource.GetResourceName())
 	return responsePayload, nil
 }
 
+// readRemoteIfNoneMatch reads the resource instance performing a conditional GET request with the eTag provided. If the
+// API responds with 304 Not Modified, notModified is returned as true and the remote data returned is nil. Otherwise,
+// the remote data is returned along with the ETag returned by the API (if any).
+func (r resourceFactory) readRemoteIfNoneMatch(id string, eTag string, providerClient ClientOpenAPI, parentIDs ...string) (remoteData map[string]interface{}, newETag string, notModified bool, err error) {
+	responsePayload := map[string]interface{}{}
+	resp, err := providerClient.GetIfNoneMatch(r.openAPIResource, id, eTag, &responsePayload, parentIDs...)
+	if err != nil {
+		return nil, "", false, err
+	}
+	if eTag != "" && resp.StatusCode == http.StatusNotModified {
+		return nil, eTag, true, nil
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, resp, []int{http.StatusOK}); err != nil {
+		return nil, "", false, err
+	}
+	resourceLog.Debug("GET '%s' response received", r.openAPIResource.GetResourceName())
+	return responsePayload, resp.Header.Get(eTagHeader), false, nil
+}
+
+// readRemoteBatched reads the resource instance from the resource collection if batch read is enabled for the resource,
+// cutting down the number of GET calls performed when refreshing many instances of the same resource. If batch read is not
+// enabled, the collection can not be retrieved or it does not contain the instance, the instance is read via readRemote.
+func (r resourceFactory) readRemoteBatched(id string, providerClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, error) {
+	if r.batchReadCache == nil {
+		return r.readRemote(id, providerClient, parentIDs...)
+	}
+	ttl, err := r.openAPIResource.getBatchReadTTL()
+	if err != nil || ttl == nil {
+		return r.readRemote(id, providerClient, parentIDs...)
+	}
+	remoteData, exists, err := r.batchReadCache.get(providerClient, parentIDs, id, *ttl, func() (map[string]map[string]interface{}, error) {
+		return r.listRemote(providerClient, parentIDs...)
+	})
+	if err != nil {
+		resourceLog.Warn("batch read of resource '%s' failed, falling back to GET '%s': %s", r.openAPIResource.GetResourceName(), id, err)
+		return r.readRemote(id, providerClient, parentIDs...)
+	}
+	if !exists {
+		resourceLog.Debug("resource '%s' collection does not contain '%s', falling back to GET", r.openAPIResource.GetResourceName(), id)
+		return r.readRemote(id, providerClient, parentIDs...)
+	}
+	return remoteData, nil
+}
+
+// listRemote returns the resource collection items indexed by the resource identifier
+func (r resourceFactory) listRemote(providerClient ClientOpenAPI, parentIDs ...string) (map[string]map[string]interface{}, error) {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	identifierProperty, err := resourceSchema.getResourceIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	responsePayload := []map[string]interface{}{}
+	resp, err := providerClient.List(r.openAPIResource, &responsePayload, parentIDs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, resp, []int{http.StatusOK}); err != nil {
+		return nil, err
+	}
+	items := make(map[string]map[string]interface{}, len(responsePayload))
+	for _, item := range responsePayload {
+		resourceSchema.resolveResourceIdentifierAliases(item)
+		switch id := item[identifierProperty].(type) {
+		case int:
+			items[strconv.Itoa(id)] = item
+		case float64:
+			items[strconv.Itoa(int(id))] = item
+		case string:
+			items[id] = item
+		}
+	}
+	resourceLog.Debug("GET '%s' collection response received (%d items)", r.openAPIResource.GetResourceName(), len(items))
+	return items, nil
+}
+
+func (r resourceFactory) invalidateBatchReadCache(providerClient ClientOpenAPI, parentIDs []string) {
+	if r.batchReadCache != nil {
+		r.batchReadCache.invalidate(providerClient, parentIDs)
+	}
+}
+
 func (r resourceFactory) getParentIDs(data *schema.ResourceData) ([]string, error) {
 	if r.openAPIResource == nil {
 		return []string{}, errors.New("can't get parent ids from a resourceFactory with no openAPIResource")
@@ -214,19 +526,59 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 	if err != nil {
 		return err
 	}
+	defer r.invalidateBatchReadCache(providerClient, parentsIDs)
 
 	operation := r.openAPIResource.getResourceOperations().Put
 	if operation == nil {
 		return fmt.Errorf("[resource='%s'] resource does not support PUT operation, check the swagger file exposed on '%s'", r.openAPIResource.GetResourceName(), resourcePath)
 	}
+	releaseLock, err := r.acquireLock(providerClient, operation, data.Timeout(schema.TimeoutUpdate), getLockIDs(parentsIDs, data.Id())...)
+	if err != nil {
+		return err
+	}
+	defer releaseLock()
 	requestPayload := r.createPayloadFromLocalStateData(data)
-	if err := r.checkImmutableFields(data, providerClient, parentsIDs...); err != nil {
+	if err := r.resolveNames(data, providerClient, requestPayload, parentsIDs...); err != nil {
+		return err
+	}
+	if err := r.uploadFiles(data, providerClient, requestPayload, parentsIDs...); err != nil {
 		return err
 	}
+	r.excludeMergeUpdateStrategyProperties(requestPayload)
+	updateStrategyDeltas, err := r.getUpdateStrategyDeltas(data)
+	if err != nil {
+		return err
+	}
+	remoteData, err := r.checkImmutableFields(data, providerClient, parentsIDs...)
+	if err != nil {
+		return err
+	}
+	if operation.skipUnchangedUpdate && len(updateStrategyDeltas) == 0 && isUpdatePayloadUnchanged(requestPayload, remoteData) {
+		resourceLog.Info("'%s' (%s) remote representation already matches the desired state, skipping the update", resourceName, data.Id())
+		return r.updateStateWithPayloadData(remoteData, data)
+	}
+	if r.isConditionalGetEnabled() {
+		// the ETag stored no longer matches the remote representation once the resource is updated
+		if err := data.Set(resourceETagPropertyName, ""); err != nil {
+			return err
+		}
+	}
+	if err := r.applyUpdateStrategyDeltas(providerClient, resourcePath, data.Id(), updateStrategyDeltas, parentsIDs...); err != nil {
+		return err
+	}
+	var updatePayload interface{} = requestPayload
+	switch {
+	case operation.isMergePatch():
+		updatePayload = r.createMergePatch(data, requestPayload)
+		resourceLog.Debug("'%s' (%s) update sent as merge patch: %s", resourceName, data.Id(), sPrettyPrint(updatePayload))
+	case operation.isJSONPatch():
+		updatePayload = r.createJSONPatch(data, requestPayload)
+		resourceLog.Debug("'%s' (%s) update sent as JSON patch: %s", resourceName, data.Id(), sPrettyPrint(updatePayload))
+	}
 
 	if operation.responses.getResponse(http.StatusNoContent) != nil {
 		// Don't populate responsePayload if the API's successful update response is 204 No Content
-		res, err := providerClient.Put(r.openAPIResource, data.Id(), requestPayload, nil, parentsIDs...)
+		res, err := providerClient.Put(r.openAPIResource, data.Id(), updatePayload, nil, parentsIDs...)
 		if err != nil {
 			return err
 		}
@@ -237,11 +589,20 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 		if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusNoContent}); err != nil {
 			return fmt.Errorf("[resource='%s'] UPDATE %s/%s failed: %s", r.openAPIResource.GetResourceName(), resourcePath, data.Id(), err)
 		}
-		return nil
+		remoteData, err := r.waitFor(data, providerClient, operation, nil, schema.TimeoutUpdate, parentsIDs...)
+		if err != nil {
+			return err
+		}
+		if remoteData != nil {
+			if err := r.updateStateWithPayloadData(remoteData, data); err != nil {
+				return err
+			}
+		}
+		return r.verifyWrite(data, providerClient, "update", requestPayload, parentsIDs...)
 	}
 
 	var responsePayload map[string]interface{}
-	res, err := providerClient.Put(r.openAPIResource, data.Id(), requestPayload, &responsePayload, parentsIDs...)
+	res, err := providerClient.Put(r.openAPIResource, data.Id(), updatePayload, &responsePayload, parentsIDs...)
 	if err != nil {
 		return err
 	}
@@ -249,12 +610,18 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 		return fmt.Errorf("[resource='%s'] UPDATE %s/%s failed: %s", r.openAPIResource.GetResourceName(), resourcePath, data.Id(), err)
 	}
 
-	err = r.handlePollingIfConfigured(&responsePayload, data, providerClient, operation, res.StatusCode, schema.TimeoutUpdate)
+	err = r.handlePollingIfConfigured(&responsePayload, data, providerClient, operation, res.StatusCode, schema.TimeoutUpdate, parentsIDs...)
 	if err != nil {
 		return fmt.Errorf("polling mechanism failed after PUT %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
 	}
+	if responsePayload, err = r.waitFor(data, providerClient, operation, responsePayload, schema.TimeoutUpdate, parentsIDs...); err != nil {
+		return err
+	}
 
-	return updateStateWithPayloadData(r.openAPIResource, responsePayload, data)
+	if err := r.updateStateWithPayloadData(responsePayload, data); err != nil {
+		return err
+	}
+	return r.verifyWrite(data, providerClient, "update", requestPayload, parentsIDs...)
 }
 
 func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error {
@@ -271,11 +638,17 @@ func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error
 	if err != nil {
 		return err
 	}
+	defer r.invalidateBatchReadCache(providerClient, parentsIDs)
 
 	operation := r.openAPIResource.getResourceOperations().Delete
 	if operation == nil {
 		return fmt.Errorf("[resource='%s'] resource does not support DELETE operation, check the swagger file exposed on '%s'", r.openAPIResource.GetResourceName(), resourcePath)
 	}
+	releaseLock, err := r.acquireLock(providerClient, operation, data.Timeout(schema.TimeoutDelete), getLockIDs(parentsIDs, data.Id())...)
+	if err != nil {
+		return err
+	}
+	defer releaseLock()
 	res, err := providerClient.Delete(r.openAPIResource, data.Id(), parentsIDs...)
 	if err != nil {
 		return err
@@ -289,7 +662,7 @@ func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error
 		return fmt.Errorf("[resource='%s'] DELETE %s/%s failed: %s", r.openAPIResource.GetResourceName(), resourcePath, data.Id(), err)
 	}
 
-	err = r.handlePollingIfConfigured(nil, data, providerClient, operation, res.StatusCode, schema.TimeoutDelete)
+	err = r.handlePollingIfConfigured(nil, data, providerClient, operation, res.StatusCode, schema.TimeoutDelete, parentsIDs...)
 	if err != nil {
 		return fmt.Errorf("polling mechanism failed after DELETE %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
 	}
@@ -346,7 +719,7 @@ func (r resourceFactory) importer() *schema.ResourceImporter {
 	}
 }
 
-func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]interface{}, resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, responseStatusCode int, timeoutFor string) error {
+func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]interface{}, resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, responseStatusCode int, timeoutFor string, parentIDs ...string) error {
 	response := operation.responses.getResponse(responseStatusCode)
 
 	if response == nil || !response.isPollingEnabled {
@@ -363,19 +736,30 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 	// will be overridden
 	if responsePayload == nil {
 		if len(targetStatuses) > 0 {
-			log.Printf("[WARN] resource speficied poll target statuses for a DELETE operation. This is not expected as the normal behaviour is the resource to no longer exists once the DELETE operation is completed; hence subsequent GET calls should return 404 NotFound instead")
+			pollerLog.Warn("resource speficied poll target statuses for a DELETE operation. This is not expected as the normal behaviour is the resource to no longer exists once the DELETE operation is completed; hence subsequent GET calls should return 404 NotFound instead")
 		}
-		log.Printf("[WARN] overriding target status with default destroy status")
+		pollerLog.Warn("overriding target status with default destroy status")
 		targetStatuses = []string{defaultDestroyStatus}
 	}
 
-	log.Printf("[DEBUG] target statuses (%s); pending statuses (%s)", targetStatuses, pendingStatuses)
-	log.Printf("[INFO] Waiting for resource '%s' to reach a completion status (%s)", r.openAPIResource.GetResourceName(), targetStatuses)
+	// The status of the resources polled via poll requests with success expression is resolved by the expressions
+	if response.pollRequest.isExpressionBased() {
+		pendingStatuses = []string{pollPendingStatus}
+		if responsePayload != nil {
+			targetStatuses = []string{pollSucceededStatus}
+		} else {
+			targetStatuses = []string{pollSucceededStatus, defaultDestroyStatus}
+		}
+	}
+
+	pollerLog.Debug("target statuses (%s); pending statuses (%s)", targetStatuses, pendingStatuses)
+	pollerLog.Info("Waiting for resource '%s' to reach a completion status (%s)", r.openAPIResource.GetResourceName(), targetStatuses)
 
+	progress := newPollProgress(r.openAPIResource.GetResourceName(), resourceLocalData.Id())
 	stateConf := &resource.StateChangeConf{
 		Pending:      pendingStatuses,
 		Target:       targetStatuses,
-		Refresh:      r.resourceStateRefreshFunc(resourceLocalData, providerClient),
+		Refresh:      progress.track(r.resourceStateRefreshFunc(resourceLocalData, providerClient, response.pollRequest, parentIDs...)),
 		Timeout:      resourceLocalData.Timeout(timeoutFor),
 		PollInterval: r.defaultPollInterval,
 		MinTimeout:   r.defaultPollMinTimeout,
@@ -384,9 +768,26 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 
 	// Wait, catching any errors
 	remoteData, err := stateConf.WaitForState()
+	if operation.recordProvisioningEvents && responsePayload != nil {
+		// the events are recorded even if the polling failed so the statuses observed are available in the state
+		if setErr := resourceLocalData.Set(provisioningEventsPropertyName, progress.getEvents(timeoutFor)); setErr != nil {
+			return setErr
+		}
+	}
 	if err != nil {
+		if _, ok := err.(*resource.TimeoutError); ok {
+			return fmt.Errorf("error waiting for resource to reach a completion status (%s) [valid pending statuses (%s)]: %s [%s]", targetStatuses, pendingStatuses, err, progress)
+		}
 		return fmt.Errorf("error waiting for resource to reach a completion status (%s) [valid pending statuses (%s)]: %s", targetStatuses, pendingStatuses, err)
 	}
+	if responsePayload != nil && response.pollRequest != nil {
+		// the response payload of the poll request is the status of the resource, so the resource is read once polling completes
+		remoteDataRead, err := r.readRemote(resourceLocalData.Id(), providerClient, parentIDs...)
+		if err != nil {
+			return fmt.Errorf("error reading resource '%s' (%s) after polling completed: %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), err)
+		}
+		remoteData = remoteDataRead
+	}
 	if responsePayload != nil {
 		remoteDataCasted, ok := remoteData.(map[string]interface{})
 		if ok {
@@ -398,10 +799,16 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 	return nil
 }
 
-func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI) resource.StateRefreshFunc {
+func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, pollRequest *specPollRequest, parentIDs ...string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 
-		remoteData, err := r.readRemote(resourceLocalData.Id(), providerClient)
+		var remoteData map[string]interface{}
+		var err error
+		if pollRequest != nil {
+			remoteData, err = r.performPollRequest(resourceLocalData.Id(), providerClient, pollRequest, parentIDs...)
+		} else {
+			remoteData, err = r.readRemote(resourceLocalData.Id(), providerClient, parentIDs...)
+		}
 		if err != nil {
 			if openapiErr, ok := err.(openapierr.Error); ok {
 				if openapierr.NotFound == openapiErr.Code() {
@@ -411,20 +818,41 @@ func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.Reso
 			return nil, "", fmt.Errorf("error on retrieving resource '%s' (%s) when waiting: %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), err)
 		}
 
-		newStatus, err := r.getStatusValueFromPayload(remoteData)
+		var newStatus string
+		if pollRequest.isExpressionBased() {
+			newStatus, err = pollRequest.getStatus(remoteData)
+		} else {
+			newStatus, err = r.getStatusValueFromPayload(remoteData)
+		}
 		if err != nil {
 			return nil, "", fmt.Errorf("error occurred while retrieving status identifier value from payload for resource '%s' (%s): %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), err)
 		}
 
-		log.Printf("[DEBUG] resource status '%s' (%s): %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), newStatus)
+		pollerLog.Debug("resource status '%s' (%s): %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), newStatus)
 		return remoteData, newStatus, nil
 	}
 }
 
-func (r resourceFactory) checkImmutableFields(updatedResourceLocalData *schema.ResourceData, openAPIClient ClientOpenAPI, parentIDs ...string) error {
+// performPollRequest performs the custom poll request (x-terraform-resource-poll-request) returning its response payload
+func (r resourceFactory) performPollRequest(id string, providerClient ClientOpenAPI, pollRequest *specPollRequest, parentIDs ...string) (map[string]interface{}, error) {
+	responsePayload := map[string]interface{}{}
+	resp, err := providerClient.Poll(r.openAPIResource, id, pollRequest, &responsePayload, parentIDs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, resp, []int{http.StatusOK}); err != nil {
+		return nil, err
+	}
+	pollerLog.Debug("%s poll request '%s' response received", pollRequest.method, r.openAPIResource.GetResourceName())
+	return responsePayload, nil
+}
+
+// checkImmutableFields reads the remote representation of the resource and checks that the update does not change any of
+// the immutable properties. The remote data read is returned so it can be reused by the update
+func (r resourceFactory) checkImmutableFields(updatedResourceLocalData *schema.ResourceData, openAPIClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, error) {
 	remoteData, err := r.readRemote(updatedResourceLocalData.Id(), openAPIClient, parentIDs...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	localData := r.createPayloadFromLocalStateData(updatedResourceLocalData)
 	s, _ := r.openAPIResource.GetResourceSchema()
@@ -433,14 +861,49 @@ func (r resourceFactory) checkImmutableFields(updatedResourceLocalData *schema.R
 		if err != nil {
 			// Rolling back data so tf values are not stored in the state file; otherwise terraform would store the
 			// data inside the updated (*schema.ResourceData) in the state file
-			updateError := updateStateWithPayloadData(r.openAPIResource, remoteData, updatedResourceLocalData)
+			updateError := r.updateStateWithPayloadData(remoteData, updatedResourceLocalData)
 			if updateError != nil {
-				return updateError
+				return nil, updateError
 			}
-			return fmt.Errorf("validation for immutable properties failed: %s. Update operation was aborted; no updates were performed", err)
+			return nil, fmt.Errorf("validation for immutable properties failed: %s. Update operation was aborted; no updates were performed", err)
 		}
 	}
-	return nil
+	return remoteData, nil
+}
+
+// isUpdatePayloadUnchanged returns true if all the properties of the update request payload match the ones in the remote
+// data. Both are normalized to their JSON representation before being compared so values decoded from the API responses
+// (e,g: numbers decoded as float64) match the ones in the request payload. Properties not returned by the API (e,g: write
+// only properties) are considered changed
+func isUpdatePayloadUnchanged(requestPayload, remoteData map[string]interface{}) bool {
+	normalizedRequestPayload, err := normalizeJSONPayload(requestPayload)
+	if err != nil {
+		return false
+	}
+	normalizedRemoteData, err := normalizeJSONPayload(remoteData)
+	if err != nil {
+		return false
+	}
+	for propertyName, desiredValue := range normalizedRequestPayload {
+		remoteValue, exists := normalizedRemoteData[propertyName]
+		if !exists || !reflect.DeepEqual(desiredValue, remoteValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeJSONPayload returns the payload as decoded from its JSON representation
+func normalizeJSONPayload(payload map[string]interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	normalizedPayload := map[string]interface{}{}
+	if err := json.Unmarshal(b, &normalizedPayload); err != nil {
+		return nil, err
+	}
+	return normalizedPayload, nil
 }
 
 func (r resourceFactory) validateImmutableProperty(property *SpecSchemaDefinitionProperty, remoteData interface{}, localData interface{}, checkObjectPropertiesUpdates bool) error {
@@ -519,6 +982,7 @@ func (r resourceFactory) validateImmutableProperty(property *SpecSchemaDefinitio
 // as the input is concerned.
 func (r resourceFactory) createPayloadFromLocalStateData(resourceLocalData *schema.ResourceData) map[string]interface{} {
 	input := map[string]interface{}{}
+	logger := resourceLog.With("resource", r.openAPIResource.GetResourceName())
 	resourceSchema, _ := r.openAPIResource.GetResourceSchema()
 	for _, property := range resourceSchema.Properties {
 		propertyName := property.Name
@@ -530,13 +994,13 @@ func (r resourceFactory) createPayloadFromLocalStateData(resourceLocalData *sche
 			if dataValue, ok := r.getResourceDataOKExists(*property, resourceLocalData); ok {
 				err := r.populatePayload(input, property, dataValue)
 				if err != nil {
-					log.Printf("[ERROR] [resource='%s'] error when creating the property payload for property '%s': %s", r.openAPIResource.GetResourceName(), propertyName, err)
+					logger.Error("error when creating the property payload for property '%s': %s", propertyName, err)
 				}
 			}
-			log.Printf("[DEBUG] [resource='%s'] property payload [propertyName: %s; propertyValue: %+v]", r.openAPIResource.GetResourceName(), propertyName, input[propertyName])
+			logger.Debug("property payload [propertyName: %s; propertyValue: %+v]", propertyName, input[propertyName])
 		}
 	}
-	log.Printf("[DEBUG] [resource='%s'] createPayloadFromLocalStateData: %s", r.openAPIResource.GetResourceName(), sPrettyPrint(input))
+	logger.Debug("createPayloadFromLocalStateData: %s", sPrettyPrint(input))
 	return input
 }
 
@@ -553,8 +1017,11 @@ func (r resourceFactory) populatePayload(input map[string]interface{}, property
 	dataValueKind := reflect.TypeOf(dataValue).Kind()
 	switch dataValueKind {
 	case reflect.Map:
+		mapValue, ok := dataValue.(map[string]interface{})
+		if !ok || property.SpecSchemaDefinition == nil {
+			return fmt.Errorf("property '%s' of type %s can not be populated with an object value", property.Name, property.Type)
+		}
 		objectInput := map[string]interface{}{}
-		mapValue := dataValue.(map[string]interface{})
 		for propertyName, propertyValue := range mapValue {
 			schemaDefinitionProperty, err := property.SpecSchemaDefinition.getPropertyBasedOnTerraformName(propertyName)
 			if err != nil {
@@ -566,14 +1033,17 @@ func (r resourceFactory) populatePayload(input map[string]interface{}, property
 		}
 		input[property.Name] = objectInput
 	case reflect.Slice, reflect.Array:
+		arrayValue, ok := dataValue.([]interface{})
+		if !ok {
+			return fmt.Errorf("property '%s' of type %s can not be populated with a %T value", property.Name, property.Type, dataValue)
+		}
 		if isListOfPrimitives, _ := property.isTerraformListOfSimpleValues(); isListOfPrimitives {
-			input[property.Name] = dataValue.([]interface{})
+			input[property.Name] = arrayValue
 		} else {
 			// This is the work around put in place to have support for complex objects. In this case, because the
 			// state representation of nested objects is an array, we need to make sure we don't end up constructing an
 			// array but rather just a json object
 			if property.shouldUseLegacyTerraformSDKBlockApproachForComplexObjects() {
-				arrayValue := dataValue.([]interface{})
 				if len(arrayValue) != 1 {
 					return fmt.Errorf("something is really wrong here...an object property with nested objects should have exactly one elem in the terraform state list")
 				}
@@ -582,7 +1052,6 @@ func (r resourceFactory) populatePayload(input map[string]interface{}, property
 				}
 			} else {
 				arrayInput := []interface{}{}
-				arrayValue := dataValue.([]interface{})
 				for _, arrayItem := range arrayValue {
 					objectInput := map[string]interface{}{}
 					if err := r.populatePayload(objectInput, property, arrayItem); err != nil {
@@ -639,5 +1108,12 @@ func (r resourceFactory) getStatusValueFromPayload(payload map[string]interface{
 
 // getResourceDataOK returns the data for the given schemaDefinitionPropertyName using the terraform compliant property name
 func (r resourceFactory) getResourceDataOKExists(schemaDefinitionProperty SpecSchemaDefinitionProperty, resourceLocalData *schema.ResourceData) (interface{}, bool) {
+	// the value of the attribute alias configured takes preference since the properties with aliases are computed, and
+	// therefore keep the value in the state if the property is configured via its alias
+	for _, alias := range schemaDefinitionProperty.AttributeAliases {
+		if value, exists := resourceLocalData.GetOkExists(alias); exists {
+			return value, true
+		}
+	}
 	return resourceLocalData.GetOkExists(schemaDefinitionProperty.GetTerraformCompliantPropertyName())
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/dikhan/terraform-provider-openapi/v3/openapi/openapierr"
 	"github.com/go-openapi/spec"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -476,6 +477,424 @@ func TestReadRemote(t *testing.T) {
 	})
 }
 
+func TestReadRemoteBatched(t *testing.T) {
+	ttl := time.Minute
+	testCases := []struct {
+		name              string
+		batchReadTTL      *time.Duration
+		client            *clientOpenAPIStub
+		ids               []string
+		expectedResponses []map[string]interface{}
+		expectedListCalls int
+		expectedGetCalls  int
+		expectedParentIDs []string
+		parentIDs         []string
+		expectedErr       string
+	}{
+		{
+			name:         "batch read not enabled",
+			batchReadTTL: nil,
+			client: &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{idProperty.Name: "1"},
+			},
+			ids:               []string{"1", "1"},
+			expectedResponses: []map[string]interface{}{{idProperty.Name: "1"}, {idProperty.Name: "1"}},
+			expectedListCalls: 0,
+			expectedGetCalls:  2,
+		},
+		{
+			name:         "batch read enabled serves the reads from one single collection call",
+			batchReadTTL: &ttl,
+			client: &clientOpenAPIStub{
+				responseListPayload: []map[string]interface{}{
+					{idProperty.Name: "1", stringProperty.Name: "value1"},
+					{idProperty.Name: float64(2), stringProperty.Name: "value2"},
+					{idProperty.Name: 3, stringProperty.Name: "value3"},
+				},
+			},
+			ids: []string{"1", "2", "3"},
+			expectedResponses: []map[string]interface{}{
+				{idProperty.Name: "1", stringProperty.Name: "value1"},
+				{idProperty.Name: float64(2), stringProperty.Name: "value2"},
+				{idProperty.Name: 3, stringProperty.Name: "value3"},
+			},
+			expectedListCalls: 1,
+			expectedGetCalls:  0,
+		},
+		{
+			name:         "batch read enabled for a subresource",
+			batchReadTTL: &ttl,
+			client: &clientOpenAPIStub{
+				responseListPayload: []map[string]interface{}{{idProperty.Name: "1"}},
+			},
+			parentIDs:         []string{"parentID"},
+			ids:               []string{"1", "1"},
+			expectedResponses: []map[string]interface{}{{idProperty.Name: "1"}, {idProperty.Name: "1"}},
+			expectedListCalls: 1,
+			expectedGetCalls:  0,
+			expectedParentIDs: []string{"parentID"},
+		},
+		{
+			name:         "batch read enabled and the collection does not contain the instance",
+			batchReadTTL: &ttl,
+			client: &clientOpenAPIStub{
+				responseListPayload: []map[string]interface{}{{idProperty.Name: "1"}},
+				responsePayload:     map[string]interface{}{idProperty.Name: "2"},
+			},
+			ids:               []string{"2"},
+			expectedResponses: []map[string]interface{}{{idProperty.Name: "2"}},
+			expectedListCalls: 1,
+			expectedGetCalls:  1,
+		},
+		{
+			name:         "batch read enabled and the collection call fails",
+			batchReadTTL: &ttl,
+			client: &clientOpenAPIStub{
+				returnHTTPCode: http.StatusInternalServerError,
+			},
+			ids:               []string{"1"},
+			expectedListCalls: 1,
+			expectedGetCalls:  1,
+			expectedErr:       "[resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200] ()",
+		},
+	}
+	for _, tc := range testCases {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		r.openAPIResource.(*specStubResource).batchReadTTL = tc.batchReadTTL
+		for i, id := range tc.ids {
+			response, err := r.readRemoteBatched(id, tc.client, tc.parentIDs...)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr, tc.name)
+				continue
+			}
+			assert.NoError(t, err, tc.name)
+			assert.Equal(t, tc.expectedResponses[i], response, tc.name)
+		}
+		assert.Equal(t, tc.expectedListCalls, tc.client.listCalls, tc.name)
+		assert.Equal(t, tc.expectedGetCalls, tc.client.getCalls, tc.name)
+		if tc.expectedParentIDs != nil {
+			assert.Equal(t, tc.expectedParentIDs, tc.client.parentIDsReceived, tc.name)
+		}
+	}
+}
+
+func TestBatchReadCacheInvalidatedOnWrite(t *testing.T) {
+	ttl := time.Minute
+	r, resourceData := testCreateResourceFactory(t, idProperty, stringProperty)
+	r.openAPIResource.(*specStubResource).batchReadTTL = &ttl
+	client := &clientOpenAPIStub{
+		responsePayload:     map[string]interface{}{idProperty.Name: "1", stringProperty.Name: "value"},
+		responseListPayload: []map[string]interface{}{{idProperty.Name: "1", stringProperty.Name: "value"}},
+	}
+	assert.NoError(t, r.create(resourceData, client))
+	assert.NoError(t, r.read(resourceData, client))
+	assert.NoError(t, r.read(resourceData, client))
+	assert.Equal(t, 1, client.listCalls)
+
+	assert.NoError(t, r.update(resourceData, client))
+	assert.NoError(t, r.read(resourceData, client))
+	assert.Equal(t, 2, client.listCalls, "update should invalidate the cached collection")
+
+	assert.NoError(t, r.delete(resourceData, client))
+	_, err := r.readRemoteBatched("1", client)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, client.listCalls, "delete should invalidate the cached collection")
+}
+
+func TestCreateTerraformResourceSchemaWithConditionalGet(t *testing.T) {
+	testCases := []struct {
+		name          string
+		properties    []*SpecSchemaDefinitionProperty
+		expectedError string
+	}{
+		{
+			name:       "conditional GET enabled adds the computed ETag property",
+			properties: []*SpecSchemaDefinitionProperty{idProperty, stringProperty},
+		},
+		{
+			name:          "conditional GET enabled and the schema already contains a property with the ETag property name",
+			properties:    []*SpecSchemaDefinitionProperty{idProperty, newStringSchemaDefinitionPropertyWithDefaults(resourceETagPropertyName, "", false, true, nil)},
+			expectedError: "resource 'resourceName' has x-terraform-resource-conditional-get enabled but the schema already contains a property named 'resource_etag'",
+		},
+	}
+	for _, tc := range testCases {
+		r, _ := testCreateResourceFactory(t, tc.properties...)
+		r.openAPIResource.getResourceOperations().Get.isConditionalGetEnabled = true
+		s, err := r.createTerraformResourceSchema()
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Contains(t, s, resourceETagPropertyName, tc.name)
+		assert.True(t, s[resourceETagPropertyName].Computed, tc.name)
+		assert.Equal(t, schema.TypeString, s[resourceETagPropertyName].Type, tc.name)
+	}
+}
+
+func TestReadWithConditionalGet(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		currentETag          string
+		client               *clientOpenAPIStub
+		expectedETagReceived string
+		expectedStringValue  string
+		expectedETag         string
+		expectedErr          string
+	}{
+		{
+			name: "resource without ETag stored performs a full read and stores the ETag returned",
+			client: &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue"},
+				responseETag:    `"v1"`,
+			},
+			expectedStringValue: "remoteValue",
+			expectedETag:        `"v1"`,
+		},
+		{
+			name:        "resource with ETag stored and API responding with 304 Not Modified skips the state update",
+			currentETag: `"v1"`,
+			client: &clientOpenAPIStub{
+				returnHTTPCode: http.StatusNotModified,
+				responseETag:   `"v1"`,
+			},
+			expectedETagReceived: `"v1"`,
+			expectedStringValue:  stringProperty.Default.(string),
+			expectedETag:         `"v1"`,
+		},
+		{
+			name:        "resource with ETag stored and API responding with 200 updates the state and the ETag",
+			currentETag: `"v1"`,
+			client: &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue"},
+				responseETag:    `"v2"`,
+			},
+			expectedETagReceived: `"v1"`,
+			expectedStringValue:  "remoteValue",
+			expectedETag:         `"v2"`,
+		},
+		{
+			name:        "resource with ETag stored and API responding with non expected status code",
+			currentETag: `"v1"`,
+			client: &clientOpenAPIStub{
+				returnHTTPCode: http.StatusInternalServerError,
+			},
+			expectedETagReceived: `"v1"`,
+			expectedErr:          "[resource='resourceName'] GET /v1/resource/id failed: [resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200] ()",
+		},
+	}
+	for _, tc := range testCases {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		r.openAPIResource.getResourceOperations().Get.isConditionalGetEnabled = true
+		resourceSchema, err := r.createTerraformResourceSchema()
+		assert.NoError(t, err, tc.name)
+		resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{stringProperty.Name: stringProperty.Default})
+		resourceData.SetId("id")
+		assert.NoError(t, resourceData.Set(resourceETagPropertyName, tc.currentETag), tc.name)
+
+		err = r.read(resourceData, tc.client)
+		assert.Equal(t, tc.expectedETagReceived, tc.client.eTagReceived, tc.name)
+		if tc.expectedErr != "" {
+			assert.EqualError(t, err, tc.expectedErr, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedStringValue, resourceData.Get(stringProperty.Name), tc.name)
+		assert.Equal(t, tc.expectedETag, resourceData.Get(resourceETagPropertyName), tc.name)
+	}
+}
+
+func TestCreateTerraformResourceSchemaWithRevisionProperty(t *testing.T) {
+	revisionProperty := newIntSchemaDefinitionPropertyWithDefaults("revision", "", false, true, nil)
+	r, _ := testCreateResourceFactory(t, idProperty, stringProperty, revisionProperty)
+	r.openAPIResource.getResourceOperations().Get.revisionProperty = "revision"
+	_, err := r.createTerraformResourceSchema()
+	assert.NoError(t, err)
+
+	r.openAPIResource.getResourceOperations().Get.revisionProperty = "non_existing"
+	_, err = r.createTerraformResourceSchema()
+	assert.EqualError(t, err, "resource 'resourceName' x-terraform-revision-property extension not valid: property with name 'non_existing' not existing in resource schema definition")
+}
+
+func TestReadWithRevisionProperty(t *testing.T) {
+	revisionProperty := newIntSchemaDefinitionPropertyWithDefaults("revision", "", false, true, nil)
+	testCases := []struct {
+		name                   string
+		currentRevision        interface{}
+		client                 *clientOpenAPIStub
+		expectedGetFieldsCalls int
+		expectedGetCalls       int
+		expectedStringValue    string
+		expectedRevision       int
+	}{
+		{
+			name:                   "resource without revision stored performs the full read",
+			client:                 &clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue", "revision": float64(2)}},
+			expectedGetFieldsCalls: 0,
+			expectedGetCalls:       1,
+			expectedStringValue:    "remoteValue",
+			expectedRevision:       2,
+		},
+		{
+			name:                   "resource with the same revision stored skips the full read",
+			currentRevision:        2,
+			client:                 &clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue", "revision": float64(2)}},
+			expectedGetFieldsCalls: 1,
+			expectedGetCalls:       0,
+			expectedStringValue:    stringProperty.Default.(string),
+			expectedRevision:       2,
+		},
+		{
+			name:                   "resource with an older revision stored performs the full read",
+			currentRevision:        1,
+			client:                 &clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue", "revision": float64(2)}},
+			expectedGetFieldsCalls: 1,
+			expectedGetCalls:       1,
+			expectedStringValue:    "remoteValue",
+			expectedRevision:       2,
+		},
+		{
+			name:                   "API response not containing the revision performs the full read",
+			currentRevision:        1,
+			client:                 &clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "remoteValue"}},
+			expectedGetFieldsCalls: 1,
+			expectedGetCalls:       1,
+			expectedStringValue:    "remoteValue",
+			expectedRevision:       1,
+		},
+	}
+	for _, tc := range testCases {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty, revisionProperty)
+		r.openAPIResource.getResourceOperations().Get.revisionProperty = "revision"
+		resourceSchema, err := r.createTerraformResourceSchema()
+		assert.NoError(t, err, tc.name)
+		resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{stringProperty.Name: stringProperty.Default})
+		resourceData.SetId("id")
+		if tc.currentRevision != nil {
+			assert.NoError(t, resourceData.Set("revision", tc.currentRevision), tc.name)
+		}
+
+		err = r.read(resourceData, tc.client)
+
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedGetFieldsCalls, tc.client.getFieldsCalls, tc.name)
+		if tc.expectedGetFieldsCalls > 0 {
+			assert.Equal(t, []string{"revision"}, tc.client.fieldsReceived, tc.name)
+		}
+		assert.Equal(t, tc.expectedGetCalls, tc.client.getCalls, tc.name)
+		assert.Equal(t, tc.expectedStringValue, resourceData.Get(stringProperty.Name), tc.name)
+		assert.Equal(t, tc.expectedRevision, resourceData.Get("revision"), tc.name)
+	}
+}
+
+func TestGetRevisionString(t *testing.T) {
+	assert.Equal(t, "1500000", getRevisionString(float64(1500000)))
+	assert.Equal(t, "1500000", getRevisionString(1500000))
+	assert.Equal(t, "1.5", getRevisionString(1.5))
+	assert.Equal(t, "2021-01-01T00:00:00Z", getRevisionString("2021-01-01T00:00:00Z"))
+}
+
+func TestUpdateWithConditionalGetClearsETag(t *testing.T) {
+	r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+	r.openAPIResource.getResourceOperations().Get.isConditionalGetEnabled = true
+	resourceSchema, err := r.createTerraformResourceSchema()
+	assert.NoError(t, err)
+	resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{stringProperty.Name: stringProperty.Default})
+	resourceData.SetId("id")
+	assert.NoError(t, resourceData.Set(resourceETagPropertyName, `"v1"`))
+	client := &clientOpenAPIStub{
+		responsePayload: map[string]interface{}{stringProperty.Name: "updatedValue"},
+	}
+	assert.NoError(t, r.update(resourceData, client))
+	assert.Equal(t, "", resourceData.Get(resourceETagPropertyName))
+}
+
+func TestUpdateSkipUnchanged(t *testing.T) {
+	testCases := []struct {
+		name                string
+		skipUnchangedUpdate bool
+		remoteValue         string
+		expectedPutCalls    int
+	}{
+		{
+			name:                "update is skipped when the remote representation matches the desired payload",
+			skipUnchangedUpdate: true,
+			remoteValue:         stringProperty.Default.(string),
+			expectedPutCalls:    0,
+		},
+		{
+			name:                "update is performed when the remote representation differs from the desired payload",
+			skipUnchangedUpdate: true,
+			remoteValue:         "remoteValue",
+			expectedPutCalls:    1,
+		},
+		{
+			name:                "update is performed when the extension is not enabled",
+			skipUnchangedUpdate: false,
+			remoteValue:         stringProperty.Default.(string),
+			expectedPutCalls:    1,
+		},
+	}
+	for _, tc := range testCases {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		r.openAPIResource.getResourceOperations().Put.skipUnchangedUpdate = tc.skipUnchangedUpdate
+		resourceSchema, err := r.createTerraformResourceSchema()
+		assert.NoError(t, err, tc.name)
+		resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{stringProperty.Name: stringProperty.Default})
+		resourceData.SetId("id")
+		putCalls := 0
+		client := &clientOpenAPIStub{
+			responsePayload: map[string]interface{}{stringProperty.Name: tc.remoteValue},
+			funcPut: func() (*http.Response, error) {
+				putCalls++
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			},
+		}
+
+		err = r.update(resourceData, client)
+
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedPutCalls, putCalls, tc.name)
+	}
+}
+
+func TestIsUpdatePayloadUnchanged(t *testing.T) {
+	testCases := []struct {
+		name           string
+		requestPayload map[string]interface{}
+		remoteData     map[string]interface{}
+		expected       bool
+	}{
+		{
+			name:           "same values",
+			requestPayload: map[string]interface{}{"name": "value", "list": []interface{}{"a", "b"}},
+			remoteData:     map[string]interface{}{"id": "id", "name": "value", "list": []interface{}{"a", "b"}},
+			expected:       true,
+		},
+		{
+			name:           "numbers are normalized before being compared",
+			requestPayload: map[string]interface{}{"count": 3, "nested": map[string]interface{}{"size": 1}},
+			remoteData:     map[string]interface{}{"count": float64(3), "nested": map[string]interface{}{"size": float64(1)}},
+			expected:       true,
+		},
+		{
+			name:           "different values",
+			requestPayload: map[string]interface{}{"name": "value"},
+			remoteData:     map[string]interface{}{"name": "otherValue"},
+			expected:       false,
+		},
+		{
+			name:           "property missing in the remote data",
+			requestPayload: map[string]interface{}{"password": "secret"},
+			remoteData:     map[string]interface{}{"name": "value"},
+			expected:       false,
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, isUpdatePayloadUnchanged(tc.requestPayload, tc.remoteData), tc.name)
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	Convey("Given a resource factory containing some properties including an immutable property", t, func() {
 		var telemetryHandlerResourceNameReceived string
@@ -1234,7 +1653,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 					statusProperty.Name: statusProperty.Default,
 				},
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, nil)
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the new status should match the one returned by the API and the remote data should be the payload returned by the API and the err returned should be nil", func() {
 				So(err, ShouldBeNil)
@@ -1248,7 +1667,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 			client := &clientOpenAPIStub{
 				returnHTTPCode: http.StatusNotFound,
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, nil)
 			_, newStatus, err := stateRefreshFunc()
 			Convey("Then the the new status should be the internal hardcoded status 'destroyed' as a response with 404 status code is not expected to have a body and err returned should be nil", func() {
 				So(err, ShouldBeNil)
@@ -1261,7 +1680,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 			client := &clientOpenAPIStub{
 				error: errors.New(expectedError),
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, nil)
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the remoteData should be empty, the new status should be empty and the err returned should not be the expected one", func() {
 				So(err, ShouldNotBeNil)
@@ -1281,7 +1700,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 					stringProperty.Name: stringProperty.Default,
 				},
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, nil)
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the remoteData should be empty, the new status should be empty and the err returned should not be the expected one", func() {
 				So(err.Error(), ShouldEqual, "error occurred while retrieving status identifier value from payload for resource 'resourceName' (id): could not find any status property. Please make sure the resource schema definition has either one property named 'status' or one property is marked with IsStatusIdentifier set to true")
@@ -1300,7 +1719,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 					stringProperty.Name: stringProperty.Default,
 				},
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, nil)
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the remoteData should be empty, the new status should be empty and the err returned should not be the expected one", func() {
 				So(err.Error(), ShouldEqual, "error occurred while retrieving status identifier value from payload for resource 'resourceName' (id): payload does not match resouce schema, could not find the status field: [status]")
@@ -1737,7 +2156,7 @@ func TestCheckImmutableFields(t *testing.T) {
 		for _, tc := range testCases {
 			r, resourceData := testCreateResourceFactory(t, tc.inputProps...)
 			Convey(fmt.Sprintf("When checkImmutableFields method is called: %s", tc.name), func() {
-				err := r.checkImmutableFields(resourceData, &tc.inputClient)
+				_, err := r.checkImmutableFields(resourceData, &tc.inputClient)
 				Convey("Then the result returned should be the expected one", func() {
 					So(err, ShouldResemble, tc.expectedError)
 					So(resourceData.Get(propName), ShouldResemble, tc.expectedResult)
@@ -2628,3 +3047,189 @@ func testCreateSubResourceFactory(t *testing.T, path string, parentResourceNames
 	specResource.fullParentResourceName = fullParentResourceName
 	return newResourceFactory(specResource), resourceData
 }
+
+func TestResourceFactoryIgnoreServerChanges(t *testing.T) {
+	nodeCountProperty := newIntSchemaDefinitionPropertyWithDefaults("node_count", "", true, false, 3)
+	nodeCountProperty.IgnoreServerChanges = true
+	versionProperty := newStringSchemaDefinitionPropertyWithDefaults("version", "", false, true, nil)
+	versionProperty.IgnoreServerChanges = true
+	r, resourceData := testCreateResourceFactory(t, newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, "some label"), nodeCountProperty, versionProperty)
+
+	remoteData := map[string]interface{}{"label": "some label", "node_count": 5, "version": "1.0"}
+	assert.NoError(t, r.updateStateWithPayloadData(remoteData, resourceData))
+	assert.Equal(t, 5, remoteData["node_count"], "the remote data passed in should not be modified")
+	assert.Equal(t, 3, resourceData.Get("node_count"), "the server value should be ignored since the property already has a value")
+	assert.Equal(t, "1.0", resourceData.Get("version"), "the server value should be stored since the property does not have a value yet")
+
+	assert.NoError(t, r.updateStateWithPayloadData(map[string]interface{}{"label": "some new label", "node_count": 7, "version": "1.1"}, resourceData))
+	assert.Equal(t, "some new label", resourceData.Get("label"))
+	assert.Equal(t, 3, resourceData.Get("node_count"))
+	assert.Equal(t, "1.0", resourceData.Get("version"))
+}
+
+func TestResourceFactoryAttributeAliases(t *testing.T) {
+	displayNameProperty := newStringSchemaDefinitionPropertyWithDefaults("display_name", "", true, false, nil)
+	displayNameProperty.AttributeAliases = []string{"label"}
+	testSchema := newTestSchema(displayNameProperty)
+	resourceSchema, err := testSchema.getSchemaDefinition().createResourceSchema()
+	assert.NoError(t, err)
+	resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{"label": "some label"})
+	specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{})
+	r := newResourceFactory(specResource)
+
+	// the value of the alias configured is sent as the property value
+	assert.Equal(t, map[string]interface{}{"display_name": "some label"}, r.createPayloadFromLocalStateData(resourceData))
+
+	// the alias in use is kept in sync with the property
+	assert.NoError(t, r.updateStateWithPayloadData(map[string]interface{}{"display_name": "some other label"}, resourceData))
+	assert.Equal(t, "some other label", resourceData.Get("display_name"))
+	assert.Equal(t, "some other label", resourceData.Get("label"))
+
+	resourceData = schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{"display_name": "some label"})
+	assert.Equal(t, map[string]interface{}{"display_name": "some label"}, r.createPayloadFromLocalStateData(resourceData))
+	assert.NoError(t, r.updateStateWithPayloadData(map[string]interface{}{"display_name": "some other label"}, resourceData))
+	assert.Equal(t, "some other label", resourceData.Get("display_name"))
+	_, exists := resourceData.GetOkExists("label")
+	assert.False(t, exists, "the alias should not be populated since it is not in use")
+}
+
+func TestHandlePollingIfConfiguredPollRequest(t *testing.T) {
+	r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty, statusProperty)
+	r.defaultPollDelay = 0
+	r.defaultPollInterval = time.Millisecond
+	r.defaultPollMinTimeout = time.Millisecond
+	pollRequest := &specPollRequest{method: httpPost, path: "/v1/rpc", body: `{"id":"{{.ID}}"}`, successExpression: `{{eq .result.status "done"}}`, failureExpression: `{{eq .result.status "failed"}}`}
+	operation := &specResourceOperation{
+		responses: map[int]*specResponse{
+			http.StatusAccepted: {isPollingEnabled: true, pollRequest: pollRequest},
+		},
+	}
+
+	client := &clientOpenAPIStub{
+		responsePayload: map[string]interface{}{idProperty.Name: idProperty.Default, stringProperty.Name: "some value", statusProperty.Name: "deployed"},
+		pollResponsePayloads: []map[string]interface{}{
+			{"result": map[string]interface{}{"status": "running"}},
+			{"result": map[string]interface{}{"status": "done"}},
+		},
+	}
+	responsePayload := map[string]interface{}{}
+	err := r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, http.StatusAccepted, schema.TimeoutCreate)
+	assert.NoError(t, err)
+	assert.Len(t, client.pollRequestsReceived, 2)
+	assert.Equal(t, pollRequest, client.pollRequestsReceived[0])
+	assert.Equal(t, client.responsePayload, responsePayload, "the resource should be read once polling completes")
+
+	client = &clientOpenAPIStub{
+		pollResponsePayloads: []map[string]interface{}{{"result": map[string]interface{}{"status": "failed"}}},
+	}
+	err = r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, http.StatusAccepted, schema.TimeoutCreate)
+	assert.Contains(t, err.Error(), `the poll request failure expression '{{eq .result.status "failed"}}' evaluated to true`)
+
+	// the status of the resource is read from the poll request response if the poll request does not have success expression
+	operation.responses[http.StatusAccepted] = &specResponse{isPollingEnabled: true, pollTargetStatuses: []string{"deployed"}, pollPendingStatuses: []string{"deploying"}, pollRequest: &specPollRequest{method: httpPost}}
+	client = &clientOpenAPIStub{
+		responsePayload:      map[string]interface{}{idProperty.Name: idProperty.Default, statusProperty.Name: "deployed"},
+		pollResponsePayloads: []map[string]interface{}{{statusProperty.Name: "deploying"}, {statusProperty.Name: "deployed"}},
+	}
+	err = r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, http.StatusAccepted, schema.TimeoutCreate)
+	assert.NoError(t, err)
+	assert.Len(t, client.pollRequestsReceived, 2)
+
+	// sub-resources are polled and read with the parent IDs
+	operation.responses[http.StatusAccepted] = &specResponse{isPollingEnabled: true, pollRequest: pollRequest}
+	client = &clientOpenAPIStub{
+		pollResponsePayloads: []map[string]interface{}{{"result": map[string]interface{}{"status": "done"}}},
+	}
+	err = r.handlePollingIfConfigured(nil, resourceData, client, operation, http.StatusAccepted, schema.TimeoutDelete, "parent-1")
+	assert.NoError(t, err)
+	assert.Len(t, client.pollRequestsReceived, 1)
+	assert.Equal(t, []string{"parent-1"}, client.parentIDsReceived)
+
+	client = &clientOpenAPIStub{
+		responsePayload:      map[string]interface{}{idProperty.Name: idProperty.Default, statusProperty.Name: "deployed"},
+		pollResponsePayloads: []map[string]interface{}{{"result": map[string]interface{}{"status": "done"}}},
+	}
+	err = r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, http.StatusAccepted, schema.TimeoutCreate, "parent-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, client.getCalls)
+	assert.Equal(t, []string{"parent-1"}, client.parentIDsReceived, "the resource should be read with the parent IDs once polling completes")
+}
+
+func TestCreateTerraformResourceSchemaWithProvisioningEvents(t *testing.T) {
+	r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+	s, err := r.createTerraformResourceSchema()
+	assert.NoError(t, err)
+	assert.NotContains(t, s, provisioningEventsPropertyName)
+
+	r.openAPIResource.getResourceOperations().Put.recordProvisioningEvents = true
+	s, err = r.createTerraformResourceSchema()
+	assert.NoError(t, err)
+	assert.Contains(t, s, provisioningEventsPropertyName)
+	assert.True(t, s[provisioningEventsPropertyName].Computed)
+	assert.Equal(t, schema.TypeList, s[provisioningEventsPropertyName].Type)
+
+	r, _ = testCreateResourceFactory(t, idProperty, newStringSchemaDefinitionPropertyWithDefaults(provisioningEventsPropertyName, "", false, true, nil))
+	r.openAPIResource.getResourceOperations().Post.recordProvisioningEvents = true
+	_, err = r.createTerraformResourceSchema()
+	assert.EqualError(t, err, "resource 'resourceName' has x-terraform-resource-provisioning-events enabled but the schema already contains a property named 'provisioning_events'")
+}
+
+func TestCreateTerraformResourceDescription(t *testing.T) {
+	resourceSchema := &SpecSchemaDefinition{Description: "Cluster of compute instances", Properties: SpecSchemaDefinitionProperties{idProperty, stringProperty}}
+	r := newResourceFactory(newSpecStubResourceWithOperations("cluster", "/v1/clusters", false, resourceSchema, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}))
+	resource, err := r.createTerraformResource()
+	require.NoError(t, err)
+	assert.Equal(t, "Cluster of compute instances", resource.Description)
+
+	dataSource, err := newDataSourceFactory(r.openAPIResource).createTerraformDataSource()
+	require.NoError(t, err)
+	assert.Equal(t, "Cluster of compute instances", dataSource.Description)
+	dataSourceInstance, err := newDataSourceInstanceFactory(r.openAPIResource).createTerraformInstanceDataSource()
+	require.NoError(t, err)
+	assert.Equal(t, "Cluster of compute instances", dataSourceInstance.Description)
+}
+
+func TestHandlePollingIfConfiguredProvisioningEvents(t *testing.T) {
+	r, _ := testCreateResourceFactory(t, idProperty, stringProperty, statusProperty)
+	r.defaultPollDelay = 0
+	r.defaultPollInterval = time.Millisecond
+	r.defaultPollMinTimeout = time.Millisecond
+	operation := r.openAPIResource.getResourceOperations().Post
+	operation.recordProvisioningEvents = true
+	operation.responses = map[int]*specResponse{
+		http.StatusAccepted: {isPollingEnabled: true, pollTargetStatuses: []string{"deployed"}, pollPendingStatuses: []string{"pending", "deploying"}, pollRequest: &specPollRequest{method: httpPost}},
+	}
+	s, err := r.createTerraformResourceSchema()
+	assert.NoError(t, err)
+	resourceData := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	resourceData.SetId("id")
+
+	client := &clientOpenAPIStub{
+		responsePayload:      map[string]interface{}{idProperty.Name: "id", statusProperty.Name: "deployed"},
+		pollResponsePayloads: []map[string]interface{}{{statusProperty.Name: "pending"}, {statusProperty.Name: "deploying"}, {statusProperty.Name: "deploying"}, {statusProperty.Name: "deployed"}},
+	}
+	responsePayload := map[string]interface{}{}
+	err = r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, http.StatusAccepted, schema.TimeoutCreate)
+	assert.NoError(t, err)
+	events := resourceData.Get(provisioningEventsPropertyName).([]interface{})
+	assert.Len(t, events, 3)
+	for i, expectedStatus := range []string{"pending", "deploying", "deployed"} {
+		event := events[i].(map[string]interface{})
+		assert.Equal(t, expectedStatus, event["status"])
+		assert.Equal(t, schema.TimeoutCreate, event["operation"])
+		_, err := time.Parse(time.RFC3339, event["timestamp"].(string))
+		assert.NoError(t, err)
+	}
+
+	// the events are not recorded if the operation does not have provisioning events enabled
+	operation.recordProvisioningEvents = false
+	resourceData = schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	resourceData.SetId("id")
+	client = &clientOpenAPIStub{
+		responsePayload:      map[string]interface{}{idProperty.Name: "id", statusProperty.Name: "deployed"},
+		pollResponsePayloads: []map[string]interface{}{{statusProperty.Name: "deployed"}},
+	}
+	err = r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, http.StatusAccepted, schema.TimeoutCreate)
+	assert.NoError(t, err)
+	assert.Empty(t, resourceData.Get(provisioningEventsPropertyName))
+}
@@ -0,0 +1,123 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// operationUpdateStrategyJSONPatch sends the resource updates as JSON Patch documents (RFC 6902) containing the add,
+	// remove and replace operations that turn the prior attributes into the desired ones in PATCH requests, instead of PUT
+	// requests containing the whole payload
+	operationUpdateStrategyJSONPatch = "json-patch"
+	// jsonPatchContentType is the content type of the JSON Patch request payloads
+	jsonPatchContentType = "application/json-patch+json"
+)
+
+const (
+	jsonPatchAdd     = "add"
+	jsonPatchRemove  = "remove"
+	jsonPatchReplace = "replace"
+)
+
+// jsonPatchOperation defines an operation of a JSON Patch document (RFC 6902)
+type jsonPatchOperation struct {
+	Op    string
+	Path  string
+	Value interface{}
+}
+
+// MarshalJSON encodes the JSON Patch operation, leaving out the value of the remove operations
+func (o jsonPatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == jsonPatchRemove {
+		return json.Marshal(map[string]interface{}{"op": o.Op, "path": o.Path})
+	}
+	return json.Marshal(map[string]interface{}{"op": o.Op, "path": o.Path, "value": o.Value})
+}
+
+// isJSONPatchOperation returns true if the operation passed in consumes JSON Patch documents (application/json-patch+json)
+func isJSONPatchOperation(operation *spec.Operation) bool {
+	if operation == nil {
+		return false
+	}
+	for _, consumes := range operation.Consumes {
+		if strings.EqualFold(strings.TrimSpace(strings.Split(consumes, ";")[0]), jsonPatchContentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// createJSONPatch returns the JSON Patch document (RFC 6902) of the update containing the operations that turn the prior
+// values of the properties changed in the configuration into the desired values in the request payload passed in. If the
+// prior values can not be determined, the desired values are added as a whole (which replaces the existing values)
+func (r resourceFactory) createJSONPatch(data *schema.ResourceData, requestPayload map[string]interface{}) []jsonPatchOperation {
+	priorPayload, desiredPayload, err := r.getUpdateChanges(data, requestPayload)
+	if err != nil {
+		resourceLog.Debug("failed to get the changes of the update, adding the whole payload in the JSON patch: %s", err)
+		normalizedRequestPayload, err := normalizeJSONPayload(requestPayload)
+		if err != nil {
+			normalizedRequestPayload = requestPayload
+		}
+		priorPayload, desiredPayload = map[string]interface{}{}, normalizedRequestPayload
+	}
+	patch := newJSONPatch("", priorPayload, desiredPayload)
+	if patch == nil {
+		return []jsonPatchOperation{}
+	}
+	return patch
+}
+
+// newJSONPatch returns the JSON Patch operations that turn the prior value passed in into the desired one, where path is the
+// JSON pointer of the values. Objects are patched member by member and arrays item by item (adding or removing the trailing
+// items if the length changed), whereas the rest of the values are replaced
+func newJSONPatch(path string, prior, desired interface{}) []jsonPatchOperation {
+	if reflect.DeepEqual(prior, desired) {
+		return nil
+	}
+	var patch []jsonPatchOperation
+	priorObject, priorIsObject := prior.(map[string]interface{})
+	desiredObject, desiredIsObject := desired.(map[string]interface{})
+	if priorIsObject && desiredIsObject {
+		for _, name := range sortedKeys(priorObject) {
+			if _, exists := desiredObject[name]; !exists {
+				patch = append(patch, jsonPatchOperation{Op: jsonPatchRemove, Path: jsonPointer(path, name)})
+			}
+		}
+		for _, name := range sortedKeys(desiredObject) {
+			priorValue, exists := priorObject[name]
+			if !exists {
+				patch = append(patch, jsonPatchOperation{Op: jsonPatchAdd, Path: jsonPointer(path, name), Value: desiredObject[name]})
+				continue
+			}
+			patch = append(patch, newJSONPatch(jsonPointer(path, name), priorValue, desiredObject[name])...)
+		}
+		return patch
+	}
+	priorArray, priorIsArray := prior.([]interface{})
+	desiredArray, desiredIsArray := desired.([]interface{})
+	if priorIsArray && desiredIsArray {
+		for i := 0; i < len(priorArray) && i < len(desiredArray); i++ {
+			patch = append(patch, newJSONPatch(jsonPointer(path, fmt.Sprintf("%d", i)), priorArray[i], desiredArray[i])...)
+		}
+		for i := len(priorArray); i < len(desiredArray); i++ {
+			patch = append(patch, jsonPatchOperation{Op: jsonPatchAdd, Path: jsonPointer(path, fmt.Sprintf("%d", i)), Value: desiredArray[i]})
+		}
+		// the trailing items are removed from the last one so the indexes of the items yet to be removed do not change
+		for i := len(priorArray) - 1; i >= len(desiredArray); i-- {
+			patch = append(patch, jsonPatchOperation{Op: jsonPatchRemove, Path: jsonPointer(path, fmt.Sprintf("%d", i))})
+		}
+		return patch
+	}
+	return []jsonPatchOperation{{Op: jsonPatchReplace, Path: path, Value: desired}}
+}
+
+// jsonPointer returns the JSON pointer (RFC 6901) of the member or item passed in within the value the path points to
+func jsonPointer(path, token string) string {
+	return path + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
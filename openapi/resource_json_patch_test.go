@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJSONPatch(t *testing.T) {
+	testCases := []struct {
+		name          string
+		prior         map[string]interface{}
+		desired       map[string]interface{}
+		expectedPatch []jsonPatchOperation
+	}{
+		{name: "nothing changed", prior: map[string]interface{}{"label": "some label"}, desired: map[string]interface{}{"label": "some label"}, expectedPatch: nil},
+		{name: "value changed", prior: map[string]interface{}{"label": "some label"}, desired: map[string]interface{}{"label": "some other label"}, expectedPatch: []jsonPatchOperation{{Op: jsonPatchReplace, Path: "/label", Value: "some other label"}}},
+		{name: "value added", prior: map[string]interface{}{}, desired: map[string]interface{}{"label": "some label"}, expectedPatch: []jsonPatchOperation{{Op: jsonPatchAdd, Path: "/label", Value: "some label"}}},
+		{name: "value removed", prior: map[string]interface{}{"label": "some label"}, desired: map[string]interface{}{}, expectedPatch: []jsonPatchOperation{{Op: jsonPatchRemove, Path: "/label"}}},
+		{name: "value type changed", prior: map[string]interface{}{"settings": map[string]interface{}{"port": float64(80)}}, desired: map[string]interface{}{"settings": "default"}, expectedPatch: []jsonPatchOperation{{Op: jsonPatchReplace, Path: "/settings", Value: "default"}}},
+		{
+			name:          "objects patched recursively",
+			prior:         map[string]interface{}{"settings": map[string]interface{}{"name": "some name", "port": float64(80), "protocol": "http"}},
+			desired:       map[string]interface{}{"settings": map[string]interface{}{"name": "some name", "port": float64(443), "tls": true}},
+			expectedPatch: []jsonPatchOperation{{Op: jsonPatchRemove, Path: "/settings/protocol"}, {Op: jsonPatchReplace, Path: "/settings/port", Value: float64(443)}, {Op: jsonPatchAdd, Path: "/settings/tls", Value: true}},
+		},
+		{
+			name:          "array items changed and added",
+			prior:         map[string]interface{}{"tags": []interface{}{"a", "b"}},
+			desired:       map[string]interface{}{"tags": []interface{}{"a", "c", "d", "e"}},
+			expectedPatch: []jsonPatchOperation{{Op: jsonPatchReplace, Path: "/tags/1", Value: "c"}, {Op: jsonPatchAdd, Path: "/tags/2", Value: "d"}, {Op: jsonPatchAdd, Path: "/tags/3", Value: "e"}},
+		},
+		{
+			name:          "array items removed from the last one",
+			prior:         map[string]interface{}{"tags": []interface{}{"a", "b", "c"}},
+			desired:       map[string]interface{}{"tags": []interface{}{"a"}},
+			expectedPatch: []jsonPatchOperation{{Op: jsonPatchRemove, Path: "/tags/2"}, {Op: jsonPatchRemove, Path: "/tags/1"}},
+		},
+		{
+			name:          "objects within arrays patched recursively",
+			prior:         map[string]interface{}{"origins": []interface{}{map[string]interface{}{"host": "origin.com", "port": float64(80)}}},
+			desired:       map[string]interface{}{"origins": []interface{}{map[string]interface{}{"host": "origin.com", "port": float64(443)}}},
+			expectedPatch: []jsonPatchOperation{{Op: jsonPatchReplace, Path: "/origins/0/port", Value: float64(443)}},
+		},
+		{name: "JSON pointer escaped", prior: map[string]interface{}{}, desired: map[string]interface{}{"a/b~c": "value"}, expectedPatch: []jsonPatchOperation{{Op: jsonPatchAdd, Path: "/a~1b~0c", Value: "value"}}},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedPatch, newJSONPatch("", tc.prior, tc.desired), tc.name)
+	}
+}
+
+func TestJSONPatchOperationMarshalJSON(t *testing.T) {
+	patch, err := json.Marshal([]jsonPatchOperation{{Op: jsonPatchAdd, Path: "/enabled", Value: false}, {Op: jsonPatchReplace, Path: "/label", Value: nil}, {Op: jsonPatchRemove, Path: "/description"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"op":"add","path":"/enabled","value":false},{"op":"replace","path":"/label","value":null},{"op":"remove","path":"/description"}]`, string(patch))
+}
+
+func TestIsJSONPatchOperation(t *testing.T) {
+	assert.False(t, isJSONPatchOperation(nil))
+	assert.False(t, isJSONPatchOperation(&spec.Operation{}))
+	assert.False(t, isJSONPatchOperation(&spec.Operation{OperationProps: spec.OperationProps{Consumes: []string{"application/json"}}}))
+	assert.True(t, isJSONPatchOperation(&spec.Operation{OperationProps: spec.OperationProps{Consumes: []string{"application/json", "application/json-patch+json"}}}))
+	assert.True(t, isJSONPatchOperation(&spec.Operation{OperationProps: spec.OperationProps{Consumes: []string{"Application/JSON-Patch+JSON; charset=utf-8"}}}))
+}
+
+func TestResourceFactoryUpdateJSONPatch(t *testing.T) {
+	schemaDefinition := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true, IsIdentifier: true},
+			&SpecSchemaDefinitionProperty{Name: "label", Type: TypeString, Required: true},
+			&SpecSchemaDefinitionProperty{Name: "size", Type: TypeInt},
+			&SpecSchemaDefinitionProperty{Name: "tags", Type: TypeList, ArrayItemsType: TypeString},
+		},
+	}
+	putOperation := &specResourceOperation{updateStrategy: operationUpdateStrategyJSONPatch}
+	specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, schemaDefinition, &specResourceOperation{}, putOperation, &specResourceOperation{}, &specResourceOperation{})
+	resource, err := newResourceFactory(specResource).createTerraformResource()
+	require.NoError(t, err)
+	state := &terraform.InstanceState{ID: "1234", Attributes: map[string]string{
+		"id":     "1234",
+		"label":  "some label",
+		"size":   "1",
+		"tags.#": "3",
+		"tags.0": "a",
+		"tags.1": "b",
+		"tags.2": "c",
+	}}
+	config := map[string]interface{}{"label": "some label", "size": 2, "tags": []interface{}{"a", "d"}}
+	diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	client := &clientOpenAPIStub{responsePayload: map[string]interface{}{"id": "1234", "label": "some label", "size": 2, "tags": []interface{}{"a", "d"}}}
+
+	_, diags := resource.Apply(context.Background(), state, diff, client)
+	require.False(t, diags.HasError(), diags)
+	expectedPatch := []jsonPatchOperation{
+		{Op: jsonPatchReplace, Path: "/size", Value: float64(2)},
+		{Op: jsonPatchReplace, Path: "/tags/1", Value: "d"},
+		{Op: jsonPatchRemove, Path: "/tags/2"},
+	}
+	assert.Equal(t, expectedPatch, client.putPayloadReceived, "only the operations for the properties changed should be sent")
+}
@@ -0,0 +1,68 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// locationHeader is the response header the APIs creating the resources asynchronously return the URL to poll in
+	locationHeader = "Location"
+	// locationPollPending is the status of the location polled while the API keeps responding 202 Accepted
+	locationPollPending = "pending"
+	// locationPollCompleted is the status of the location polled once the API responds 200 OK or 201 Created
+	locationPollCompleted = "completed"
+)
+
+// shouldPollLocation returns true if the create response passed in follows the asynchronous create pattern (202 Accepted
+// along with the Location header) and the response is not configured to poll the resource instead (x-terraform-resource-poll-enabled)
+func shouldPollLocation(operation *specResourceOperation, res *http.Response) bool {
+	if res.StatusCode != http.StatusAccepted || res.Header.Get(locationHeader) == "" {
+		return false
+	}
+	response := operation.responses.getResponse(http.StatusAccepted)
+	return response == nil || !response.isPollingEnabled
+}
+
+// pollLocation polls the location returned by the API when the resource is created asynchronously until the API stops
+// responding 202 Accepted, returning the response payload of the final response which is expected to be the resource
+// created. The response payload passed in (the create response) is returned if the final response does not have payload.
+// The polling times out as configured in the resource create timeout
+func (r resourceFactory) pollLocation(data *schema.ResourceData, providerClient ClientOpenAPI, location string, responsePayload map[string]interface{}, parentIDs ...string) (map[string]interface{}, error) {
+	resourceName := r.openAPIResource.GetResourceName()
+	pollerLog.Info("Waiting for resource '%s' to be created, polling the location '%s'", resourceName, location)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{locationPollPending},
+		Target:  []string{locationPollCompleted},
+		Refresh: func() (interface{}, string, error) {
+			locationPayload := map[string]interface{}{}
+			res, err := providerClient.PollLocation(r.openAPIResource, location, &locationPayload, parentIDs...)
+			if err != nil {
+				return nil, "", err
+			}
+			if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}); err != nil {
+				return nil, "", err
+			}
+			if res.StatusCode == http.StatusAccepted {
+				pollerLog.Debug("location '%s' of resource '%s' responded with 202 Accepted, the resource is still being created", location, resourceName)
+				return locationPayload, locationPollPending, nil
+			}
+			return locationPayload, locationPollCompleted, nil
+		},
+		Timeout:      data.Timeout(schema.TimeoutCreate),
+		PollInterval: r.defaultPollInterval,
+		MinTimeout:   r.defaultPollMinTimeout,
+		Delay:        r.defaultPollDelay,
+	}
+	remoteData, err := stateConf.WaitForState()
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for location '%s' to complete: %s", location, err)
+	}
+	if locationPayload, ok := remoteData.(map[string]interface{}); ok && len(locationPayload) > 0 {
+		return locationPayload, nil
+	}
+	return responsePayload, nil
+}
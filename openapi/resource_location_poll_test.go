@@ -0,0 +1,83 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldPollLocation(t *testing.T) {
+	locationHeaders := http.Header{locationHeader: []string{"/v1/operations/1234"}}
+	pollEnabledOperation := &specResourceOperation{responses: specResponses{http.StatusAccepted: {isPollingEnabled: true}}}
+	testCases := []struct {
+		name      string
+		operation *specResourceOperation
+		response  *http.Response
+		expected  bool
+	}{
+		{name: "202 Accepted with Location header", operation: &specResourceOperation{}, response: &http.Response{StatusCode: http.StatusAccepted, Header: locationHeaders}, expected: true},
+		{name: "202 Accepted without Location header", operation: &specResourceOperation{}, response: &http.Response{StatusCode: http.StatusAccepted}, expected: false},
+		{name: "201 Created with Location header", operation: &specResourceOperation{}, response: &http.Response{StatusCode: http.StatusCreated, Header: locationHeaders}, expected: false},
+		{name: "202 Accepted response configured to poll the resource", operation: pollEnabledOperation, response: &http.Response{StatusCode: http.StatusAccepted, Header: locationHeaders}, expected: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, shouldPollLocation(tc.operation, tc.response), tc.name)
+	}
+}
+
+func TestCreateWithLocationPolling(t *testing.T) {
+	r, resourceData := testCreateResourceFactory(t, idProperty, stringProperty, statusProperty)
+	r.defaultPollDelay = 0
+	r.defaultPollInterval = time.Millisecond
+	r.defaultPollMinTimeout = time.Millisecond
+	client := &clientOpenAPIStub{
+		returnHTTPCode:          http.StatusAccepted,
+		postResponseHeader:      http.Header{locationHeader: []string{"/v1/operations/1234"}},
+		responsePayload:         map[string]interface{}{},
+		locationResponseCodes:   []int{http.StatusAccepted, http.StatusAccepted, http.StatusOK},
+		locationResponsePayload: map[string]interface{}{idProperty.Name: "someID", stringProperty.Name: "some value", statusProperty.Name: "deployed"},
+	}
+
+	require.NoError(t, r.create(resourceData, client))
+	assert.Equal(t, []string{"/v1/operations/1234", "/v1/operations/1234", "/v1/operations/1234"}, client.locationsReceived)
+	assert.Equal(t, "someID", resourceData.Id())
+	assert.Equal(t, "some value", resourceData.Get(stringProperty.Name))
+	assert.Equal(t, "deployed", resourceData.Get(statusProperty.Name))
+}
+
+func TestCreateWithLocationPollingWithoutPayload(t *testing.T) {
+	r, resourceData := testCreateResourceFactory(t, idProperty, stringProperty)
+	r.defaultPollDelay = 0
+	r.defaultPollInterval = time.Millisecond
+	r.defaultPollMinTimeout = time.Millisecond
+	client := &clientOpenAPIStub{
+		returnHTTPCode:        http.StatusAccepted,
+		postResponseHeader:    http.Header{locationHeader: []string{"/v1/resource/someID"}},
+		responsePayload:       map[string]interface{}{idProperty.Name: "someID", stringProperty.Name: "some value"},
+		locationResponseCodes: []int{http.StatusCreated},
+	}
+
+	require.NoError(t, r.create(resourceData, client))
+	assert.Equal(t, "someID", resourceData.Id(), "the create response payload should be used if the location final response does not have payload")
+}
+
+func TestCreateWithLocationPollingFails(t *testing.T) {
+	r, resourceData := testCreateResourceFactory(t, idProperty, stringProperty)
+	r.defaultPollDelay = 0
+	r.defaultPollInterval = time.Millisecond
+	r.defaultPollMinTimeout = time.Millisecond
+	client := &clientOpenAPIStub{
+		returnHTTPCode:        http.StatusAccepted,
+		postResponseHeader:    http.Header{locationHeader: []string{"/v1/operations/1234"}},
+		responsePayload:       map[string]interface{}{},
+		locationResponseCodes: []int{http.StatusAccepted, http.StatusInternalServerError},
+	}
+
+	err := r.create(resourceData, client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "polling mechanism failed after POST /v1/resource call with response status code (202): error waiting for location '/v1/operations/1234' to complete")
+	assert.Empty(t, resourceData.Id())
+}
@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// lockRetryWait defines the time waited before trying to acquire a lock held by someone else again; the wait is doubled
+// on every attempt up to maxLockRetryWait
+var lockRetryWait = time.Duration(1 * time.Second)
+var maxLockRetryWait = time.Duration(30 * time.Second)
+
+// acquireLock acquires the lock of the operation passed in (x-terraform-lock-path) and returns the function releasing it.
+// Locks held by someone else (e,g: a concurrent terraform run), which the API reports with a 409 Conflict or 423 Locked
+// response, are retried with an exponential backoff until the timeout passed in expires. Nothing is locked if the
+// operation does not have a lock configured
+func (r resourceFactory) acquireLock(providerClient ClientOpenAPI, operation *specResourceOperation, timeout time.Duration, ids ...string) (func(), error) {
+	if operation == nil || operation.lock == nil {
+		return func() {}, nil
+	}
+	lock := operation.lock
+	resourceName := r.openAPIResource.GetResourceName()
+	deadline := time.Now().Add(timeout)
+	wait := lockRetryWait
+	for {
+		res, err := providerClient.AcquireLock(r.openAPIResource, lock, ids...)
+		if err != nil {
+			return nil, fmt.Errorf("[resource='%s'] lock POST %s failed: %s", resourceName, lock.path, err)
+		}
+		if res.StatusCode != http.StatusConflict && res.StatusCode != http.StatusLocked {
+			if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusCreated, http.StatusNoContent}); err != nil {
+				return nil, fmt.Errorf("[resource='%s'] lock POST %s failed: %s", resourceName, lock.path, err)
+			}
+			break
+		}
+		if time.Now().Add(wait).After(deadline) {
+			return nil, fmt.Errorf("[resource='%s'] lock POST %s failed: the lock is still held by someone else after %s (response status code %d)", resourceName, lock.path, timeout, res.StatusCode)
+		}
+		resourceLog.Info("'%s' lock %s is held by someone else (response status code %d), trying again in %s", resourceName, lock.path, res.StatusCode, wait)
+		time.Sleep(wait)
+		if wait *= 2; wait > maxLockRetryWait {
+			wait = maxLockRetryWait
+		}
+	}
+	resourceLog.Debug("'%s' lock %s acquired", resourceName, lock.path)
+	return func() {
+		res, err := providerClient.ReleaseLock(r.openAPIResource, lock, ids...)
+		if err == nil {
+			err = checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusAccepted, http.StatusNoContent})
+		}
+		if err != nil {
+			resourceLog.Warn("'%s' lock DELETE %s failed, the lock may need to be released manually: %s", resourceName, lock.path, err)
+			return
+		}
+		resourceLog.Debug("'%s' lock %s released", resourceName, lock.path)
+	}, nil
+}
+
+// getLockIDs returns the ids the lock paths of the resource are resolved with: the parent IDs followed by the resource ID
+func getLockIDs(parentIDs []string, id string) []string {
+	ids := make([]string, 0, len(parentIDs)+1)
+	ids = append(ids, parentIDs...)
+	return append(ids, id)
+}
@@ -0,0 +1,131 @@
+package openapi
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceFactoryAcquireLock(t *testing.T) {
+	defer func(wait, maxWait time.Duration) { lockRetryWait, maxLockRetryWait = wait, maxWait }(lockRetryWait, maxLockRetryWait)
+	lockRetryWait, maxLockRetryWait = time.Millisecond, 2*time.Millisecond
+
+	lockedResponse := func(statusCode int) *http.Response {
+		return &http.Response{StatusCode: statusCode, Body: ioutil.NopCloser(strings.NewReader(""))}
+	}
+	testCases := []struct {
+		name                 string
+		lock                 *specLock
+		acquireLockResponses []int
+		clientError          error
+		timeout              time.Duration
+		expectedRequests     []string
+		expectedError        string
+	}{
+		{
+			name: "lock not configured",
+		},
+		{
+			name:             "lock acquired and released",
+			lock:             &specLock{path: "/v1/projects/{project_id}/lock"},
+			timeout:          time.Minute,
+			expectedRequests: []string{"POST /v1/projects/{project_id}/lock", "DELETE /v1/projects/{project_id}/lock"},
+		},
+		{
+			name:                 "lock held by someone else is retried until acquired",
+			lock:                 &specLock{path: "/v1/projects/{project_id}/lock"},
+			acquireLockResponses: []int{http.StatusConflict, http.StatusLocked, http.StatusLocked, http.StatusCreated},
+			timeout:              time.Minute,
+			expectedRequests:     []string{"POST /v1/projects/{project_id}/lock", "POST /v1/projects/{project_id}/lock", "POST /v1/projects/{project_id}/lock", "POST /v1/projects/{project_id}/lock", "DELETE /v1/projects/{project_id}/lock"},
+		},
+		{
+			name:                 "lock held by someone else until the timeout expires",
+			lock:                 &specLock{path: "/v1/projects/{project_id}/lock"},
+			acquireLockResponses: []int{http.StatusLocked},
+			timeout:              0,
+			expectedError:        "[resource='resourceName'] lock POST /v1/projects/{project_id}/lock failed: the lock is still held by someone else after 0s (response status code 423)",
+		},
+		{
+			name:                 "unexpected response",
+			lock:                 &specLock{path: "/v1/projects/{project_id}/lock"},
+			acquireLockResponses: []int{http.StatusForbidden},
+			timeout:              time.Minute,
+			expectedError:        "[resource='resourceName'] lock POST /v1/projects/{project_id}/lock failed: [resource='resourceName'] HTTP Response Status Code 403 not matching expected one [200 201 204] ()",
+		},
+		{
+			name:          "client error",
+			lock:          &specLock{path: "/v1/projects/{project_id}/lock"},
+			clientError:   errors.New("some error"),
+			timeout:       time.Minute,
+			expectedError: "[resource='resourceName'] lock POST /v1/projects/{project_id}/lock failed: some error",
+		},
+	}
+	for _, tc := range testCases {
+		r, _ := testCreateResourceFactory(t, newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, "some label"))
+		operation := r.openAPIResource.getResourceOperations().Put
+		operation.lock = tc.lock
+		client := &clientOpenAPIStub{error: tc.clientError}
+		if len(tc.acquireLockResponses) > 0 {
+			responses := tc.acquireLockResponses
+			client.funcAcquireLock = func() (*http.Response, error) {
+				statusCode := responses[0]
+				if len(responses) > 1 {
+					responses = responses[1:]
+				}
+				return lockedResponse(statusCode), nil
+			}
+		}
+		releaseLock, err := r.acquireLock(client, operation, tc.timeout, "p1", "1234")
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		releaseLock()
+		assert.Equal(t, tc.expectedRequests, client.lockRequestsReceived, tc.name)
+		if tc.lock != nil {
+			assert.Equal(t, []string{"p1", "1234"}, client.lockIDsReceived, tc.name)
+		}
+	}
+}
+
+func TestResourceFactoryDeleteWithLock(t *testing.T) {
+	r, resourceData := testCreateResourceFactoryWithID(t, newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, "1234"), newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, "some label"))
+	r.openAPIResource.getResourceOperations().Delete.lock = &specLock{path: "/v1/resource/{id}/lock"}
+	client := &clientOpenAPIStub{}
+	err := r.delete(resourceData, client)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"POST /v1/resource/{id}/lock", "DELETE /v1/resource/{id}/lock"}, client.lockRequestsReceived)
+	assert.Equal(t, []string{"1234"}, client.lockIDsReceived)
+}
+
+func TestResourceFactoryUpdateLockNotAcquired(t *testing.T) {
+	r, resourceData := testCreateResourceFactoryWithID(t, newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, "1234"), newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, "some label"))
+	r.openAPIResource.getResourceOperations().Put.lock = &specLock{path: "/v1/resource/{id}/lock"}
+	putCalled := false
+	client := &clientOpenAPIStub{
+		funcAcquireLock: func() (*http.Response, error) { return nil, errors.New("connection refused") },
+		funcPut: func() (*http.Response, error) {
+			putCalled = true
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		},
+	}
+	err := r.update(resourceData, client)
+	assert.EqualError(t, err, "[resource='resourceName'] lock POST /v1/resource/{id}/lock failed: connection refused")
+	assert.False(t, putCalled, "the resource should not be updated if the lock is not acquired")
+	assert.Equal(t, []string{"POST /v1/resource/{id}/lock"}, client.lockRequestsReceived, "the lock should not be released if it was not acquired")
+}
+
+func TestGetLockIDs(t *testing.T) {
+	parentIDs := make([]string, 1, 2)
+	parentIDs[0] = "p1"
+	assert.Equal(t, []string{"p1", "1234"}, getLockIDs(parentIDs, "1234"))
+	assert.Equal(t, []string{"p1"}, parentIDs, "the parent IDs passed in should not be modified")
+	assert.Equal(t, []string{"1234"}, getLockIDs(nil, "1234"))
+}
@@ -0,0 +1,102 @@
+package openapi
+
+import (
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// operationUpdateStrategyMergePatch sends the resource updates as JSON merge patches (RFC 7386) containing only the
+	// changed attributes in PATCH requests, instead of PUT requests containing the whole payload
+	operationUpdateStrategyMergePatch = "merge-patch"
+	// mergePatchContentType is the content type of the JSON merge patch request payloads
+	mergePatchContentType = "application/merge-patch+json"
+)
+
+// createMergePatch returns the JSON merge patch (RFC 7386) of the update containing only the properties changed in the
+// configuration (see getUpdateChanges): the values of the properties changed as in the request payload passed in, null
+// for the properties removed and, for the object properties, only the nested properties changed
+func (r resourceFactory) createMergePatch(data *schema.ResourceData, requestPayload map[string]interface{}) map[string]interface{} {
+	priorPayload, desiredPayload, err := r.getUpdateChanges(data, requestPayload)
+	if err != nil {
+		resourceLog.Debug("failed to get the changes of the update, sending the whole payload as the merge patch: %s", err)
+		return requestPayload
+	}
+	return newMergePatch(priorPayload, desiredPayload)
+}
+
+// getUpdateChanges returns the prior and desired values (normalized as JSON) of the properties changed in the configuration,
+// the desired values being the ones in the request payload passed in. The properties using the merge update strategy are
+// left out since their changes are sent to the collection sub-endpoints
+func (r resourceFactory) getUpdateChanges(data *schema.ResourceData, requestPayload map[string]interface{}) (map[string]interface{}, map[string]interface{}, error) {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, nil, err
+	}
+	priorPayload := map[string]interface{}{}
+	desiredPayload := map[string]interface{}{}
+	for _, property := range resourceSchema.Properties {
+		if property.isReadOnly() || property.IsParentProperty || property.isMergeUpdateStrategy() {
+			continue
+		}
+		terraformName := property.GetTerraformCompliantPropertyName()
+		if !data.HasChange(terraformName) {
+			continue
+		}
+		if priorValue, _ := data.GetChange(terraformName); priorValue != nil && !isZeroValue(priorValue) {
+			if err := r.populatePayload(priorPayload, property, priorValue); err != nil {
+				resourceLog.Debug("failed to populate the prior value of the property '%s', sending the whole value in the patch: %s", property.Name, err)
+				delete(priorPayload, property.Name)
+			}
+		}
+		if desiredValue, exists := requestPayload[property.Name]; exists {
+			desiredPayload[property.Name] = desiredValue
+		}
+	}
+	normalizedPriorPayload, err := normalizeJSONPayload(priorPayload)
+	if err != nil {
+		return nil, nil, err
+	}
+	normalizedDesiredPayload, err := normalizeJSONPayload(desiredPayload)
+	if err != nil {
+		return nil, nil, err
+	}
+	return normalizedPriorPayload, normalizedDesiredPayload, nil
+}
+
+// newMergePatch returns the JSON merge patch (RFC 7386) that turns the prior object passed in into the desired one. Objects
+// are patched recursively whereas the rest of the values (including arrays) are replaced as a whole
+func newMergePatch(prior, desired map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for name, desiredValue := range desired {
+		priorValue, exists := prior[name]
+		if exists && reflect.DeepEqual(priorValue, desiredValue) {
+			continue
+		}
+		priorObject, priorIsObject := priorValue.(map[string]interface{})
+		desiredObject, desiredIsObject := desiredValue.(map[string]interface{})
+		if exists && priorIsObject && desiredIsObject {
+			patch[name] = newMergePatch(priorObject, desiredObject)
+			continue
+		}
+		patch[name] = desiredValue
+	}
+	for name := range prior {
+		if _, exists := desired[name]; !exists {
+			patch[name] = nil
+		}
+	}
+	return patch
+}
+
+// isZeroValue returns true if the value passed in is the zero value of its type or an empty collection, which is how
+// terraform represents the properties not set
+func isZeroValue(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
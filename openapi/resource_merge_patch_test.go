@@ -0,0 +1,82 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMergePatch(t *testing.T) {
+	testCases := []struct {
+		name          string
+		prior         map[string]interface{}
+		desired       map[string]interface{}
+		expectedPatch map[string]interface{}
+	}{
+		{name: "nothing changed", prior: map[string]interface{}{"label": "some label"}, desired: map[string]interface{}{"label": "some label"}, expectedPatch: map[string]interface{}{}},
+		{name: "value changed", prior: map[string]interface{}{"label": "some label"}, desired: map[string]interface{}{"label": "some other label"}, expectedPatch: map[string]interface{}{"label": "some other label"}},
+		{name: "value added", prior: map[string]interface{}{}, desired: map[string]interface{}{"label": "some label"}, expectedPatch: map[string]interface{}{"label": "some label"}},
+		{name: "value removed", prior: map[string]interface{}{"label": "some label"}, desired: map[string]interface{}{}, expectedPatch: map[string]interface{}{"label": nil}},
+		{name: "arrays replaced as a whole", prior: map[string]interface{}{"tags": []interface{}{"a", "b"}}, desired: map[string]interface{}{"tags": []interface{}{"a", "c"}}, expectedPatch: map[string]interface{}{"tags": []interface{}{"a", "c"}}},
+		{
+			name:          "objects patched recursively",
+			prior:         map[string]interface{}{"settings": map[string]interface{}{"name": "some name", "port": float64(80), "protocol": "http"}},
+			desired:       map[string]interface{}{"settings": map[string]interface{}{"name": "some name", "port": float64(443)}},
+			expectedPatch: map[string]interface{}{"settings": map[string]interface{}{"port": float64(443), "protocol": nil}},
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedPatch, newMergePatch(tc.prior, tc.desired), tc.name)
+	}
+}
+
+func TestResourceFactoryUpdateMergePatch(t *testing.T) {
+	schemaDefinition := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			&SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true, IsIdentifier: true},
+			&SpecSchemaDefinitionProperty{Name: "label", Type: TypeString, Required: true},
+			&SpecSchemaDefinitionProperty{Name: "description", Type: TypeString},
+			&SpecSchemaDefinitionProperty{Name: "size", Type: TypeInt},
+			&SpecSchemaDefinitionProperty{Name: "tags", Type: TypeList, ArrayItemsType: TypeString},
+		},
+	}
+	putOperation := &specResourceOperation{updateStrategy: operationUpdateStrategyMergePatch}
+	specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, schemaDefinition, &specResourceOperation{}, putOperation, &specResourceOperation{}, &specResourceOperation{})
+	resource, err := newResourceFactory(specResource).createTerraformResource()
+	require.NoError(t, err)
+	state := &terraform.InstanceState{ID: "1234", Attributes: map[string]string{
+		"id":          "1234",
+		"label":       "some label",
+		"description": "some description",
+		"size":        "1",
+		"tags.#":      "2",
+		"tags.0":      "a",
+		"tags.1":      "b",
+	}}
+	config := map[string]interface{}{"label": "some label", "size": 2, "tags": []interface{}{"a", "c"}}
+	diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	client := &clientOpenAPIStub{responsePayload: map[string]interface{}{"id": "1234", "label": "some label", "size": 2, "tags": []interface{}{"a", "c"}}}
+
+	_, diags := resource.Apply(context.Background(), state, diff, client)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, map[string]interface{}{"description": "", "size": float64(2), "tags": []interface{}{"a", "c"}}, client.putPayloadReceived, "only the properties changed should be sent, with the values cleared sent as in the whole payload")
+}
+
+func TestResourceFactoryUpdateWithoutMergePatch(t *testing.T) {
+	r, resourceData := testCreateResourceFactoryWithID(t, newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, "1234"), newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, "some label"))
+	client := &clientOpenAPIStub{responsePayload: map[string]interface{}{"id": "1234", "label": "some label"}}
+	require.NoError(t, r.update(resourceData, client))
+	assert.Equal(t, map[string]interface{}{"label": "some label"}, client.putPayloadReceived, "the whole payload should be sent if the update strategy is not merge-patch")
+}
+
+func TestSpecResourceOperationGetUpdateMethod(t *testing.T) {
+	var nilOperation *specResourceOperation
+	assert.Equal(t, httpPut, nilOperation.getUpdateMethod())
+	assert.Equal(t, httpPut, (&specResourceOperation{}).getUpdateMethod())
+	assert.Equal(t, httpPatch, (&specResourceOperation{updateStrategy: operationUpdateStrategyMergePatch}).getUpdateMethod())
+	assert.Equal(t, httpPatch, (&specResourceOperation{updateStrategy: operationUpdateStrategyJSONPatch}).getUpdateMethod())
+}
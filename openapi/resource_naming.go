@@ -0,0 +1,47 @@
+package openapi
+
+// CurrentResourceNamingVersion is the version of the algorithm the resource and data source names are built with from the
+// OpenAPI document. The version is increased every time the algorithm changes in a way that the names of existing resources
+// change, so service providers can keep building the names with a previous version (resource_naming_version service
+// configuration) and existing state addresses are not orphaned when the provider is upgraded.
+//
+// Versions:
+// 1: the names are built from the resource path segments as is (e,g: /v1/cdn-edges -> cdn-edges_v1)
+// 2: the hyphens of the resource path segments are replaced with underscores so the names follow Terraform's snake case
+// naming convention (e,g: /v1/cdn-edges -> cdn_edges)
+const CurrentResourceNamingVersion = resourceNamingV2
+
+const (
+	resourceNamingV1 = 1
+	resourceNamingV2 = 2
+)
+
+// namingVersionAwareResource is implemented by the resources whose name can be built with the previous versions of the
+// naming algorithm
+type namingVersionAwareResource interface {
+	getResourceNameForNamingVersion(namingVersion int) (string, error)
+}
+
+// GetResourceNameForNamingVersion returns the name of the resource built with the given version of the naming algorithm.
+// The current name of the resource is returned if the resource name does not depend on the naming algorithm version or
+// the name can not be built with the version given
+func GetResourceNameForNamingVersion(resource SpecResource, namingVersion int) string {
+	if namingVersion == 0 || namingVersion == CurrentResourceNamingVersion {
+		return resource.GetResourceName()
+	}
+	namingVersionAware, ok := resource.(namingVersionAwareResource)
+	if !ok {
+		return resource.GetResourceName()
+	}
+	name, err := namingVersionAware.getResourceNameForNamingVersion(namingVersion)
+	if err != nil {
+		analyserLog.Warn("failed to build the name of resource '%s' with the naming version %d, using the current name instead: %s", resource.GetResourceName(), namingVersion, err)
+		return resource.GetResourceName()
+	}
+	return name
+}
+
+// isResourceNamingVersionSupported returns true if the naming version given is one of the naming algorithm versions
+func isResourceNamingVersionSupported(namingVersion int) bool {
+	return namingVersion >= resourceNamingV1 && namingVersion <= CurrentResourceNamingVersion
+}
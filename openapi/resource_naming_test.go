@@ -0,0 +1,45 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetResourceNameForNamingVersion(t *testing.T) {
+	paths := map[string]spec.PathItem{
+		"/v1/cdns":      {},
+		"/v1/cdns/{id}": {},
+	}
+	resource, err := newSpecV2Resource("/v1/cdn-edges", spec.Schema{}, spec.PathItem{}, spec.PathItem{}, nil, paths)
+	require.NoError(t, err)
+	subResource, err := newSpecV2Resource("/v1/cdns/{id}/origin-rules", spec.Schema{}, spec.PathItem{}, spec.PathItem{}, nil, paths)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		resource      SpecResource
+		namingVersion int
+		expectedName  string
+	}{
+		{name: "current naming version", resource: resource, namingVersion: CurrentResourceNamingVersion, expectedName: "cdn_edges"},
+		{name: "naming version not configured", resource: resource, namingVersion: 0, expectedName: "cdn_edges"},
+		{name: "naming version 1 keeps the hyphens (and the version)", resource: resource, namingVersion: resourceNamingV1, expectedName: "cdn-edges_v1"},
+		{name: "subresource naming version 1", resource: subResource, namingVersion: resourceNamingV1, expectedName: "cdns_v1_origin-rules"},
+		{name: "subresource current naming version", resource: subResource, namingVersion: CurrentResourceNamingVersion, expectedName: "cdns_v1_origin_rules"},
+		{name: "naming version not supported falls back to the current name", resource: resource, namingVersion: 99, expectedName: "cdn_edges"},
+		{name: "resource not aware of the naming versions", resource: newSpecStubResource("cdn_edges", "/v1/cdn-edges", false, nil), namingVersion: resourceNamingV1, expectedName: "cdn_edges"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedName, GetResourceNameForNamingVersion(tc.resource, tc.namingVersion), tc.name)
+	}
+}
+
+func TestIsResourceNamingVersionSupported(t *testing.T) {
+	assert.False(t, isResourceNamingVersionSupported(0))
+	assert.True(t, isResourceNamingVersionSupported(resourceNamingV1))
+	assert.True(t, isResourceNamingVersionSupported(CurrentResourceNamingVersion))
+	assert.False(t, isResourceNamingVersionSupported(CurrentResourceNamingVersion+1))
+}
@@ -0,0 +1,129 @@
+package openapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// pollProgressReportInterval defines how often the progress of long-running polls is reported in the logs. Status
+// transitions are always reported as soon as they are observed
+var pollProgressReportInterval = time.Duration(1 * time.Minute)
+
+// pollProgress keeps track of the progress of the polling of a resource (current status, elapsed time and last status
+// transition) so users watching long-running operations (e,g: clusters taking 45 minutes to be built) can tell the
+// operation is progressing rather than hung
+type pollProgress struct {
+	resourceName string
+	id           string
+	// now returns the current time, it can be overridden in tests
+	now func() time.Time
+
+	startedAt        time.Time
+	lastReportedAt   time.Time
+	status           string
+	previousStatus   string
+	lastTransitionAt time.Time
+	polls            int
+	// events contains the statuses observed in order along with the time they were first observed
+	events []pollEvent
+}
+
+// pollEvent defines a status observed while polling a resource
+type pollEvent struct {
+	status    string
+	timestamp time.Time
+}
+
+func newPollProgress(resourceName, id string) *pollProgress {
+	now := time.Now()
+	return &pollProgress{
+		resourceName:   resourceName,
+		id:             id,
+		now:            time.Now,
+		startedAt:      now,
+		lastReportedAt: now,
+	}
+}
+
+// track returns a resource.StateRefreshFunc that records the status returned by the refresh function passed in every time
+// the resource is polled
+func (p *pollProgress) track(refresh resource.StateRefreshFunc) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		remoteData, status, err := refresh()
+		if err == nil {
+			p.update(status)
+		}
+		return remoteData, status, err
+	}
+}
+
+// update records the status passed in, logging status transitions straight away and the overall progress periodically
+// (every pollProgressReportInterval)
+func (p *pollProgress) update(status string) {
+	now := p.now()
+	p.polls++
+	if p.polls == 1 || status != p.status {
+		if p.polls > 1 {
+			p.previousStatus = p.status
+			pollerLog.Info("resource '%s' (%s) status changed from '%s' to '%s' after %s", p.resourceName, p.id, p.status, status, p.elapsed(now))
+		}
+		p.status = status
+		p.lastTransitionAt = now
+		p.events = append(p.events, pollEvent{status: status, timestamp: now})
+	}
+	if now.Sub(p.lastReportedAt) >= pollProgressReportInterval {
+		p.lastReportedAt = now
+		pollerLog.Info("still waiting for resource '%s' (%s) to reach a completion status: %s", p.resourceName, p.id, p)
+	}
+}
+
+// String returns the description of the current progress (e,g: current status 'deploying', elapsed 12m0s, last transition
+// from 'pending' to 'deploying' 5m0s ago)
+func (p *pollProgress) String() string {
+	now := p.now()
+	if p.polls == 0 {
+		return fmt.Sprintf("no status received yet, elapsed %s", p.elapsed(now))
+	}
+	if p.previousStatus == "" {
+		return fmt.Sprintf("current status '%s', elapsed %s, no status transitions observed", p.status, p.elapsed(now))
+	}
+	return fmt.Sprintf("current status '%s', elapsed %s, last transition from '%s' to '%s' %s ago", p.status, p.elapsed(now), p.previousStatus, p.status, now.Sub(p.lastTransitionAt).Round(time.Second))
+}
+
+func (p *pollProgress) elapsed(now time.Time) time.Duration {
+	return now.Sub(p.startedAt).Round(time.Second)
+}
+
+// getEvents returns the statuses observed in the format of the provisioning_events attribute, where operation is the
+// operation the resource was polled for (create or update)
+func (p *pollProgress) getEvents(operation string) []interface{} {
+	events := make([]interface{}, 0, len(p.events))
+	for _, event := range p.events {
+		events = append(events, map[string]interface{}{
+			"operation": operation,
+			"status":    event.status,
+			"timestamp": event.timestamp.UTC().Format(time.RFC3339),
+		})
+	}
+	return events
+}
+
+// provisioningEventsSchema returns the schema of the provisioning_events computed attribute containing the statuses observed
+// while polling the resource after being created or updated
+func provisioningEventsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Statuses observed while polling the resource during the last create or update operation, along with the time they were first observed",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"operation": {Type: schema.TypeString, Computed: true, Description: "Operation the resource was polled for (create or update)"},
+				"status":    {Type: schema.TypeString, Computed: true, Description: "Status of the resource"},
+				"timestamp": {Type: schema.TypeString, Computed: true, Description: "Time (RFC3339) the status was first observed"},
+			},
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollProgress(t *testing.T) {
+	now := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	progress := newPollProgress("cluster", "id")
+	progress.startedAt, progress.lastReportedAt = now, now
+	progress.now = func() time.Time { return now }
+	assert.Equal(t, "no status received yet, elapsed 0s", progress.String())
+
+	now = now.Add(30 * time.Second)
+	progress.update("pending")
+	assert.Equal(t, "current status 'pending', elapsed 30s, no status transitions observed", progress.String())
+	assert.Equal(t, now.Add(-30*time.Second), progress.lastReportedAt, "the progress should not be reported before the report interval")
+
+	now = now.Add(2 * time.Minute)
+	progress.update("deploying")
+	assert.Equal(t, now, progress.lastReportedAt, "the progress should be reported once the report interval is reached")
+
+	now = now.Add(10 * time.Minute)
+	progress.update("deploying")
+	assert.Equal(t, "current status 'deploying', elapsed 12m30s, last transition from 'pending' to 'deploying' 10m0s ago", progress.String())
+	assert.Equal(t, 3, progress.polls)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"operation": "create", "status": "pending", "timestamp": "2021-01-01T10:00:30Z"},
+		map[string]interface{}{"operation": "create", "status": "deploying", "timestamp": "2021-01-01T10:02:30Z"},
+	}, progress.getEvents("create"))
+}
+
+func TestPollProgressTrack(t *testing.T) {
+	progress := newPollProgress("cluster", "id")
+	statuses := []string{"pending", "deploying"}
+	refresh := progress.track(func() (interface{}, string, error) {
+		status := statuses[0]
+		statuses = statuses[1:]
+		return map[string]interface{}{"status": status}, status, nil
+	})
+	_, status, err := refresh()
+	assert.NoError(t, err)
+	assert.Equal(t, "pending", status)
+	_, status, err = refresh()
+	assert.NoError(t, err)
+	assert.Equal(t, "deploying", status)
+	assert.Equal(t, "deploying", progress.status)
+	assert.Equal(t, "pending", progress.previousStatus)
+
+	// failed refreshes are not recorded
+	refresh = progress.track(func() (interface{}, string, error) {
+		return nil, "", errors.New("some error")
+	})
+	_, _, err = refresh()
+	assert.EqualError(t, err, "some error")
+	assert.Equal(t, 2, progress.polls)
+}
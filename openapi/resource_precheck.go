@@ -0,0 +1,144 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultPrecheckMessageField defines the response payload field containing the message returned to the user when the
+// precheck fails if the operation does not have the x-terraform-precheck-message-field extension
+const defaultPrecheckMessageField = "message"
+
+var pathParameterPlaceholderRegex = regexp.MustCompile(`{[\w-]*}`)
+
+// renderPath returns the precheck path with the path parameters resolved in order with the parent IDs passed in. Precheck
+// paths may use fewer path parameters than the parent IDs available (e,g: account wide quota endpoints)
+func (p *specPrecheck) renderPath(parentIDs []string) (string, error) {
+	return resolvePathParameters(p.path, parentIDs)
+}
+
+// resolvePathParameters returns the path passed in with the path parameters (e,g: {project_id}) resolved in order with the
+// parent IDs passed in. The path may use fewer path parameters than the parent IDs available
+func resolvePathParameters(path string, parentIDs []string) (string, error) {
+	pathParameters := pathParameterPlaceholderRegex.FindAllString(path, -1)
+	if len(pathParameters) > len(parentIDs) {
+		return "", fmt.Errorf("could not resolve the path '%s' with the given ids - missing ids to resolve the path params properly: %s", path, parentIDs)
+	}
+	resolvedPath := path
+	for idx, pathParameter := range pathParameters {
+		resolvedPath = strings.Replace(resolvedPath, pathParameter, parentIDs[idx], 1)
+	}
+	return resolvedPath, nil
+}
+
+// hasPathParameters returns true if the precheck path contains path parameters that need to be resolved with the parent IDs
+func (p *specPrecheck) hasPathParameters() bool {
+	return pathParameterPlaceholderRegex.MatchString(p.path)
+}
+
+// precheck performs the precheck request configured in the POST operation (x-terraform-precheck-path) and returns an error
+// containing the message returned by the API if the resource can not be created (e,g: quota exceeded). Nothing is checked
+// if the resource does not have a precheck configured
+func (r resourceFactory) precheck(providerClient ClientOpenAPI, parentIDs ...string) error {
+	operation := r.openAPIResource.getResourceOperations().Post
+	if operation == nil || operation.precheck == nil {
+		return nil
+	}
+	precheck := operation.precheck
+	resourceName := r.openAPIResource.GetResourceName()
+	responsePayload := map[string]interface{}{}
+	res, err := providerClient.Precheck(r.openAPIResource, precheck, &responsePayload, parentIDs...)
+	if err != nil {
+		return fmt.Errorf("[resource='%s'] precheck GET %s failed: %s", resourceName, precheck.path, err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("[resource='%s'] precheck GET %s failed with response status code (%d): %s", resourceName, precheck.path, res.StatusCode, getPrecheckErrorMessage(res, precheck.messageField))
+	}
+	if precheck.field == "" {
+		return nil
+	}
+	if value, _ := getPayloadValue(responsePayload, precheck.field); isFalsy(value) {
+		message, ok := getPayloadValue(responsePayload, precheck.messageField)
+		if !ok || message == nil || message == "" {
+			message = fmt.Sprintf("precheck field '%s' is not true (%v)", precheck.field, value)
+		}
+		return fmt.Errorf("[resource='%s'] precheck GET %s failed: %v", resourceName, precheck.path, message)
+	}
+	return nil
+}
+
+// planPrecheck performs the precheck when planning the creation of resources so failures (e,g: quota exceeded) are reported
+// at plan time. Prechecks whose path needs to be resolved with the parent IDs are only performed when the resource is
+// created since the parent IDs may not be known when planning
+func (r resourceFactory) planPrecheck(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
+	if diff.Id() != "" {
+		return nil
+	}
+	providerClient, ok := i.(ClientOpenAPI)
+	if !ok {
+		return nil
+	}
+	operation := r.openAPIResource.getResourceOperations().Post
+	if operation == nil || operation.precheck == nil || operation.precheck.hasPathParameters() {
+		return nil
+	}
+	return r.precheck(providerClient)
+}
+
+// getPrecheckErrorMessage returns the message contained in the message field of the error response body; the whole body
+// is returned if the body does not contain the message field
+func getPrecheckErrorMessage(res *http.Response, messageField string) string {
+	if res.Body == nil {
+		return http.StatusText(res.StatusCode)
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil || len(b) == 0 {
+		return http.StatusText(res.StatusCode)
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(b, &payload); err == nil {
+		if message, ok := getPayloadValue(payload, messageField); ok && message != nil && message != "" {
+			return fmt.Sprintf("%v", message)
+		}
+	}
+	return string(b)
+}
+
+// getPayloadValue returns the value of the field passed in, which can be dot separated for nested fields (e,g: quota.available)
+func getPayloadValue(payload map[string]interface{}, field string) (interface{}, bool) {
+	var value interface{} = payload
+	for _, name := range strings.Split(field, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// isFalsy returns true if the value is missing (nil), false, zero, an empty string or the string 'false'
+func isFalsy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case int:
+		return v == 0
+	case string:
+		return v == "" || strings.EqualFold(v, "false")
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecPrecheckRenderPath(t *testing.T) {
+	testCases := []struct {
+		name          string
+		path          string
+		parentIDs     []string
+		expectedPath  string
+		expectedError string
+	}{
+		{name: "path without parameters", path: "/v1/quotas/clusters", expectedPath: "/v1/quotas/clusters"},
+		{name: "path parameters resolved with the parent IDs", path: "/v1/projects/{project_id}/quota", parentIDs: []string{"p1"}, expectedPath: "/v1/projects/p1/quota"},
+		{name: "path with fewer parameters than parent IDs", path: "/v1/projects/{project_id}/quota", parentIDs: []string{"p1", "c1"}, expectedPath: "/v1/projects/p1/quota"},
+		{name: "missing parent IDs", path: "/v1/projects/{project_id}/quota", expectedError: "could not resolve the path '/v1/projects/{project_id}/quota' with the given ids - missing ids to resolve the path params properly: []"},
+	}
+	for _, tc := range testCases {
+		precheck := &specPrecheck{path: tc.path}
+		path, err := precheck.renderPath(tc.parentIDs)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedPath, path, tc.name)
+		assert.Equal(t, strings.Contains(tc.path, "{"), precheck.hasPathParameters(), tc.name)
+	}
+}
+
+func TestResourceFactoryPrecheck(t *testing.T) {
+	testCases := []struct {
+		name            string
+		precheck        *specPrecheck
+		responsePayload map[string]interface{}
+		returnHTTPCode  int
+		clientError     error
+		expectedError   string
+	}{
+		{
+			name: "precheck not configured",
+		},
+		{
+			name:            "precheck field true",
+			precheck:        &specPrecheck{path: "/v1/quotas/clusters", field: "quota.available", messageField: "message"},
+			responsePayload: map[string]interface{}{"quota": map[string]interface{}{"available": true}},
+		},
+		{
+			name:            "only the status code is checked if there is no precheck field",
+			precheck:        &specPrecheck{path: "/v1/quotas/clusters", messageField: "message"},
+			responsePayload: map[string]interface{}{"allowed": false},
+		},
+		{
+			name:            "precheck field false with message",
+			precheck:        &specPrecheck{path: "/v1/quotas/clusters", field: "allowed", messageField: "message"},
+			responsePayload: map[string]interface{}{"allowed": false, "message": "quota exceeded: 10 of 10 clusters in use"},
+			expectedError:   "[resource='resourceName'] precheck GET /v1/quotas/clusters failed: quota exceeded: 10 of 10 clusters in use",
+		},
+		{
+			name:            "precheck field missing without message",
+			precheck:        &specPrecheck{path: "/v1/quotas/clusters", field: "allowed", messageField: "message"},
+			responsePayload: map[string]interface{}{},
+			expectedError:   "[resource='resourceName'] precheck GET /v1/quotas/clusters failed: precheck field 'allowed' is not true (<nil>)",
+		},
+		{
+			name:           "non 2xx response",
+			precheck:       &specPrecheck{path: "/v1/quotas/clusters", field: "allowed", messageField: "message"},
+			returnHTTPCode: http.StatusForbidden,
+			expectedError:  "[resource='resourceName'] precheck GET /v1/quotas/clusters failed with response status code (403): Forbidden",
+		},
+		{
+			name:          "client error",
+			precheck:      &specPrecheck{path: "/v1/quotas/clusters", messageField: "message"},
+			clientError:   errors.New("some error"),
+			expectedError: "[resource='resourceName'] precheck GET /v1/quotas/clusters failed: some error",
+		},
+	}
+	for _, tc := range testCases {
+		r, _ := testCreateResourceFactory(t, newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, "some label"))
+		r.openAPIResource.getResourceOperations().Post.precheck = tc.precheck
+		client := &clientOpenAPIStub{precheckResponsePayload: tc.responsePayload, returnHTTPCode: tc.returnHTTPCode, error: tc.clientError}
+		err := r.precheck(client, "p1")
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		if tc.precheck != nil {
+			assert.Equal(t, []*specPrecheck{tc.precheck}, client.prechecksReceived, tc.name)
+			assert.Equal(t, []string{"p1"}, client.parentIDsReceived, tc.name)
+		}
+	}
+}
+
+func TestResourceFactoryCreatePrecheckFailed(t *testing.T) {
+	r, resourceData := testCreateResourceFactory(t, newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, "some label"))
+	r.openAPIResource.getResourceOperations().Post.precheck = &specPrecheck{path: "/v1/quotas/clusters", field: "allowed", messageField: "message"}
+	client := &clientOpenAPIStub{precheckResponsePayload: map[string]interface{}{"allowed": false, "message": "quota exceeded"}}
+	err := r.create(resourceData, client)
+	assert.EqualError(t, err, "[resource='resourceName'] precheck GET /v1/quotas/clusters failed: quota exceeded")
+	assert.Empty(t, resourceData.Id(), "the resource should not be created if the precheck fails")
+}
+
+func TestGetPrecheckErrorMessage(t *testing.T) {
+	testCases := []struct {
+		name            string
+		body            string
+		expectedMessage string
+	}{
+		{name: "message field", body: `{"error":{"message":"quota exceeded"}}`, expectedMessage: "quota exceeded"},
+		{name: "body without message field", body: `{"code":"QUOTA_EXCEEDED"}`, expectedMessage: `{"code":"QUOTA_EXCEEDED"}`},
+		{name: "plain text body", body: "quota exceeded", expectedMessage: "quota exceeded"},
+		{name: "empty body", expectedMessage: "Too Many Requests"},
+	}
+	for _, tc := range testCases {
+		res := &http.Response{StatusCode: http.StatusTooManyRequests, Body: ioutil.NopCloser(strings.NewReader(tc.body))}
+		assert.Equal(t, tc.expectedMessage, getPrecheckErrorMessage(res, "error.message"), tc.name)
+	}
+}
+
+func TestIsFalsy(t *testing.T) {
+	for _, value := range []interface{}{nil, false, float64(0), 0, "", "false", "FALSE"} {
+		assert.True(t, isFalsy(value), "%v", value)
+	}
+	for _, value := range []interface{}{true, float64(1), 5, "true", "yes", map[string]interface{}{}} {
+		assert.False(t, isFalsy(value), "%v", value)
+	}
+}
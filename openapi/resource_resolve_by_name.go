@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resolveNames looks up the IDs of the resources referenced by the properties resolved by name (x-terraform-resolve-by-name)
+// whose name attribute has been configured or changed, and sets them in the request payload passed in. The properties whose
+// name has not changed keep the ID stored in the state
+func (r resourceFactory) resolveNames(data *schema.ResourceData, providerClient ClientOpenAPI, requestPayload map[string]interface{}, parentIDs ...string) error {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	for _, property := range resourceSchema.Properties {
+		attribute := property.getResolveByNameAttribute()
+		if attribute == "" || !data.HasChange(attribute) {
+			continue
+		}
+		name, ok := data.GetOk(attribute)
+		if !ok {
+			continue
+		}
+		id, err := r.lookupID(providerClient, property.ResolveByName, name.(string), parentIDs...)
+		if err != nil {
+			return fmt.Errorf("[resource='%s'] failed to resolve the %s '%s': %s", r.openAPIResource.GetResourceName(), attribute, name, err)
+		}
+		resourceLog.Debug("'%s' %s '%s' resolved to %s '%s'", r.openAPIResource.GetResourceName(), attribute, name, property.GetTerraformCompliantPropertyName(), id)
+		requestPayload[property.Name] = id
+	}
+	return nil
+}
+
+// lookupID lists the resources from the lookup path and returns the ID of the one with the name passed in. An error is
+// returned if no resource or more than one resource have the name
+func (r resourceFactory) lookupID(providerClient ClientOpenAPI, resolveByName *specResolveByName, name string, parentIDs ...string) (string, error) {
+	var responsePayload interface{}
+	res, err := providerClient.Lookup(r.openAPIResource, resolveByName.path, &responsePayload, parentIDs...)
+	if err != nil {
+		return "", err
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK}); err != nil {
+		return "", fmt.Errorf("GET %s failed: %s", resolveByName.path, err)
+	}
+	items := responsePayload
+	if resolveByName.itemsField != "" {
+		object, _ := responsePayload.(map[string]interface{})
+		items, _ = getPayloadValue(object, resolveByName.itemsField)
+	}
+	list, ok := items.([]interface{})
+	if !ok {
+		return "", fmt.Errorf("GET %s did not return a list of items", resolveByName.path)
+	}
+	var ids []string
+	for _, item := range list {
+		object, ok := item.(map[string]interface{})
+		if !ok || object[resolveByName.nameField] != name {
+			continue
+		}
+		if id, exists := object[resolveByName.idField]; exists && id != nil {
+			ids = append(ids, fmt.Sprintf("%v", id))
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no item found with %s '%s' in GET %s", resolveByName.nameField, name, resolveByName.path)
+	case 1:
+		return ids[0], nil
+	}
+	sort.Strings(ids)
+	return "", fmt.Errorf("more than one item found with %s '%s' in GET %s: %v", resolveByName.nameField, name, resolveByName.path, ids)
+}
+
+// planResolveByName marks the properties resolved by name as unknown when their name attribute changes, so the plan shows
+// the ID will be resolved again when applying the changes
+func (r resourceFactory) planResolveByName(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	for _, property := range resourceSchema.Properties {
+		attribute := property.getResolveByNameAttribute()
+		if attribute == "" || !diff.HasChange(attribute) {
+			continue
+		}
+		if name, ok := diff.GetOk(attribute); ok && name != "" {
+			if err := diff.SetNewComputed(property.GetTerraformCompliantPropertyName()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceFactoryLookupID(t *testing.T) {
+	vpcs := []interface{}{
+		map[string]interface{}{"id": "vpc-1", "name": "prod"},
+		map[string]interface{}{"id": "vpc-2", "name": "dev"},
+		map[string]interface{}{"id": "vpc-3", "name": "dev"},
+	}
+	testCases := []struct {
+		name            string
+		resolveByName   *specResolveByName
+		lookupName      string
+		responsePayload interface{}
+		returnHTTPCode  int
+		clientError     error
+		expectedID      string
+		expectedError   string
+	}{
+		{
+			name:            "item found",
+			resolveByName:   &specResolveByName{path: "/v1/vpcs", nameField: "name", idField: "id"},
+			lookupName:      "prod",
+			responsePayload: vpcs,
+			expectedID:      "vpc-1",
+		},
+		{
+			name:            "item found in wrapped response",
+			resolveByName:   &specResolveByName{path: "/v1/vpcs", nameField: "label", idField: "uuid", itemsField: "data.items"},
+			lookupName:      "prod",
+			responsePayload: map[string]interface{}{"data": map[string]interface{}{"items": []interface{}{map[string]interface{}{"uuid": float64(10), "label": "prod"}}}},
+			expectedID:      "10",
+		},
+		{
+			name:            "item not found",
+			resolveByName:   &specResolveByName{path: "/v1/vpcs", nameField: "name", idField: "id"},
+			lookupName:      "staging",
+			responsePayload: vpcs,
+			expectedError:   "no item found with name 'staging' in GET /v1/vpcs",
+		},
+		{
+			name:            "more than one item found",
+			resolveByName:   &specResolveByName{path: "/v1/vpcs", nameField: "name", idField: "id"},
+			lookupName:      "dev",
+			responsePayload: vpcs,
+			expectedError:   "more than one item found with name 'dev' in GET /v1/vpcs: [vpc-2 vpc-3]",
+		},
+		{
+			name:            "response is not a list",
+			resolveByName:   &specResolveByName{path: "/v1/vpcs", nameField: "name", idField: "id"},
+			lookupName:      "prod",
+			responsePayload: map[string]interface{}{"items": vpcs},
+			expectedError:   "GET /v1/vpcs did not return a list of items",
+		},
+		{
+			name:           "non 200 response",
+			resolveByName:  &specResolveByName{path: "/v1/vpcs", nameField: "name", idField: "id"},
+			lookupName:     "prod",
+			returnHTTPCode: http.StatusInternalServerError,
+			expectedError:  "GET /v1/vpcs failed: [resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200] ()",
+		},
+		{
+			name:          "client error",
+			resolveByName: &specResolveByName{path: "/v1/vpcs", nameField: "name", idField: "id"},
+			lookupName:    "prod",
+			clientError:   errors.New("some error"),
+			expectedError: "some error",
+		},
+	}
+	for _, tc := range testCases {
+		r, _ := testCreateResourceFactory(t)
+		client := &clientOpenAPIStub{lookupResponsePayload: tc.responsePayload, returnHTTPCode: tc.returnHTTPCode, error: tc.clientError}
+		id, err := r.lookupID(client, tc.resolveByName, tc.lookupName, "p1")
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedID, id, tc.name)
+		assert.Equal(t, []string{tc.resolveByName.path}, client.lookupPathsReceived, tc.name)
+		assert.Equal(t, []string{"p1"}, client.parentIDsReceived, tc.name)
+	}
+}
+
+func TestResourceFactoryResolveNames(t *testing.T) {
+	vpcProperty := newStringSchemaDefinitionPropertyWithDefaults("vpc_id", "", false, false, nil)
+	vpcProperty.ResolveByName = &specResolveByName{path: "/v1/vpcs", nameField: "name", idField: "id"}
+	subnetProperty := newStringSchemaDefinitionPropertyWithDefaults("subnet_id", "", false, false, nil)
+	subnetProperty.ResolveByName = &specResolveByName{path: "/v1/subnets", nameField: "name", idField: "id"}
+	resourceSchema := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{vpcProperty, subnetProperty}}
+	r := newResourceFactory(newSpecStubResourceWithOperations("cluster", "/v1/clusters", false, resourceSchema, &specResourceOperation{}, nil, nil, nil))
+	terraformSchema, err := resourceSchema.createResourceSchema()
+	assert.NoError(t, err)
+	data := schema.TestResourceDataRaw(t, terraformSchema, map[string]interface{}{"vpc_name": "prod", "subnet_id": "subnet-1"})
+
+	client := &clientOpenAPIStub{lookupResponsePayload: []interface{}{map[string]interface{}{"id": "vpc-1", "name": "prod"}}}
+	requestPayload := map[string]interface{}{"subnet_id": "subnet-1"}
+	assert.NoError(t, r.resolveNames(data, client, requestPayload))
+	assert.Equal(t, map[string]interface{}{"vpc_id": "vpc-1", "subnet_id": "subnet-1"}, requestPayload)
+	assert.Equal(t, []string{"/v1/vpcs"}, client.lookupPathsReceived, "only the names configured should be looked up")
+
+	client = &clientOpenAPIStub{lookupResponsePayload: []interface{}{}}
+	err = r.resolveNames(data, client, map[string]interface{}{})
+	assert.EqualError(t, err, "[resource='cluster'] failed to resolve the vpc_name 'prod': no item found with name 'prod' in GET /v1/vpcs")
+}
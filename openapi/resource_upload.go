@@ -0,0 +1,104 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// uploadFiles uploads the local files configured for the upload properties (x-terraform-upload-property) whose file
+// attribute has been configured or changed, and sets the keys of the objects uploaded in the request payload passed in.
+// The properties whose file has not changed keep the key stored in the state
+func (r resourceFactory) uploadFiles(data *schema.ResourceData, providerClient ClientOpenAPI, requestPayload map[string]interface{}, parentIDs ...string) error {
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	for _, property := range resourceSchema.Properties {
+		attribute := property.getUploadAttribute()
+		if attribute == "" || !data.HasChange(attribute) {
+			continue
+		}
+		filePath, ok := data.GetOk(attribute)
+		if !ok {
+			continue
+		}
+		key, err := r.uploadFile(providerClient, property.Upload, filePath.(string), parentIDs...)
+		if err != nil {
+			return fmt.Errorf("[resource='%s'] failed to upload the %s '%s': %s", r.openAPIResource.GetResourceName(), attribute, filePath, err)
+		}
+		resourceLog.Debug("'%s' %s '%s' uploaded as %s '%s'", r.openAPIResource.GetResourceName(), attribute, filePath, property.GetTerraformCompliantPropertyName(), key)
+		requestPayload[property.Name] = key
+	}
+	return nil
+}
+
+// uploadFile requests a signed upload URL to the upload path, uploads the content of the local file to it and returns the
+// key of the object uploaded
+func (r resourceFactory) uploadFile(providerClient ClientOpenAPI, upload *specUpload, filePath string, parentIDs ...string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	requestPayload := map[string]interface{}{"filename": filepath.Base(filePath), "size": fileInfo.Size()}
+	responsePayload := map[string]interface{}{}
+	res, err := providerClient.RequestUpload(r.openAPIResource, upload.path, requestPayload, &responsePayload, parentIDs...)
+	if err != nil {
+		return "", err
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusCreated}); err != nil {
+		return "", fmt.Errorf("POST %s failed: %s", upload.path, err)
+	}
+	uploadURLValue, _ := getPayloadValue(responsePayload, upload.urlField)
+	uploadURL, _ := uploadURLValue.(string)
+	if uploadURL == "" {
+		return "", fmt.Errorf("POST %s response is missing the upload URL field '%s'", upload.path, upload.urlField)
+	}
+	key, _ := getPayloadValue(responsePayload, upload.keyField)
+	if key == nil || key == "" {
+		return "", fmt.Errorf("POST %s response is missing the key field '%s'", upload.path, upload.keyField)
+	}
+
+	res, err = providerClient.Upload(uploadURL, file, fileInfo.Size())
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return "", fmt.Errorf("upload to the signed URL responded with status code '%d'", res.StatusCode)
+	}
+	return fmt.Sprintf("%v", key), nil
+}
+
+// planUpload marks the upload properties as unknown when their file attribute changes, so the plan shows the file will be
+// uploaded again when applying the changes
+func (r resourceFactory) planUpload(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	for _, property := range resourceSchema.Properties {
+		attribute := property.getUploadAttribute()
+		if attribute == "" || !diff.HasChange(attribute) {
+			continue
+		}
+		if filePath, ok := diff.GetOk(attribute); ok && filePath != "" {
+			if err := diff.SetNewComputed(property.GetTerraformCompliantPropertyName()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
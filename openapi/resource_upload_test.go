@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceFactoryUploadFiles(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "bundle.zip")
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("bundle content"), 0600))
+
+	bundleProperty := newStringSchemaDefinitionPropertyWithDefaults("bundle_key", "", true, false, nil)
+	bundleProperty.Upload = &specUpload{path: "/v1/uploads", urlField: "upload.url", keyField: "key"}
+	iconProperty := newStringSchemaDefinitionPropertyWithDefaults("icon_key", "", false, false, nil)
+	iconProperty.Upload = &specUpload{path: "/v1/icons", urlField: "upload_url", keyField: "key"}
+	resourceSchema := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{bundleProperty, iconProperty}}
+	r := newResourceFactory(newSpecStubResourceWithOperations("function", "/v1/functions", false, resourceSchema, &specResourceOperation{}, nil, nil, nil))
+	terraformSchema, err := resourceSchema.createResourceSchema()
+	require.NoError(t, err)
+	data := schema.TestResourceDataRaw(t, terraformSchema, map[string]interface{}{"bundle_file": filePath, "icon_key": "icons/default.png"})
+
+	client := &clientOpenAPIStub{uploadResponsePayload: map[string]interface{}{"upload": map[string]interface{}{"url": "https://storage.example.com/bundles/1?signature=abc"}, "key": "bundles/1"}}
+	requestPayload := map[string]interface{}{"icon_key": "icons/default.png"}
+	require.NoError(t, r.uploadFiles(data, client, requestPayload))
+	assert.Equal(t, map[string]interface{}{"bundle_key": "bundles/1", "icon_key": "icons/default.png"}, requestPayload)
+	assert.Equal(t, []string{"/v1/uploads"}, client.uploadRequestsReceived, "only the files configured should be uploaded")
+	assert.Equal(t, []interface{}{map[string]interface{}{"filename": "bundle.zip", "size": int64(14)}}, client.uploadRequestPayloads)
+	assert.Equal(t, []string{"PUT https://storage.example.com/bundles/1?signature=abc bundle content"}, client.uploadsReceived)
+
+	client = &clientOpenAPIStub{uploadResponsePayload: map[string]interface{}{"key": "bundles/1"}}
+	err = r.uploadFiles(data, client, map[string]interface{}{})
+	assert.EqualError(t, err, "[resource='function'] failed to upload the bundle_file '"+filePath+"': POST /v1/uploads response is missing the upload URL field 'upload.url'")
+
+	data = schema.TestResourceDataRaw(t, terraformSchema, map[string]interface{}{"bundle_file": filepath.Join(t.TempDir(), "missing.zip")})
+	err = r.uploadFiles(data, &clientOpenAPIStub{}, map[string]interface{}{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to upload the bundle_file")
+}
+
+func TestProviderClientUpload(t *testing.T) {
+	var received []string
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = append(received, r.Method+" "+r.URL.RequestURI()+" "+string(body)+" "+r.Header.Get(authorizationHeader))
+	}))
+	defer storage.Close()
+	providerClient := &ProviderClient{httpClient: &http_goclient.HttpClient{HttpClient: &http.Client{}}}
+	resp, err := providerClient.Upload(storage.URL+"/bundles/1?signature=abc", strings.NewReader("bundle content"), 14)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"PUT /bundles/1?signature=abc bundle content "}, received, "the content should be uploaded without the API headers")
+}
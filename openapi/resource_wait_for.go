@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// waitForPropertyName is the name of the optional block users configure to wait for a resource attribute to have one of
+// the values specified after the resource is created or updated, when the POST or PUT operations have x-terraform-wait-for
+// enabled
+const waitForPropertyName = "wait_for"
+
+// waitForCondition defines the condition configured in the wait_for block
+type waitForCondition struct {
+	attribute string
+	values    []string
+	timeout   time.Duration
+}
+
+// waitForSchema returns the schema of the wait_for block
+func waitForSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Condition the resource must meet after being created or updated before the operation completes",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"attribute": {Type: schema.TypeString, Required: true, Description: "Name of the resource attribute waited on (e,g: status)"},
+				"values": {
+					Type:        schema.TypeList,
+					Required:    true,
+					MinItems:    1,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Values the attribute must have one of (e,g: [\"RUNNING\"])",
+				},
+				"timeout": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateWaitForTimeout,
+					Description:  "Max time to wait for the condition to be met (e,g: 30m). If not provided, the create or update timeout of the resource is used",
+				},
+			},
+		},
+	}
+}
+
+// validateWaitForTimeout validates the wait_for timeout is a valid duration (e,g: 30m)
+func validateWaitForTimeout(value interface{}, key string) ([]string, []error) {
+	if _, err := time.ParseDuration(value.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s '%s' not valid: %s", key, value, err)}
+	}
+	return nil, nil
+}
+
+// isWaitForEnabled returns true if the resource POST or PUT operations support waiting for the condition configured in the
+// wait_for block (x-terraform-wait-for)
+func (r resourceFactory) isWaitForEnabled() bool {
+	operations := r.openAPIResource.getResourceOperations()
+	return (operations.Post != nil && operations.Post.waitFor) || (operations.Put != nil && operations.Put.waitFor)
+}
+
+// getWaitForCondition returns the condition configured in the wait_for block; nil if the block is not configured. The
+// timeout defaults to the timeout of the operation passed in (create or update) if not configured
+func getWaitForCondition(data *schema.ResourceData, timeoutFor string) *waitForCondition {
+	waitFor, ok := data.Get(waitForPropertyName).([]interface{})
+	if !ok || len(waitFor) == 0 || waitFor[0] == nil {
+		return nil
+	}
+	waitForBlock := waitFor[0].(map[string]interface{})
+	condition := &waitForCondition{timeout: data.Timeout(timeoutFor)}
+	condition.attribute, _ = waitForBlock["attribute"].(string)
+	values, _ := waitForBlock["values"].([]interface{})
+	for _, value := range values {
+		if value, ok := value.(string); ok {
+			condition.values = append(condition.values, value)
+		}
+	}
+	if timeout, _ := waitForBlock["timeout"].(string); timeout != "" {
+		condition.timeout, _ = time.ParseDuration(timeout)
+	}
+	return condition
+}
+
+// waitFor waits for the resource attribute configured in the wait_for block to have one of the values configured, reading
+// the resource until the condition is met or the timeout expires. The latest remote data read is returned so the state
+// is populated with it. The remote data passed in (the response of the create or update) is returned as is if the
+// operation does not have x-terraform-wait-for enabled, the wait_for block is not configured or the condition is already met
+func (r resourceFactory) waitFor(data *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, remoteData map[string]interface{}, timeoutFor string, parentIDs ...string) (map[string]interface{}, error) {
+	if operation == nil || !operation.waitFor {
+		return remoteData, nil
+	}
+	condition := getWaitForCondition(data, timeoutFor)
+	if condition == nil {
+		return remoteData, nil
+	}
+	resourceName := r.openAPIResource.GetResourceName()
+	schemaDefinition, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	property, err := schemaDefinition.getPropertyBasedOnTerraformName(condition.attribute)
+	if err != nil {
+		return nil, fmt.Errorf("[resource='%s'] %s attribute '%s' not valid: %s", resourceName, waitForPropertyName, condition.attribute, err)
+	}
+	getValue := func(remoteData map[string]interface{}) string {
+		value, exists := remoteData[property.Name]
+		if !exists || value == nil {
+			return ""
+		}
+		return fmt.Sprintf("%v", value)
+	}
+	if remoteData != nil && isWaitForConditionMet(condition, getValue(remoteData)) {
+		return remoteData, nil
+	}
+
+	resourceLog.Info("Waiting for resource '%s' (%s) %s to be one of %s", resourceName, data.Id(), condition.attribute, condition.values)
+	stateConf := &resource.StateChangeConf{
+		Target: condition.values,
+		Refresh: func() (interface{}, string, error) {
+			remoteData, err := r.readRemote(data.Id(), providerClient, parentIDs...)
+			if err != nil {
+				return nil, "", fmt.Errorf("error on retrieving resource '%s' (%s) when waiting: %s", resourceName, data.Id(), err)
+			}
+			value := getValue(remoteData)
+			resourceLog.Debug("resource '%s' (%s) %s: %s", resourceName, data.Id(), condition.attribute, value)
+			return remoteData, value, nil
+		},
+		Timeout:      condition.timeout,
+		PollInterval: r.defaultPollInterval,
+		MinTimeout:   r.defaultPollMinTimeout,
+		Delay:        r.defaultPollDelay,
+	}
+	result, err := stateConf.WaitForState()
+	if err != nil {
+		return nil, fmt.Errorf("[resource='%s'] error waiting for %s to be one of %s: %s", resourceName, condition.attribute, condition.values, err)
+	}
+	return result.(map[string]interface{}), nil
+}
+
+func isWaitForConditionMet(condition *waitForCondition, value string) bool {
+	for _, expectedValue := range condition.values {
+		if value == expectedValue {
+			return true
+		}
+	}
+	return false
+}
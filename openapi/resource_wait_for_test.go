@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTerraformResourceSchemaWithWaitFor(t *testing.T) {
+	r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+	s, err := r.createTerraformResourceSchema()
+	require.NoError(t, err)
+	assert.NotContains(t, s, waitForPropertyName)
+
+	r.openAPIResource.getResourceOperations().Post.waitFor = true
+	s, err = r.createTerraformResourceSchema()
+	require.NoError(t, err)
+	require.Contains(t, s, waitForPropertyName)
+	assert.True(t, s[waitForPropertyName].Optional)
+	assert.Equal(t, 1, s[waitForPropertyName].MaxItems)
+
+	r, _ = testCreateResourceFactory(t, idProperty, newStringSchemaDefinitionPropertyWithDefaults(waitForPropertyName, "", false, true, nil))
+	r.openAPIResource.getResourceOperations().Put.waitFor = true
+	_, err = r.createTerraformResourceSchema()
+	assert.EqualError(t, err, "resource 'resourceName' has x-terraform-wait-for enabled but the schema already contains a property named 'wait_for'")
+}
+
+func TestResourceFactoryWaitFor(t *testing.T) {
+	waitForRunning := []interface{}{map[string]interface{}{"attribute": "status", "values": []interface{}{"RUNNING", "DEGRADED"}, "timeout": "1m"}}
+	testCases := []struct {
+		name                string
+		waitForEnabled      bool
+		waitFor             []interface{}
+		remoteData          map[string]interface{}
+		getResponsePayloads []map[string]interface{}
+		expectedRemoteData  map[string]interface{}
+		expectedGetCalls    int
+		expectedError       string
+	}{
+		{
+			name:               "wait for not enabled",
+			waitFor:            waitForRunning,
+			remoteData:         map[string]interface{}{"id": "id", "status": "PENDING"},
+			expectedRemoteData: map[string]interface{}{"id": "id", "status": "PENDING"},
+		},
+		{
+			name:               "wait for not configured",
+			waitForEnabled:     true,
+			remoteData:         map[string]interface{}{"id": "id", "status": "PENDING"},
+			expectedRemoteData: map[string]interface{}{"id": "id", "status": "PENDING"},
+		},
+		{
+			name:               "condition already met",
+			waitForEnabled:     true,
+			waitFor:            waitForRunning,
+			remoteData:         map[string]interface{}{"id": "id", "status": "DEGRADED"},
+			expectedRemoteData: map[string]interface{}{"id": "id", "status": "DEGRADED"},
+		},
+		{
+			name:                "resource read until the condition is met",
+			waitForEnabled:      true,
+			waitFor:             waitForRunning,
+			remoteData:          map[string]interface{}{"id": "id", "status": "PENDING"},
+			getResponsePayloads: []map[string]interface{}{{"id": "id", "status": "PENDING"}, {"id": "id", "status": "STARTING"}, {"id": "id", "status": "RUNNING"}},
+			expectedRemoteData:  map[string]interface{}{"id": "id", "status": "RUNNING"},
+			expectedGetCalls:    3,
+		},
+		{
+			name:                "no remote data (e,g: 204 No Content update)",
+			waitForEnabled:      true,
+			waitFor:             waitForRunning,
+			getResponsePayloads: []map[string]interface{}{{"id": "id", "status": "RUNNING"}},
+			expectedRemoteData:  map[string]interface{}{"id": "id", "status": "RUNNING"},
+			expectedGetCalls:    1,
+		},
+		{
+			name:                "condition not met before the timeout expires",
+			waitForEnabled:      true,
+			waitFor:             []interface{}{map[string]interface{}{"attribute": "status", "values": []interface{}{"RUNNING"}, "timeout": "20ms"}},
+			remoteData:          map[string]interface{}{"id": "id", "status": "PENDING"},
+			getResponsePayloads: []map[string]interface{}{{"id": "id", "status": "PENDING"}},
+			expectedError:       "[resource='resourceName'] error waiting for status to be one of [RUNNING]: timeout while waiting for state to become 'RUNNING' (last state: 'PENDING', timeout: 20ms)",
+		},
+		{
+			name:           "attribute not defined in the resource schema",
+			waitForEnabled: true,
+			waitFor:        []interface{}{map[string]interface{}{"attribute": "state", "values": []interface{}{"RUNNING"}}},
+			remoteData:     map[string]interface{}{"id": "id", "status": "PENDING"},
+			expectedError:  "[resource='resourceName'] wait_for attribute 'state' not valid: property with terraform name 'state' not existing in resource schema definition",
+		},
+	}
+	for _, tc := range testCases {
+		r, _ := testCreateResourceFactory(t, idProperty, statusProperty)
+		r.defaultPollDelay = 0
+		r.defaultPollInterval = time.Millisecond
+		r.defaultPollMinTimeout = time.Millisecond
+		operation := r.openAPIResource.getResourceOperations().Post
+		operation.waitFor = true
+		s, err := r.createTerraformResourceSchema()
+		require.NoError(t, err, tc.name)
+		operation.waitFor = tc.waitForEnabled
+		resourceData := schema.TestResourceDataRaw(t, s, map[string]interface{}{waitForPropertyName: tc.waitFor})
+		resourceData.SetId("id")
+		client := &clientOpenAPIStub{getResponsePayloads: tc.getResponsePayloads}
+
+		remoteData, err := r.waitFor(resourceData, client, operation, tc.remoteData, schema.TimeoutCreate, "parentID")
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedRemoteData, remoteData, tc.name)
+		assert.Equal(t, tc.expectedGetCalls, client.getCalls, tc.name)
+		if tc.expectedGetCalls > 0 {
+			assert.Equal(t, []string{"parentID"}, client.parentIDsReceived, tc.name)
+		}
+	}
+}
+
+func TestResourceFactoryCreateWithWaitFor(t *testing.T) {
+	r, _ := testCreateResourceFactory(t, idProperty, stringProperty, statusProperty)
+	r.defaultPollDelay = 0
+	r.defaultPollInterval = time.Millisecond
+	r.defaultPollMinTimeout = time.Millisecond
+	r.openAPIResource.getResourceOperations().Post.waitFor = true
+	s, err := r.createTerraformResourceSchema()
+	require.NoError(t, err)
+	resourceData := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		stringProperty.Name: "some value",
+		waitForPropertyName: []interface{}{map[string]interface{}{"attribute": "status", "values": []interface{}{"RUNNING"}}},
+	})
+	client := &clientOpenAPIStub{
+		responsePayload:     map[string]interface{}{idProperty.Name: "id", stringProperty.Name: "some value", statusProperty.Name: "PENDING"},
+		getResponsePayloads: []map[string]interface{}{{idProperty.Name: "id", stringProperty.Name: "some value", statusProperty.Name: "STARTING"}, {idProperty.Name: "id", stringProperty.Name: "some value", statusProperty.Name: "RUNNING"}},
+	}
+	err = r.create(resourceData, client)
+	require.NoError(t, err)
+	assert.Equal(t, "RUNNING", resourceData.Get(statusProperty.Name), "the state should be populated with the resource representation that met the condition")
+	assert.Equal(t, 2, client.getCalls)
+}
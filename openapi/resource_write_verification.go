@@ -0,0 +1,119 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// verifyWrite reads the resource after being created or updated and compares the remote representation with the request
+// payload sent, returning operationWarnings listing the properties whose values the API did not keep (e,g: APIs that accept
+// and ignore unknown or invalid values). Nothing is verified if the read-after-write verification is not enabled. The
+// verification is best effort so failures reading the resource are only logged
+func (r resourceFactory) verifyWrite(data *schema.ResourceData, providerClient ClientOpenAPI, operation string, requestPayload map[string]interface{}, parentIDs ...string) error {
+	if !r.verifyWrites {
+		return nil
+	}
+	resourceName := r.openAPIResource.GetResourceName()
+	remoteData, err := r.readRemote(data.Id(), providerClient, parentIDs...)
+	if err != nil {
+		resourceLog.Warn("failed to read '%s' (%s) to verify the %s: %s", resourceName, data.Id(), operation, err)
+		return nil
+	}
+	resourceSchema, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	mismatches, err := getWriteMismatches(resourceSchema, requestPayload, remoteData)
+	if err != nil {
+		resourceLog.Warn("failed to verify the %s of '%s' (%s): %s", operation, resourceName, data.Id(), err)
+		return nil
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return &operationWarnings{
+		summary: fmt.Sprintf("'%s' (%s) %s: the API did not keep some of the values sent", resourceName, data.Id(), operation),
+		details: mismatches,
+	}
+}
+
+// getWriteMismatches returns the descriptions of the properties of the request payload that are not kept in the remote data.
+// Only the user configurable properties are verified, sensitive properties are skipped since APIs usually do not return
+// them. Objects are verified recursively considering only the properties sent (the API may return additional computed
+// properties) and lists with IgnoreItemsOrder enabled are verified regardless of the order of the items
+func getWriteMismatches(resourceSchema *SpecSchemaDefinition, requestPayload, remoteData map[string]interface{}) ([]string, error) {
+	normalizedRequestPayload, err := normalizeJSONPayload(requestPayload)
+	if err != nil {
+		return nil, err
+	}
+	normalizedRemoteData, err := normalizeJSONPayload(remoteData)
+	if err != nil {
+		return nil, err
+	}
+	var mismatches []string
+	for propertyName, sentValue := range normalizedRequestPayload {
+		property, err := resourceSchema.getProperty(propertyName)
+		if err != nil || property.isComputed() || property.Sensitive || property.IsParentProperty {
+			continue
+		}
+		remoteValue, exists := normalizedRemoteData[propertyName]
+		switch {
+		case !exists || (remoteValue == nil && sentValue != nil):
+			mismatches = append(mismatches, fmt.Sprintf("property '%s' was sent but not returned by the API", property.GetTerraformCompliantPropertyName()))
+		case !isSentValueKept(sentValue, remoteValue, property.shouldIgnoreOrder()):
+			mismatches = append(mismatches, fmt.Sprintf("property '%s' was sent with value %v but the API returned %v", property.GetTerraformCompliantPropertyName(), sentValue, remoteValue))
+		}
+	}
+	sort.Strings(mismatches)
+	return mismatches, nil
+}
+
+// isSentValueKept returns true if the value sent matches the remote value. Objects match if all the properties sent match
+// the remote ones, and lists match if they have the same number of items matching one to one (by position unless
+// ignoreOrder is true)
+func isSentValueKept(sentValue, remoteValue interface{}, ignoreOrder bool) bool {
+	switch sent := sentValue.(type) {
+	case map[string]interface{}:
+		remote, ok := remoteValue.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range sent {
+			if !isSentValueKept(value, remote[key], false) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		remote, ok := remoteValue.([]interface{})
+		if !ok || len(sent) != len(remote) {
+			return false
+		}
+		if !ignoreOrder {
+			for i := range sent {
+				if !isSentValueKept(sent[i], remote[i], false) {
+					return false
+				}
+			}
+			return true
+		}
+		matched := make([]bool, len(remote))
+		for _, sentItem := range sent {
+			found := false
+			for i, remoteItem := range remote {
+				if !matched[i] && isSentValueKept(sentItem, remoteItem, false) {
+					matched[i], found = true, true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(sentValue, remoteValue)
+}
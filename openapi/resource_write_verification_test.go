@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWriteMismatches(t *testing.T) {
+	tagsProperty := newListSchemaDefinitionPropertyWithDefaults("tags", "", false, false, false, nil, TypeString, nil)
+	tagsProperty.IgnoreItemsOrder = true
+	passwordProperty := newStringSchemaDefinitionPropertyWithDefaults("password", "", false, false, nil)
+	passwordProperty.Sensitive = true
+	objectSchemaDefinition := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			newStringSchemaDefinitionPropertyWithDefaults("origin_port", "", false, false, nil),
+		},
+	}
+	resourceSchema := &SpecSchemaDefinition{
+		Properties: SpecSchemaDefinitionProperties{
+			newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, nil),
+			newIntSchemaDefinitionPropertyWithDefaults("node_count", "", false, false, nil),
+			newStringSchemaDefinitionPropertyWithDefaults("status", "", false, true, nil),
+			newObjectSchemaDefinitionPropertyWithDefaults("config", "", false, false, false, nil, objectSchemaDefinition),
+			newListSchemaDefinitionPropertyWithDefaults("zones", "", false, false, false, nil, TypeString, nil),
+			tagsProperty,
+			passwordProperty,
+		},
+	}
+	testCases := []struct {
+		name               string
+		requestPayload     map[string]interface{}
+		remoteData         map[string]interface{}
+		expectedMismatches []string
+	}{
+		{
+			name:           "all the values sent are kept",
+			requestPayload: map[string]interface{}{"label": "label", "node_count": 3, "config": map[string]interface{}{"origin_port": "80"}, "zones": []interface{}{"a", "b"}, "tags": []interface{}{"x", "y"}},
+			remoteData:     map[string]interface{}{"label": "label", "node_count": 3, "status": "ready", "config": map[string]interface{}{"origin_port": "80", "protocol": "http"}, "zones": []interface{}{"a", "b"}, "tags": []interface{}{"y", "x"}},
+		},
+		{
+			name:           "computed and sensitive properties are not verified",
+			requestPayload: map[string]interface{}{"label": "label", "status": "ready", "password": "secret"},
+			remoteData:     map[string]interface{}{"label": "label", "status": "deploying"},
+		},
+		{
+			name:           "values not kept by the API",
+			requestPayload: map[string]interface{}{"label": "label", "node_count": 3, "config": map[string]interface{}{"origin_port": "80"}, "zones": []interface{}{"a", "b"}, "tags": []interface{}{"x", "y"}},
+			remoteData:     map[string]interface{}{"node_count": 1, "config": map[string]interface{}{"origin_port": "8080"}, "zones": []interface{}{"b", "a"}, "tags": []interface{}{"x"}},
+			expectedMismatches: []string{
+				"property 'config' was sent with value map[origin_port:80] but the API returned map[origin_port:8080]",
+				"property 'label' was sent but not returned by the API",
+				"property 'node_count' was sent with value 3 but the API returned 1",
+				"property 'tags' was sent with value [x y] but the API returned [x]",
+				"property 'zones' was sent with value [a b] but the API returned [b a]",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		mismatches, err := getWriteMismatches(resourceSchema, tc.requestPayload, tc.remoteData)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedMismatches, mismatches, tc.name)
+	}
+}
+
+func TestResourceFactoryVerifyWrite(t *testing.T) {
+	r, resourceData := testCreateResourceFactory(t, newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, "some label"))
+	resourceData.SetId("id")
+	requestPayload := map[string]interface{}{"label": "some label"}
+
+	// verification disabled
+	client := &clientOpenAPIStub{responsePayload: map[string]interface{}{"label": "some other label"}}
+	assert.NoError(t, r.verifyWrite(resourceData, client, "create", requestPayload))
+	assert.Equal(t, 0, client.getCalls)
+
+	r.verifyWrites = true
+	err := r.verifyWrite(resourceData, client, "create", requestPayload)
+	var warnings *operationWarnings
+	assert.True(t, errors.As(err, &warnings))
+	assert.Equal(t, "'resourceName' (id) create: the API did not keep some of the values sent", warnings.summary)
+	assert.Equal(t, []string{"property 'label' was sent with value some label but the API returned some other label"}, warnings.details)
+
+	client = &clientOpenAPIStub{responsePayload: map[string]interface{}{"label": "some label"}}
+	assert.NoError(t, r.verifyWrite(resourceData, client, "update", requestPayload))
+	assert.Equal(t, 1, client.getCalls)
+
+	// the verification is best effort so failures reading the resource are ignored
+	client = &clientOpenAPIStub{returnHTTPCode: http.StatusInternalServerError}
+	assert.NoError(t, r.verifyWrite(resourceData, client, "update", requestPayload))
+}
+
+func TestCrudDiagnostics(t *testing.T) {
+	assert.Nil(t, crudDiagnostics(nil))
+
+	diags := crudDiagnostics(errors.New("some error"))
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "some error", diags[0].Summary)
+
+	diags = crudDiagnostics(&operationWarnings{summary: "some summary", details: []string{"detail 1", "detail 2"}})
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "some summary", diags[0].Summary)
+	assert.Equal(t, "detail 1\ndetail 2", diags[0].Detail)
+}
@@ -0,0 +1,129 @@
+package openapi
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updateGoldenFiles regenerates the golden files of the golden-file tests with the current output instead of comparing
+// against them: go test ./openapi -run TestStateConversionGolden -update
+var updateGoldenFiles = flag.Bool("update", false, "update the golden files of the golden-file tests")
+
+// stateConversionGoldenDir contains the golden-file test cases of the state conversion, one directory per case with the
+// following files:
+// - schema.json: the schema of the resource model as defined in the OpenAPI document definitions
+// - payload.json: the payload returned by the API
+// - prior_state.json (optional): the values of the resource in the terraform configuration/state before the payload is saved
+// - state.golden.json: the resulting terraform state attributes (flatmap)
+const stateConversionGoldenDir = "testdata/state_conversion"
+
+// TestStateConversionGolden locks down how the payloads returned by the API are converted into the terraform state (e,g:
+// nested objects, lists of objects or lists that ignore the order). New cases are added by creating a new directory with
+// the input files and running the test with the -update flag to generate the golden file, which must be reviewed
+func TestStateConversionGolden(t *testing.T) {
+	caseDirs, err := filepath.Glob(filepath.Join(stateConversionGoldenDir, "*"))
+	require.NoError(t, err)
+	require.NotEmpty(t, caseDirs)
+	for _, caseDir := range caseDirs {
+		caseDir := caseDir
+		t.Run(filepath.Base(caseDir), func(t *testing.T) {
+			state, err := convertStateConversionGoldenCase(t, caseDir)
+			require.NoError(t, err)
+			goldenFile := filepath.Join(caseDir, "state.golden.json")
+			if *updateGoldenFiles {
+				require.NoError(t, ioutil.WriteFile(goldenFile, state, 0644))
+			}
+			expectedState, err := ioutil.ReadFile(goldenFile)
+			require.NoError(t, err, "golden file missing, run the test with the -update flag to generate it")
+			assert.Equal(t, string(expectedState), string(state))
+		})
+	}
+}
+
+// convertStateConversionGoldenCase saves the payload of the case into the state of the resource described by the case
+// schema and returns the resulting state attributes
+func convertStateConversionGoldenCase(t *testing.T, caseDir string) ([]byte, error) {
+	resourceSchema, err := ioutil.ReadFile(filepath.Join(caseDir, "schema.json"))
+	if err != nil {
+		return nil, err
+	}
+	var payload map[string]interface{}
+	if err := readGoldenCaseJSONFile(filepath.Join(caseDir, "payload.json"), &payload); err != nil {
+		return nil, err
+	}
+	priorState := map[string]interface{}{}
+	if err := readGoldenCaseJSONFile(filepath.Join(caseDir, "prior_state.json"), &priorState); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	file := initAPISpecFile(newStateConversionGoldenSwagger(string(resourceSchema)))
+	defer os.Remove(file.Name())
+	analyser, err := newSpecAnalyserV2(file.Name())
+	if err != nil {
+		return nil, err
+	}
+	resources, err := analyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) != 1 {
+		return nil, fmt.Errorf("expected the case schema to produce one resource, found %d", len(resources))
+	}
+
+	r := newResourceFactory(resources[0])
+	terraformSchema, err := r.createTerraformResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	data := schema.TestResourceDataRaw(t, terraformSchema, priorState)
+	data.SetId("golden")
+	if err := r.updateStateWithPayloadData(payload, data); err != nil {
+		return nil, err
+	}
+	state, err := json.MarshalIndent(data.State().Attributes, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(state, '\n'), nil
+}
+
+func readGoldenCaseJSONFile(path string, value interface{}) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, value)
+}
+
+// newStateConversionGoldenSwagger returns an OpenAPI document containing a resource with the given schema
+func newStateConversionGoldenSwagger(resourceSchema string) string {
+	return fmt.Sprintf(`{
+  "swagger": "2.0",
+  "paths": {
+    "/v1/resources": {
+      "post": {
+        "parameters": [{"in": "body", "name": "body", "required": true, "schema": {"$ref": "#/definitions/ResourceV1"}}],
+        "responses": {"201": {"schema": {"$ref": "#/definitions/ResourceV1"}}}
+      }
+    },
+    "/v1/resources/{id}": {
+      "get": {
+        "parameters": [{"in": "path", "name": "id", "type": "string", "required": true}],
+        "responses": {"200": {"schema": {"$ref": "#/definitions/ResourceV1"}}}
+      }
+    }
+  },
+  "definitions": {
+    "ResourceV1": %s
+  }
+}`, resourceSchema)
+}
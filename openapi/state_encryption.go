@@ -0,0 +1,223 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// fingerprintStateValuePrefix is prepended to the fingerprints stored in the state, followed by the first
+// fingerprintStateValueLength hex characters of the SHA-256 hash of the value
+const fingerprintStateValuePrefix = "sha256:"
+const fingerprintStateValueLength = 16
+
+// encryptedStateValuePrefix is prepended to the values encrypted in the state, followed by the base64 encoded AES-256-GCM
+// nonce and ciphertext
+const encryptedStateValuePrefix = "enc:v1:"
+
+// stateEncrypter encrypts the values of the properties with the x-terraform-encrypt-in-state extension before they are
+// stored in the state. The key is resolved the first time it is needed, so the key command is only executed if the
+// provider manages resources with encrypted properties
+type stateEncrypter struct {
+	config *StateEncryptionConfig
+	once   sync.Once
+	aead   cipher.AEAD
+	err    error
+}
+
+// newStateEncrypter returns a stateEncrypter using the key configured; nil is returned if the state encryption is not configured
+func newStateEncrypter(config *StateEncryptionConfig) *stateEncrypter {
+	if config == nil {
+		return nil
+	}
+	return &stateEncrypter{config: config}
+}
+
+func (e *stateEncrypter) getAEAD() (cipher.AEAD, error) {
+	e.once.Do(func() {
+		key, err := e.getKey()
+		if err != nil {
+			e.err = fmt.Errorf("failed to get the state encryption key: %s", err)
+			return
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			e.err = err
+			return
+		}
+		e.aead, e.err = cipher.NewGCM(block)
+	})
+	return e.aead, e.err
+}
+
+func (e *stateEncrypter) getKey() ([]byte, error) {
+	var encodedKey string
+	if e.config.KeyEnv != "" {
+		encodedKey = os.Getenv(e.config.KeyEnv)
+		if encodedKey == "" {
+			return nil, fmt.Errorf("environment variable '%s' is not set", e.config.KeyEnv)
+		}
+	} else {
+		output, err := e.runKeyCommand()
+		if err != nil {
+			return nil, err
+		}
+		encodedKey = output
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("key is not base64 encoded: %s", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key must be 256 bits long, got %d bits", len(key)*8)
+	}
+	return key, nil
+}
+
+func (e *stateEncrypter) runKeyCommand() (string, error) {
+	timeout := cmdTimeout
+	if e.config.KeyCommandTimeout > 0 {
+		timeout = e.config.KeyCommandTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, e.config.KeyCommand[0], e.config.KeyCommand[1:]...) // #nosec G204
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("key command '%s' did not finish executing within the expected time %ds", e.config.KeyCommand[0], timeout)
+	}
+	if err != nil {
+		return "", fmt.Errorf("key command '%s' failed: %s(%s)", e.config.KeyCommand[0], stderr.String(), err)
+	}
+	return stdout.String(), nil
+}
+
+func (e *stateEncrypter) encrypt(value string) (string, error) {
+	aead, err := e.getAEAD()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	ciphertext := aead.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedStateValuePrefix + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+func (e *stateEncrypter) decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedStateValuePrefix) {
+		return "", fmt.Errorf("value is not encrypted")
+	}
+	aead, err := e.getAEAD()
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedStateValuePrefix))
+	if err != nil {
+		return "", err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return "", fmt.Errorf("encrypted value is too short")
+	}
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// fingerprintStateValue returns the fingerprint stored in the state instead of the value of the properties with the
+// x-terraform-fingerprint-in-state extension: the prefix of the SHA-256 hash of the value
+func fingerprintStateValue(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return fingerprintStateValuePrefix + hex.EncodeToString(hash[:])[:fingerprintStateValueLength]
+}
+
+// validateProtectedStateProperties checks that the properties with the x-terraform-encrypt-in-state or
+// x-terraform-fingerprint-in-state extensions are read only string properties and that the state encryption is configured
+// if there are properties to encrypt
+func validateProtectedStateProperties(openAPIResource SpecResource, schemaDefinition *SpecSchemaDefinition, encrypter *stateEncrypter) error {
+	for _, property := range schemaDefinition.Properties {
+		if !property.EncryptInState && !property.FingerprintInState {
+			continue
+		}
+		if property.EncryptInState && property.FingerprintInState {
+			return fmt.Errorf("resource '%s' property '%s' can not have both %s and %s extensions", openAPIResource.GetResourceName(), property.Name, extTfEncryptInState, extTfFingerprintInState)
+		}
+		extension := extTfEncryptInState
+		if property.FingerprintInState {
+			extension = extTfFingerprintInState
+		}
+		if property.Type != TypeString || !property.ReadOnly {
+			return fmt.Errorf("resource '%s' property '%s' %s extension not valid: only read only string properties are supported", openAPIResource.GetResourceName(), property.Name, extension)
+		}
+		if property.EncryptInState && encrypter == nil {
+			return fmt.Errorf("resource '%s' property '%s' has the %s extension but the state encryption is not configured", openAPIResource.GetResourceName(), property.Name, extTfEncryptInState)
+		}
+	}
+	return nil
+}
+
+// protectStateProperties returns a copy of the remote data where the values of the properties with the
+// x-terraform-fingerprint-in-state extension are replaced with their fingerprint and the values of the properties with
+// the x-terraform-encrypt-in-state extension are encrypted. If the value stored in the state decrypts to the remote value,
+// the value stored is kept so the state does not change on every refresh (the encryption is not deterministic)
+func protectStateProperties(openAPIResource SpecResource, encrypter *stateEncrypter, remoteData map[string]interface{}, resourceLocalData *schema.ResourceData) (map[string]interface{}, error) {
+	resourceSchema, err := openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	var protectedData map[string]interface{}
+	for _, property := range resourceSchema.Properties {
+		if !property.FingerprintInState && (!property.EncryptInState || encrypter == nil) {
+			continue
+		}
+		remoteValue, ok := remoteData[property.Name].(string)
+		if !ok {
+			continue
+		}
+		if protectedData == nil {
+			protectedData = make(map[string]interface{}, len(remoteData))
+			for k, v := range remoteData {
+				protectedData[k] = v
+			}
+		}
+		if property.FingerprintInState {
+			protectedData[property.Name] = fingerprintStateValue(remoteValue)
+			continue
+		}
+		if stateValue, _ := resourceLocalData.Get(property.GetTerraformCompliantPropertyName()).(string); stateValue != "" {
+			if decryptedValue, err := encrypter.decrypt(stateValue); err == nil && decryptedValue == remoteValue {
+				protectedData[property.Name] = stateValue
+				continue
+			}
+		}
+		encryptedValue, err := encrypter.encrypt(remoteValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt the '%s' property value: %s", property.Name, err)
+		}
+		protectedData[property.Name] = encryptedValue
+	}
+	if protectedData == nil {
+		return remoteData, nil
+	}
+	return protectedData, nil
+}
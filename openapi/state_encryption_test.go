@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStateEncryptionKey = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+func TestNewStateEncrypter(t *testing.T) {
+	assert.Nil(t, newStateEncrypter(nil))
+	assert.NotNil(t, newStateEncrypter(&StateEncryptionConfig{KeyEnv: "OTF_STATE_ENCRYPTION_KEY"}))
+}
+
+func TestStateEncrypterEncryptDecrypt(t *testing.T) {
+	t.Setenv("OTF_STATE_ENCRYPTION_KEY", testStateEncryptionKey)
+	testCases := []struct {
+		name   string
+		config *StateEncryptionConfig
+	}{
+		{name: "key provided via environment variable", config: &StateEncryptionConfig{KeyEnv: "OTF_STATE_ENCRYPTION_KEY"}},
+		{name: "key provided via command", config: &StateEncryptionConfig{KeyCommand: []string{"echo", testStateEncryptionKey}}},
+	}
+	for _, tc := range testCases {
+		e := newStateEncrypter(tc.config)
+		encryptedValue, err := e.encrypt("some secret")
+		require.NoError(t, err, tc.name)
+		assert.True(t, strings.HasPrefix(encryptedValue, encryptedStateValuePrefix), tc.name)
+		assert.NotContains(t, encryptedValue, "some secret", tc.name)
+
+		otherEncryptedValue, err := e.encrypt("some secret")
+		require.NoError(t, err, tc.name)
+		assert.NotEqual(t, encryptedValue, otherEncryptedValue, "%s: encrypted values should use different nonces", tc.name)
+
+		decryptedValue, err := e.decrypt(encryptedValue)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, "some secret", decryptedValue, tc.name)
+	}
+}
+
+func TestStateEncrypterDecryptErrors(t *testing.T) {
+	t.Setenv("OTF_STATE_ENCRYPTION_KEY", testStateEncryptionKey)
+	e := newStateEncrypter(&StateEncryptionConfig{KeyEnv: "OTF_STATE_ENCRYPTION_KEY"})
+	_, err := e.decrypt("some secret")
+	assert.EqualError(t, err, "value is not encrypted")
+	_, err = e.decrypt(encryptedStateValuePrefix + "AAAA")
+	assert.EqualError(t, err, "encrypted value is too short")
+
+	t.Setenv("OTF_OTHER_STATE_ENCRYPTION_KEY", base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210")))
+	encryptedValue, err := newStateEncrypter(&StateEncryptionConfig{KeyEnv: "OTF_OTHER_STATE_ENCRYPTION_KEY"}).encrypt("some secret")
+	require.NoError(t, err)
+	_, err = e.decrypt(encryptedValue)
+	assert.EqualError(t, err, "cipher: message authentication failed", "values encrypted with a different key should not be decrypted")
+}
+
+func TestStateEncrypterKeyErrors(t *testing.T) {
+	t.Setenv("OTF_NOT_BASE64_KEY", "not base64!")
+	t.Setenv("OTF_SHORT_KEY", base64.StdEncoding.EncodeToString([]byte("0123456789abcdef")))
+	testCases := []struct {
+		name          string
+		config        *StateEncryptionConfig
+		expectedError string
+	}{
+		{name: "environment variable not set", config: &StateEncryptionConfig{KeyEnv: "OTF_MISSING_KEY"}, expectedError: "failed to get the state encryption key: environment variable 'OTF_MISSING_KEY' is not set"},
+		{name: "key not base64 encoded", config: &StateEncryptionConfig{KeyEnv: "OTF_NOT_BASE64_KEY"}, expectedError: "failed to get the state encryption key: key is not base64 encoded: illegal base64 data at input byte 3"},
+		{name: "key too short", config: &StateEncryptionConfig{KeyEnv: "OTF_SHORT_KEY"}, expectedError: "failed to get the state encryption key: key must be 256 bits long, got 128 bits"},
+		{name: "key command failing", config: &StateEncryptionConfig{KeyCommand: []string{"false"}}, expectedError: "failed to get the state encryption key: key command 'false' failed: (exit status 1)"},
+	}
+	for _, tc := range testCases {
+		_, err := newStateEncrypter(tc.config).encrypt("some secret")
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
+
+func TestFingerprintStateValue(t *testing.T) {
+	fingerprint := fingerprintStateValue("some secret")
+	assert.Equal(t, "sha256:ccb6a08d85c8c3ba", fingerprint)
+	assert.Equal(t, fingerprint, fingerprintStateValue("some secret"), "the fingerprint should be stable")
+	assert.NotEqual(t, fingerprint, fingerprintStateValue("some rotated secret"))
+}
+
+func TestValidateProtectedStateProperties(t *testing.T) {
+	encrypter := newStateEncrypter(&StateEncryptionConfig{KeyEnv: "OTF_STATE_ENCRYPTION_KEY"})
+	newProperty := func(propertyType schemaDefinitionPropertyType, readOnly, encryptInState bool) *SpecSchemaDefinitionProperty {
+		return &SpecSchemaDefinitionProperty{Name: "password", Type: propertyType, ReadOnly: readOnly, EncryptInState: encryptInState}
+	}
+	newFingerprintProperty := func(propertyType schemaDefinitionPropertyType, readOnly bool) *SpecSchemaDefinitionProperty {
+		return &SpecSchemaDefinitionProperty{Name: "password", Type: propertyType, ReadOnly: readOnly, FingerprintInState: true}
+	}
+	testCases := []struct {
+		name          string
+		property      *SpecSchemaDefinitionProperty
+		encrypter     *stateEncrypter
+		expectedError string
+	}{
+		{name: "property not encrypted", property: newProperty(TypeString, false, false)},
+		{name: "read only string property encrypted", property: newProperty(TypeString, true, true), encrypter: encrypter},
+		{name: "property encrypted that is not read only", property: newProperty(TypeString, false, true), encrypter: encrypter, expectedError: "resource 'resourceName' property 'password' x-terraform-encrypt-in-state extension not valid: only read only string properties are supported"},
+		{name: "property encrypted that is not a string", property: newProperty(TypeInt, true, true), encrypter: encrypter, expectedError: "resource 'resourceName' property 'password' x-terraform-encrypt-in-state extension not valid: only read only string properties are supported"},
+		{name: "property encrypted without state encryption configured", property: newProperty(TypeString, true, true), expectedError: "resource 'resourceName' property 'password' has the x-terraform-encrypt-in-state extension but the state encryption is not configured"},
+		{name: "read only string property fingerprinted without state encryption configured", property: newFingerprintProperty(TypeString, true)},
+		{name: "property fingerprinted that is not read only", property: newFingerprintProperty(TypeString, false), expectedError: "resource 'resourceName' property 'password' x-terraform-fingerprint-in-state extension not valid: only read only string properties are supported"},
+		{name: "property both encrypted and fingerprinted", property: &SpecSchemaDefinitionProperty{Name: "password", Type: TypeString, ReadOnly: true, EncryptInState: true, FingerprintInState: true}, encrypter: encrypter, expectedError: "resource 'resourceName' property 'password' can not have both x-terraform-encrypt-in-state and x-terraform-fingerprint-in-state extensions"},
+	}
+	for _, tc := range testCases {
+		schemaDefinition := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{tc.property}}
+		err := validateProtectedStateProperties(&specStubResource{name: "resourceName"}, schemaDefinition, tc.encrypter)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+	}
+}
+
+func TestResourceFactoryEncryptInState(t *testing.T) {
+	t.Setenv("OTF_STATE_ENCRYPTION_KEY", testStateEncryptionKey)
+	passwordProperty := newStringSchemaDefinitionPropertyWithDefaults("password", "", false, true, nil)
+	passwordProperty.EncryptInState = true
+	r, resourceData := testCreateResourceFactory(t, newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, "some label"), passwordProperty)
+	r.stateEncrypter = newStateEncrypter(&StateEncryptionConfig{KeyEnv: "OTF_STATE_ENCRYPTION_KEY"})
+	_, err := r.createTerraformResourceSchema()
+	require.NoError(t, err)
+
+	remoteData := map[string]interface{}{"label": "some label", "password": "some secret"}
+	require.NoError(t, r.updateStateWithPayloadData(remoteData, resourceData))
+	assert.Equal(t, "some secret", remoteData["password"], "the remote data passed in should not be modified")
+	assert.Equal(t, "some label", resourceData.Get("label"))
+	encryptedPassword := resourceData.Get("password").(string)
+	decryptedPassword, err := r.stateEncrypter.decrypt(encryptedPassword)
+	require.NoError(t, err)
+	assert.Equal(t, "some secret", decryptedPassword)
+
+	require.NoError(t, r.updateStateWithPayloadData(map[string]interface{}{"password": "some secret"}, resourceData))
+	assert.Equal(t, encryptedPassword, resourceData.Get("password"), "the value stored in the state should be kept if the remote value has not changed")
+
+	require.NoError(t, r.updateStateWithPayloadData(map[string]interface{}{"password": "some new secret"}, resourceData))
+	decryptedPassword, err = r.stateEncrypter.decrypt(resourceData.Get("password").(string))
+	require.NoError(t, err)
+	assert.Equal(t, "some new secret", decryptedPassword)
+}
+
+func TestResourceFactoryFingerprintInState(t *testing.T) {
+	passwordProperty := newStringSchemaDefinitionPropertyWithDefaults("password", "", false, true, nil)
+	passwordProperty.FingerprintInState = true
+	r, resourceData := testCreateResourceFactory(t, newStringSchemaDefinitionPropertyWithDefaults("label", "", true, false, "some label"), passwordProperty)
+	_, err := r.createTerraformResourceSchema()
+	require.NoError(t, err)
+
+	remoteData := map[string]interface{}{"label": "some label", "password": "some secret"}
+	require.NoError(t, r.updateStateWithPayloadData(remoteData, resourceData))
+	assert.Equal(t, "some secret", remoteData["password"], "the remote data passed in should not be modified")
+	assert.Equal(t, fingerprintStateValue("some secret"), resourceData.Get("password"))
+
+	require.NoError(t, r.updateStateWithPayloadData(map[string]interface{}{"password": "some rotated secret"}, resourceData))
+	assert.Equal(t, fingerprintStateValue("some rotated secret"), resourceData.Get("password"))
+}
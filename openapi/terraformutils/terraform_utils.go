@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/iancoleman/strcase"
 
@@ -18,8 +19,13 @@ import (
 // https://www.terraform.io/docs/configuration/providers.html#third-party-plugins
 const TerraformPluginVendorDir = ".terraform.d/plugins"
 
-// TerraformPluginVendorDirWindows defines the path under which third party terraform plugins are to be installed
-const TerraformPluginVendorDirWindows = "AppData\\terraform.d\\plugins"
+// TerraformPluginVendorDirWindows defines the path under which third party terraform plugins are to be installed on Windows,
+// relative to the user's "Application Data" directory (%APPDATA%)
+const TerraformPluginVendorDirWindows = "terraform.d\\plugins"
+
+// windowsAppDataDir defines the default location of the user's "Application Data" directory relative to the user's home
+// directory, used on Windows when the APPDATA environment variable is not set
+const windowsAppDataDir = "AppData\\Roaming"
 
 // TerraformUtils defines a struct that exposes some handy terraform utils functions
 type TerraformUtils struct {
@@ -27,6 +33,9 @@ type TerraformUtils struct {
 	Platform string
 	// HomeDir defines the user's home directory
 	HomeDir string
+	// AppDataDir defines the user's "Application Data" directory (%APPDATA%). Only used on Windows, if empty the default
+	// location beneath the user's home directory is used
+	AppDataDir string
 }
 
 // NewTerraformUtils is a handy constructor to build a TerraformUtils object with default platform and homeDir values
@@ -37,8 +46,9 @@ func NewTerraformUtils() (*TerraformUtils, error) {
 		return nil, fmt.Errorf("failure occurred when getting the user's home directory: %s", err)
 	}
 	return &TerraformUtils{
-		Platform: runtime.GOOS,
-		HomeDir:  homeDir,
+		Platform:   runtime.GOOS,
+		HomeDir:    homeDir,
+		AppDataDir: os.Getenv("APPDATA"),
 	}, nil
 }
 
@@ -46,27 +56,68 @@ func NewTerraformUtils() (*TerraformUtils, error) {
 // custom plugins such as the OopenAPI Terraform provider. This function supports the most used platforms including
 // darwin, linux and windows.
 func (t *TerraformUtils) GetTerraformPluginsVendorDir() (string, error) {
-	var terraformPluginsFolder string
 	if t.Platform == "" {
 		return "", fmt.Errorf("mandatory platform information is missing")
 	}
 	if t.HomeDir == "" {
 		return "", fmt.Errorf("mandatory HomeDir value missing")
 	}
-	// On all other systems, in the sub-path .terraform.d/plugins in your user's home directory.
-	terraformPluginsFolder = fmt.Sprintf("%s/%s", t.HomeDir, TerraformPluginVendorDir)
 	// On Windows, in the sub-path (%APPDATA%\terraform.d\plugins) beneath your user's "Application Data" directory.
 	if t.Platform == "windows" {
-		terraformPluginsFolder = fmt.Sprintf("%s\\%s", t.HomeDir, TerraformPluginVendorDirWindows)
+		appDataDir := t.AppDataDir
+		if appDataDir == "" {
+			appDataDir = t.joinPath(t.HomeDir, windowsAppDataDir)
+		}
+		return t.joinPath(appDataDir, TerraformPluginVendorDirWindows), nil
+	}
+	// On all other systems, in the sub-path .terraform.d/plugins in your user's home directory.
+	return t.joinPath(t.HomeDir, TerraformPluginVendorDir), nil
+}
+
+// GetTerraformPluginsVendorFilePath returns the path of the file passed in located in Terraform's global plugin vendor
+// directory (e,g: the plugin configuration file)
+func (t *TerraformUtils) GetTerraformPluginsVendorFilePath(fileName string) (string, error) {
+	vendorDir, err := t.GetTerraformPluginsVendorDir()
+	if err != nil {
+		return "", err
+	}
+	return t.joinPath(vendorDir, fileName), nil
+}
+
+// joinPath joins the given path elements using the path separator of the platform. The path package can not be used
+// since it relies on the OS the binary is running on rather than the configured platform
+func (t *TerraformUtils) joinPath(base, elem string) string {
+	separator := "/"
+	if t.Platform == "windows" {
+		separator = "\\"
 	}
-	return terraformPluginsFolder, nil
+	return strings.TrimRight(base, "/\\") + separator + elem
 }
 
 var numberInName = regexp.MustCompile("([0-9]+)")
 
+// compliantNamesCache holds the names already converted by ConvertToTerraformCompliantName. The conversion relies on
+// regular expressions and is called for every property of every payload processed, so caching the results considerably
+// speeds up the processing of big payloads.
+// The cache is not bounded on purpose: the names converted are always the ones defined in the OpenAPI documents loaded
+// (property, header, security definition and provider names), never the payload keys returned by the API, so the cache
+// can not grow beyond the number of distinct names of the documents. This holds even when the documents are reloaded
+// (e,g: dev mode), since the names of the revisions of a document mostly overlap. Callers converting values that are
+// not defined in the OpenAPI documents must use a cache scoped to their lifetime instead.
+var compliantNamesCache sync.Map
+
 // ConvertToTerraformCompliantName will convert the input string into a terraform compatible field name following
 // Terraform's snake case field name convention (lower case and snake case).
 func ConvertToTerraformCompliantName(name string) string {
+	if compliantName, ok := compliantNamesCache.Load(name); ok {
+		return compliantName.(string)
+	}
+	compliantName := convertToTerraformCompliantName(name)
+	compliantNamesCache.Store(name, compliantName)
+	return compliantName
+}
+
+func convertToTerraformCompliantName(name string) string {
 	//convert the name is Snake Case, this is the ONLY operation is needed in most of the case...
 	compliantName := strcase.ToSnake(name)
 
@@ -1,13 +1,13 @@
 package terraformutils
 
 import (
-	"fmt"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestTerraformUtilsGetTerraformPluginsVendorDir(t *testing.T) {
@@ -53,7 +53,7 @@ func TestTerraformUtilsGetTerraformPluginsVendorDir(t *testing.T) {
 				So(err, ShouldBeNil)
 			})
 			Convey("And vendor dir should be the windows specific path`", func() {
-				So(vendorDir, ShouldEqual, fmt.Sprintf("%s\\AppData\\terraform.d\\plugins", homeDir))
+				So(vendorDir, ShouldEqual, "C:\\Users\\username\\AppData\\Roaming\\terraform.d\\plugins")
 			})
 		})
 	})
@@ -89,6 +89,57 @@ func TestTerraformUtilsGetTerraformPluginsVendorDir(t *testing.T) {
 	})
 }
 
+func TestTerraformUtilsGetTerraformPluginsVendorFilePath(t *testing.T) {
+	testCases := []struct {
+		name             string
+		terraformUtils   TerraformUtils
+		expectedFilePath string
+	}{
+		{
+			name:             "linux platform",
+			terraformUtils:   TerraformUtils{Platform: "linux", HomeDir: "/home/username"},
+			expectedFilePath: "/home/username/.terraform.d/plugins/terraform-provider-openapi.yaml",
+		},
+		{
+			name:             "darwin platform with home dir ending with separator",
+			terraformUtils:   TerraformUtils{Platform: "darwin", HomeDir: "/Users/username/"},
+			expectedFilePath: "/Users/username/.terraform.d/plugins/terraform-provider-openapi.yaml",
+		},
+		{
+			name:             "windows platform with APPDATA set",
+			terraformUtils:   TerraformUtils{Platform: "windows", HomeDir: "C:\\Users\\username", AppDataDir: "D:\\Profiles\\username\\AppData\\Roaming\\"},
+			expectedFilePath: "D:\\Profiles\\username\\AppData\\Roaming\\terraform.d\\plugins\\terraform-provider-openapi.yaml",
+		},
+		{
+			name:             "windows platform without APPDATA set",
+			terraformUtils:   TerraformUtils{Platform: "windows", HomeDir: "C:\\Users\\username"},
+			expectedFilePath: "C:\\Users\\username\\AppData\\Roaming\\terraform.d\\plugins\\terraform-provider-openapi.yaml",
+		},
+		{
+			name:             "APPDATA is ignored on platforms other than windows",
+			terraformUtils:   TerraformUtils{Platform: "linux", HomeDir: "/home/username", AppDataDir: "/some/dir"},
+			expectedFilePath: "/home/username/.terraform.d/plugins/terraform-provider-openapi.yaml",
+		},
+	}
+	for _, tc := range testCases {
+		filePath, err := tc.terraformUtils.GetTerraformPluginsVendorFilePath("terraform-provider-openapi.yaml")
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedFilePath, filePath, tc.name)
+	}
+
+	_, err := (&TerraformUtils{Platform: "windows"}).GetTerraformPluginsVendorFilePath("terraform-provider-openapi.yaml")
+	assert.EqualError(t, err, "mandatory HomeDir value missing")
+}
+
+func TestNewTerraformUtils(t *testing.T) {
+	os.Setenv("APPDATA", "C:\\Users\\username\\AppData\\Roaming")
+	defer os.Unsetenv("APPDATA")
+	terraformUtils, err := NewTerraformUtils()
+	assert.NoError(t, err)
+	assert.Equal(t, "C:\\Users\\username\\AppData\\Roaming", terraformUtils.AppDataDir)
+	assert.NotEmpty(t, terraformUtils.Platform)
+}
+
 func TestConvertToTerraformCompliantFieldName(t *testing.T) {
 	testCases := []struct {
 		name                 string
@@ -242,3 +293,21 @@ func TestEnvDefaultFunc(t *testing.T) {
 		})
 	})
 }
+
+func TestConvertToTerraformCompliantNameIsCached(t *testing.T) {
+	name := "someCachedPropertyName1"
+	_, cached := compliantNamesCache.Load(name)
+	assert.False(t, cached)
+	assert.Equal(t, "some_cached_property_name1", ConvertToTerraformCompliantName(name))
+	compliantName, cached := compliantNamesCache.Load(name)
+	assert.True(t, cached)
+	assert.Equal(t, "some_cached_property_name1", compliantName)
+	assert.Equal(t, "some_cached_property_name1", ConvertToTerraformCompliantName(name))
+}
+
+func BenchmarkConvertToTerraformCompliantName(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ConvertToTerraformCompliantName("someProperty1NameWith2Numbers")
+	}
+}
@@ -0,0 +1,122 @@
+package openapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// defaultTLSMinVersion defines the min TLS version negotiated with the API if the TLS configuration does not specify one
+const defaultTLSMinVersion = tls.VersionTLS12
+
+// tlsVersions contains the TLS versions that can be configured as the min TLS version
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// fipsApprovedCipherSuites contains the TLS 1.2 cipher suites approved for FIPS 140 which are the only ones allowed when
+// the provider binary is built in FIPS mode
+var fipsApprovedCipherSuites = map[uint16]bool{
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:   true,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384:   true,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256: true,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384: true,
+}
+
+func getTLSVersion(version string) (uint16, error) {
+	if version == "" {
+		return defaultTLSMinVersion, nil
+	}
+	tlsVersion, exists := tlsVersions[version]
+	if !exists {
+		return 0, fmt.Errorf("min_version '%s' not supported, supported versions are [1.2, 1.3]", version)
+	}
+	return tlsVersion, nil
+}
+
+// getTLSCipherSuiteID returns the ID of the cipher suite name passed in. Only the cipher suites Go considers secure are
+// supported, and if the provider binary is built in FIPS mode, the cipher suite must be FIPS-approved too
+func getTLSCipherSuiteID(name string) (uint16, error) {
+	for _, cipherSuite := range tls.CipherSuites() {
+		if cipherSuite.Name != name {
+			continue
+		}
+		if fipsModeEnabled && !fipsApprovedCipherSuites[cipherSuite.ID] {
+			return 0, fmt.Errorf("cipher suite '%s' is not FIPS-approved", name)
+		}
+		return cipherSuite.ID, nil
+	}
+	return 0, fmt.Errorf("cipher suite '%s' not supported", name)
+}
+
+// newTLSClientConfig returns the tls.Config of the client transport based on the TLS configuration. nil is returned if
+// the TLS configuration is nil, in which case the Go defaults apply
+func (t *TLSConfig) newTLSClientConfig() (*tls.Config, error) {
+	if t == nil {
+		return nil, nil
+	}
+	minVersion, err := getTLSVersion(t.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{MinVersion: minVersion}
+	for _, cipherSuite := range t.CipherSuites {
+		cipherSuiteID, err := getTLSCipherSuiteID(cipherSuite)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.CipherSuites = append(tlsConfig.CipherSuites, cipherSuiteID)
+	}
+	return tlsConfig, nil
+}
+
+// newMutualTLSConfig returns a copy of the base tls.Config passed in presenting the client certificate and key, and
+// verifying the API server certificate with the CA certificate, if configured. The certificates and key can be passed
+// in either as file paths or PEM contents
+func newMutualTLSConfig(base *tls.Config, clientCertificate, clientKey, caCertificate string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: defaultTLSMinVersion}
+	if base != nil {
+		tlsConfig = base.Clone()
+	}
+	if (clientCertificate == "") != (clientKey == "") {
+		return nil, fmt.Errorf("both the %s and %s must be configured", providerPropertyClientCertificate, providerPropertyClientKey)
+	}
+	if clientCertificate != "" {
+		certPEM, err := readPEM(clientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("%s not valid: %s", providerPropertyClientCertificate, err)
+		}
+		keyPEM, err := readPEM(clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("%s not valid: %s", providerPropertyClientKey, err)
+		}
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("%s not valid: %s", providerPropertyClientCertificate, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	if caCertificate != "" {
+		caPEM, err := readPEM(caCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("%s not valid: %s", providerPropertyCACertificate, err)
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("%s not valid: no PEM encoded certificates found", providerPropertyCACertificate)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	return tlsConfig, nil
+}
+
+// readPEM returns the PEM contents passed in as is, or the contents of the file otherwise
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return ioutil.ReadFile(value)
+}
@@ -0,0 +1,11 @@
+//go:build boringcrypto
+// +build boringcrypto
+
+package openapi
+
+// Importing fipsonly restricts all the TLS configurations to FIPS-approved settings. The package is only available when
+// the provider binary is built with GOEXPERIMENT=boringcrypto (see the build-fips make target)
+import _ "crypto/tls/fipsonly"
+
+// fipsModeEnabled is true when the provider binary is built in FIPS mode (GOEXPERIMENT=boringcrypto)
+const fipsModeEnabled = true
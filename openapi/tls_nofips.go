@@ -0,0 +1,7 @@
+//go:build !boringcrypto
+// +build !boringcrypto
+
+package openapi
+
+// fipsModeEnabled is true when the provider binary is built in FIPS mode (GOEXPERIMENT=boringcrypto)
+const fipsModeEnabled = false
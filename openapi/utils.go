@@ -4,16 +4,9 @@ import (
 	"encoding/json"
 	"github.com/mitchellh/go-homedir"
 	"io/ioutil"
-	"log"
 	"net/url"
 )
 
-func prettyPrint(v interface{}) {
-	b, _ := json.MarshalIndent(v, "", "  ")
-	log.Printf(string(b))
-	log.Println()
-}
-
 func sPrettyPrint(v interface{}) string {
 	if v == nil {
 		return "nil"
@@ -0,0 +1,144 @@
+package openapiterraformdocsgenerator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownListItemRegex = regexp.MustCompile(`^\s*([-*+]|\d+[.)])\s+(.*)$`)
+	markdownHeadingRegex  = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*$`)
+	markdownLinkRegex     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownBoldRegex     = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	markdownItalicRegex   = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+	markdownHTMLEscaper   = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// markdownBlock defines a block of a markdown text (e,g: paragraph, list or code block) rendered as HTML
+type markdownBlock struct {
+	html string
+	// inline contains the HTML of the paragraph content without the enclosing tags; empty for the rest of blocks
+	inline string
+}
+
+// renderMarkdown renders the markdown text passed in (e,g: descriptions from the OpenAPI document) as HTML. The subset of
+// markdown commonly used in API descriptions is supported: paragraphs, headings (rendered as bold paragraphs so they do
+// not interfere with the document sections), unordered and ordered lists, fenced code blocks, inline code, bold, italic
+// and links. Raw HTML is escaped. If inline is true and the text is a single paragraph, the paragraph content is returned
+// without the enclosing tags so it can be embedded in other elements (e,g: argument descriptions)
+func renderMarkdown(text string, inline bool) string {
+	blocks := parseMarkdownBlocks(text)
+	if inline && len(blocks) == 1 && blocks[0].inline != "" {
+		return blocks[0].inline
+	}
+	var html []string
+	for _, block := range blocks {
+		html = append(html, block.html)
+	}
+	return strings.Join(html, "\n")
+}
+
+func parseMarkdownBlocks(text string) []markdownBlock {
+	var blocks []markdownBlock
+	var paragraph []string
+	var listTag string
+	var listItems []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			content := renderMarkdownInline(strings.Join(paragraph, " "))
+			blocks = append(blocks, markdownBlock{html: fmt.Sprintf("<p>%s</p>", content), inline: content})
+			paragraph = nil
+		}
+		if len(listItems) > 0 {
+			var items []string
+			for _, item := range listItems {
+				items = append(items, fmt.Sprintf("<li>%s</li>", renderMarkdownInline(item)))
+			}
+			blocks = append(blocks, markdownBlock{html: fmt.Sprintf("<%s>%s</%s>", listTag, strings.Join(items, ""), listTag)})
+			listItems = nil
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(strings.TrimSpace(text), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmedLine := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmedLine, "```"):
+			flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, markdownBlock{html: fmt.Sprintf("<pre><code>%s</code></pre>", markdownHTMLEscaper.Replace(strings.Join(code, "\n")))})
+		case trimmedLine == "":
+			flush()
+		case markdownHeadingRegex.MatchString(trimmedLine):
+			flush()
+			heading := markdownHeadingRegex.FindStringSubmatch(trimmedLine)[1]
+			blocks = append(blocks, markdownBlock{html: fmt.Sprintf("<p><strong>%s</strong></p>", renderMarkdownInline(heading))})
+		case markdownListItemRegex.MatchString(line):
+			match := markdownListItemRegex.FindStringSubmatch(line)
+			tag := "ul"
+			if !strings.ContainsAny(match[1], "-*+") {
+				tag = "ol"
+			}
+			if len(paragraph) > 0 || tag != listTag {
+				flush()
+			}
+			listTag = tag
+			listItems = append(listItems, match[2])
+		case len(listItems) > 0:
+			// lazy continuation of the last list item
+			listItems[len(listItems)-1] += " " + trimmedLine
+		default:
+			paragraph = append(paragraph, trimmedLine)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// renderMarkdownInline renders the inline markdown elements (inline code, bold, italic and links) of the text passed in as
+// HTML. The content of the inline code spans is not rendered
+func renderMarkdownInline(text string) string {
+	parts := strings.Split(text, "`")
+	var html strings.Builder
+	for i, part := range parts {
+		escaped := markdownHTMLEscaper.Replace(part)
+		switch {
+		case i%2 == 1 && i < len(parts)-1:
+			html.WriteString("<code>" + escaped + "</code>")
+		case i%2 == 1:
+			// unmatched backtick
+			html.WriteString("`" + renderMarkdownEmphasis(escaped))
+		default:
+			html.WriteString(renderMarkdownEmphasis(escaped))
+		}
+	}
+	return html.String()
+}
+
+func renderMarkdownEmphasis(text string) string {
+	text = markdownLinkRegex.ReplaceAllStringFunc(text, func(link string) string {
+		match := markdownLinkRegex.FindStringSubmatch(link)
+		if !isSafeMarkdownLink(match[2]) {
+			return match[1]
+		}
+		return fmt.Sprintf(`<a href="%s" target="_blank">%s</a>`, match[2], match[1])
+	})
+	text = markdownBoldRegex.ReplaceAllString(text, "<strong>$1$2</strong>")
+	return markdownItalicRegex.ReplaceAllString(text, "<em>$1$2</em>")
+}
+
+// isSafeMarkdownLink returns true if the link passed in is a web, mail or relative link; links with other schemes (e,g:
+// javascript:) are rendered as plain text
+func isSafeMarkdownLink(link string) bool {
+	for _, prefix := range []string{"http://", "https://", "mailto:", "#", "/"} {
+		if strings.HasPrefix(link, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package openapiterraformdocsgenerator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	testCases := []struct {
+		name           string
+		markdown       string
+		inline         bool
+		expectedOutput string
+	}{
+		{
+			name:           "empty text",
+			markdown:       "",
+			expectedOutput: "",
+		},
+		{
+			name:           "plain text",
+			markdown:       "The 'cdn' allows you to manage 'cdn' resources",
+			expectedOutput: "<p>The 'cdn' allows you to manage 'cdn' resources</p>",
+		},
+		{
+			name:           "plain text rendered inline",
+			markdown:       "The 'cdn' allows you to manage 'cdn' resources",
+			inline:         true,
+			expectedOutput: "The 'cdn' allows you to manage 'cdn' resources",
+		},
+		{
+			name:           "inline elements",
+			markdown:       "Use **bold**, __bold__, *italic*, _italic_, `code_with_*stars*` and [links](https://example.com/some_path)",
+			inline:         true,
+			expectedOutput: `Use <strong>bold</strong>, <strong>bold</strong>, <em>italic</em>, <em>italic</em>, <code>code_with_*stars*</code> and <a href="https://example.com/some_path" target="_blank">links</a>`,
+		},
+		{
+			name:           "snake case names are not rendered as italic",
+			markdown:       "The property_name_value and the other_name",
+			inline:         true,
+			expectedOutput: "The property_name_value and the other_name",
+		},
+		{
+			name:           "raw HTML is escaped",
+			markdown:       "<script>alert('x')</script> & `<b>`",
+			inline:         true,
+			expectedOutput: "&lt;script&gt;alert('x')&lt;/script&gt; &amp; <code>&lt;b&gt;</code>",
+		},
+		{
+			name:           "unsafe links are rendered as text",
+			markdown:       "[click](javascript:alert(1))",
+			inline:         true,
+			expectedOutput: "click)",
+		},
+		{
+			name:           "paragraphs, lists and headings",
+			markdown:       "# Sizes\nThe size of the\ncluster:\n\n- `small`: 2 nodes\n- `large`: 4\n  nodes\n\n1. first\n2. second",
+			inline:         true,
+			expectedOutput: "<p><strong>Sizes</strong></p>\n<p>The size of the cluster:</p>\n<ul><li><code>small</code>: 2 nodes</li><li><code>large</code>: 4 nodes</li></ul>\n<ol><li>first</li><li>second</li></ol>",
+		},
+		{
+			name:           "fenced code block",
+			markdown:       "Example:\n```json\n{\"size\": \"<small>\"}\n```",
+			expectedOutput: "<p>Example:</p>\n<pre><code>{\"size\": \"&lt;small&gt;\"}</code></pre>",
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, renderMarkdown(tc.markdown, tc.inline), tc.name)
+	}
+}
@@ -25,6 +25,10 @@ type TerraformProviderDocGenerator struct {
 	// SpecAnalyser analyses the swagger doc and provides helper methods to retrieve all the end points that can
 	// be used as terraform resources.
 	SpecAnalyser openapi.SpecAnalyser
+	// ResourceNamingVersion defines the version of the naming algorithm the documented resource and data source names are
+	// built with, it should match the resource_naming_version of the service configuration. If not populated the current
+	// version of the naming algorithm is used
+	ResourceNamingVersion int
 }
 
 // NewTerraformProviderDocGenerator returns a TerraformProviderDocGenerator populated with the provider documentation which
@@ -116,8 +120,10 @@ func (t TerraformProviderDocGenerator) GenerateDocumentation() (TerraformProvide
 			ConfigProperties: configProperties,
 		},
 		ProviderResources: ProviderResources{
-			ProviderName: t.ProviderName,
-			Resources:    resources,
+			ProviderName:          t.ProviderName,
+			Resources:             resources,
+			ResourceNamingVersion: t.getResourceNamingVersion(),
+			ResourceRenames:       t.getResourceRenames(r),
 		},
 		DataSources: DataSources{
 			ProviderName:        t.ProviderName,
@@ -172,8 +178,9 @@ func (t TerraformProviderDocGenerator) getDataSourceFilters(dataSourcesFilter []
 			props = append(props, prop)
 		}
 		dataSources = append(dataSources, DataSource{
-			Name:       dataSource.GetResourceName(),
-			Properties: orderProps(props),
+			Name:        t.getResourceName(dataSource),
+			Description: dataSourceSchemaDefinition.Description,
+			Properties:  orderProps(props),
 		})
 	}
 	return dataSources, nil
@@ -193,8 +200,9 @@ func (t TerraformProviderDocGenerator) getDataSourceInstances(dataSourceInstance
 			props = append(props, prop)
 		}
 		dataSourcesInstance = append(dataSourcesInstance, DataSource{
-			Name:       fmt.Sprintf("%s_instance", dataSource.GetResourceName()),
-			Properties: orderProps(props),
+			Name:        fmt.Sprintf("%s_instance", t.getResourceName(dataSource)),
+			Description: dataSourceSchemaDefinition.Description,
+			Properties:  orderProps(props),
 		})
 	}
 	return dataSourcesInstance, nil
@@ -202,6 +210,15 @@ func (t TerraformProviderDocGenerator) getDataSourceInstances(dataSourceInstance
 
 func (t TerraformProviderDocGenerator) getProviderResources(resources []openapi.SpecResource) ([]Resource, error) {
 	r := []Resource{}
+	// documentedNames contains the documented names of the resources keyed by their current names, which is how the
+	// sub-resources refer to their parent resources
+	documentedNames := map[string]string{}
+	for _, resource := range resources {
+		if !resource.ShouldIgnoreResource() {
+			documentedNames[resource.GetResourceName()] = t.getResourceName(resource)
+		}
+	}
+	subResources := map[string][]string{}
 	for _, resource := range resources {
 		if resource.ShouldIgnoreResource() {
 			continue
@@ -227,20 +244,31 @@ func (t TerraformProviderDocGenerator) getProviderResources(resources []openapi.
 
 		parentInfo := resource.GetParentResourceInfo()
 		var parentProperties []string
+		var parentResource string
 		if parentInfo != nil {
 			parentProperties = parentInfo.GetParentPropertiesNames()
+			parentResource = documentedNames[parentInfo.GetParentResourceName()]
+		}
+		name := t.getResourceName(resource)
+		if parentResource != "" {
+			subResources[parentResource] = append(subResources[parentResource], name)
 		}
 
 		r = append(r, Resource{
-			Name:             resource.GetResourceName(),
-			Description:      "",
+			Name:             name,
+			Description:      resourceSchema.Description,
 			Properties:       props,
 			ParentProperties: parentProperties,
+			ParentResource:   parentResource,
 			ArgumentsReference: ArgumentsReference{
 				Notes: []string{},
 			},
 		})
 	}
+	for i := range r {
+		r[i].SubResources = subResources[r[i].Name]
+		sort.Strings(r[i].SubResources)
+	}
 	return r, nil
 }
 
@@ -262,8 +290,12 @@ func (t TerraformProviderDocGenerator) resourceSchemaToProperty(specSchemaDefini
 		IsOptionalComputed: specSchemaDefinitionProperty.IsOptionalComputed() || specSchemaDefinitionProperty.IsOptionalComputedWithDefault(),
 		IsSensitive:        specSchemaDefinitionProperty.Sensitive,
 		IsParent:           specSchemaDefinitionProperty.IsParentProperty,
+		MinItems:           specSchemaDefinitionProperty.MinItems,
+		MaxItems:           specSchemaDefinitionProperty.MaxItems,
 		Description:        specSchemaDefinitionProperty.Description,
 		Default:            specSchemaDefinitionProperty.Default,
+		Example:            specSchemaDefinitionProperty.Example,
+		Enum:               specSchemaDefinitionProperty.Enum,
 		Schema:             orderProps(schema),
 	}
 }
@@ -314,3 +346,41 @@ func orderProps(props []Property) []Property {
 	})
 	return props
 }
+
+// getResourceNamingVersion returns the version of the naming algorithm the documented names are built with
+func (t TerraformProviderDocGenerator) getResourceNamingVersion() int {
+	if t.ResourceNamingVersion == 0 {
+		return openapi.CurrentResourceNamingVersion
+	}
+	return t.ResourceNamingVersion
+}
+
+// getResourceName returns the name of the resource built with the naming algorithm version documented
+func (t TerraformProviderDocGenerator) getResourceName(resource openapi.SpecResource) string {
+	return openapi.GetResourceNameForNamingVersion(resource, t.getResourceNamingVersion())
+}
+
+// getResourceRenames returns the resources whose names built with the previous versions of the naming algorithm differ
+// from the documented ones, so users upgrading from provider releases that used a previous version can migrate their state
+func (t TerraformProviderDocGenerator) getResourceRenames(resources []openapi.SpecResource) []ResourceRename {
+	renames := []ResourceRename{}
+	for _, resource := range resources {
+		if resource.ShouldIgnoreResource() {
+			continue
+		}
+		name := t.getResourceName(resource)
+		for namingVersion := 1; namingVersion < t.getResourceNamingVersion(); namingVersion++ {
+			previousName := openapi.GetResourceNameForNamingVersion(resource, namingVersion)
+			if previousName != name {
+				renames = append(renames, ResourceRename{NamingVersion: namingVersion, PreviousName: previousName, Name: name})
+			}
+		}
+	}
+	sort.SliceStable(renames, func(i, j int) bool {
+		if renames[i].Name != renames[j].Name {
+			return renames[i].Name < renames[j].Name
+		}
+		return renames[i].NamingVersion < renames[j].NamingVersion
+	})
+	return renames
+}
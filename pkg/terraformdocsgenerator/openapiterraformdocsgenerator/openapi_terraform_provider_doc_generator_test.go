@@ -809,6 +809,40 @@ func TestGetProviderResources_HasParentProps(t *testing.T) {
 	assert.Equal(t, "parentResourceName_id", actualResources[0].ParentProperties[0])
 }
 
+func TestGetProviderResources_SubResources(t *testing.T) {
+	openapiResources := []openapi.SpecResource{
+		&specStubResource{
+			name:                "cdn_v1_firewall_v1",
+			schemaDefinition:    &openapi.SpecSchemaDefinition{},
+			parentResourceNames: []string{"cdn_v1"},
+		},
+		&specStubResource{
+			name:             "cdn_v1",
+			schemaDefinition: &openapi.SpecSchemaDefinition{Description: "Content delivery network"},
+		},
+		&specStubResource{
+			name:                "cdn_v1_ignored_v1",
+			shouldIgnore:        true,
+			parentResourceNames: []string{"cdn_v1"},
+		},
+		&specStubResource{
+			name:                "orphan_v1_rule_v1",
+			schemaDefinition:    &openapi.SpecSchemaDefinition{},
+			parentResourceNames: []string{"orphan_v1"},
+		},
+	}
+	dg := TerraformProviderDocGenerator{}
+	actualResources, err := dg.getProviderResources(openapiResources)
+	assert.NoError(t, err)
+	assert.Len(t, actualResources, 3)
+	assert.Equal(t, "cdn_v1", actualResources[0].ParentResource)
+	assert.Nil(t, actualResources[0].SubResources)
+	assert.Equal(t, "Content delivery network", actualResources[1].Description)
+	assert.Empty(t, actualResources[1].ParentResource)
+	assert.Equal(t, []string{"cdn_v1_firewall_v1"}, actualResources[1].SubResources)
+	assert.Empty(t, actualResources[2].ParentResource, "parent resources not documented should not be linked")
+}
+
 func TestGetProviderResources_IgnoreResource(t *testing.T) {
 	openapiResources := []openapi.SpecResource{
 		&specStubResource{
@@ -836,6 +870,71 @@ func TestGetProviderResources_Error(t *testing.T) {
 	assert.EqualError(t, err, "specStubResource error")
 }
 
+func TestGetResourceRenames(t *testing.T) {
+	swaggerServer := newSwaggerServer(t, `swagger: "2.0"
+paths:
+  /v1/cdn-edges:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/CDN"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/CDN"
+  /v1/cdn-edges/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/CDN"
+definitions:
+  CDN:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true`)
+	defer swaggerServer.Close()
+	analyser, err := openapi.CreateSpecAnalyser("v2", swaggerServer.URL)
+	assert.NoError(t, err)
+	resources, err := analyser.GetTerraformCompliantResources()
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name                  string
+		resourceNamingVersion int
+		expectedResourceName  string
+		expectedRenames       []ResourceRename
+	}{
+		{
+			name:                  "current naming version documents the renames from previous versions",
+			resourceNamingVersion: 0,
+			expectedResourceName:  "cdn_edges",
+			expectedRenames:       []ResourceRename{{NamingVersion: 1, PreviousName: "cdn-edges_v1", Name: "cdn_edges"}},
+		},
+		{
+			name:                  "first naming version has no renames",
+			resourceNamingVersion: 1,
+			expectedResourceName:  "cdn-edges_v1",
+			expectedRenames:       []ResourceRename{},
+		},
+	}
+	for _, tc := range testCases {
+		dg := TerraformProviderDocGenerator{ResourceNamingVersion: tc.resourceNamingVersion}
+		providerResources, err := dg.getProviderResources(resources)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedResourceName, providerResources[0].Name, tc.name)
+		assert.Equal(t, tc.expectedRenames, dg.getResourceRenames(resources), tc.name)
+	}
+}
+
 func TestGetRequiredProviderConfigurationProperties(t *testing.T) {
 	testCases := []struct {
 		name                  string
@@ -15,3 +15,8 @@ type DataSource struct {
 	OtherExample string
 	Properties   []Property
 }
+
+// DescriptionHTML returns the description of the data source (markdown) rendered as HTML
+func (d DataSource) DescriptionHTML() string {
+	return renderMarkdown(d.Description, false)
+}
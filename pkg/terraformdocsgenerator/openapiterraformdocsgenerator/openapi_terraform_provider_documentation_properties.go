@@ -1,5 +1,11 @@
 package openapiterraformdocsgenerator
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // Property defines the attributes for describing a given property for a resource
 type Property struct {
 	Name               string
@@ -13,6 +19,14 @@ type Property struct {
 	Description        string
 	Default            interface{}
 	Schema             []Property // This is used to describe the schema for array of objects or object properties
+	// MinItems and MaxItems are only populated for array properties with minItems/maxItems. They are not part of the hash
+	// used to order the properties (refer to orderProps) so the order of the properties does not change when they are set
+	MinItems int `hash:"ignore"`
+	MaxItems int `hash:"ignore"`
+	// Example and Enum are only populated for properties with an example value or a list of allowed values. Like MinItems
+	// and MaxItems, they are not part of the hash used to order the properties
+	Example interface{}   `hash:"ignore"`
+	Enum    []interface{} `hash:"ignore"`
 }
 
 // ContainsComputedSubProperties checks if a schema contains properties that are computed recursively
@@ -29,3 +43,65 @@ func (p Property) ContainsComputedSubProperties() bool {
 func (p Property) DefaultNotNil() bool {
 	return p.Default != nil
 }
+
+// ExampleNotNil checks whether the Example value is nil. If the value is populated it returns true, false otherwise
+func (p Property) ExampleNotNil() bool {
+	return p.Example != nil
+}
+
+// ExampleValue returns the value used for the property in the example usage, formatted as a terraform expression. The
+// example value of the property is used if available, otherwise the first allowed value. An empty string is returned if
+// the property has neither
+func (p Property) ExampleValue() string {
+	if p.Example != nil {
+		return formatTerraformValue(p.Example)
+	}
+	if len(p.Enum) > 0 {
+		if p.Type == "list" {
+			return formatTerraformValue([]interface{}{p.Enum[0]})
+		}
+		return formatTerraformValue(p.Enum[0])
+	}
+	return ""
+}
+
+// EnumValues returns the values allowed for the property formatted as a comma separated list of terraform expressions
+func (p Property) EnumValues() string {
+	var values []string
+	for _, value := range p.Enum {
+		values = append(values, fmt.Sprintf("<code>%s</code>", formatTerraformValue(value)))
+	}
+	return strings.Join(values, ", ")
+}
+
+// DescriptionHTML returns the description of the property (markdown) rendered as HTML
+func (p Property) DescriptionHTML() string {
+	return renderMarkdown(p.Description, true)
+}
+
+// formatTerraformValue returns the value passed in (e,g: an example value from the OpenAPI document) formatted as a
+// terraform expression
+func formatTerraformValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return markdownHTMLEscaper.Replace(fmt.Sprintf("%q", v))
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			items = append(items, formatTerraformValue(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		var keys []string
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var items []string
+		for _, key := range keys {
+			items = append(items, fmt.Sprintf("%s = %s", key, formatTerraformValue(v[key])))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return fmt.Sprintf("%v", value)
+}
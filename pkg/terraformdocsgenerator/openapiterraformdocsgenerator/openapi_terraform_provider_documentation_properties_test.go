@@ -76,3 +76,27 @@ func TestProperty_DefaultNotNil(t *testing.T) {
 		assert.Equal(t, tc.expectedResult, result)
 	}
 }
+
+func TestProperty_ExampleValue(t *testing.T) {
+	testCases := []struct {
+		name           string
+		property       Property
+		expectedResult string
+	}{
+		{name: "property without example nor enum", property: Property{Type: "string"}, expectedResult: ""},
+		{name: "string example", property: Property{Type: "string", Example: `some "label"`}, expectedResult: `"some \"label\""`},
+		{name: "number example", property: Property{Type: "number", Example: 12.5}, expectedResult: "12.5"},
+		{name: "list example", property: Property{Type: "list", ArrayItemsType: "string", Example: []interface{}{"a", "b"}}, expectedResult: `["a", "b"]`},
+		{name: "object example", property: Property{Type: "object", Example: map[string]interface{}{"b": true, "a": 1}}, expectedResult: "{a = 1, b = true}"},
+		{name: "enum property", property: Property{Type: "string", Enum: []interface{}{"small", "large"}}, expectedResult: `"small"`},
+		{name: "list enum property", property: Property{Type: "list", ArrayItemsType: "integer", Enum: []interface{}{80, 443}}, expectedResult: "[80]"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedResult, tc.property.ExampleValue(), tc.name)
+	}
+}
+
+func TestProperty_EnumValues(t *testing.T) {
+	assert.Equal(t, "", Property{}.EnumValues())
+	assert.Equal(t, `<code>"small"</code>, <code>"large"</code>`, Property{Enum: []interface{}{"small", "large"}}.EnumValues())
+}
@@ -5,6 +5,19 @@ type ProviderResources struct {
 	// ProviderName is the name of the provider
 	ProviderName string
 	Resources    []Resource
+	// ResourceNamingVersion is the version of the naming algorithm the resource names are built with
+	ResourceNamingVersion int
+	// ResourceRenames contains the resources whose names differ in provider releases using previous versions of the
+	// naming algorithm
+	ResourceRenames []ResourceRename
+}
+
+// ResourceRename defines the name a resource had when built with a previous version of the naming algorithm
+type ResourceRename struct {
+	// NamingVersion is the version of the naming algorithm the previous name was built with
+	NamingVersion int
+	PreviousName  string
+	Name          string
 }
 
 // ContainsResourcesWithSecretProperties checks if the provider exposes resources containing secret properties
@@ -21,10 +34,15 @@ func (r ProviderResources) ContainsResourcesWithSecretProperties() bool {
 
 // Resource defines the attributes to generate documentation for a Terraform provider resource
 type Resource struct {
-	Name               string
-	Description        string
-	Properties         []Property
-	ParentProperties   []string
+	Name             string
+	Description      string
+	Properties       []Property
+	ParentProperties []string
+	// ParentResource is the name of the closest parent resource if the resource is a sub-resource and the parent resource
+	// is documented too
+	ParentResource string
+	// SubResources contains the names of the sub-resources whose closest parent is the resource
+	SubResources       []string
 	ExampleUsage       []ExampleUsage
 	ArgumentsReference ArgumentsReference
 	KnownIssues        []KnownIssue
@@ -46,6 +64,11 @@ func (r Resource) BuildImportIDsExample() string {
 	return idExamples
 }
 
+// DescriptionHTML returns the description of the resource (markdown) rendered as HTML
+func (r Resource) DescriptionHTML() string {
+	return renderMarkdown(r.Description, false)
+}
+
 // ExampleUsage defines a block of code/commands to include in the docs
 type ExampleUsage struct {
 	Title   string
@@ -154,7 +154,9 @@ var ProviderConfigurationTmpl = `{{if or .Regions .ConfigProperties}}
 // ProviderResourcesTmpl contains the template used to render the TerraformProviderDocumentation.ProviderResources struct as HTML formatted for Zendesk
 var ProviderResourcesTmpl = fmt.Sprintf(`{{define "resource_example"}}
 {{- if .Required}}
-    {{if eq .Type "string" -}}
+    {{if and .ExampleValue (ne .Type "object") (ne .ArrayItemsType "object") -}}
+        <span>{{.Name}}  </span>= <span>{{.ExampleValue}}</span>
+    {{- else if eq .Type "string" -}}
         <span>{{.Name}}  </span>= <span>"{{.Name}}"</span>
     {{- else if eq .Type "integer" -}}
         <span>{{.Name}}  </span>= <span>1234</span>
@@ -194,7 +196,13 @@ var ProviderResourcesTmpl = fmt.Sprintf(`{{define "resource_example"}}
 	{{ $resource := . }}
 <h3 id="{{.Name}}" dir="ltr">{{$.ProviderName}}_{{.Name}}</h3>
 {{if ne .Description "" -}}
-<p>{{.Description}}</p>
+{{.DescriptionHTML}}
+{{- end}}
+{{- if .ParentResource}}
+<p>This resource is a sub-resource of <a href="#{{.ParentResource}}" target="_self">{{$.ProviderName}}_{{.ParentResource}}</a>.</p>
+{{- end}}
+{{- if .SubResources}}
+<p>Sub-resources: {{range $i, $subResource := .SubResources}}{{if $i}}, {{end}}<a href="#{{$subResource}}" target="_self">{{$.ProviderName}}_{{$subResource}}</a>{{end}}</p>
 {{- end}}
 {{- if .KnownIssues}}
 <p>If you experience any issues using this resource, please check the <a href="#resource_{{.Name}}_known_issues" target="_self">Known Issues</a> section to see if there is a fix/workaround.</p>
@@ -281,7 +289,21 @@ var ProviderResourcesTmpl = fmt.Sprintf(`{{define "resource_example"}}
 			{{- end}}
 		{{end}}
 	{{- end}}
-{{end}} {{/* END range .Resources */}}`, ArgumentReferenceTmpl, AttributeReferenceTmpl)
+{{end}} {{/* END range .Resources */}}
+{{- if .ResourceRenames}}
+<h3 id="provider_resources_renamed" dir="ltr">Renamed Resources</h3>
+<p dir="ltr">The resource names are built with the version {{.ResourceNamingVersion}} of the naming algorithm. The following resources have a different name in provider releases that used a previous version of the naming algorithm:</p>
+<ul dir="ltr">
+{{- range .ResourceRenames}}
+    <li><code>{{$.ProviderName}}_{{.PreviousName}}</code> (naming version {{.NamingVersion}}) is now <code>{{$.ProviderName}}_{{.Name}}</code></li>
+{{- end}}
+</ul>
+<p dir="ltr">Terraform can not move resources between different resource types, so the existing resources need to be removed from the state and imported again using the new name, e.g:</p>
+{{- range .ResourceRenames}}
+<pre dir="ltr">$ terraform state rm {{$.ProviderName}}_{{.PreviousName}}.my_{{.Name}}
+$ terraform import {{$.ProviderName}}_{{.Name}}.my_{{.Name}} id</pre>
+{{- end}}
+{{- end}}`, ArgumentReferenceTmpl, AttributeReferenceTmpl)
 
 // ArgumentReferenceTmpl contains the definition used in resources to render the arguments
 var ArgumentReferenceTmpl = `{{- define "resource_argument_reference" -}}
@@ -290,7 +312,7 @@ var ArgumentReferenceTmpl = `{{- define "resource_argument_reference" -}}
         {{- $required = "Required" -}}
     {{end}}
 	{{- if or .Required (and (not .Required) (not .Computed)) .IsOptionalComputed -}}
-    <li>{{if eq .Type "object"}}<span class="wysiwyg-color-red">*</span>{{end}} {{.Name}} [{{.Type}} {{- if eq .Type "list" }} of {{.ArrayItemsType}}s{{- end -}}] {{- if .IsSensitive -}}(<a href="#special_terms_definitions_sensitive_property" target="_self">sensitive</a>){{- end}} - ({{$required}}) {{if .IsParent}}The {{.Name}} that this resource belongs to{{else}}{{.Description}}{{- if .DefaultNotNil -}}. Default value is: {{.Default}}{{- end -}}{{end}}
+    <li>{{if eq .Type "object"}}<span class="wysiwyg-color-red">*</span>{{end}} {{.Name}} [{{.Type}} {{- if eq .Type "list" }} of {{.ArrayItemsType}}s{{- end -}}] {{- if .IsSensitive -}}(<a href="#special_terms_definitions_sensitive_property" target="_self">sensitive</a>){{- end}} - ({{$required}}) {{if .IsParent}}The {{.Name}} that this resource belongs to{{else}}{{.DescriptionHTML}}{{- if .Enum -}}. Allowed values: {{.EnumValues}}{{- end -}}{{- if .DefaultNotNil -}}. Default value is: {{.Default}}{{- end -}}{{- if .ExampleNotNil -}}. Example: <code>{{.ExampleValue}}</code>{{- end -}}{{- if .MinItems -}}. Minimum number of items: {{.MinItems}}{{- end -}}{{- if .MaxItems -}}. Maximum number of items: {{.MaxItems}}{{- end -}}{{end}}
         {{- if or (eq .Type "object") (eq .ArrayItemsType "object")}}. The following properties compose the object schema
         :<ul dir="ltr">
             {{- range .Schema}}
@@ -307,7 +329,7 @@ var AttributeReferenceTmpl = `{{- define "resource_attribute_reference" -}}
     {{- if or .Computed .ContainsComputedSubProperties -}}
 		{{- if and .Schema (not .ContainsComputedSubProperties) -}}{{- /* objects or arrays of objects that DO NOT have computed props are ignored since they will be documented in the arguments section */ -}}
 		{{- else -}}
-        <li>{{if eq .Type "object"}}<span class="wysiwyg-color-red">*</span>{{end}} {{.Name}} [{{.Type}} {{- if eq .Type "list" }} of {{.ArrayItemsType}}s{{- end -}}] {{ if .IsSensitive }}(<a href="#special_terms_definitions_sensitive_property" target="_self">sensitive</a>) {{end -}}{{- if .Description }}- {{.Description}} {{- end -}}
+        <li>{{if eq .Type "object"}}<span class="wysiwyg-color-red">*</span>{{end}} {{.Name}} [{{.Type}} {{- if eq .Type "list" }} of {{.ArrayItemsType}}s{{- end -}}] {{ if .IsSensitive }}(<a href="#special_terms_definitions_sensitive_property" target="_self">sensitive</a>) {{end -}}{{- if .Description }}- {{.DescriptionHTML}} {{- end -}}{{- if .Enum }}. Possible values: {{.EnumValues}}{{- end -}}
             {{- if or (eq .Type "object") (eq .ArrayItemsType "object")}} The following properties compose the object schema:
             <ul dir="ltr">
                 {{- range .Schema}}
@@ -331,7 +353,7 @@ No data sources using resource id are supported at the moment.
     {{ $datasource := . }}
     <h3 id="{{.Name}}" dir="ltr">{{$.ProviderName}}_{{.Name}}</h3>
 	{{if ne .Description "" -}}
-	<p>{{.Description}}</p>
+	{{.DescriptionHTML}}
 	{{else}}
 	<p>Retrieve an existing resource using it's ID</p>
 	{{- end}}
@@ -372,7 +394,7 @@ No data sources using filters are supported at the moment.
     {{ $datasource := . }}
 	<h3 id="{{.Name}}_datasource" dir="ltr">{{$.ProviderName}}_{{.Name}} (filters)</h3>
 	{{if ne .Description "" -}}
-	<p>{{.Description}}</p>
+	{{.DescriptionHTML}}
 	{{else}}
 	<p>The {{.Name}} data source allows you to retrieve an already existing {{.Name}} resource using filters. Refer to the arguments section to learn more about how to configure the filters.</p>
 	{{- end}}
@@ -56,6 +56,16 @@ func TestArgumentReferenceTmpl(t *testing.T) {
 			property:       createArrayProperty("list_float_prop", "list", "number", "list_float_prop property description", true, false),
 			expectedOutput: "<li> list_float_prop [list of numbers] - (Required) list_float_prop property description</li>\n\t",
 		},
+		{
+			name:           "required list string property with min and max items",
+			property:       Property{Name: "list_string_prop", Type: "list", ArrayItemsType: "string", Description: "list_string_prop property description", Required: true, MinItems: 1, MaxItems: 3},
+			expectedOutput: "<li> list_string_prop [list of strings] - (Required) list_string_prop property description. Minimum number of items: 1. Maximum number of items: 3</li>\n\t",
+		},
+		{
+			name:           "optional list string property with max items",
+			property:       Property{Name: "list_string_prop", Type: "list", ArrayItemsType: "string", Description: "list_string_prop property description", MaxItems: 3},
+			expectedOutput: "<li> list_string_prop [list of strings] - (Optional) list_string_prop property description. Maximum number of items: 3</li>\n\t",
+		},
 		{
 			name:           "required object property",
 			property:       Property{Name: "object_prop", Type: "object", Description: "this is an object property", Required: true, Schema: []Property{{Name: "objectPropertyRequired", Type: "string", Required: true}, {Name: "objectPropertyComputed", Type: "string", Computed: true}}},
@@ -476,6 +486,38 @@ resource "openapi_cdn" "my_cdn" {
 	assert.Equal(t, expectedHTML, strings.Trim(buf.String(), "\n"))
 }
 
+func TestProviderResourcesTmpl_MarkdownExamplesAndSubResources(t *testing.T) {
+	r := ProviderResources{
+		ProviderName: "openapi",
+		Resources: []Resource{
+			{
+				Name:         "cdn",
+				Description:  "Manages a **CDN**.\n\n- fast\n- cheap",
+				SubResources: []string{"cdn_firewall", "cdn_rule"},
+				Properties: []Property{
+					{Name: "size", Type: "string", Description: "The `size` of the CDN", Required: true, Enum: []interface{}{"small", "large"}},
+					{Name: "label", Type: "string", Description: "The label", Required: true, Example: "my-cdn"},
+				},
+			},
+			{
+				Name:           "cdn_firewall",
+				ParentResource: "cdn",
+			},
+		},
+	}
+	var renderedTemplate bytes.Buffer
+	err := render(&renderedTemplate, "ProviderResources", ProviderResourcesTmpl, r)
+	assert.NoError(t, err)
+	output := renderedTemplate.String()
+	assert.Contains(t, output, "<p>Manages a <strong>CDN</strong>.</p>\n<ul><li>fast</li><li>cheap</li></ul>")
+	assert.Contains(t, output, `<p>Sub-resources: <a href="#cdn_firewall" target="_self">openapi_cdn_firewall</a>, <a href="#cdn_rule" target="_self">openapi_cdn_rule</a></p>`)
+	assert.Contains(t, output, `<p>This resource is a sub-resource of <a href="#cdn" target="_self">openapi_cdn</a>.</p>`)
+	assert.Contains(t, output, `<span>size  </span>= <span>"small"</span>`)
+	assert.Contains(t, output, `<span>label  </span>= <span>"my-cdn"</span>`)
+	assert.Contains(t, output, `<li> size [string] - (Required) The <code>size</code> of the CDN. Allowed values: <code>"small"</code>, <code>"large"</code></li>`)
+	assert.Contains(t, output, `<li> label [string] - (Required) The label. Example: <code>"my-cdn"</code></li>`)
+}
+
 func TestProviderResourcesTmpl_NoResources(t *testing.T) {
 	r := ProviderResources{
 		ProviderName: "openapi",
@@ -490,6 +532,21 @@ func TestProviderResourcesTmpl_NoResources(t *testing.T) {
 	assert.Equal(t, expectedHTML, strings.Trim(buf.String(), "\n"))
 }
 
+func TestProviderResourcesTmpl_ResourceRenames(t *testing.T) {
+	r := ProviderResources{
+		ProviderName:          "openapi",
+		Resources:             []Resource{},
+		ResourceNamingVersion: 2,
+		ResourceRenames:       []ResourceRename{{NamingVersion: 1, PreviousName: "cdn-edges_v1", Name: "cdn_edges"}},
+	}
+	var buf bytes.Buffer
+	renderTest(t, &buf, "ProviderResources", ProviderResourcesTmpl, r, "TestProviderResourcesTmpl")
+	assert.Contains(t, buf.String(), `<h3 id="provider_resources_renamed" dir="ltr">Renamed Resources</h3>`)
+	assert.Contains(t, buf.String(), `<li><code>openapi_cdn-edges_v1</code> (naming version 1) is now <code>openapi_cdn_edges</code></li>`)
+	assert.Contains(t, buf.String(), `$ terraform state rm openapi_cdn-edges_v1.my_cdn_edges
+$ terraform import openapi_cdn_edges.my_cdn_edges id`)
+}
+
 func TestDataSourcesTmpl(t *testing.T) {
 	dataSource := DataSources{
 		ProviderName: "openapi",
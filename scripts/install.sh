@@ -12,6 +12,7 @@
 #  $ ./install --provider-name [name] --provider-source-address [source-address]
 # * --provider-name: provider's name which will be used to name the plugin binary installed, e,g: terraform-provider-<provider-name>
 # * --provider-source-address: provider source address in the form of <HOSTNAME>/<NAMESPACE>. Default value is 'terraform.example.com/examplecorp'
+# * --dev-overrides: prints the .terraformrc dev_overrides entry pointing at the installed provider (Terraform >= v0.14)
 # * --debug: sets the logging level to debug mode. Default logging level is error.
 # Example:
 # $ ./install.sh --provider-name myprovider --provider-source-address "terraform.example.com/examplecorp"
@@ -24,6 +25,7 @@ _BASE="$(basename ${_FILE})"
 INSTALLATION_DIR="${HOME}/.terraform.d/plugins"
 TF_PROVIDER_BASE_NAME="terraform-provider-"
 PROVIDER_SOURCE_ADDRESS="terraform.example.com/examplecorp"
+TF_CLI_CONFIG_FILE=${TF_CLI_CONFIG_FILE:-"${HOME}/.terraformrc"}
 
 colrst='\033[0m'    # Text Reset
 
@@ -72,23 +74,79 @@ function usage(){
     esilent "-h, --help                                            show help"
     esilent "-p, --provider-name=PROVIDER_NAME                     [required] specify the provider name. The plugin installed will be named like terraform-provider-NAME (all lower case)"
     esilent "-s, --provider-source-address=PROVIDER_SOURCE_ADDR    specify the provider source address <HOSTNAME>/<NAMESPACE>. Default value ${PROVIDER_SOURCE_ADDRESS}"
+    esilent "-o, --dev-overrides                                   prints the ${TF_CLI_CONFIG_FILE} dev_overrides entry pointing at the installed provider (Terraform >= v0.14)"
     esilent "-d, --debug                                           sets the logging level to debug mode. Default logging level is error."
 }
 
 function determineOSAndArch(){
   # used to determine which os and architecture to install
   XC_OS=$(uname)
-  XC_ARCH=${XC_ARCH:-"amd64"}
 
-  # determine which binary to fetch depending upon current architecture
+  # determine which binary to fetch depending upon current os
   if [ "${XC_OS}" == "Linux" ]; then
     XC_OS="linux"
   elif [ "${XC_OS}" == "Darwin" ]; then
     XC_OS="darwin"
   else
-    eerror "Unsupported architecture: ${XC_OS}, only architecture supported at the moment are Darwin and Linux"
+    eerror "Unsupported operating system: ${XC_OS}, only operating systems supported at the moment are Darwin and Linux"
     exit 2
   fi
+
+  # determine which binary to fetch depending upon current architecture, unless the architecture is provided via XC_ARCH
+  if [ -z "${XC_ARCH}" ]; then
+    case "$(uname -m)" in
+      x86_64 | amd64)
+        XC_ARCH="amd64"
+        ;;
+      arm64 | aarch64)
+        XC_ARCH="arm64"
+        ;;
+      *)
+        eerror "Unsupported architecture: $(uname -m), only architectures supported at the moment are amd64 and arm64"
+        exit 2
+    esac
+  fi
+  edebug "Detected platform ${XC_OS}_${XC_ARCH}"
+}
+
+# verifyProviderName makes sure the plugin binary is not installed under a different provider name than the one embedded
+# in the binary (self-contained binaries generated with the build command). Binaries that do not support the
+# -embedded-provider-name flag (e,g: older releases) or can not be executed on this platform are not verified
+function verifyProviderName(){
+  local PLUGIN_PATH=$1
+  if ! EMBEDDED_PROVIDER_NAME=$("${PLUGIN_PATH}" -embedded-provider-name 2>/dev/null); then
+    edebug "Skipping provider name verification, '${PLUGIN_PATH}' does not support the -embedded-provider-name flag"
+    return
+  fi
+  if [ "${EMBEDDED_PROVIDER_NAME}" != "" ] && [ "${EMBEDDED_PROVIDER_NAME}" != "${PROVIDER_NAME}" ]; then
+    eerror "The plugin binary '${PLUGIN_PATH}' was built for provider '${EMBEDDED_PROVIDER_NAME}' and can not be installed as provider '${PROVIDER_NAME}', please use --provider-name ${EMBEDDED_PROVIDER_NAME}"
+    cleanup
+    exit 1
+  fi
+}
+
+# getPluginCacheDir prints the Terraform plugin cache directory configured via TF_PLUGIN_CACHE_DIR environment variable or
+# the plugin_cache_dir setting of the Terraform CLI configuration file, if any
+function getPluginCacheDir(){
+  if [ "${TF_PLUGIN_CACHE_DIR}" != "" ]; then
+    echo "${TF_PLUGIN_CACHE_DIR}"
+  elif [ -f "${TF_CLI_CONFIG_FILE}" ]; then
+    sed -n 's/^[[:space:]]*plugin_cache_dir[[:space:]]*=[[:space:]]*"\(.*\)"[[:space:]]*$/\1/p' "${TF_CLI_CONFIG_FILE}" | sed "s|\$HOME|${HOME}|" | tail -1
+  fi
+}
+
+# clearPluginCache removes the copy of the provider version being installed from the Terraform plugin cache, otherwise
+# Terraform would keep using the previously cached binary instead of the one installed
+function clearPluginCache(){
+  local PLUGIN_CACHE_DIR=$(getPluginCacheDir)
+  if [ "${PLUGIN_CACHE_DIR}" == "" ]; then
+    return
+  fi
+  local CACHED_PROVIDER_DIR="${PLUGIN_CACHE_DIR}/${PROVIDER_SOURCE_ADDRESS}/${PROVIDER_NAME}/${PLUGIN_VERSION}/${XC_OS}_${XC_ARCH}"
+  if [ -d "${CACHED_PROVIDER_DIR}" ]; then
+    einfo "Removing the previously cached provider from the Terraform plugin cache: ${CACHED_PROVIDER_DIR}"
+    rm -rf "${CACHED_PROVIDER_DIR}"
+  fi
 }
 
 function setupInstallationPath(){
@@ -175,7 +233,7 @@ function downloadAndExtract() {
 }
 
 function printRequiredProvidersExample() {
-	if [ $TF_MINOR_VERSION -ge 13 ]
+  if [ $TF_MAJOR_VERSION -gt 0 ] || [ $TF_MINOR_VERSION -ge 13 ]
   then
     esilent " |--> \033[1;31mImportant Note:${colrst} As of Terraform >=0.13 each Terraform module must declare which providers it requires, so that Terraform can install and use them. You can copy into your .tf file the following snippet was autogenerated for your convenience based on the input provided:"
     esilent
@@ -190,6 +248,22 @@ function printRequiredProvidersExample() {
   fi
 }
 
+function printDevOverridesExample() {
+  if [ $TF_MAJOR_VERSION -eq 0 ] && [ $TF_MINOR_VERSION -lt 14 ]
+  then
+    eerror "dev_overrides are only supported by Terraform >= v0.14, skipping the ${TF_CLI_CONFIG_FILE} example"
+    return
+  fi
+  esilent " |--> To use the installed provider without running 'terraform init' (and bypassing the dependency lock file), add the following dev_overrides entry into ${TF_CLI_CONFIG_FILE}:"
+  esilent
+  echo -e "\033[0;33mprovider_installation {"
+  echo -e "\033[0;33m  dev_overrides {"
+  echo -e "\033[0;33m    \"${PROVIDER_SOURCE_ADDRESS}/${PROVIDER_NAME}\" = \"${INSTALLATION_DIR}\""
+  echo -e "\033[0;33m  }"
+  echo -e "\033[0;33m  direct {}"
+  echo -e "\033[0;33m}${colrst}"
+}
+
 # process input arguments
 while [ $# -gt 0 ]; do
     case $1 in
@@ -205,6 +279,9 @@ while [ $# -gt 0 ]; do
             shift
             PROVIDER_SOURCE_ADDRESS=$1
             ;;
+        --dev-overrides | -o)
+            DEV_OVERRIDES="true"
+            ;;
         --compiled-plugin-path | -c) # Only used for building purposes, not recommended for external use
             shift
             TF_SOURCE_PLUGIN_PATH=$1
@@ -242,6 +319,7 @@ else # This branch is only to be exercised when using a pre-compiled provider us
   einfo "Using the pre-compiled OpenAPI Terraform Plugin specified: ${TF_SOURCE_PLUGIN_PATH} v${PLUGIN_VERSION}"
 fi
 
+verifyProviderName "${TF_SOURCE_PLUGIN_PATH}"
 setupInstallationPath $PROVIDER_NAME $PLUGIN_VERSION $XC_OS $XC_ARCH
 TF_DESTINATION_PLUGIN_INSTALLATION_PATH="${INSTALLATION_DIR}/${TF_PROVIDER_PLUGIN_NAME}"
 
@@ -258,7 +336,15 @@ else
     exit 1
 fi
 
+if [ $TF_MAJOR_VERSION -gt 0 ] || [ $TF_MINOR_VERSION -ge 13 ]
+then
+  clearPluginCache
+fi
+
 cleanup
 echo -e "\033[1;32mTerraform provider successfully installed!${colrst}"
 esilent " |--> Installation Path: ${TF_DESTINATION_PLUGIN_INSTALLATION_PATH}"
-printRequiredProvidersExample
\ No newline at end of file
+printRequiredProvidersExample
+if [ "$DEV_OVERRIDES" == "true" ]; then
+  printDevOverridesExample
+fi
\ No newline at end of file
@@ -0,0 +1,248 @@
+#!/usr/bin/env bash
+
+# Packaging script to build the OpenAPI Terraform provider rebranded with the given provider name and generate the release
+# artifacts expected by the Terraform Registry Provider Protocol, so the provider can be published to private registries:
+# https://www.terraform.io/internals/provider-registry-protocol
+#
+# The following artifacts are generated in the output directory for each of the platforms:
+# - terraform-provider-<provider-name>_<version>_<os>_<arch>.zip: zip file containing the provider binary named terraform-provider-<provider-name>_v<version>
+# - terraform-provider-<provider-name>_<version>_manifest.json: registry manifest containing the plugin protocol versions supported
+# - terraform-provider-<provider-name>_<version>_SHA256SUMS: SHA256 checksums of the zip files and the registry manifest
+# - terraform-provider-<provider-name>_<version>_SHA256SUMS.sig: detached GPG signature of the SHA256SUMS file (only if a GPG key is provided)
+#
+# Usage:
+#  $ ./package_provider.sh --provider-name [name] --provider-version [version] --gpg-key [key]
+# * --provider-name: provider's name which will be used to name the plugin binary, e,g: terraform-provider-<provider-name>
+# * --provider-version: provider's version. Default value is the OpenAPI Terraform provider version
+# * --platforms: space separated list of platforms in the form of <OS>_<ARCH>. Default value is 'darwin_amd64 darwin_arm64 linux_amd64 linux_arm64 windows_amd64'
+# * --output-dir: directory where the artifacts are generated. Default value is './dist/<provider-name>'
+# * --gpg-key: GPG key id or fingerprint used to sign the SHA256SUMS file. If not provided, the SHA256SUMS file is not signed
+# * --debug: sets the logging level to debug mode. Default logging level is error.
+# Example:
+# $ ./package_provider.sh --provider-name myprovider --provider-version 1.0.0 --gpg-key 5A1C4D1B9FF5E3A2
+
+# variables storing package script location and name
+_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+_FILE="${_DIR}/$(basename "${BASH_SOURCE[0]}")"
+_BASE="$(basename ${_FILE})"
+
+REPO="github.com/dikhan/terraform-provider-openapi/v3"
+TF_PROVIDER_BASE_NAME="terraform-provider-"
+PLATFORMS="darwin_amd64 darwin_arm64 linux_amd64 linux_arm64 windows_amd64"
+REGISTRY_MANIFEST="${_DIR}/../terraform-registry-manifest.json"
+
+colrst='\033[0m'    # Text Reset
+
+verbosity=1
+### verbosity levels
+silent_lvl=0
+err_lvl=1
+inf_lvl=2
+dbg_lvl=3
+
+## esilent prints output even in silent mode
+function esilent () { verb_lvl=$silent_lvl elog "$@" ;}
+function einfo ()  { verb_lvl=$inf_lvl elog "\033[0;37m[INFO]${colrst} $@" ;} # White
+function edebug () { verb_lvl=$dbg_lvl elog "\033[0;36m[DEBUG]${colrst} $@" ;} # Cyan
+function eerror () { verb_lvl=$err_lvl elog "\033[0;31m[ERROR]${colrst} $@" ;} # Red
+function elog() {
+  if [ $verbosity -ge $verb_lvl ]; then
+    if [ $silent_lvl -eq $verb_lvl ]; then
+      echo -e "$@"
+    else
+      datestring=`date +"%Y-%m-%d %H:%M:%S"`
+      echo -e "$datestring $@"
+    fi
+  fi
+}
+
+# cleanup removes temporarily assets created during the packaging process
+function cleanup() {
+  if [ $TMP_BUILD_DIR ]
+  then
+    edebug "Cleaning up tmp dir created for building purposes: ${TMP_BUILD_DIR}"
+    rm -rf ${TMP_BUILD_DIR}
+  fi
+}
+
+# usage prints script usage
+function usage(){
+    esilent ""
+    esilent "Usage: ${_BASE} [options]"
+    esilent " "
+    esilent "Builds the OpenAPI Terraform provider rebranded with the provider name provided and generates the release artifacts (zip files,"
+    esilent "registry manifest, SHA256SUMS and its signature) expected by Terraform registries in the output directory."
+    esilent " "
+    esilent "options:"
+    esilent "-h, --help                                            show help"
+    esilent "-p, --provider-name=PROVIDER_NAME                     [required] specify the provider name. The plugin built will be named like terraform-provider-NAME (all lower case)"
+    esilent "-v, --provider-version=PROVIDER_VERSION               specify the provider version. Default value is the OpenAPI Terraform provider version"
+    esilent "-l, --platforms=PLATFORMS                             specify the space separated list of platforms (OS_ARCH). Default value '${PLATFORMS}'"
+    esilent "-o, --output-dir=OUTPUT_DIR                           specify the directory where the artifacts are generated. Default value './dist/PROVIDER_NAME'"
+    esilent "-g, --gpg-key=GPG_KEY                                 specify the GPG key id or fingerprint used to sign the SHA256SUMS file"
+    esilent "-d, --debug                                           sets the logging level to debug mode. Default logging level is error."
+}
+
+function buildAndArchive() {
+  local XC_OS=$1
+  local XC_ARCH=$2
+
+  local PLATFORM_BUILD_DIR="${TMP_BUILD_DIR}/${XC_OS}_${XC_ARCH}"
+  local BINARY_NAME="${TF_PROVIDER_PLUGIN_NAME}_v${PROVIDER_VERSION}"
+  if [ "${XC_OS}" == "windows" ]; then
+    BINARY_NAME="${BINARY_NAME}.exe"
+  fi
+  local ZIP_FILE_NAME="${TF_PROVIDER_PLUGIN_NAME}_${PROVIDER_VERSION}_${XC_OS}_${XC_ARCH}.zip"
+
+  einfo "Building ${BINARY_NAME} for ${XC_OS}_${XC_ARCH}"
+  mkdir -p ${PLATFORM_BUILD_DIR}
+  if ! (cd ${_DIR}/.. && CGO_ENABLED=0 GOOS=${XC_OS} GOARCH=${XC_ARCH} go build -tags=netgo -ldflags="${LDFLAGS}" -o "${PLATFORM_BUILD_DIR}/${BINARY_NAME}")
+  then
+    eerror "Failed to build ${BINARY_NAME} for ${XC_OS}_${XC_ARCH}"
+    cleanup
+    exit 1
+  fi
+
+  edebug "Archiving ${BINARY_NAME} into ${OUTPUT_DIR}/${ZIP_FILE_NAME}"
+  if ! (cd ${PLATFORM_BUILD_DIR} && zip -q "${OUTPUT_DIR}/${ZIP_FILE_NAME}" "${BINARY_NAME}")
+  then
+    eerror "Failed to archive ${BINARY_NAME} into ${OUTPUT_DIR}/${ZIP_FILE_NAME}"
+    cleanup
+    exit 1
+  fi
+}
+
+function generateChecksums() {
+  local SHASUMS_FILE_NAME=$1
+  if hash sha256sum 2>/dev/null; then
+    SHASUM_CMD="sha256sum"
+  elif hash shasum 2>/dev/null; then
+    SHASUM_CMD="shasum -a 256"
+  else
+    eerror "sha256sum or shasum not available on this system, please install one of them."
+    cleanup
+    exit 1
+  fi
+  edebug "Generating ${SHASUMS_FILE_NAME}"
+  if ! (cd ${OUTPUT_DIR} && ${SHASUM_CMD} ${TF_PROVIDER_PLUGIN_NAME}_${PROVIDER_VERSION}_*.zip ${TF_PROVIDER_PLUGIN_NAME}_${PROVIDER_VERSION}_manifest.json > ${SHASUMS_FILE_NAME})
+  then
+    eerror "Failed to generate ${SHASUMS_FILE_NAME}"
+    cleanup
+    exit 1
+  fi
+}
+
+function signChecksums() {
+  local SHASUMS_FILE_NAME=$1
+  if ! hash gpg 2>/dev/null; then
+    eerror "gpg not available on this system, please install it."
+    cleanup
+    exit 1
+  fi
+  edebug "Signing ${SHASUMS_FILE_NAME} with GPG key ${GPG_KEY}"
+  if ! (cd ${OUTPUT_DIR} && gpg --batch --yes --local-user "${GPG_KEY}" --detach-sign --output ${SHASUMS_FILE_NAME}.sig ${SHASUMS_FILE_NAME})
+  then
+    eerror "Failed to sign ${SHASUMS_FILE_NAME} with GPG key ${GPG_KEY}"
+    cleanup
+    exit 1
+  fi
+}
+
+# process input arguments
+while [ $# -gt 0 ]; do
+    case $1 in
+        --help | -h)
+            usage
+            exit 0
+            ;;
+        --provider-name | -p)
+            shift
+            PROVIDER_NAME=$1
+            ;;
+        --provider-version | -v)
+            shift
+            PROVIDER_VERSION=$1
+            ;;
+        --platforms | -l)
+            shift
+            PLATFORMS=$1
+            ;;
+        --output-dir | -o)
+            shift
+            OUTPUT_DIR=$1
+            ;;
+        --gpg-key | -g)
+            shift
+            GPG_KEY=$1
+            ;;
+        --debug | -d)
+            verbosity=$dbg_lvl
+            ;;
+        *)
+            eerror "Unknown option: $1"
+            usage
+            exit 2
+    esac
+    shift
+done
+
+if [ "$PROVIDER_NAME" == "" ]; then
+  eerror "required argument --provider-name missing value. Check the usage for more info."
+  usage
+  exit 1
+fi
+
+if ! [[ "$PROVIDER_NAME" =~ ^[a-z0-9]+$ ]]; then
+  eerror "provider name '${PROVIDER_NAME}' not valid, the provider name must only contain lower case letters and numbers."
+  exit 1
+fi
+
+# verifying zip is available in the system
+if ! hash zip 2>/dev/null; then
+  eerror "zip not available on this system, please install it."
+  exit 1
+fi
+
+# packaging variables
+OPENAPI_PROVIDER_VERSION=`cat ${_DIR}/../version`
+PROVIDER_VERSION=${PROVIDER_VERSION:-$OPENAPI_PROVIDER_VERSION}
+OUTPUT_DIR=${OUTPUT_DIR:-"./dist/${PROVIDER_NAME}"}
+TF_PROVIDER_PLUGIN_NAME="${TF_PROVIDER_BASE_NAME}${PROVIDER_NAME}"
+COMMIT=$(cd ${_DIR}/.. && git rev-parse --verify --short HEAD 2>/dev/null)
+DATE=$(date +'%FT%TZ%z')
+LDFLAGS="-s -w -extldflags \"-static\" -X \"${REPO}/openapi/version.Version=${OPENAPI_PROVIDER_VERSION}\" -X \"${REPO}/openapi/version.Commit=${COMMIT}\" -X \"${REPO}/openapi/version.Date=${DATE}\""
+
+mkdir -p ${OUTPUT_DIR}
+OUTPUT_DIR="$(cd ${OUTPUT_DIR} && pwd)"
+TMP_BUILD_DIR=$(mktemp -d)
+if [ "$?" != "0" ]; then
+  eerror "Failed to create temporary directory."
+  exit 1
+fi
+
+einfo "Packaging ${TF_PROVIDER_PLUGIN_NAME} v${PROVIDER_VERSION} (OpenAPI Terraform provider v${OPENAPI_PROVIDER_VERSION}) into ${OUTPUT_DIR}"
+for PLATFORM in ${PLATFORMS}; do
+  XC_OS=$(echo ${PLATFORM} | cut -f1 -d_)
+  XC_ARCH=$(echo ${PLATFORM} | cut -f2 -d_)
+  if [ -z ${XC_OS} ] || [ -z ${XC_ARCH} ]; then
+    eerror "Platform '${PLATFORM}' not valid, expected format is OS_ARCH (e,g: linux_amd64)"
+    cleanup
+    exit 1
+  fi
+  buildAndArchive ${XC_OS} ${XC_ARCH}
+done
+
+edebug "Generating ${TF_PROVIDER_PLUGIN_NAME}_${PROVIDER_VERSION}_manifest.json"
+cp ${REGISTRY_MANIFEST} ${OUTPUT_DIR}/${TF_PROVIDER_PLUGIN_NAME}_${PROVIDER_VERSION}_manifest.json
+
+SHASUMS_FILE_NAME="${TF_PROVIDER_PLUGIN_NAME}_${PROVIDER_VERSION}_SHA256SUMS"
+generateChecksums ${SHASUMS_FILE_NAME}
+if [ "$GPG_KEY" != "" ]; then
+  signChecksums ${SHASUMS_FILE_NAME}
+else
+  einfo "No GPG key provided, skipping ${SHASUMS_FILE_NAME} signature. Note that Terraform registries require the SHA256SUMS file to be signed."
+fi
+
+cleanup
+echo -e "\033[1;32mTerraform provider successfully packaged!${colrst}"
+esilent " |--> Artifacts Path: ${OUTPUT_DIR}"
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi"
+)
+
+// smokeTestCommand defines the subcommand used to run a create/read/delete smoke test for each of the provider resources
+// against the API configured for the provider (e,g: a mock server or a sandbox):
+// terraform-provider-openapi smoke-test --provider-name foo --provider-config ./foo.json
+const smokeTestCommand = "smoke-test"
+
+// runSmokeTestCommand runs the smoke tests of the provider resources and reports the results. The OpenAPI document is
+// discovered the same way the provider does at runtime (plugin configuration file or OTF_VAR_{name}_SWAGGER_URL)
+func runSmokeTestCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(smokeTestCommand, flag.ContinueOnError)
+	providerName := flags.String("provider-name", "", "[required] name of the provider the smoke tests are run for")
+	providerConfigFile := flags.String("provider-config", "", "path to a JSON file containing the provider configuration (e,g: the API keys)")
+	resources := flags.String("resources", "", "comma separated list of the resources to test (e,g: foo_cdns_v1). If not specified, all the resources are tested")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if !providerNameRegex.MatchString(*providerName) {
+		return fmt.Errorf("provider name '%s' not valid, the provider name must only contain lower case letters and numbers", *providerName)
+	}
+	providerConfiguration, err := readSmokeTestProviderConfiguration(*providerConfigFile)
+	if err != nil {
+		return err
+	}
+	var resourceNames []string
+	if *resources != "" {
+		resourceNames = strings.Split(*resources, ",")
+	}
+	p := openapi.ProviderOpenAPI{ProviderName: *providerName}
+	results, err := p.RunSmokeTests(context.Background(), providerConfiguration, resourceNames)
+	if err != nil {
+		return err
+	}
+	return reportSmokeTestResults(out, results)
+}
+
+// readSmokeTestProviderConfiguration returns the provider configuration stored in the JSON file passed in; an empty
+// configuration is returned if no file is specified
+func readSmokeTestProviderConfiguration(providerConfigFile string) (map[string]interface{}, error) {
+	providerConfiguration := map[string]interface{}{}
+	if providerConfigFile == "" {
+		return providerConfiguration, nil
+	}
+	content, err := ioutil.ReadFile(providerConfigFile) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the provider configuration file '%s': %s", providerConfigFile, err)
+	}
+	if err := json.Unmarshal(content, &providerConfiguration); err != nil {
+		return nil, fmt.Errorf("failed to parse the provider configuration file '%s': %s", providerConfigFile, err)
+	}
+	return providerConfiguration, nil
+}
+
+// reportSmokeTestResults writes the result of each resource smoke test and returns an error if any of the resources failed
+// or has insufficient examples, so pipelines running the smoke tests fail accordingly
+func reportSmokeTestResults(out io.Writer, results []openapi.SmokeTestResult) error {
+	var failed, insufficientExamples int
+	for _, result := range results {
+		line := fmt.Sprintf("%s: %s", result.ResourceName, result.Status)
+		if result.Reason != "" {
+			line = fmt.Sprintf("%s (%s)", line, result.Reason)
+		}
+		fmt.Fprintln(out, line)
+		switch result.Status {
+		case openapi.SmokeTestFailed:
+			failed++
+		case openapi.SmokeTestInsufficientExamples:
+			insufficientExamples++
+		}
+	}
+	fmt.Fprintf(out, "%d resources tested: %d failed, %d with insufficient examples\n", len(results), failed, insufficientExamples)
+	if failed > 0 || insufficientExamples > 0 {
+		return fmt.Errorf("smoke tests did not pass: %d failed, %d with insufficient examples", failed, insufficientExamples)
+	}
+	return nil
+}
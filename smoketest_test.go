@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReadSmokeTestProviderConfiguration(t *testing.T) {
+	Convey("Given a JSON file containing the provider configuration", t, func() {
+		dir, err := ioutil.TempDir("", "smoketest")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		providerConfigFile := filepath.Join(dir, "foo.json")
+		So(ioutil.WriteFile(providerConfigFile, []byte(`{"apikey_auth":"some-api-key"}`), 0600), ShouldBeNil)
+		Convey("When readSmokeTestProviderConfiguration method is called", func() {
+			providerConfiguration, err := readSmokeTestProviderConfiguration(providerConfigFile)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the provider configuration should contain the values in the file", func() {
+				So(providerConfiguration, ShouldResemble, map[string]interface{}{"apikey_auth": "some-api-key"})
+			})
+		})
+	})
+	Convey("Given a provider configuration file that is not valid JSON", t, func() {
+		dir, err := ioutil.TempDir("", "smoketest")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		providerConfigFile := filepath.Join(dir, "foo.json")
+		So(ioutil.WriteFile(providerConfigFile, []byte(`apikey_auth: some-api-key`), 0600), ShouldBeNil)
+		Convey("When readSmokeTestProviderConfiguration method is called", func() {
+			_, err := readSmokeTestProviderConfiguration(providerConfigFile)
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, "failed to parse the provider configuration file")
+			})
+		})
+	})
+	Convey("Given no provider configuration file", t, func() {
+		Convey("When readSmokeTestProviderConfiguration method is called", func() {
+			providerConfiguration, err := readSmokeTestProviderConfiguration("")
+			Convey("Then the provider configuration returned should be empty", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestReportSmokeTestResults(t *testing.T) {
+	Convey("Given the results of the smoke tests where all the resources passed", t, func() {
+		results := []openapi.SmokeTestResult{{ResourceName: "foo_cdns_v1", Status: openapi.SmokeTestPassed}}
+		Convey("When reportSmokeTestResults method is called", func() {
+			out := &bytes.Buffer{}
+			err := reportSmokeTestResults(out, results)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the report should contain the result of each resource", func() {
+				So(out.String(), ShouldEqual, "foo_cdns_v1: passed\n1 resources tested: 0 failed, 0 with insufficient examples\n")
+			})
+		})
+	})
+	Convey("Given the results of the smoke tests where some resources failed or have insufficient examples", t, func() {
+		results := []openapi.SmokeTestResult{
+			{ResourceName: "foo_cdns_v1", Status: openapi.SmokeTestPassed},
+			{ResourceName: "foo_lbs_v1", Status: openapi.SmokeTestInsufficientExamples, Reason: "missing example for the required properties: name"},
+			{ResourceName: "foo_monitors_v1", Status: openapi.SmokeTestFailed, Reason: "create failed: some error"},
+			{ResourceName: "foo_cdns_v1_firewalls_v1", Status: openapi.SmokeTestSkipped, Reason: "sub-resources are not supported"},
+		}
+		Convey("When reportSmokeTestResults method is called", func() {
+			out := &bytes.Buffer{}
+			err := reportSmokeTestResults(out, results)
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "smoke tests did not pass: 1 failed, 1 with insufficient examples")
+			})
+			Convey("And the report should contain the result of each resource", func() {
+				So(out.String(), ShouldEqual, `foo_cdns_v1: passed
+foo_lbs_v1: insufficient examples (missing example for the required properties: name)
+foo_monitors_v1: failed (create failed: some error)
+foo_cdns_v1_firewalls_v1: skipped (sub-resources are not supported)
+4 resources tested: 1 failed, 1 with insufficient examples
+`)
+			})
+		})
+	})
+}
@@ -0,0 +1,46 @@
+package e2e
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dikhan/terraform-provider-openapi/v3/openapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAcc_ProviderSchemaConcurrentExtraction mimics 'terraform providers schema -json' being requested concurrently
+// against the same provider. The test is meant to be executed with the race detector enabled (make race-test)
+func TestAcc_ProviderSchemaConcurrentExtraction(t *testing.T) {
+	api := initAPI(t, cdnSwaggerYAMLTemplate)
+	p := openapi.ProviderOpenAPI{ProviderName: providerName}
+
+	const concurrency = 20
+	providers := make([]*schema.Provider, concurrency)
+	schemas := make([][]byte, concurrency)
+	errs := make([]error, concurrency)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			provider, err := p.CreateSchemaProviderFromServiceConfiguration(&openapi.ServiceConfigStub{SwaggerURL: api.swaggerURL})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			providers[i] = provider
+			schemas[i], errs[i] = openapi.ExportProviderSchemaJSON(provider, "registry.terraform.io/dikhan/openapi")
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < concurrency; i++ {
+		require.NoError(t, errs[i])
+		assert.Same(t, providers[0], providers[i], "all the concurrent calls are expected to get the same cached provider")
+		assert.JSONEq(t, string(schemas[0]), string(schemas[i]))
+	}
+	assertProviderSchema(t, providers[0])
+}